@@ -0,0 +1,10 @@
+# Look up a branch created for review outside Terraform
+data "netbox_branch" "review" {
+  name = "terraform-review"
+}
+
+# Make all changes of this provider configuration in the branch
+provider "netbox" {
+  alias  = "review"
+  branch = data.netbox_branch.review.schema_id
+}
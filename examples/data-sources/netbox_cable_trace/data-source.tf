@@ -0,0 +1,21 @@
+# Trace from an interface, identified by device and port name.
+data "netbox_cable_trace" "uplink" {
+  termination_type = "dcim.interface"
+  device           = "access-sw01"
+  name             = "ge-0/0/47"
+}
+
+# What is at the far end of this patch-panel run?
+output "uplink_peer" {
+  value = data.netbox_cable_trace.uplink.is_complete ? "${data.netbox_cable_trace.uplink.endpoints[0].device} ${data.netbox_cable_trace.uplink.endpoints[0].name}" : "not connected"
+}
+
+output "uplink_cables" {
+  value = [for hop in data.netbox_cable_trace.uplink.hops : hop.cable_label if hop.cable_id != null]
+}
+
+# Trace outwards from a patch panel front port by ID.
+data "netbox_cable_trace" "panel_port" {
+  termination_type = "dcim.frontport"
+  termination_id   = "123"
+}
@@ -0,0 +1,25 @@
+# Look up the choices of a select custom field
+data "netbox_custom_field_choices" "country" {
+  name = "country"
+}
+
+variable "country" {
+  type = string
+}
+
+# Reject values that Netbox would not accept before apply
+resource "terraform_data" "country" {
+  input = var.country
+
+  lifecycle {
+    precondition {
+      condition     = contains(data.netbox_custom_field_choices.country.values, var.country)
+      error_message = "country must be one of the choices of the country custom field."
+    }
+  }
+}
+
+output "country_choices" {
+  value       = data.netbox_custom_field_choices.country.choices
+  description = "Values and labels of the choices of the country custom field"
+}
@@ -4,7 +4,8 @@
 # - Multiple `filter` blocks are ANDed together.
 # - Multiple values inside one filter block are ORed together.
 # - `custom_field` and `custom_field_value` are applied client-side against returned `custom_fields`.
-# - The datasource returns `ids`, `names`, and `devices` (list of `{id,name}` objects).
+# - Any filter supported by the NetBox API can be used.
+# - The datasource returns `ids`, `names`, and `devices`, whose objects have the same attributes as the `netbox_device` data source.
 
 # Example: Exact match by name
 data "netbox_devices" "by_name" {
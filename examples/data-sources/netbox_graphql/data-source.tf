@@ -0,0 +1,45 @@
+# Example: Devices of a site with their interfaces, IP addresses and cables in one request
+data "netbox_graphql" "topology" {
+  query = <<-EOT
+    query ($site: [String!]) {
+      device_list(filters: {site: $site}) {
+        id
+        name
+        interfaces {
+          name
+          cable { id }
+          ip_addresses { address }
+        }
+      }
+    }
+  EOT
+
+  variables = {
+    site = ["dc1"]
+  }
+}
+
+output "device_interfaces" {
+  value = {
+    for d in data.netbox_graphql.topology.data.device_list :
+    d.name => [for i in d.interfaces : i.name]
+  }
+}
+
+# Example: Automatic pagination using $offset and $limit
+data "netbox_graphql" "all_prefixes" {
+  query = <<-EOT
+    query ($offset: Int, $limit: Int) {
+      prefix_list(pagination: {offset: $offset, limit: $limit}) {
+        prefix
+        status
+      }
+    }
+  EOT
+
+  page_size = 500
+}
+
+output "prefix_count" {
+  value = length(jsondecode(data.netbox_graphql.all_prefixes.data_json).prefix_list)
+}
@@ -32,6 +32,14 @@ output "interface_enabled" {
   value = data.netbox_interface.by_id.enabled
 }
 
+output "interface_poe_mode" {
+  value = data.netbox_interface.by_id.poe_mode
+}
+
+output "interface_wireless_lans" {
+  value = data.netbox_interface.by_id.wireless_lans
+}
+
 # Access all custom fields
 output "interface_custom_fields" {
   value       = data.netbox_interface.by_id.custom_fields
@@ -3,7 +3,8 @@
 # Notes:
 # - Multiple `filter` blocks are ANDed together.
 # - Multiple values inside one filter block are ORed together.
-# - The datasource returns `ids`, `names`, and `interfaces` (list of `{id,name}` objects).
+# - Any filter supported by the NetBox API can be used.
+# - The datasource returns `ids`, `names`, and `interfaces`, whose objects have the same attributes as the `netbox_interface` data source.
 
 resource "netbox_site" "example" {
   name = "example"
@@ -62,3 +63,16 @@ output "interface_names" {
 output "interface_objects" {
   value = data.netbox_interfaces.by_device_and_name.interfaces
 }
+
+# All PoE-supplying ports on the device
+data "netbox_interfaces" "poe_ports" {
+  filter {
+    name   = "device_id"
+    values = [netbox_device.example.id]
+  }
+
+  filter {
+    name   = "poe_mode"
+    values = ["pse"]
+  }
+}
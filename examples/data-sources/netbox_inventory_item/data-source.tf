@@ -9,6 +9,16 @@ data "netbox_inventory_item" "by_name_device" {
   device_id = "5"
 }
 
+# Look up the inventory item installed in an interface
+data "netbox_inventory_item" "by_component" {
+  component_type = "dcim.interface"
+  component_id   = 42
+}
+
+output "optic_status" {
+  value = data.netbox_inventory_item.by_component.status
+}
+
 # Use inventory item data in other resources
 output "item_id" {
   value = data.netbox_inventory_item.by_id.id
@@ -3,7 +3,8 @@
 # Notes:
 # - Multiple `filter` blocks are ANDed together.
 # - Multiple values inside one filter block are ORed together.
-# - The datasource returns `ids`, `addresses`, and `ip_addresses` (list of `{id,address}` objects).
+# - Any filter supported by the NetBox API can be used.
+# - The datasource returns `ids`, `addresses`, and `ip_addresses`, whose objects have the same attributes as the `netbox_ip_address` data source.
 
 resource "netbox_ip_address" "example" {
   address = "192.0.2.10/32"
@@ -0,0 +1,37 @@
+resource "netbox_site" "example" {
+  name = "Example Site"
+  slug = "example-site"
+}
+
+# Changes made by this provider during the current run. Depend on the managed
+# resources so that the changes are read after they are applied.
+data "netbox_object_changes" "run" {
+  current_run = true
+
+  depends_on = [netbox_site.example]
+}
+
+# Fail the run when Terraform changed anything but the example site
+check "only_example_site_changed" {
+  assert {
+    condition = alltrue([
+      for change in data.netbox_object_changes.run.changes :
+      change.object_type == "dcim.site" && change.object_id == netbox_site.example.id
+    ])
+    error_message = "Terraform changed objects other than the example site."
+  }
+}
+
+# History of a single object
+data "netbox_object_changes" "site_history" {
+  object_type = "dcim.site"
+  object_id   = netbox_site.example.id
+  time_after  = "2024-01-01T00:00:00Z"
+}
+
+output "site_changes" {
+  value = [
+    for change in data.netbox_object_changes.site_history.changes :
+    "${change.time} ${change.user} ${change.action}: ${join(", ", change.changed_attributes)}"
+  ]
+}
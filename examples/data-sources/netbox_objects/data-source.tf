@@ -0,0 +1,40 @@
+# Examples for the generic objects data source.
+#
+# Notes:
+# - `path` is any NetBox REST list endpoint relative to /api/, including plugin endpoints.
+# - Filter names are forwarded to the endpoint unchanged.
+# - `results` mirrors the JSON returned by NetBox; `results_json` holds the raw JSON array.
+
+# Example: BGP sessions from the netbox-bgp plugin
+data "netbox_objects" "bgp_sessions" {
+  path = "plugins/bgp/session"
+
+  filter {
+    name   = "status"
+    values = ["active"]
+  }
+}
+
+output "bgp_session_names" {
+  value = [for s in data.netbox_objects.bgp_sessions.results : s.name]
+}
+
+# Example: DNS zones from the netbox-dns plugin, limited to a few fields
+data "netbox_objects" "dns_zones" {
+  path   = "plugins/netbox-dns/zones"
+  fields = ["id", "name", "status"]
+
+  filter {
+    name   = "name__iew"
+    values = [".example.com"]
+  }
+}
+
+output "dns_zone_ids" {
+  value = data.netbox_objects.dns_zones.ids
+}
+
+# Example: Decode the raw JSON instead of using the dynamic results
+locals {
+  zones = jsondecode(data.netbox_objects.dns_zones.results_json)
+}
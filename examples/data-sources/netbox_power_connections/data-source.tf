@@ -0,0 +1,20 @@
+# Outlet to power port connections of a PDU, e.g. to document its outlets.
+data "netbox_power_connections" "pdu" {
+  device_id = "42"
+}
+
+output "pdu_outlets" {
+  value = {
+    for c in data.netbox_power_connections.pdu.connections :
+    c.outlet => "${c.device} ${c.power_port} (${coalesce(c.allocated_draw, 0)} W, leg ${coalesce(c.feed_leg, "-")})"
+  }
+}
+
+# All connections in or out of a rack.
+data "netbox_power_connections" "rack" {
+  rack_id = "12"
+}
+
+output "rack_allocated_draw" {
+  value = data.netbox_power_connections.rack.allocated_draw
+}
@@ -0,0 +1,32 @@
+# Load on every feed supplying rack 12.
+data "netbox_power_feed_utilization" "r1" {
+  rack_id = "12"
+}
+
+locals {
+  server_allocated_draw = 450
+}
+
+resource "netbox_power_port" "psu1" {
+  device         = netbox_device.server.id
+  name           = "PSU1"
+  allocated_draw = local.server_allocated_draw
+
+  # Fail the plan if the new server would push a feed, or any leg of a
+  # three-phase feed, over its available power.
+  lifecycle {
+    precondition {
+      condition = alltrue(flatten([
+        for feed in data.netbox_power_feed_utilization.r1.feeds : concat(
+          [feed.allocated_draw + local.server_allocated_draw <= feed.available_power],
+          [for leg in feed.legs : leg.allocated_draw + local.server_allocated_draw <= leg.available_power],
+        )
+      ]))
+      error_message = "Rack 12 does not have enough power left on its feeds for this server."
+    }
+  }
+}
+
+output "rack_utilization" {
+  value = data.netbox_power_feed_utilization.r1.utilization
+}
@@ -3,7 +3,8 @@
 # Notes:
 # - Multiple `filter` blocks are ANDed together.
 # - Multiple values inside one filter block are ORed together.
-# - The datasource returns `ids`, `cidrs`, and `prefixes` (list of `{id,prefix}` objects).
+# - Any filter supported by the NetBox API can be used.
+# - The datasource returns `ids`, `cidrs`, and `prefixes`, whose objects have the same attributes as the `netbox_prefix` data source.
 
 resource "netbox_prefix" "example" {
   prefix = "10.10.0.0/24"
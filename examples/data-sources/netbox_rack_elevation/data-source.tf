@@ -0,0 +1,11 @@
+data "netbox_rack_elevation" "r1" {
+  rack_id = "12"
+}
+
+output "free_front_units" {
+  value = [for u in data.netbox_rack_elevation.r1.front : u.name if u.status == "free"]
+}
+
+output "devices_in_rack" {
+  value = distinct([for u in data.netbox_rack_elevation.r1.front : u.device if u.status == "device"])
+}
@@ -0,0 +1,26 @@
+data "netbox_device_type" "server" {
+  slug = "poweredge-r650"
+}
+
+# Find the lowest free position for a 1U full-depth server.
+data "netbox_rack_free_units" "r1" {
+  rack_id       = "12"
+  u_height      = data.netbox_device_type.server.u_height
+  face          = "front"
+  is_full_depth = true
+}
+
+resource "netbox_device" "server" {
+  name        = "server01"
+  device_type = data.netbox_device_type.server.id
+  role        = "server"
+  site        = "dc1"
+  rack        = "12"
+  face        = "front"
+  position    = data.netbox_rack_free_units.r1.position
+
+  # Keep the device where it was first racked; the free position moves once it is placed.
+  lifecycle {
+    ignore_changes = [position]
+  }
+}
@@ -0,0 +1,45 @@
+# Examples for the plural/query site data source.
+#
+# Notes:
+# - Filter names are forwarded to the NetBox API unchanged, so any filter supported
+#   by /api/dcim/sites/ can be used (e.g. `region`, `tenant_id`, `status`, `cf_<name>`).
+# - Multiple `filter` blocks are ANDed together.
+# - Multiple values inside one filter block are ORed together.
+# - Each element of `sites` has the same attributes as the `netbox_site` data source.
+
+# Example: All active sites in a region (by region slug)
+data "netbox_sites" "active_in_region" {
+  filter {
+    name   = "region"
+    values = ["emea"]
+  }
+
+  filter {
+    name   = "status"
+    values = ["active"]
+  }
+}
+
+output "site_ids" {
+  value = data.netbox_sites.active_in_region.ids
+}
+
+output "site_names_by_facility" {
+  value = { for s in data.netbox_sites.active_in_region.sites : s.name => s.facility }
+}
+
+# Example: Sites owned by either of two tenants (by tenant ID)
+data "netbox_sites" "by_tenant" {
+  filter {
+    name   = "tenant_id"
+    values = ["12", "15"]
+  }
+}
+
+# Example: Native NetBox custom field filter
+data "netbox_sites" "by_cost_center" {
+  filter {
+    name   = "cf_cost_center"
+    values = ["cc-1001"]
+  }
+}
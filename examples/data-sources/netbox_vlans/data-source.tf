@@ -0,0 +1,24 @@
+# Example: All VLANs in a VLAN group, with full VLAN attributes
+data "netbox_vlans" "campus" {
+  filter {
+    name   = "group"
+    values = ["campus-access"]
+  }
+}
+
+output "vlan_ids_by_name" {
+  value = { for v in data.netbox_vlans.campus.vlans : v.name => v.vid }
+}
+
+# Example: VLANs in a VID range using NetBox lookup expressions
+data "netbox_vlans" "range" {
+  filter {
+    name   = "vid__gte"
+    values = ["100"]
+  }
+
+  filter {
+    name   = "vid__lte"
+    values = ["199"]
+  }
+}
@@ -10,4 +10,28 @@ provider "netbox" {
   server_url = "https://netbox.example.com"
   api_token  = "your-api-token-here"
   # insecure = true  # Only for testing with self-signed certificates
+
+  # Added to every object managed by this provider configuration.
+  # default_tags = ["managed-by-terraform"]
+  # default_custom_fields = {
+  #   cost_center = "1234"
+  # }
+
+  # Managed outside Terraform: never read into state nor removed on update.
+  # ignore_tags          = ["discovered-*"]
+  # ignore_custom_fields = ["last_seen"]
+
+  # Post a journal entry to every object created or updated by Terraform.
+  # journal_changes  = true
+  # journal_kind     = "info"
+  # journal_template = "Changed by Terraform run {{.RunID}}: {{join .Changes \", \"}}"
+
+  # Sent in the X-Request-ID header of every request; random per run by default.
+  # request_id = "pipeline-1234"
+
+  # Make all changes in a branch of the netbox-branching plugin (schema ID).
+  # branch = "td5smq0f"
+
+  # Only read from NetBox, e.g. for plan-only audit pipelines.
+  # read_only = true
 }
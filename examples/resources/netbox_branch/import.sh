@@ -0,0 +1,2 @@
+# Import an existing branch
+terraform import netbox_branch.example 123
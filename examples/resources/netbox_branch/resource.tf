@@ -0,0 +1,25 @@
+variable "merge" {
+  type        = bool
+  default     = false
+  description = "Set once the changes in the branch have been reviewed"
+}
+
+# Stage changes in a branch for review
+resource "netbox_branch" "review" {
+  name        = "terraform-review"
+  description = "Changes staged by Terraform"
+
+  # Sync the branch with main whenever the review is restarted
+  sync_triggers = {
+    review = "1"
+  }
+
+  merged = var.merge
+}
+
+# Created in the branch instead of main
+resource "netbox_site" "example" {
+  name   = "Example Site"
+  slug   = "example-site"
+  branch = netbox_branch.review.schema_id
+}
@@ -129,6 +129,37 @@ import {
   }
 }
 
+# Example: Terminations referenced by device and port name instead of ID.
+# Names are resolved at plan time, and the plan fails if a port is already
+# connected to another cable.
+resource "netbox_cable" "by_name" {
+  a_terminations = [{
+    device    = "switch01"
+    interface = "GigabitEthernet1/0/1"
+  }]
+  b_terminations = [{
+    device     = "patch-panel-01"
+    front_port = "Port 1"
+  }]
+
+  type   = "cat6"
+  status = "connected"
+}
+
+# Example: Cable to one side of a circuit
+resource "netbox_cable" "circuit" {
+  a_terminations = [{
+    device    = "router01"
+    interface = "TenGigabitEthernet0/0/0"
+  }]
+  b_terminations = [{
+    circuit   = "CID-12345"
+    term_side = "A"
+  }]
+
+  type = "smf"
+}
+
 # Example: Planned cable (not yet installed)
 resource "netbox_cable" "planned" {
   a_terminations = [{
@@ -0,0 +1,2 @@
+# All interfaces of a device can be imported by the device ID
+terraform import netbox_device_interfaces.example 123
@@ -0,0 +1,43 @@
+# Manage the access ports of a switch alongside interfaces managed elsewhere.
+# Ports created from the device type's templates are adopted, and are left in
+# place when the resource is destroyed.
+resource "netbox_device_interfaces" "example" {
+  device = netbox_device.example.id
+
+  interfaces = {
+    "ge-0/0/[0-47]" = {
+      type          = "1000base-t"
+      mode          = "access"
+      untagged_vlan = netbox_vlan.access.id
+      poe_mode      = "pse"
+      poe_type      = "type2-ieee802.3at"
+    }
+    "ae0" = {
+      type        = "lag"
+      mtu         = 9216
+      description = "Uplink"
+      tags        = ["uplink"]
+    }
+    "xe-0/1/[0-3]" = {
+      type = "10gbase-x-sfpp"
+      mtu  = 9216
+      lag  = "ae0"
+    }
+  }
+}
+
+# Own every interface of a device; unlisted interfaces are deleted
+resource "netbox_device_interfaces" "authoritative" {
+  device        = netbox_device.example_router.id
+  authoritative = true
+
+  interfaces = {
+    "Ethernet[1-8]" = {
+      type = "10gbase-x-sfpp"
+    }
+    "Management1" = {
+      type      = "1000base-t"
+      mgmt_only = true
+    }
+  }
+}
@@ -0,0 +1,3 @@
+# Device types can be imported by ID. The definition is not known after import,
+# so the next apply reconciles the templates against definition_yaml.
+terraform import netbox_device_type_from_library.ex4300 123
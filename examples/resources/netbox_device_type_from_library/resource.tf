@@ -0,0 +1,13 @@
+# Manage a device type and all of its component templates from a
+# netbox-community/devicetype-library definition.
+resource "netbox_device_type_from_library" "ex4300" {
+  definition_yaml = file("${path.module}/device-types/Juniper/EX4300-48T.yaml")
+}
+
+# Devices reference the resulting device type by ID.
+resource "netbox_device" "switch" {
+  name        = "access-sw01"
+  device_type = netbox_device_type_from_library.ex4300.id
+  role        = "access-switch"
+  site        = "dc1"
+}
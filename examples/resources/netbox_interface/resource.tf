@@ -118,3 +118,24 @@ resource "netbox_interface" "mgmt" {
   type      = "1000base-t"
   mgmt_only = true
 }
+
+# PoE access port in a VRF
+resource "netbox_interface" "poe" {
+  device   = netbox_device.example.id
+  name     = "ge-0/0/10"
+  type     = "1000base-t"
+  poe_mode = "pse"
+  poe_type = "type2-ieee802.3at"
+  vrf      = "campus"
+}
+
+# Wireless access point radio
+resource "netbox_interface" "radio" {
+  device        = netbox_device.example.id
+  name          = "wlan0"
+  type          = "ieee802.11ax"
+  rf_role       = "ap"
+  rf_channel    = "5g-36-5180-20"
+  tx_power      = 20
+  wireless_lans = [netbox_wireless_lan.corp.id]
+}
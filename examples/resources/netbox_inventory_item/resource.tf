@@ -62,6 +62,15 @@ resource "netbox_inventory_item" "test" {
   ]
 }
 
+# Example: An optic installed in a switch port, referenced by interface name
+resource "netbox_inventory_item" "optic" {
+  name           = "SFP-10G-LR"
+  device         = netbox_device.test.id
+  status         = "active"
+  component_type = "dcim.interface"
+  component_name = "xe-0/0/1"
+}
+
 # Optional: seed owned custom fields during import
 import {
   to = netbox_inventory_item.test
@@ -28,6 +28,20 @@ resource "netbox_inventory_item_template" "test" {
   ]
 }
 
+resource "netbox_interface_template" "uplink" {
+  name        = "xe-0/0/1"
+  device_type = netbox_device_type.test.id
+  type        = "10gbase-x-sfpp"
+}
+
+# Example: Optic template installed in an interface template, referenced by name
+resource "netbox_inventory_item_template" "optic" {
+  name           = "SFP-10G-LR"
+  device_type    = netbox_device_type.test.id
+  component_type = "dcim.interfacetemplate"
+  component_name = netbox_interface_template.uplink.name
+}
+
 # Optional: seed owned custom fields during import
 import {
   to = netbox_inventory_item_template.test
@@ -45,6 +45,24 @@ resource "netbox_module" "test" {
   module_bay  = netbox_module_bay.test.id
   module_type = netbox_module_type.test.id
   status      = "active"
+
+  # Assign interfaces that already exist on the device with the names from
+  # the module type's templates to the module instead of failing the install.
+  # Both flags only apply when the module is installed.
+  replicate_components = true
+  adopt_components     = true
+}
+
+# Cable the first interface instantiated from the module type templates.
+resource "netbox_cable" "uplink" {
+  a_terminations = [{
+    object_type = netbox_module.test.components[0].object_type
+    object_id   = netbox_module.test.components[0].id
+  }]
+  b_terminations = [{
+    object_type = "dcim.interface"
+    object_id   = 2
+  }]
 }
 
 import {
@@ -0,0 +1,3 @@
+# Module types can be imported by ID. The definition is not known after import,
+# so the next apply reconciles the templates against definition_yaml.
+terraform import netbox_module_type_from_library.uplink 123
@@ -0,0 +1,5 @@
+# Manage a module type and its component templates from a
+# netbox-community/devicetype-library definition.
+resource "netbox_module_type_from_library" "uplink" {
+  definition_yaml = file("${path.module}/module-types/Juniper/EX-UM-4X4SFP.yaml")
+}
@@ -0,0 +1,2 @@
+# Objects can be imported by API path and ID
+terraform import netbox_object.zone plugins/netbox-dns/zones/12
@@ -0,0 +1,30 @@
+# Manage a netbox-dns zone, which has no typed resource.
+resource "netbox_object" "zone" {
+  path = "plugins/netbox-dns/zones"
+
+  body = {
+    name        = "example.com"
+    status      = "active"
+    view        = 1
+    default_ttl = 3600
+  }
+}
+
+# Manage a netbox-bgp session using a JSON string body.
+resource "netbox_object" "bgp_session" {
+  path = "plugins/bgp/session"
+
+  body = jsonencode({
+    name           = "edge-01 to transit"
+    device         = 10
+    local_as       = 3
+    remote_as      = 4
+    local_address  = 101
+    remote_address = 102
+    status         = "active"
+  })
+}
+
+output "zone_serial" {
+  value = jsondecode(netbox_object.zone.object_json).soa_serial
+}
@@ -38,6 +38,23 @@ resource "netbox_site" "example" {
   ]
 }
 
+# Typed custom fields: values use native types and are checked against the
+# custom field definitions in NetBox. Setting a field to null clears it.
+resource "netbox_site" "typed" {
+  name   = "Typed Site"
+  slug   = "typed-site"
+  status = "active"
+
+  custom_fields_map = {
+    power_capacity_kw = 500
+    pue               = 1.35
+    monitored         = true
+    roles             = ["edge, core", "access"]
+    operator          = "acme-corp" # object field, by tenant slug
+    decommission_date = null
+  }
+}
+
 # Optional: seed owned custom fields during import
 import {
   to = netbox_site.example
@@ -39,3 +39,29 @@ import {
     ]
   }
 }
+
+# Switch stack: the master joins the virtual chassis through `master`, the
+# other members through their own `virtual_chassis` attribute.
+resource "netbox_device" "stack_master" {
+  name        = "access-sw01"
+  device_type = "ex4300-48t"
+  role        = "access-switch"
+  site        = "dc1"
+  vc_position = 1
+  vc_priority = 255
+}
+
+resource "netbox_virtual_chassis" "stack" {
+  name   = "access-stack01"
+  master = netbox_device.stack_master.id
+}
+
+resource "netbox_device" "stack_member" {
+  name            = "access-sw02"
+  device_type     = "ex4300-48t"
+  role            = "access-switch"
+  site            = "dc1"
+  virtual_chassis = netbox_virtual_chassis.stack.id
+  vc_position     = 2
+  vc_priority     = 128
+}
@@ -0,0 +1,133 @@
+package datasources
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/bab3l/go-netbox"
+	nbschema "github.com/bab3l/terraform-provider-netbox/internal/schema"
+	"github.com/bab3l/terraform-provider-netbox/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource              = &BranchDataSource{}
+	_ datasource.DataSourceWithConfigure = &BranchDataSource{}
+)
+
+func NewBranchDataSource() datasource.DataSource {
+	return &BranchDataSource{}
+}
+
+// BranchDataSource reads a branch of the netbox-branching plugin.
+type BranchDataSource struct {
+	client *netbox.APIClient
+}
+
+// BranchDataSourceModel describes the branch data source data model.
+type BranchDataSourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+	Comments    types.String `tfsdk:"comments"`
+	SchemaID    types.String `tfsdk:"schema_id"`
+	Status      types.String `tfsdk:"status"`
+	LastSync    types.String `tfsdk:"last_sync"`
+	MergedTime  types.String `tfsdk:"merged_time"`
+}
+
+func (d *BranchDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_branch"
+}
+
+func (d *BranchDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Use this data source to get information about a branch of the netbox-branching plugin, e.g. its `schema_id` to make changes in it.",
+		Attributes: map[string]schema.Attribute{
+			"id":          nbschema.DSIDAttribute("branch"),
+			"name":        nbschema.DSNameAttribute("branch"),
+			"description": nbschema.DSComputedStringAttribute("Description of the branch."),
+			"comments":    nbschema.DSComputedStringAttribute("Comments of the branch."),
+			"schema_id":   nbschema.DSComputedStringAttribute("Schema ID of the branch, which the provider's and the resources' `branch` attributes refer to."),
+			"status":      nbschema.DSComputedStringAttribute("Status of the branch, e.g. `ready` or `merged`."),
+			"last_sync":   nbschema.DSComputedStringAttribute("Time the branch was last synced with main, in RFC 3339 format."),
+			"merged_time": nbschema.DSComputedStringAttribute("Time the branch was merged, in RFC 3339 format."),
+		},
+	}
+}
+
+func (d *BranchDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*netbox.APIClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *netbox.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.client = client
+}
+
+func (d *BranchDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data BranchDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var branch *utils.Branch
+	switch {
+	case utils.IsSet(data.ID) && data.ID.ValueString() != "":
+		id, err := utils.ParseID64(data.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid Branch ID", "Branch ID must be a number.")
+			return
+		}
+		found, httpResp, err := utils.GetBranch(ctx, d.client, id)
+		defer utils.CloseResponseBody(httpResp)
+		if err != nil {
+			resp.Diagnostics.AddError("Error reading branch", utils.FormatAPIError(fmt.Sprintf("read branch ID %d", id), err, httpResp))
+			return
+		}
+		branch = found
+	case utils.IsSet(data.Name) && data.Name.ValueString() != "":
+		name := data.Name.ValueString()
+		branches, httpResp, err := utils.ListBranches(ctx, d.client, url.Values{"name": {name}})
+		defer utils.CloseResponseBody(httpResp)
+		if err != nil {
+			resp.Diagnostics.AddError("Error reading branch", utils.FormatAPIError(fmt.Sprintf("read branch %q", name), err, httpResp))
+			return
+		}
+		found, ok := utils.ExpectSingleResult(
+			branches,
+			"Branch Not Found",
+			fmt.Sprintf("No branch found with name: %s", name),
+			"Multiple Branches Found",
+			fmt.Sprintf("Multiple branches found with name: %s", name),
+			&resp.Diagnostics,
+		)
+		if !ok {
+			return
+		}
+		branch = found
+	default:
+		resp.Diagnostics.AddError("Missing Branch Identifier", "Either 'id' or 'name' must be specified.")
+		return
+	}
+
+	data.ID = types.StringValue(fmt.Sprintf("%d", branch.ID))
+	data.Name = types.StringValue(branch.Name)
+	data.Description = types.StringValue(branch.Description)
+	data.Comments = types.StringValue(branch.Comments)
+	data.SchemaID = types.StringValue(branch.SchemaID)
+	data.Status = types.StringValue(branch.Status)
+	data.LastSync = utils.BranchTime(branch.LastSync)
+	data.MergedTime = utils.BranchTime(branch.MergedTime)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,543 @@
+package datasources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/bab3l/go-netbox"
+	"github.com/bab3l/terraform-provider-netbox/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ datasource.DataSource              = &CableTraceDataSource{}
+	_ datasource.DataSourceWithConfigure = &CableTraceDataSource{}
+)
+
+func NewCableTraceDataSource() datasource.DataSource {
+	return &CableTraceDataSource{}
+}
+
+// CableTraceDataSource follows the cable path from a termination to the far
+// end, across patch panels and circuits.
+type CableTraceDataSource struct {
+	client *netbox.APIClient
+}
+
+type CableTraceDataSourceModel struct {
+	TerminationType types.String `tfsdk:"termination_type"`
+	TerminationID   types.String `tfsdk:"termination_id"`
+	Device          types.String `tfsdk:"device"`
+	Name            types.String `tfsdk:"name"`
+	Hops            types.List   `tfsdk:"hops"`
+	Endpoints       types.List   `tfsdk:"endpoints"`
+	IsComplete      types.Bool   `tfsdk:"is_complete"`
+	IsActive        types.Bool   `tfsdk:"is_active"`
+	IsSplit         types.Bool   `tfsdk:"is_split"`
+}
+
+var cableTraceNodeAttrTypes = map[string]attr.Type{
+	"object_type": types.StringType,
+	"id":          types.StringType,
+	"name":        types.StringType,
+	"device":      types.StringType,
+}
+
+var cableTraceHopAttrTypes = map[string]attr.Type{
+	"near_end":    types.ListType{ElemType: types.ObjectType{AttrTypes: cableTraceNodeAttrTypes}},
+	"cable_id":    types.StringType,
+	"cable_label": types.StringType,
+	"far_end":     types.ListType{ElemType: types.ObjectType{AttrTypes: cableTraceNodeAttrTypes}},
+}
+
+func (d *CableTraceDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cable_trace"
+}
+
+func (d *CableTraceDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	nodesAttribute := func(description string) schema.ListNestedAttribute {
+		return schema.ListNestedAttribute{
+			MarkdownDescription: description,
+			Computed:            true,
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"object_type": schema.StringAttribute{
+						MarkdownDescription: "Content type of the object, e.g. `dcim.interface` or `dcim.frontport`.",
+						Computed:            true,
+					},
+					"id": schema.StringAttribute{
+						MarkdownDescription: "ID of the object.",
+						Computed:            true,
+					},
+					"name": schema.StringAttribute{
+						MarkdownDescription: "Name of the object, or its display name when it has none.",
+						Computed:            true,
+					},
+					"device": schema.StringAttribute{
+						MarkdownDescription: "Name of the device the object belongs to, if any.",
+						Computed:            true,
+					},
+				},
+			},
+		}
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Use this data source to trace the cable path from a termination (interface, front or rear port, console port, console server port, power port, power outlet or power feed) to whatever is connected at the far end, across patch panels and circuits. Identify the termination with `termination_type` plus either `termination_id` or `device` and `name`. Front and rear ports are traced outwards through their own cable.",
+		Attributes: map[string]schema.Attribute{
+			"termination_type": schema.StringAttribute{
+				MarkdownDescription: "Content type of the termination to trace from, e.g. `dcim.interface`, `dcim.frontport`, `dcim.rearport`, `dcim.consoleport`, `dcim.consoleserverport`, `dcim.powerport`, `dcim.poweroutlet` or `dcim.powerfeed`.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(cableTraceTerminationTypes()...),
+				},
+			},
+			"termination_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the termination. Computed when the termination is looked up by `device` and `name`.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"device": schema.StringAttribute{
+				MarkdownDescription: "ID or name of the device the termination belongs to. Must be combined with `name`.",
+				Optional:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the termination on `device`, e.g. `eth0` or `Front 12`.",
+				Optional:            true,
+			},
+			"hops": schema.ListNestedAttribute{
+				MarkdownDescription: "Ordered segments of the path, starting at the termination. Each hop is a cable with the objects it connects.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"near_end": nodesAttribute("Objects at the near end of the cable."),
+						"cable_id": schema.StringAttribute{
+							MarkdownDescription: "ID of the cable. Null for a trailing segment without a cable.",
+							Computed:            true,
+						},
+						"cable_label": schema.StringAttribute{
+							MarkdownDescription: "Label of the cable, if any.",
+							Computed:            true,
+						},
+						"far_end": nodesAttribute("Objects at the far end of the cable."),
+					},
+				},
+			},
+			"endpoints": nodesAttribute("Objects the termination is ultimately connected to. Empty unless the path is complete."),
+			"is_complete": schema.BoolAttribute{
+				MarkdownDescription: "Whether the path ends at a connected endpoint rather than at an unconnected patch panel port.",
+				Computed:            true,
+			},
+			"is_active": schema.BoolAttribute{
+				MarkdownDescription: "Whether every cable on the path has the `connected` status.",
+				Computed:            true,
+			},
+			"is_split": schema.BoolAttribute{
+				MarkdownDescription: "Whether the path fans out at a rear port with several positions and cannot be followed to a single endpoint.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *CableTraceDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*netbox.APIClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *netbox.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.client = client
+}
+
+func (d *CableTraceDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data CableTraceDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	endpoint, _ := utils.LookupCableTerminationEndpoint(data.TerminationType.ValueString())
+
+	var terminationID int64
+	switch {
+	case utils.IsSet(data.TerminationID):
+		id, err := utils.ParseID64(data.TerminationID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid termination ID", fmt.Sprintf("Termination ID must be a number, got: %s", data.TerminationID.ValueString()))
+			return
+		}
+		terminationID = id
+	case utils.IsSet(data.Device) && utils.IsSet(data.Name):
+		terminationID = utils.FindDeviceComponentID(ctx, d.client, endpoint, data.Device.ValueString(), data.Name.ValueString(), &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	default:
+		resp.Diagnostics.AddError(
+			"Missing identifier",
+			"You must specify either `termination_id` or both `device` and `name`",
+		)
+		return
+	}
+	tflog.Debug(ctx, "Tracing cable path", map[string]interface{}{
+		"termination_type": endpoint.ObjectType,
+		"termination_id":   terminationID,
+	})
+
+	origin := cableTraceNode{ObjectType: endpoint.ObjectType, ID: terminationID}
+	var trace cableTrace
+	if endpoint.PassThrough {
+		trace = d.tracePassThrough(ctx, endpoint, origin, &resp.Diagnostics)
+	} else {
+		trace = d.traceEndpoint(ctx, endpoint, origin, &resp.Diagnostics)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.TerminationID = types.StringValue(strconv.FormatInt(terminationID, 10))
+	data.Hops = cableTraceHopsToList(trace.Hops, &resp.Diagnostics)
+	var endpoints []cableTraceNode
+	if trace.IsComplete && len(trace.Hops) > 0 {
+		endpoints = trace.Hops[len(trace.Hops)-1].FarEnd
+	}
+	data.Endpoints = cableTraceNodesToList(endpoints, &resp.Diagnostics)
+	data.IsComplete = types.BoolValue(trace.IsComplete)
+	data.IsActive = types.BoolValue(trace.IsActive)
+	data.IsSplit = types.BoolValue(trace.IsSplit)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// cableTraceTerminationTypes returns the termination types that can be traced.
+func cableTraceTerminationTypes() []string {
+	var objectTypes []string
+	for _, objectType := range utils.CableTerminationObjectTypes(false) {
+		// Circuit terminations are traced from the device side.
+		if objectType != "circuits.circuittermination" {
+			objectTypes = append(objectTypes, objectType)
+		}
+	}
+	return objectTypes
+}
+
+type cableTraceNode struct {
+	ObjectType string
+	ID         int64
+	Name       string
+	Device     string
+}
+
+func (n cableTraceNode) is(other cableTraceNode) bool {
+	return n.ObjectType == other.ObjectType && n.ID == other.ID
+}
+
+type cableTraceHop struct {
+	NearEnd    []cableTraceNode
+	CableID    int64
+	CableLabel string
+	FarEnd     []cableTraceNode
+}
+
+type cableTrace struct {
+	Hops       []cableTraceHop
+	IsComplete bool
+	IsActive   bool
+	IsSplit    bool
+}
+
+// cablePath mirrors an element of the `/paths/` endpoint of pass-through ports.
+type cablePath struct {
+	Path       []json.RawMessage `json:"path"`
+	IsActive   bool              `json:"is_active"`
+	IsComplete bool              `json:"is_complete"`
+	IsSplit    bool              `json:"is_split"`
+}
+
+// traceEndpoint reads the `/trace/` endpoint of a path endpoint such as an
+// interface. The trace has no path flags, so they are taken from the cable
+// path stored on the first pass-through port, or derived from the trace when
+// the path is a single cable.
+func (d *CableTraceDataSource) traceEndpoint(ctx context.Context, endpoint utils.CableTerminationEndpoint, origin cableTraceNode, diags *diag.Diagnostics) cableTrace {
+	body, httpResp, err := utils.DoRawAPIRequest(ctx, d.client, http.MethodGet, fmt.Sprintf("%s/%d/trace", endpoint.APIPath, origin.ID), nil, nil)
+	if err != nil {
+		addCableTraceError(diags, endpoint, origin, err, httpResp)
+		return cableTrace{}
+	}
+
+	var segments [][]json.RawMessage
+	if err := json.Unmarshal(body, &segments); err != nil {
+		diags.AddError("Error tracing cable path", fmt.Sprintf("Could not decode trace: %s", err))
+		return cableTrace{}
+	}
+	trace := cableTrace{IsActive: len(segments) > 0}
+	for _, segment := range segments {
+		if len(segment) != 3 {
+			diags.AddError("Error tracing cable path", fmt.Sprintf("Expected trace segments of 3 elements, got %d", len(segment)))
+			return cableTrace{}
+		}
+		hop, status, err := decodeCableTraceHop(segment[0], segment[1], segment[2])
+		if err != nil {
+			diags.AddError("Error tracing cable path", err.Error())
+			return cableTrace{}
+		}
+		if hop.CableID != 0 && status != "connected" {
+			trace.IsActive = false
+		}
+		trace.Hops = append(trace.Hops, hop)
+	}
+	if len(trace.Hops) == 0 || trace.Hops[0].CableID == 0 {
+		trace.IsActive = false
+		return trace
+	}
+
+	for _, hop := range trace.Hops {
+		for _, node := range hop.FarEnd {
+			passThrough, ok := utils.LookupCableTerminationEndpoint(node.ObjectType)
+			if !ok || !passThrough.PassThrough {
+				continue
+			}
+			paths := d.readCablePaths(ctx, passThrough, node, diags)
+			if diags.HasError() {
+				return cableTrace{}
+			}
+			for _, path := range paths {
+				hops, err := decodeCablePathHops(path.Path)
+				if err != nil {
+					diags.AddError("Error tracing cable path", err.Error())
+					return cableTrace{}
+				}
+				if len(hops) > 0 && containsCableTraceNode(hops[0].NearEnd, origin) {
+					trace.IsComplete, trace.IsActive, trace.IsSplit = path.IsComplete, path.IsActive, path.IsSplit
+					return trace
+				}
+			}
+		}
+	}
+
+	last := trace.Hops[len(trace.Hops)-1]
+	trace.IsComplete = last.CableID != 0 && len(last.FarEnd) > 0
+	return trace
+}
+
+// tracePassThrough reads the `/paths/` endpoint of a front or rear port and
+// returns the path leaving through the port's own cable, starting at the port.
+func (d *CableTraceDataSource) tracePassThrough(ctx context.Context, endpoint utils.CableTerminationEndpoint, origin cableTraceNode, diags *diag.Diagnostics) cableTrace {
+	paths := d.readCablePaths(ctx, endpoint, origin, diags)
+	if diags.HasError() {
+		return cableTrace{}
+	}
+
+	for _, path := range paths {
+		hops, err := decodeCablePathHops(path.Path)
+		if err != nil {
+			diags.AddError("Error tracing cable path", err.Error())
+			return cableTrace{}
+		}
+		for i, hop := range hops {
+			if hop.CableID != 0 && containsCableTraceNode(hop.NearEnd, origin) {
+				return cableTrace{Hops: hops[i:], IsComplete: path.IsComplete, IsActive: path.IsActive, IsSplit: path.IsSplit}
+			}
+		}
+	}
+	return cableTrace{}
+}
+
+func (d *CableTraceDataSource) readCablePaths(ctx context.Context, endpoint utils.CableTerminationEndpoint, node cableTraceNode, diags *diag.Diagnostics) []cablePath {
+	body, httpResp, err := utils.DoRawAPIRequest(ctx, d.client, http.MethodGet, fmt.Sprintf("%s/%d/paths", endpoint.APIPath, node.ID), nil, nil)
+	if err != nil {
+		addCableTraceError(diags, endpoint, node, err, httpResp)
+		return nil
+	}
+	var paths []cablePath
+	if err := json.Unmarshal(body, &paths); err != nil {
+		diags.AddError("Error tracing cable path", fmt.Sprintf("Could not decode cable paths: %s", err))
+		return nil
+	}
+	return paths
+}
+
+func addCableTraceError(diags *diag.Diagnostics, endpoint utils.CableTerminationEndpoint, node cableTraceNode, err error, httpResp *http.Response) {
+	if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
+		diags.AddError("Termination Not Found", fmt.Sprintf("No %s found with ID: %d", endpoint.ObjectType, node.ID))
+		return
+	}
+	diags.AddError(
+		"Error tracing cable path",
+		utils.FormatAPIError(fmt.Sprintf("trace %s ID %d", endpoint.ObjectType, node.ID), err, httpResp),
+	)
+}
+
+// decodeCablePathHops splits the nodes of a stored cable path into hops of
+// near end, cables and far end.
+func decodeCablePathHops(path []json.RawMessage) ([]cableTraceHop, error) {
+	var hops []cableTraceHop
+	for i := 0; i < len(path); i += 3 {
+		cables, farEnd := json.RawMessage("null"), json.RawMessage("[]")
+		if i+1 < len(path) {
+			cables = path[i+1]
+		}
+		if i+2 < len(path) {
+			farEnd = path[i+2]
+		}
+		hop, _, err := decodeCableTraceHop(path[i], cables, farEnd)
+		if err != nil {
+			return nil, err
+		}
+		hops = append(hops, hop)
+	}
+	return hops, nil
+}
+
+// decodeCableTraceHop decodes one segment of a trace. The cable is an object
+// in traces and a list of objects in stored paths. The cable status is
+// returned when present.
+func decodeCableTraceHop(nearEnd, cable, farEnd json.RawMessage) (cableTraceHop, string, error) {
+	var hop cableTraceHop
+	var err error
+	if hop.NearEnd, err = decodeCableTraceNodes(nearEnd); err != nil {
+		return hop, "", err
+	}
+	if hop.FarEnd, err = decodeCableTraceNodes(farEnd); err != nil {
+		return hop, "", err
+	}
+
+	type rawCable struct {
+		ID     int64  `json:"id"`
+		Label  string `json:"label"`
+		Status *struct {
+			Value string `json:"value"`
+		} `json:"status"`
+	}
+	var cables []*rawCable
+	if len(cable) > 0 && cable[0] == '[' {
+		err = json.Unmarshal(cable, &cables)
+	} else {
+		var single *rawCable
+		err = json.Unmarshal(cable, &single)
+		cables = append(cables, single)
+	}
+	if err != nil {
+		return hop, "", fmt.Errorf("could not decode cable: %w", err)
+	}
+
+	status := ""
+	if len(cables) > 0 && cables[0] != nil {
+		hop.CableID = cables[0].ID
+		hop.CableLabel = cables[0].Label
+		if cables[0].Status != nil {
+			status = cables[0].Status.Value
+		}
+	}
+	return hop, status, nil
+}
+
+func decodeCableTraceNodes(raw json.RawMessage) ([]cableTraceNode, error) {
+	var objects []struct {
+		ID      int64   `json:"id"`
+		URL     string  `json:"url"`
+		Name    *string `json:"name"`
+		Display string  `json:"display"`
+		Device  *struct {
+			Name    *string `json:"name"`
+			Display string  `json:"display"`
+		} `json:"device"`
+	}
+	if len(raw) > 0 && string(raw) != "null" {
+		if err := json.Unmarshal(raw, &objects); err != nil {
+			return nil, fmt.Errorf("could not decode path objects: %w", err)
+		}
+	}
+
+	nodes := make([]cableTraceNode, 0, len(objects))
+	for _, object := range objects {
+		node := cableTraceNode{
+			ObjectType: utils.ObjectTypeFromURL(object.URL),
+			ID:         object.ID,
+			Name:       object.Display,
+		}
+		if object.Name != nil && *object.Name != "" {
+			node.Name = *object.Name
+		}
+		if object.Device != nil {
+			node.Device = object.Device.Display
+			if object.Device.Name != nil && *object.Device.Name != "" {
+				node.Device = *object.Device.Name
+			}
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes, nil
+}
+
+func containsCableTraceNode(nodes []cableTraceNode, target cableTraceNode) bool {
+	for _, node := range nodes {
+		if node.is(target) {
+			return true
+		}
+	}
+	return false
+}
+
+func cableTraceNodesToList(nodes []cableTraceNode, diags *diag.Diagnostics) types.List {
+	values := make([]attr.Value, 0, len(nodes))
+	for _, node := range nodes {
+		device := types.StringNull()
+		if node.Device != "" {
+			device = types.StringValue(node.Device)
+		}
+		value, valueDiags := types.ObjectValue(cableTraceNodeAttrTypes, map[string]attr.Value{
+			"object_type": types.StringValue(node.ObjectType),
+			"id":          types.StringValue(strconv.FormatInt(node.ID, 10)),
+			"name":        types.StringValue(node.Name),
+			"device":      device,
+		})
+		diags.Append(valueDiags...)
+		values = append(values, value)
+	}
+	list, listDiags := types.ListValue(types.ObjectType{AttrTypes: cableTraceNodeAttrTypes}, values)
+	diags.Append(listDiags...)
+	return list
+}
+
+func cableTraceHopsToList(hops []cableTraceHop, diags *diag.Diagnostics) types.List {
+	values := make([]attr.Value, 0, len(hops))
+	for _, hop := range hops {
+		cableID, cableLabel := types.StringNull(), types.StringNull()
+		if hop.CableID != 0 {
+			cableID = types.StringValue(strconv.FormatInt(hop.CableID, 10))
+			if hop.CableLabel != "" {
+				cableLabel = types.StringValue(hop.CableLabel)
+			}
+		}
+		value, valueDiags := types.ObjectValue(cableTraceHopAttrTypes, map[string]attr.Value{
+			"near_end":    cableTraceNodesToList(hop.NearEnd, diags),
+			"cable_id":    cableID,
+			"cable_label": cableLabel,
+			"far_end":     cableTraceNodesToList(hop.FarEnd, diags),
+		})
+		diags.Append(valueDiags...)
+		values = append(values, value)
+	}
+	list, listDiags := types.ListValue(types.ObjectType{AttrTypes: cableTraceHopAttrTypes}, values)
+	diags.Append(listDiags...)
+	return list
+}
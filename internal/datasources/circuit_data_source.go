@@ -12,6 +12,7 @@ import (
 	"github.com/bab3l/terraform-provider-netbox/internal/utils"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -171,7 +172,20 @@ func (d *CircuitDataSource) Read(ctx context.Context, req datasource.ReadRequest
 		return
 	}
 
-	// Map the circuit to state
+	// Map response to state
+	d.mapCircuitToState(ctx, circuit, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "Read circuit", map[string]interface{}{
+		"id":  circuit.GetId(),
+		"cid": circuit.GetCid(),
+	})
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// mapCircuitToState maps a circuit API response to the data source model.
+func (d *CircuitDataSource) mapCircuitToState(ctx context.Context, circuit *netbox.Circuit, data *CircuitDataSourceModel, diags *diag.Diagnostics) {
 	data.ID = types.StringValue(fmt.Sprintf("%d", circuit.GetId()))
 	data.Cid = types.StringValue(circuit.GetCid())
 	data.CircuitProvider = types.StringValue(circuit.GetProvider().Name)
@@ -237,8 +251,8 @@ func (d *CircuitDataSource) Read(ctx context.Context, req datasource.ReadRequest
 	if circuit.HasTags() {
 		tags := utils.NestedTagsToTagModels(circuit.GetTags())
 		tagsValue, tagDiags := types.SetValueFrom(ctx, utils.GetTagsAttributeType().ElemType, tags)
-		resp.Diagnostics.Append(tagDiags...)
-		if resp.Diagnostics.HasError() {
+		diags.Append(tagDiags...)
+		if diags.HasError() {
 			return
 		}
 		data.Tags = tagsValue
@@ -247,7 +261,7 @@ func (d *CircuitDataSource) Read(ctx context.Context, req datasource.ReadRequest
 	}
 
 	// Handle custom fields - datasources return ALL fields
-	data.CustomFields = utils.CustomFieldsSetFromAPI(ctx, circuit.HasCustomFields(), circuit.GetCustomFields(), &resp.Diagnostics)
+	data.CustomFields = utils.CustomFieldsSetFromAPI(ctx, circuit.HasCustomFields(), circuit.GetCustomFields(), diags)
 
 	// Map display name
 	if circuit.GetDisplay() != "" {
@@ -255,9 +269,4 @@ func (d *CircuitDataSource) Read(ctx context.Context, req datasource.ReadRequest
 	} else {
 		data.DisplayName = types.StringNull()
 	}
-	tflog.Debug(ctx, "Read circuit", map[string]interface{}{
-		"id":  circuit.GetId(),
-		"cid": circuit.GetCid(),
-	})
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/bab3l/terraform-provider-netbox/internal/utils"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -175,7 +176,19 @@ func (d *CircuitTypeDataSource) Read(ctx context.Context, req datasource.ReadReq
 		return
 	}
 
-	// Map the circuit type to state
+	d.mapResponseToState(ctx, circuitType, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "Read circuit type", map[string]interface{}{
+		"id":   circuitType.GetId(),
+		"name": circuitType.GetName(),
+	})
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// mapResponseToState maps a circuit type from the API to the data source model.
+func (d *CircuitTypeDataSource) mapResponseToState(ctx context.Context, circuitType *netbox.CircuitType, data *CircuitTypeDataSourceModel, diags *diag.Diagnostics) {
 	data.ID = types.StringValue(fmt.Sprintf("%d", circuitType.GetId()))
 	data.Name = types.StringValue(circuitType.GetName())
 	data.Slug = types.StringValue(circuitType.GetSlug())
@@ -198,8 +211,8 @@ func (d *CircuitTypeDataSource) Read(ctx context.Context, req datasource.ReadReq
 	if circuitType.HasTags() {
 		tags := utils.NestedTagsToTagModels(circuitType.GetTags())
 		tagsValue, tagDiags := types.SetValueFrom(ctx, utils.GetTagsAttributeType().ElemType, tags)
-		resp.Diagnostics.Append(tagDiags...)
-		if resp.Diagnostics.HasError() {
+		diags.Append(tagDiags...)
+		if diags.HasError() {
 			return
 		}
 		data.Tags = tagsValue
@@ -208,7 +221,7 @@ func (d *CircuitTypeDataSource) Read(ctx context.Context, req datasource.ReadReq
 	}
 
 	// Handle custom fields - datasources return ALL fields
-	data.CustomFields = utils.CustomFieldsSetFromAPI(ctx, circuitType.HasCustomFields(), circuitType.GetCustomFields(), &resp.Diagnostics)
+	data.CustomFields = utils.CustomFieldsSetFromAPI(ctx, circuitType.HasCustomFields(), circuitType.GetCustomFields(), diags)
 
 	// Map display name
 	if circuitType.GetDisplay() != "" {
@@ -216,9 +229,4 @@ func (d *CircuitTypeDataSource) Read(ctx context.Context, req datasource.ReadReq
 	} else {
 		data.DisplayName = types.StringNull()
 	}
-	tflog.Debug(ctx, "Read circuit type", map[string]interface{}{
-		"id":   circuitType.GetId(),
-		"name": circuitType.GetName(),
-	})
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/bab3l/terraform-provider-netbox/internal/utils"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -173,6 +174,19 @@ func (d *ClusterDataSource) Read(ctx context.Context, req datasource.ReadRequest
 	}
 
 	// Map response to state
+	d.mapClusterToState(ctx, cluster, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "Read cluster", map[string]interface{}{
+		"id":   data.ID.ValueString(),
+		"name": data.Name.ValueString(),
+	})
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// mapClusterToState maps a cluster API response to the data source model.
+func (d *ClusterDataSource) mapClusterToState(ctx context.Context, cluster *netbox.Cluster, data *ClusterDataSourceModel, diags *diag.Diagnostics) {
 	data.ID = types.StringValue(fmt.Sprintf("%d", cluster.GetId()))
 	data.Name = types.StringValue(cluster.GetName())
 
@@ -225,8 +239,8 @@ func (d *ClusterDataSource) Read(ctx context.Context, req datasource.ReadRequest
 	if cluster.HasTags() && len(cluster.GetTags()) > 0 {
 		tags := utils.NestedTagsToTagModels(cluster.GetTags())
 		tagsValue, tagDiags := types.SetValueFrom(ctx, utils.GetTagsAttributeType().ElemType, tags)
-		resp.Diagnostics.Append(tagDiags...)
-		if resp.Diagnostics.HasError() {
+		diags.Append(tagDiags...)
+		if diags.HasError() {
 			return
 		}
 		data.Tags = tagsValue
@@ -235,7 +249,7 @@ func (d *ClusterDataSource) Read(ctx context.Context, req datasource.ReadRequest
 	}
 
 	// Handle custom fields - datasources return ALL fields
-	data.CustomFields = utils.CustomFieldsSetFromAPI(ctx, cluster.HasCustomFields(), cluster.GetCustomFields(), &resp.Diagnostics)
+	data.CustomFields = utils.CustomFieldsSetFromAPI(ctx, cluster.HasCustomFields(), cluster.GetCustomFields(), diags)
 
 	// Map display name
 	if cluster.GetDisplay() != "" {
@@ -243,9 +257,4 @@ func (d *ClusterDataSource) Read(ctx context.Context, req datasource.ReadRequest
 	} else {
 		data.DisplayName = types.StringNull()
 	}
-	tflog.Debug(ctx, "Read cluster", map[string]interface{}{
-		"id":   data.ID.ValueString(),
-		"name": data.Name.ValueString(),
-	})
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -10,6 +10,7 @@ import (
 	"github.com/bab3l/terraform-provider-netbox/internal/utils"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -141,7 +142,15 @@ func (d *ClusterGroupDataSource) Read(ctx context.Context, req datasource.ReadRe
 		return
 	}
 
-	// Map response to state
+	d.mapResponseToState(ctx, clusterGroup, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// mapResponseToState maps a cluster group from the API to the data source model.
+func (d *ClusterGroupDataSource) mapResponseToState(ctx context.Context, clusterGroup *netbox.ClusterGroup, data *ClusterGroupDataSourceModel, diags *diag.Diagnostics) {
 	data.ID = types.StringValue(fmt.Sprintf("%d", clusterGroup.GetId()))
 	data.Name = types.StringValue(clusterGroup.GetName())
 	data.Slug = types.StringValue(clusterGroup.GetSlug())
@@ -165,6 +174,4 @@ func (d *ClusterGroupDataSource) Read(ctx context.Context, req datasource.ReadRe
 	} else {
 		data.CustomFields = nil
 	}
-
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
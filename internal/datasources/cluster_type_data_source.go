@@ -12,6 +12,7 @@ import (
 	"github.com/bab3l/terraform-provider-netbox/internal/utils"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -195,7 +196,19 @@ func (d *ClusterTypeDataSource) Read(ctx context.Context, req datasource.ReadReq
 		return
 	}
 
-	// Map response to state
+	d.mapResponseToState(ctx, clusterType, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "Read cluster type", map[string]interface{}{
+		"id":   data.ID.ValueString(),
+		"name": data.Name.ValueString(),
+	})
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// mapResponseToState maps a cluster type from the API to the data source model.
+func (d *ClusterTypeDataSource) mapResponseToState(ctx context.Context, clusterType *netbox.ClusterType, data *ClusterTypeDataSourceModel, diags *diag.Diagnostics) {
 	data.ID = types.StringValue(fmt.Sprintf("%d", clusterType.GetId()))
 	data.Name = types.StringValue(clusterType.GetName())
 	data.Slug = types.StringValue(clusterType.GetSlug())
@@ -211,8 +224,8 @@ func (d *ClusterTypeDataSource) Read(ctx context.Context, req datasource.ReadReq
 	if clusterType.HasTags() && len(clusterType.GetTags()) > 0 {
 		tags := utils.NestedTagsToTagModels(clusterType.GetTags())
 		tagsValue, tagDiags := types.SetValueFrom(ctx, utils.GetTagsAttributeType().ElemType, tags)
-		resp.Diagnostics.Append(tagDiags...)
-		if resp.Diagnostics.HasError() {
+		diags.Append(tagDiags...)
+		if diags.HasError() {
 			return
 		}
 		data.Tags = tagsValue
@@ -221,7 +234,7 @@ func (d *ClusterTypeDataSource) Read(ctx context.Context, req datasource.ReadReq
 	}
 
 	// Handle custom fields - datasources return ALL fields
-	data.CustomFields = utils.CustomFieldsSetFromAPI(ctx, clusterType.HasCustomFields(), clusterType.GetCustomFields(), &resp.Diagnostics)
+	data.CustomFields = utils.CustomFieldsSetFromAPI(ctx, clusterType.HasCustomFields(), clusterType.GetCustomFields(), diags)
 
 	// Map display name
 	if clusterType.GetDisplay() != "" {
@@ -229,9 +242,4 @@ func (d *ClusterTypeDataSource) Read(ctx context.Context, req datasource.ReadReq
 	} else {
 		data.DisplayName = types.StringNull()
 	}
-	tflog.Debug(ctx, "Read cluster type", map[string]interface{}{
-		"id":   data.ID.ValueString(),
-		"name": data.Name.ValueString(),
-	})
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
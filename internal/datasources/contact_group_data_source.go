@@ -10,6 +10,7 @@ import (
 	"github.com/bab3l/terraform-provider-netbox/internal/utils"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -147,7 +148,15 @@ func (d *ContactGroupDataSource) Read(ctx context.Context, req datasource.ReadRe
 		return
 	}
 
-	// Map response to state
+	d.mapResponseToState(ctx, contactGroup, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// mapResponseToState maps a contact group from the API to the data source model.
+func (d *ContactGroupDataSource) mapResponseToState(ctx context.Context, contactGroup *netbox.ContactGroup, data *ContactGroupDataSourceModel, diags *diag.Diagnostics) {
 	data.ID = types.StringValue(fmt.Sprintf("%d", contactGroup.GetId()))
 	data.Name = types.StringValue(contactGroup.GetName())
 	data.Slug = types.StringValue(contactGroup.GetSlug())
@@ -186,6 +195,4 @@ func (d *ContactGroupDataSource) Read(ctx context.Context, req datasource.ReadRe
 	} else {
 		data.CustomFields = nil
 	}
-
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -10,6 +10,7 @@ import (
 	"github.com/bab3l/terraform-provider-netbox/internal/utils"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -143,7 +144,15 @@ func (d *ContactRoleDataSource) Read(ctx context.Context, req datasource.ReadReq
 		return
 	}
 
-	// Map response to state
+	d.mapResponseToState(ctx, contactRole, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// mapResponseToState maps a contact role from the API to the data source model.
+func (d *ContactRoleDataSource) mapResponseToState(ctx context.Context, contactRole *netbox.ContactRole, data *ContactRoleDataSourceModel, diags *diag.Diagnostics) {
 	data.ID = types.StringValue(fmt.Sprintf("%d", contactRole.GetId()))
 	data.Name = types.StringValue(contactRole.GetName())
 	data.Slug = types.StringValue(contactRole.GetSlug())
@@ -167,6 +176,4 @@ func (d *ContactRoleDataSource) Read(ctx context.Context, req datasource.ReadReq
 	} else {
 		data.CustomFields = nil
 	}
-
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
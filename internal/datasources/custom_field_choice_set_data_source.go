@@ -36,6 +36,7 @@ type CustomFieldChoiceSetDataSourceModel struct {
 	ExtraChoices        types.List   `tfsdk:"extra_choices"`
 	OrderAlphabetically types.Bool   `tfsdk:"order_alphabetically"`
 	ChoicesCount        types.Int64  `tfsdk:"choices_count"`
+	Choices             types.List   `tfsdk:"choices"`
 }
 
 func (d *CustomFieldChoiceSetDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -88,6 +89,22 @@ func (d *CustomFieldChoiceSetDataSource) Schema(ctx context.Context, req datasou
 				MarkdownDescription: "Total number of choices available.",
 				Computed:            true,
 			},
+			"choices": schema.ListNestedAttribute{
+				MarkdownDescription: "The choices offered by the choice set as resolved by Netbox: the base choices merged with the extra choices, ordered as Netbox presents them. Null in the results of `netbox_custom_field_choice_sets`, which would need a request per choice set.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"value": schema.StringAttribute{
+							MarkdownDescription: "The internal value.",
+							Computed:            true,
+						},
+						"label": schema.StringAttribute{
+							MarkdownDescription: "The display label.",
+							Computed:            true,
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -162,6 +179,13 @@ func (d *CustomFieldChoiceSetDataSource) Read(ctx context.Context, req datasourc
 		return
 	}
 	d.mapToState(result, &data)
+	choices, choicesResp, err := utils.ListCustomFieldChoices(ctx, d.client, result.GetId())
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading custom field choices",
+			utils.FormatAPIError(fmt.Sprintf("read choices of custom field choice set ID %d", result.GetId()), err, choicesResp))
+		return
+	}
+	data.Choices = utils.CustomFieldChoicesToList(choices)
 	tflog.Debug(ctx, "Read custom field choice set", map[string]interface{}{
 		"id":   data.ID.ValueString(),
 		"name": data.Name.ValueString(),
@@ -0,0 +1,166 @@
+package datasources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bab3l/go-netbox"
+	"github.com/bab3l/terraform-provider-netbox/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ datasource.DataSource              = &CustomFieldChoicesDataSource{}
+	_ datasource.DataSourceWithConfigure = &CustomFieldChoicesDataSource{}
+)
+
+func NewCustomFieldChoicesDataSource() datasource.DataSource {
+	return &CustomFieldChoicesDataSource{}
+}
+
+// CustomFieldChoicesDataSource returns the choices a select or multiselect
+// custom field accepts.
+type CustomFieldChoicesDataSource struct {
+	client *netbox.APIClient
+}
+
+type CustomFieldChoicesDataSourceModel struct {
+	Name        types.String `tfsdk:"name"`
+	ID          types.String `tfsdk:"id"`
+	ChoiceSet   types.String `tfsdk:"choice_set"`
+	ChoiceSetID types.String `tfsdk:"choice_set_id"`
+	Choices     types.List   `tfsdk:"choices"`
+	Values      types.List   `tfsdk:"values"`
+}
+
+func (d *CustomFieldChoicesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_custom_field_choices"
+}
+
+func (d *CustomFieldChoicesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Use this data source to get the choices a select or multiselect custom field accepts, as resolved by Netbox from its choice set: the base choices merged with the extra choices. Use `values` to validate inputs before apply, e.g. with `contains()`.",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the custom field.",
+				Required:            true,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the custom field.",
+				Computed:            true,
+			},
+			"choice_set": schema.StringAttribute{
+				MarkdownDescription: "Name of the choice set of the custom field.",
+				Computed:            true,
+			},
+			"choice_set_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the choice set of the custom field.",
+				Computed:            true,
+			},
+			"choices": schema.ListNestedAttribute{
+				MarkdownDescription: "The choices of the custom field, ordered as Netbox presents them.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"value": schema.StringAttribute{
+							MarkdownDescription: "The internal value.",
+							Computed:            true,
+						},
+						"label": schema.StringAttribute{
+							MarkdownDescription: "The display label.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"values": schema.ListAttribute{
+				MarkdownDescription: "The values of `choices`.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *CustomFieldChoicesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*netbox.APIClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *netbox.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.client = client
+}
+
+func (d *CustomFieldChoicesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data CustomFieldChoicesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	name := data.Name.ValueString()
+
+	list, httpResp, err := d.client.ExtrasAPI.ExtrasCustomFieldsList(ctx).Name([]string{name}).Execute()
+	defer utils.CloseResponseBody(httpResp)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading custom field",
+			utils.FormatAPIError(fmt.Sprintf("read custom field %q", name), err, httpResp))
+		return
+	}
+	customField, ok := utils.ExpectSingleResult(
+		list.GetResults(),
+		"Not Found",
+		fmt.Sprintf("No custom field found with name: %s", name),
+		"Multiple Found",
+		fmt.Sprintf("Multiple custom fields found with name: %s", name),
+		&resp.Diagnostics,
+	)
+	if !ok {
+		return
+	}
+	choiceSet, ok := customField.GetChoiceSetOk()
+	if !ok || choiceSet == nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("name"),
+			"Custom field has no choices",
+			fmt.Sprintf("Custom field %q of type %q has no choice set. Only select and multiselect custom fields have choices.", name, customField.Type.GetValue()),
+		)
+		return
+	}
+
+	choices, choicesResp, err := utils.ListCustomFieldChoices(ctx, d.client, choiceSet.GetId())
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading custom field choices",
+			utils.FormatAPIError(fmt.Sprintf("read choices of custom field choice set ID %d", choiceSet.GetId()), err, choicesResp))
+		return
+	}
+	values := make([]string, len(choices))
+	for i, choice := range choices {
+		values[i] = choice.Value
+	}
+
+	data.ID = types.StringValue(fmt.Sprintf("%d", customField.GetId()))
+	data.ChoiceSet = types.StringValue(choiceSet.GetName())
+	data.ChoiceSetID = types.StringValue(fmt.Sprintf("%d", choiceSet.GetId()))
+	data.Choices = utils.CustomFieldChoicesToList(choices)
+	valuesList, diags := types.ListValueFrom(ctx, types.StringType, values)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Values = valuesList
+	tflog.Debug(ctx, "Read custom field choices", map[string]interface{}{
+		"name":    name,
+		"choices": len(choices),
+	})
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
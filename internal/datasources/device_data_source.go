@@ -33,30 +33,31 @@ type DeviceDataSource struct {
 
 // DeviceDataSourceModel describes the data source data model.
 type DeviceDataSourceModel struct {
-	ID           types.String  `tfsdk:"id"`
-	Name         types.String  `tfsdk:"name"`
-	DeviceType   types.String  `tfsdk:"device_type"`
-	Role         types.String  `tfsdk:"role"`
-	Tenant       types.String  `tfsdk:"tenant"`
-	Platform     types.String  `tfsdk:"platform"`
-	Serial       types.String  `tfsdk:"serial"`
-	AssetTag     types.String  `tfsdk:"asset_tag"`
-	Site         types.String  `tfsdk:"site"`
-	Location     types.String  `tfsdk:"location"`
-	Rack         types.String  `tfsdk:"rack"`
-	Position     types.Float64 `tfsdk:"position"`
-	Face         types.String  `tfsdk:"face"`
-	Latitude     types.Float64 `tfsdk:"latitude"`
-	Longitude    types.Float64 `tfsdk:"longitude"`
-	Status       types.String  `tfsdk:"status"`
-	Airflow      types.String  `tfsdk:"airflow"`
-	VcPosition   types.Int64   `tfsdk:"vc_position"`
-	VcPriority   types.Int64   `tfsdk:"vc_priority"`
-	Description  types.String  `tfsdk:"description"`
-	Comments     types.String  `tfsdk:"comments"`
-	Tags         types.Set     `tfsdk:"tags"`
-	CustomFields types.Set     `tfsdk:"custom_fields"`
-	DisplayName  types.String  `tfsdk:"display_name"`
+	ID             types.String  `tfsdk:"id"`
+	Name           types.String  `tfsdk:"name"`
+	DeviceType     types.String  `tfsdk:"device_type"`
+	Role           types.String  `tfsdk:"role"`
+	Tenant         types.String  `tfsdk:"tenant"`
+	Platform       types.String  `tfsdk:"platform"`
+	Serial         types.String  `tfsdk:"serial"`
+	AssetTag       types.String  `tfsdk:"asset_tag"`
+	Site           types.String  `tfsdk:"site"`
+	Location       types.String  `tfsdk:"location"`
+	Rack           types.String  `tfsdk:"rack"`
+	Position       types.Float64 `tfsdk:"position"`
+	Face           types.String  `tfsdk:"face"`
+	Latitude       types.Float64 `tfsdk:"latitude"`
+	Longitude      types.Float64 `tfsdk:"longitude"`
+	Status         types.String  `tfsdk:"status"`
+	Airflow        types.String  `tfsdk:"airflow"`
+	VirtualChassis types.String  `tfsdk:"virtual_chassis"`
+	VcPosition     types.Int64   `tfsdk:"vc_position"`
+	VcPriority     types.Int64   `tfsdk:"vc_priority"`
+	Description    types.String  `tfsdk:"description"`
+	Comments       types.String  `tfsdk:"comments"`
+	Tags           types.Set     `tfsdk:"tags"`
+	CustomFields   types.Set     `tfsdk:"custom_fields"`
+	DisplayName    types.String  `tfsdk:"display_name"`
 }
 
 func (d *DeviceDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -78,23 +79,24 @@ func (d *DeviceDataSource) Schema(ctx context.Context, req datasource.SchemaRequ
 				Optional:            true,
 				Computed:            true,
 			},
-			"asset_tag":     nbschema.DSComputedStringAttribute("A unique tag used to identify this device."),
-			"site":          nbschema.DSComputedStringAttribute("The site where this device is located. Returns the site slug."),
-			"location":      nbschema.DSComputedStringAttribute("The location within the site. Returns the location slug."),
-			"rack":          nbschema.DSComputedStringAttribute("The rack where this device is mounted. Returns the rack name."),
-			"position":      nbschema.DSComputedFloat64Attribute("Position in the rack (in rack units from the bottom)."),
-			"face":          nbschema.DSComputedStringAttribute("Which face of the rack the device is mounted on (front or rear)."),
-			"latitude":      nbschema.DSComputedFloat64Attribute("GPS latitude coordinate in decimal format."),
-			"longitude":     nbschema.DSComputedFloat64Attribute("GPS longitude coordinate in decimal format."),
-			"status":        nbschema.DSComputedStringAttribute("Operational status of the device."),
-			"airflow":       nbschema.DSComputedStringAttribute("Direction of airflow through the device."),
-			"vc_position":   nbschema.DSComputedInt64Attribute("Position within a virtual chassis."),
-			"vc_priority":   nbschema.DSComputedInt64Attribute("Virtual chassis master election priority."),
-			"description":   nbschema.DSComputedStringAttribute("Brief description of the device."),
-			"comments":      nbschema.DSComputedStringAttribute("Comments about the device (supports Markdown)."),
-			"tags":          nbschema.DSTagsAttribute(),
-			"custom_fields": nbschema.DSCustomFieldsAttribute(),
-			"display_name":  nbschema.DSComputedStringAttribute("The display name of the device."),
+			"asset_tag":       nbschema.DSComputedStringAttribute("A unique tag used to identify this device."),
+			"site":            nbschema.DSComputedStringAttribute("The site where this device is located. Returns the site slug."),
+			"location":        nbschema.DSComputedStringAttribute("The location within the site. Returns the location slug."),
+			"rack":            nbschema.DSComputedStringAttribute("The rack where this device is mounted. Returns the rack name."),
+			"position":        nbschema.DSComputedFloat64Attribute("Position in the rack (in rack units from the bottom)."),
+			"face":            nbschema.DSComputedStringAttribute("Which face of the rack the device is mounted on (front or rear)."),
+			"latitude":        nbschema.DSComputedFloat64Attribute("GPS latitude coordinate in decimal format."),
+			"longitude":       nbschema.DSComputedFloat64Attribute("GPS longitude coordinate in decimal format."),
+			"status":          nbschema.DSComputedStringAttribute("Operational status of the device."),
+			"airflow":         nbschema.DSComputedStringAttribute("Direction of airflow through the device."),
+			"virtual_chassis": nbschema.DSComputedStringAttribute("The virtual chassis this device is a member of. Returns the virtual chassis name."),
+			"vc_position":     nbschema.DSComputedInt64Attribute("Position within a virtual chassis."),
+			"vc_priority":     nbschema.DSComputedInt64Attribute("Virtual chassis master election priority."),
+			"description":     nbschema.DSComputedStringAttribute("Brief description of the device."),
+			"comments":        nbschema.DSComputedStringAttribute("Comments about the device (supports Markdown)."),
+			"tags":            nbschema.DSTagsAttribute(),
+			"custom_fields":   nbschema.DSCustomFieldsAttribute(),
+			"display_name":    nbschema.DSComputedStringAttribute("The display name of the device."),
 		},
 	}
 }
@@ -325,6 +327,13 @@ func (d *DeviceDataSource) mapDeviceToDataSource(ctx context.Context, device *ne
 		data.Airflow = types.StringNull()
 	}
 
+	// Handle virtual_chassis
+	if device.HasVirtualChassis() && device.VirtualChassis.Get() != nil {
+		data.VirtualChassis = types.StringValue(device.VirtualChassis.Get().GetName())
+	} else {
+		data.VirtualChassis = types.StringNull()
+	}
+
 	// Handle vc_position
 	if device.HasVcPosition() && device.VcPosition.Get() != nil {
 		data.VcPosition = types.Int64Value(int64(*device.VcPosition.Get()))
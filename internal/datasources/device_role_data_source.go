@@ -15,6 +15,7 @@ import (
 	"github.com/bab3l/terraform-provider-netbox/internal/utils"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -219,7 +220,16 @@ func (d *DeviceRoleDataSource) Read(ctx context.Context, req datasource.ReadRequ
 		return
 	}
 
-	// Update the model with the response from the API
+	d.mapResponseToState(ctx, deviceRole, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// mapResponseToState maps a device role from the API to the data source model.
+func (d *DeviceRoleDataSource) mapResponseToState(ctx context.Context, deviceRole *netbox.DeviceRole, data *DeviceRoleDataSourceModel, diags *diag.Diagnostics) {
 	data.ID = types.StringValue(fmt.Sprintf("%d", deviceRole.GetId()))
 	data.Name = types.StringValue(deviceRole.GetName())
 	data.Slug = types.StringValue(deviceRole.GetSlug())
@@ -248,9 +258,9 @@ func (d *DeviceRoleDataSource) Read(ctx context.Context, req datasource.ReadRequ
 	// Handle tags
 	if deviceRole.HasTags() {
 		tags := utils.NestedTagsToTagModels(deviceRole.GetTags())
-		tagsValue, diags := types.SetValueFrom(ctx, utils.GetTagsAttributeType().ElemType, tags)
-		resp.Diagnostics.Append(diags...)
-		if resp.Diagnostics.HasError() {
+		tagsValue, tagDiags := types.SetValueFrom(ctx, utils.GetTagsAttributeType().ElemType, tags)
+		diags.Append(tagDiags...)
+		if diags.HasError() {
 			return
 		}
 		data.Tags = tagsValue
@@ -259,7 +269,7 @@ func (d *DeviceRoleDataSource) Read(ctx context.Context, req datasource.ReadRequ
 	}
 
 	// Handle custom fields
-	data.CustomFields = utils.CustomFieldsSetFromAPI(ctx, deviceRole.HasCustomFields(), deviceRole.GetCustomFields(), &resp.Diagnostics)
+	data.CustomFields = utils.CustomFieldsSetFromAPI(ctx, deviceRole.HasCustomFields(), deviceRole.GetCustomFields(), diags)
 
 	// Map display_name
 	if deviceRole.GetDisplay() != "" {
@@ -267,7 +277,4 @@ func (d *DeviceRoleDataSource) Read(ctx context.Context, req datasource.ReadRequ
 	} else {
 		data.DisplayName = types.StringNull()
 	}
-
-	// Save data into Terraform state
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
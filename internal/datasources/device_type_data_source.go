@@ -15,6 +15,7 @@ import (
 	"github.com/bab3l/terraform-provider-netbox/internal/utils"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -234,7 +235,16 @@ func (d *DeviceTypeDataSource) Read(ctx context.Context, req datasource.ReadRequ
 		return
 	}
 
-	// Update the model with the response from the API
+	d.mapResponseToState(ctx, deviceType, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// mapResponseToState maps a device type from the API to the data source model.
+func (d *DeviceTypeDataSource) mapResponseToState(ctx context.Context, deviceType *netbox.DeviceType, data *DeviceTypeDataSourceModel, diags *diag.Diagnostics) {
 	data.ID = types.StringValue(fmt.Sprintf("%d", deviceType.GetId()))
 	data.Model = types.StringValue(deviceType.GetModel())
 	data.Slug = types.StringValue(deviceType.GetSlug())
@@ -331,9 +341,9 @@ func (d *DeviceTypeDataSource) Read(ctx context.Context, req datasource.ReadRequ
 	// Handle tags
 	if deviceType.HasTags() {
 		tags := utils.NestedTagsToTagModels(deviceType.GetTags())
-		tagsValue, diags := types.SetValueFrom(ctx, utils.GetTagsAttributeType().ElemType, tags)
-		resp.Diagnostics.Append(diags...)
-		if resp.Diagnostics.HasError() {
+		tagsValue, tagDiags := types.SetValueFrom(ctx, utils.GetTagsAttributeType().ElemType, tags)
+		diags.Append(tagDiags...)
+		if diags.HasError() {
 			return
 		}
 		data.Tags = tagsValue
@@ -342,7 +352,7 @@ func (d *DeviceTypeDataSource) Read(ctx context.Context, req datasource.ReadRequ
 	}
 
 	// Handle custom fields
-	data.CustomFields = utils.CustomFieldsSetFromAPI(ctx, deviceType.HasCustomFields(), deviceType.GetCustomFields(), &resp.Diagnostics)
+	data.CustomFields = utils.CustomFieldsSetFromAPI(ctx, deviceType.HasCustomFields(), deviceType.GetCustomFields(), diags)
 
 	// Handle device_count (read-only, always present)
 	data.DeviceCount = types.Int64Value(deviceType.GetDeviceCount())
@@ -353,7 +363,4 @@ func (d *DeviceTypeDataSource) Read(ctx context.Context, req datasource.ReadRequ
 	} else {
 		data.DisplayName = types.StringNull()
 	}
-
-	// Save data into Terraform state
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/bab3l/terraform-provider-netbox/internal/utils"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -177,7 +178,22 @@ func (d *FHRPGroupDataSource) Read(ctx context.Context, req datasource.ReadReque
 		return
 	}
 
-	// Map response to state
+	d.mapResponseToState(ctx, fhrpGroup, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "Read FHRP group", map[string]interface{}{
+		"id":       data.ID.ValueInt32(),
+		"protocol": data.Protocol.ValueString(),
+		"group_id": data.GroupID.ValueInt32(),
+	})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// mapResponseToState maps an FHRP group from the API to the data source model.
+func (d *FHRPGroupDataSource) mapResponseToState(ctx context.Context, fhrpGroup *netbox.FHRPGroup, data *FHRPGroupDataSourceModel, diags *diag.Diagnostics) {
 	data.ID = types.Int32Value(fhrpGroup.GetId())
 	data.Protocol = types.StringValue(string(fhrpGroup.Protocol))
 	data.GroupID = types.Int32Value(fhrpGroup.GetGroupId())
@@ -223,14 +239,5 @@ func (d *FHRPGroupDataSource) Read(ctx context.Context, req datasource.ReadReque
 	}
 
 	// Handle custom fields - datasources return ALL fields
-	data.CustomFields = utils.CustomFieldsSetFromAPI(ctx, fhrpGroup.HasCustomFields(), fhrpGroup.GetCustomFields(), &resp.Diagnostics)
-
-	tflog.Debug(ctx, "Read FHRP group", map[string]interface{}{
-		"id":       data.ID.ValueInt32(),
-		"protocol": data.Protocol.ValueString(),
-		"group_id": data.GroupID.ValueInt32(),
-	})
-
-	// Save data into Terraform state
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	data.CustomFields = utils.CustomFieldsSetFromAPI(ctx, fhrpGroup.HasCustomFields(), fhrpGroup.GetCustomFields(), diags)
 }
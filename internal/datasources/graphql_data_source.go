@@ -0,0 +1,247 @@
+package datasources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+
+	"github.com/bab3l/go-netbox"
+	"github.com/bab3l/terraform-provider-netbox/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ datasource.DataSource              = &GraphQLDataSource{}
+	_ datasource.DataSourceWithConfigure = &GraphQLDataSource{}
+)
+
+// graphQLMaxPages bounds automatic pagination so a query that never returns a
+// short page cannot loop forever.
+const graphQLMaxPages = 1000
+
+var (
+	graphQLOffsetVariable = regexp.MustCompile(`\$offset\b`)
+	graphQLLimitVariable  = regexp.MustCompile(`\$limit\b`)
+)
+
+func NewGraphQLDataSource() datasource.DataSource {
+	return &GraphQLDataSource{}
+}
+
+// GraphQLDataSource runs a query against the NetBox GraphQL API.
+type GraphQLDataSource struct {
+	client *netbox.APIClient
+}
+
+type GraphQLDataSourceModel struct {
+	Query     types.String  `tfsdk:"query"`
+	Variables types.Dynamic `tfsdk:"variables"`
+	PageSize  types.Int64   `tfsdk:"page_size"`
+	Data      types.Dynamic `tfsdk:"data"`
+	DataJSON  types.String  `tfsdk:"data_json"`
+}
+
+func (d *GraphQLDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_graphql"
+}
+
+func (d *GraphQLDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Run a query against the NetBox GraphQL API (`/graphql/`). This fetches related objects (e.g. devices with their interfaces, IP addresses and cables) in a single request. Errors returned by GraphQL are reported as diagnostics.",
+		Attributes: map[string]schema.Attribute{
+			"query": schema.StringAttribute{
+				MarkdownDescription: "The GraphQL query document.",
+				Required:            true,
+			},
+			"variables": schema.DynamicAttribute{
+				MarkdownDescription: "Variables for the query, as an object or map (e.g. `{ site = \"dc1\", limit = 50 }`). Values keep their types.",
+				Optional:            true,
+			},
+			"page_size": schema.Int64Attribute{
+				MarkdownDescription: "Enables automatic pagination. The query must declare `$offset` and `$limit` variables and pass them to the paginated fields (e.g. `device_list(pagination: {offset: $offset, limit: $limit})`). The query is repeated with increasing `offset` until every top-level list returns fewer than `page_size` items, and the top-level lists are concatenated. When unset, `offset` and `limit` in `variables` are sent unchanged.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"data": schema.DynamicAttribute{
+				MarkdownDescription: "The `data` of the GraphQL response. Attributes mirror the fields selected in the query.",
+				Computed:            true,
+			},
+			"data_json": schema.StringAttribute{
+				MarkdownDescription: "The `data` of the GraphQL response as raw JSON, for use with `jsondecode()`.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *GraphQLDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*netbox.APIClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *netbox.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.client = client
+}
+
+func (d *GraphQLDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data GraphQLDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	variables, err := graphQLVariables(ctx, data.Variables)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("variables"), "Invalid variables", err.Error())
+		return
+	}
+
+	request := utils.GraphQLRequest{Query: data.Query.ValueString(), Variables: variables}
+	var result json.RawMessage
+	if data.PageSize.IsNull() || data.PageSize.IsUnknown() {
+		result = d.execute(ctx, request, &resp.Diagnostics)
+	} else {
+		result = d.executePaginated(ctx, request, data.PageSize.ValueInt64(), &resp.Diagnostics)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if len(result) == 0 {
+		result = json.RawMessage("null")
+	}
+	dataValue, err := utils.JSONToDynamic(result)
+	if err != nil {
+		resp.Diagnostics.AddError("Unexpected GraphQL response", fmt.Sprintf("Could not decode GraphQL data: %s", err))
+		return
+	}
+
+	data.Data = dataValue
+	data.DataJSON = types.StringValue(string(result))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// execute runs a single GraphQL request and returns its data.
+func (d *GraphQLDataSource) execute(ctx context.Context, request utils.GraphQLRequest, diags *diag.Diagnostics) json.RawMessage {
+	tflog.Debug(ctx, "Executing GraphQL query", map[string]interface{}{
+		"variables": request.Variables,
+	})
+
+	result, httpResp, err := utils.ExecuteGraphQL(ctx, d.client, request)
+	defer utils.CloseResponseBody(httpResp)
+	if err != nil {
+		diags.AddError("Error executing GraphQL query", utils.FormatAPIError("execute GraphQL query", err, httpResp))
+		return nil
+	}
+	for _, gqlErr := range result.Errors {
+		diags.AddError("GraphQL query returned an error", gqlErr.String())
+	}
+	return result.Data
+}
+
+// executePaginated repeats the query with increasing offsets and concatenates
+// the top-level lists of each page.
+func (d *GraphQLDataSource) executePaginated(ctx context.Context, request utils.GraphQLRequest, pageSize int64, diags *diag.Diagnostics) json.RawMessage {
+	if !graphQLOffsetVariable.MatchString(request.Query) || !graphQLLimitVariable.MatchString(request.Query) {
+		diags.AddAttributeError(
+			path.Root("query"),
+			"Missing pagination variables",
+			"When `page_size` is set, the query must declare and use `$offset` and `$limit` variables.",
+		)
+		return nil
+	}
+
+	var merged map[string]json.RawMessage
+	for page := int64(0); page < graphQLMaxPages; page++ {
+		variables := make(map[string]interface{}, len(request.Variables)+2)
+		for k, v := range request.Variables {
+			variables[k] = v
+		}
+		variables["offset"] = page * pageSize
+		variables["limit"] = pageSize
+
+		raw := d.execute(ctx, utils.GraphQLRequest{Query: request.Query, Variables: variables}, diags)
+		if diags.HasError() {
+			return nil
+		}
+
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &fields); err != nil {
+			diags.AddError("Unexpected GraphQL response", fmt.Sprintf("Paginated queries must return an object of fields: %s", err))
+			return nil
+		}
+
+		more := false
+		if merged == nil {
+			merged = fields
+			for _, value := range fields {
+				var items []json.RawMessage
+				if json.Unmarshal(value, &items) == nil && int64(len(items)) >= pageSize {
+					more = true
+				}
+			}
+		} else {
+			for name, value := range fields {
+				var items []json.RawMessage
+				if err := json.Unmarshal(value, &items); err != nil {
+					continue
+				}
+				var existing []json.RawMessage
+				_ = json.Unmarshal(merged[name], &existing)
+				combined, err := json.Marshal(append(existing, items...))
+				if err != nil {
+					diags.AddError("Error merging GraphQL pages", err.Error())
+					return nil
+				}
+				merged[name] = combined
+				if int64(len(items)) >= pageSize {
+					more = true
+				}
+			}
+		}
+
+		if !more {
+			encoded, err := json.Marshal(merged)
+			if err != nil {
+				diags.AddError("Error merging GraphQL pages", err.Error())
+				return nil
+			}
+			return encoded
+		}
+	}
+
+	diags.AddError("Too many GraphQL pages", fmt.Sprintf("Stopped after %d pages; increase `page_size` or narrow the query.", graphQLMaxPages))
+	return nil
+}
+
+// graphQLVariables converts the configured variables into a JSON object.
+func graphQLVariables(ctx context.Context, value types.Dynamic) (map[string]interface{}, error) {
+	if value.IsNull() || value.IsUnderlyingValueNull() {
+		return nil, nil
+	}
+	raw, err := utils.DynamicToJSON(ctx, value)
+	if err != nil {
+		return nil, err
+	}
+	variables, err := utils.DecodeJSONObject(raw)
+	if err != nil {
+		return nil, fmt.Errorf("variables must be an object or map: %w", err)
+	}
+	return variables, nil
+}
@@ -49,6 +49,14 @@ type InterfaceDataSourceModel struct {
 	DisplayName   types.String `tfsdk:"display_name"`
 	Mode          types.String `tfsdk:"mode"`
 	MarkConnected types.Bool   `tfsdk:"mark_connected"`
+	Vrf           types.String `tfsdk:"vrf"`
+	PoeMode       types.String `tfsdk:"poe_mode"`
+	PoeType       types.String `tfsdk:"poe_type"`
+	RfRole        types.String `tfsdk:"rf_role"`
+	RfChannel     types.String `tfsdk:"rf_channel"`
+	TxPower       types.Int64  `tfsdk:"tx_power"`
+	WirelessLans  types.Set    `tfsdk:"wireless_lans"`
+	Vdcs          types.Set    `tfsdk:"vdcs"`
 	Tags          types.Set    `tfsdk:"tags"`
 	CustomFields  types.Set    `tfsdk:"custom_fields"`
 }
@@ -88,8 +96,24 @@ func (d *InterfaceDataSource) Schema(ctx context.Context, req datasource.SchemaR
 			"display_name":   nbschema.DSComputedStringAttribute("The display name of the interface."),
 			"mode":           nbschema.DSComputedStringAttribute("802.1Q mode (access, tagged, tagged-all)."),
 			"mark_connected": nbschema.DSComputedBoolAttribute("Treat as if a cable is connected."),
-			"tags":           nbschema.DSTagsAttribute(),
-			"custom_fields":  nbschema.DSCustomFieldsAttribute(),
+			"vrf":            nbschema.DSComputedStringAttribute("ID of the VRF this interface is assigned to."),
+			"poe_mode":       nbschema.DSComputedStringAttribute("Power over Ethernet mode (pd, pse)."),
+			"poe_type":       nbschema.DSComputedStringAttribute("Power over Ethernet type."),
+			"rf_role":        nbschema.DSComputedStringAttribute("Wireless role (ap, station)."),
+			"rf_channel":     nbschema.DSComputedStringAttribute("Wireless channel."),
+			"tx_power":       nbschema.DSComputedInt64Attribute("Transmit power in dBm."),
+			"wireless_lans": schema.SetAttribute{
+				MarkdownDescription: "IDs of the wireless LANs attached to this interface.",
+				Computed:            true,
+				ElementType:         types.Int64Type,
+			},
+			"vdcs": schema.SetAttribute{
+				MarkdownDescription: "IDs of the virtual device contexts this interface is assigned to.",
+				Computed:            true,
+				ElementType:         types.Int64Type,
+			},
+			"tags":          nbschema.DSTagsAttribute(),
+			"custom_fields": nbschema.DSCustomFieldsAttribute(),
 		},
 	}
 }
@@ -359,6 +383,54 @@ func (d *InterfaceDataSource) mapInterfaceToDataSource(ctx context.Context, ifac
 		data.MarkConnected = types.BoolValue(false)
 	}
 
+	// VRF
+	if vrf, ok := iface.GetVrfOk(); ok && vrf != nil {
+		data.Vrf = types.StringValue(fmt.Sprintf("%d", vrf.GetId()))
+	} else {
+		data.Vrf = types.StringNull()
+	}
+
+	// PoE and RF choices
+	data.PoeMode = types.StringNull()
+	if poeMode, ok := iface.GetPoeModeOk(); ok && poeMode != nil && poeMode.GetValue() != "" {
+		data.PoeMode = types.StringValue(string(poeMode.GetValue()))
+	}
+	data.PoeType = types.StringNull()
+	if poeType, ok := iface.GetPoeTypeOk(); ok && poeType != nil && poeType.GetValue() != "" {
+		data.PoeType = types.StringValue(string(poeType.GetValue()))
+	}
+	data.RfRole = types.StringNull()
+	if rfRole, ok := iface.GetRfRoleOk(); ok && rfRole != nil && rfRole.GetValue() != "" {
+		data.RfRole = types.StringValue(string(rfRole.GetValue()))
+	}
+	data.RfChannel = types.StringNull()
+	if rfChannel, ok := iface.GetRfChannelOk(); ok && rfChannel != nil && rfChannel.GetValue() != "" {
+		data.RfChannel = types.StringValue(string(rfChannel.GetValue()))
+	}
+
+	// TX power
+	if txPower, ok := iface.GetTxPowerOk(); ok && txPower != nil {
+		data.TxPower = types.Int64Value(int64(*txPower))
+	} else {
+		data.TxPower = types.Int64Null()
+	}
+
+	// Wireless LANs and VDCs
+	wirelessLANIDs := make([]int64, 0, len(iface.GetWirelessLans()))
+	for _, wlan := range iface.GetWirelessLans() {
+		wirelessLANIDs = append(wirelessLANIDs, int64(wlan.GetId()))
+	}
+	wirelessLans, setDiags := types.SetValueFrom(ctx, types.Int64Type, wirelessLANIDs)
+	resp.Diagnostics.Append(setDiags...)
+	data.WirelessLans = wirelessLans
+	vdcIDs := make([]int64, 0, len(iface.GetVdcs()))
+	for _, vdc := range iface.GetVdcs() {
+		vdcIDs = append(vdcIDs, int64(vdc.GetId()))
+	}
+	vdcs, setDiags := types.SetValueFrom(ctx, types.Int64Type, vdcIDs)
+	resp.Diagnostics.Append(setDiags...)
+	data.Vdcs = vdcs
+
 	// Tags
 	if iface.HasTags() && len(iface.GetTags()) > 0 {
 		tags := utils.NestedTagsToTagModels(iface.GetTags())
@@ -12,6 +12,7 @@ import (
 	"github.com/bab3l/terraform-provider-netbox/internal/utils"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -52,6 +53,10 @@ type InventoryItemDataSourceModel struct {
 	DisplayName      types.String `tfsdk:"display_name"`
 	Tags             types.Set    `tfsdk:"tags"`
 	CustomFields     types.Set    `tfsdk:"custom_fields"`
+	Status           types.String `tfsdk:"status"`
+	ComponentType    types.String `tfsdk:"component_type"`
+	ComponentID      types.Int64  `tfsdk:"component_id"`
+	ComponentName    types.String `tfsdk:"component_name"`
 }
 
 // Metadata returns the data source type name.
@@ -83,6 +88,16 @@ func (d *InventoryItemDataSource) Schema(ctx context.Context, req datasource.Sch
 				Optional:            true,
 				Computed:            true,
 			},
+			"component_type": schema.StringAttribute{
+				MarkdownDescription: "Type of the component the inventory item is installed in, e.g. `dcim.interface`. Use this together with `component_id` to find the item installed in a component.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"component_id": schema.Int64Attribute{
+				MarkdownDescription: "ID of the component the inventory item is installed in. Use this to filter by component.",
+				Optional:            true,
+				Computed:            true,
+			},
 
 			// Computed attributes
 			"device_name": schema.StringAttribute{
@@ -140,6 +155,16 @@ func (d *InventoryItemDataSource) Schema(ctx context.Context, req datasource.Sch
 				Computed:            true,
 			},
 
+			"status": schema.StringAttribute{
+				MarkdownDescription: "Operational status of the inventory item.",
+				Computed:            true,
+			},
+
+			"component_name": schema.StringAttribute{
+				MarkdownDescription: "Name of the component the inventory item is installed in.",
+				Computed:            true,
+			},
+
 			"description": schema.StringAttribute{
 				MarkdownDescription: "A description of the inventory item.",
 				Computed:            true,
@@ -234,6 +259,17 @@ func (d *InventoryItemDataSource) Read(ctx context.Context, req datasource.ReadR
 			}
 			listReq = listReq.DeviceId([]int32{deviceID32})
 		}
+		if utils.IsSet(data.ComponentType) {
+			listReq = listReq.ComponentType(data.ComponentType.ValueString())
+		}
+		if utils.IsSet(data.ComponentID) {
+			componentID32, err := utils.SafeInt32FromValue(data.ComponentID)
+			if err != nil {
+				resp.Diagnostics.AddError("Invalid Component ID", fmt.Sprintf("Component ID value overflow: %s", err))
+				return
+			}
+			listReq = listReq.ComponentId([]int32{componentID32})
+		}
 		response, httpResp, err := listReq.Execute()
 		defer utils.CloseResponseBody(httpResp)
 		if err != nil {
@@ -257,7 +293,15 @@ func (d *InventoryItemDataSource) Read(ctx context.Context, req datasource.ReadR
 		item = result
 	}
 
-	// Map response to model
+	d.mapResponseToState(ctx, item, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// mapResponseToState maps a inventory item from the API to the data source model.
+func (d *InventoryItemDataSource) mapResponseToState(ctx context.Context, item *netbox.InventoryItem, data *InventoryItemDataSourceModel, diags *diag.Diagnostics) {
 	data.ID = types.StringValue(fmt.Sprintf("%d", item.GetId()))
 	data.Name = types.StringValue(item.GetName())
 
@@ -341,9 +385,9 @@ func (d *InventoryItemDataSource) Read(ctx context.Context, req datasource.ReadR
 		for _, tag := range item.GetTags() {
 			tagNames = append(tagNames, tag.GetName())
 		}
-		tagsValue, diags := types.SetValueFrom(ctx, types.StringType, tagNames)
-		resp.Diagnostics.Append(diags...)
-		if resp.Diagnostics.HasError() {
+		tagsValue, tagDiags := types.SetValueFrom(ctx, types.StringType, tagNames)
+		diags.Append(tagDiags...)
+		if diags.HasError() {
 			return
 		}
 		data.Tags = tagsValue
@@ -358,8 +402,30 @@ func (d *InventoryItemDataSource) Read(ctx context.Context, req datasource.ReadR
 		data.DisplayName = types.StringNull()
 	}
 
-	// Handle custom fields - datasources return ALL fields
-	data.CustomFields = utils.CustomFieldsSetFromAPI(ctx, item.HasCustomFields(), item.GetCustomFields(), &resp.Diagnostics)
+	// Map status (not part of the generated model)
+	data.Status = types.StringNull()
+	if status, ok := item.AdditionalProperties["status"].(map[string]interface{}); ok {
+		if value, ok := status["value"].(string); ok && value != "" {
+			data.Status = types.StringValue(value)
+		}
+	}
 
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	// Map component
+	data.ComponentType = types.StringNull()
+	data.ComponentID = types.Int64Null()
+	data.ComponentName = types.StringNull()
+	if componentType, ok := item.GetComponentTypeOk(); ok && componentType != nil && *componentType != "" {
+		data.ComponentType = types.StringValue(*componentType)
+	}
+	if componentID, ok := item.GetComponentIdOk(); ok && componentID != nil {
+		data.ComponentID = types.Int64Value(*componentID)
+	}
+	if component, ok := item.Component.(map[string]interface{}); ok {
+		if name, ok := component["name"].(string); ok {
+			data.ComponentName = types.StringValue(name)
+		}
+	}
+
+	// Handle custom fields - datasources return ALL fields
+	data.CustomFields = utils.CustomFieldsSetFromAPI(ctx, item.HasCustomFields(), item.GetCustomFields(), diags)
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/bab3l/terraform-provider-netbox/internal/utils"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -185,7 +186,15 @@ func (d *InventoryItemRoleDataSource) Read(ctx context.Context, req datasource.R
 		role = result
 	}
 
-	// Map response to model
+	d.mapResponseToState(ctx, role, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// mapResponseToState maps a inventory item role from the API to the data source model.
+func (d *InventoryItemRoleDataSource) mapResponseToState(ctx context.Context, role *netbox.InventoryItemRole, data *InventoryItemRoleDataSourceModel, diags *diag.Diagnostics) {
 	data.ID = types.StringValue(fmt.Sprintf("%d", role.GetId()))
 	data.Name = types.StringValue(role.GetName())
 	data.Slug = types.StringValue(role.GetSlug())
@@ -210,9 +219,9 @@ func (d *InventoryItemRoleDataSource) Read(ctx context.Context, req datasource.R
 		for _, tag := range role.GetTags() {
 			tagNames = append(tagNames, tag.GetName())
 		}
-		tagsValue, diags := types.SetValueFrom(ctx, types.StringType, tagNames)
-		resp.Diagnostics.Append(diags...)
-		if resp.Diagnostics.HasError() {
+		tagsValue, tagDiags := types.SetValueFrom(ctx, types.StringType, tagNames)
+		diags.Append(tagDiags...)
+		if diags.HasError() {
 			return
 		}
 		data.Tags = tagsValue
@@ -228,7 +237,5 @@ func (d *InventoryItemRoleDataSource) Read(ctx context.Context, req datasource.R
 	}
 
 	// Handle custom fields - datasources return ALL fields
-	data.CustomFields = utils.CustomFieldsSetFromAPI(ctx, role.HasCustomFields(), role.GetCustomFields(), &resp.Diagnostics)
-
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	data.CustomFields = utils.CustomFieldsSetFromAPI(ctx, role.HasCustomFields(), role.GetCustomFields(), diags)
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/bab3l/terraform-provider-netbox/internal/utils"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -122,7 +123,21 @@ func (d *JournalEntryDataSource) Read(ctx context.Context, req datasource.ReadRe
 		return
 	}
 
-	// Map response to state
+	d.mapResponseToState(ctx, journalEntry, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "Read journal entry", map[string]interface{}{
+		"id":                   data.ID.ValueInt32(),
+		"assigned_object_type": data.AssignedObjectType.ValueString(),
+	})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// mapResponseToState maps a journal entry from the API to the data source model.
+func (d *JournalEntryDataSource) mapResponseToState(ctx context.Context, journalEntry *netbox.JournalEntry, data *JournalEntryDataSourceModel, diags *diag.Diagnostics) {
 	data.ID = types.Int32Value(journalEntry.GetId())
 
 	// Display Name
@@ -143,13 +158,5 @@ func (d *JournalEntryDataSource) Read(ctx context.Context, req datasource.ReadRe
 	}
 
 	// Map custom fields
-	data.CustomFields = utils.CustomFieldsSetFromAPI(ctx, journalEntry.HasCustomFields(), journalEntry.GetCustomFields(), &resp.Diagnostics)
-
-	tflog.Debug(ctx, "Read journal entry", map[string]interface{}{
-		"id":                   data.ID.ValueInt32(),
-		"assigned_object_type": data.AssignedObjectType.ValueString(),
-	})
-
-	// Save data into Terraform state
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	data.CustomFields = utils.CustomFieldsSetFromAPI(ctx, journalEntry.HasCustomFields(), journalEntry.GetCustomFields(), diags)
 }
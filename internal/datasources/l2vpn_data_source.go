@@ -11,6 +11,7 @@ import (
 	"github.com/bab3l/terraform-provider-netbox/internal/utils"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -198,12 +199,15 @@ func (d *L2VPNDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	}
 
 	// Map response to state
-	d.mapResponseToState(ctx, l2vpn, &data, resp)
+	d.mapResponseToState(ctx, l2vpn, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 // mapResponseToState maps an L2VPN API response to the Terraform state model.
-func (d *L2VPNDataSource) mapResponseToState(ctx context.Context, l2vpn *netbox.L2VPN, data *L2VPNDataSourceModel, resp *datasource.ReadResponse) {
+func (d *L2VPNDataSource) mapResponseToState(ctx context.Context, l2vpn *netbox.L2VPN, data *L2VPNDataSourceModel, diags *diag.Diagnostics) {
 	data.ID = types.StringValue(fmt.Sprintf("%d", l2vpn.GetId()))
 
 	// Display Name
@@ -260,8 +264,8 @@ func (d *L2VPNDataSource) mapResponseToState(ctx context.Context, l2vpn *netbox.
 		for _, target := range l2vpn.GetImportTargets() {
 			targetIDs = append(targetIDs, fmt.Sprintf("%d", target.GetId()))
 		}
-		targetSet, diags := types.SetValueFrom(ctx, types.StringType, targetIDs)
-		resp.Diagnostics.Append(diags...)
+		targetSet, setDiags := types.SetValueFrom(ctx, types.StringType, targetIDs)
+		diags.Append(setDiags...)
 		data.ImportTargets = targetSet
 	} else {
 		data.ImportTargets = types.SetNull(types.StringType)
@@ -273,8 +277,8 @@ func (d *L2VPNDataSource) mapResponseToState(ctx context.Context, l2vpn *netbox.
 		for _, target := range l2vpn.GetExportTargets() {
 			targetIDs = append(targetIDs, fmt.Sprintf("%d", target.GetId()))
 		}
-		targetSet, diags := types.SetValueFrom(ctx, types.StringType, targetIDs)
-		resp.Diagnostics.Append(diags...)
+		targetSet, setDiags := types.SetValueFrom(ctx, types.StringType, targetIDs)
+		diags.Append(setDiags...)
 		data.ExportTargets = targetSet
 	} else {
 		data.ExportTargets = types.SetNull(types.StringType)
@@ -283,13 +287,13 @@ func (d *L2VPNDataSource) mapResponseToState(ctx context.Context, l2vpn *netbox.
 	// Tags
 	if l2vpn.HasTags() && len(l2vpn.GetTags()) > 0 {
 		tags := utils.NestedTagsToTagModels(l2vpn.GetTags())
-		tagsValue, diags := types.SetValueFrom(ctx, utils.GetTagsAttributeType().ElemType, tags)
-		resp.Diagnostics.Append(diags...)
+		tagsValue, tagDiags := types.SetValueFrom(ctx, utils.GetTagsAttributeType().ElemType, tags)
+		diags.Append(tagDiags...)
 		data.Tags = tagsValue
 	} else {
 		data.Tags = types.SetNull(utils.GetTagsAttributeType().ElemType)
 	}
 
 	// Custom fields
-	data.CustomFields = utils.CustomFieldsSetFromAPI(ctx, l2vpn.HasCustomFields(), l2vpn.GetCustomFields(), &resp.Diagnostics)
+	data.CustomFields = utils.CustomFieldsSetFromAPI(ctx, l2vpn.HasCustomFields(), l2vpn.GetCustomFields(), diags)
 }
@@ -16,6 +16,7 @@ import (
 	"github.com/bab3l/terraform-provider-netbox/internal/utils"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -207,7 +208,20 @@ func (d *LocationDataSource) Read(ctx context.Context, req datasource.ReadReques
 		return
 	}
 
-	// Map response to state
+	d.mapResponseToState(ctx, location, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "Read location", map[string]interface{}{
+		"id":   location.GetId(),
+		"name": location.GetName(),
+		"slug": location.GetSlug(),
+	})
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// mapResponseToState maps a location from the API to the data source model.
+func (d *LocationDataSource) mapResponseToState(ctx context.Context, location *netbox.Location, data *LocationDataSourceModel, diags *diag.Diagnostics) {
 	data.ID = types.StringValue(fmt.Sprintf("%d", location.GetId()))
 
 	// Display Name
@@ -269,9 +283,9 @@ func (d *LocationDataSource) Read(ctx context.Context, req datasource.ReadReques
 	// Handle tags
 	if location.HasTags() {
 		tags := utils.NestedTagsToTagModels(location.GetTags())
-		tagsValue, diags := types.SetValueFrom(ctx, utils.GetTagsAttributeType().ElemType, tags)
-		resp.Diagnostics.Append(diags...)
-		if resp.Diagnostics.HasError() {
+		tagsValue, tagDiags := types.SetValueFrom(ctx, utils.GetTagsAttributeType().ElemType, tags)
+		diags.Append(tagDiags...)
+		if diags.HasError() {
 			return
 		}
 		data.Tags = tagsValue
@@ -280,11 +294,5 @@ func (d *LocationDataSource) Read(ctx context.Context, req datasource.ReadReques
 	}
 
 	// Handle custom fields
-	data.CustomFields = utils.CustomFieldsSetFromAPI(ctx, location.HasCustomFields(), location.GetCustomFields(), &resp.Diagnostics)
-	tflog.Debug(ctx, "Read location", map[string]interface{}{
-		"id":   location.GetId(),
-		"name": location.GetName(),
-		"slug": location.GetSlug(),
-	})
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	data.CustomFields = utils.CustomFieldsSetFromAPI(ctx, location.HasCustomFields(), location.GetCustomFields(), diags)
 }
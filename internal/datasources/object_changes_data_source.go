@@ -0,0 +1,369 @@
+package datasources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"reflect"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/bab3l/go-netbox"
+	"github.com/bab3l/terraform-provider-netbox/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ datasource.DataSource              = &ObjectChangesDataSource{}
+	_ datasource.DataSourceWithConfigure = &ObjectChangesDataSource{}
+)
+
+func NewObjectChangesDataSource() datasource.DataSource {
+	return &ObjectChangesDataSource{}
+}
+
+// ObjectChangesDataSource lists the change log records of NetBox.
+type ObjectChangesDataSource struct {
+	client *netbox.APIClient
+}
+
+type ObjectChangesDataSourceModel struct {
+	RequestID         types.String `tfsdk:"request_id"`
+	CurrentRun        types.Bool   `tfsdk:"current_run"`
+	ObjectType        types.String `tfsdk:"object_type"`
+	ObjectID          types.String `tfsdk:"object_id"`
+	User              types.String `tfsdk:"user"`
+	Action            types.String `tfsdk:"action"`
+	TimeAfter         types.String `tfsdk:"time_after"`
+	TimeBefore        types.String `tfsdk:"time_before"`
+	ProviderRequestID types.String `tfsdk:"provider_request_id"`
+	Changes           types.List   `tfsdk:"changes"`
+}
+
+// rfc3339Pattern matches the RFC 3339 timestamps accepted by the time filters.
+var rfc3339Pattern = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:\d{2})$`)
+
+var objectChangeAttrTypes = map[string]attr.Type{
+	"id":                 types.StringType,
+	"time":               types.StringType,
+	"user":               types.StringType,
+	"request_id":         types.StringType,
+	"action":             types.StringType,
+	"object_type":        types.StringType,
+	"object_id":          types.StringType,
+	"object_display":     types.StringType,
+	"changed_attributes": types.ListType{ElemType: types.StringType},
+	"prechange_data":     types.StringType,
+	"postchange_data":    types.StringType,
+}
+
+// objectChangeAPI mirrors the fields of /api/core/object-changes/ used by the
+// data source.
+type objectChangeAPI struct {
+	ID                int64           `json:"id"`
+	Time              time.Time       `json:"time"`
+	UserName          string          `json:"user_name"`
+	RequestID         string          `json:"request_id"`
+	Action            json.RawMessage `json:"action"`
+	ChangedObjectType string          `json:"changed_object_type"`
+	ChangedObjectID   int64           `json:"changed_object_id"`
+	ChangedObject     *struct {
+		Display string `json:"display"`
+	} `json:"changed_object"`
+	PrechangeData  map[string]interface{} `json:"prechange_data"`
+	PostchangeData map[string]interface{} `json:"postchange_data"`
+}
+
+func (d *ObjectChangesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_object_changes"
+}
+
+func (d *ObjectChangesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	timeValidators := []validator.String{
+		stringvalidator.RegexMatches(rfc3339Pattern, "must be an RFC 3339 timestamp, e.g. `2024-01-02T15:04:05Z`"),
+	}
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Use this data source to list change log records (`/api/core/object-changes/`), e.g. to check after an apply exactly which objects Terraform changed. Filters are combined with AND. Changes are ordered from oldest to newest.",
+		Attributes: map[string]schema.Attribute{
+			"request_id": schema.StringAttribute{
+				MarkdownDescription: "Only changes made by the request with this ID.",
+				Optional:            true,
+			},
+			"current_run": schema.BoolAttribute{
+				MarkdownDescription: "Only changes made by the requests of this provider configuration in the current Terraform run, i.e. by the resources applied before the data source is read. Netbox assigns the IDs of these requests, so this works whether or not Netbox honours the provider's `request_id`.",
+				Optional:            true,
+			},
+			"object_type": schema.StringAttribute{
+				MarkdownDescription: "Only changes of objects of this content type, e.g. `dcim.site`.",
+				Optional:            true,
+			},
+			"object_id": schema.StringAttribute{
+				MarkdownDescription: "Only changes of the object with this ID. Usually combined with `object_type`.",
+				Optional:            true,
+			},
+			"user": schema.StringAttribute{
+				MarkdownDescription: "Only changes made by the user with this username.",
+				Optional:            true,
+			},
+			"action": schema.StringAttribute{
+				MarkdownDescription: "Only changes with this action: `create`, `update` or `delete`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("create", "update", "delete"),
+				},
+			},
+			"time_after": schema.StringAttribute{
+				MarkdownDescription: "Only changes made at or after this RFC 3339 time.",
+				Optional:            true,
+				Validators:          timeValidators,
+			},
+			"time_before": schema.StringAttribute{
+				MarkdownDescription: "Only changes made at or before this RFC 3339 time.",
+				Optional:            true,
+				Validators:          timeValidators,
+			},
+			"provider_request_id": schema.StringAttribute{
+				MarkdownDescription: "The ID the provider sends in the `X-Request-ID` header of its requests.",
+				Computed:            true,
+			},
+			"changes": schema.ListNestedAttribute{
+				MarkdownDescription: "The matching changes.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "ID of the change record.",
+							Computed:            true,
+						},
+						"time": schema.StringAttribute{
+							MarkdownDescription: "Time of the change, in RFC 3339 format.",
+							Computed:            true,
+						},
+						"user": schema.StringAttribute{
+							MarkdownDescription: "Username of the user who made the change.",
+							Computed:            true,
+						},
+						"request_id": schema.StringAttribute{
+							MarkdownDescription: "ID of the request that made the change.",
+							Computed:            true,
+						},
+						"action": schema.StringAttribute{
+							MarkdownDescription: "Action of the change: `create`, `update` or `delete`.",
+							Computed:            true,
+						},
+						"object_type": schema.StringAttribute{
+							MarkdownDescription: "Content type of the changed object, e.g. `dcim.site`.",
+							Computed:            true,
+						},
+						"object_id": schema.StringAttribute{
+							MarkdownDescription: "ID of the changed object.",
+							Computed:            true,
+						},
+						"object_display": schema.StringAttribute{
+							MarkdownDescription: "Display name of the changed object. Null when it no longer exists.",
+							Computed:            true,
+						},
+						"changed_attributes": schema.ListAttribute{
+							MarkdownDescription: "Sorted names of the attributes whose values differ between `prechange_data` and `postchange_data`.",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+						"prechange_data": schema.StringAttribute{
+							MarkdownDescription: "JSON of the object before the change. Null for creations.",
+							Computed:            true,
+						},
+						"postchange_data": schema.StringAttribute{
+							MarkdownDescription: "JSON of the object after the change. Null for deletions.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *ObjectChangesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*netbox.APIClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *netbox.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.client = client
+}
+
+func (d *ObjectChangesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ObjectChangesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	settings := utils.ProviderSettingsFor(d.client)
+
+	query := url.Values{}
+	for name, value := range map[string]types.String{
+		"request_id":          data.RequestID,
+		"changed_object_type": data.ObjectType,
+		"changed_object_id":   data.ObjectID,
+		"user_name":           data.User,
+		"action":              data.Action,
+		"time_after":          data.TimeAfter,
+		"time_before":         data.TimeBefore,
+	} {
+		if utils.IsSet(value) {
+			query.Set(name, value.ValueString())
+		}
+	}
+
+	// The changes of the current run are those made by the requests recorded
+	// since the provider was configured.
+	var runRequests []string
+	if data.CurrentRun.ValueBool() {
+		if settings.WriteRequests != nil {
+			runRequests = settings.WriteRequests.IDs()
+			if !utils.IsSet(data.TimeAfter) {
+				query.Set("time_after", settings.WriteRequests.Since().Add(-time.Minute).UTC().Format(time.RFC3339))
+			}
+		}
+		if len(runRequests) == 0 {
+			data.ProviderRequestID = types.StringValue(settings.RequestID)
+			data.Changes = types.ListValueMust(types.ObjectType{AttrTypes: objectChangeAttrTypes}, []attr.Value{})
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			return
+		}
+	}
+
+	results, httpResp, err := utils.ListRawAPIObjects(ctx, d.client, "core/object-changes", query)
+	defer utils.CloseResponseBody(httpResp)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading object changes",
+			utils.FormatAPIError("list object changes", err, httpResp))
+		return
+	}
+
+	changes := make([]objectChangeAPI, 0, len(results))
+	for _, raw := range results {
+		var change objectChangeAPI
+		if err := json.Unmarshal(raw, &change); err != nil {
+			resp.Diagnostics.AddError("Error decoding object change", err.Error())
+			return
+		}
+		if runRequests != nil && !slices.Contains(runRequests, change.RequestID) {
+			continue
+		}
+		changes = append(changes, change)
+	}
+	sort.SliceStable(changes, func(i, j int) bool {
+		if changes[i].Time.Equal(changes[j].Time) {
+			return changes[i].ID < changes[j].ID
+		}
+		return changes[i].Time.Before(changes[j].Time)
+	})
+
+	values := make([]attr.Value, 0, len(changes))
+	for _, change := range changes {
+		value, diags := objectChangeValue(change)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		values = append(values, value)
+	}
+	data.ProviderRequestID = types.StringValue(settings.RequestID)
+	data.Changes = types.ListValueMust(types.ObjectType{AttrTypes: objectChangeAttrTypes}, values)
+	tflog.Debug(ctx, "Read object changes", map[string]interface{}{
+		"changes": len(values),
+	})
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// objectChangeValue converts a change record to its Terraform value.
+func objectChangeValue(change objectChangeAPI) (attr.Value, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	jsonValue := func(data map[string]interface{}, name string) types.String {
+		if data == nil {
+			return types.StringNull()
+		}
+		encoded, err := json.Marshal(data)
+		if err != nil {
+			diags.AddAttributeError(path.Root("changes"), "Error encoding object change", fmt.Sprintf("%s of change %d: %s", name, change.ID, err))
+			return types.StringNull()
+		}
+		return types.StringValue(string(encoded))
+	}
+	display := types.StringNull()
+	if change.ChangedObject != nil {
+		display = types.StringValue(change.ChangedObject.Display)
+	}
+
+	changed := changedObjectAttributes(change.PrechangeData, change.PostchangeData)
+	changedValues := make([]attr.Value, len(changed))
+	for i, name := range changed {
+		changedValues[i] = types.StringValue(name)
+	}
+	value, valueDiags := types.ObjectValue(objectChangeAttrTypes, map[string]attr.Value{
+		"id":                 types.StringValue(strconv.FormatInt(change.ID, 10)),
+		"time":               types.StringValue(change.Time.UTC().Format(time.RFC3339Nano)),
+		"user":               types.StringValue(change.UserName),
+		"request_id":         types.StringValue(change.RequestID),
+		"action":             types.StringValue(objectChangeAction(change.Action)),
+		"object_type":        types.StringValue(change.ChangedObjectType),
+		"object_id":          types.StringValue(strconv.FormatInt(change.ChangedObjectID, 10)),
+		"object_display":     display,
+		"changed_attributes": types.ListValueMust(types.StringType, changedValues),
+		"prechange_data":     jsonValue(change.PrechangeData, "prechange_data"),
+		"postchange_data":    jsonValue(change.PostchangeData, "postchange_data"),
+	})
+	diags.Append(valueDiags...)
+	return value, diags
+}
+
+// objectChangeAction returns the value of the action of a change, which
+// NetBox returns as {"value": ..., "label": ...}.
+func objectChangeAction(raw json.RawMessage) string {
+	var choice struct {
+		Value string `json:"value"`
+	}
+	if json.Unmarshal(raw, &choice) == nil && choice.Value != "" {
+		return choice.Value
+	}
+	var value string
+	_ = json.Unmarshal(raw, &value)
+	return value
+}
+
+// changedObjectAttributes returns the sorted names of the attributes whose
+// values differ between before and after.
+func changedObjectAttributes(before, after map[string]interface{}) []string {
+	var changed []string
+	for name, value := range after {
+		if previous, ok := before[name]; !ok || !reflect.DeepEqual(previous, value) {
+			changed = append(changed, name)
+		}
+	}
+	for name := range before {
+		if _, ok := after[name]; !ok {
+			changed = append(changed, name)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
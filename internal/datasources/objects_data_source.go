@@ -0,0 +1,212 @@
+package datasources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/bab3l/go-netbox"
+	"github.com/bab3l/terraform-provider-netbox/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ datasource.DataSource              = &ObjectsDataSource{}
+	_ datasource.DataSourceWithConfigure = &ObjectsDataSource{}
+)
+
+func NewObjectsDataSource() datasource.DataSource {
+	return &ObjectsDataSource{}
+}
+
+// ObjectsDataSource queries any NetBox REST list endpoint, including plugin
+// endpoints that have no typed data source.
+type ObjectsDataSource struct {
+	client *netbox.APIClient
+}
+
+type ObjectsDataSourceModel struct {
+	Path        types.String             `tfsdk:"path"`
+	Filter      []utils.QueryFilterModel `tfsdk:"filter"`
+	Fields      types.List               `tfsdk:"fields"`
+	Brief       types.Bool               `tfsdk:"brief"`
+	IDs         types.List               `tfsdk:"ids"`
+	Results     types.Dynamic            `tfsdk:"results"`
+	ResultsJSON types.String             `tfsdk:"results_json"`
+}
+
+func (d *ObjectsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_objects"
+}
+
+func (d *ObjectsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Query any NetBox REST API list endpoint, including plugin models (e.g. `plugins/bgp/session`, `plugins/netbox-dns/zones`) that have no typed data source. Filter blocks are forwarded to the endpoint as query parameters and every page of results is returned. Multiple `filter` blocks are ANDed; values within a filter are ORed.",
+		Attributes: map[string]schema.Attribute{
+			"path": schema.StringAttribute{
+				MarkdownDescription: "API path of the list endpoint relative to `/api/`, for example `dcim/sites` or `plugins/bgp/session`. A leading `/api/` and surrounding slashes are ignored.",
+				Required:            true,
+			},
+			"fields": schema.ListAttribute{
+				MarkdownDescription: "Optional list of fields to return for each object (sent as the NetBox `fields` query parameter). Requires NetBox 4.0 or later.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"brief": schema.BoolAttribute{
+				MarkdownDescription: "Request the brief representation of each object (sent as the NetBox `brief` query parameter).",
+				Optional:            true,
+			},
+			"ids": schema.ListAttribute{
+				MarkdownDescription: "List of IDs of the matching objects, in API order.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"results": schema.DynamicAttribute{
+				MarkdownDescription: "Matching objects as returned by the API. Each element is an object whose attributes mirror the JSON returned by NetBox, so it can be indexed and iterated like a list (e.g. `results[0].name`).",
+				Computed:            true,
+			},
+			"results_json": schema.StringAttribute{
+				MarkdownDescription: "Matching objects as a raw JSON array, for use with `jsondecode()`.",
+				Computed:            true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"filter": schema.SetNestedBlock{
+				MarkdownDescription: "Filter criteria. When omitted, every object of the endpoint is returned.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Filter key name as accepted by the NetBox API endpoint (e.g. `name`, `name__ic`, `status`, `tag`, `q`, `cf_<name>`).",
+							Required:            true,
+						},
+						"values": schema.ListAttribute{
+							MarkdownDescription: "List of values for this filter.",
+							Required:            true,
+							ElementType:         types.StringType,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *ObjectsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*netbox.APIClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *netbox.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.client = client
+}
+
+func (d *ObjectsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ObjectsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	apiPath := utils.NormalizeAPIPath(data.Path.ValueString())
+	if apiPath == "" {
+		resp.Diagnostics.AddError("Invalid path", "`path` must reference a NetBox API list endpoint, for example `dcim/sites`.")
+		return
+	}
+
+	filters, filterDiags := utils.ExpandQueryFilters(ctx, data.Filter)
+	resp.Diagnostics.Append(filterDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	query := utils.QueryFiltersToValues(filters)
+	resp.Diagnostics.Append(d.applyFieldSelection(ctx, &data, query)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Querying NetBox objects", map[string]interface{}{
+		"path":  apiPath,
+		"query": query.Encode(),
+	})
+
+	rawResults, httpResp, err := utils.ListRawAPIObjects(ctx, d.client, apiPath, query)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error querying objects",
+			utils.FormatAPIError(fmt.Sprintf("list objects at %q", apiPath), err, httpResp),
+		)
+		return
+	}
+
+	ids := make([]string, 0, len(rawResults))
+	for _, raw := range rawResults {
+		var meta struct {
+			ID json.Number `json:"id"`
+		}
+		if err := json.Unmarshal(raw, &meta); err == nil && meta.ID != "" {
+			ids = append(ids, meta.ID.String())
+		}
+	}
+
+	resultsJSON, err := json.Marshal(rawResults)
+	if err != nil {
+		resp.Diagnostics.AddError("Error encoding results", err.Error())
+		return
+	}
+	results, err := utils.JSONToDynamic(resultsJSON)
+	if err != nil {
+		resp.Diagnostics.AddError("Unexpected API response", fmt.Sprintf("Could not decode objects returned by %q: %s", apiPath, err))
+		return
+	}
+
+	idsValue, idDiags := types.ListValueFrom(ctx, types.StringType, ids)
+	resp.Diagnostics.Append(idDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.IDs = idsValue
+	data.Results = results
+	data.ResultsJSON = types.StringValue(string(resultsJSON))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// applyFieldSelection adds the `fields` and `brief` query parameters.
+func (d *ObjectsDataSource) applyFieldSelection(ctx context.Context, data *ObjectsDataSourceModel, query url.Values) (diags diag.Diagnostics) {
+	if !data.Fields.IsNull() && !data.Fields.IsUnknown() {
+		var fields []string
+		diags.Append(data.Fields.ElementsAs(ctx, &fields, false)...)
+		if diags.HasError() {
+			return diags
+		}
+		selected := make([]string, 0, len(fields))
+		for _, f := range fields {
+			if f = strings.TrimSpace(f); f != "" {
+				selected = append(selected, f)
+			}
+		}
+		if len(selected) > 0 {
+			query.Set("fields", strings.Join(selected, ","))
+		}
+	}
+
+	if !data.Brief.IsNull() && !data.Brief.IsUnknown() && data.Brief.ValueBool() {
+		query.Set("brief", strconv.FormatBool(true))
+	}
+	return diags
+}
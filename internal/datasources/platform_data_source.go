@@ -10,6 +10,7 @@ import (
 	"github.com/bab3l/terraform-provider-netbox/internal/utils"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -125,6 +126,15 @@ func (d *PlatformDataSource) Read(ctx context.Context, req datasource.ReadReques
 		resp.Diagnostics.AddError("Platform Not Found", "No platform found with the specified identifier.")
 		return
 	}
+	d.mapResponseToState(ctx, platform, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// mapResponseToState maps a platform from the API to the data source model.
+func (d *PlatformDataSource) mapResponseToState(ctx context.Context, platform *netbox.Platform, data *PlatformDataSourceModel, diags *diag.Diagnostics) {
 	data.ID = types.StringValue(fmt.Sprintf("%d", platform.GetId()))
 
 	// Display Name
@@ -150,7 +160,5 @@ func (d *PlatformDataSource) Read(ctx context.Context, req datasource.ReadReques
 	}
 
 	// Handle custom fields
-	data.CustomFields = utils.CustomFieldsSetFromAPI(ctx, platform.HasCustomFields(), platform.GetCustomFields(), &resp.Diagnostics)
-
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	data.CustomFields = utils.CustomFieldsSetFromAPI(ctx, platform.HasCustomFields(), platform.GetCustomFields(), diags)
 }
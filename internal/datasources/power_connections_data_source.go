@@ -0,0 +1,423 @@
+package datasources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+
+	"github.com/bab3l/go-netbox"
+	"github.com/bab3l/terraform-provider-netbox/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ datasource.DataSource              = &PowerConnectionsDataSource{}
+	_ datasource.DataSourceWithConfigure = &PowerConnectionsDataSource{}
+)
+
+// Content types of the power components that can be cabled to each other.
+const (
+	powerPortObjectType   = "dcim.powerport"
+	powerOutletObjectType = "dcim.poweroutlet"
+)
+
+func NewPowerConnectionsDataSource() datasource.DataSource {
+	return &PowerConnectionsDataSource{}
+}
+
+// PowerConnectionsDataSource lists the power outlet to power port
+// connections of a device or rack.
+type PowerConnectionsDataSource struct {
+	client *netbox.APIClient
+}
+
+type PowerConnectionsDataSourceModel struct {
+	DeviceID      types.String `tfsdk:"device_id"`
+	RackID        types.String `tfsdk:"rack_id"`
+	Connections   types.List   `tfsdk:"connections"`
+	AllocatedDraw types.Int64  `tfsdk:"allocated_draw"`
+	MaximumDraw   types.Int64  `tfsdk:"maximum_draw"`
+}
+
+var powerConnectionAttrTypes = map[string]attr.Type{
+	"outlet_id":        types.StringType,
+	"outlet":           types.StringType,
+	"outlet_device_id": types.StringType,
+	"outlet_device":    types.StringType,
+	"feed_leg":         types.StringType,
+	"cable_id":         types.StringType,
+	"power_port_id":    types.StringType,
+	"power_port":       types.StringType,
+	"device_id":        types.StringType,
+	"device":           types.StringType,
+	"allocated_draw":   types.Int64Type,
+	"maximum_draw":     types.Int64Type,
+}
+
+func (d *PowerConnectionsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_power_connections"
+}
+
+func (d *PowerConnectionsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Use this data source to list the power outlet to power port connections of a device or rack in NetBox. A connection is included when either its outlet or its power port belongs to the device or rack, so both the PDU side and the consumer side are covered. Use `netbox_power_feed_utilization` for the load on power feeds.",
+		Attributes: map[string]schema.Attribute{
+			"device_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the device, e.g. a PDU or a server. Exactly one of `device_id` and `rack_id` must be set.",
+				Optional:            true,
+			},
+			"rack_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the rack. Exactly one of `device_id` and `rack_id` must be set.",
+				Optional:            true,
+			},
+			"connections": schema.ListNestedAttribute{
+				MarkdownDescription: "Outlet to power port connections, ordered by outlet ID and then power port ID.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"outlet_id": schema.StringAttribute{
+							MarkdownDescription: "ID of the power outlet.",
+							Computed:            true,
+						},
+						"outlet": schema.StringAttribute{
+							MarkdownDescription: "Name of the power outlet.",
+							Computed:            true,
+						},
+						"outlet_device_id": schema.StringAttribute{
+							MarkdownDescription: "ID of the device the outlet belongs to, typically a PDU.",
+							Computed:            true,
+						},
+						"outlet_device": schema.StringAttribute{
+							MarkdownDescription: "Name of the device the outlet belongs to.",
+							Computed:            true,
+						},
+						"feed_leg": schema.StringAttribute{
+							MarkdownDescription: "Phase leg of the outlet (`A`, `B` or `C`), if set.",
+							Computed:            true,
+						},
+						"cable_id": schema.StringAttribute{
+							MarkdownDescription: "ID of the cable connecting the outlet and the power port.",
+							Computed:            true,
+						},
+						"power_port_id": schema.StringAttribute{
+							MarkdownDescription: "ID of the power port.",
+							Computed:            true,
+						},
+						"power_port": schema.StringAttribute{
+							MarkdownDescription: "Name of the power port.",
+							Computed:            true,
+						},
+						"device_id": schema.StringAttribute{
+							MarkdownDescription: "ID of the device the power port belongs to.",
+							Computed:            true,
+						},
+						"device": schema.StringAttribute{
+							MarkdownDescription: "Name of the device the power port belongs to.",
+							Computed:            true,
+						},
+						"allocated_draw": schema.Int64Attribute{
+							MarkdownDescription: "Allocated draw of the power port in watts, if set.",
+							Computed:            true,
+						},
+						"maximum_draw": schema.Int64Attribute{
+							MarkdownDescription: "Maximum draw of the power port in watts, if set.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"allocated_draw": schema.Int64Attribute{
+				MarkdownDescription: "Sum of the allocated draw of all connected power ports, in watts.",
+				Computed:            true,
+			},
+			"maximum_draw": schema.Int64Attribute{
+				MarkdownDescription: "Sum of the maximum draw of all connected power ports, in watts.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *PowerConnectionsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*netbox.APIClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *netbox.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.client = client
+}
+
+func (d *PowerConnectionsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data PowerConnectionsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var filter, value string
+	switch {
+	case utils.IsSet(data.DeviceID) && utils.IsSet(data.RackID):
+		resp.Diagnostics.AddError("Conflicting identifiers", "Only one of `device_id` and `rack_id` can be specified")
+		return
+	case utils.IsSet(data.DeviceID):
+		filter, value = "device_id", data.DeviceID.ValueString()
+	case utils.IsSet(data.RackID):
+		filter, value = "rack_id", data.RackID.ValueString()
+	default:
+		resp.Diagnostics.AddError("Missing identifier", "You must specify either `device_id` or `rack_id`")
+		return
+	}
+	if _, err := utils.ParseID(value); err != nil {
+		resp.Diagnostics.AddError("Invalid ID", fmt.Sprintf("%s must be a number, got: %s", filter, value))
+		return
+	}
+	tflog.Debug(ctx, "Reading power connections", map[string]interface{}{filter: value})
+
+	connections := readPowerConnections(ctx, d.client, url.Values{filter: {value}, "cabled": {"true"}}, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var allocated, maximum int64
+	for _, connection := range connections {
+		allocated += powerDraw(connection.Port.AllocatedDraw)
+		maximum += powerDraw(connection.Port.MaximumDraw)
+	}
+	data.Connections = powerConnectionsToList(connections, &resp.Diagnostics)
+	data.AllocatedDraw = types.Int64Value(allocated)
+	data.MaximumDraw = types.Int64Value(maximum)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// powerComponent mirrors the fields of power ports, power outlets and power
+// feeds used to follow the power chain.
+type powerComponent struct {
+	ID     int64  `json:"id"`
+	Name   string `json:"name"`
+	Device *struct {
+		ID      int64   `json:"id"`
+		Name    *string `json:"name"`
+		Display string  `json:"display"`
+	} `json:"device"`
+	AllocatedDraw *int64 `json:"allocated_draw"`
+	MaximumDraw   *int64 `json:"maximum_draw"`
+	FeedLeg       *struct {
+		Value string `json:"value"`
+	} `json:"feed_leg"`
+	PowerPort *struct {
+		ID int64 `json:"id"`
+	} `json:"power_port"`
+	Cable *struct {
+		ID int64 `json:"id"`
+	} `json:"cable"`
+	LinkPeers []struct {
+		ID int64 `json:"id"`
+	} `json:"link_peers"`
+	LinkPeersType      *string `json:"link_peers_type"`
+	ConnectedEndpoints []struct {
+		ID int64 `json:"id"`
+	} `json:"connected_endpoints"`
+	ConnectedEndpointsType *string `json:"connected_endpoints_type"`
+}
+
+// DeviceName returns the name of the device the component belongs to, or its
+// display name for unnamed devices.
+func (c powerComponent) DeviceName() string {
+	if c.Device == nil {
+		return ""
+	}
+	if c.Device.Name != nil && *c.Device.Name != "" {
+		return *c.Device.Name
+	}
+	return c.Device.Display
+}
+
+// peers returns the IDs of the objects of the given type cabled to the component.
+func (c powerComponent) peers(objectType string) []int64 {
+	if c.LinkPeersType == nil || *c.LinkPeersType != objectType {
+		return nil
+	}
+	ids := make([]int64, 0, len(c.LinkPeers))
+	for _, peer := range c.LinkPeers {
+		ids = append(ids, peer.ID)
+	}
+	return ids
+}
+
+// powerDraw returns a draw value in watts, treating unset values as zero.
+func powerDraw(value *int64) int64 {
+	if value == nil {
+		return 0
+	}
+	return *value
+}
+
+// listPowerComponents lists the power components at apiPath matching query.
+func listPowerComponents(ctx context.Context, client *netbox.APIClient, apiPath string, query url.Values, diags *diag.Diagnostics) []powerComponent {
+	results, httpResp, err := utils.ListRawAPIObjects(ctx, client, apiPath, query)
+	if err != nil {
+		diags.AddError("Error reading power components", utils.FormatAPIError(fmt.Sprintf("list %s", apiPath), err, httpResp))
+		return nil
+	}
+
+	components := make([]powerComponent, 0, len(results))
+	for _, raw := range results {
+		var component powerComponent
+		if err := json.Unmarshal(raw, &component); err != nil {
+			diags.AddError("Error reading power components", fmt.Sprintf("Could not decode %s object: %s", apiPath, err))
+			return nil
+		}
+		components = append(components, component)
+	}
+	return components
+}
+
+// listPowerComponentsByID returns the power components at apiPath with the
+// given IDs, keyed by ID.
+func listPowerComponentsByID(ctx context.Context, client *netbox.APIClient, apiPath string, ids []int64, diags *diag.Diagnostics) map[int64]powerComponent {
+	byID := make(map[int64]powerComponent, len(ids))
+	if len(ids) == 0 {
+		return byID
+	}
+	query := url.Values{}
+	for _, id := range ids {
+		query.Add("id", strconv.FormatInt(id, 10))
+	}
+	for _, component := range listPowerComponents(ctx, client, apiPath, query, diags) {
+		byID[component.ID] = component
+	}
+	return byID
+}
+
+type powerConnection struct {
+	Outlet powerComponent
+	Port   powerComponent
+}
+
+// readPowerConnections returns the outlet to power port connections in which
+// the outlet or the power port matches query.
+func readPowerConnections(ctx context.Context, client *netbox.APIClient, query url.Values, diags *diag.Diagnostics) []powerConnection {
+	outlets := map[int64]powerComponent{}
+	for _, outlet := range listPowerComponents(ctx, client, "dcim/power-outlets", query, diags) {
+		outlets[outlet.ID] = outlet
+	}
+	ports := map[int64]powerComponent{}
+	for _, port := range listPowerComponents(ctx, client, "dcim/power-ports", query, diags) {
+		ports[port.ID] = port
+	}
+	if diags.HasError() {
+		return nil
+	}
+
+	// Fetch the far end of connections that cross the device or rack boundary.
+	var missingOutlets, missingPorts []int64
+	for _, outlet := range outlets {
+		for _, id := range outlet.peers(powerPortObjectType) {
+			if _, ok := ports[id]; !ok {
+				missingPorts = append(missingPorts, id)
+			}
+		}
+	}
+	for _, port := range ports {
+		for _, id := range port.peers(powerOutletObjectType) {
+			if _, ok := outlets[id]; !ok {
+				missingOutlets = append(missingOutlets, id)
+			}
+		}
+	}
+	for id, outlet := range listPowerComponentsByID(ctx, client, "dcim/power-outlets", missingOutlets, diags) {
+		outlets[id] = outlet
+	}
+	for id, port := range listPowerComponentsByID(ctx, client, "dcim/power-ports", missingPorts, diags) {
+		ports[id] = port
+	}
+	if diags.HasError() {
+		return nil
+	}
+
+	var connections []powerConnection
+	for _, outlet := range outlets {
+		for _, id := range outlet.peers(powerPortObjectType) {
+			if port, ok := ports[id]; ok {
+				connections = append(connections, powerConnection{Outlet: outlet, Port: port})
+			}
+		}
+	}
+	sort.Slice(connections, func(i, j int) bool {
+		if connections[i].Outlet.ID != connections[j].Outlet.ID {
+			return connections[i].Outlet.ID < connections[j].Outlet.ID
+		}
+		return connections[i].Port.ID < connections[j].Port.ID
+	})
+	return connections
+}
+
+func powerConnectionsToList(connections []powerConnection, diags *diag.Diagnostics) types.List {
+	objectType := types.ObjectType{AttrTypes: powerConnectionAttrTypes}
+	id := func(id int64) types.String {
+		return types.StringValue(strconv.FormatInt(id, 10))
+	}
+	optionalDraw := func(value *int64) types.Int64 {
+		if value == nil {
+			return types.Int64Null()
+		}
+		return types.Int64Value(*value)
+	}
+
+	values := make([]attr.Value, 0, len(connections))
+	for _, connection := range connections {
+		outlet, port := connection.Outlet, connection.Port
+		attrs := map[string]attr.Value{
+			"outlet_id":        id(outlet.ID),
+			"outlet":           types.StringValue(outlet.Name),
+			"outlet_device_id": types.StringNull(),
+			"outlet_device":    types.StringNull(),
+			"feed_leg":         types.StringNull(),
+			"cable_id":         types.StringNull(),
+			"power_port_id":    id(port.ID),
+			"power_port":       types.StringValue(port.Name),
+			"device_id":        types.StringNull(),
+			"device":           types.StringNull(),
+			"allocated_draw":   optionalDraw(port.AllocatedDraw),
+			"maximum_draw":     optionalDraw(port.MaximumDraw),
+		}
+		if outlet.Device != nil {
+			attrs["outlet_device_id"] = id(outlet.Device.ID)
+			attrs["outlet_device"] = types.StringValue(outlet.DeviceName())
+		}
+		if outlet.FeedLeg != nil && outlet.FeedLeg.Value != "" {
+			attrs["feed_leg"] = types.StringValue(outlet.FeedLeg.Value)
+		}
+		if outlet.Cable != nil {
+			attrs["cable_id"] = id(outlet.Cable.ID)
+		}
+		if port.Device != nil {
+			attrs["device_id"] = id(port.Device.ID)
+			attrs["device"] = types.StringValue(port.DeviceName())
+		}
+		value, valueDiags := types.ObjectValue(powerConnectionAttrTypes, attrs)
+		diags.Append(valueDiags...)
+		values = append(values, value)
+	}
+
+	list, listDiags := types.ListValue(objectType, values)
+	diags.Append(listDiags...)
+	return list
+}
@@ -0,0 +1,524 @@
+package datasources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+
+	"github.com/bab3l/go-netbox"
+	"github.com/bab3l/terraform-provider-netbox/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ datasource.DataSource              = &PowerFeedUtilizationDataSource{}
+	_ datasource.DataSourceWithConfigure = &PowerFeedUtilizationDataSource{}
+)
+
+// powerFeedLegs lists the legs of a three-phase power feed.
+var powerFeedLegs = []string{"A", "B", "C"}
+
+const powerFeedThreePhase = "three-phase"
+
+func NewPowerFeedUtilizationDataSource() datasource.DataSource {
+	return &PowerFeedUtilizationDataSource{}
+}
+
+// PowerFeedUtilizationDataSource computes the allocated and maximum draw of
+// power feeds from the power ports connected downstream of them.
+type PowerFeedUtilizationDataSource struct {
+	client *netbox.APIClient
+}
+
+type PowerFeedUtilizationDataSourceModel struct {
+	PowerFeedID    types.String  `tfsdk:"power_feed_id"`
+	PowerPanelID   types.String  `tfsdk:"power_panel_id"`
+	RackID         types.String  `tfsdk:"rack_id"`
+	Feeds          types.List    `tfsdk:"feeds"`
+	Racks          types.List    `tfsdk:"racks"`
+	AvailablePower types.Int64   `tfsdk:"available_power"`
+	AllocatedDraw  types.Int64   `tfsdk:"allocated_draw"`
+	MaximumDraw    types.Int64   `tfsdk:"maximum_draw"`
+	Utilization    types.Float64 `tfsdk:"utilization"`
+}
+
+var powerLoadAttrTypes = map[string]attr.Type{
+	"available_power": types.Int64Type,
+	"allocated_draw":  types.Int64Type,
+	"maximum_draw":    types.Int64Type,
+	"utilization":     types.Float64Type,
+}
+
+var powerFeedLegAttrTypes = withPowerLoadAttrTypes(map[string]attr.Type{
+	"name": types.StringType,
+})
+
+var powerRackLoadAttrTypes = withPowerLoadAttrTypes(map[string]attr.Type{
+	"rack_id": types.StringType,
+})
+
+var powerFeedLoadAttrTypes = withPowerLoadAttrTypes(map[string]attr.Type{
+	"id":              types.StringType,
+	"name":            types.StringType,
+	"power_panel_id":  types.StringType,
+	"rack_id":         types.StringType,
+	"status":          types.StringType,
+	"phase":           types.StringType,
+	"voltage":         types.Int64Type,
+	"amperage":        types.Int64Type,
+	"max_utilization": types.Int64Type,
+	"power_port_ids":  types.ListType{ElemType: types.StringType},
+	"legs":            types.ListType{ElemType: types.ObjectType{AttrTypes: powerFeedLegAttrTypes}},
+})
+
+func withPowerLoadAttrTypes(attrTypes map[string]attr.Type) map[string]attr.Type {
+	for name, attrType := range powerLoadAttrTypes {
+		attrTypes[name] = attrType
+	}
+	return attrTypes
+}
+
+func (d *PowerFeedUtilizationDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_power_feed_utilization"
+}
+
+func (d *PowerFeedUtilizationDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	loadAttributes := func(scope string, attributes map[string]schema.Attribute) map[string]schema.Attribute {
+		attributes["available_power"] = schema.Int64Attribute{
+			MarkdownDescription: fmt.Sprintf("Power available to %s in VA, after the feed's maximum utilization is applied.", scope),
+			Computed:            true,
+		}
+		attributes["allocated_draw"] = schema.Int64Attribute{
+			MarkdownDescription: fmt.Sprintf("Allocated draw of the power ports connected downstream of %s, in watts.", scope),
+			Computed:            true,
+		}
+		attributes["maximum_draw"] = schema.Int64Attribute{
+			MarkdownDescription: fmt.Sprintf("Maximum draw of the power ports connected downstream of %s, in watts.", scope),
+			Computed:            true,
+		}
+		attributes["utilization"] = schema.Float64Attribute{
+			MarkdownDescription: fmt.Sprintf("Allocated draw of %s as a percentage of its available power. `0` when no power is available.", scope),
+			Computed:            true,
+		}
+		return attributes
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Use this data source to compute the load on power feeds in NetBox, the same way NetBox does on the power feed page. " +
+			"The draw of a feed is taken from the power port it is cabled to (typically a PDU inlet): the port's own `allocated_draw` and `maximum_draw` when either is set, " +
+			"otherwise the sum of the power ports cabled to the outlets fed by that port, split by `feed_leg` on three-phase feeds. " +
+			"Select feeds with exactly one of `power_feed_id`, `power_panel_id` and `rack_id`, and use the results in preconditions to fail plans that would overload a feed.",
+		Attributes: loadAttributes("all selected feeds", map[string]schema.Attribute{
+			"power_feed_id": schema.StringAttribute{
+				MarkdownDescription: "ID of a single power feed.",
+				Optional:            true,
+			},
+			"power_panel_id": schema.StringAttribute{
+				MarkdownDescription: "ID of a power panel. All feeds of the panel are selected.",
+				Optional:            true,
+			},
+			"rack_id": schema.StringAttribute{
+				MarkdownDescription: "ID of a rack. All feeds supplying the rack are selected.",
+				Optional:            true,
+			},
+			"feeds": schema.ListNestedAttribute{
+				MarkdownDescription: "Load of each selected feed, ordered by ID.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: loadAttributes("the feed", map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "ID of the power feed.",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Name of the power feed.",
+							Computed:            true,
+						},
+						"power_panel_id": schema.StringAttribute{
+							MarkdownDescription: "ID of the power panel the feed belongs to.",
+							Computed:            true,
+						},
+						"rack_id": schema.StringAttribute{
+							MarkdownDescription: "ID of the rack the feed supplies, if any.",
+							Computed:            true,
+						},
+						"status": schema.StringAttribute{
+							MarkdownDescription: "Status of the power feed.",
+							Computed:            true,
+						},
+						"phase": schema.StringAttribute{
+							MarkdownDescription: "Phase of the power feed: `single-phase` or `three-phase`.",
+							Computed:            true,
+						},
+						"voltage": schema.Int64Attribute{
+							MarkdownDescription: "Voltage of the power feed.",
+							Computed:            true,
+						},
+						"amperage": schema.Int64Attribute{
+							MarkdownDescription: "Amperage of the power feed.",
+							Computed:            true,
+						},
+						"max_utilization": schema.Int64Attribute{
+							MarkdownDescription: "Maximum permissible utilization of the power feed, in percent.",
+							Computed:            true,
+						},
+						"power_port_ids": schema.ListAttribute{
+							MarkdownDescription: "IDs of the power ports cabled to the feed.",
+							Computed:            true,
+							ElementType:         types.StringType,
+						},
+						"legs": schema.ListNestedAttribute{
+							MarkdownDescription: "Load on each leg (`A`, `B` and `C`) of a three-phase feed. Each leg has a third of the feed's available power. Empty for single-phase feeds and for feeds whose power port sets its own draw.",
+							Computed:            true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: loadAttributes("the leg", map[string]schema.Attribute{
+									"name": schema.StringAttribute{
+										MarkdownDescription: "Name of the leg.",
+										Computed:            true,
+									},
+								}),
+							},
+						},
+					}),
+				},
+			},
+			"racks": schema.ListNestedAttribute{
+				MarkdownDescription: "Load of the selected feeds summed per rack, ordered by rack ID. Redundant feeds are included in the sum. Feeds without a rack are not included.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: loadAttributes("the rack's feeds", map[string]schema.Attribute{
+						"rack_id": schema.StringAttribute{
+							MarkdownDescription: "ID of the rack.",
+							Computed:            true,
+						},
+					}),
+				},
+			},
+		}),
+	}
+}
+
+func (d *PowerFeedUtilizationDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*netbox.APIClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *netbox.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.client = client
+}
+
+func (d *PowerFeedUtilizationDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data PowerFeedUtilizationDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	query := url.Values{}
+	for filter, value := range map[string]types.String{"id": data.PowerFeedID, "power_panel_id": data.PowerPanelID, "rack_id": data.RackID} {
+		if !utils.IsSet(value) {
+			continue
+		}
+		if _, err := utils.ParseID(value.ValueString()); err != nil {
+			resp.Diagnostics.AddError("Invalid ID", fmt.Sprintf("ID must be a number, got: %s", value.ValueString()))
+			return
+		}
+		query.Set(filter, value.ValueString())
+	}
+	if len(query) == 0 {
+		resp.Diagnostics.AddError("Missing identifier", "You must specify one of `power_feed_id`, `power_panel_id` and `rack_id`")
+		return
+	}
+	if len(query) > 1 {
+		resp.Diagnostics.AddError("Conflicting identifiers", "Only one of `power_feed_id`, `power_panel_id` and `rack_id` can be specified")
+		return
+	}
+	tflog.Debug(ctx, "Reading power feed utilization", map[string]interface{}{"query": query.Encode()})
+
+	feeds := readPowerFeedLoads(ctx, d.client, query, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if utils.IsSet(data.PowerFeedID) && len(feeds) == 0 {
+		resp.Diagnostics.AddError("Power Feed Not Found", fmt.Sprintf("No power feed found with ID: %s", data.PowerFeedID.ValueString()))
+		return
+	}
+
+	var total powerLoad
+	racks := map[int64]*powerLoad{}
+	for _, feed := range feeds {
+		total.add(feed.powerLoad)
+		if feed.Rack != nil {
+			if racks[feed.Rack.ID] == nil {
+				racks[feed.Rack.ID] = &powerLoad{}
+			}
+			racks[feed.Rack.ID].add(feed.powerLoad)
+		}
+	}
+
+	data.Feeds = powerFeedLoadsToList(feeds, &resp.Diagnostics)
+	data.Racks = powerRackLoadsToList(racks, &resp.Diagnostics)
+	data.AvailablePower = types.Int64Value(total.Available)
+	data.AllocatedDraw = types.Int64Value(total.Allocated)
+	data.MaximumDraw = types.Int64Value(total.Maximum)
+	data.Utilization = types.Float64Value(total.utilization())
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// powerLoad is the available power and the draw on a feed, leg or rack.
+type powerLoad struct {
+	Available int64
+	Allocated int64
+	Maximum   int64
+}
+
+func (l *powerLoad) add(other powerLoad) {
+	l.Available += other.Available
+	l.Allocated += other.Allocated
+	l.Maximum += other.Maximum
+}
+
+// utilization returns the allocated draw as a percentage of available power.
+func (l powerLoad) utilization() float64 {
+	if l.Available <= 0 {
+		return 0
+	}
+	return float64(l.Allocated) * 100 / float64(l.Available)
+}
+
+func (l powerLoad) attrs(attrs map[string]attr.Value) map[string]attr.Value {
+	attrs["available_power"] = types.Int64Value(l.Available)
+	attrs["allocated_draw"] = types.Int64Value(l.Allocated)
+	attrs["maximum_draw"] = types.Int64Value(l.Maximum)
+	attrs["utilization"] = types.Float64Value(l.utilization())
+	return attrs
+}
+
+// powerFeed mirrors the fields of a NetBox power feed used to compute its load.
+type powerFeed struct {
+	powerComponent
+	PowerPanel *struct {
+		ID int64 `json:"id"`
+	} `json:"power_panel"`
+	Rack *struct {
+		ID int64 `json:"id"`
+	} `json:"rack"`
+	Status *struct {
+		Value string `json:"value"`
+	} `json:"status"`
+	Phase *struct {
+		Value string `json:"value"`
+	} `json:"phase"`
+	Voltage        int64 `json:"voltage"`
+	Amperage       int64 `json:"amperage"`
+	MaxUtilization int64 `json:"max_utilization"`
+	AvailablePower int64 `json:"available_power"`
+}
+
+type powerFeedLoad struct {
+	powerFeed
+	powerLoad
+	PortIDs []int64
+	// Legs holds the load per leg of three-phase feeds, keyed by leg name.
+	Legs map[string]powerLoad
+}
+
+// readPowerFeedLoads returns the load of the power feeds matching query.
+func readPowerFeedLoads(ctx context.Context, client *netbox.APIClient, query url.Values, diags *diag.Diagnostics) []powerFeedLoad {
+	results, httpResp, err := utils.ListRawAPIObjects(ctx, client, "dcim/power-feeds", query)
+	if err != nil {
+		diags.AddError("Error reading power feeds", utils.FormatAPIError("list power feeds", err, httpResp))
+		return nil
+	}
+	feeds := make([]powerFeedLoad, 0, len(results))
+	var portIDs []int64
+	for _, raw := range results {
+		var feed powerFeed
+		if err := json.Unmarshal(raw, &feed); err != nil {
+			diags.AddError("Error reading power feeds", fmt.Sprintf("Could not decode power feed: %s", err))
+			return nil
+		}
+		load := powerFeedLoad{powerFeed: feed, PortIDs: feed.peers(powerPortObjectType)}
+		load.Available = feed.AvailablePower
+		portIDs = append(portIDs, load.PortIDs...)
+		feeds = append(feeds, load)
+	}
+
+	// Ports without their own draw are loaded by the ports cabled to the
+	// outlets they feed.
+	ports := listPowerComponentsByID(ctx, client, "dcim/power-ports", portIDs, diags)
+	outlets := map[int64][]powerComponent{}
+	var downstreamIDs []int64
+	if query := powerPortIDsQuery(ports); len(query) > 0 {
+		for _, outlet := range listPowerComponents(ctx, client, "dcim/power-outlets", query, diags) {
+			if outlet.PowerPort != nil {
+				outlets[outlet.PowerPort.ID] = append(outlets[outlet.PowerPort.ID], outlet)
+				downstreamIDs = append(downstreamIDs, outlet.peers(powerPortObjectType)...)
+			}
+		}
+	}
+	downstream := listPowerComponentsByID(ctx, client, "dcim/power-ports", downstreamIDs, diags)
+	if diags.HasError() {
+		return nil
+	}
+
+	for i := range feeds {
+		feed := &feeds[i]
+		threePhase := feed.Phase != nil && feed.Phase.Value == powerFeedThreePhase
+		if threePhase {
+			feed.Legs = map[string]powerLoad{}
+		}
+		for _, portID := range feed.PortIDs {
+			port, ok := ports[portID]
+			if !ok {
+				continue
+			}
+			if port.AllocatedDraw != nil || port.MaximumDraw != nil {
+				feed.Allocated += powerDraw(port.AllocatedDraw)
+				feed.Maximum += powerDraw(port.MaximumDraw)
+				// NetBox only splits the draw computed from outlets by leg.
+				threePhase = false
+				continue
+			}
+			for _, outlet := range outlets[portID] {
+				var draw powerLoad
+				for _, id := range outlet.peers(powerPortObjectType) {
+					draw.Allocated += powerDraw(downstream[id].AllocatedDraw)
+					draw.Maximum += powerDraw(downstream[id].MaximumDraw)
+				}
+				feed.Allocated += draw.Allocated
+				feed.Maximum += draw.Maximum
+				if threePhase && outlet.FeedLeg != nil {
+					leg := feed.Legs[outlet.FeedLeg.Value]
+					leg.add(draw)
+					feed.Legs[outlet.FeedLeg.Value] = leg
+				}
+			}
+		}
+		if !threePhase {
+			feed.Legs = nil
+			continue
+		}
+		for _, name := range powerFeedLegs {
+			leg := feed.Legs[name]
+			leg.Available = feed.Available / int64(len(powerFeedLegs))
+			feed.Legs[name] = leg
+		}
+	}
+
+	sort.Slice(feeds, func(i, j int) bool { return feeds[i].ID < feeds[j].ID })
+	return feeds
+}
+
+// powerPortIDsQuery returns a query selecting the outlets fed by the ports
+// that do not set their own draw.
+func powerPortIDsQuery(ports map[int64]powerComponent) url.Values {
+	query := url.Values{}
+	for id, port := range ports {
+		if port.AllocatedDraw == nil && port.MaximumDraw == nil {
+			query.Add("power_port_id", strconv.FormatInt(id, 10))
+		}
+	}
+	return query
+}
+
+func powerFeedLoadsToList(feeds []powerFeedLoad, diags *diag.Diagnostics) types.List {
+	legType := types.ObjectType{AttrTypes: powerFeedLegAttrTypes}
+	id := func(id int64) types.String {
+		return types.StringValue(strconv.FormatInt(id, 10))
+	}
+
+	values := make([]attr.Value, 0, len(feeds))
+	for _, feed := range feeds {
+		legs := make([]attr.Value, 0, len(feed.Legs))
+		if feed.Legs != nil {
+			for _, name := range powerFeedLegs {
+				leg, legDiags := types.ObjectValue(powerFeedLegAttrTypes, feed.Legs[name].attrs(map[string]attr.Value{
+					"name": types.StringValue(name),
+				}))
+				diags.Append(legDiags...)
+				legs = append(legs, leg)
+			}
+		}
+		legList, legDiags := types.ListValue(legType, legs)
+		diags.Append(legDiags...)
+
+		portIDs := make([]attr.Value, 0, len(feed.PortIDs))
+		for _, portID := range feed.PortIDs {
+			portIDs = append(portIDs, id(portID))
+		}
+		portIDList, portDiags := types.ListValue(types.StringType, portIDs)
+		diags.Append(portDiags...)
+
+		attrs := feed.attrs(map[string]attr.Value{
+			"id":              id(feed.ID),
+			"name":            types.StringValue(feed.Name),
+			"power_panel_id":  types.StringNull(),
+			"rack_id":         types.StringNull(),
+			"status":          types.StringNull(),
+			"phase":           types.StringNull(),
+			"voltage":         types.Int64Value(feed.Voltage),
+			"amperage":        types.Int64Value(feed.Amperage),
+			"max_utilization": types.Int64Value(feed.MaxUtilization),
+			"power_port_ids":  portIDList,
+			"legs":            legList,
+		})
+		if feed.PowerPanel != nil {
+			attrs["power_panel_id"] = id(feed.PowerPanel.ID)
+		}
+		if feed.Rack != nil {
+			attrs["rack_id"] = id(feed.Rack.ID)
+		}
+		if feed.Status != nil {
+			attrs["status"] = types.StringValue(feed.Status.Value)
+		}
+		if feed.Phase != nil {
+			attrs["phase"] = types.StringValue(feed.Phase.Value)
+		}
+		value, valueDiags := types.ObjectValue(powerFeedLoadAttrTypes, attrs)
+		diags.Append(valueDiags...)
+		values = append(values, value)
+	}
+
+	list, listDiags := types.ListValue(types.ObjectType{AttrTypes: powerFeedLoadAttrTypes}, values)
+	diags.Append(listDiags...)
+	return list
+}
+
+func powerRackLoadsToList(racks map[int64]*powerLoad, diags *diag.Diagnostics) types.List {
+	rackIDs := make([]int64, 0, len(racks))
+	for rackID := range racks {
+		rackIDs = append(rackIDs, rackID)
+	}
+	sort.Slice(rackIDs, func(i, j int) bool { return rackIDs[i] < rackIDs[j] })
+
+	values := make([]attr.Value, 0, len(rackIDs))
+	for _, rackID := range rackIDs {
+		value, valueDiags := types.ObjectValue(powerRackLoadAttrTypes, racks[rackID].attrs(map[string]attr.Value{
+			"rack_id": types.StringValue(strconv.FormatInt(rackID, 10)),
+		}))
+		diags.Append(valueDiags...)
+		values = append(values, value)
+	}
+
+	list, listDiags := types.ListValue(types.ObjectType{AttrTypes: powerRackLoadAttrTypes}, values)
+	diags.Append(listDiags...)
+	return list
+}
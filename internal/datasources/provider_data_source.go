@@ -12,6 +12,7 @@ import (
 	"github.com/bab3l/terraform-provider-netbox/internal/utils"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -177,7 +178,19 @@ func (d *ProviderDataSource) Read(ctx context.Context, req datasource.ReadReques
 		return
 	}
 
-	// Map the provider to state
+	d.mapResponseToState(ctx, provider, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "Read circuit provider", map[string]interface{}{
+		"id":   provider.GetId(),
+		"name": provider.GetName(),
+	})
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// mapResponseToState maps a circuit provider from the API to the data source model.
+func (d *ProviderDataSource) mapResponseToState(ctx context.Context, provider *netbox.Provider, data *ProviderDataSourceModel, diags *diag.Diagnostics) {
 	data.ID = types.StringValue(fmt.Sprintf("%d", provider.GetId()))
 	data.Name = types.StringValue(provider.GetName())
 	data.Slug = types.StringValue(provider.GetSlug())
@@ -200,8 +213,8 @@ func (d *ProviderDataSource) Read(ctx context.Context, req datasource.ReadReques
 	if provider.HasTags() {
 		tags := utils.NestedTagsToTagModels(provider.GetTags())
 		tagsValue, tagDiags := types.SetValueFrom(ctx, utils.GetTagsAttributeType().ElemType, tags)
-		resp.Diagnostics.Append(tagDiags...)
-		if resp.Diagnostics.HasError() {
+		diags.Append(tagDiags...)
+		if diags.HasError() {
 			return
 		}
 		data.Tags = tagsValue
@@ -210,7 +223,7 @@ func (d *ProviderDataSource) Read(ctx context.Context, req datasource.ReadReques
 	}
 
 	// Handle custom fields
-	data.CustomFields = utils.CustomFieldsSetFromAPI(ctx, provider.HasCustomFields(), provider.GetCustomFields(), &resp.Diagnostics)
+	data.CustomFields = utils.CustomFieldsSetFromAPI(ctx, provider.HasCustomFields(), provider.GetCustomFields(), diags)
 
 	// Map display_name
 	if provider.GetDisplay() != "" {
@@ -218,9 +231,4 @@ func (d *ProviderDataSource) Read(ctx context.Context, req datasource.ReadReques
 	} else {
 		data.DisplayName = types.StringNull()
 	}
-	tflog.Debug(ctx, "Read circuit provider", map[string]interface{}{
-		"id":   provider.GetId(),
-		"name": provider.GetName(),
-	})
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
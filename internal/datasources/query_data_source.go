@@ -0,0 +1,361 @@
+package datasources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/bab3l/go-netbox"
+	"github.com/bab3l/terraform-provider-netbox/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// queryDataSourceSpec describes a plural/query data source built on top of an
+// existing singular data source.
+//
+// T is the go-netbox model returned by the list endpoint and M is the singular
+// data source model. Results are decoded into T and mapped with the singular
+// data source's mapping function, so every plural data source returns exactly
+// the same attributes as its singular counterpart.
+type queryDataSourceSpec[T any, M any] struct {
+	// typeName is the data source type name without the provider prefix (e.g. "sites").
+	typeName string
+	// objectName is the human readable plural name used in descriptions (e.g. "sites").
+	objectName string
+	// apiPath is the NetBox list endpoint relative to /api/ (e.g. "dcim/sites").
+	apiPath string
+	// singular returns the singular data source whose schema describes one result.
+	singular func() datasource.DataSource
+	// mapResult maps one API object onto the singular data source model.
+	mapResult func(ctx context.Context, result *T, data *M, diags *diag.Diagnostics)
+	// values optionally adds a list of one string attribute of the results.
+	values *queryValuesSpec
+}
+
+// queryValuesSpec describes a computed list holding one string attribute of
+// every result, such as the `names` of netbox_devices.
+type queryValuesSpec struct {
+	// attribute is the name of the list attribute (e.g. "names").
+	attribute string
+	// resultAttribute is the result attribute listed (e.g. "name").
+	resultAttribute string
+	// description is the MarkdownDescription of the list attribute.
+	description string
+}
+
+// queryDataSource is a generic plural data source. Filter blocks are forwarded
+// verbatim to the NetBox list endpoint as query parameters, so any filter key
+// supported by NetBox for that endpoint can be used.
+type queryDataSource[T any, M any] struct {
+	client *netbox.APIClient
+	spec   queryDataSourceSpec[T, M]
+}
+
+func newQueryDataSource[T any, M any](spec queryDataSourceSpec[T, M]) datasource.DataSource {
+	return &queryDataSource[T, M]{spec: spec}
+}
+
+func (d *queryDataSource[T, M]) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_" + d.spec.typeName
+}
+
+// singularSchema returns the schema of the singular data source backing this query.
+func (d *queryDataSource[T, M]) singularSchema(ctx context.Context) (schema.Schema, diag.Diagnostics) {
+	resp := &datasource.SchemaResponse{}
+	d.spec.singular().Schema(ctx, datasource.SchemaRequest{}, resp)
+	return resp.Schema, resp.Diagnostics
+}
+
+func (d *queryDataSource[T, M]) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	singular, diags := d.singularSchema(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resultAttrs := make(map[string]schema.Attribute, len(singular.Attributes))
+	for name, a := range singular.Attributes {
+		resultAttrs[name] = computedAttribute(a)
+	}
+
+	attributes := map[string]schema.Attribute{
+		"ids": schema.ListAttribute{
+			MarkdownDescription: fmt.Sprintf("List of %s IDs that match the query.", d.spec.objectName),
+			Computed:            true,
+			ElementType:         types.StringType,
+		},
+		d.spec.typeName: schema.ListNestedAttribute{
+			MarkdownDescription: fmt.Sprintf("List of matching %s.", d.spec.objectName),
+			Computed:            true,
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: resultAttrs,
+			},
+		},
+	}
+	if d.spec.values != nil {
+		attributes[d.spec.values.attribute] = schema.ListAttribute{
+			MarkdownDescription: d.spec.values.description,
+			Computed:            true,
+			ElementType:         types.StringType,
+		}
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: fmt.Sprintf("Query %s in NetBox using AWS-style filter blocks. Filter names are passed through to the NetBox API as query parameters, so any filter supported by the `/api/%s/` endpoint can be used. Multiple `filter` blocks are ANDed; values within a filter are ORed. Each result contains the same attributes as the singular data source.", d.spec.objectName, d.spec.apiPath),
+		Attributes:          attributes,
+		Blocks: map[string]schema.Block{
+			"filter": queryFilterBlock("Filter key name as accepted by the NetBox API (e.g. `name`, `name__ic`, `site`, `site_id`, `status`, `tag`, `q`, `cf_<name>`). The client-side `custom_field` and `custom_field_value` filters are also supported."),
+		},
+	}
+}
+
+// queryFilterBlock returns the shared `filter` block used by query data sources.
+func queryFilterBlock(nameDescription string) schema.SetNestedBlock {
+	return schema.SetNestedBlock{
+		MarkdownDescription: "Filter criteria. At least one filter must be provided.",
+		NestedObject: schema.NestedBlockObject{
+			Attributes: map[string]schema.Attribute{
+				"name": schema.StringAttribute{
+					MarkdownDescription: nameDescription,
+					Required:            true,
+				},
+				"values": schema.ListAttribute{
+					MarkdownDescription: "List of values for this filter.",
+					Required:            true,
+					ElementType:         types.StringType,
+				},
+			},
+		},
+	}
+}
+
+func (d *queryDataSource[T, M]) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*netbox.APIClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *netbox.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.client = client
+}
+
+func (d *queryDataSource[T, M]) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	// The results attribute is named after the data source (e.g. `sites`), so
+	// the configuration and state are accessed by path rather than a static model.
+	var filterModels []utils.QueryFilterModel
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("filter"), &filterModels)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filters, filterDiags := utils.ExpandQueryFilters(ctx, filterModels)
+	resp.Diagnostics.Append(filterDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if len(filters) == 0 {
+		resp.Diagnostics.AddError(
+			"Missing filters",
+			fmt.Sprintf("At least one `filter` block must be provided to avoid accidentally listing all %s.", d.spec.objectName),
+		)
+		return
+	}
+
+	customFieldExists := filters[filterKeyCustomField]
+	customFieldValueRaw := filters[filterKeyCustomFieldValue]
+	delete(filters, filterKeyCustomField)
+	delete(filters, filterKeyCustomFieldValue)
+
+	customFieldValueFilters, valueDiags := utils.ParseCustomFieldValueFilters(customFieldValueRaw)
+	resp.Diagnostics.Append(valueDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Querying "+d.spec.objectName, map[string]interface{}{
+		"path":    d.spec.apiPath,
+		"filters": filters,
+	})
+
+	rawResults, httpResp, err := utils.ListRawAPIObjects(ctx, d.client, d.spec.apiPath, utils.QueryFiltersToValues(filters))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error querying "+d.spec.objectName,
+			utils.FormatAPIError("list "+d.spec.objectName, err, httpResp),
+		)
+		return
+	}
+
+	singular, schemaDiags := d.singularSchema(ctx)
+	resp.Diagnostics.Append(schemaDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	objectType, ok := singular.Type().(types.ObjectType)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected schema type", fmt.Sprintf("Expected object schema for %s, got %T", d.spec.typeName, singular.Type()))
+		return
+	}
+
+	ids := make([]string, 0, len(rawResults))
+	values := make([]string, 0, len(rawResults))
+	results := make([]attr.Value, 0, len(rawResults))
+	for _, raw := range rawResults {
+		var result T
+		if err := json.Unmarshal(raw, &result); err != nil {
+			resp.Diagnostics.AddError(
+				"Unexpected API response",
+				fmt.Sprintf("Could not decode %s result: %s", d.spec.objectName, err),
+			)
+			return
+		}
+
+		if len(customFieldExists) > 0 || len(customFieldValueFilters) > 0 {
+			var meta struct {
+				CustomFields map[string]interface{} `json:"custom_fields"`
+			}
+			_ = json.Unmarshal(raw, &meta)
+			if !utils.MatchesCustomFieldFilters(meta.CustomFields, customFieldExists, customFieldValueFilters) {
+				continue
+			}
+		}
+
+		model, modelDiags := newNullModel[M](ctx, objectType)
+		resp.Diagnostics.Append(modelDiags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		d.spec.mapResult(ctx, &result, model, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		obj, objDiags := types.ObjectValueFrom(ctx, objectType.AttrTypes, model)
+		resp.Diagnostics.Append(objDiags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		switch id := obj.Attributes()["id"].(type) {
+		case types.String:
+			ids = append(ids, id.ValueString())
+		case types.Int64:
+			ids = append(ids, fmt.Sprintf("%d", id.ValueInt64()))
+		case types.Int32:
+			ids = append(ids, fmt.Sprintf("%d", id.ValueInt32()))
+		}
+		if d.spec.values != nil {
+			value, _ := obj.Attributes()[d.spec.values.resultAttribute].(types.String)
+			values = append(values, value.ValueString())
+		}
+		results = append(results, obj)
+	}
+
+	idsValue, idDiags := types.ListValueFrom(ctx, types.StringType, ids)
+	resp.Diagnostics.Append(idDiags...)
+	resultsValue, resultsDiags := types.ListValue(objectType, results)
+	resp.Diagnostics.Append(resultsDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("filter"), filterModels)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("ids"), idsValue)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(d.spec.typeName), resultsValue)...)
+	if d.spec.values != nil {
+		valuesValue, valueDiags := types.ListValueFrom(ctx, types.StringType, values)
+		resp.Diagnostics.Append(valueDiags...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(d.spec.values.attribute), valuesValue)...)
+	}
+}
+
+// newNullModel returns a model whose attributes are all typed null values,
+// mirroring what the singular data source receives from its configuration.
+func newNullModel[M any](ctx context.Context, objectType types.ObjectType) (*M, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	values := make(map[string]attr.Value, len(objectType.AttrTypes))
+	for name, t := range objectType.AttrTypes {
+		v, err := t.ValueFromTerraform(ctx, tftypes.NewValue(t.TerraformType(ctx), nil))
+		if err != nil {
+			diags.AddError("Error building result model", fmt.Sprintf("Could not create null value for %q: %s", name, err))
+			return nil, diags
+		}
+		values[name] = v
+	}
+	obj, objDiags := types.ObjectValue(objectType.AttrTypes, values)
+	diags.Append(objDiags...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	var model M
+	diags.Append(obj.As(ctx, &model, basetypes.ObjectAsOptions{})...)
+	return &model, diags
+}
+
+// computedAttribute converts a singular data source attribute into a read-only
+// attribute suitable for use inside a query result object.
+func computedAttribute(a schema.Attribute) schema.Attribute {
+	switch v := a.(type) {
+	case schema.StringAttribute:
+		return schema.StringAttribute{MarkdownDescription: v.MarkdownDescription, CustomType: v.CustomType, Sensitive: v.Sensitive, Computed: true}
+	case schema.BoolAttribute:
+		return schema.BoolAttribute{MarkdownDescription: v.MarkdownDescription, CustomType: v.CustomType, Sensitive: v.Sensitive, Computed: true}
+	case schema.Int64Attribute:
+		return schema.Int64Attribute{MarkdownDescription: v.MarkdownDescription, CustomType: v.CustomType, Sensitive: v.Sensitive, Computed: true}
+	case schema.Int32Attribute:
+		return schema.Int32Attribute{MarkdownDescription: v.MarkdownDescription, CustomType: v.CustomType, Sensitive: v.Sensitive, Computed: true}
+	case schema.Float64Attribute:
+		return schema.Float64Attribute{MarkdownDescription: v.MarkdownDescription, CustomType: v.CustomType, Sensitive: v.Sensitive, Computed: true}
+	case schema.Float32Attribute:
+		return schema.Float32Attribute{MarkdownDescription: v.MarkdownDescription, CustomType: v.CustomType, Sensitive: v.Sensitive, Computed: true}
+	case schema.NumberAttribute:
+		return schema.NumberAttribute{MarkdownDescription: v.MarkdownDescription, CustomType: v.CustomType, Sensitive: v.Sensitive, Computed: true}
+	case schema.DynamicAttribute:
+		return schema.DynamicAttribute{MarkdownDescription: v.MarkdownDescription, CustomType: v.CustomType, Sensitive: v.Sensitive, Computed: true}
+	case schema.ListAttribute:
+		return schema.ListAttribute{MarkdownDescription: v.MarkdownDescription, CustomType: v.CustomType, ElementType: v.ElementType, Sensitive: v.Sensitive, Computed: true}
+	case schema.SetAttribute:
+		return schema.SetAttribute{MarkdownDescription: v.MarkdownDescription, CustomType: v.CustomType, ElementType: v.ElementType, Sensitive: v.Sensitive, Computed: true}
+	case schema.MapAttribute:
+		return schema.MapAttribute{MarkdownDescription: v.MarkdownDescription, CustomType: v.CustomType, ElementType: v.ElementType, Sensitive: v.Sensitive, Computed: true}
+	case schema.ObjectAttribute:
+		return schema.ObjectAttribute{MarkdownDescription: v.MarkdownDescription, CustomType: v.CustomType, AttributeTypes: v.AttributeTypes, Sensitive: v.Sensitive, Computed: true}
+	case schema.ListNestedAttribute:
+		return schema.ListNestedAttribute{MarkdownDescription: v.MarkdownDescription, CustomType: v.CustomType, NestedObject: computedNestedObject(v.NestedObject), Sensitive: v.Sensitive, Computed: true}
+	case schema.SetNestedAttribute:
+		return schema.SetNestedAttribute{MarkdownDescription: v.MarkdownDescription, CustomType: v.CustomType, NestedObject: computedNestedObject(v.NestedObject), Sensitive: v.Sensitive, Computed: true}
+	case schema.MapNestedAttribute:
+		return schema.MapNestedAttribute{MarkdownDescription: v.MarkdownDescription, CustomType: v.CustomType, NestedObject: computedNestedObject(v.NestedObject), Sensitive: v.Sensitive, Computed: true}
+	case schema.SingleNestedAttribute:
+		attrs := make(map[string]schema.Attribute, len(v.Attributes))
+		for name, nested := range v.Attributes {
+			attrs[name] = computedAttribute(nested)
+		}
+		return schema.SingleNestedAttribute{MarkdownDescription: v.MarkdownDescription, CustomType: v.CustomType, Attributes: attrs, Sensitive: v.Sensitive, Computed: true}
+	default:
+		return a
+	}
+}
+
+func computedNestedObject(o schema.NestedAttributeObject) schema.NestedAttributeObject {
+	attrs := make(map[string]schema.Attribute, len(o.Attributes))
+	for name, nested := range o.Attributes {
+		attrs[name] = computedAttribute(nested)
+	}
+	return schema.NestedAttributeObject{Attributes: attrs, CustomType: o.CustomType}
+}
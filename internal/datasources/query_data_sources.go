@@ -0,0 +1,1402 @@
+package datasources
+
+import (
+	"context"
+
+	"github.com/bab3l/go-netbox"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// Query (plural) data sources built on queryDataSource. Each entry pairs a NetBox
+// list endpoint with the singular data source whose schema and mapping function
+// describe one result.
+
+// NewAggregatesDataSource returns the netbox_aggregates query data source.
+func NewAggregatesDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.Aggregate, AggregateDataSourceModel]{
+		typeName:   "aggregates",
+		objectName: "aggregates",
+		apiPath:    "ipam/aggregates",
+		singular:   NewAggregateDataSource,
+		mapResult: func(ctx context.Context, result *netbox.Aggregate, data *AggregateDataSourceModel, diags *diag.Diagnostics) {
+			(&AggregateDataSource{}).mapResponseToModel(ctx, result, data)
+		},
+	})
+}
+
+// NewASNsDataSource returns the netbox_asns query data source.
+func NewASNsDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.ASN, ASNDataSourceModel]{
+		typeName:   "asns",
+		objectName: "ASNs",
+		apiPath:    "ipam/asns",
+		singular:   NewASNDataSource,
+		mapResult: func(ctx context.Context, result *netbox.ASN, data *ASNDataSourceModel, diags *diag.Diagnostics) {
+			(&ASNDataSource{}).mapResponseToModel(ctx, result, data, diags)
+		},
+	})
+}
+
+// NewASNRangesDataSource returns the netbox_asn_ranges query data source.
+func NewASNRangesDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.ASNRange, ASNRangeDataSourceModel]{
+		typeName:   "asn_ranges",
+		objectName: "ASN ranges",
+		apiPath:    "ipam/asn-ranges",
+		singular:   NewASNRangeDataSource,
+		mapResult: func(ctx context.Context, result *netbox.ASNRange, data *ASNRangeDataSourceModel, diags *diag.Diagnostics) {
+			(&ASNRangeDataSource{}).mapASNRangeToDataSourceModel(ctx, result, data)
+		},
+	})
+}
+
+// NewCablesDataSource returns the netbox_cables query data source.
+func NewCablesDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.Cable, CableDataSourceModel]{
+		typeName:   "cables",
+		objectName: "cables",
+		apiPath:    "dcim/cables",
+		singular:   NewCableDataSource,
+		mapResult: func(ctx context.Context, result *netbox.Cable, data *CableDataSourceModel, diags *diag.Diagnostics) {
+			diags.Append((&CableDataSource{}).mapResponseToState(ctx, result, data)...)
+		},
+	})
+}
+
+// NewCableTerminationsDataSource returns the netbox_cable_terminations query data source.
+func NewCableTerminationsDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.CableTermination, CableTerminationDataSourceModel]{
+		typeName:   "cable_terminations",
+		objectName: "cable terminations",
+		apiPath:    "dcim/cable-terminations",
+		singular:   NewCableTerminationDataSource,
+		mapResult: func(ctx context.Context, result *netbox.CableTermination, data *CableTerminationDataSourceModel, diags *diag.Diagnostics) {
+			(&CableTerminationDataSource{}).mapToState(result, data)
+		},
+	})
+}
+
+// NewCircuitTypesDataSource returns the netbox_circuit_types query data source.
+func NewCircuitTypesDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.CircuitType, CircuitTypeDataSourceModel]{
+		typeName:   "circuit_types",
+		objectName: "circuit types",
+		apiPath:    "circuits/circuit-types",
+		singular:   NewCircuitTypeDataSource,
+		mapResult: func(ctx context.Context, result *netbox.CircuitType, data *CircuitTypeDataSourceModel, diags *diag.Diagnostics) {
+			(&CircuitTypeDataSource{}).mapResponseToState(ctx, result, data, diags)
+		},
+	})
+}
+
+// NewCircuitsDataSource returns the netbox_circuits query data source.
+func NewCircuitsDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.Circuit, CircuitDataSourceModel]{
+		typeName:   "circuits",
+		objectName: "circuits",
+		apiPath:    "circuits/circuits",
+		singular:   NewCircuitDataSource,
+		mapResult: func(ctx context.Context, result *netbox.Circuit, data *CircuitDataSourceModel, diags *diag.Diagnostics) {
+			(&CircuitDataSource{}).mapCircuitToState(ctx, result, data, diags)
+		},
+	})
+}
+
+// NewCircuitGroupAssignmentsDataSource returns the netbox_circuit_group_assignments query data source.
+func NewCircuitGroupAssignmentsDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.CircuitGroupAssignment, CircuitGroupAssignmentDataSourceModel]{
+		typeName:   "circuit_group_assignments",
+		objectName: "circuit group assignments",
+		apiPath:    "circuits/circuit-group-assignments",
+		singular:   NewCircuitGroupAssignmentDataSource,
+		mapResult: func(ctx context.Context, result *netbox.CircuitGroupAssignment, data *CircuitGroupAssignmentDataSourceModel, diags *diag.Diagnostics) {
+			resp := &datasource.ReadResponse{}
+			(&CircuitGroupAssignmentDataSource{}).mapResponseToState(ctx, result, data, resp)
+			diags.Append(resp.Diagnostics...)
+		},
+	})
+}
+
+// NewCircuitGroupsDataSource returns the netbox_circuit_groups query data source.
+func NewCircuitGroupsDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.CircuitGroup, CircuitGroupDataSourceModel]{
+		typeName:   "circuit_groups",
+		objectName: "circuit groups",
+		apiPath:    "circuits/circuit-groups",
+		singular:   NewCircuitGroupDataSource,
+		mapResult: func(ctx context.Context, result *netbox.CircuitGroup, data *CircuitGroupDataSourceModel, diags *diag.Diagnostics) {
+			resp := &datasource.ReadResponse{}
+			(&CircuitGroupDataSource{}).mapResponseToState(ctx, result, data, resp)
+			diags.Append(resp.Diagnostics...)
+		},
+	})
+}
+
+// NewCircuitTerminationsDataSource returns the netbox_circuit_terminations query data source.
+func NewCircuitTerminationsDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.CircuitTermination, CircuitTerminationDataSourceModel]{
+		typeName:   "circuit_terminations",
+		objectName: "circuit terminations",
+		apiPath:    "circuits/circuit-terminations",
+		singular:   NewCircuitTerminationDataSource,
+		mapResult: func(ctx context.Context, result *netbox.CircuitTermination, data *CircuitTerminationDataSourceModel, diags *diag.Diagnostics) {
+			(&CircuitTerminationDataSource{}).mapResponseToModel(ctx, result, data)
+		},
+	})
+}
+
+// NewClusterGroupsDataSource returns the netbox_cluster_groups query data source.
+func NewClusterGroupsDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.ClusterGroup, ClusterGroupDataSourceModel]{
+		typeName:   "cluster_groups",
+		objectName: "cluster groups",
+		apiPath:    "virtualization/cluster-groups",
+		singular:   NewClusterGroupDataSource,
+		mapResult: func(ctx context.Context, result *netbox.ClusterGroup, data *ClusterGroupDataSourceModel, diags *diag.Diagnostics) {
+			(&ClusterGroupDataSource{}).mapResponseToState(ctx, result, data, diags)
+		},
+	})
+}
+
+// NewClusterTypesDataSource returns the netbox_cluster_types query data source.
+func NewClusterTypesDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.ClusterType, ClusterTypeDataSourceModel]{
+		typeName:   "cluster_types",
+		objectName: "cluster types",
+		apiPath:    "virtualization/cluster-types",
+		singular:   NewClusterTypeDataSource,
+		mapResult: func(ctx context.Context, result *netbox.ClusterType, data *ClusterTypeDataSourceModel, diags *diag.Diagnostics) {
+			(&ClusterTypeDataSource{}).mapResponseToState(ctx, result, data, diags)
+		},
+	})
+}
+
+// NewClustersDataSource returns the netbox_clusters query data source.
+func NewClustersDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.Cluster, ClusterDataSourceModel]{
+		typeName:   "clusters",
+		objectName: "clusters",
+		apiPath:    "virtualization/clusters",
+		singular:   NewClusterDataSource,
+		mapResult: func(ctx context.Context, result *netbox.Cluster, data *ClusterDataSourceModel, diags *diag.Diagnostics) {
+			(&ClusterDataSource{}).mapClusterToState(ctx, result, data, diags)
+		},
+	})
+}
+
+// NewConfigContextsDataSource returns the netbox_config_contexts query data source.
+func NewConfigContextsDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.ConfigContext, ConfigContextDataSourceModel]{
+		typeName:   "config_contexts",
+		objectName: "config contexts",
+		apiPath:    "extras/config-contexts",
+		singular:   NewConfigContextDataSource,
+		mapResult: func(ctx context.Context, result *netbox.ConfigContext, data *ConfigContextDataSourceModel, diags *diag.Diagnostics) {
+			mapConfigContextDataSourceResponse(ctx, result, data)
+		},
+	})
+}
+
+// NewConfigTemplatesDataSource returns the netbox_config_templates query data source.
+func NewConfigTemplatesDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.ConfigTemplate, ConfigTemplateDataSourceModel]{
+		typeName:   "config_templates",
+		objectName: "config templates",
+		apiPath:    "extras/config-templates",
+		singular:   NewConfigTemplateDataSource,
+		mapResult: func(ctx context.Context, result *netbox.ConfigTemplate, data *ConfigTemplateDataSourceModel, diags *diag.Diagnostics) {
+			(&ConfigTemplateDataSource{}).mapResponseToModel(result, data)
+		},
+	})
+}
+
+// NewConsolePortsDataSource returns the netbox_console_ports query data source.
+func NewConsolePortsDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.ConsolePort, ConsolePortDataSourceModel]{
+		typeName:   "console_ports",
+		objectName: "console ports",
+		apiPath:    "dcim/console-ports",
+		singular:   NewConsolePortDataSource,
+		mapResult: func(ctx context.Context, result *netbox.ConsolePort, data *ConsolePortDataSourceModel, diags *diag.Diagnostics) {
+			(&ConsolePortDataSource{}).mapResponseToModel(result, data)
+		},
+	})
+}
+
+// NewConsolePortTemplatesDataSource returns the netbox_console_port_templates query data source.
+func NewConsolePortTemplatesDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.ConsolePortTemplate, ConsolePortTemplateDataSourceModel]{
+		typeName:   "console_port_templates",
+		objectName: "console port templates",
+		apiPath:    "dcim/console-port-templates",
+		singular:   NewConsolePortTemplateDataSource,
+		mapResult: func(ctx context.Context, result *netbox.ConsolePortTemplate, data *ConsolePortTemplateDataSourceModel, diags *diag.Diagnostics) {
+			(&ConsolePortTemplateDataSource{}).mapResponseToModel(result, data)
+		},
+	})
+}
+
+// NewConsoleServerPortsDataSource returns the netbox_console_server_ports query data source.
+func NewConsoleServerPortsDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.ConsoleServerPort, ConsoleServerPortDataSourceModel]{
+		typeName:   "console_server_ports",
+		objectName: "console server ports",
+		apiPath:    "dcim/console-server-ports",
+		singular:   NewConsoleServerPortDataSource,
+		mapResult: func(ctx context.Context, result *netbox.ConsoleServerPort, data *ConsoleServerPortDataSourceModel, diags *diag.Diagnostics) {
+			(&ConsoleServerPortDataSource{}).mapResponseToModel(result, data)
+		},
+	})
+}
+
+// NewConsoleServerPortTemplatesDataSource returns the netbox_console_server_port_templates query data source.
+func NewConsoleServerPortTemplatesDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.ConsoleServerPortTemplate, ConsoleServerPortTemplateDataSourceModel]{
+		typeName:   "console_server_port_templates",
+		objectName: "console server port templates",
+		apiPath:    "dcim/console-server-port-templates",
+		singular:   NewConsoleServerPortTemplateDataSource,
+		mapResult: func(ctx context.Context, result *netbox.ConsoleServerPortTemplate, data *ConsoleServerPortTemplateDataSourceModel, diags *diag.Diagnostics) {
+			(&ConsoleServerPortTemplateDataSource{}).mapResponseToModel(result, data)
+		},
+	})
+}
+
+// NewContactAssignmentsDataSource returns the netbox_contact_assignments query data source.
+func NewContactAssignmentsDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.ContactAssignment, ContactAssignmentDataSourceModel]{
+		typeName:   "contact_assignments",
+		objectName: "contact assignments",
+		apiPath:    "tenancy/contact-assignments",
+		singular:   NewContactAssignmentDataSource,
+		mapResult: func(ctx context.Context, result *netbox.ContactAssignment, data *ContactAssignmentDataSourceModel, diags *diag.Diagnostics) {
+			resp := &datasource.ReadResponse{}
+			(&ContactAssignmentDataSource{}).mapResponseToState(ctx, result, data, resp)
+			diags.Append(resp.Diagnostics...)
+		},
+	})
+}
+
+// NewContactGroupsDataSource returns the netbox_contact_groups query data source.
+func NewContactGroupsDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.ContactGroup, ContactGroupDataSourceModel]{
+		typeName:   "contact_groups",
+		objectName: "contact groups",
+		apiPath:    "tenancy/contact-groups",
+		singular:   NewContactGroupDataSource,
+		mapResult: func(ctx context.Context, result *netbox.ContactGroup, data *ContactGroupDataSourceModel, diags *diag.Diagnostics) {
+			(&ContactGroupDataSource{}).mapResponseToState(ctx, result, data, diags)
+		},
+	})
+}
+
+// NewContactRolesDataSource returns the netbox_contact_roles query data source.
+func NewContactRolesDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.ContactRole, ContactRoleDataSourceModel]{
+		typeName:   "contact_roles",
+		objectName: "contact roles",
+		apiPath:    "tenancy/contact-roles",
+		singular:   NewContactRoleDataSource,
+		mapResult: func(ctx context.Context, result *netbox.ContactRole, data *ContactRoleDataSourceModel, diags *diag.Diagnostics) {
+			(&ContactRoleDataSource{}).mapResponseToState(ctx, result, data, diags)
+		},
+	})
+}
+
+// NewContactsDataSource returns the netbox_contacts query data source.
+func NewContactsDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.Contact, ContactDataSourceModel]{
+		typeName:   "contacts",
+		objectName: "contacts",
+		apiPath:    "tenancy/contacts",
+		singular:   NewContactDataSource,
+		mapResult: func(ctx context.Context, result *netbox.Contact, data *ContactDataSourceModel, diags *diag.Diagnostics) {
+			(&ContactDataSource{}).mapContactToState(ctx, result, data)
+		},
+	})
+}
+
+// NewCustomFieldChoiceSetsDataSource returns the netbox_custom_field_choice_sets query data source.
+func NewCustomFieldChoiceSetsDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.CustomFieldChoiceSet, CustomFieldChoiceSetDataSourceModel]{
+		typeName:   "custom_field_choice_sets",
+		objectName: "custom field choice sets",
+		apiPath:    "extras/custom-field-choice-sets",
+		singular:   NewCustomFieldChoiceSetDataSource,
+		mapResult: func(ctx context.Context, result *netbox.CustomFieldChoiceSet, data *CustomFieldChoiceSetDataSourceModel, diags *diag.Diagnostics) {
+			(&CustomFieldChoiceSetDataSource{}).mapToState(result, data)
+		},
+	})
+}
+
+// NewCustomFieldsDataSource returns the netbox_custom_fields query data source.
+func NewCustomFieldsDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.CustomField, CustomFieldDataSourceModel]{
+		typeName:   "custom_fields",
+		objectName: "custom fields",
+		apiPath:    "extras/custom-fields",
+		singular:   NewCustomFieldDataSource,
+		mapResult: func(ctx context.Context, result *netbox.CustomField, data *CustomFieldDataSourceModel, diags *diag.Diagnostics) {
+			(&CustomFieldDataSource{}).mapResponseToModel(ctx, result, data)
+		},
+	})
+}
+
+// NewCustomLinksDataSource returns the netbox_custom_links query data source.
+func NewCustomLinksDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.CustomLink, CustomLinkDataSourceModel]{
+		typeName:   "custom_links",
+		objectName: "custom links",
+		apiPath:    "extras/custom-links",
+		singular:   NewCustomLinkDataSource,
+		mapResult: func(ctx context.Context, result *netbox.CustomLink, data *CustomLinkDataSourceModel, diags *diag.Diagnostics) {
+			(&CustomLinkDataSource{}).mapToState(ctx, result, data)
+		},
+	})
+}
+
+// NewDeviceRolesDataSource returns the netbox_device_roles query data source.
+func NewDeviceRolesDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.DeviceRole, DeviceRoleDataSourceModel]{
+		typeName:   "device_roles",
+		objectName: "device roles",
+		apiPath:    "dcim/device-roles",
+		singular:   NewDeviceRoleDataSource,
+		mapResult: func(ctx context.Context, result *netbox.DeviceRole, data *DeviceRoleDataSourceModel, diags *diag.Diagnostics) {
+			(&DeviceRoleDataSource{}).mapResponseToState(ctx, result, data, diags)
+		},
+	})
+}
+
+// NewDeviceTypesDataSource returns the netbox_device_types query data source.
+func NewDeviceTypesDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.DeviceType, DeviceTypeDataSourceModel]{
+		typeName:   "device_types",
+		objectName: "device types",
+		apiPath:    "dcim/device-types",
+		singular:   NewDeviceTypeDataSource,
+		mapResult: func(ctx context.Context, result *netbox.DeviceType, data *DeviceTypeDataSourceModel, diags *diag.Diagnostics) {
+			(&DeviceTypeDataSource{}).mapResponseToState(ctx, result, data, diags)
+		},
+	})
+}
+
+// NewDevicesDataSource returns the netbox_devices query data source.
+func NewDevicesDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.Device, DeviceDataSourceModel]{
+		typeName:   "devices",
+		objectName: "devices",
+		apiPath:    "dcim/devices",
+		singular:   NewDeviceDataSource,
+		values: &queryValuesSpec{
+			attribute:       "names",
+			resultAttribute: "name",
+			description:     "Best-effort list of device names that match the query.",
+		},
+		mapResult: func(ctx context.Context, result *netbox.Device, data *DeviceDataSourceModel, diags *diag.Diagnostics) {
+			resp := &datasource.ReadResponse{}
+			(&DeviceDataSource{}).mapDeviceToDataSource(ctx, result, data, resp)
+			diags.Append(resp.Diagnostics...)
+		},
+	})
+}
+
+// NewDeviceBaysDataSource returns the netbox_device_bays query data source.
+func NewDeviceBaysDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.DeviceBay, DeviceBayDataSourceModel]{
+		typeName:   "device_bays",
+		objectName: "device bays",
+		apiPath:    "dcim/device-bays",
+		singular:   NewDeviceBayDataSource,
+		mapResult: func(ctx context.Context, result *netbox.DeviceBay, data *DeviceBayDataSourceModel, diags *diag.Diagnostics) {
+			(&DeviceBayDataSource{}).mapResponseToModel(ctx, result, data, diags)
+		},
+	})
+}
+
+// NewDeviceBayTemplatesDataSource returns the netbox_device_bay_templates query data source.
+func NewDeviceBayTemplatesDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.DeviceBayTemplate, DeviceBayTemplateDataSourceModel]{
+		typeName:   "device_bay_templates",
+		objectName: "device bay templates",
+		apiPath:    "dcim/device-bay-templates",
+		singular:   NewDeviceBayTemplateDataSource,
+		mapResult: func(ctx context.Context, result *netbox.DeviceBayTemplate, data *DeviceBayTemplateDataSourceModel, diags *diag.Diagnostics) {
+			(&DeviceBayTemplateDataSource{}).mapTemplateToDataSourceModel(result, data)
+		},
+	})
+}
+
+// NewEventRulesDataSource returns the netbox_event_rules query data source.
+func NewEventRulesDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.EventRule, EventRuleDataSourceModel]{
+		typeName:   "event_rules",
+		objectName: "event rules",
+		apiPath:    "extras/event-rules",
+		singular:   NewEventRuleDataSource,
+		mapResult: func(ctx context.Context, result *netbox.EventRule, data *EventRuleDataSourceModel, diags *diag.Diagnostics) {
+			(&EventRuleDataSource{}).mapToDataSourceModel(ctx, result, data, diags)
+		},
+	})
+}
+
+// NewExportTemplatesDataSource returns the netbox_export_templates query data source.
+func NewExportTemplatesDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.ExportTemplate, ExportTemplateDataSourceModel]{
+		typeName:   "export_templates",
+		objectName: "export templates",
+		apiPath:    "extras/export-templates",
+		singular:   NewExportTemplateDataSource,
+		mapResult: func(ctx context.Context, result *netbox.ExportTemplate, data *ExportTemplateDataSourceModel, diags *diag.Diagnostics) {
+			resp := &datasource.ReadResponse{}
+			(&ExportTemplateDataSource{}).mapResponseToState(ctx, result, data, resp)
+			diags.Append(resp.Diagnostics...)
+		},
+	})
+}
+
+// NewFHRPGroupAssignmentsDataSource returns the netbox_fhrp_group_assignments query data source.
+func NewFHRPGroupAssignmentsDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.FHRPGroupAssignment, FHRPGroupAssignmentDataSourceModel]{
+		typeName:   "fhrp_group_assignments",
+		objectName: "FHRP group assignments",
+		apiPath:    "ipam/fhrp-group-assignments",
+		singular:   NewFHRPGroupAssignmentDataSource,
+		mapResult: func(ctx context.Context, result *netbox.FHRPGroupAssignment, data *FHRPGroupAssignmentDataSourceModel, diags *diag.Diagnostics) {
+			(&FHRPGroupAssignmentDataSource{}).mapResponseToState(result, data)
+		},
+	})
+}
+
+// NewFHRPGroupsDataSource returns the netbox_fhrp_groups query data source.
+func NewFHRPGroupsDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.FHRPGroup, FHRPGroupDataSourceModel]{
+		typeName:   "fhrp_groups",
+		objectName: "FHRP groups",
+		apiPath:    "ipam/fhrp-groups",
+		singular:   NewFHRPGroupDataSource,
+		mapResult: func(ctx context.Context, result *netbox.FHRPGroup, data *FHRPGroupDataSourceModel, diags *diag.Diagnostics) {
+			(&FHRPGroupDataSource{}).mapResponseToState(ctx, result, data, diags)
+		},
+	})
+}
+
+// NewFrontPortsDataSource returns the netbox_front_ports query data source.
+func NewFrontPortsDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.FrontPort, FrontPortDataSourceModel]{
+		typeName:   "front_ports",
+		objectName: "front ports",
+		apiPath:    "dcim/front-ports",
+		singular:   NewFrontPortDataSource,
+		mapResult: func(ctx context.Context, result *netbox.FrontPort, data *FrontPortDataSourceModel, diags *diag.Diagnostics) {
+			(&FrontPortDataSource{}).mapResponseToModel(result, data)
+		},
+	})
+}
+
+// NewFrontPortTemplatesDataSource returns the netbox_front_port_templates query data source.
+func NewFrontPortTemplatesDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.FrontPortTemplate, FrontPortTemplateDataSourceModel]{
+		typeName:   "front_port_templates",
+		objectName: "front port templates",
+		apiPath:    "dcim/front-port-templates",
+		singular:   NewFrontPortTemplateDataSource,
+		mapResult: func(ctx context.Context, result *netbox.FrontPortTemplate, data *FrontPortTemplateDataSourceModel, diags *diag.Diagnostics) {
+			(&FrontPortTemplateDataSource{}).mapResponseToModel(result, data)
+		},
+	})
+}
+
+// NewIKEPoliciesDataSource returns the netbox_ike_policies query data source.
+func NewIKEPoliciesDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.IKEPolicy, IKEPolicyDataSourceModel]{
+		typeName:   "ike_policies",
+		objectName: "IKE policies",
+		apiPath:    "vpn/ike-policies",
+		singular:   NewIKEPolicyDataSource,
+		mapResult: func(ctx context.Context, result *netbox.IKEPolicy, data *IKEPolicyDataSourceModel, diags *diag.Diagnostics) {
+			(&IKEPolicyDataSource{}).mapIKEPolicyToState(result, data)
+		},
+	})
+}
+
+// NewIKEProposalsDataSource returns the netbox_ike_proposals query data source.
+func NewIKEProposalsDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.IKEProposal, IKEProposalDataSourceModel]{
+		typeName:   "ike_proposals",
+		objectName: "IKE proposals",
+		apiPath:    "vpn/ike-proposals",
+		singular:   NewIKEProposalDataSource,
+		mapResult: func(ctx context.Context, result *netbox.IKEProposal, data *IKEProposalDataSourceModel, diags *diag.Diagnostics) {
+			(&IKEProposalDataSource{}).mapIKEProposalToState(result, data)
+		},
+	})
+}
+
+// NewInterfacesDataSource returns the netbox_interfaces query data source.
+func NewInterfacesDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.Interface, InterfaceDataSourceModel]{
+		typeName:   "interfaces",
+		objectName: "interfaces",
+		apiPath:    "dcim/interfaces",
+		singular:   NewInterfaceDataSource,
+		values: &queryValuesSpec{
+			attribute:       "names",
+			resultAttribute: "name",
+			description:     "List of interface names that match the query.",
+		},
+		mapResult: func(ctx context.Context, result *netbox.Interface, data *InterfaceDataSourceModel, diags *diag.Diagnostics) {
+			resp := &datasource.ReadResponse{}
+			(&InterfaceDataSource{}).mapInterfaceToDataSource(ctx, result, data, resp)
+			diags.Append(resp.Diagnostics...)
+		},
+	})
+}
+
+// NewInterfaceTemplatesDataSource returns the netbox_interface_templates query data source.
+func NewInterfaceTemplatesDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.InterfaceTemplate, InterfaceTemplateDataSourceModel]{
+		typeName:   "interface_templates",
+		objectName: "interface templates",
+		apiPath:    "dcim/interface-templates",
+		singular:   NewInterfaceTemplateDataSource,
+		mapResult: func(ctx context.Context, result *netbox.InterfaceTemplate, data *InterfaceTemplateDataSourceModel, diags *diag.Diagnostics) {
+			(&InterfaceTemplateDataSource{}).mapResponseToModel(result, data)
+		},
+	})
+}
+
+// NewInventoryItemRolesDataSource returns the netbox_inventory_item_roles query data source.
+func NewInventoryItemRolesDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.InventoryItemRole, InventoryItemRoleDataSourceModel]{
+		typeName:   "inventory_item_roles",
+		objectName: "inventory item roles",
+		apiPath:    "dcim/inventory-item-roles",
+		singular:   NewInventoryItemRoleDataSource,
+		mapResult: func(ctx context.Context, result *netbox.InventoryItemRole, data *InventoryItemRoleDataSourceModel, diags *diag.Diagnostics) {
+			(&InventoryItemRoleDataSource{}).mapResponseToState(ctx, result, data, diags)
+		},
+	})
+}
+
+// NewInventoryItemTemplatesDataSource returns the netbox_inventory_item_templates query data source.
+func NewInventoryItemTemplatesDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.InventoryItemTemplate, InventoryItemTemplateDataSourceModel]{
+		typeName:   "inventory_item_templates",
+		objectName: "inventory item templates",
+		apiPath:    "dcim/inventory-item-templates",
+		singular:   NewInventoryItemTemplateDataSource,
+		mapResult: func(ctx context.Context, result *netbox.InventoryItemTemplate, data *InventoryItemTemplateDataSourceModel, diags *diag.Diagnostics) {
+			(&InventoryItemTemplateDataSource{}).mapToState(result, data)
+		},
+	})
+}
+
+// NewInventoryItemsDataSource returns the netbox_inventory_items query data source.
+func NewInventoryItemsDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.InventoryItem, InventoryItemDataSourceModel]{
+		typeName:   "inventory_items",
+		objectName: "inventory items",
+		apiPath:    "dcim/inventory-items",
+		singular:   NewInventoryItemDataSource,
+		mapResult: func(ctx context.Context, result *netbox.InventoryItem, data *InventoryItemDataSourceModel, diags *diag.Diagnostics) {
+			(&InventoryItemDataSource{}).mapResponseToState(ctx, result, data, diags)
+		},
+	})
+}
+
+// NewIPAddressesDataSource returns the netbox_ip_addresses query data source.
+func NewIPAddressesDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.IPAddress, IPAddressDataSourceModel]{
+		typeName:   "ip_addresses",
+		objectName: "IP addresses",
+		apiPath:    "ipam/ip-addresses",
+		singular:   NewIPAddressDataSource,
+		values: &queryValuesSpec{
+			attribute:       "addresses",
+			resultAttribute: "address",
+			description:     "List of IP address strings (with prefix length) that match the query.",
+		},
+		mapResult: func(ctx context.Context, result *netbox.IPAddress, data *IPAddressDataSourceModel, diags *diag.Diagnostics) {
+			(&IPAddressDataSource{}).mapIPAddressToState(ctx, result, data, diags)
+		},
+	})
+}
+
+// NewIPRangesDataSource returns the netbox_ip_ranges query data source.
+func NewIPRangesDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.IPRange, IPRangeDataSourceModel]{
+		typeName:   "ip_ranges",
+		objectName: "IP ranges",
+		apiPath:    "ipam/ip-ranges",
+		singular:   NewIPRangeDataSource,
+		mapResult: func(ctx context.Context, result *netbox.IPRange, data *IPRangeDataSourceModel, diags *diag.Diagnostics) {
+			(&IPRangeDataSource{}).mapIPRangeToDataSourceModel(ctx, result, data)
+		},
+	})
+}
+
+// NewIPSecPoliciesDataSource returns the netbox_ipsec_policies query data source.
+func NewIPSecPoliciesDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.IPSecPolicy, IPSecPolicyDataSourceModel]{
+		typeName:   "ipsec_policies",
+		objectName: "IPSec policies",
+		apiPath:    "vpn/ipsec-policies",
+		singular:   NewIPSecPolicyDataSource,
+		mapResult: func(ctx context.Context, result *netbox.IPSecPolicy, data *IPSecPolicyDataSourceModel, diags *diag.Diagnostics) {
+			(&IPSecPolicyDataSource{}).mapIPSecPolicyToState(result, data)
+		},
+	})
+}
+
+// NewIPSecProfilesDataSource returns the netbox_ipsec_profiles query data source.
+func NewIPSecProfilesDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.IPSecProfile, IPSecProfileDataSourceModel]{
+		typeName:   "ipsec_profiles",
+		objectName: "IPSec profiles",
+		apiPath:    "vpn/ipsec-profiles",
+		singular:   NewIPSecProfileDataSource,
+		mapResult: func(ctx context.Context, result *netbox.IPSecProfile, data *IPSecProfileDataSourceModel, diags *diag.Diagnostics) {
+			(&IPSecProfileDataSource{}).mapIPSecProfileToState(result, data)
+		},
+	})
+}
+
+// NewIPSecProposalsDataSource returns the netbox_ipsec_proposals query data source.
+func NewIPSecProposalsDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.IPSecProposal, IPSecProposalDataSourceModel]{
+		typeName:   "ipsec_proposals",
+		objectName: "IPSec proposals",
+		apiPath:    "vpn/ipsec-proposals",
+		singular:   NewIPSecProposalDataSource,
+		mapResult: func(ctx context.Context, result *netbox.IPSecProposal, data *IPSecProposalDataSourceModel, diags *diag.Diagnostics) {
+			(&IPSecProposalDataSource{}).mapIPSecProposalToState(result, data)
+		},
+	})
+}
+
+// NewJournalEntriesDataSource returns the netbox_journal_entries query data source.
+func NewJournalEntriesDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.JournalEntry, JournalEntryDataSourceModel]{
+		typeName:   "journal_entries",
+		objectName: "journal entries",
+		apiPath:    "extras/journal-entries",
+		singular:   NewJournalEntryDataSource,
+		mapResult: func(ctx context.Context, result *netbox.JournalEntry, data *JournalEntryDataSourceModel, diags *diag.Diagnostics) {
+			(&JournalEntryDataSource{}).mapResponseToState(ctx, result, data, diags)
+		},
+	})
+}
+
+// NewL2VPNTerminationsDataSource returns the netbox_l2vpn_terminations query data source.
+func NewL2VPNTerminationsDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.L2VPNTermination, L2VPNTerminationDataSourceModel]{
+		typeName:   "l2vpn_terminations",
+		objectName: "L2VPN terminations",
+		apiPath:    "vpn/l2vpn-terminations",
+		singular:   NewL2VPNTerminationDataSource,
+		mapResult: func(ctx context.Context, result *netbox.L2VPNTermination, data *L2VPNTerminationDataSourceModel, diags *diag.Diagnostics) {
+			(&L2VPNTerminationDataSource{}).mapResponseToState(ctx, result, data, diags)
+		},
+	})
+}
+
+// NewL2VPNsDataSource returns the netbox_l2vpns query data source.
+func NewL2VPNsDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.L2VPN, L2VPNDataSourceModel]{
+		typeName:   "l2vpns",
+		objectName: "L2VPNs",
+		apiPath:    "vpn/l2vpns",
+		singular:   NewL2VPNDataSource,
+		mapResult: func(ctx context.Context, result *netbox.L2VPN, data *L2VPNDataSourceModel, diags *diag.Diagnostics) {
+			(&L2VPNDataSource{}).mapResponseToState(ctx, result, data, diags)
+		},
+	})
+}
+
+// NewLocationsDataSource returns the netbox_locations query data source.
+func NewLocationsDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.Location, LocationDataSourceModel]{
+		typeName:   "locations",
+		objectName: "locations",
+		apiPath:    "dcim/locations",
+		singular:   NewLocationDataSource,
+		mapResult: func(ctx context.Context, result *netbox.Location, data *LocationDataSourceModel, diags *diag.Diagnostics) {
+			(&LocationDataSource{}).mapResponseToState(ctx, result, data, diags)
+		},
+	})
+}
+
+// NewManufacturersDataSource returns the netbox_manufacturers query data source.
+func NewManufacturersDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.Manufacturer, ManufacturerDataSourceModel]{
+		typeName:   "manufacturers",
+		objectName: "manufacturers",
+		apiPath:    "dcim/manufacturers",
+		singular:   NewManufacturerDataSource,
+		mapResult: func(ctx context.Context, result *netbox.Manufacturer, data *ManufacturerDataSourceModel, diags *diag.Diagnostics) {
+			(&ManufacturerDataSource{}).mapManufacturerToState(ctx, result, data, diags)
+		},
+	})
+}
+
+// NewModuleBaysDataSource returns the netbox_module_bays query data source.
+func NewModuleBaysDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.ModuleBay, ModuleBayDataSourceModel]{
+		typeName:   "module_bays",
+		objectName: "module bays",
+		apiPath:    "dcim/module-bays",
+		singular:   NewModuleBayDataSource,
+		mapResult: func(ctx context.Context, result *netbox.ModuleBay, data *ModuleBayDataSourceModel, diags *diag.Diagnostics) {
+			(&ModuleBayDataSource{}).mapResponseToModel(result, data)
+		},
+	})
+}
+
+// NewModuleBayTemplatesDataSource returns the netbox_module_bay_templates query data source.
+func NewModuleBayTemplatesDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.ModuleBayTemplate, ModuleBayTemplateDataSourceModel]{
+		typeName:   "module_bay_templates",
+		objectName: "module bay templates",
+		apiPath:    "dcim/module-bay-templates",
+		singular:   NewModuleBayTemplateDataSource,
+		mapResult: func(ctx context.Context, result *netbox.ModuleBayTemplate, data *ModuleBayTemplateDataSourceModel, diags *diag.Diagnostics) {
+			(&ModuleBayTemplateDataSource{}).mapToState(result, data)
+		},
+	})
+}
+
+// NewModulesDataSource returns the netbox_modules query data source.
+func NewModulesDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.Module, ModuleDataSourceModel]{
+		typeName:   "modules",
+		objectName: "modules",
+		apiPath:    "dcim/modules",
+		singular:   NewModuleDataSource,
+		mapResult: func(ctx context.Context, result *netbox.Module, data *ModuleDataSourceModel, diags *diag.Diagnostics) {
+			(&ModuleDataSource{}).mapResponseToModel(result, data)
+		},
+	})
+}
+
+// NewModuleTypesDataSource returns the netbox_module_types query data source.
+func NewModuleTypesDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.ModuleType, ModuleTypeDataSourceModel]{
+		typeName:   "module_types",
+		objectName: "module types",
+		apiPath:    "dcim/module-types",
+		singular:   NewModuleTypeDataSource,
+		mapResult: func(ctx context.Context, result *netbox.ModuleType, data *ModuleTypeDataSourceModel, diags *diag.Diagnostics) {
+			(&ModuleTypeDataSource{}).mapResponseToModel(result, data)
+		},
+	})
+}
+
+// NewNotificationGroupsDataSource returns the netbox_notification_groups query data source.
+func NewNotificationGroupsDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.NotificationGroup, NotificationGroupDataSourceModel]{
+		typeName:   "notification_groups",
+		objectName: "notification groups",
+		apiPath:    "extras/notification-groups",
+		singular:   NewNotificationGroupDataSource,
+		mapResult: func(ctx context.Context, result *netbox.NotificationGroup, data *NotificationGroupDataSourceModel, diags *diag.Diagnostics) {
+			(&NotificationGroupDataSource{}).mapToDataSourceModel(ctx, result, data, diags)
+		},
+	})
+}
+
+// NewPlatformsDataSource returns the netbox_platforms query data source.
+func NewPlatformsDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.Platform, PlatformDataSourceModel]{
+		typeName:   "platforms",
+		objectName: "platforms",
+		apiPath:    "dcim/platforms",
+		singular:   NewPlatformDataSource,
+		mapResult: func(ctx context.Context, result *netbox.Platform, data *PlatformDataSourceModel, diags *diag.Diagnostics) {
+			(&PlatformDataSource{}).mapResponseToState(ctx, result, data, diags)
+		},
+	})
+}
+
+// NewPowerFeedsDataSource returns the netbox_power_feeds query data source.
+func NewPowerFeedsDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.PowerFeed, PowerFeedDataSourceModel]{
+		typeName:   "power_feeds",
+		objectName: "power feeds",
+		apiPath:    "dcim/power-feeds",
+		singular:   NewPowerFeedDataSource,
+		mapResult: func(ctx context.Context, result *netbox.PowerFeed, data *PowerFeedDataSourceModel, diags *diag.Diagnostics) {
+			(&PowerFeedDataSource{}).mapResponseToModel(ctx, result, data, diags)
+		},
+	})
+}
+
+// NewPowerOutletsDataSource returns the netbox_power_outlets query data source.
+func NewPowerOutletsDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.PowerOutlet, PowerOutletDataSourceModel]{
+		typeName:   "power_outlets",
+		objectName: "power outlets",
+		apiPath:    "dcim/power-outlets",
+		singular:   NewPowerOutletDataSource,
+		mapResult: func(ctx context.Context, result *netbox.PowerOutlet, data *PowerOutletDataSourceModel, diags *diag.Diagnostics) {
+			(&PowerOutletDataSource{}).mapResponseToModel(result, data)
+		},
+	})
+}
+
+// NewPowerOutletTemplatesDataSource returns the netbox_power_outlet_templates query data source.
+func NewPowerOutletTemplatesDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.PowerOutletTemplate, PowerOutletTemplateDataSourceModel]{
+		typeName:   "power_outlet_templates",
+		objectName: "power outlet templates",
+		apiPath:    "dcim/power-outlet-templates",
+		singular:   NewPowerOutletTemplateDataSource,
+		mapResult: func(ctx context.Context, result *netbox.PowerOutletTemplate, data *PowerOutletTemplateDataSourceModel, diags *diag.Diagnostics) {
+			(&PowerOutletTemplateDataSource{}).mapResponseToModel(result, data)
+		},
+	})
+}
+
+// NewPowerPanelsDataSource returns the netbox_power_panels query data source.
+func NewPowerPanelsDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.PowerPanel, PowerPanelDataSourceModel]{
+		typeName:   "power_panels",
+		objectName: "power panels",
+		apiPath:    "dcim/power-panels",
+		singular:   NewPowerPanelDataSource,
+		mapResult: func(ctx context.Context, result *netbox.PowerPanel, data *PowerPanelDataSourceModel, diags *diag.Diagnostics) {
+			(&PowerPanelDataSource{}).mapResponseToModel(ctx, result, data, diags)
+		},
+	})
+}
+
+// NewPowerPortsDataSource returns the netbox_power_ports query data source.
+func NewPowerPortsDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.PowerPort, PowerPortDataSourceModel]{
+		typeName:   "power_ports",
+		objectName: "power ports",
+		apiPath:    "dcim/power-ports",
+		singular:   NewPowerPortDataSource,
+		mapResult: func(ctx context.Context, result *netbox.PowerPort, data *PowerPortDataSourceModel, diags *diag.Diagnostics) {
+			(&PowerPortDataSource{}).mapResponseToModel(result, data)
+		},
+	})
+}
+
+// NewPowerPortTemplatesDataSource returns the netbox_power_port_templates query data source.
+func NewPowerPortTemplatesDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.PowerPortTemplate, PowerPortTemplateDataSourceModel]{
+		typeName:   "power_port_templates",
+		objectName: "power port templates",
+		apiPath:    "dcim/power-port-templates",
+		singular:   NewPowerPortTemplateDataSource,
+		mapResult: func(ctx context.Context, result *netbox.PowerPortTemplate, data *PowerPortTemplateDataSourceModel, diags *diag.Diagnostics) {
+			(&PowerPortTemplateDataSource{}).mapResponseToModel(result, data)
+		},
+	})
+}
+
+// NewPrefixesDataSource returns the netbox_prefixes query data source.
+func NewPrefixesDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.Prefix, PrefixDataSourceModel]{
+		typeName:   "prefixes",
+		objectName: "prefixes",
+		apiPath:    "ipam/prefixes",
+		singular:   NewPrefixDataSource,
+		values: &queryValuesSpec{
+			attribute:       "cidrs",
+			resultAttribute: "prefix",
+			description:     "List of prefixes in CIDR notation (e.g. 192.0.2.0/24) that match the query.",
+		},
+		mapResult: func(ctx context.Context, result *netbox.Prefix, data *PrefixDataSourceModel, diags *diag.Diagnostics) {
+			(&PrefixDataSource{}).mapPrefixToState(ctx, result, data, diags)
+		},
+	})
+}
+
+// NewProvidersDataSource returns the netbox_providers query data source.
+func NewProvidersDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.Provider, ProviderDataSourceModel]{
+		typeName:   "providers",
+		objectName: "providers",
+		apiPath:    "circuits/providers",
+		singular:   NewProviderDataSource,
+		mapResult: func(ctx context.Context, result *netbox.Provider, data *ProviderDataSourceModel, diags *diag.Diagnostics) {
+			(&ProviderDataSource{}).mapResponseToState(ctx, result, data, diags)
+		},
+	})
+}
+
+// NewProviderAccountsDataSource returns the netbox_provider_accounts query data source.
+func NewProviderAccountsDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.ProviderAccount, ProviderAccountDataSourceModel]{
+		typeName:   "provider_accounts",
+		objectName: "provider accounts",
+		apiPath:    "circuits/provider-accounts",
+		singular:   NewProviderAccountDataSource,
+		mapResult: func(ctx context.Context, result *netbox.ProviderAccount, data *ProviderAccountDataSourceModel, diags *diag.Diagnostics) {
+			(&ProviderAccountDataSource{}).mapResponseToModel(ctx, result, data)
+		},
+	})
+}
+
+// NewProviderNetworksDataSource returns the netbox_provider_networks query data source.
+func NewProviderNetworksDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.ProviderNetwork, ProviderNetworkDataSourceModel]{
+		typeName:   "provider_networks",
+		objectName: "provider networks",
+		apiPath:    "circuits/provider-networks",
+		singular:   NewProviderNetworkDataSource,
+		mapResult: func(ctx context.Context, result *netbox.ProviderNetwork, data *ProviderNetworkDataSourceModel, diags *diag.Diagnostics) {
+			(&ProviderNetworkDataSource{}).mapResponseToModel(ctx, result, data, diags)
+		},
+	})
+}
+
+// NewRackRolesDataSource returns the netbox_rack_roles query data source.
+func NewRackRolesDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.RackRole, RackRoleDataSourceModel]{
+		typeName:   "rack_roles",
+		objectName: "rack roles",
+		apiPath:    "dcim/rack-roles",
+		singular:   NewRackRoleDataSource,
+		mapResult: func(ctx context.Context, result *netbox.RackRole, data *RackRoleDataSourceModel, diags *diag.Diagnostics) {
+			(&RackRoleDataSource{}).mapResponseToState(ctx, result, data, diags)
+		},
+	})
+}
+
+// NewRacksDataSource returns the netbox_racks query data source.
+func NewRacksDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.Rack, RackDataSourceModel]{
+		typeName:   "racks",
+		objectName: "racks",
+		apiPath:    "dcim/racks",
+		singular:   NewRackDataSource,
+		mapResult: func(ctx context.Context, result *netbox.Rack, data *RackDataSourceModel, diags *diag.Diagnostics) {
+			mapRackDataSourceToState(ctx, result, data)
+		},
+	})
+}
+
+// NewRackReservationsDataSource returns the netbox_rack_reservations query data source.
+func NewRackReservationsDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.RackReservation, RackReservationDataSourceModel]{
+		typeName:   "rack_reservations",
+		objectName: "rack reservations",
+		apiPath:    "dcim/rack-reservations",
+		singular:   NewRackReservationDataSource,
+		mapResult: func(ctx context.Context, result *netbox.RackReservation, data *RackReservationDataSourceModel, diags *diag.Diagnostics) {
+			(&RackReservationDataSource{}).mapToState(ctx, result, data, diags)
+		},
+	})
+}
+
+// NewRackTypesDataSource returns the netbox_rack_types query data source.
+func NewRackTypesDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.RackType, RackTypeDataSourceModel]{
+		typeName:   "rack_types",
+		objectName: "rack types",
+		apiPath:    "dcim/rack-types",
+		singular:   NewRackTypeDataSource,
+		mapResult: func(ctx context.Context, result *netbox.RackType, data *RackTypeDataSourceModel, diags *diag.Diagnostics) {
+			(&RackTypeDataSource{}).mapResponseToModel(ctx, result, data, diags)
+		},
+	})
+}
+
+// NewRearPortsDataSource returns the netbox_rear_ports query data source.
+func NewRearPortsDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.RearPort, RearPortDataSourceModel]{
+		typeName:   "rear_ports",
+		objectName: "rear ports",
+		apiPath:    "dcim/rear-ports",
+		singular:   NewRearPortDataSource,
+		mapResult: func(ctx context.Context, result *netbox.RearPort, data *RearPortDataSourceModel, diags *diag.Diagnostics) {
+			(&RearPortDataSource{}).mapResponseToModel(result, data)
+		},
+	})
+}
+
+// NewRearPortTemplatesDataSource returns the netbox_rear_port_templates query data source.
+func NewRearPortTemplatesDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.RearPortTemplate, RearPortTemplateDataSourceModel]{
+		typeName:   "rear_port_templates",
+		objectName: "rear port templates",
+		apiPath:    "dcim/rear-port-templates",
+		singular:   NewRearPortTemplateDataSource,
+		mapResult: func(ctx context.Context, result *netbox.RearPortTemplate, data *RearPortTemplateDataSourceModel, diags *diag.Diagnostics) {
+			(&RearPortTemplateDataSource{}).mapResponseToModel(result, data)
+		},
+	})
+}
+
+// NewRegionsDataSource returns the netbox_regions query data source.
+func NewRegionsDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.Region, RegionDataSourceModel]{
+		typeName:   "regions",
+		objectName: "regions",
+		apiPath:    "dcim/regions",
+		singular:   NewRegionDataSource,
+		mapResult: func(ctx context.Context, result *netbox.Region, data *RegionDataSourceModel, diags *diag.Diagnostics) {
+			(&RegionDataSource{}).mapRegionToState(ctx, result, data)
+		},
+	})
+}
+
+// NewRIRsDataSource returns the netbox_rirs query data source.
+func NewRIRsDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.RIR, RIRDataSourceModel]{
+		typeName:   "rirs",
+		objectName: "RIRs",
+		apiPath:    "ipam/rirs",
+		singular:   NewRIRDataSource,
+		mapResult: func(ctx context.Context, result *netbox.RIR, data *RIRDataSourceModel, diags *diag.Diagnostics) {
+			(&RIRDataSource{}).mapRIRToDataSourceModel(ctx, result, data)
+		},
+	})
+}
+
+// NewRolesDataSource returns the netbox_roles query data source.
+func NewRolesDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.Role, RoleDataSourceModel]{
+		typeName:   "roles",
+		objectName: "roles",
+		apiPath:    "ipam/roles",
+		singular:   NewRoleDataSource,
+		mapResult: func(ctx context.Context, result *netbox.Role, data *RoleDataSourceModel, diags *diag.Diagnostics) {
+			(&RoleDataSource{}).mapResponseToModel(ctx, result, data, diags)
+		},
+	})
+}
+
+// NewRouteTargetsDataSource returns the netbox_route_targets query data source.
+func NewRouteTargetsDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.RouteTarget, RouteTargetDataSourceModel]{
+		typeName:   "route_targets",
+		objectName: "route targets",
+		apiPath:    "ipam/route-targets",
+		singular:   NewRouteTargetDataSource,
+		mapResult: func(ctx context.Context, result *netbox.RouteTarget, data *RouteTargetDataSourceModel, diags *diag.Diagnostics) {
+			(&RouteTargetDataSource{}).mapRouteTargetToDataSourceModel(ctx, result, data)
+		},
+	})
+}
+
+// NewScriptsDataSource returns the netbox_scripts query data source.
+func NewScriptsDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.Script, ScriptDataSourceModel]{
+		typeName:   "scripts",
+		objectName: "scripts",
+		apiPath:    "extras/scripts",
+		singular:   NewScriptDataSource,
+		mapResult: func(ctx context.Context, result *netbox.Script, data *ScriptDataSourceModel, diags *diag.Diagnostics) {
+			(&ScriptDataSource{}).mapResponseToState(result, data)
+		},
+	})
+}
+
+// NewServicesDataSource returns the netbox_services query data source.
+func NewServicesDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.Service, ServiceDataSourceModel]{
+		typeName:   "services",
+		objectName: "services",
+		apiPath:    "ipam/services",
+		singular:   NewServiceDataSource,
+		mapResult: func(ctx context.Context, result *netbox.Service, data *ServiceDataSourceModel, diags *diag.Diagnostics) {
+			(&ServiceDataSource{}).mapResponseToModel(ctx, result, data, diags)
+		},
+	})
+}
+
+// NewServiceTemplatesDataSource returns the netbox_service_templates query data source.
+func NewServiceTemplatesDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.ServiceTemplate, ServiceTemplateDataSourceModel]{
+		typeName:   "service_templates",
+		objectName: "service templates",
+		apiPath:    "ipam/service-templates",
+		singular:   NewServiceTemplateDataSource,
+		mapResult: func(ctx context.Context, result *netbox.ServiceTemplate, data *ServiceTemplateDataSourceModel, diags *diag.Diagnostics) {
+			(&ServiceTemplateDataSource{}).mapResponseToState(ctx, result, data, diags)
+		},
+	})
+}
+
+// NewSitesDataSource returns the netbox_sites query data source.
+func NewSitesDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.Site, SiteDataSourceModel]{
+		typeName:   "sites",
+		objectName: "sites",
+		apiPath:    "dcim/sites",
+		singular:   NewSiteDataSource,
+		mapResult: func(ctx context.Context, result *netbox.Site, data *SiteDataSourceModel, diags *diag.Diagnostics) {
+			(&SiteDataSource{}).mapSiteToState(ctx, result, data)
+		},
+	})
+}
+
+// NewSiteGroupsDataSource returns the netbox_site_groups query data source.
+func NewSiteGroupsDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.SiteGroup, SiteGroupDataSourceModel]{
+		typeName:   "site_groups",
+		objectName: "site groups",
+		apiPath:    "dcim/site-groups",
+		singular:   NewSiteGroupDataSource,
+		mapResult: func(ctx context.Context, result *netbox.SiteGroup, data *SiteGroupDataSourceModel, diags *diag.Diagnostics) {
+			(&SiteGroupDataSource{}).mapSiteGroupToState(ctx, result, data)
+		},
+	})
+}
+
+// NewTagsDataSource returns the netbox_tags query data source.
+func NewTagsDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.Tag, TagDataSourceModel]{
+		typeName:   "tags",
+		objectName: "tags",
+		apiPath:    "extras/tags",
+		singular:   NewTagDataSource,
+		mapResult: func(ctx context.Context, result *netbox.Tag, data *TagDataSourceModel, diags *diag.Diagnostics) {
+			(&TagDataSource{}).mapTagToState(ctx, result, data)
+		},
+	})
+}
+
+// NewTenantsDataSource returns the netbox_tenants query data source.
+func NewTenantsDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.Tenant, TenantDataSourceModel]{
+		typeName:   "tenants",
+		objectName: "tenants",
+		apiPath:    "tenancy/tenants",
+		singular:   NewTenantDataSource,
+		mapResult: func(ctx context.Context, result *netbox.Tenant, data *TenantDataSourceModel, diags *diag.Diagnostics) {
+			(&TenantDataSource{}).mapTenantToState(ctx, result, data)
+		},
+	})
+}
+
+// NewTenantGroupsDataSource returns the netbox_tenant_groups query data source.
+func NewTenantGroupsDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.TenantGroup, TenantGroupDataSourceModel]{
+		typeName:   "tenant_groups",
+		objectName: "tenant groups",
+		apiPath:    "tenancy/tenant-groups",
+		singular:   NewTenantGroupDataSource,
+		mapResult: func(ctx context.Context, result *netbox.TenantGroup, data *TenantGroupDataSourceModel, diags *diag.Diagnostics) {
+			(&TenantGroupDataSource{}).mapTenantGroupToState(ctx, result, data, diags)
+		},
+	})
+}
+
+// NewTunnelGroupsDataSource returns the netbox_tunnel_groups query data source.
+func NewTunnelGroupsDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.TunnelGroup, TunnelGroupDataSourceModel]{
+		typeName:   "tunnel_groups",
+		objectName: "tunnel groups",
+		apiPath:    "vpn/tunnel-groups",
+		singular:   NewTunnelGroupDataSource,
+		mapResult: func(ctx context.Context, result *netbox.TunnelGroup, data *TunnelGroupDataSourceModel, diags *diag.Diagnostics) {
+			(&TunnelGroupDataSource{}).mapResponseToState(ctx, result, data, diags)
+		},
+	})
+}
+
+// NewTunnelTerminationsDataSource returns the netbox_tunnel_terminations query data source.
+func NewTunnelTerminationsDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.TunnelTermination, TunnelTerminationDataSourceModel]{
+		typeName:   "tunnel_terminations",
+		objectName: "tunnel terminations",
+		apiPath:    "vpn/tunnel-terminations",
+		singular:   NewTunnelTerminationDataSource,
+		mapResult: func(ctx context.Context, result *netbox.TunnelTermination, data *TunnelTerminationDataSourceModel, diags *diag.Diagnostics) {
+			(&TunnelTerminationDataSource{}).mapResponseToState(ctx, result, data, diags)
+		},
+	})
+}
+
+// NewTunnelsDataSource returns the netbox_tunnels query data source.
+func NewTunnelsDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.Tunnel, TunnelDataSourceModel]{
+		typeName:   "tunnels",
+		objectName: "tunnels",
+		apiPath:    "vpn/tunnels",
+		singular:   NewTunnelDataSource,
+		mapResult: func(ctx context.Context, result *netbox.Tunnel, data *TunnelDataSourceModel, diags *diag.Diagnostics) {
+			(&TunnelDataSource{}).mapResponseToState(ctx, result, data, diags)
+		},
+	})
+}
+
+// NewUsersDataSource returns the netbox_users query data source.
+func NewUsersDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.User, UserDataSourceModel]{
+		typeName:   "users",
+		objectName: "users",
+		apiPath:    "users/users",
+		singular:   NewUserDataSource,
+		mapResult: func(ctx context.Context, result *netbox.User, data *UserDataSourceModel, diags *diag.Diagnostics) {
+			(&UserDataSource{}).mapResponseToState(ctx, result, data, diags)
+		},
+	})
+}
+
+// NewVirtualChassisListDataSource returns the netbox_virtual_chassis_list query data source.
+func NewVirtualChassisListDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.VirtualChassis, VirtualChassisDataSourceModel]{
+		typeName:   "virtual_chassis_list",
+		objectName: "virtual chassis",
+		apiPath:    "dcim/virtual-chassis",
+		singular:   NewVirtualChassisDataSource,
+		mapResult: func(ctx context.Context, result *netbox.VirtualChassis, data *VirtualChassisDataSourceModel, diags *diag.Diagnostics) {
+			(&VirtualChassisDataSource{}).mapResponseToModel(ctx, result, data, diags)
+		},
+	})
+}
+
+// NewVirtualDeviceContextsDataSource returns the netbox_virtual_device_contexts query data source.
+func NewVirtualDeviceContextsDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.VirtualDeviceContext, VirtualDeviceContextDataSourceModel]{
+		typeName:   "virtual_device_contexts",
+		objectName: "virtual device contexts",
+		apiPath:    "dcim/virtual-device-contexts",
+		singular:   NewVirtualDeviceContextDataSource,
+		mapResult: func(ctx context.Context, result *netbox.VirtualDeviceContext, data *VirtualDeviceContextDataSourceModel, diags *diag.Diagnostics) {
+			(&VirtualDeviceContextDataSource{}).mapToState(ctx, result, data, diags)
+		},
+	})
+}
+
+// NewVirtualDisksDataSource returns the netbox_virtual_disks query data source.
+func NewVirtualDisksDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.VirtualDisk, VirtualDiskDataSourceModel]{
+		typeName:   "virtual_disks",
+		objectName: "virtual disks",
+		apiPath:    "virtualization/virtual-disks",
+		singular:   NewVirtualDiskDataSource,
+		mapResult: func(ctx context.Context, result *netbox.VirtualDisk, data *VirtualDiskDataSourceModel, diags *diag.Diagnostics) {
+			(&VirtualDiskDataSource{}).mapVirtualDiskToDataSourceModel(ctx, result, data, diags)
+		},
+	})
+}
+
+// NewVirtualMachinesDataSource returns the netbox_virtual_machines query data source.
+func NewVirtualMachinesDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.VirtualMachineWithConfigContext, VirtualMachineDataSourceModel]{
+		typeName:   "virtual_machines",
+		objectName: "virtual machines",
+		apiPath:    "virtualization/virtual-machines",
+		singular:   NewVirtualMachineDataSource,
+		values: &queryValuesSpec{
+			attribute:       "names",
+			resultAttribute: "name",
+			description:     "Best-effort list of virtual machine names that match the query.",
+		},
+		mapResult: func(ctx context.Context, result *netbox.VirtualMachineWithConfigContext, data *VirtualMachineDataSourceModel, diags *diag.Diagnostics) {
+			(&VirtualMachineDataSource{}).mapVirtualMachineToState(ctx, result, data, diags)
+		},
+	})
+}
+
+// NewVLANsDataSource returns the netbox_vlans query data source.
+func NewVLANsDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.VLAN, VLANDataSourceModel]{
+		typeName:   "vlans",
+		objectName: "VLANs",
+		apiPath:    "ipam/vlans",
+		singular:   NewVLANDataSource,
+		mapResult: func(ctx context.Context, result *netbox.VLAN, data *VLANDataSourceModel, diags *diag.Diagnostics) {
+			(&VLANDataSource{}).mapVLANToState(ctx, result, data, diags)
+		},
+	})
+}
+
+// NewVLANGroupsDataSource returns the netbox_vlan_groups query data source.
+func NewVLANGroupsDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.VLANGroup, VLANGroupDataSourceModel]{
+		typeName:   "vlan_groups",
+		objectName: "VLAN groups",
+		apiPath:    "ipam/vlan-groups",
+		singular:   NewVLANGroupDataSource,
+		mapResult: func(ctx context.Context, result *netbox.VLANGroup, data *VLANGroupDataSourceModel, diags *diag.Diagnostics) {
+			(&VLANGroupDataSource{}).mapVLANGroupToState(ctx, result, data, diags)
+		},
+	})
+}
+
+// NewVMInterfacesDataSource returns the netbox_vm_interfaces query data source.
+func NewVMInterfacesDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.VMInterface, VMInterfaceDataSourceModel]{
+		typeName:   "vm_interfaces",
+		objectName: "VM interfaces",
+		apiPath:    "virtualization/interfaces",
+		singular:   NewVMInterfaceDataSource,
+		mapResult: func(ctx context.Context, result *netbox.VMInterface, data *VMInterfaceDataSourceModel, diags *diag.Diagnostics) {
+			(&VMInterfaceDataSource{}).mapResponseToState(ctx, result, data, diags)
+		},
+	})
+}
+
+// NewVRFsDataSource returns the netbox_vrfs query data source.
+func NewVRFsDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.VRF, VRFDataSourceModel]{
+		typeName:   "vrfs",
+		objectName: "VRFs",
+		apiPath:    "ipam/vrfs",
+		singular:   NewVRFDataSource,
+		mapResult: func(ctx context.Context, result *netbox.VRF, data *VRFDataSourceModel, diags *diag.Diagnostics) {
+			(&VRFDataSource{}).mapVRFToState(ctx, result, data, diags)
+		},
+	})
+}
+
+// NewWebhooksDataSource returns the netbox_webhooks query data source.
+func NewWebhooksDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.Webhook, WebhookDataSourceModel]{
+		typeName:   "webhooks",
+		objectName: "webhooks",
+		apiPath:    "extras/webhooks",
+		singular:   NewWebhookDataSource,
+		mapResult: func(ctx context.Context, result *netbox.Webhook, data *WebhookDataSourceModel, diags *diag.Diagnostics) {
+			(&WebhookDataSource{}).mapWebhookToState(ctx, result, data, diags)
+		},
+	})
+}
+
+// NewWirelessLANGroupsDataSource returns the netbox_wireless_lan_groups query data source.
+func NewWirelessLANGroupsDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.WirelessLANGroup, WirelessLANGroupDataSourceModel]{
+		typeName:   "wireless_lan_groups",
+		objectName: "wireless LAN groups",
+		apiPath:    "wireless/wireless-lan-groups",
+		singular:   NewWirelessLANGroupDataSource,
+		mapResult: func(ctx context.Context, result *netbox.WirelessLANGroup, data *WirelessLANGroupDataSourceModel, diags *diag.Diagnostics) {
+			(&WirelessLANGroupDataSource{}).mapResponseToState(ctx, result, data, diags)
+		},
+	})
+}
+
+// NewWirelessLANsDataSource returns the netbox_wireless_lans query data source.
+func NewWirelessLANsDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.WirelessLAN, WirelessLANDataSourceModel]{
+		typeName:   "wireless_lans",
+		objectName: "wireless LANs",
+		apiPath:    "wireless/wireless-lans",
+		singular:   NewWirelessLANDataSource,
+		mapResult: func(ctx context.Context, result *netbox.WirelessLAN, data *WirelessLANDataSourceModel, diags *diag.Diagnostics) {
+			(&WirelessLANDataSource{}).mapResponseToState(ctx, result, data, diags)
+		},
+	})
+}
+
+// NewWirelessLinksDataSource returns the netbox_wireless_links query data source.
+func NewWirelessLinksDataSource() datasource.DataSource {
+	return newQueryDataSource(queryDataSourceSpec[netbox.WirelessLink, WirelessLinkDataSourceModel]{
+		typeName:   "wireless_links",
+		objectName: "wireless links",
+		apiPath:    "wireless/wireless-links",
+		singular:   NewWirelessLinkDataSource,
+		mapResult: func(ctx context.Context, result *netbox.WirelessLink, data *WirelessLinkDataSourceModel, diags *diag.Diagnostics) {
+			(&WirelessLinkDataSource{}).mapToState(ctx, result, data)
+		},
+	})
+}
@@ -1,12 +1,6 @@
 package datasources
 
 const (
-	filterKeyName             = "name"
-	filterKeyNameIc           = "name__ic"
-	filterKeyStatus           = "status"
-	filterKeySite             = "site"
-	filterKeyTag              = "tag"
-	filterKeyQ                = "q"
 	filterKeyCustomField      = "custom_field"
 	filterKeyCustomFieldValue = "custom_field_value"
 )
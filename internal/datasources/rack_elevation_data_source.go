@@ -0,0 +1,341 @@
+package datasources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/bab3l/go-netbox"
+	"github.com/bab3l/terraform-provider-netbox/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ datasource.DataSource              = &RackElevationDataSource{}
+	_ datasource.DataSourceWithConfigure = &RackElevationDataSource{}
+)
+
+// Rack unit occupancy states reported by the netbox_rack_elevation data source.
+const (
+	rackUnitStatusDevice      = "device"
+	rackUnitStatusReservation = "reservation"
+	rackUnitStatusFree        = "free"
+)
+
+// rackFaces lists the rack faces in the order they are reported.
+var rackFaces = []string{"front", "rear"}
+
+func NewRackElevationDataSource() datasource.DataSource {
+	return &RackElevationDataSource{}
+}
+
+// RackElevationDataSource reports per-unit occupancy of both faces of a rack.
+type RackElevationDataSource struct {
+	client *netbox.APIClient
+}
+
+type RackElevationDataSourceModel struct {
+	RackID types.String `tfsdk:"rack_id"`
+	Front  types.List   `tfsdk:"front"`
+	Rear   types.List   `tfsdk:"rear"`
+}
+
+var rackElevationUnitAttrTypes = map[string]attr.Type{
+	"id":             types.Float64Type,
+	"name":           types.StringType,
+	"status":         types.StringType,
+	"device_id":      types.StringType,
+	"device":         types.StringType,
+	"reservation_id": types.StringType,
+}
+
+func (d *RackElevationDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_rack_elevation"
+}
+
+func (d *RackElevationDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	unitsAttribute := func(face string) schema.ListNestedAttribute {
+		return schema.ListNestedAttribute{
+			MarkdownDescription: fmt.Sprintf("Units of the %s face, in the order returned by NetBox. Units occupied by full-depth devices mounted on the other face are reported as occupied by that device.", face),
+			Computed:            true,
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"id": schema.Float64Attribute{
+						MarkdownDescription: "Unit position. Half units (e.g. `42.5`) are included when NetBox reports them.",
+						Computed:            true,
+					},
+					"name": schema.StringAttribute{
+						MarkdownDescription: "Unit name, e.g. `U42`.",
+						Computed:            true,
+					},
+					"status": schema.StringAttribute{
+						MarkdownDescription: "Occupancy of the unit: `device`, `reservation` or `free`. A unit occupied by a device is reported as `device` even when it is also reserved.",
+						Computed:            true,
+					},
+					"device_id": schema.StringAttribute{
+						MarkdownDescription: "ID of the device occupying the unit, if any.",
+						Computed:            true,
+					},
+					"device": schema.StringAttribute{
+						MarkdownDescription: "Name (or display name for unnamed devices) of the device occupying the unit, if any.",
+						Computed:            true,
+					},
+					"reservation_id": schema.StringAttribute{
+						MarkdownDescription: "ID of the rack reservation covering the unit, if any.",
+						Computed:            true,
+					},
+				},
+			},
+		}
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Use this data source to get the elevation of a rack in NetBox: the occupancy of every unit on the front and rear face, taken from `/api/dcim/racks/{id}/elevation/` and the rack's reservations. Use `netbox_rack_free_units` to find a free position for a new device.",
+		Attributes: map[string]schema.Attribute{
+			"rack_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the rack.",
+				Required:            true,
+			},
+			"front": unitsAttribute("front"),
+			"rear":  unitsAttribute("rear"),
+		},
+	}
+}
+
+func (d *RackElevationDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*netbox.APIClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *netbox.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.client = client
+}
+
+func (d *RackElevationDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data RackElevationDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rackID, err := utils.ParseID(data.RackID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid rack ID", fmt.Sprintf("Could not parse rack ID %q: %s", data.RackID.ValueString(), err))
+		return
+	}
+	tflog.Debug(ctx, "Reading rack elevation", map[string]interface{}{"rack_id": rackID})
+
+	elevation := readRackElevation(ctx, d.client, rackID, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Front = rackUnitsToList(elevation["front"], &resp.Diagnostics)
+	data.Rear = rackUnitsToList(elevation["rear"], &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// rackUnit is the occupancy of a single unit on one face of a rack.
+type rackUnit struct {
+	ID            float64
+	Name          string
+	DeviceID      int64
+	Device        string
+	ReservationID int64
+}
+
+// Status returns the occupancy state of the unit.
+func (u rackUnit) Status() string {
+	switch {
+	case u.DeviceID != 0:
+		return rackUnitStatusDevice
+	case u.ReservationID != 0:
+		return rackUnitStatusReservation
+	default:
+		return rackUnitStatusFree
+	}
+}
+
+// rackElevationUnit mirrors an element of the NetBox elevation endpoint. The
+// unit ID is a decimal that NetBox may render as a string.
+type rackElevationUnit struct {
+	ID     json.Number `json:"id"`
+	Name   string      `json:"name"`
+	Device *struct {
+		ID      int64   `json:"id"`
+		Name    *string `json:"name"`
+		Display string  `json:"display"`
+	} `json:"device"`
+}
+
+// readRackElevation returns the units of both faces of a rack, keyed by face,
+// with rack reservations applied.
+func readRackElevation(ctx context.Context, client *netbox.APIClient, rackID int32, diags *diag.Diagnostics) map[string][]rackUnit {
+	reserved := readRackReservations(ctx, client, rackID, diags)
+	if diags.HasError() {
+		return nil
+	}
+
+	elevation := make(map[string][]rackUnit, len(rackFaces))
+	for _, face := range rackFaces {
+		apiPath := fmt.Sprintf("dcim/racks/%d/elevation", rackID)
+		results, httpResp, err := utils.ListRawAPIObjects(ctx, client, apiPath, url.Values{"face": {face}})
+		if err != nil {
+			if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
+				diags.AddError("Rack Not Found", fmt.Sprintf("No rack found with ID: %d", rackID))
+				return nil
+			}
+			diags.AddError(
+				"Error reading rack elevation",
+				utils.FormatAPIError(fmt.Sprintf("read %s elevation of rack ID %d", face, rackID), err, httpResp),
+			)
+			return nil
+		}
+
+		units := make([]rackUnit, 0, len(results))
+		for _, raw := range results {
+			var item rackElevationUnit
+			if err := json.Unmarshal(raw, &item); err != nil {
+				diags.AddError("Error reading rack elevation", fmt.Sprintf("Could not decode rack unit: %s", err))
+				return nil
+			}
+			id, err := item.ID.Float64()
+			if err != nil {
+				diags.AddError("Error reading rack elevation", fmt.Sprintf("Invalid rack unit ID %q: %s", item.ID, err))
+				return nil
+			}
+
+			unit := rackUnit{ID: id, Name: item.Name, ReservationID: reserved[int64(math.Floor(id))]}
+			if item.Device != nil && item.Device.ID != 0 {
+				unit.DeviceID = item.Device.ID
+				unit.Device = item.Device.Display
+				if item.Device.Name != nil && *item.Device.Name != "" {
+					unit.Device = *item.Device.Name
+				}
+			}
+			units = append(units, unit)
+		}
+		elevation[face] = units
+	}
+	return elevation
+}
+
+// readRackReservations maps every reserved unit of a rack to its reservation ID.
+func readRackReservations(ctx context.Context, client *netbox.APIClient, rackID int32, diags *diag.Diagnostics) map[int64]int64 {
+	results, httpResp, err := utils.ListRawAPIObjects(ctx, client, "dcim/rack-reservations", url.Values{"rack_id": {strconv.Itoa(int(rackID))}})
+	if err != nil {
+		diags.AddError(
+			"Error reading rack reservations",
+			utils.FormatAPIError(fmt.Sprintf("list reservations of rack ID %d", rackID), err, httpResp),
+		)
+		return nil
+	}
+
+	reserved := map[int64]int64{}
+	for _, raw := range results {
+		var reservation struct {
+			ID    int64   `json:"id"`
+			Units []int64 `json:"units"`
+		}
+		if err := json.Unmarshal(raw, &reservation); err != nil {
+			diags.AddError("Error reading rack reservations", fmt.Sprintf("Could not decode rack reservation: %s", err))
+			return nil
+		}
+		for _, u := range reservation.Units {
+			reserved[u] = reservation.ID
+		}
+	}
+	return reserved
+}
+
+func rackUnitsToList(units []rackUnit, diags *diag.Diagnostics) types.List {
+	objectType := types.ObjectType{AttrTypes: rackElevationUnitAttrTypes}
+	optionalID := func(id int64) types.String {
+		if id == 0 {
+			return types.StringNull()
+		}
+		return types.StringValue(strconv.FormatInt(id, 10))
+	}
+
+	values := make([]attr.Value, 0, len(units))
+	for _, unit := range units {
+		device := types.StringNull()
+		if unit.DeviceID != 0 {
+			device = types.StringValue(unit.Device)
+		}
+		value, valueDiags := types.ObjectValue(rackElevationUnitAttrTypes, map[string]attr.Value{
+			"id":             types.Float64Value(unit.ID),
+			"name":           types.StringValue(unit.Name),
+			"status":         types.StringValue(unit.Status()),
+			"device_id":      optionalID(unit.DeviceID),
+			"device":         device,
+			"reservation_id": optionalID(unit.ReservationID),
+		})
+		diags.Append(valueDiags...)
+		values = append(values, value)
+	}
+
+	list, listDiags := types.ListValue(objectType, values)
+	diags.Append(listDiags...)
+	return list
+}
+
+// freeRackPositions returns, in ascending order, every whole-unit position at
+// which a device of the given height fits without overlapping an occupied or
+// reserved unit on any of the given faces.
+func freeRackPositions(faces [][]rackUnit, height float64) []float64 {
+	if len(faces) == 0 || height <= 0 {
+		return nil
+	}
+
+	busy := map[float64]bool{}
+	low, high, step := math.Inf(1), math.Inf(-1), 1.0
+	for _, units := range faces {
+		for _, unit := range units {
+			if unit.Status() != rackUnitStatusFree {
+				busy[unit.ID] = true
+			}
+			low = math.Min(low, unit.ID)
+			high = math.Max(high, unit.ID)
+			if unit.ID != math.Trunc(unit.ID) {
+				step = 0.5
+			}
+		}
+	}
+	if math.IsInf(low, 0) {
+		return nil
+	}
+
+	var positions []float64
+	for start := math.Ceil(low); start+height <= high+step; start++ {
+		fits := true
+		for u := start; u < start+height; u += step {
+			if busy[u] {
+				fits = false
+				break
+			}
+		}
+		if fits {
+			positions = append(positions, start)
+		}
+	}
+	return positions
+}
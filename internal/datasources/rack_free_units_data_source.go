@@ -0,0 +1,154 @@
+package datasources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bab3l/go-netbox"
+	"github.com/bab3l/terraform-provider-netbox/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ datasource.DataSource              = &RackFreeUnitsDataSource{}
+	_ datasource.DataSourceWithConfigure = &RackFreeUnitsDataSource{}
+)
+
+func NewRackFreeUnitsDataSource() datasource.DataSource {
+	return &RackFreeUnitsDataSource{}
+}
+
+// RackFreeUnitsDataSource finds the positions in a rack where a device of a
+// given height fits.
+type RackFreeUnitsDataSource struct {
+	client *netbox.APIClient
+}
+
+type RackFreeUnitsDataSourceModel struct {
+	RackID             types.String  `tfsdk:"rack_id"`
+	UHeight            types.Float64 `tfsdk:"u_height"`
+	Face               types.String  `tfsdk:"face"`
+	IsFullDepth        types.Bool    `tfsdk:"is_full_depth"`
+	Position           types.Float64 `tfsdk:"position"`
+	AvailablePositions types.List    `tfsdk:"available_positions"`
+}
+
+func (d *RackFreeUnitsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_rack_free_units"
+}
+
+func (d *RackFreeUnitsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Use this data source to find the lowest contiguous free position in a rack for a device of a given height, so that `netbox_device.position` can be computed instead of hard-coded. Units occupied by devices or covered by a rack reservation are not free. Note that the result changes once the device is placed; use `lifecycle { ignore_changes = [position] }` on the device if it should stay where it was first racked.",
+		Attributes: map[string]schema.Attribute{
+			"rack_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the rack.",
+				Required:            true,
+			},
+			"u_height": schema.Float64Attribute{
+				MarkdownDescription: "Height of the device in rack units, typically the `u_height` of its device type.",
+				Required:            true,
+				Validators: []validator.Float64{
+					float64validator.AtLeast(0.5),
+				},
+			},
+			"face": schema.StringAttribute{
+				MarkdownDescription: "Rack face the device is mounted on: `front` or `rear`.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(rackFaces...),
+				},
+			},
+			"is_full_depth": schema.BoolAttribute{
+				MarkdownDescription: "Whether the device occupies both faces of the rack. Defaults to `true`, matching NetBox's default for device types. When `false`, only units on `face` need to be free.",
+				Optional:            true,
+			},
+			"position": schema.Float64Attribute{
+				MarkdownDescription: "The lowest position at which the device fits.",
+				Computed:            true,
+			},
+			"available_positions": schema.ListAttribute{
+				MarkdownDescription: "Every position at which the device fits, in ascending order.",
+				Computed:            true,
+				ElementType:         types.Float64Type,
+			},
+		},
+	}
+}
+
+func (d *RackFreeUnitsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*netbox.APIClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *netbox.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.client = client
+}
+
+func (d *RackFreeUnitsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data RackFreeUnitsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rackID, err := utils.ParseID(data.RackID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid rack ID", fmt.Sprintf("Could not parse rack ID %q: %s", data.RackID.ValueString(), err))
+		return
+	}
+	height := data.UHeight.ValueFloat64()
+	face := data.Face.ValueString()
+	fullDepth := data.IsFullDepth.IsNull() || data.IsFullDepth.ValueBool()
+	tflog.Debug(ctx, "Finding free rack units", map[string]interface{}{
+		"rack_id":       rackID,
+		"u_height":      height,
+		"face":          face,
+		"is_full_depth": fullDepth,
+	})
+
+	elevation := readRackElevation(ctx, d.client, rackID, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// NetBox already reports units taken by full-depth devices on both faces,
+	// so a half-depth device only has to check its own face.
+	faces := [][]rackUnit{elevation[face]}
+	if fullDepth {
+		faces = [][]rackUnit{elevation["front"], elevation["rear"]}
+	}
+	positions := freeRackPositions(faces, height)
+	if len(positions) == 0 {
+		faceDescription := fmt.Sprintf("the %s face", face)
+		if fullDepth {
+			faceDescription = "both faces"
+		}
+		resp.Diagnostics.AddError(
+			"Insufficient rack space",
+			fmt.Sprintf("Rack ID %d has no %g contiguous free units on %s.", rackID, height, faceDescription),
+		)
+		return
+	}
+
+	available, diags := types.ListValueFrom(ctx, types.Float64Type, positions)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Position = types.Float64Value(positions[0])
+	data.AvailablePositions = available
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/bab3l/terraform-provider-netbox/internal/utils"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -209,7 +210,16 @@ func (d *RackRoleDataSource) Read(ctx context.Context, req datasource.ReadReques
 		return
 	}
 
-	// Update the model with the response from the API
+	d.mapResponseToState(ctx, rackRole, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// mapResponseToState maps a rack role from the API to the data source model.
+func (d *RackRoleDataSource) mapResponseToState(ctx context.Context, rackRole *netbox.RackRole, data *RackRoleDataSourceModel, diags *diag.Diagnostics) {
 	data.ID = types.StringValue(fmt.Sprintf("%d", rackRole.GetId()))
 	data.Name = types.StringValue(rackRole.GetName())
 	data.Slug = types.StringValue(rackRole.GetSlug())
@@ -231,9 +241,9 @@ func (d *RackRoleDataSource) Read(ctx context.Context, req datasource.ReadReques
 	// Handle tags
 	if rackRole.HasTags() {
 		tags := utils.NestedTagsToTagModels(rackRole.GetTags())
-		tagsValue, diags := types.SetValueFrom(ctx, utils.GetTagsAttributeType().ElemType, tags)
-		resp.Diagnostics.Append(diags...)
-		if resp.Diagnostics.HasError() {
+		tagsValue, tagDiags := types.SetValueFrom(ctx, utils.GetTagsAttributeType().ElemType, tags)
+		diags.Append(tagDiags...)
+		if diags.HasError() {
 			return
 		}
 		data.Tags = tagsValue
@@ -242,8 +252,5 @@ func (d *RackRoleDataSource) Read(ctx context.Context, req datasource.ReadReques
 	}
 
 	// Handle custom fields
-	data.CustomFields = utils.CustomFieldsSetFromAPI(ctx, rackRole.HasCustomFields(), rackRole.GetCustomFields(), &resp.Diagnostics)
-
-	// Save data into Terraform state
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	data.CustomFields = utils.CustomFieldsSetFromAPI(ctx, rackRole.HasCustomFields(), rackRole.GetCustomFields(), diags)
 }
@@ -15,6 +15,7 @@ import (
 	"github.com/bab3l/terraform-provider-netbox/internal/utils"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -194,7 +195,16 @@ func (d *TunnelDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
-	// Update the model with the response from the API
+	d.mapResponseToState(ctx, tunnel, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// mapResponseToState maps a tunnel from the API to the data source model.
+func (d *TunnelDataSource) mapResponseToState(ctx context.Context, tunnel *netbox.Tunnel, data *TunnelDataSourceModel, diags *diag.Diagnostics) {
 	data.ID = types.StringValue(fmt.Sprintf("%d", tunnel.GetId()))
 	data.Name = types.StringValue(tunnel.GetName())
 	data.Status = types.StringValue(string(tunnel.Status.GetValue()))
@@ -262,9 +272,9 @@ func (d *TunnelDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	// Handle tags
 	if tunnel.HasTags() {
 		tags := utils.NestedTagsToTagModels(tunnel.GetTags())
-		tagsValue, diags := types.SetValueFrom(ctx, utils.GetTagsAttributeType().ElemType, tags)
-		resp.Diagnostics.Append(diags...)
-		if resp.Diagnostics.HasError() {
+		tagsValue, tagDiags := types.SetValueFrom(ctx, utils.GetTagsAttributeType().ElemType, tags)
+		diags.Append(tagDiags...)
+		if diags.HasError() {
 			return
 		}
 		data.Tags = tagsValue
@@ -273,8 +283,5 @@ func (d *TunnelDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	}
 
 	// Handle custom fields
-	data.CustomFields = utils.CustomFieldsSetFromAPI(ctx, tunnel.HasCustomFields(), tunnel.GetCustomFields(), &resp.Diagnostics)
-
-	// Save data into Terraform state
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	data.CustomFields = utils.CustomFieldsSetFromAPI(ctx, tunnel.HasCustomFields(), tunnel.GetCustomFields(), diags)
 }
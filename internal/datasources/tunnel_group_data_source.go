@@ -16,6 +16,7 @@ import (
 	"github.com/bab3l/terraform-provider-netbox/internal/utils"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -209,7 +210,16 @@ func (d *TunnelGroupDataSource) Read(ctx context.Context, req datasource.ReadReq
 		return
 	}
 
-	// Update the model with the response from the API
+	d.mapResponseToState(ctx, tunnelGroup, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// mapResponseToState maps a tunnel group from the API to the data source model.
+func (d *TunnelGroupDataSource) mapResponseToState(ctx context.Context, tunnelGroup *netbox.TunnelGroup, data *TunnelGroupDataSourceModel, diags *diag.Diagnostics) {
 	data.ID = types.StringValue(fmt.Sprintf("%d", tunnelGroup.GetId()))
 	data.Name = types.StringValue(tunnelGroup.GetName())
 	data.Slug = types.StringValue(tunnelGroup.GetSlug())
@@ -231,9 +241,9 @@ func (d *TunnelGroupDataSource) Read(ctx context.Context, req datasource.ReadReq
 	// Handle tags
 	if tunnelGroup.HasTags() {
 		tags := utils.NestedTagsToTagModels(tunnelGroup.GetTags())
-		tagsValue, diags := types.SetValueFrom(ctx, utils.GetTagsAttributeType().ElemType, tags)
-		resp.Diagnostics.Append(diags...)
-		if resp.Diagnostics.HasError() {
+		tagsValue, tagDiags := types.SetValueFrom(ctx, utils.GetTagsAttributeType().ElemType, tags)
+		diags.Append(tagDiags...)
+		if diags.HasError() {
 			return
 		}
 		data.Tags = tagsValue
@@ -242,8 +252,5 @@ func (d *TunnelGroupDataSource) Read(ctx context.Context, req datasource.ReadReq
 	}
 
 	// Handle custom fields
-	data.CustomFields = utils.CustomFieldsSetFromAPI(ctx, tunnelGroup.HasCustomFields(), tunnelGroup.GetCustomFields(), &resp.Diagnostics)
-
-	// Save data into Terraform state
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	data.CustomFields = utils.CustomFieldsSetFromAPI(ctx, tunnelGroup.HasCustomFields(), tunnelGroup.GetCustomFields(), diags)
 }
@@ -15,6 +15,7 @@ import (
 	"github.com/bab3l/terraform-provider-netbox/internal/utils"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -229,7 +230,15 @@ func (d *TunnelTerminationDataSource) Read(ctx context.Context, req datasource.R
 		tunnelTermination = &list.Results[0]
 	}
 
-	// Map response to state
+	d.mapResponseToState(ctx, tunnelTermination, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// mapResponseToState maps a tunnel termination from the API to the data source model.
+func (d *TunnelTerminationDataSource) mapResponseToState(ctx context.Context, tunnelTermination *netbox.TunnelTermination, data *TunnelTerminationDataSourceModel, diags *diag.Diagnostics) {
 	data.ID = types.StringValue(fmt.Sprintf("%d", tunnelTermination.GetId()))
 	data.Tunnel = types.StringValue(fmt.Sprintf("%d", tunnelTermination.Tunnel.GetId()))
 	data.TunnelName = types.StringValue(tunnelTermination.Tunnel.GetName())
@@ -271,8 +280,8 @@ func (d *TunnelTerminationDataSource) Read(ctx context.Context, req datasource.R
 	if tunnelTermination.HasTags() && len(tunnelTermination.GetTags()) > 0 {
 		tags := utils.NestedTagsToTagModels(tunnelTermination.GetTags())
 		tagsValue, tagsDiags := types.SetValueFrom(ctx, utils.GetTagsAttributeType().ElemType, tags)
-		resp.Diagnostics.Append(tagsDiags...)
-		if resp.Diagnostics.HasError() {
+		diags.Append(tagsDiags...)
+		if diags.HasError() {
 			return
 		}
 		data.Tags = tagsValue
@@ -281,7 +290,5 @@ func (d *TunnelTerminationDataSource) Read(ctx context.Context, req datasource.R
 	}
 
 	// Handle custom fields
-	data.CustomFields = utils.CustomFieldsSetFromAPI(ctx, tunnelTermination.HasCustomFields(), tunnelTermination.GetCustomFields(), &resp.Diagnostics)
-
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	data.CustomFields = utils.CustomFieldsSetFromAPI(ctx, tunnelTermination.HasCustomFields(), tunnelTermination.GetCustomFields(), diags)
 }
@@ -14,6 +14,7 @@ import (
 	"github.com/bab3l/terraform-provider-netbox/internal/utils"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -183,7 +184,21 @@ func (d *UserDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 		return
 	}
 
-	// Map response to model
+	d.mapResponseToState(ctx, user, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Trace(ctx, "read user data source", map[string]interface{}{
+		"id":       data.ID.ValueString(),
+		"username": data.Username.ValueString(),
+	})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// mapResponseToState maps a user from the API to the data source model.
+func (d *UserDataSource) mapResponseToState(ctx context.Context, user *netbox.User, data *UserDataSourceModel, diags *diag.Diagnostics) {
 	data.ID = types.StringValue(fmt.Sprintf("%d", user.GetId()))
 	data.Username = types.StringValue(user.GetUsername())
 	if user.HasFirstName() && user.GetFirstName() != "" {
@@ -215,12 +230,4 @@ func (d *UserDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 	} else {
 		data.IsActive = types.BoolNull()
 	}
-
-	tflog.Trace(ctx, "read user data source", map[string]interface{}{
-		"id":       data.ID.ValueString(),
-		"username": data.Username.ValueString(),
-	})
-
-	// Save data into Terraform state
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
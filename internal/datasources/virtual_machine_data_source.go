@@ -12,6 +12,7 @@ import (
 	"github.com/bab3l/terraform-provider-netbox/internal/utils"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -192,7 +193,19 @@ func (d *VirtualMachineDataSource) Read(ctx context.Context, req datasource.Read
 		return
 	}
 
-	// Map response to state
+	d.mapVirtualMachineToState(ctx, vm, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "Read virtual machine", map[string]interface{}{
+		"id":   data.ID.ValueString(),
+		"name": data.Name.ValueString(),
+	})
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// mapVirtualMachineToState maps a virtual machine from the API to the data source model.
+func (d *VirtualMachineDataSource) mapVirtualMachineToState(ctx context.Context, vm *netbox.VirtualMachineWithConfigContext, data *VirtualMachineDataSourceModel, diags *diag.Diagnostics) {
 	data.ID = types.StringValue(fmt.Sprintf("%d", vm.GetId()))
 	data.Name = types.StringValue(vm.GetName())
 
@@ -298,7 +311,7 @@ func (d *VirtualMachineDataSource) Read(ctx context.Context, req datasource.Read
 	if vm.LocalContextData != nil {
 		localContextJSON, err := utils.ToJSONString(vm.LocalContextData)
 		if err != nil {
-			resp.Diagnostics.AddError("Failed to serialize local_context_data", fmt.Sprintf("Unable to serialize local_context_data to JSON: %s", err))
+			diags.AddError("Failed to serialize local_context_data", fmt.Sprintf("Unable to serialize local_context_data to JSON: %s", err))
 			return
 		}
 		if localContextJSON != "" {
@@ -318,13 +331,8 @@ func (d *VirtualMachineDataSource) Read(ctx context.Context, req datasource.Read
 	}
 
 	// Handle tags (slug list)
-	data.Tags = utils.PopulateTagsSlugListFromAPI(ctx, vm.HasTags(), vm.GetTags(), &resp.Diagnostics)
+	data.Tags = utils.PopulateTagsSlugListFromAPI(ctx, vm.HasTags(), vm.GetTags(), diags)
 
 	// Handle custom fields
-	data.CustomFields = utils.CustomFieldsSetFromAPI(ctx, vm.HasCustomFields(), vm.GetCustomFields(), &resp.Diagnostics)
-	tflog.Debug(ctx, "Read virtual machine", map[string]interface{}{
-		"id":   data.ID.ValueString(),
-		"name": data.Name.ValueString(),
-	})
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	data.CustomFields = utils.CustomFieldsSetFromAPI(ctx, vm.HasCustomFields(), vm.GetCustomFields(), diags)
 }
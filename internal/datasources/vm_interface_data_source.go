@@ -10,6 +10,7 @@ import (
 	"github.com/bab3l/terraform-provider-netbox/internal/utils"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -195,7 +196,19 @@ func (d *VMInterfaceDataSource) Read(ctx context.Context, req datasource.ReadReq
 		return
 	}
 
-	// Map response to state
+	d.mapResponseToState(ctx, iface, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "Read VM interface", map[string]interface{}{
+		"id":   data.ID.ValueString(),
+		"name": data.Name.ValueString(),
+	})
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// mapResponseToState maps a VM interface from the API to the data source model.
+func (d *VMInterfaceDataSource) mapResponseToState(ctx context.Context, iface *netbox.VMInterface, data *VMInterfaceDataSourceModel, diags *diag.Diagnostics) {
 	data.ID = types.StringValue(fmt.Sprintf("%d", iface.GetId()))
 	data.Name = types.StringValue(iface.GetName())
 
@@ -265,8 +278,8 @@ func (d *VMInterfaceDataSource) Read(ctx context.Context, req datasource.ReadReq
 			vlanNames = append(vlanNames, vlan.GetName())
 		}
 		taggedValue, taggedDiags := types.ListValueFrom(ctx, types.StringType, vlanNames)
-		resp.Diagnostics.Append(taggedDiags...)
-		if resp.Diagnostics.HasError() {
+		diags.Append(taggedDiags...)
+		if diags.HasError() {
 			return
 		}
 		data.TaggedVLANs = taggedValue
@@ -289,13 +302,8 @@ func (d *VMInterfaceDataSource) Read(ctx context.Context, req datasource.ReadReq
 	}
 
 	// Handle tags (slug list)
-	data.Tags = utils.PopulateTagsSlugListFromAPI(ctx, iface.HasTags(), iface.GetTags(), &resp.Diagnostics)
+	data.Tags = utils.PopulateTagsSlugListFromAPI(ctx, iface.HasTags(), iface.GetTags(), diags)
 
 	// Handle custom fields
-	data.CustomFields = utils.CustomFieldsSetFromAPI(ctx, iface.HasCustomFields(), iface.GetCustomFields(), &resp.Diagnostics)
-	tflog.Debug(ctx, "Read VM interface", map[string]interface{}{
-		"id":   data.ID.ValueString(),
-		"name": data.Name.ValueString(),
-	})
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	data.CustomFields = utils.CustomFieldsSetFromAPI(ctx, iface.HasCustomFields(), iface.GetCustomFields(), diags)
 }
@@ -10,6 +10,7 @@ import (
 	"github.com/bab3l/terraform-provider-netbox/internal/utils"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -226,7 +227,15 @@ func (d *WirelessLANDataSource) Read(ctx context.Context, req datasource.ReadReq
 		wlan = result
 	}
 
-	// Map response to model
+	d.mapResponseToState(ctx, wlan, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// mapResponseToState maps a wireless LAN from the API to the data source model.
+func (d *WirelessLANDataSource) mapResponseToState(ctx context.Context, wlan *netbox.WirelessLAN, data *WirelessLANDataSourceModel, diags *diag.Diagnostics) {
 	data.ID = types.StringValue(fmt.Sprintf("%d", wlan.GetId()))
 	data.SSID = types.StringValue(wlan.GetSsid())
 
@@ -303,10 +312,8 @@ func (d *WirelessLANDataSource) Read(ctx context.Context, req datasource.ReadReq
 	}
 
 	// Handle tags (slug list)
-	data.Tags = utils.PopulateTagsSlugFromAPI(ctx, wlan.HasTags(), wlan.GetTags(), data.Tags)
+	data.Tags = utils.PopulateTagsSlugFromAPI(ctx, nil, wlan.HasTags(), wlan.GetTags(), data.Tags)
 
 	// Handle custom fields - datasources return ALL fields
-	data.CustomFields = utils.CustomFieldsSetFromAPI(ctx, wlan.HasCustomFields(), wlan.GetCustomFields(), &resp.Diagnostics)
-
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	data.CustomFields = utils.CustomFieldsSetFromAPI(ctx, wlan.HasCustomFields(), wlan.GetCustomFields(), diags)
 }
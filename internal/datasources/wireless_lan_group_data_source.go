@@ -10,6 +10,7 @@ import (
 	"github.com/bab3l/terraform-provider-netbox/internal/utils"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -187,7 +188,15 @@ func (d *WirelessLANGroupDataSource) Read(ctx context.Context, req datasource.Re
 		group = result
 	}
 
-	// Map response to model
+	d.mapResponseToState(ctx, group, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// mapResponseToState maps a wireless LAN group from the API to the data source model.
+func (d *WirelessLANGroupDataSource) mapResponseToState(ctx context.Context, group *netbox.WirelessLANGroup, data *WirelessLANGroupDataSourceModel, diags *diag.Diagnostics) {
 	data.ID = types.StringValue(fmt.Sprintf("%d", group.GetId()))
 	data.Name = types.StringValue(group.GetName())
 	data.Slug = types.StringValue(group.GetSlug())
@@ -217,10 +226,8 @@ func (d *WirelessLANGroupDataSource) Read(ctx context.Context, req datasource.Re
 	}
 
 	// Handle tags (slug list)
-	data.Tags = utils.PopulateTagsSlugListFromAPI(ctx, group.HasTags(), group.GetTags(), &resp.Diagnostics)
+	data.Tags = utils.PopulateTagsSlugListFromAPI(ctx, group.HasTags(), group.GetTags(), diags)
 
 	// Handle custom fields - datasources return ALL fields
-	data.CustomFields = utils.CustomFieldsSetFromAPI(ctx, group.HasCustomFields(), group.GetCustomFields(), &resp.Diagnostics)
-
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	data.CustomFields = utils.CustomFieldsSetFromAPI(ctx, group.HasCustomFields(), group.GetCustomFields(), diags)
 }
@@ -249,7 +249,7 @@ func (d *WirelessLinkDataSource) mapToState(ctx context.Context, result *netbox.
 	}
 
 	// Map tags (slug list)
-	data.Tags = utils.PopulateTagsSlugFromAPI(ctx, result.HasTags(), result.GetTags(), data.Tags)
+	data.Tags = utils.PopulateTagsSlugFromAPI(ctx, nil, result.HasTags(), result.GetTags(), data.Tags)
 
 	// Handle custom fields - datasources return ALL fields
 	data.CustomFields = utils.CustomFieldsSetFromAPI(ctx, result.HasCustomFields(), result.GetCustomFields(), nil)
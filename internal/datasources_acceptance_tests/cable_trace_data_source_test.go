@@ -0,0 +1,159 @@
+package datasources_acceptance_tests
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/bab3l/terraform-provider-netbox/internal/testutil"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccCableTraceDataSource_patchPanel(t *testing.T) {
+	t.Parallel()
+
+	siteName := testutil.RandomName("trace-site")
+	siteSlug := testutil.RandomSlug("trace-site")
+	roleName := testutil.RandomName("trace-role")
+	roleSlug := testutil.RandomSlug("trace-role")
+	manufacturerName := testutil.RandomName("trace-mfg")
+	manufacturerSlug := testutil.RandomSlug("trace-mfg")
+	deviceTypeName := testutil.RandomName("trace-dt")
+	deviceTypeSlug := testutil.RandomSlug("trace-dt")
+	switchName := testutil.RandomName("trace-sw")
+	panelName := testutil.RandomName("trace-pp")
+	serverName := testutil.RandomName("trace-srv")
+
+	cleanup := testutil.NewCleanupResource(t)
+	cleanup.RegisterSiteCleanup(siteSlug)
+	cleanup.RegisterDeviceRoleCleanup(roleSlug)
+	cleanup.RegisterManufacturerCleanup(manufacturerSlug)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testutil.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: testutil.TestAccProtoV6ProviderFactories,
+		CheckDestroy: testutil.ComposeCheckDestroy(
+			testutil.CheckSiteDestroy,
+			testutil.CheckDeviceRoleDestroy,
+			testutil.CheckManufacturerDestroy,
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCableTraceDataSourceConfig(siteName, siteSlug, roleName, roleSlug, manufacturerName, manufacturerSlug, deviceTypeName, deviceTypeSlug, switchName, panelName, serverName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.netbox_cable_trace.switch", "termination_id", "netbox_interface.switch", "id"),
+					resource.TestCheckResourceAttr("data.netbox_cable_trace.switch", "hops.#", "2"),
+					resource.TestCheckResourceAttr("data.netbox_cable_trace.switch", "hops.0.far_end.0.object_type", "dcim.frontport"),
+					resource.TestCheckResourceAttr("data.netbox_cable_trace.switch", "hops.1.near_end.0.object_type", "dcim.rearport"),
+					resource.TestCheckResourceAttr("data.netbox_cable_trace.switch", "endpoints.#", "1"),
+					resource.TestCheckResourceAttr("data.netbox_cable_trace.switch", "endpoints.0.device", serverName),
+					resource.TestCheckResourceAttr("data.netbox_cable_trace.switch", "endpoints.0.name", "eth0"),
+					resource.TestCheckResourceAttr("data.netbox_cable_trace.switch", "is_complete", "true"),
+					resource.TestCheckResourceAttr("data.netbox_cable_trace.switch", "is_active", "true"),
+					resource.TestCheckResourceAttr("data.netbox_cable_trace.switch", "is_split", "false"),
+					resource.TestCheckResourceAttr("data.netbox_cable_trace.front", "hops.#", "1"),
+					resource.TestCheckResourceAttr("data.netbox_cable_trace.front", "endpoints.0.device", switchName),
+				),
+			},
+		},
+	})
+}
+
+func testAccCableTraceDataSourceConfig(siteName, siteSlug, roleName, roleSlug, manufacturerName, manufacturerSlug, deviceTypeName, deviceTypeSlug, switchName, panelName, serverName string) string {
+	return fmt.Sprintf(`
+resource "netbox_site" "test" {
+  name = %q
+  slug = %q
+}
+
+resource "netbox_device_role" "test" {
+  name = %q
+  slug = %q
+}
+
+resource "netbox_manufacturer" "test" {
+  name = %q
+  slug = %q
+}
+
+resource "netbox_device_type" "test" {
+  manufacturer = netbox_manufacturer.test.id
+  model        = %q
+  slug         = %q
+}
+
+resource "netbox_device" "switch" {
+  name        = %q
+  device_type = netbox_device_type.test.id
+  role        = netbox_device_role.test.id
+  site        = netbox_site.test.id
+}
+
+resource "netbox_device" "panel" {
+  name        = %q
+  device_type = netbox_device_type.test.id
+  role        = netbox_device_role.test.id
+  site        = netbox_site.test.id
+}
+
+resource "netbox_device" "server" {
+  name        = %q
+  device_type = netbox_device_type.test.id
+  role        = netbox_device_role.test.id
+  site        = netbox_site.test.id
+}
+
+resource "netbox_interface" "switch" {
+  device = netbox_device.switch.id
+  name   = "eth0"
+  type   = "1000base-t"
+}
+
+resource "netbox_interface" "server" {
+  device = netbox_device.server.id
+  name   = "eth0"
+  type   = "1000base-t"
+}
+
+resource "netbox_rear_port" "panel" {
+  device    = netbox_device.panel.id
+  name      = "Rear 1"
+  type      = "8p8c"
+  positions = 1
+}
+
+resource "netbox_front_port" "panel" {
+  device             = netbox_device.panel.id
+  name               = "Front 1"
+  type               = "8p8c"
+  rear_port          = netbox_rear_port.panel.id
+  rear_port_position = 1
+}
+
+resource "netbox_cable" "switch_to_panel" {
+  a_terminations = [{ object_type = "dcim.interface", object_id = netbox_interface.switch.id }]
+  b_terminations = [{ object_type = "dcim.frontport", object_id = netbox_front_port.panel.id }]
+  status         = "connected"
+}
+
+resource "netbox_cable" "panel_to_server" {
+  a_terminations = [{ object_type = "dcim.rearport", object_id = netbox_rear_port.panel.id }]
+  b_terminations = [{ object_type = "dcim.interface", object_id = netbox_interface.server.id }]
+  status         = "connected"
+}
+
+data "netbox_cable_trace" "switch" {
+  termination_type = "dcim.interface"
+  device           = netbox_device.switch.name
+  name             = "eth0"
+
+  depends_on = [netbox_cable.switch_to_panel, netbox_cable.panel_to_server]
+}
+
+data "netbox_cable_trace" "front" {
+  termination_type = "dcim.frontport"
+  termination_id   = netbox_front_port.panel.id
+
+  depends_on = [netbox_cable.switch_to_panel, netbox_cable.panel_to_server]
+}
+`, siteName, siteSlug, roleName, roleSlug, manufacturerName, manufacturerSlug, deviceTypeName, deviceTypeSlug, switchName, panelName, serverName)
+}
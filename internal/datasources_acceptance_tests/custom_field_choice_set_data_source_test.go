@@ -44,6 +44,8 @@ func TestAccCustomFieldChoiceSetDataSource_byID(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr("data.netbox_custom_field_choice_set.test", "name", name),
 					resource.TestCheckResourceAttr("data.netbox_custom_field_choice_set.test", "extra_choices.#", "3"),
+					resource.TestCheckResourceAttr("data.netbox_custom_field_choice_set.test", "choices.#", "3"),
+					resource.TestCheckResourceAttr("data.netbox_custom_field_choice_set.test", "choices.2.value", "opt3"),
 				),
 			},
 		},
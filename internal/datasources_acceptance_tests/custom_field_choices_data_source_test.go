@@ -0,0 +1,68 @@
+package datasources_acceptance_tests
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/bab3l/terraform-provider-netbox/internal/testutil"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccCustomFieldChoicesDataSource_basic(t *testing.T) {
+	t.Parallel()
+
+	// Custom field names only allow alphanumeric and underscores
+	name := strings.ReplaceAll(testutil.RandomName("tf_test_cf_choices"), "-", "_")
+	choiceSetName := testutil.RandomName("cfcs-choices")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testutil.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: testutil.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCustomFieldChoicesDataSourceConfig(name, choiceSetName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.netbox_custom_field_choices.test", "id", "netbox_custom_field.test", "id"),
+					resource.TestCheckResourceAttr("data.netbox_custom_field_choices.test", "choice_set", choiceSetName),
+					resource.TestCheckResourceAttrPair("data.netbox_custom_field_choices.test", "choice_set_id", "netbox_custom_field_choice_set.test", "id"),
+					// The ISO 3166 base choices are merged with the extra choices.
+					resource.TestCheckTypeSetElemAttr("data.netbox_custom_field_choices.test", "values.*", "US"),
+					resource.TestCheckTypeSetElemAttr("data.netbox_custom_field_choices.test", "values.*", "eu"),
+					resource.TestCheckTypeSetElemNestedAttrs("data.netbox_custom_field_choices.test", "choices.*", map[string]string{
+						"value": "eu",
+						"label": "European Union",
+					}),
+					resource.TestCheckOutput("valid", "true"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCustomFieldChoicesDataSourceConfig(name, choiceSetName string) string {
+	return fmt.Sprintf(`
+resource "netbox_custom_field_choice_set" "test" {
+  name         = %[2]q
+  base_choices = "ISO_3166"
+  extra_choices = [
+    { value = "eu", label = "European Union" },
+  ]
+}
+
+resource "netbox_custom_field" "test" {
+  name         = %[1]q
+  type         = "select"
+  object_types = ["dcim.site"]
+  choice_set   = netbox_custom_field_choice_set.test.name
+}
+
+data "netbox_custom_field_choices" "test" {
+  name = netbox_custom_field.test.name
+}
+
+output "valid" {
+  value = contains(data.netbox_custom_field_choices.test.values, "DE")
+}
+`, name, choiceSetName)
+}
@@ -0,0 +1,65 @@
+package datasources_acceptance_tests
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/bab3l/terraform-provider-netbox/internal/testutil"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccGraphQLDataSource_siteBySlug(t *testing.T) {
+	t.Parallel()
+
+	siteName := testutil.RandomName("tf-test-site-graphql")
+	siteSlug := testutil.RandomSlug("tf-test-site-graphql")
+
+	cleanup := testutil.NewCleanupResource(t)
+	cleanup.RegisterSiteCleanup(siteSlug)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testutil.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: testutil.TestAccProtoV6ProviderFactories,
+		CheckDestroy:             testutil.CheckSiteDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGraphQLDataSourceConfig(siteName, siteSlug),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckOutput("site_name", siteName),
+					resource.TestCheckResourceAttrSet("data.netbox_graphql.test", "data_json"),
+				),
+			},
+		},
+	})
+}
+
+func testAccGraphQLDataSourceConfig(siteName, siteSlug string) string {
+	return fmt.Sprintf(`
+provider "netbox" {}
+
+resource "netbox_site" "test" {
+  name   = %q
+  slug   = %q
+  status = "active"
+}
+
+data "netbox_graphql" "test" {
+  query = <<-EOT
+    query ($id: ID!) {
+      site(id: $id) {
+        name
+        slug
+      }
+    }
+  EOT
+
+  variables = {
+    id = netbox_site.test.id
+  }
+}
+
+output "site_name" {
+  value = data.netbox_graphql.test.data.site.name
+}
+`, siteName, siteSlug)
+}
@@ -0,0 +1,53 @@
+package datasources_acceptance_tests
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/bab3l/terraform-provider-netbox/internal/testutil"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccObjectChangesDataSource_basic(t *testing.T) {
+	t.Parallel()
+
+	name := testutil.RandomName("tf-test-site-changes")
+	slug := testutil.RandomSlug("tf-test-site-changes")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testutil.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: testutil.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccObjectChangesDataSourceConfig(name, slug, "first"),
+			},
+			{
+				Config: testAccObjectChangesDataSourceConfig(name, slug, "second"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.netbox_object_changes.test", "changes.#", "2"),
+					resource.TestCheckResourceAttr("data.netbox_object_changes.test", "changes.0.action", "create"),
+					resource.TestCheckResourceAttr("data.netbox_object_changes.test", "changes.1.action", "update"),
+					resource.TestCheckTypeSetElemAttr("data.netbox_object_changes.test", "changes.1.changed_attributes.*", "description"),
+					resource.TestCheckResourceAttrPair("data.netbox_object_changes.test", "changes.1.object_id", "netbox_site.test", "id"),
+					resource.TestCheckResourceAttrSet("data.netbox_object_changes.test", "provider_request_id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccObjectChangesDataSourceConfig(name, slug, description string) string {
+	return fmt.Sprintf(`
+resource "netbox_site" "test" {
+  name        = %[1]q
+  slug        = %[2]q
+  description = %[3]q
+}
+
+data "netbox_object_changes" "test" {
+  object_type = "dcim.site"
+  object_id   = netbox_site.test.id
+  depends_on  = [netbox_site.test]
+}
+`, name, slug, description)
+}
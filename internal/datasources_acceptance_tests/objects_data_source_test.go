@@ -0,0 +1,66 @@
+package datasources_acceptance_tests
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/bab3l/terraform-provider-netbox/internal/testutil"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccObjectsDataSource_bySlug(t *testing.T) {
+	t.Parallel()
+
+	siteName := testutil.RandomName("tf-test-site-objects")
+	siteSlug := testutil.RandomSlug("tf-test-site-objects")
+
+	cleanup := testutil.NewCleanupResource(t)
+	cleanup.RegisterSiteCleanup(siteSlug)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testutil.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: testutil.TestAccProtoV6ProviderFactories,
+		CheckDestroy:             testutil.CheckSiteDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccObjectsDataSourceConfig_bySlug(siteName, siteSlug),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.netbox_objects.test", "ids.#", "1"),
+					resource.TestCheckResourceAttrPair("data.netbox_objects.test", "ids.0", "netbox_site.test", "id"),
+					resource.TestCheckOutput("site_name", siteName),
+					resource.TestCheckOutput("site_status", "active"),
+					resource.TestCheckResourceAttrSet("data.netbox_objects.test", "results_json"),
+				),
+			},
+		},
+	})
+}
+
+func testAccObjectsDataSourceConfig_bySlug(siteName, siteSlug string) string {
+	return fmt.Sprintf(`
+provider "netbox" {}
+
+resource "netbox_site" "test" {
+  name   = %q
+  slug   = %q
+  status = "active"
+}
+
+data "netbox_objects" "test" {
+  path = "dcim/sites"
+
+  filter {
+    name   = "slug"
+    values = [netbox_site.test.slug]
+  }
+}
+
+output "site_name" {
+  value = data.netbox_objects.test.results[0].name
+}
+
+output "site_status" {
+  value = data.netbox_objects.test.results[0].status.value
+}
+`, siteName, siteSlug)
+}
@@ -0,0 +1,176 @@
+package datasources_acceptance_tests
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/bab3l/terraform-provider-netbox/internal/testutil"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccPowerFeedUtilizationDataSource_basic(t *testing.T) {
+	t.Parallel()
+
+	name := testutil.RandomName("tf-test-power-chain")
+	siteSlug := testutil.RandomSlug("site")
+	mfrSlug := testutil.RandomSlug("mfr")
+	deviceTypeSlug := testutil.RandomSlug("device-type")
+	roleSlug := testutil.RandomSlug("role")
+
+	cleanup := testutil.NewCleanupResource(t)
+	cleanup.RegisterSiteCleanup(siteSlug)
+	cleanup.RegisterRackCleanup(name + "-rack")
+	cleanup.RegisterPowerPanelCleanup(name + "-panel")
+	cleanup.RegisterPowerFeedCleanup(name + "-feed")
+	cleanup.RegisterManufacturerCleanup(mfrSlug)
+	cleanup.RegisterDeviceTypeCleanup(deviceTypeSlug)
+	cleanup.RegisterDeviceRoleCleanup(roleSlug)
+	cleanup.RegisterDeviceCleanup(name + "-pdu")
+	cleanup.RegisterDeviceCleanup(name + "-server")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testutil.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: testutil.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPowerFeedUtilizationDataSourceConfig(name, siteSlug, mfrSlug, deviceTypeSlug, roleSlug),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.netbox_power_feed_utilization.test", "feeds.#", "1"),
+					resource.TestCheckResourceAttrPair("data.netbox_power_feed_utilization.test", "feeds.0.id", "netbox_power_feed.test", "id"),
+					resource.TestCheckResourceAttrPair("data.netbox_power_feed_utilization.test", "feeds.0.power_port_ids.0", "netbox_power_port.inlet", "id"),
+					resource.TestCheckResourceAttr("data.netbox_power_feed_utilization.test", "feeds.0.allocated_draw", "400"),
+					resource.TestCheckResourceAttr("data.netbox_power_feed_utilization.test", "feeds.0.maximum_draw", "500"),
+					resource.TestCheckResourceAttr("data.netbox_power_feed_utilization.test", "feeds.0.legs.#", "3"),
+					resource.TestCheckResourceAttr("data.netbox_power_feed_utilization.test", "feeds.0.legs.0.name", "A"),
+					resource.TestCheckResourceAttr("data.netbox_power_feed_utilization.test", "feeds.0.legs.0.allocated_draw", "400"),
+					resource.TestCheckResourceAttr("data.netbox_power_feed_utilization.test", "feeds.0.legs.1.allocated_draw", "0"),
+					resource.TestCheckResourceAttrSet("data.netbox_power_feed_utilization.test", "available_power"),
+					resource.TestCheckResourceAttr("data.netbox_power_feed_utilization.test", "racks.#", "1"),
+					resource.TestCheckResourceAttrPair("data.netbox_power_feed_utilization.test", "racks.0.rack_id", "netbox_rack.test", "id"),
+					resource.TestCheckResourceAttr("data.netbox_power_connections.test", "connections.#", "1"),
+					resource.TestCheckResourceAttrPair("data.netbox_power_connections.test", "connections.0.outlet_id", "netbox_power_outlet.test", "id"),
+					resource.TestCheckResourceAttrPair("data.netbox_power_connections.test", "connections.0.power_port_id", "netbox_power_port.server", "id"),
+					resource.TestCheckResourceAttr("data.netbox_power_connections.test", "connections.0.feed_leg", "A"),
+					resource.TestCheckResourceAttr("data.netbox_power_connections.test", "connections.0.device", name+"-server"),
+					resource.TestCheckResourceAttr("data.netbox_power_connections.test", "allocated_draw", "400"),
+				),
+			},
+		},
+	})
+}
+
+func testAccPowerFeedUtilizationDataSourceConfig(name, siteSlug, manufacturerSlug, deviceTypeSlug, roleSlug string) string {
+	return fmt.Sprintf(`
+resource "netbox_site" "test" {
+  name = "%[1]s-site"
+  slug = %[2]q
+}
+
+resource "netbox_rack" "test" {
+  name = "%[1]s-rack"
+  site = netbox_site.test.id
+}
+
+resource "netbox_power_panel" "test" {
+  site = netbox_site.test.id
+  name = "%[1]s-panel"
+}
+
+resource "netbox_power_feed" "test" {
+  power_panel = netbox_power_panel.test.id
+  rack        = netbox_rack.test.id
+  name        = "%[1]s-feed"
+  status      = "active"
+  type        = "primary"
+  supply      = "ac"
+  phase       = "three-phase"
+  voltage     = 230
+  amperage    = 32
+}
+
+resource "netbox_manufacturer" "test" {
+  name = "%[1]s-mfr"
+  slug = %[3]q
+}
+
+resource "netbox_device_type" "test" {
+  manufacturer = netbox_manufacturer.test.id
+  model        = "%[1]s-model"
+  slug         = %[4]q
+}
+
+resource "netbox_device_role" "test" {
+  name = "%[1]s-role"
+  slug = %[5]q
+}
+
+resource "netbox_device" "pdu" {
+  name        = "%[1]s-pdu"
+  site        = netbox_site.test.id
+  rack        = netbox_rack.test.id
+  device_type = netbox_device_type.test.id
+  role        = netbox_device_role.test.id
+}
+
+resource "netbox_device" "server" {
+  name        = "%[1]s-server"
+  site        = netbox_site.test.id
+  rack        = netbox_rack.test.id
+  device_type = netbox_device_type.test.id
+  role        = netbox_device_role.test.id
+}
+
+resource "netbox_power_port" "inlet" {
+  device = netbox_device.pdu.id
+  name   = "Inlet"
+}
+
+resource "netbox_power_outlet" "test" {
+  device     = netbox_device.pdu.id
+  name       = "Outlet 1"
+  power_port = netbox_power_port.inlet.id
+  feed_leg   = "A"
+}
+
+resource "netbox_power_port" "server" {
+  device         = netbox_device.server.id
+  name           = "PSU1"
+  allocated_draw = 400
+  maximum_draw   = 500
+}
+
+resource "netbox_cable" "feed" {
+  a_terminations = [{
+    object_type = "dcim.powerfeed"
+    object_id   = netbox_power_feed.test.id
+  }]
+  b_terminations = [{
+    object_type = "dcim.powerport"
+    object_id   = netbox_power_port.inlet.id
+  }]
+}
+
+resource "netbox_cable" "outlet" {
+  a_terminations = [{
+    object_type = "dcim.poweroutlet"
+    object_id   = netbox_power_outlet.test.id
+  }]
+  b_terminations = [{
+    object_type = "dcim.powerport"
+    object_id   = netbox_power_port.server.id
+  }]
+}
+
+data "netbox_power_feed_utilization" "test" {
+  rack_id = netbox_rack.test.id
+
+  depends_on = [netbox_cable.feed, netbox_cable.outlet]
+}
+
+data "netbox_power_connections" "test" {
+  device_id = netbox_device.server.id
+
+  depends_on = [netbox_cable.outlet]
+}
+`, name, siteSlug, manufacturerSlug, deviceTypeSlug, roleSlug)
+}
@@ -0,0 +1,74 @@
+package datasources_acceptance_tests
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/bab3l/terraform-provider-netbox/internal/testutil"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccSitesDataSource_byTenantFilter(t *testing.T) {
+	t.Parallel()
+
+	tenantName := testutil.RandomName("tf-test-tenant-q")
+	tenantSlug := testutil.RandomSlug("tf-test-tenant-q")
+	siteName := testutil.RandomName("tf-test-site-q")
+	siteSlug := testutil.RandomSlug("tf-test-site-q")
+
+	cleanup := testutil.NewCleanupResource(t)
+	cleanup.RegisterSiteCleanup(siteSlug)
+	cleanup.RegisterTenantCleanup(tenantSlug)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testutil.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: testutil.TestAccProtoV6ProviderFactories,
+		CheckDestroy: testutil.ComposeCheckDestroy(
+			testutil.CheckSiteDestroy,
+			testutil.CheckTenantDestroy,
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSitesDataSourceConfig_byTenant(tenantName, tenantSlug, siteName, siteSlug),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.netbox_sites.test", "ids.#", "1"),
+					resource.TestCheckResourceAttrPair("data.netbox_sites.test", "ids.0", "netbox_site.test", "id"),
+					resource.TestCheckResourceAttr("data.netbox_sites.test", "sites.#", "1"),
+					resource.TestCheckResourceAttr("data.netbox_sites.test", "sites.0.name", siteName),
+					resource.TestCheckResourceAttr("data.netbox_sites.test", "sites.0.slug", siteSlug),
+					resource.TestCheckResourceAttr("data.netbox_sites.test", "sites.0.status", "planned"),
+					resource.TestCheckResourceAttr("data.netbox_sites.test", "sites.0.tenant", tenantName),
+					resource.TestCheckResourceAttr("data.netbox_sites.test", "sites.0.description", "query data source test"),
+				),
+			},
+		},
+	})
+}
+
+func testAccSitesDataSourceConfig_byTenant(tenantName, tenantSlug, siteName, siteSlug string) string {
+	return fmt.Sprintf(`
+provider "netbox" {}
+
+resource "netbox_tenant" "test" {
+  name = %q
+  slug = %q
+}
+
+resource "netbox_site" "test" {
+  name        = %q
+  slug        = %q
+  status      = "planned"
+  tenant      = netbox_tenant.test.id
+  description = "query data source test"
+}
+
+data "netbox_sites" "test" {
+  filter {
+    name   = "tenant_id"
+    values = [netbox_tenant.test.id]
+  }
+
+  depends_on = [netbox_site.test]
+}
+`, tenantName, tenantSlug, siteName, siteSlug)
+}
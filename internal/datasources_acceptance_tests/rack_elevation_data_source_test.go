@@ -0,0 +1,81 @@
+package datasources_acceptance_tests
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/bab3l/terraform-provider-netbox/internal/testutil"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccRackElevationDataSource_basic(t *testing.T) {
+	t.Parallel()
+
+	siteName := testutil.RandomName("test-site-elev")
+	siteSlug := testutil.GenerateSlug(siteName)
+	rackName := testutil.RandomName("test-rack-elev")
+
+	cleanup := testutil.NewCleanupResource(t)
+	cleanup.RegisterSiteCleanup(siteSlug)
+	cleanup.RegisterRackCleanup(rackName)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testutil.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: testutil.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRackElevationDataSourceConfig(siteName, siteSlug, rackName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.netbox_rack_elevation.test", "front.#", "10"),
+					resource.TestCheckResourceAttr("data.netbox_rack_elevation.test", "rear.#", "10"),
+					// Units are listed top-down, so the last one is U1.
+					resource.TestCheckResourceAttr("data.netbox_rack_elevation.test", "front.9.name", "U1"),
+					resource.TestCheckResourceAttr("data.netbox_rack_elevation.test", "front.9.status", "reservation"),
+					resource.TestCheckResourceAttrPair("data.netbox_rack_elevation.test", "front.9.reservation_id", "netbox_rack_reservation.test", "id"),
+					resource.TestCheckResourceAttr("data.netbox_rack_elevation.test", "front.8.status", "free"),
+					resource.TestCheckResourceAttr("data.netbox_rack_free_units.test", "position", "2"),
+					resource.TestCheckResourceAttr("data.netbox_rack_free_units.test", "available_positions.#", "8"),
+				),
+			},
+		},
+	})
+}
+
+func testAccRackElevationDataSourceConfig(siteName, siteSlug, rackName string) string {
+	return fmt.Sprintf(`
+resource "netbox_site" "test" {
+  name   = %q
+  slug   = %q
+  status = "active"
+}
+
+resource "netbox_rack" "test" {
+  name     = %q
+  site     = netbox_site.test.id
+  status   = "active"
+  width    = 19
+  u_height = 10
+}
+
+resource "netbox_rack_reservation" "test" {
+  rack        = netbox_rack.test.id
+  units       = [1]
+  description = "Reserved for patching"
+  user        = 1 # Assuming user ID 1 exists (admin)
+}
+
+data "netbox_rack_elevation" "test" {
+  rack_id = netbox_rack.test.id
+
+  depends_on = [netbox_rack_reservation.test]
+}
+
+data "netbox_rack_free_units" "test" {
+  rack_id  = netbox_rack.test.id
+  u_height = 2
+  face     = "front"
+
+  depends_on = [netbox_rack_reservation.test]
+}
+`, siteName, siteSlug, rackName)
+}
@@ -0,0 +1,58 @@
+package datasources_unit_tests
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/bab3l/terraform-provider-netbox/internal/datasources"
+	"github.com/bab3l/terraform-provider-netbox/internal/testutil"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBranchDataSource(t *testing.T) {
+	t.Parallel()
+
+	d := datasources.NewBranchDataSource()
+	testutil.ValidateDataSourceMetadata(t, d, "netbox", "netbox_branch")
+	testutil.ValidateDataSourceConfigure(t, d)
+	testutil.ValidateDataSourceSchema(t, testutil.DataSourceSchema(t, d).Attributes, testutil.DataSourceValidation{
+		LookupAttrs:   []string{"id", "name"},
+		ComputedAttrs: []string{"description", "comments", "schema_id", "status", "last_sync", "merged_time"},
+	})
+}
+
+func TestBranchDataSourceRead_ByName(t *testing.T) {
+	t.Parallel()
+
+	client := testutil.NewMockAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/plugins/branching/branches/", r.URL.Path)
+		assert.Equal(t, "review", r.URL.Query().Get("name"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"count": 1, "results": [{"id": 3, "name": "review", "description": "Staged changes",
+			"comments": "", "schema_id": "td5smq0f", "status": {"value": "ready", "label": "Ready"},
+			"last_sync": "2024-05-01T10:00:00Z", "merged_time": null}]}`)
+	}))
+
+	resp := testutil.ReadDataSource(t, datasources.NewBranchDataSource(), client, map[string]tftypes.Value{
+		"name": tftypes.NewValue(tftypes.String, "review"),
+	})
+	require.False(t, resp.Diagnostics.HasError(), "Read returned errors: %v", resp.Diagnostics)
+
+	for name, want := range map[string]types.String{
+		"id":          types.StringValue("3"),
+		"schema_id":   types.StringValue("td5smq0f"),
+		"status":      types.StringValue("ready"),
+		"description": types.StringValue("Staged changes"),
+		"last_sync":   types.StringValue("2024-05-01T10:00:00Z"),
+		"merged_time": types.StringNull(),
+	} {
+		var got types.String
+		require.False(t, resp.State.GetAttribute(t.Context(), path.Root(name), &got).HasError())
+		assert.Equal(t, want, got, name)
+	}
+}
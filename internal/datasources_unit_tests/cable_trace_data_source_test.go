@@ -0,0 +1,256 @@
+package datasources_unit_tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/bab3l/go-netbox"
+	"github.com/bab3l/terraform-provider-netbox/internal/datasources"
+	"github.com/bab3l/terraform-provider-netbox/internal/testutil"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newCableTraceMock serves a patch panel run: interface eth0 on sw1 is cabled
+// to front port 1 of pp1, whose rear port is cabled to eth1 on srv1. A second
+// interface, mgmt0 on sw1, is directly cabled to a planned cable.
+func newCableTraceMock(t *testing.T) *netbox.APIClient {
+	t.Helper()
+
+	node := func(path string, id int, name, device string) map[string]interface{} {
+		return map[string]interface{}{
+			"id":      id,
+			"url":     "http://netbox.example/api/" + path + "/" + strconv.Itoa(id) + "/",
+			"display": name,
+			"name":    name,
+			"device":  map[string]interface{}{"id": 1, "name": device, "display": device},
+		}
+	}
+	eth0 := node("dcim/interfaces", 10, "eth0", "sw1")
+	front := node("dcim/front-ports", 20, "Front 1", "pp1")
+	rear := node("dcim/rear-ports", 30, "Rear 1", "pp1")
+	eth1 := node("dcim/interfaces", 40, "eth1", "srv1")
+	mgmt0 := node("dcim/interfaces", 11, "mgmt0", "sw1")
+	oob := node("dcim/interfaces", 50, "oob", "oob-sw1")
+	cable := func(id int, label, status string) map[string]interface{} {
+		return map[string]interface{}{"id": id, "label": label, "status": map[string]interface{}{"value": status, "label": status}}
+	}
+	list := func(items ...interface{}) []interface{} { return items }
+
+	responses := map[string]interface{}{
+		"/api/dcim/interfaces/10/trace/": list(
+			list(list(eth0), cable(1, "P-001", "connected"), list(front)),
+			list(list(rear), cable(2, "", "connected"), list(eth1)),
+		),
+		"/api/dcim/interfaces/11/trace/": list(
+			list(list(mgmt0), cable(3, "OOB", "planned"), list(oob)),
+		),
+		"/api/dcim/interfaces/12/trace/": list(),
+		"/api/dcim/front-ports/20/paths/": list(
+			map[string]interface{}{
+				"path":        list(list(eth0), list(cable(1, "P-001", "connected")), list(front), list(rear), list(cable(2, "", "connected")), list(eth1)),
+				"is_active":   true,
+				"is_complete": true,
+				"is_split":    false,
+			},
+			map[string]interface{}{
+				"path":        list(list(eth1), list(cable(2, "", "connected")), list(rear), list(front), list(cable(1, "P-001", "connected")), list(eth0)),
+				"is_active":   true,
+				"is_complete": true,
+				"is_split":    false,
+			},
+		),
+	}
+
+	return testutil.NewMockAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/dcim/interfaces/" {
+			results := list()
+			if r.URL.Query().Get("device") == "sw1" && r.URL.Query().Get("name") == "eth0" {
+				results = list(eth0)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"count": len(results), "results": results})
+			return
+		}
+		response, ok := responses[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"detail": "Not found."}`))
+			return
+		}
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+}
+
+func strPtr(s string) *string {
+	return &s
+}
+
+type cableTraceNodeState struct {
+	ObjectType string  `tfsdk:"object_type"`
+	ID         string  `tfsdk:"id"`
+	Name       string  `tfsdk:"name"`
+	Device     *string `tfsdk:"device"`
+}
+
+type cableTraceHopState struct {
+	NearEnd    []cableTraceNodeState `tfsdk:"near_end"`
+	CableID    *string               `tfsdk:"cable_id"`
+	CableLabel *string               `tfsdk:"cable_label"`
+	FarEnd     []cableTraceNodeState `tfsdk:"far_end"`
+}
+
+func readCableTrace(t *testing.T, values map[string]tftypes.Value) (hops []cableTraceHopState, endpoints []cableTraceNodeState, flags map[string]bool, id string) {
+	t.Helper()
+
+	for _, name := range []string{"termination_id", "device", "name"} {
+		if _, ok := values[name]; !ok {
+			values[name] = tftypes.NewValue(tftypes.String, nil)
+		}
+	}
+	resp := testutil.ReadDataSource(t, datasources.NewCableTraceDataSource(), newCableTraceMock(t), values)
+	require.False(t, resp.Diagnostics.HasError(), "Read returned errors: %v", resp.Diagnostics)
+
+	ctx := t.Context()
+	require.False(t, resp.State.GetAttribute(ctx, path.Root("hops"), &hops).HasError())
+	require.False(t, resp.State.GetAttribute(ctx, path.Root("endpoints"), &endpoints).HasError())
+	require.False(t, resp.State.GetAttribute(ctx, path.Root("termination_id"), &id).HasError())
+	flags = map[string]bool{}
+	for _, name := range []string{"is_complete", "is_active", "is_split"} {
+		var value bool
+		require.False(t, resp.State.GetAttribute(ctx, path.Root(name), &value).HasError())
+		flags[name] = value
+	}
+	return hops, endpoints, flags, id
+}
+
+func TestCableTraceDataSource(t *testing.T) {
+	t.Parallel()
+
+	d := datasources.NewCableTraceDataSource()
+	testutil.ValidateDataSourceMetadata(t, d, "netbox", "netbox_cable_trace")
+	testutil.ValidateDataSourceConfigure(t, d)
+
+	s := testutil.DataSourceSchema(t, d)
+	testutil.ValidateDataSourceSchema(t, s.Attributes, testutil.DataSourceValidation{
+		LookupAttrs:   []string{"termination_id", "device", "name"},
+		ComputedAttrs: []string{"termination_id", "hops", "endpoints", "is_complete", "is_active", "is_split"},
+	})
+	assert.True(t, s.Attributes["termination_type"].IsRequired())
+}
+
+func TestCableTraceDataSourceRead_ByDeviceAndName(t *testing.T) {
+	t.Parallel()
+
+	hops, endpoints, flags, id := readCableTrace(t, map[string]tftypes.Value{
+		"termination_type": tftypes.NewValue(tftypes.String, "dcim.interface"),
+		"device":           tftypes.NewValue(tftypes.String, "sw1"),
+		"name":             tftypes.NewValue(tftypes.String, "eth0"),
+	})
+
+	assert.Equal(t, "10", id)
+	require.Len(t, hops, 2)
+	assert.Equal(t, "eth0", hops[0].NearEnd[0].Name)
+	assert.Equal(t, "dcim.interface", hops[0].NearEnd[0].ObjectType)
+	assert.Equal(t, "1", *hops[0].CableID)
+	assert.Equal(t, "P-001", *hops[0].CableLabel)
+	assert.Equal(t, "dcim.frontport", hops[0].FarEnd[0].ObjectType)
+	assert.Equal(t, "dcim.rearport", hops[1].NearEnd[0].ObjectType)
+	assert.Nil(t, hops[1].CableLabel)
+
+	require.Len(t, endpoints, 1)
+	assert.Equal(t, cableTraceNodeState{ObjectType: "dcim.interface", ID: "40", Name: "eth1", Device: strPtr("srv1")}, endpoints[0])
+	assert.Equal(t, map[string]bool{"is_complete": true, "is_active": true, "is_split": false}, flags)
+}
+
+func TestCableTraceDataSourceRead_PassThrough(t *testing.T) {
+	t.Parallel()
+
+	hops, endpoints, flags, id := readCableTrace(t, map[string]tftypes.Value{
+		"termination_type": tftypes.NewValue(tftypes.String, "dcim.frontport"),
+		"termination_id":   tftypes.NewValue(tftypes.String, "20"),
+	})
+
+	// A front port is traced outwards through its own cable.
+	assert.Equal(t, "20", id)
+	require.Len(t, hops, 1)
+	assert.Equal(t, "Front 1", hops[0].NearEnd[0].Name)
+	require.Len(t, endpoints, 1)
+	assert.Equal(t, "eth0", endpoints[0].Name)
+	assert.Equal(t, map[string]bool{"is_complete": true, "is_active": true, "is_split": false}, flags)
+}
+
+func TestCableTraceDataSourceRead_DirectCable(t *testing.T) {
+	t.Parallel()
+
+	hops, endpoints, flags, _ := readCableTrace(t, map[string]tftypes.Value{
+		"termination_type": tftypes.NewValue(tftypes.String, "dcim.interface"),
+		"termination_id":   tftypes.NewValue(tftypes.String, "11"),
+	})
+
+	require.Len(t, hops, 1)
+	require.Len(t, endpoints, 1)
+	assert.Equal(t, "oob-sw1", *endpoints[0].Device)
+	// The only cable is planned, so the path is complete but not active.
+	assert.Equal(t, map[string]bool{"is_complete": true, "is_active": false, "is_split": false}, flags)
+}
+
+func TestCableTraceDataSourceRead_NotCabled(t *testing.T) {
+	t.Parallel()
+
+	hops, endpoints, flags, _ := readCableTrace(t, map[string]tftypes.Value{
+		"termination_type": tftypes.NewValue(tftypes.String, "dcim.interface"),
+		"termination_id":   tftypes.NewValue(tftypes.String, "12"),
+	})
+
+	assert.Empty(t, hops)
+	assert.Empty(t, endpoints)
+	assert.Equal(t, map[string]bool{"is_complete": false, "is_active": false, "is_split": false}, flags)
+}
+
+func TestCableTraceDataSourceRead_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		values  map[string]tftypes.Value
+		summary string
+	}{
+		"missing identifier": {
+			values:  map[string]tftypes.Value{"name": tftypes.NewValue(tftypes.String, "eth0")},
+			summary: "Missing identifier",
+		},
+		"unknown port": {
+			values: map[string]tftypes.Value{
+				"device": tftypes.NewValue(tftypes.String, "sw1"),
+				"name":   tftypes.NewValue(tftypes.String, "eth9"),
+			},
+			summary: "Termination not found",
+		},
+		"unknown id": {
+			values:  map[string]tftypes.Value{"termination_id": tftypes.NewValue(tftypes.String, "99")},
+			summary: "Termination Not Found",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			values := map[string]tftypes.Value{
+				"termination_type": tftypes.NewValue(tftypes.String, "dcim.interface"),
+				"termination_id":   tftypes.NewValue(tftypes.String, nil),
+				"device":           tftypes.NewValue(tftypes.String, nil),
+				"name":             tftypes.NewValue(tftypes.String, nil),
+			}
+			for k, v := range tc.values {
+				values[k] = v
+			}
+			resp := testutil.ReadDataSource(t, datasources.NewCableTraceDataSource(), newCableTraceMock(t), values)
+			require.True(t, resp.Diagnostics.HasError())
+			assert.Equal(t, tc.summary, resp.Diagnostics.Errors()[0].Summary())
+		})
+	}
+}
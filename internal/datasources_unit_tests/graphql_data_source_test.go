@@ -0,0 +1,194 @@
+package datasources_unit_tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/bab3l/terraform-provider-netbox/internal/datasources"
+	"github.com/bab3l/terraform-provider-netbox/internal/testutil"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGraphQLDataSource(t *testing.T) {
+	t.Parallel()
+
+	d := datasources.NewGraphQLDataSource()
+	testutil.ValidateDataSourceMetadata(t, d, "netbox", "netbox_graphql")
+	testutil.ValidateDataSourceConfigure(t, d)
+
+	s := testutil.DataSourceSchema(t, d)
+	testutil.ValidateDataSourceSchema(t, s.Attributes, testutil.DataSourceValidation{
+		LookupAttrs:   []string{"variables", "page_size"},
+		ComputedAttrs: []string{"data", "data_json"},
+	})
+	assert.True(t, s.Attributes["query"].IsRequired())
+}
+
+type graphQLRequest struct {
+	Query     string                 `json:"query"`
+	Variables map[string]interface{} `json:"variables"`
+}
+
+// fakeGraphQLHandler serves device_list queries over a fixed set of devices,
+// honouring the offset and limit variables.
+func fakeGraphQLHandler(t *testing.T, total int, requests *[]graphQLRequest) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/graphql/", r.URL.Path)
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "Token test-token", r.Header.Get("Authorization"))
+
+		var req graphQLRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		*requests = append(*requests, req)
+
+		offset, limit := 0, total
+		if v, ok := req.Variables["offset"].(float64); ok {
+			offset = int(v)
+		}
+		if v, ok := req.Variables["limit"].(float64); ok {
+			limit = int(v)
+		}
+
+		devices := []interface{}{}
+		for i := offset; i < total && i < offset+limit; i++ {
+			devices = append(devices, map[string]interface{}{
+				"id":   fmt.Sprintf("%d", i+1),
+				"name": fmt.Sprintf("device-%d", i+1),
+				"interfaces": []interface{}{
+					map[string]interface{}{"name": "eth0", "ip_addresses": []interface{}{map[string]interface{}{"address": "10.0.0.1/24"}}},
+				},
+			})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": map[string]interface{}{
+				"device_list": devices,
+				"site":        map[string]interface{}{"name": "dc1"},
+			},
+		})
+	})
+}
+
+func graphQLVariablesValue(values map[string]tftypes.Value) tftypes.Value {
+	attrTypes := make(map[string]tftypes.Type, len(values))
+	for name, v := range values {
+		attrTypes[name] = v.Type()
+	}
+	return tftypes.NewValue(tftypes.Object{AttributeTypes: attrTypes}, values)
+}
+
+func TestGraphQLDataSourceRead(t *testing.T) {
+	t.Parallel()
+
+	var requests []graphQLRequest
+	client := testutil.NewMockAPIClient(t, fakeGraphQLHandler(t, 3, &requests))
+
+	query := `query ($site: [String!]) { device_list(filters: {site: $site}) { id name interfaces { name ip_addresses { address } } } site(id: 1) { name } }`
+	resp := testutil.ReadDataSource(t, datasources.NewGraphQLDataSource(), client, map[string]tftypes.Value{
+		"query": tftypes.NewValue(tftypes.String, query),
+		"variables": graphQLVariablesValue(map[string]tftypes.Value{
+			"site":   tftypes.NewValue(tftypes.String, "dc1"),
+			"offset": tftypes.NewValue(tftypes.Number, 1),
+		}),
+	})
+	require.False(t, resp.Diagnostics.HasError(), "Read returned errors: %v", resp.Diagnostics)
+
+	// Variables are sent unchanged with their types.
+	require.Len(t, requests, 1)
+	assert.Equal(t, query, requests[0].Query)
+	assert.Equal(t, "dc1", requests[0].Variables["site"])
+	assert.InDelta(t, 1, requests[0].Variables["offset"], 0)
+
+	var data types.Dynamic
+	require.False(t, resp.State.GetAttribute(t.Context(), path.Root("data"), &data).HasError())
+	attrs := data.UnderlyingValue().(types.Object).Attributes()
+	devices := attrs["device_list"].(types.Tuple).Elements()
+	require.Len(t, devices, 2)
+	assert.Equal(t, types.StringValue("device-2"), devices[0].(types.Object).Attributes()["name"])
+
+	var dataJSON string
+	require.False(t, resp.State.GetAttribute(t.Context(), path.Root("data_json"), &dataJSON).HasError())
+	assert.Contains(t, dataJSON, `"10.0.0.1/24"`)
+}
+
+func TestGraphQLDataSourceRead_Paginated(t *testing.T) {
+	t.Parallel()
+
+	var requests []graphQLRequest
+	client := testutil.NewMockAPIClient(t, fakeGraphQLHandler(t, 25, &requests))
+
+	resp := testutil.ReadDataSource(t, datasources.NewGraphQLDataSource(), client, map[string]tftypes.Value{
+		"query":     tftypes.NewValue(tftypes.String, `query ($offset: Int, $limit: Int) { device_list(pagination: {offset: $offset, limit: $limit}) { id name } site(id: 1) { name } }`),
+		"page_size": tftypes.NewValue(tftypes.Number, 10),
+	})
+	require.False(t, resp.Diagnostics.HasError(), "Read returned errors: %v", resp.Diagnostics)
+
+	require.Len(t, requests, 3)
+	assert.InDelta(t, 20, requests[2].Variables["offset"], 0)
+	assert.InDelta(t, 10, requests[2].Variables["limit"], 0)
+
+	var dataJSON string
+	require.False(t, resp.State.GetAttribute(t.Context(), path.Root("data_json"), &dataJSON).HasError())
+	var decoded struct {
+		DeviceList []map[string]interface{} `json:"device_list"`
+		Site       map[string]interface{}   `json:"site"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(dataJSON), &decoded))
+	assert.Len(t, decoded.DeviceList, 25)
+	assert.Equal(t, "device-25", decoded.DeviceList[24]["name"])
+	assert.Equal(t, "dc1", decoded.Site["name"])
+}
+
+func TestGraphQLDataSourceRead_PaginationRequiresVariables(t *testing.T) {
+	t.Parallel()
+
+	client := testutil.NewMockAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request to %s", r.URL.Path)
+	}))
+
+	resp := testutil.ReadDataSource(t, datasources.NewGraphQLDataSource(), client, map[string]tftypes.Value{
+		"query":     tftypes.NewValue(tftypes.String, `{ device_list { id } }`),
+		"page_size": tftypes.NewValue(tftypes.Number, 10),
+	})
+	require.True(t, resp.Diagnostics.HasError())
+	assert.Contains(t, resp.Diagnostics.Errors()[0].Summary(), "Missing pagination variables")
+}
+
+func TestGraphQLDataSourceRead_Errors(t *testing.T) {
+	t.Parallel()
+
+	client := testutil.NewMockAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data": null, "errors": [
+			{"message": "Cannot query field 'nme' on type 'DeviceType'.", "locations": [{"line": 1, "column": 17}]},
+			{"message": "Permission denied", "path": ["device_list", 0, "tenant"]}
+		]}`))
+	}))
+
+	resp := testutil.ReadDataSource(t, datasources.NewGraphQLDataSource(), client, map[string]tftypes.Value{
+		"query": tftypes.NewValue(tftypes.String, `{ device_list { nme } }`),
+	})
+	require.Len(t, resp.Diagnostics.Errors(), 2)
+	assert.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "Cannot query field 'nme'")
+	assert.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "line 1, column 17")
+	assert.Contains(t, resp.Diagnostics.Errors()[1].Detail(), "device_list.0.tenant")
+}
+
+func TestGraphQLDataSourceRead_HTTPError(t *testing.T) {
+	t.Parallel()
+
+	client := testutil.NewMockAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"detail": "Invalid token."}`))
+	}))
+
+	resp := testutil.ReadDataSource(t, datasources.NewGraphQLDataSource(), client, map[string]tftypes.Value{
+		"query": tftypes.NewValue(tftypes.String, `{ site_list { name } }`),
+	})
+	require.True(t, resp.Diagnostics.HasError())
+	assert.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "Invalid token.")
+}
@@ -2,11 +2,17 @@ package datasources_unit_tests
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
 	"testing"
 
 	"github.com/bab3l/terraform-provider-netbox/internal/datasources"
 	"github.com/bab3l/terraform-provider-netbox/internal/testutil"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestInterfaceDataSourceSchema(t *testing.T) {
@@ -39,3 +45,56 @@ func TestInterfaceDataSourceConfigure(t *testing.T) {
 	d := datasources.NewInterfaceDataSource()
 	testutil.ValidateDataSourceConfigure(t, d)
 }
+
+func TestInterfaceDataSourceReadWireless(t *testing.T) {
+	t.Parallel()
+
+	choice := func(value, label string) map[string]interface{} {
+		return map[string]interface{}{"value": value, "label": label}
+	}
+	client := testutil.NewMockAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/dcim/interfaces/5/" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		device := map[string]interface{}{"id": 1, "url": "/api/dcim/devices/1/", "display": "ap01", "name": "ap01"}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"id": 5, "url": "/api/dcim/interfaces/5/", "display": "wlan0", "device": device, "name": "wlan0",
+			"type":  choice("ieee802.11ax", "IEEE 802.11ax"),
+			"cable": nil, "wireless_link": nil, "link_peers": []interface{}{}, "link_peers_type": nil,
+			"l2vpn_termination": nil, "connected_endpoints": nil, "connected_endpoints_type": nil,
+			"connected_endpoints_reachable": false, "count_ipaddresses": 0, "count_fhrp_groups": 0, "_occupied": false,
+			"poe_mode":   choice("pd", "PD"),
+			"poe_type":   choice("type2-ieee802.3at", "802.3at (Type 2)"),
+			"rf_role":    choice("ap", "Access point"),
+			"rf_channel": choice("5g-36-5180-20", "36 (5180/20 MHz)"),
+			"tx_power":   20,
+			"vrf":        map[string]interface{}{"id": 3, "url": "/api/ipam/vrfs/3/", "display": "guest", "name": "guest"},
+			"wireless_lans": []interface{}{
+				map[string]interface{}{"id": 7, "url": "/api/wireless/wireless-lans/7/", "display": "corp", "ssid": "corp"},
+			},
+			"vdcs": []interface{}{},
+		})
+	}))
+
+	resp := testutil.ReadDataSource(t, datasources.NewInterfaceDataSource(), client, map[string]tftypes.Value{
+		"id": tftypes.NewValue(tftypes.String, "5"),
+	})
+	require.False(t, resp.Diagnostics.HasError(), "Read returned errors: %v", resp.Diagnostics)
+
+	var data datasources.InterfaceDataSourceModel
+	require.False(t, resp.State.Get(context.Background(), &data).HasError())
+	assert.Equal(t, "3", data.Vrf.ValueString())
+	assert.Equal(t, "pd", data.PoeMode.ValueString())
+	assert.Equal(t, "type2-ieee802.3at", data.PoeType.ValueString())
+	assert.Equal(t, "ap", data.RfRole.ValueString())
+	assert.Equal(t, "5g-36-5180-20", data.RfChannel.ValueString())
+	assert.Equal(t, int64(20), data.TxPower.ValueInt64())
+
+	var wirelessLans, vdcs []int64
+	require.False(t, resp.State.GetAttribute(context.Background(), path.Root("wireless_lans"), &wirelessLans).HasError())
+	require.False(t, resp.State.GetAttribute(context.Background(), path.Root("vdcs"), &vdcs).HasError())
+	assert.Equal(t, []int64{7}, wirelessLans)
+	assert.Empty(t, vdcs)
+}
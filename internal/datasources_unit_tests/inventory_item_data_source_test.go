@@ -2,11 +2,17 @@ package datasources_unit_tests
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
 	"testing"
 
 	"github.com/bab3l/terraform-provider-netbox/internal/datasources"
 	"github.com/bab3l/terraform-provider-netbox/internal/testutil"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestInventoryItemDataSourceSchema(t *testing.T) {
@@ -39,3 +45,44 @@ func TestInventoryItemDataSourceConfigure(t *testing.T) {
 	d := datasources.NewInventoryItemDataSource()
 	testutil.ValidateDataSourceConfigure(t, d)
 }
+
+func TestInventoryItemDataSourceReadByComponent(t *testing.T) {
+	t.Parallel()
+
+	client := testutil.NewMockAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/dcim/inventory-items/", r.URL.Path)
+		assert.Equal(t, "dcim.interface", r.URL.Query().Get("component_type"))
+		assert.Equal(t, "11", r.URL.Query().Get("component_id"))
+		item := map[string]interface{}{
+			"id":             5,
+			"url":            "http://netbox/api/dcim/inventory-items/5/",
+			"display":        "SFP-10G-LR",
+			"device":         map[string]interface{}{"id": 1, "url": "http://netbox/api/dcim/devices/1/", "display": "sw1", "name": "sw1"},
+			"name":           "SFP-10G-LR",
+			"status":         map[string]interface{}{"value": "planned", "label": "Planned"},
+			"component_type": "dcim.interface",
+			"component_id":   11,
+			"component":      map[string]interface{}{"id": 11, "url": "http://netbox/api/dcim/interfaces/11/", "display": "xe-0/0/1", "name": "xe-0/0/1"},
+			"_depth":         0,
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"count": 1, "results": []interface{}{item}})
+	}))
+
+	d := datasources.NewInventoryItemDataSource()
+	resp := testutil.ReadDataSource(t, d, client, map[string]tftypes.Value{
+		"component_type": tftypes.NewValue(tftypes.String, "dcim.interface"),
+		"component_id":   tftypes.NewValue(tftypes.Number, 11),
+	})
+	require.False(t, resp.Diagnostics.HasError(), "Read returned errors: %v", resp.Diagnostics)
+
+	for attr, expected := range map[string]string{
+		"id":             "5",
+		"status":         "planned",
+		"component_name": "xe-0/0/1",
+	} {
+		var value string
+		require.False(t, resp.State.GetAttribute(context.Background(), path.Root(attr), &value).HasError())
+		assert.Equal(t, expected, value, attr)
+	}
+}
@@ -0,0 +1,145 @@
+package datasources_unit_tests
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/bab3l/terraform-provider-netbox/internal/datasources"
+	"github.com/bab3l/terraform-provider-netbox/internal/testutil"
+	"github.com/bab3l/terraform-provider-netbox/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestObjectChangesDataSource(t *testing.T) {
+	t.Parallel()
+
+	d := datasources.NewObjectChangesDataSource()
+	testutil.ValidateDataSourceMetadata(t, d, "netbox", "netbox_object_changes")
+	testutil.ValidateDataSourceConfigure(t, d)
+
+	s := testutil.DataSourceSchema(t, d)
+	testutil.ValidateDataSourceSchema(t, s.Attributes, testutil.DataSourceValidation{
+		LookupAttrs:   []string{"request_id", "current_run", "object_type", "object_id", "user", "action", "time_after", "time_before"},
+		ComputedAttrs: []string{"provider_request_id", "changes"},
+	})
+}
+
+const objectChangesResponse = `{"count": 3, "next": null, "previous": null, "results": [
+	{"id": 12, "time": "2024-05-01T10:00:02Z", "user_name": "terraform", "request_id": "req-b",
+	 "action": {"value": "update", "label": "Updated"}, "changed_object_type": "dcim.site", "changed_object_id": 1,
+	 "changed_object": {"id": 1, "display": "Site 1"},
+	 "prechange_data": {"name": "Site 1", "description": "old", "tags": []},
+	 "postchange_data": {"name": "Site 1", "description": "new", "tags": [], "comments": "x"}},
+	{"id": 11, "time": "2024-05-01T10:00:01Z", "user_name": "terraform", "request_id": "req-a",
+	 "action": {"value": "create", "label": "Created"}, "changed_object_type": "dcim.site", "changed_object_id": 1,
+	 "changed_object": {"id": 1, "display": "Site 1"},
+	 "prechange_data": null, "postchange_data": {"name": "Site 1"}},
+	{"id": 13, "time": "2024-05-01T10:00:03Z", "user_name": "admin", "request_id": "req-c",
+	 "action": {"value": "delete", "label": "Deleted"}, "changed_object_type": "dcim.site", "changed_object_id": 2,
+	 "changed_object": null, "prechange_data": {"name": "Site 2"}, "postchange_data": null}
+]}`
+
+func objectChangesHandler(t *testing.T, queries *[]map[string][]string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.Header().Set("X-Request-ID", "req-b")
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		assert.Equal(t, "/api/core/object-changes/", r.URL.Path)
+		*queries = append(*queries, r.URL.Query())
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, objectChangesResponse)
+	})
+}
+
+func TestObjectChangesDataSourceRead(t *testing.T) {
+	t.Parallel()
+
+	var queries []map[string][]string
+	client := testutil.NewMockAPIClient(t, objectChangesHandler(t, &queries))
+	utils.SetProviderSettings(client, utils.ProviderSettings{RequestID: "run-1"})
+
+	resp := testutil.ReadDataSource(t, datasources.NewObjectChangesDataSource(), client, map[string]tftypes.Value{
+		"object_type": tftypes.NewValue(tftypes.String, "dcim.site"),
+		"object_id":   tftypes.NewValue(tftypes.String, "1"),
+		"user":        tftypes.NewValue(tftypes.String, "terraform"),
+		"time_after":  tftypes.NewValue(tftypes.String, "2024-05-01T00:00:00Z"),
+	})
+	require.False(t, resp.Diagnostics.HasError(), "Read returned errors: %v", resp.Diagnostics)
+
+	require.Len(t, queries, 1)
+	assert.Equal(t, []string{"dcim.site"}, queries[0]["changed_object_type"])
+	assert.Equal(t, []string{"1"}, queries[0]["changed_object_id"])
+	assert.Equal(t, []string{"terraform"}, queries[0]["user_name"])
+	assert.Equal(t, []string{"2024-05-01T00:00:00Z"}, queries[0]["time_after"])
+	assert.NotContains(t, queries[0], "request_id")
+
+	var providerRequestID string
+	require.False(t, resp.State.GetAttribute(t.Context(), path.Root("provider_request_id"), &providerRequestID).HasError())
+	assert.Equal(t, "run-1", providerRequestID)
+
+	var changes types.List
+	require.False(t, resp.State.GetAttribute(t.Context(), path.Root("changes"), &changes).HasError())
+	elements := changes.Elements()
+	require.Len(t, elements, 3)
+
+	created := elements[0].(types.Object).Attributes()
+	assert.Equal(t, types.StringValue("11"), created["id"], "changes are ordered by time")
+	assert.Equal(t, types.StringValue("create"), created["action"])
+	assert.Equal(t, types.StringValue("2024-05-01T10:00:01Z"), created["time"])
+	assert.True(t, created["prechange_data"].IsNull())
+
+	updated := elements[1].(types.Object).Attributes()
+	assert.Equal(t, types.StringValue("req-b"), updated["request_id"])
+	assert.Equal(t, types.StringValue("Site 1"), updated["object_display"])
+	assert.Equal(t, types.ListValueMust(types.StringType, []attr.Value{
+		types.StringValue("comments"), types.StringValue("description"),
+	}), updated["changed_attributes"])
+
+	deleted := elements[2].(types.Object).Attributes()
+	assert.Equal(t, types.StringValue("admin"), deleted["user"])
+	assert.True(t, deleted["object_display"].IsNull())
+	assert.Equal(t, types.StringValue(`{"name":"Site 2"}`), deleted["prechange_data"])
+}
+
+func TestObjectChangesDataSourceRead_CurrentRun(t *testing.T) {
+	t.Parallel()
+
+	var queries []map[string][]string
+	client := testutil.NewMockAPIClient(t, objectChangesHandler(t, &queries))
+	settings := utils.ProviderSettings{RequestID: "run-1", WriteRequests: utils.NewWriteRequestLog()}
+	utils.SetProviderSettings(client, settings)
+	read := func() types.List {
+		t.Helper()
+		resp := testutil.ReadDataSource(t, datasources.NewObjectChangesDataSource(), client, map[string]tftypes.Value{
+			"current_run": tftypes.NewValue(tftypes.Bool, true),
+		})
+		require.False(t, resp.Diagnostics.HasError(), "Read returned errors: %v", resp.Diagnostics)
+		var changes types.List
+		require.False(t, resp.State.GetAttribute(t.Context(), path.Root("changes"), &changes).HasError())
+		return changes
+	}
+
+	assert.Empty(t, read().Elements(), "nothing was written in this run")
+	assert.Empty(t, queries, "change records are not listed when nothing was written")
+
+	// A write through the provider's transport records the request ID NetBox
+	// returns.
+	httpClient := &http.Client{Transport: &utils.ProviderTransport{Settings: settings}}
+	writeResp, err := httpClient.Post(client.GetConfig().Servers[0].URL+"/api/dcim/sites/", "application/json", nil)
+	require.NoError(t, err)
+	writeResp.Body.Close()
+
+	changes := read().Elements()
+	require.Len(t, changes, 1)
+	assert.Equal(t, types.StringValue("12"), changes[0].(types.Object).Attributes()["id"])
+	require.Len(t, queries, 1)
+	assert.Len(t, queries[0]["time_after"], 1, "the window starts with the run")
+}
@@ -0,0 +1,118 @@
+package datasources_unit_tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/bab3l/terraform-provider-netbox/internal/datasources"
+	"github.com/bab3l/terraform-provider-netbox/internal/testutil"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestObjectsDataSource(t *testing.T) {
+	t.Parallel()
+
+	d := datasources.NewObjectsDataSource()
+	testutil.ValidateDataSourceMetadata(t, d, "netbox", "netbox_objects")
+	testutil.ValidateDataSourceConfigure(t, d)
+
+	s := testutil.DataSourceSchema(t, d)
+	testutil.ValidateDataSourceSchema(t, s.Attributes, testutil.DataSourceValidation{
+		LookupAttrs:   []string{"fields", "brief"},
+		ComputedAttrs: []string{"ids", "results", "results_json"},
+	})
+	assert.True(t, s.Attributes["path"].IsRequired())
+	assert.Contains(t, s.Blocks, "filter")
+}
+
+func TestObjectsDataSourceRead(t *testing.T) {
+	t.Parallel()
+
+	const total = 120
+	var queries []map[string][]string
+	client := testutil.NewMockAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/plugins/bgp/session/", r.URL.Path)
+		queries = append(queries, r.URL.Query())
+
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		results := []interface{}{}
+		for i := offset; i < total && i < offset+limit; i++ {
+			session := map[string]interface{}{
+				"id":        i + 1,
+				"name":      "session-" + strconv.Itoa(i+1),
+				"remote_as": map[string]interface{}{"id": 3, "asn": 65001},
+			}
+			if i%2 == 0 {
+				session["description"] = nil
+			} else {
+				session["description"] = "odd"
+			}
+			results = append(results, session)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"count": total, "results": results})
+	}))
+
+	resp := testutil.ReadDataSource(t, datasources.NewObjectsDataSource(), client, map[string]tftypes.Value{
+		"path":   tftypes.NewValue(tftypes.String, "/api/plugins/bgp/session/"),
+		"filter": testutil.QueryFilterValue(map[string][]string{"status": {"active"}}),
+		"fields": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{
+			tftypes.NewValue(tftypes.String, "id"),
+			tftypes.NewValue(tftypes.String, "name"),
+		}),
+		"brief": tftypes.NewValue(tftypes.Bool, true),
+	})
+	require.False(t, resp.Diagnostics.HasError(), "Read returned errors: %v", resp.Diagnostics)
+
+	require.Len(t, queries, 2)
+	assert.Equal(t, []string{"active"}, queries[0]["status"])
+	assert.Equal(t, []string{"id,name"}, queries[0]["fields"])
+	assert.Equal(t, []string{"true"}, queries[0]["brief"])
+
+	var ids []string
+	require.False(t, resp.State.GetAttribute(t.Context(), path.Root("ids"), &ids).HasError())
+	require.Len(t, ids, total)
+	assert.Equal(t, "120", ids[total-1])
+
+	var results types.Dynamic
+	require.False(t, resp.State.GetAttribute(t.Context(), path.Root("results"), &results).HasError())
+	tuple, ok := results.UnderlyingValue().(types.Tuple)
+	require.True(t, ok, "expected results to be a tuple, got %T", results.UnderlyingValue())
+	require.Len(t, tuple.Elements(), total)
+
+	// Elements may differ in shape, e.g. null versus string descriptions.
+	first := tuple.Elements()[0].(types.Object).Attributes()
+	assert.Equal(t, types.StringValue("session-1"), first["name"])
+	assert.True(t, first["description"].IsNull())
+	remoteAS := first["remote_as"].(types.Object).Attributes()
+	asn, _ := remoteAS["asn"].(types.Number).ValueBigFloat().Int64()
+	assert.Equal(t, int64(65001), asn)
+
+	var resultsJSON string
+	require.False(t, resp.State.GetAttribute(t.Context(), path.Root("results_json"), &resultsJSON).HasError())
+	var decoded []map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(resultsJSON), &decoded))
+	assert.Len(t, decoded, total)
+	assert.Equal(t, "odd", decoded[1]["description"])
+}
+
+func TestObjectsDataSourceRead_APIError(t *testing.T) {
+	t.Parallel()
+
+	client := testutil.NewMockAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"detail": "Not found."}`))
+	}))
+
+	resp := testutil.ReadDataSource(t, datasources.NewObjectsDataSource(), client, map[string]tftypes.Value{
+		"path": tftypes.NewValue(tftypes.String, "plugins/missing/things"),
+	})
+	require.True(t, resp.Diagnostics.HasError())
+	assert.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "Not found.")
+}
@@ -0,0 +1,127 @@
+package datasources_unit_tests
+
+import (
+	"testing"
+
+	"github.com/bab3l/terraform-provider-netbox/internal/datasources"
+	"github.com/bab3l/terraform-provider-netbox/internal/testutil"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type powerConnectionValue struct {
+	OutletID       string  `tfsdk:"outlet_id"`
+	Outlet         string  `tfsdk:"outlet"`
+	OutletDeviceID *string `tfsdk:"outlet_device_id"`
+	OutletDevice   *string `tfsdk:"outlet_device"`
+	FeedLeg        *string `tfsdk:"feed_leg"`
+	CableID        *string `tfsdk:"cable_id"`
+	PowerPortID    string  `tfsdk:"power_port_id"`
+	PowerPort      string  `tfsdk:"power_port"`
+	DeviceID       *string `tfsdk:"device_id"`
+	Device         *string `tfsdk:"device"`
+	AllocatedDraw  *int64  `tfsdk:"allocated_draw"`
+	MaximumDraw    *int64  `tfsdk:"maximum_draw"`
+}
+
+func TestPowerConnectionsDataSource(t *testing.T) {
+	t.Parallel()
+
+	d := datasources.NewPowerConnectionsDataSource()
+	testutil.ValidateDataSourceMetadata(t, d, "netbox", "netbox_power_connections")
+	testutil.ValidateDataSourceConfigure(t, d)
+
+	s := testutil.DataSourceSchema(t, d)
+	testutil.ValidateDataSourceSchema(t, s.Attributes, testutil.DataSourceValidation{
+		LookupAttrs:   []string{"device_id", "rack_id"},
+		ComputedAttrs: []string{"connections", "allocated_draw", "maximum_draw"},
+	})
+}
+
+func TestPowerConnectionsDataSourceRead(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		config    map[string]tftypes.Value
+		ports     []string
+		allocated int64
+	}{
+		// The PDU outlets in rack 7 also supply srv02 in rack 9.
+		"rack":             {map[string]tftypes.Value{"rack_id": tftypes.NewValue(tftypes.String, "7")}, []string{"300", "301"}, 700},
+		"pdu":              {map[string]tftypes.Value{"device_id": tftypes.NewValue(tftypes.String, "10")}, []string{"300", "301"}, 700},
+		"consumer":         {map[string]tftypes.Value{"device_id": tftypes.NewValue(tftypes.String, "12")}, []string{"301"}, 300},
+		"device on a feed": {map[string]tftypes.Value{"device_id": tftypes.NewValue(tftypes.String, "13")}, []string{}, 0},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := testutil.ReadDataSource(t, datasources.NewPowerConnectionsDataSource(), newPowerChainMock(t), tc.config)
+			require.False(t, resp.Diagnostics.HasError(), "Read returned errors: %v", resp.Diagnostics)
+
+			var connections []powerConnectionValue
+			var allocated int64
+			require.False(t, resp.State.GetAttribute(t.Context(), path.Root("connections"), &connections).HasError())
+			require.False(t, resp.State.GetAttribute(t.Context(), path.Root("allocated_draw"), &allocated).HasError())
+
+			ports := []string{}
+			for _, connection := range connections {
+				ports = append(ports, connection.PowerPortID)
+			}
+			assert.Equal(t, tc.ports, ports)
+			assert.Equal(t, tc.allocated, allocated)
+		})
+	}
+}
+
+func TestPowerConnectionsDataSourceRead_Attributes(t *testing.T) {
+	t.Parallel()
+
+	resp := testutil.ReadDataSource(t, datasources.NewPowerConnectionsDataSource(), newPowerChainMock(t), map[string]tftypes.Value{
+		"device_id": tftypes.NewValue(tftypes.String, "11"),
+	})
+	require.False(t, resp.Diagnostics.HasError(), "Read returned errors: %v", resp.Diagnostics)
+
+	var connections []powerConnectionValue
+	require.False(t, resp.State.GetAttribute(t.Context(), path.Root("connections"), &connections).HasError())
+	require.Len(t, connections, 1)
+
+	connection := connections[0]
+	assert.Equal(t, "200", connection.OutletID)
+	assert.Equal(t, "Outlet 1", connection.Outlet)
+	assert.Equal(t, "10", *connection.OutletDeviceID)
+	assert.Equal(t, "pdu-a", *connection.OutletDevice)
+	assert.Equal(t, "A", *connection.FeedLeg)
+	assert.Equal(t, "50", *connection.CableID)
+	assert.Equal(t, "PSU1", connection.PowerPort)
+	assert.Equal(t, "srv01", *connection.Device)
+	assert.Equal(t, int64(400), *connection.AllocatedDraw)
+	assert.Equal(t, int64(500), *connection.MaximumDraw)
+}
+
+func TestPowerConnectionsDataSourceRead_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		config  map[string]tftypes.Value
+		summary string
+	}{
+		"no identifier": {map[string]tftypes.Value{"device_id": tftypes.NewValue(tftypes.String, nil)}, "Missing identifier"},
+		"both identifiers": {map[string]tftypes.Value{
+			"device_id": tftypes.NewValue(tftypes.String, "10"),
+			"rack_id":   tftypes.NewValue(tftypes.String, "7"),
+		}, "Conflicting identifiers"},
+		"invalid id": {map[string]tftypes.Value{"device_id": tftypes.NewValue(tftypes.String, "pdu-a")}, "Invalid ID"},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := testutil.ReadDataSource(t, datasources.NewPowerConnectionsDataSource(), newPowerChainMock(t), tc.config)
+			require.True(t, resp.Diagnostics.HasError())
+			assert.Equal(t, tc.summary, resp.Diagnostics.Errors()[0].Summary())
+		})
+	}
+}
@@ -0,0 +1,251 @@
+package datasources_unit_tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/bab3l/go-netbox"
+	"github.com/bab3l/terraform-provider-netbox/internal/datasources"
+	"github.com/bab3l/terraform-provider-netbox/internal/testutil"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// powerChainObject is a mocked power feed, port or outlet with the filter
+// values it matches.
+type powerChainObject struct {
+	filters map[string]int
+	body    map[string]interface{}
+}
+
+// newPowerChainMock serves a power chain with a three-phase feed 5 cabled to
+// the inlet (port 100) of PDU pdu-a in rack 7, whose outlets 200 (leg A) and
+// 201 (leg B) supply srv01 in rack 7 and srv02 in rack 9. Single-phase feed 6
+// supplies pdu-b in rack 7, whose inlet (port 101) sets its own draw. Feed 8
+// belongs to the same panel but has no rack and no cable.
+func newPowerChainMock(t *testing.T) *netbox.APIClient {
+	t.Helper()
+
+	device := func(id int, name string) map[string]interface{} {
+		return map[string]interface{}{"id": id, "name": name, "display": name}
+	}
+	ref := func(id int) map[string]interface{} { return map[string]interface{}{"id": id} }
+	choice := func(value string) map[string]interface{} {
+		return map[string]interface{}{"value": value, "label": value}
+	}
+	peers := func(objectType string, ids ...int) (interface{}, interface{}) {
+		list := make([]interface{}, 0, len(ids))
+		for _, id := range ids {
+			list = append(list, ref(id))
+		}
+		return list, objectType
+	}
+	draw := func(allocated, maximum interface{}) map[string]interface{} {
+		return map[string]interface{}{"allocated_draw": allocated, "maximum_draw": maximum}
+	}
+	withPeers := func(body map[string]interface{}, objectType string, ids ...int) map[string]interface{} {
+		body["link_peers"], body["link_peers_type"] = peers(objectType, ids...)
+		return body
+	}
+	with := func(body map[string]interface{}, extra map[string]interface{}) map[string]interface{} {
+		for k, v := range extra {
+			body[k] = v
+		}
+		return body
+	}
+
+	feed := func(id int, name string, rack interface{}, phase string, available int) map[string]interface{} {
+		return map[string]interface{}{
+			"id": id, "name": name, "power_panel": ref(2), "rack": rack, "status": choice("active"),
+			"phase": choice(phase), "voltage": 230, "amperage": 32, "max_utilization": 80, "available_power": available,
+			"link_peers": []interface{}{}, "link_peers_type": nil,
+		}
+	}
+	objects := map[string][]powerChainObject{
+		"/api/dcim/power-feeds/": {
+			{map[string]int{"id": 5, "power_panel_id": 2, "rack_id": 7}, withPeers(feed(5, "feed-a", ref(7), "three-phase", 17304), "dcim.powerport", 100)},
+			{map[string]int{"id": 6, "power_panel_id": 2, "rack_id": 7}, withPeers(feed(6, "feed-b", ref(7), "single-phase", 5888), "dcim.powerport", 101)},
+			{map[string]int{"id": 8, "power_panel_id": 2}, feed(8, "feed-spare", nil, "single-phase", 5888)},
+		},
+		"/api/dcim/power-ports/": {
+			{map[string]int{"id": 100, "device_id": 10, "rack_id": 7}, withPeers(with(map[string]interface{}{"id": 100, "name": "Inlet", "device": device(10, "pdu-a")}, draw(nil, nil)), "dcim.powerfeed", 5)},
+			{map[string]int{"id": 101, "device_id": 13, "rack_id": 7}, withPeers(with(map[string]interface{}{"id": 101, "name": "Inlet", "device": device(13, "pdu-b")}, draw(1000, 1200)), "dcim.powerfeed", 6)},
+			{map[string]int{"id": 300, "device_id": 11, "rack_id": 7}, withPeers(with(map[string]interface{}{"id": 300, "name": "PSU1", "device": device(11, "srv01")}, draw(400, 500)), "dcim.poweroutlet", 200)},
+			{map[string]int{"id": 301, "device_id": 12, "rack_id": 9}, withPeers(with(map[string]interface{}{"id": 301, "name": "PSU1", "device": device(12, "srv02")}, draw(300, 350)), "dcim.poweroutlet", 201)},
+		},
+		"/api/dcim/power-outlets/": {
+			{map[string]int{"id": 200, "device_id": 10, "rack_id": 7, "power_port_id": 100}, withPeers(map[string]interface{}{"id": 200, "name": "Outlet 1", "device": device(10, "pdu-a"), "power_port": ref(100), "feed_leg": choice("A"), "cable": ref(50)}, "dcim.powerport", 300)},
+			{map[string]int{"id": 201, "device_id": 10, "rack_id": 7, "power_port_id": 100}, withPeers(map[string]interface{}{"id": 201, "name": "Outlet 2", "device": device(10, "pdu-a"), "power_port": ref(100), "feed_leg": choice("B"), "cable": ref(51)}, "dcim.powerport", 301)},
+			{map[string]int{"id": 202, "device_id": 10, "rack_id": 7, "power_port_id": 100}, map[string]interface{}{"id": 202, "name": "Outlet 3", "device": device(10, "pdu-a"), "power_port": ref(100), "feed_leg": choice("A"), "cable": nil, "link_peers": []interface{}{}, "link_peers_type": nil}},
+		},
+	}
+
+	return testutil.NewMockAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		candidates, ok := objects[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"detail": "Not found."}`))
+			return
+		}
+		results := []interface{}{}
+		for _, object := range candidates {
+			matches := true
+			for name, values := range r.URL.Query() {
+				switch name {
+				case "limit", "offset":
+					continue
+				case "cabled":
+					matches = matches && object.body["link_peers_type"] != nil
+					continue
+				}
+				found := false
+				for _, value := range values {
+					found = found || value == strconv.Itoa(object.filters[name])
+				}
+				matches = matches && found
+			}
+			if matches {
+				results = append(results, object.body)
+			}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"count": len(results), "results": results})
+	}))
+}
+
+func TestPowerFeedUtilizationDataSource(t *testing.T) {
+	t.Parallel()
+
+	d := datasources.NewPowerFeedUtilizationDataSource()
+	testutil.ValidateDataSourceMetadata(t, d, "netbox", "netbox_power_feed_utilization")
+	testutil.ValidateDataSourceConfigure(t, d)
+
+	s := testutil.DataSourceSchema(t, d)
+	testutil.ValidateDataSourceSchema(t, s.Attributes, testutil.DataSourceValidation{
+		LookupAttrs:   []string{"power_feed_id", "power_panel_id", "rack_id"},
+		ComputedAttrs: []string{"feeds", "racks", "available_power", "allocated_draw", "maximum_draw", "utilization"},
+	})
+}
+
+func TestPowerFeedUtilizationDataSourceRead(t *testing.T) {
+	t.Parallel()
+
+	resp := testutil.ReadDataSource(t, datasources.NewPowerFeedUtilizationDataSource(), newPowerChainMock(t), map[string]tftypes.Value{
+		"rack_id": tftypes.NewValue(tftypes.String, "7"),
+	})
+	require.False(t, resp.Diagnostics.HasError(), "Read returned errors: %v", resp.Diagnostics)
+
+	get := func(p path.Path, target interface{}) {
+		t.Helper()
+		require.False(t, resp.State.GetAttribute(t.Context(), p, target).HasError(), p.String())
+	}
+	feed := func(i int) path.Path { return path.Root("feeds").AtListIndex(i) }
+
+	var ids []string
+	for i := range 2 {
+		var id string
+		get(feed(i).AtName("id"), &id)
+		ids = append(ids, id)
+	}
+	assert.Equal(t, []string{"5", "6"}, ids)
+
+	// The three-phase feed is loaded by the servers on the PDU outlets, split by leg.
+	var allocated, maximum, available int64
+	var portIDs []string
+	get(feed(0).AtName("allocated_draw"), &allocated)
+	get(feed(0).AtName("maximum_draw"), &maximum)
+	get(feed(0).AtName("power_port_ids"), &portIDs)
+	assert.Equal(t, int64(700), allocated)
+	assert.Equal(t, int64(850), maximum)
+	assert.Equal(t, []string{"100"}, portIDs)
+
+	type leg struct {
+		Name           string  `tfsdk:"name"`
+		AvailablePower int64   `tfsdk:"available_power"`
+		AllocatedDraw  int64   `tfsdk:"allocated_draw"`
+		MaximumDraw    int64   `tfsdk:"maximum_draw"`
+		Utilization    float64 `tfsdk:"utilization"`
+	}
+	var legs []leg
+	get(feed(0).AtName("legs"), &legs)
+	assert.Equal(t, []leg{
+		{Name: "A", AvailablePower: 5768, AllocatedDraw: 400, MaximumDraw: 500, Utilization: 400 * 100 / 5768.0},
+		{Name: "B", AvailablePower: 5768, AllocatedDraw: 300, MaximumDraw: 350, Utilization: 300 * 100 / 5768.0},
+		{Name: "C", AvailablePower: 5768},
+	}, legs)
+
+	// A port that sets its own draw is used as is.
+	get(feed(1).AtName("allocated_draw"), &allocated)
+	get(feed(1).AtName("legs"), &legs)
+	assert.Equal(t, int64(1000), allocated)
+	assert.Empty(t, legs)
+
+	var utilization float64
+	get(path.Root("available_power"), &available)
+	get(path.Root("allocated_draw"), &allocated)
+	get(path.Root("utilization"), &utilization)
+	assert.Equal(t, int64(17304+5888), available)
+	assert.Equal(t, int64(1700), allocated)
+	assert.InDelta(t, 1700*100/float64(17304+5888), utilization, 1e-9)
+
+	var racks []struct {
+		RackID         string  `tfsdk:"rack_id"`
+		AvailablePower int64   `tfsdk:"available_power"`
+		AllocatedDraw  int64   `tfsdk:"allocated_draw"`
+		MaximumDraw    int64   `tfsdk:"maximum_draw"`
+		Utilization    float64 `tfsdk:"utilization"`
+	}
+	get(path.Root("racks"), &racks)
+	require.Len(t, racks, 1)
+	assert.Equal(t, "7", racks[0].RackID)
+	assert.Equal(t, int64(2050), racks[0].MaximumDraw)
+}
+
+func TestPowerFeedUtilizationDataSourceRead_PowerPanel(t *testing.T) {
+	t.Parallel()
+
+	resp := testutil.ReadDataSource(t, datasources.NewPowerFeedUtilizationDataSource(), newPowerChainMock(t), map[string]tftypes.Value{
+		"power_panel_id": tftypes.NewValue(tftypes.String, "2"),
+	})
+	require.False(t, resp.Diagnostics.HasError(), "Read returned errors: %v", resp.Diagnostics)
+
+	var rackID *string
+	require.False(t, resp.State.GetAttribute(t.Context(), path.Root("feeds").AtListIndex(2).AtName("rack_id"), &rackID).HasError())
+	assert.Nil(t, rackID, "feeds without a rack are reported with a null rack_id")
+
+	var available, allocated int64
+	require.False(t, resp.State.GetAttribute(t.Context(), path.Root("available_power"), &available).HasError())
+	require.False(t, resp.State.GetAttribute(t.Context(), path.Root("allocated_draw"), &allocated).HasError())
+	assert.Equal(t, int64(17304+5888+5888), available)
+	assert.Equal(t, int64(1700), allocated)
+}
+
+func TestPowerFeedUtilizationDataSourceRead_Errors(t *testing.T) {
+	t.Parallel()
+
+	null := tftypes.NewValue(tftypes.String, nil)
+	tests := map[string]struct {
+		config  map[string]tftypes.Value
+		summary string
+	}{
+		"not found":     {map[string]tftypes.Value{"power_feed_id": tftypes.NewValue(tftypes.String, "99")}, "Power Feed Not Found"},
+		"no identifier": {map[string]tftypes.Value{"power_feed_id": null}, "Missing identifier"},
+		"two identifiers": {map[string]tftypes.Value{
+			"power_feed_id": tftypes.NewValue(tftypes.String, "5"),
+			"rack_id":       tftypes.NewValue(tftypes.String, "7"),
+		}, "Conflicting identifiers"},
+		"invalid id": {map[string]tftypes.Value{"rack_id": tftypes.NewValue(tftypes.String, "rack")}, "Invalid ID"},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := testutil.ReadDataSource(t, datasources.NewPowerFeedUtilizationDataSource(), newPowerChainMock(t), tc.config)
+			require.True(t, resp.Diagnostics.HasError())
+			assert.Equal(t, tc.summary, resp.Diagnostics.Errors()[0].Summary())
+		})
+	}
+}
@@ -0,0 +1,329 @@
+package datasources_unit_tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/bab3l/terraform-provider-netbox/internal/datasources"
+	"github.com/bab3l/terraform-provider-netbox/internal/testutil"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type queryDataSourceInfo struct {
+	name     string
+	factory  func() datasource.DataSource
+	singular func() datasource.DataSource
+}
+
+func queryDataSourcesUnderTest() []queryDataSourceInfo {
+	return []queryDataSourceInfo{
+		{name: "sites", factory: datasources.NewSitesDataSource, singular: datasources.NewSiteDataSource},
+		{name: "racks", factory: datasources.NewRacksDataSource, singular: datasources.NewRackDataSource},
+		{name: "vlans", factory: datasources.NewVLANsDataSource, singular: datasources.NewVLANDataSource},
+		{name: "vrfs", factory: datasources.NewVRFsDataSource, singular: datasources.NewVRFDataSource},
+		{name: "circuits", factory: datasources.NewCircuitsDataSource, singular: datasources.NewCircuitDataSource},
+		{name: "cables", factory: datasources.NewCablesDataSource, singular: datasources.NewCableDataSource},
+		{name: "tenants", factory: datasources.NewTenantsDataSource, singular: datasources.NewTenantDataSource},
+		{name: "clusters", factory: datasources.NewClustersDataSource, singular: datasources.NewClusterDataSource},
+		{name: "tags", factory: datasources.NewTagsDataSource, singular: datasources.NewTagDataSource},
+		{name: "ike_policies", factory: datasources.NewIKEPoliciesDataSource, singular: datasources.NewIKEPolicyDataSource},
+		{name: "circuit_groups", factory: datasources.NewCircuitGroupsDataSource, singular: datasources.NewCircuitGroupDataSource},
+		{name: "devices", factory: datasources.NewDevicesDataSource, singular: datasources.NewDeviceDataSource},
+		{name: "interfaces", factory: datasources.NewInterfacesDataSource, singular: datasources.NewInterfaceDataSource},
+		{name: "ip_addresses", factory: datasources.NewIPAddressesDataSource, singular: datasources.NewIPAddressDataSource},
+		{name: "prefixes", factory: datasources.NewPrefixesDataSource, singular: datasources.NewPrefixDataSource},
+		{name: "virtual_machines", factory: datasources.NewVirtualMachinesDataSource, singular: datasources.NewVirtualMachineDataSource},
+		{name: "circuit_types", factory: datasources.NewCircuitTypesDataSource, singular: datasources.NewCircuitTypeDataSource},
+		{name: "cluster_groups", factory: datasources.NewClusterGroupsDataSource, singular: datasources.NewClusterGroupDataSource},
+		{name: "cluster_types", factory: datasources.NewClusterTypesDataSource, singular: datasources.NewClusterTypeDataSource},
+		{name: "contact_groups", factory: datasources.NewContactGroupsDataSource, singular: datasources.NewContactGroupDataSource},
+		{name: "contact_roles", factory: datasources.NewContactRolesDataSource, singular: datasources.NewContactRoleDataSource},
+		{name: "device_roles", factory: datasources.NewDeviceRolesDataSource, singular: datasources.NewDeviceRoleDataSource},
+		{name: "device_types", factory: datasources.NewDeviceTypesDataSource, singular: datasources.NewDeviceTypeDataSource},
+		{name: "fhrp_groups", factory: datasources.NewFHRPGroupsDataSource, singular: datasources.NewFHRPGroupDataSource},
+		{name: "inventory_items", factory: datasources.NewInventoryItemsDataSource, singular: datasources.NewInventoryItemDataSource},
+		{name: "inventory_item_roles", factory: datasources.NewInventoryItemRolesDataSource, singular: datasources.NewInventoryItemRoleDataSource},
+		{name: "journal_entries", factory: datasources.NewJournalEntriesDataSource, singular: datasources.NewJournalEntryDataSource},
+		{name: "l2vpns", factory: datasources.NewL2VPNsDataSource, singular: datasources.NewL2VPNDataSource},
+		{name: "l2vpn_terminations", factory: datasources.NewL2VPNTerminationsDataSource, singular: datasources.NewL2VPNTerminationDataSource},
+		{name: "locations", factory: datasources.NewLocationsDataSource, singular: datasources.NewLocationDataSource},
+		{name: "platforms", factory: datasources.NewPlatformsDataSource, singular: datasources.NewPlatformDataSource},
+		{name: "providers", factory: datasources.NewProvidersDataSource, singular: datasources.NewProviderDataSource},
+		{name: "rack_roles", factory: datasources.NewRackRolesDataSource, singular: datasources.NewRackRoleDataSource},
+		{name: "scripts", factory: datasources.NewScriptsDataSource, singular: datasources.NewScriptDataSource},
+		{name: "tunnels", factory: datasources.NewTunnelsDataSource, singular: datasources.NewTunnelDataSource},
+		{name: "tunnel_groups", factory: datasources.NewTunnelGroupsDataSource, singular: datasources.NewTunnelGroupDataSource},
+		{name: "tunnel_terminations", factory: datasources.NewTunnelTerminationsDataSource, singular: datasources.NewTunnelTerminationDataSource},
+		{name: "users", factory: datasources.NewUsersDataSource, singular: datasources.NewUserDataSource},
+		{name: "virtual_chassis_list", factory: datasources.NewVirtualChassisListDataSource, singular: datasources.NewVirtualChassisDataSource},
+		{name: "vm_interfaces", factory: datasources.NewVMInterfacesDataSource, singular: datasources.NewVMInterfaceDataSource},
+		{name: "wireless_lans", factory: datasources.NewWirelessLANsDataSource, singular: datasources.NewWirelessLANDataSource},
+		{name: "wireless_lan_groups", factory: datasources.NewWirelessLANGroupsDataSource, singular: datasources.NewWirelessLANGroupDataSource},
+	}
+}
+
+func TestQueryDataSourcesSchema(t *testing.T) {
+	t.Parallel()
+
+	for _, info := range queryDataSourcesUnderTest() {
+		t.Run(info.name, func(t *testing.T) {
+			t.Parallel()
+
+			d := info.factory()
+			testutil.ValidateDataSourceMetadata(t, d, "netbox", "netbox_"+info.name)
+			testutil.ValidateDataSourceConfigure(t, d)
+
+			s := testutil.DataSourceSchema(t, d)
+			testutil.ValidateDataSourceSchema(t, s.Attributes, testutil.DataSourceValidation{
+				LookupAttrs:   []string{},
+				ComputedAttrs: []string{"ids", info.name},
+			})
+
+			block, ok := s.Blocks["filter"].(schema.SetNestedBlock)
+			require.True(t, ok, "expected a 'filter' set block")
+			assert.Contains(t, block.NestedObject.Attributes, "name")
+			assert.Contains(t, block.NestedObject.Attributes, "values")
+
+			results, ok := s.Attributes[info.name].(schema.ListNestedAttribute)
+			require.True(t, ok, "expected %s to be a list nested attribute", info.name)
+
+			// Every result mirrors the singular data source and is read-only.
+			singular := testutil.DataSourceSchema(t, info.singular())
+			assert.Len(t, results.NestedObject.Attributes, len(singular.Attributes))
+			for name := range singular.Attributes {
+				nested, ok := results.NestedObject.Attributes[name]
+				if !assert.True(t, ok, "result is missing singular attribute %q", name) {
+					continue
+				}
+				assert.True(t, nested.IsComputed(), "result attribute %q should be computed", name)
+				assert.False(t, nested.IsOptional(), "result attribute %q should not be optional", name)
+				assert.False(t, nested.IsRequired(), "result attribute %q should not be required", name)
+			}
+		})
+	}
+}
+
+func mockSite(id int) map[string]interface{} {
+	return map[string]interface{}{
+		"id":      id,
+		"url":     fmt.Sprintf("http://netbox.example.com/api/dcim/sites/%d/", id),
+		"display": fmt.Sprintf("site-%d", id),
+		"name":    fmt.Sprintf("site-%d", id),
+		"slug":    fmt.Sprintf("site-%d", id),
+		"status":  map[string]interface{}{"value": "active", "label": "Active"},
+		"tenant":  map[string]interface{}{"id": 7, "url": "http://netbox.example.com/api/tenancy/tenants/7/", "display": "Tenant", "name": "Tenant", "slug": "tenant"},
+		"tags": []interface{}{
+			map[string]interface{}{"id": 1, "url": "http://netbox.example.com/api/extras/tags/1/", "display": "Prod", "name": "Prod", "slug": "prod"},
+		},
+		"custom_fields": map[string]interface{}{"cost_center": fmt.Sprintf("cc-%d", id%2)},
+	}
+}
+
+func TestQueryDataSourceRead(t *testing.T) {
+	t.Parallel()
+
+	const total = 150
+	var queries []map[string][]string
+	client := testutil.NewMockAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/dcim/sites/", r.URL.Path)
+		require.Equal(t, "Token test-token", r.Header.Get("Authorization"))
+		queries = append(queries, r.URL.Query())
+
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		results := []interface{}{}
+		for i := offset; i < total && i < offset+limit; i++ {
+			results = append(results, mockSite(i+1))
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"count": total, "results": results})
+	}))
+
+	resp := testutil.ReadDataSource(t, datasources.NewSitesDataSource(), client, map[string]tftypes.Value{
+		"filter": testutil.QueryFilterValue(map[string][]string{
+			"region_id": {"3", "4"},
+			"status":    {"active"},
+		}),
+	})
+	require.False(t, resp.Diagnostics.HasError(), "Read returned errors: %v", resp.Diagnostics)
+
+	// Filters are forwarded verbatim and pagination walks every page.
+	require.Len(t, queries, 2)
+	assert.Equal(t, []string{"3", "4"}, queries[0]["region_id"])
+	assert.Equal(t, []string{"active"}, queries[0]["status"])
+	assert.Equal(t, "0", queries[0]["offset"][0])
+	assert.Equal(t, "100", queries[1]["offset"][0])
+
+	var ids []string
+	require.False(t, resp.State.GetAttribute(t.Context(), path.Root("ids"), &ids).HasError())
+	require.Len(t, ids, total)
+	assert.Equal(t, "1", ids[0])
+
+	var sites types.List
+	require.False(t, resp.State.GetAttribute(t.Context(), path.Root("sites"), &sites).HasError())
+	require.Len(t, sites.Elements(), total)
+
+	first := sites.Elements()[0].(types.Object).Attributes()
+	assert.Equal(t, types.StringValue("site-1"), first["name"])
+	assert.Equal(t, types.StringValue("active"), first["status"])
+	assert.Equal(t, types.StringValue("Tenant"), first["tenant"])
+	assert.Equal(t, types.StringValue("7"), first["tenant_id"])
+	assert.Len(t, first["tags"].(types.Set).Elements(), 1)
+	assert.Equal(t, types.StringNull(), first["description"])
+	assertNoUnknownValues(t, first)
+}
+
+func TestQueryDataSourceRead_CustomFieldFilter(t *testing.T) {
+	t.Parallel()
+
+	client := testutil.NewMockAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Empty(t, r.URL.Query()["custom_field_value"], "client-side filters must not be forwarded")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"count":   2,
+			"results": []interface{}{mockSite(1), mockSite(2)},
+		})
+	}))
+
+	resp := testutil.ReadDataSource(t, datasources.NewSitesDataSource(), client, map[string]tftypes.Value{
+		"filter": testutil.QueryFilterValue(map[string][]string{
+			"custom_field_value": {"cost_center=cc-0"},
+		}),
+	})
+	require.False(t, resp.Diagnostics.HasError(), "Read returned errors: %v", resp.Diagnostics)
+
+	var ids []string
+	require.False(t, resp.State.GetAttribute(t.Context(), path.Root("ids"), &ids).HasError())
+	assert.Equal(t, []string{"2"}, ids)
+}
+
+func TestQueryDataSourceRead_VirtualMachines(t *testing.T) {
+	t.Parallel()
+
+	var queries []map[string][]string
+	client := testutil.NewMockAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/virtualization/virtual-machines/", r.URL.Path)
+		queries = append(queries, r.URL.Query())
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"count": 1,
+			"results": []interface{}{map[string]interface{}{
+				"id":                 9,
+				"url":                "http://netbox.example.com/api/virtualization/virtual-machines/9/",
+				"display":            "vm-1",
+				"name":               "vm-1",
+				"status":             map[string]interface{}{"value": "active", "label": "Active"},
+				"cluster":            map[string]interface{}{"id": 4, "url": "http://netbox.example.com/api/virtualization/clusters/4/", "display": "Cluster", "name": "Cluster"},
+				"vcpus":              2,
+				"memory":             4096,
+				"primary_ip":         nil,
+				"interface_count":    0,
+				"virtual_disk_count": 0,
+			}},
+		})
+	}))
+
+	resp := testutil.ReadDataSource(t, datasources.NewVirtualMachinesDataSource(), client, map[string]tftypes.Value{
+		"filter": testutil.QueryFilterValue(map[string][]string{"cluster_id": {"4"}}),
+	})
+	require.False(t, resp.Diagnostics.HasError(), "Read returned errors: %v", resp.Diagnostics)
+
+	require.Len(t, queries, 1)
+	assert.Equal(t, []string{"4"}, queries[0]["cluster_id"])
+
+	var vms types.List
+	require.False(t, resp.State.GetAttribute(t.Context(), path.Root("virtual_machines"), &vms).HasError())
+	require.Len(t, vms.Elements(), 1)
+	vm := vms.Elements()[0].(types.Object).Attributes()
+	assert.Equal(t, types.StringValue("9"), vm["id"])
+	assert.Equal(t, types.StringValue("vm-1"), vm["name"])
+	assert.Equal(t, types.StringValue("Cluster"), vm["cluster"])
+	assert.Equal(t, types.Float64Value(2), vm["vcpus"])
+	assert.Equal(t, types.Int64Value(4096), vm["memory"])
+	assertNoUnknownValues(t, vm)
+
+	var names []string
+	require.False(t, resp.State.GetAttribute(t.Context(), path.Root("names"), &names).HasError())
+	assert.Equal(t, []string{"vm-1"}, names)
+}
+
+func TestQueryDataSourceRead_Locations(t *testing.T) {
+	t.Parallel()
+
+	client := testutil.NewMockAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/dcim/locations/", r.URL.Path)
+		assert.Equal(t, []string{"3"}, r.URL.Query()["site_id"])
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"count": 1,
+			"results": []interface{}{map[string]interface{}{
+				"id":           12,
+				"url":          "http://netbox.example.com/api/dcim/locations/12/",
+				"display":      "Floor 1",
+				"name":         "Floor 1",
+				"slug":         "floor-1",
+				"site":         map[string]interface{}{"id": 3, "url": "http://netbox.example.com/api/dcim/sites/3/", "display": "Site", "name": "Site", "slug": "site"},
+				"status":       map[string]interface{}{"value": "active", "label": "Active"},
+				"rack_count":   0,
+				"device_count": 0,
+				"_depth":       0,
+			}},
+		})
+	}))
+
+	resp := testutil.ReadDataSource(t, datasources.NewLocationsDataSource(), client, map[string]tftypes.Value{
+		"filter": testutil.QueryFilterValue(map[string][]string{"site_id": {"3"}}),
+	})
+	require.False(t, resp.Diagnostics.HasError(), "Read returned errors: %v", resp.Diagnostics)
+
+	var locations types.List
+	require.False(t, resp.State.GetAttribute(t.Context(), path.Root("locations"), &locations).HasError())
+	require.Len(t, locations.Elements(), 1)
+	location := locations.Elements()[0].(types.Object).Attributes()
+	assert.Equal(t, types.StringValue("12"), location["id"])
+	assert.Equal(t, types.StringValue("floor-1"), location["slug"])
+	assert.Equal(t, types.StringValue("Site"), location["site"])
+	assert.Equal(t, types.StringValue("3"), location["site_id"])
+	assert.Equal(t, types.StringValue("active"), location["status"])
+	assertNoUnknownValues(t, location)
+}
+
+func TestQueryDataSourceRead_RequiresFilter(t *testing.T) {
+	t.Parallel()
+
+	client := testutil.NewMockAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected API call to %s", r.URL.Path)
+	}))
+
+	resp := testutil.ReadDataSource(t, datasources.NewTenantsDataSource(), client, nil)
+	require.True(t, resp.Diagnostics.HasError())
+	assert.Contains(t, resp.Diagnostics.Errors()[0].Summary(), "Missing filters")
+}
+
+func TestQueryDataSourceRead_APIError(t *testing.T) {
+	t.Parallel()
+
+	client := testutil.NewMockAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"status": ["Select a valid choice."]}`))
+	}))
+
+	resp := testutil.ReadDataSource(t, datasources.NewVLANsDataSource(), client, map[string]tftypes.Value{
+		"filter": testutil.QueryFilterValue(map[string][]string{"status": {"bogus"}}),
+	})
+	require.True(t, resp.Diagnostics.HasError())
+	assert.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "Select a valid choice.")
+}
+
+func assertNoUnknownValues(t *testing.T, attrs map[string]attr.Value) {
+	t.Helper()
+	for name, v := range attrs {
+		assert.False(t, v.IsUnknown(), "attribute %q should not be unknown", name)
+	}
+}
@@ -0,0 +1,191 @@
+package datasources_unit_tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/bab3l/go-netbox"
+	"github.com/bab3l/terraform-provider-netbox/internal/datasources"
+	"github.com/bab3l/terraform-provider-netbox/internal/testutil"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newRackElevationMock serves a 10U rack with a full-depth 2U device at U1, a
+// half-depth device on the front at U5, and a reservation of U8.
+func newRackElevationMock(t *testing.T) *netbox.APIClient {
+	t.Helper()
+
+	device := func(id int, name interface{}) map[string]interface{} {
+		return map[string]interface{}{"id": id, "name": name, "display": "device-" + strconv.Itoa(id)}
+	}
+	return testutil.NewMockAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var results []interface{}
+		switch r.URL.Path {
+		case "/api/dcim/racks/7/elevation/":
+			face := r.URL.Query().Get("face")
+			for u := 10; u >= 1; u-- {
+				unit := map[string]interface{}{"id": u, "name": "U" + strconv.Itoa(u), "face": face, "device": nil, "occupied": false}
+				if face == "rear" {
+					// NetBox renders decimal unit IDs as strings by default.
+					unit["id"] = strconv.Itoa(u) + ".0"
+				}
+				switch {
+				case u <= 2:
+					unit["device"], unit["occupied"] = device(1, "core-sw01"), true
+				case u == 5 && face == "front":
+					unit["device"], unit["occupied"] = device(2, nil), true
+				}
+				results = append(results, unit)
+			}
+		case "/api/dcim/rack-reservations/":
+			results = []interface{}{}
+			if r.URL.Query().Get("rack_id") == "7" {
+				results = append(results, map[string]interface{}{"id": 3, "units": []int{8}})
+			}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"detail": "Not found."}`))
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"count": len(results), "results": results})
+	}))
+}
+
+func TestRackElevationDataSource(t *testing.T) {
+	t.Parallel()
+
+	d := datasources.NewRackElevationDataSource()
+	testutil.ValidateDataSourceMetadata(t, d, "netbox", "netbox_rack_elevation")
+	testutil.ValidateDataSourceConfigure(t, d)
+
+	s := testutil.DataSourceSchema(t, d)
+	testutil.ValidateDataSourceSchema(t, s.Attributes, testutil.DataSourceValidation{
+		ComputedAttrs: []string{"front", "rear"},
+	})
+	assert.True(t, s.Attributes["rack_id"].IsRequired())
+}
+
+func TestRackElevationDataSourceRead(t *testing.T) {
+	t.Parallel()
+
+	resp := testutil.ReadDataSource(t, datasources.NewRackElevationDataSource(), newRackElevationMock(t), map[string]tftypes.Value{
+		"rack_id": tftypes.NewValue(tftypes.String, "7"),
+	})
+	require.False(t, resp.Diagnostics.HasError(), "Read returned errors: %v", resp.Diagnostics)
+
+	type unit struct {
+		ID            float64 `tfsdk:"id"`
+		Name          string  `tfsdk:"name"`
+		Status        string  `tfsdk:"status"`
+		DeviceID      *string `tfsdk:"device_id"`
+		Device        *string `tfsdk:"device"`
+		ReservationID *string `tfsdk:"reservation_id"`
+	}
+	var front, rear []unit
+	require.False(t, resp.State.GetAttribute(t.Context(), path.Root("front"), &front).HasError())
+	require.False(t, resp.State.GetAttribute(t.Context(), path.Root("rear"), &rear).HasError())
+	require.Len(t, front, 10)
+	require.Len(t, rear, 10)
+
+	statuses := func(units []unit) []string {
+		result := make([]string, 0, len(units))
+		for _, u := range units {
+			result = append(result, u.Status)
+		}
+		return result
+	}
+	// Units are returned top-down, as NetBox does.
+	assert.Equal(t, []string{"free", "free", "reservation", "free", "free", "device", "free", "free", "device", "device"}, statuses(front))
+	assert.Equal(t, []string{"free", "free", "reservation", "free", "free", "free", "free", "free", "device", "device"}, statuses(rear))
+
+	assert.Equal(t, 10.0, rear[0].ID)
+	assert.Equal(t, 1.0, front[9].ID)
+	require.NotNil(t, front[9].Device)
+	assert.Equal(t, "core-sw01", *front[9].Device)
+	assert.Equal(t, "1", *front[9].DeviceID)
+	// Unnamed devices are reported by their display name.
+	assert.Equal(t, "device-2", *front[5].Device)
+	assert.Equal(t, "3", *front[2].ReservationID)
+	assert.Nil(t, front[0].DeviceID)
+	assert.Nil(t, front[0].ReservationID)
+}
+
+func TestRackElevationDataSourceRead_NotFound(t *testing.T) {
+	t.Parallel()
+
+	resp := testutil.ReadDataSource(t, datasources.NewRackElevationDataSource(), newRackElevationMock(t), map[string]tftypes.Value{
+		"rack_id": tftypes.NewValue(tftypes.String, "99"),
+	})
+	require.True(t, resp.Diagnostics.HasError())
+	assert.Equal(t, "Rack Not Found", resp.Diagnostics.Errors()[0].Summary())
+}
+
+func TestRackFreeUnitsDataSource(t *testing.T) {
+	t.Parallel()
+
+	d := datasources.NewRackFreeUnitsDataSource()
+	testutil.ValidateDataSourceMetadata(t, d, "netbox", "netbox_rack_free_units")
+	testutil.ValidateDataSourceConfigure(t, d)
+
+	s := testutil.DataSourceSchema(t, d)
+	testutil.ValidateDataSourceSchema(t, s.Attributes, testutil.DataSourceValidation{
+		LookupAttrs:   []string{"is_full_depth"},
+		ComputedAttrs: []string{"position", "available_positions"},
+	})
+	for _, name := range []string{"rack_id", "u_height", "face"} {
+		assert.True(t, s.Attributes[name].IsRequired(), "%s should be required", name)
+	}
+}
+
+func TestRackFreeUnitsDataSourceRead(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		height    float64
+		face      string
+		fullDepth interface{}
+		position  float64
+		available []float64
+	}{
+		"full depth by default": {height: 2, face: "rear", fullDepth: nil, position: 3, available: []float64{3, 6, 9}},
+		"half depth on rear":    {height: 2, face: "rear", fullDepth: false, position: 3, available: []float64{3, 4, 5, 6, 9}},
+		"half depth on front":   {height: 1, face: "front", fullDepth: false, position: 3, available: []float64{3, 4, 6, 7, 9, 10}},
+		"tall device":           {height: 3, face: "front", fullDepth: true, position: 0, available: nil},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			fullDepth := tftypes.NewValue(tftypes.Bool, nil)
+			if tc.fullDepth != nil {
+				fullDepth = tftypes.NewValue(tftypes.Bool, tc.fullDepth)
+			}
+			resp := testutil.ReadDataSource(t, datasources.NewRackFreeUnitsDataSource(), newRackElevationMock(t), map[string]tftypes.Value{
+				"rack_id":       tftypes.NewValue(tftypes.String, "7"),
+				"u_height":      tftypes.NewValue(tftypes.Number, tc.height),
+				"face":          tftypes.NewValue(tftypes.String, tc.face),
+				"is_full_depth": fullDepth,
+			})
+
+			if tc.available == nil {
+				require.True(t, resp.Diagnostics.HasError())
+				assert.Equal(t, "Insufficient rack space", resp.Diagnostics.Errors()[0].Summary())
+				return
+			}
+			require.False(t, resp.Diagnostics.HasError(), "Read returned errors: %v", resp.Diagnostics)
+
+			var position float64
+			var available []float64
+			require.False(t, resp.State.GetAttribute(t.Context(), path.Root("position"), &position).HasError())
+			require.False(t, resp.State.GetAttribute(t.Context(), path.Root("available_positions"), &available).HasError())
+			assert.Equal(t, tc.position, position)
+			assert.Equal(t, tc.available, available)
+		})
+	}
+}
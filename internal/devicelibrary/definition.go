@@ -0,0 +1,215 @@
+// Package devicelibrary parses device type and module type definitions in the
+// format of the community devicetype-library
+// (https://github.com/netbox-community/devicetype-library) and computes the
+// component template changes needed to make NetBox match a definition.
+package devicelibrary
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/bab3l/terraform-provider-netbox/internal/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// Kind selects the type of definition: a device type or a module type.
+type Kind int
+
+const (
+	DeviceType Kind = iota
+	ModuleType
+)
+
+// String returns the human-readable name of the kind.
+func (k Kind) String() string {
+	if k == ModuleType {
+		return "module type"
+	}
+	return "device type"
+}
+
+// ComponentKind describes one component template list of a definition.
+type ComponentKind struct {
+	// Key is the YAML key of the list, e.g. "front-ports".
+	Key string
+	// Endpoint is the NetBox API path of the templates, e.g. "dcim/front-port-templates".
+	Endpoint string
+	// References maps template fields that name another template to the Key
+	// of that template's list, e.g. front port "rear_port" -> "rear-ports".
+	References map[string]string
+	// DeviceTypeOnly is set for templates that module types do not support.
+	DeviceTypeOnly bool
+}
+
+// componentKinds lists the supported component templates. Templates are created
+// in this order and deleted in reverse, so referenced templates (rear ports,
+// power ports) exist before the templates that reference them.
+var componentKinds = []ComponentKind{
+	{Key: "console-ports", Endpoint: "dcim/console-port-templates"},
+	{Key: "console-server-ports", Endpoint: "dcim/console-server-port-templates"},
+	{Key: "power-ports", Endpoint: "dcim/power-port-templates"},
+	{Key: "power-outlets", Endpoint: "dcim/power-outlet-templates", References: map[string]string{"power_port": "power-ports"}},
+	{Key: "interfaces", Endpoint: "dcim/interface-templates"},
+	{Key: "rear-ports", Endpoint: "dcim/rear-port-templates"},
+	{Key: "front-ports", Endpoint: "dcim/front-port-templates", References: map[string]string{"rear_port": "rear-ports"}},
+	{Key: "module-bays", Endpoint: "dcim/module-bay-templates"},
+	{Key: "device-bays", Endpoint: "dcim/device-bay-templates", DeviceTypeOnly: true},
+	{Key: "inventory-items", Endpoint: "dcim/inventory-item-templates", DeviceTypeOnly: true},
+}
+
+// typeAttributes are the top-level keys sent to NetBox for each kind, besides
+// manufacturer, model and slug.
+var typeAttributes = map[Kind][]string{
+	DeviceType: {"part_number", "u_height", "exclude_from_utilization", "is_full_depth", "subdevice_role", "airflow", "weight", "weight_unit", "description", "comments"},
+	ModuleType: {"part_number", "airflow", "weight", "weight_unit", "description", "comments"},
+}
+
+// ComponentKinds returns the component templates supported by kind, in
+// creation order.
+func ComponentKinds(kind Kind) []ComponentKind {
+	kinds := make([]ComponentKind, 0, len(componentKinds))
+	for _, c := range componentKinds {
+		if kind == ModuleType && c.DeviceTypeOnly {
+			continue
+		}
+		kinds = append(kinds, c)
+	}
+	return kinds
+}
+
+// Template is a component template of a definition. Attributes hold every key
+// of the YAML entry except `name`, decoded as JSON values.
+type Template struct {
+	Name       string
+	Attributes map[string]interface{}
+}
+
+// Definition is a parsed devicetype-library device type or module type.
+type Definition struct {
+	Kind         Kind
+	Manufacturer string
+	Model        string
+	// Slug is only set for device types.
+	Slug string
+	// Attributes are the other type fields sent to NetBox (part_number, u_height, ...).
+	Attributes map[string]interface{}
+	// Components holds the templates by ComponentKind.Key.
+	Components map[string][]Template
+	// Ignored lists top-level keys that are not supported and were skipped
+	// (e.g. front_image), sorted.
+	Ignored []string
+}
+
+// Parse decodes a devicetype-library YAML document.
+func Parse(data []byte, kind Kind) (*Definition, error) {
+	var raw map[string]interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("invalid YAML: %w", err)
+	}
+	if raw == nil {
+		return nil, fmt.Errorf("the definition is empty")
+	}
+
+	// Round-trip through JSON so values have the types used for API payloads
+	// and comparisons (json.Number for numbers).
+	encoded, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("unsupported YAML value: %w", err)
+	}
+	doc, err := utils.DecodeJSONObject(encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	def := &Definition{
+		Kind:       kind,
+		Attributes: map[string]interface{}{},
+		Components: map[string][]Template{},
+	}
+	if def.Manufacturer, err = requiredString(doc, "manufacturer"); err != nil {
+		return nil, err
+	}
+	if def.Model, err = requiredString(doc, "model"); err != nil {
+		return nil, err
+	}
+	if kind == DeviceType {
+		if def.Slug, err = requiredString(doc, "slug"); err != nil {
+			return nil, err
+		}
+	}
+
+	known := map[string]bool{"manufacturer": true, "model": true, "slug": kind == DeviceType}
+	for _, key := range typeAttributes[kind] {
+		known[key] = true
+		if value, ok := doc[key]; ok {
+			def.Attributes[key] = value
+		}
+	}
+
+	for _, c := range componentKinds {
+		value, ok := doc[c.Key]
+		if !ok {
+			continue
+		}
+		if kind == ModuleType && c.DeviceTypeOnly {
+			return nil, fmt.Errorf("%q are not supported on module types", c.Key)
+		}
+		known[c.Key] = true
+		templates, err := parseTemplates(c.Key, value)
+		if err != nil {
+			return nil, err
+		}
+		def.Components[c.Key] = templates
+	}
+
+	for key := range doc {
+		if !known[key] {
+			def.Ignored = append(def.Ignored, key)
+		}
+	}
+	sort.Strings(def.Ignored)
+	return def, nil
+}
+
+func requiredString(doc map[string]interface{}, key string) (string, error) {
+	value, ok := doc[key].(string)
+	if !ok || strings.TrimSpace(value) == "" {
+		return "", fmt.Errorf("%q is required and must be a string", key)
+	}
+	return value, nil
+}
+
+func parseTemplates(key string, value interface{}) ([]Template, error) {
+	items, ok := value.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%q must be a list", key)
+	}
+
+	templates := make([]Template, 0, len(items))
+	seen := make(map[string]bool, len(items))
+	for i, item := range items {
+		fields, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%s[%d] must be a mapping", key, i)
+		}
+		name, ok := fields["name"].(string)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("%s[%d] must have a name", key, i)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("%s contains %q more than once", key, name)
+		}
+		seen[name] = true
+
+		attributes := make(map[string]interface{}, len(fields)-1)
+		for k, v := range fields {
+			if k != "name" {
+				attributes[k] = v
+			}
+		}
+		templates = append(templates, Template{Name: name, Attributes: attributes})
+	}
+	return templates, nil
+}
@@ -0,0 +1,221 @@
+package devicelibrary
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func parseFixture(t *testing.T, name string, kind Kind) *Definition {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	require.NoError(t, err)
+	def, err := Parse(data, kind)
+	require.NoError(t, err)
+	return def
+}
+
+func templateNames(templates []Template) []string {
+	names := make([]string, 0, len(templates))
+	for _, tmpl := range templates {
+		names = append(names, tmpl.Name)
+	}
+	return names
+}
+
+func TestParse_DeviceType(t *testing.T) {
+	def := parseFixture(t, "juniper-ex4300-48t.yaml", DeviceType)
+
+	assert.Equal(t, "Juniper", def.Manufacturer)
+	assert.Equal(t, "EX4300-48T", def.Model)
+	assert.Equal(t, "juniper-ex4300-48t", def.Slug)
+	assert.Equal(t, json.Number("1"), def.Attributes["u_height"])
+	assert.Equal(t, json.Number("8.75"), def.Attributes["weight"])
+	assert.Equal(t, true, def.Attributes["is_full_depth"])
+	assert.NotContains(t, def.Attributes, "front_image")
+	assert.Equal(t, []string{"front_image", "rear_image"}, def.Ignored)
+
+	assert.Equal(t, []string{"Console"}, templateNames(def.Components["console-ports"]))
+	assert.Equal(t, []string{"PSU0", "PSU1"}, templateNames(def.Components["power-ports"]))
+	assert.Equal(t, []string{"ge-0/0/0", "ge-0/0/1", "em0"}, templateNames(def.Components["interfaces"]))
+	assert.Equal(t, []string{"PIC 2"}, templateNames(def.Components["module-bays"]))
+
+	em0 := def.Components["interfaces"][2]
+	assert.Equal(t, map[string]interface{}{"type": "1000base-t", "mgmt_only": true}, em0.Attributes)
+	assert.Equal(t, json.Number("350"), def.Components["power-ports"][0].Attributes["maximum_draw"])
+}
+
+func TestParse_References(t *testing.T) {
+	def := parseFixture(t, "patch-panel-24.yaml", DeviceType)
+
+	require.Len(t, def.Components["front-ports"], 2)
+	assert.Equal(t, "Rear 1", def.Components["front-ports"][1].Attributes["rear_port"])
+	assert.Equal(t, json.Number("2"), def.Components["front-ports"][1].Attributes["rear_port_position"])
+	assert.Empty(t, def.Ignored)
+}
+
+func TestParse_ModuleType(t *testing.T) {
+	def := parseFixture(t, "module-sfp-4x10g.yaml", ModuleType)
+
+	assert.Equal(t, "Juniper", def.Manufacturer)
+	assert.Equal(t, "EX-UM-4X4SFP", def.Model)
+	assert.Empty(t, def.Slug)
+	assert.Equal(t, map[string]interface{}{"part_number": "EX-UM-4X4SFP", "comments": "Uplink module"}, def.Attributes)
+	assert.Equal(t, []string{"xe-0/2/{module}0", "xe-0/2/{module}1"}, templateNames(def.Components["interfaces"]))
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := map[string]struct {
+		yaml     string
+		kind     Kind
+		contains string
+	}{
+		"invalid yaml":          {yaml: "model: [", kind: DeviceType, contains: "invalid YAML"},
+		"empty":                 {yaml: "", kind: DeviceType, contains: "empty"},
+		"missing manufacturer":  {yaml: "model: X\nslug: x", kind: DeviceType, contains: `"manufacturer" is required`},
+		"missing model":         {yaml: "manufacturer: X\nslug: x", kind: DeviceType, contains: `"model" is required`},
+		"missing slug":          {yaml: "manufacturer: X\nmodel: X", kind: DeviceType, contains: `"slug" is required`},
+		"components not a list": {yaml: "manufacturer: X\nmodel: X\nslug: x\ninterfaces: eth0", kind: DeviceType, contains: `"interfaces" must be a list`},
+		"template without name": {yaml: "manufacturer: X\nmodel: X\nslug: x\ninterfaces:\n  - type: virtual", kind: DeviceType, contains: "interfaces[0] must have a name"},
+		"device bays on module": {yaml: "manufacturer: X\nmodel: X\ndevice-bays:\n  - name: Bay 1", kind: ModuleType, contains: `"device-bays" are not supported on module types`},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := Parse([]byte(tc.yaml), tc.kind)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.contains)
+		})
+	}
+
+	data, err := os.ReadFile(filepath.Join("testdata", "invalid-duplicate-interface.yaml"))
+	require.NoError(t, err)
+	_, err = Parse(data, DeviceType)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `interfaces contains "eth0" more than once`)
+}
+
+func TestComponentKinds(t *testing.T) {
+	keys := func(kinds []ComponentKind) []string {
+		result := make([]string, 0, len(kinds))
+		for _, k := range kinds {
+			result = append(result, k.Key)
+		}
+		return result
+	}
+
+	deviceKeys := keys(ComponentKinds(DeviceType))
+	assert.Contains(t, deviceKeys, "device-bays")
+	assert.Contains(t, deviceKeys, "inventory-items")
+
+	moduleKeys := keys(ComponentKinds(ModuleType))
+	assert.NotContains(t, moduleKeys, "device-bays")
+	assert.NotContains(t, moduleKeys, "inventory-items")
+
+	// Referenced templates are created before the templates that reference them.
+	position := map[string]int{}
+	for i, key := range deviceKeys {
+		position[key] = i
+	}
+	for _, kind := range ComponentKinds(DeviceType) {
+		for _, target := range kind.References {
+			assert.Less(t, position[target], position[kind.Key], "%s must come before %s", target, kind.Key)
+		}
+	}
+}
+
+func TestDiffTemplates(t *testing.T) {
+	def := parseFixture(t, "juniper-ex4300-48t.yaml", DeviceType)
+
+	// NetBox returns choices and references as nested objects, and fields the
+	// definition does not set.
+	existing := []ExistingTemplate{
+		{ID: 12, Name: "ge-0/0/0", Attributes: map[string]interface{}{
+			"type":        map[string]interface{}{"value": "1000base-t", "label": "1000BASE-T (1GE)"},
+			"mgmt_only":   false,
+			"description": "",
+		}},
+		{ID: 13, Name: "em0", Attributes: map[string]interface{}{
+			"type":      map[string]interface{}{"value": "1000base-t", "label": "1000BASE-T (1GE)"},
+			"mgmt_only": false,
+		}},
+		{ID: 15, Name: "xe-0/0/0", Attributes: map[string]interface{}{
+			"type": map[string]interface{}{"value": "10gbase-x-sfpp", "label": "SFP+ (10GE)"},
+		}},
+		{ID: 14, Name: "vme", Attributes: map[string]interface{}{
+			"type": map[string]interface{}{"value": "virtual", "label": "Virtual"},
+		}},
+	}
+
+	changes := DiffTemplates(def.Components["interfaces"], existing)
+	assert.Equal(t, []string{"ge-0/0/1"}, templateNames(changes.Create))
+	require.Len(t, changes.Update, 1)
+	assert.Equal(t, int64(13), changes.Update[0].ID)
+	assert.Equal(t, "em0", changes.Update[0].Template.Name)
+	require.Len(t, changes.Delete, 2)
+	assert.Equal(t, []int64{14, 15}, []int64{changes.Delete[0].ID, changes.Delete[1].ID})
+	assert.False(t, changes.IsEmpty())
+}
+
+func TestDiffTemplates_InSync(t *testing.T) {
+	def := parseFixture(t, "patch-panel-24.yaml", DeviceType)
+
+	// References are resolved to template IDs before diffing.
+	desired := def.Components["front-ports"]
+	for i := range desired {
+		desired[i].Attributes["rear_port"] = json.Number("7")
+	}
+	existing := []ExistingTemplate{
+		{ID: 1, Name: "Front 1", Attributes: map[string]interface{}{
+			"type":               map[string]interface{}{"value": "8p8c", "label": "8P8C"},
+			"rear_port":          map[string]interface{}{"id": json.Number("7"), "name": "Rear 1"},
+			"rear_port_position": json.Number("1"),
+		}},
+		{ID: 2, Name: "Front 2", Attributes: map[string]interface{}{
+			"type":               map[string]interface{}{"value": "8p8c", "label": "8P8C"},
+			"rear_port":          map[string]interface{}{"id": json.Number("7"), "name": "Rear 1"},
+			"rear_port_position": json.Number("2"),
+		}},
+	}
+
+	changes := DiffTemplates(desired, existing)
+	assert.True(t, changes.IsEmpty(), "unexpected changes: %+v", changes)
+
+	// Moving a front port to another rear port position is an update.
+	existing[1].Attributes["rear_port_position"] = json.Number("1")
+	changes = DiffTemplates(desired, existing)
+	require.Len(t, changes.Update, 1)
+	assert.Equal(t, int64(2), changes.Update[0].ID)
+}
+
+func TestDiffTemplates_Empty(t *testing.T) {
+	changes := DiffTemplates(nil, []ExistingTemplate{{ID: 3, Name: "Console"}})
+	assert.Empty(t, changes.Create)
+	assert.Empty(t, changes.Update)
+	require.Len(t, changes.Delete, 1)
+	assert.Equal(t, "Console", changes.Delete[0].Name)
+
+	assert.True(t, DiffTemplates(nil, nil).IsEmpty())
+}
+
+func TestAttributesMatch(t *testing.T) {
+	actual := map[string]interface{}{
+		"u_height":      json.Number("1.0"),
+		"airflow":       map[string]interface{}{"value": "front-to-rear", "label": "Front to rear"},
+		"is_full_depth": true,
+		"weight":        json.Number("8.75"),
+	}
+
+	assert.True(t, AttributesMatch(map[string]interface{}{
+		"u_height":      json.Number("1"),
+		"airflow":       "front-to-rear",
+		"is_full_depth": true,
+		"front_image":   true,
+	}, actual))
+	assert.False(t, AttributesMatch(map[string]interface{}{"airflow": "rear-to-front"}, actual))
+	assert.False(t, AttributesMatch(map[string]interface{}{"weight": json.Number("9")}, actual))
+}
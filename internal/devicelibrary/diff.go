@@ -0,0 +1,87 @@
+package devicelibrary
+
+import (
+	"sort"
+
+	"github.com/bab3l/terraform-provider-netbox/internal/utils"
+)
+
+// ExistingTemplate is a component template read from NetBox. Attributes hold
+// the API representation, where references and choices are nested objects.
+type ExistingTemplate struct {
+	ID         int64
+	Name       string
+	Attributes map[string]interface{}
+}
+
+// TemplateUpdate is a change to an existing template.
+type TemplateUpdate struct {
+	ID       int64
+	Template Template
+}
+
+// TemplateChanges are the API calls needed to make one template list match a
+// definition.
+type TemplateChanges struct {
+	Create []Template
+	Update []TemplateUpdate
+	// Delete lists orphaned templates that are not part of the definition.
+	Delete []ExistingTemplate
+}
+
+// IsEmpty reports whether the templates already match.
+func (c TemplateChanges) IsEmpty() bool {
+	return len(c.Create) == 0 && len(c.Update) == 0 && len(c.Delete) == 0
+}
+
+// DiffTemplates matches desired templates to existing ones by name. References
+// in desired must already be resolved to template IDs.
+//
+// Only attributes present in both the definition and the API representation
+// are compared: keys NetBox does not return are not reported as drift, and
+// attributes removed from the definition are left unchanged in NetBox.
+func DiffTemplates(desired []Template, existing []ExistingTemplate) TemplateChanges {
+	byName := make(map[string]ExistingTemplate, len(existing))
+	for _, e := range existing {
+		byName[e.Name] = e
+	}
+
+	var changes TemplateChanges
+	wanted := make(map[string]bool, len(desired))
+	for _, d := range desired {
+		wanted[d.Name] = true
+		current, ok := byName[d.Name]
+		if !ok {
+			changes.Create = append(changes.Create, d)
+			continue
+		}
+		if !AttributesMatch(d.Attributes, current.Attributes) {
+			changes.Update = append(changes.Update, TemplateUpdate{ID: current.ID, Template: d})
+		}
+	}
+
+	for _, e := range existing {
+		if !wanted[e.Name] {
+			changes.Delete = append(changes.Delete, e)
+		}
+	}
+	sort.Slice(changes.Delete, func(i, j int) bool {
+		return changes.Delete[i].ID < changes.Delete[j].ID
+	})
+	return changes
+}
+
+// AttributesMatch reports whether every desired attribute that NetBox returns
+// has the desired value. Nested objects match by ID and choices by value.
+func AttributesMatch(desired, actual map[string]interface{}) bool {
+	for key, want := range desired {
+		got, ok := actual[key]
+		if !ok {
+			continue
+		}
+		if !utils.JSONValueMatches(want, got) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,125 @@
+package hclgen
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/bab3l/go-netbox"
+)
+
+// CommandName is the provider binary subcommand that runs the generator.
+const CommandName = "generate"
+
+// filterFlag collects repeated -filter flags of the form [type:]key=value.
+type filterFlag struct {
+	all     map[string][]string
+	perType map[string]map[string][]string
+}
+
+func (f *filterFlag) String() string {
+	return ""
+}
+
+func (f *filterFlag) Set(value string) error {
+	key, val, ok := strings.Cut(value, "=")
+	if !ok || strings.TrimSpace(key) == "" {
+		return fmt.Errorf("filter %q must have the form [type:]key=value", value)
+	}
+
+	key = strings.TrimSpace(key)
+	if typeName, filterKey, scoped := strings.Cut(key, ":"); scoped {
+		if _, ok := findObjectType(typeName); !ok {
+			return fmt.Errorf("filter %q references unknown object type %q", value, typeName)
+		}
+		if f.perType[typeName] == nil {
+			f.perType[typeName] = map[string][]string{}
+		}
+		f.perType[typeName][filterKey] = append(f.perType[typeName][filterKey], val)
+		return nil
+	}
+	f.all[key] = append(f.all[key], val)
+	return nil
+}
+
+// Run executes the generate subcommand with args (excluding the subcommand
+// name) and returns the process exit code.
+func Run(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet(CommandName, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+
+	filters := &filterFlag{all: map[string][]string{}, perType: map[string]map[string][]string{}}
+	typesFlag := fs.String("types", "all", "Comma-separated object types to export, or \"all\". Supported: "+strings.Join(supportedTypeNames(), ", "))
+	outDir := fs.String("out", ".", "Directory to write the generated .tf files to.")
+	serverURL := fs.String("server-url", os.Getenv("NETBOX_SERVER_URL"), "NetBox server URL. Defaults to NETBOX_SERVER_URL.")
+	apiToken := fs.String("api-token", os.Getenv("NETBOX_API_TOKEN"), "NetBox API token. Defaults to NETBOX_API_TOKEN.")
+	fs.Var(filters, "filter", "Filter forwarded to the NetBox API as [type:]key=value. Unscoped filters apply to every type. May be repeated.")
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: terraform-provider-netbox %s [flags]\n\n", CommandName)
+		fmt.Fprintln(stderr, "Exports existing NetBox objects as Terraform configuration with matching import blocks.")
+		fmt.Fprintln(stderr)
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *serverURL == "" || *apiToken == "" {
+		fmt.Fprintln(stderr, "Error: a NetBox server URL and API token are required (-server-url/-api-token or NETBOX_SERVER_URL/NETBOX_API_TOKEN).")
+		return 2
+	}
+
+	cfg := netbox.NewConfiguration()
+	cfg.Servers = netbox.ServerConfigurations{{URL: *serverURL}}
+	cfg.DefaultHeader = map[string]string{"Authorization": "Token " + *apiToken}
+	client := netbox.NewAPIClient(cfg)
+
+	generator, err := NewGenerator(ctx, client)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %s\n", err)
+		return 1
+	}
+
+	files, err := generator.Generate(ctx, Options{
+		Types:       strings.Split(*typesFlag, ","),
+		Filters:     filters.all,
+		TypeFilters: filters.perType,
+	})
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %s\n", err)
+		return 1
+	}
+
+	if err := WriteFiles(*outDir, files); err != nil {
+		fmt.Fprintf(stderr, "Error: %s\n", err)
+		return 1
+	}
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(stdout, "Wrote %s\n", filepath.Join(*outDir, name))
+	}
+	return 0
+}
+
+// WriteFiles writes generated files into dir, creating it if needed.
+func WriteFiles(dir string, files map[string][]byte) error {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return err
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), content, 0o600); err != nil {
+			return err
+		}
+	}
+	return nil
+}
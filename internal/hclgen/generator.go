@@ -0,0 +1,463 @@
+// Package hclgen generates Terraform configuration for existing NetBox objects.
+//
+// Objects are read through the REST API and rendered using the resource schemas
+// of this provider: every configurable attribute that NetBox returns is written
+// out, references to other exported objects are rewritten to resource
+// addresses, and each resource gets a matching `import {}` block.
+package hclgen
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/bab3l/go-netbox"
+	"github.com/bab3l/terraform-provider-netbox/internal/provider"
+	"github.com/bab3l/terraform-provider-netbox/internal/utils"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	rsschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+// fileHeader is written at the top of every generated file.
+const fileHeader = "# Generated by `terraform-provider-netbox generate`.\n\n"
+
+// skippedAttributes are never written: `id` is set through the import block and
+// custom field values need their field type, which the object does not carry.
+var skippedAttributes = map[string]bool{
+	"id":            true,
+	"custom_fields": true,
+}
+
+var invalidLabelChars = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// Options controls which objects are exported.
+type Options struct {
+	// Types lists the object type names to export, or "all".
+	Types []string
+	// Filters are forwarded to the list endpoint of every selected type.
+	Filters map[string][]string
+	// TypeFilters are forwarded only to the list endpoint of the named type.
+	TypeFilters map[string]map[string][]string
+}
+
+// Generator renders NetBox objects as Terraform configuration.
+type Generator struct {
+	client  *netbox.APIClient
+	schemas map[string]rsschema.Schema
+}
+
+// exportedObject is a NetBox object selected for export.
+type exportedObject struct {
+	objectType objectType
+	id         string
+	label      string
+	fields     map[string]interface{}
+}
+
+// address returns the resource address of the object, e.g. netbox_site.dc1.
+func (o *exportedObject) address() hcl.Traversal {
+	return hcl.Traversal{
+		hcl.TraverseRoot{Name: o.objectType.resource},
+		hcl.TraverseAttr{Name: o.label},
+	}
+}
+
+// NewGenerator returns a generator that reads objects through client and uses
+// the resource schemas of this provider.
+func NewGenerator(ctx context.Context, client *netbox.APIClient) (*Generator, error) {
+	schemas := make(map[string]rsschema.Schema)
+	for _, newResource := range provider.New("generate")().Resources(ctx) {
+		r := newResource()
+
+		metaResp := &resource.MetadataResponse{}
+		r.Metadata(ctx, resource.MetadataRequest{ProviderTypeName: "netbox"}, metaResp)
+
+		schemaResp := &resource.SchemaResponse{}
+		r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+		if schemaResp.Diagnostics.HasError() {
+			return nil, fmt.Errorf("loading schema of %s: %v", metaResp.TypeName, schemaResp.Diagnostics)
+		}
+		schemas[metaResp.TypeName] = schemaResp.Schema
+	}
+	return &Generator{client: client, schemas: schemas}, nil
+}
+
+// Generate reads the selected objects and returns the generated files keyed
+// by file name (one `<type>.tf` file per object type with at least one object).
+// The output only depends on the API data, so repeated runs are identical.
+func (g *Generator) Generate(ctx context.Context, opts Options) (map[string][]byte, error) {
+	types, err := lookupObjectTypes(opts.Types)
+	if err != nil {
+		return nil, err
+	}
+
+	exported := make(map[string][]*exportedObject, len(types))
+	index := make(map[string]*exportedObject)
+	byContentType := make(map[string]*exportedObject)
+	for _, t := range types {
+		if _, ok := g.schemas[t.resource]; !ok {
+			return nil, fmt.Errorf("resource %s is not provided by this provider", t.resource)
+		}
+
+		objects, err := g.fetch(ctx, t, opts)
+		if err != nil {
+			return nil, err
+		}
+		exported[t.name] = objects
+		for _, obj := range objects {
+			index[t.apiPath+"/"+obj.id] = obj
+			byContentType[t.contentType+"/"+obj.id] = obj
+		}
+	}
+
+	r := &renderer{index: index, byContentType: byContentType}
+	files := make(map[string][]byte)
+	for _, t := range types {
+		objects := exported[t.name]
+		if len(objects) == 0 {
+			continue
+		}
+		content, err := r.renderFile(g.schemas[t.resource], objects)
+		if err != nil {
+			return nil, fmt.Errorf("rendering %s: %w", t.name, err)
+		}
+		files[t.name+".tf"] = content
+	}
+	return files, nil
+}
+
+// fetch lists every object of t matching the filters, sorted by ID, and assigns
+// each a unique resource label.
+func (g *Generator) fetch(ctx context.Context, t objectType, opts Options) ([]*exportedObject, error) {
+	query := url.Values{}
+	for key, values := range opts.Filters {
+		query[key] = append(query[key], values...)
+	}
+	for key, values := range opts.TypeFilters[t.name] {
+		query[key] = append(query[key], values...)
+	}
+
+	rawResults, httpResp, err := utils.ListRawAPIObjects(ctx, g.client, t.apiPath, query)
+	defer utils.CloseResponseBody(httpResp)
+	if err != nil {
+		return nil, fmt.Errorf("%s", utils.FormatAPIError("list "+t.name, err, httpResp))
+	}
+
+	objects := make([]*exportedObject, 0, len(rawResults))
+	for _, raw := range rawResults {
+		fields, err := utils.DecodeJSONObject(raw)
+		if err != nil {
+			return nil, fmt.Errorf("decoding %s: %w", t.name, err)
+		}
+		id, ok := fields["id"].(json.Number)
+		if !ok {
+			return nil, fmt.Errorf("decoding %s: object without a numeric id", t.name)
+		}
+		objects = append(objects, &exportedObject{objectType: t, id: id.String(), fields: fields})
+	}
+
+	sort.SliceStable(objects, func(i, j int) bool {
+		return compareIDs(objects[i].id, objects[j].id) < 0
+	})
+
+	used := make(map[string]bool, len(objects))
+	for _, obj := range objects {
+		label := objectLabel(t, obj)
+		for used[label] {
+			label = label + "_" + obj.id
+		}
+		used[label] = true
+		obj.label = label
+	}
+	return objects, nil
+}
+
+// compareIDs orders numeric IDs numerically.
+func compareIDs(a, b string) int {
+	if len(a) != len(b) {
+		return len(a) - len(b)
+	}
+	return strings.Compare(a, b)
+}
+
+// objectLabel builds a valid Terraform resource label from the label fields.
+func objectLabel(t objectType, obj *exportedObject) string {
+	shortName := strings.TrimPrefix(t.resource, "netbox_")
+
+	parts := make([]string, 0, len(t.labelFields))
+	for _, field := range t.labelFields {
+		if s := lookupString(obj.fields, field); s != "" {
+			parts = append(parts, s)
+		}
+	}
+
+	label := strings.Trim(invalidLabelChars.ReplaceAllString(strings.ToLower(strings.Join(parts, "_")), "_"), "_")
+	switch {
+	case label == "":
+		return shortName + "_" + obj.id
+	case label[0] >= '0' && label[0] <= '9':
+		return shortName + "_" + label
+	default:
+		return label
+	}
+}
+
+// lookupString resolves a dotted path such as "device.name" to a string.
+func lookupString(fields map[string]interface{}, dotted string) string {
+	var current interface{} = fields
+	for _, part := range strings.Split(dotted, ".") {
+		obj, ok := current.(map[string]interface{})
+		if !ok {
+			return ""
+		}
+		current = obj[part]
+	}
+	switch v := current.(type) {
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	default:
+		return ""
+	}
+}
+
+// renderer turns exported objects into HCL.
+type renderer struct {
+	index         map[string]*exportedObject
+	byContentType map[string]*exportedObject
+}
+
+func (r *renderer) renderFile(s rsschema.Schema, objects []*exportedObject) ([]byte, error) {
+	f := hclwrite.NewEmptyFile()
+	body := f.Body()
+
+	names := configurableAttributes(s)
+	for i, obj := range objects {
+		if i > 0 {
+			body.AppendNewline()
+		}
+
+		block := body.AppendNewBlock("resource", []string{obj.objectType.resource, obj.label})
+		for _, name := range names {
+			attrType := s.Attributes[name].GetType().TerraformType(context.Background())
+			tokens, ok, err := r.attributeTokens(obj, name, attrType)
+			if err != nil {
+				return nil, fmt.Errorf("%s %s attribute %s: %w", obj.objectType.resource, obj.id, name, err)
+			}
+			if ok {
+				block.Body().SetAttributeRaw(name, tokens)
+			}
+		}
+
+		body.AppendNewline()
+		importBlock := body.AppendNewBlock("import", nil)
+		importBlock.Body().SetAttributeTraversal("to", obj.address())
+		importBlock.Body().SetAttributeValue("id", cty.StringVal(obj.id))
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(fileHeader)
+	buf.Write(hclwrite.Format(f.Bytes()))
+	return buf.Bytes(), nil
+}
+
+// configurableAttributes returns the attributes that can be set in
+// configuration: required attributes first, then optional ones, each sorted.
+func configurableAttributes(s rsschema.Schema) []string {
+	var required, optional []string
+	for name, a := range s.Attributes {
+		if skippedAttributes[name] {
+			continue
+		}
+		switch {
+		case a.IsRequired():
+			required = append(required, name)
+		case a.IsOptional():
+			optional = append(optional, name)
+		}
+	}
+	sort.Strings(required)
+	sort.Strings(optional)
+	return append(required, optional...)
+}
+
+// attributeTokens renders the API value of attribute name, if it has one.
+func (r *renderer) attributeTokens(obj *exportedObject, name string, attrType tftypes.Type) (hclwrite.Tokens, bool, error) {
+	value, ok := obj.fields[name]
+	if !ok || value == nil {
+		return nil, false, nil
+	}
+
+	// Generic object references are split into <name>_type and <name>_id
+	// fields (e.g. assigned_object_type = "dcim.interface").
+	if prefix, isID := strings.CutSuffix(name, "_id"); isID {
+		if contentType, ok := obj.fields[prefix+"_type"].(string); ok {
+			if id, ok := value.(json.Number); ok {
+				if target := r.byContentType[contentType+"/"+id.String()]; target != nil {
+					return referenceTokens(target, "id"), true, nil
+				}
+			}
+		}
+	}
+
+	elementAttr := "id"
+	if name == "tags" {
+		elementAttr = "slug"
+	}
+	return r.valueTokens(value, attrType, elementAttr)
+}
+
+// valueTokens renders a JSON value as an expression of type t. Nested NetBox
+// objects are rendered as a reference to their refAttr (or the literal value
+// when the object is not exported), and choices as their value.
+func (r *renderer) valueTokens(value interface{}, t tftypes.Type, refAttr string) (hclwrite.Tokens, bool, error) {
+	switch v := value.(type) {
+	case nil:
+		return nil, false, nil
+	case map[string]interface{}:
+		if _, isRef := v["id"]; isRef {
+			if target := r.lookupReference(v); target != nil {
+				return referenceTokens(target, refAttr), true, nil
+			}
+			return r.valueTokens(v[refAttr], t, refAttr)
+		}
+		if choice, isChoice := v["value"]; isChoice {
+			if _, hasLabel := v["label"]; hasLabel {
+				return r.valueTokens(choice, t, refAttr)
+			}
+		}
+		if t.Is(tftypes.String) {
+			return jsonencodeTokens(v)
+		}
+		return nil, false, nil
+	case []interface{}:
+		if len(v) == 0 {
+			return nil, false, nil
+		}
+		var elemType tftypes.Type
+		switch collection := t.(type) {
+		case tftypes.List:
+			elemType = collection.ElementType
+		case tftypes.Set:
+			elemType = collection.ElementType
+		default:
+			if t.Is(tftypes.String) {
+				return jsonencodeTokens(v)
+			}
+			return nil, false, nil
+		}
+
+		elems := make([]hclwrite.Tokens, 0, len(v))
+		for _, item := range v {
+			tokens, ok, err := r.valueTokens(item, elemType, refAttr)
+			if err != nil {
+				return nil, false, err
+			}
+			if !ok {
+				return nil, false, nil
+			}
+			elems = append(elems, tokens)
+		}
+		return hclwrite.TokensForTuple(elems), true, nil
+	default:
+		ctyValue, ok := scalarValue(v, t)
+		if !ok {
+			return nil, false, nil
+		}
+		return hclwrite.TokensForValue(ctyValue), true, nil
+	}
+}
+
+// lookupReference finds the exported object a nested NetBox object points to.
+func (r *renderer) lookupReference(ref map[string]interface{}) *exportedObject {
+	rawURL, ok := ref["url"].(string)
+	if !ok {
+		return nil
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil
+	}
+	_, apiPath, found := strings.Cut(u.Path, "/api/")
+	if !found {
+		return nil
+	}
+	return r.index[strings.Trim(apiPath, "/")]
+}
+
+func referenceTokens(target *exportedObject, attr string) hclwrite.Tokens {
+	traversal := append(target.address(), hcl.TraverseAttr{Name: attr})
+	return hclwrite.TokensForTraversal(traversal)
+}
+
+// scalarValue converts a JSON scalar into a cty value of type t. Empty strings
+// are treated as unset, since NetBox returns them for blank optional fields.
+func scalarValue(value interface{}, t tftypes.Type) (cty.Value, bool) {
+	switch {
+	case t.Is(tftypes.String):
+		switch v := value.(type) {
+		case string:
+			if v == "" {
+				return cty.NilVal, false
+			}
+			return cty.StringVal(v), true
+		case json.Number:
+			return cty.StringVal(v.String()), true
+		case bool:
+			if v {
+				return cty.StringVal("true"), true
+			}
+			return cty.StringVal("false"), true
+		}
+	case t.Is(tftypes.Number):
+		var text string
+		switch v := value.(type) {
+		case json.Number:
+			text = v.String()
+		case string:
+			text = v
+		default:
+			return cty.NilVal, false
+		}
+		f, _, err := big.ParseFloat(text, 10, 512, big.ToNearestEven)
+		if err != nil {
+			return cty.NilVal, false
+		}
+		return cty.NumberVal(f), true
+	case t.Is(tftypes.Bool):
+		if v, ok := value.(bool); ok {
+			return cty.BoolVal(v), true
+		}
+	}
+	return cty.NilVal, false
+}
+
+// jsonencodeTokens renders a JSON object or array as a jsonencode() call, for
+// string attributes that hold JSON documents (e.g. local_context_data).
+func jsonencodeTokens(value interface{}) (hclwrite.Tokens, bool, error) {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return nil, false, err
+	}
+	impliedType, err := ctyjson.ImpliedType(raw)
+	if err != nil {
+		return nil, false, err
+	}
+	ctyValue, err := ctyjson.Unmarshal(raw, impliedType)
+	if err != nil {
+		return nil, false, err
+	}
+	return hclwrite.TokensForFunctionCall("jsonencode", hclwrite.TokensForValue(ctyValue)), true, nil
+}
@@ -0,0 +1,219 @@
+package hclgen
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/bab3l/go-netbox"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata/golden")
+
+var goldenTypes = []string{"tags", "tenants", "sites", "devices", "interfaces", "ip_addresses"}
+
+// fakeAPI serves list endpoints from testdata/api/<path>.json, where path is
+// the API path with "/" replaced by "_". Objects are returned in reverse order
+// to make sure the generator does not depend on the API ordering.
+type fakeAPI struct {
+	t       *testing.T
+	mu      sync.Mutex
+	queries map[string]url.Values
+}
+
+func newFakeAPI(t *testing.T) (*fakeAPI, *netbox.APIClient) {
+	t.Helper()
+
+	api := &fakeAPI{t: t, queries: map[string]url.Values{}}
+	server := httptest.NewServer(api)
+	t.Cleanup(server.Close)
+
+	cfg := netbox.NewConfiguration()
+	cfg.Servers = netbox.ServerConfigurations{{URL: server.URL}}
+	cfg.HTTPClient = server.Client()
+	return api, netbox.NewAPIClient(cfg)
+}
+
+func (f *fakeAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	apiPath := strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/"), "/")
+
+	f.mu.Lock()
+	f.queries[apiPath] = r.URL.Query()
+	f.mu.Unlock()
+
+	var results []json.RawMessage
+	raw, err := os.ReadFile(filepath.Join("testdata", "api", strings.ReplaceAll(apiPath, "/", "_")+".json"))
+	if err == nil {
+		if err := json.Unmarshal(raw, &results); err != nil {
+			f.t.Errorf("invalid fixture for %s: %s", apiPath, err)
+		}
+	}
+	for i, j := 0, len(results)-1; i < j; i, j = i+1, j-1 {
+		results[i], results[j] = results[j], results[i]
+	}
+
+	page := results
+	if r.URL.Query().Get("offset") != "0" {
+		page = nil
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"count":   len(results),
+		"results": page,
+	})
+}
+
+func (f *fakeAPI) query(apiPath string) url.Values {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.queries[apiPath]
+}
+
+func newTestGenerator(t *testing.T, client *netbox.APIClient) *Generator {
+	t.Helper()
+	g, err := NewGenerator(context.Background(), client)
+	require.NoError(t, err)
+	return g
+}
+
+func TestGenerate_Golden(t *testing.T) {
+	_, client := newFakeAPI(t)
+	g := newTestGenerator(t, client)
+
+	files, err := g.Generate(context.Background(), Options{Types: goldenTypes})
+	require.NoError(t, err)
+
+	goldenDir := filepath.Join("testdata", "golden")
+	if *update {
+		require.NoError(t, WriteFiles(goldenDir, files))
+	}
+
+	entries, err := os.ReadDir(goldenDir)
+	require.NoError(t, err)
+	assert.Len(t, files, len(entries), "generated files do not match the golden files")
+
+	for name, content := range files {
+		expected, err := os.ReadFile(filepath.Join(goldenDir, name))
+		require.NoError(t, err, "missing golden file %s; run go test ./internal/hclgen -update", name)
+		assert.Equal(t, string(expected), string(content), "%s differs from its golden file", name)
+	}
+}
+
+func TestGenerate_Deterministic(t *testing.T) {
+	_, client := newFakeAPI(t)
+	g := newTestGenerator(t, client)
+
+	first, err := g.Generate(context.Background(), Options{Types: []string{"all"}})
+	require.NoError(t, err)
+	second, err := g.Generate(context.Background(), Options{Types: []string{"all"}})
+	require.NoError(t, err)
+
+	require.Equal(t, len(first), len(second))
+	for name, content := range first {
+		assert.True(t, bytes.Equal(content, second[name]), "%s differs between runs", name)
+	}
+}
+
+func TestGenerate_ForwardsFilters(t *testing.T) {
+	api, client := newFakeAPI(t)
+	g := newTestGenerator(t, client)
+
+	_, err := g.Generate(context.Background(), Options{
+		Types:       []string{"sites", "tenants"},
+		Filters:     map[string][]string{"tag": {"prod"}},
+		TypeFilters: map[string]map[string][]string{"sites": {"region": {"emea", "apac"}}},
+	})
+	require.NoError(t, err)
+
+	sites := api.query("dcim/sites")
+	assert.Equal(t, []string{"prod"}, sites["tag"])
+	assert.Equal(t, []string{"emea", "apac"}, sites["region"])
+
+	tenants := api.query("tenancy/tenants")
+	assert.Equal(t, []string{"prod"}, tenants["tag"])
+	assert.Empty(t, tenants["region"])
+}
+
+func TestGenerate_UnknownType(t *testing.T) {
+	_, client := newFakeAPI(t)
+	g := newTestGenerator(t, client)
+
+	_, err := g.Generate(context.Background(), Options{Types: []string{"widgets"}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown object type "widgets"`)
+}
+
+func TestNewGenerator_SchemasForAllTypes(t *testing.T) {
+	g := newTestGenerator(t, nil)
+	for _, ot := range objectTypes {
+		_, ok := g.schemas[ot.resource]
+		assert.True(t, ok, "no schema for %s (%s)", ot.name, ot.resource)
+	}
+}
+
+func TestObjectLabel(t *testing.T) {
+	site, _ := findObjectType("sites")
+	iface, _ := findObjectType("interfaces")
+
+	tests := []struct {
+		name     string
+		t        objectType
+		fields   map[string]interface{}
+		expected string
+	}{
+		{name: "slug", t: site, fields: map[string]interface{}{"slug": "dc1"}, expected: "dc1"},
+		{name: "invalid characters", t: site, fields: map[string]interface{}{"slug": "DC--2.a"}, expected: "dc_2_a"},
+		{name: "leading digit", t: site, fields: map[string]interface{}{"slug": "1st"}, expected: "site_1st"},
+		{name: "empty", t: site, fields: map[string]interface{}{}, expected: "site_42"},
+		{
+			name:     "nested fields",
+			t:        iface,
+			fields:   map[string]interface{}{"name": "Gi0/1", "device": map[string]interface{}{"name": "sw1"}},
+			expected: "sw1_gi0_1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj := &exportedObject{objectType: tt.t, id: "42", fields: tt.fields}
+			assert.Equal(t, tt.expected, objectLabel(tt.t, obj))
+		})
+	}
+}
+
+func TestFilterFlag(t *testing.T) {
+	f := &filterFlag{all: map[string][]string{}, perType: map[string]map[string][]string{}}
+
+	require.NoError(t, f.Set("tag=prod"))
+	require.NoError(t, f.Set("tag=core"))
+	require.NoError(t, f.Set("sites:region=emea"))
+
+	assert.Equal(t, map[string][]string{"tag": {"prod", "core"}}, f.all)
+	assert.Equal(t, map[string]map[string][]string{"sites": {"region": {"emea"}}}, f.perType)
+
+	assert.Error(t, f.Set("tag"))
+	assert.Error(t, f.Set("=prod"))
+	assert.Error(t, f.Set("widgets:name=x"))
+}
+
+func TestRun_RequiresCredentials(t *testing.T) {
+	t.Setenv("NETBOX_SERVER_URL", "")
+	t.Setenv("NETBOX_API_TOKEN", "")
+
+	var stdout, stderr bytes.Buffer
+	code := Run(context.Background(), []string{"-types", "sites"}, &stdout, &stderr)
+	assert.Equal(t, 2, code)
+	assert.Contains(t, stderr.String(), "server URL and API token are required")
+}
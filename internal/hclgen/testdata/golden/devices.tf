@@ -0,0 +1,23 @@
+# Generated by `terraform-provider-netbox generate`.
+
+resource "netbox_device" "sw1" {
+  device_type = "2"
+  role        = "3"
+  site        = netbox_site.dc1.id
+  face        = "front"
+  local_context_data = jsonencode({
+    ntp_servers = ["10.0.0.1", "10.0.0.2"]
+    snmp = {
+      community = "public"
+    }
+  })
+  name     = "sw1"
+  position = 10
+  serial   = "SN123"
+  status   = "active"
+}
+
+import {
+  to = netbox_device.sw1
+  id = "7"
+}
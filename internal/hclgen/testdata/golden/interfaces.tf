@@ -0,0 +1,16 @@
+# Generated by `terraform-provider-netbox generate`.
+
+resource "netbox_interface" "sw1_gi0_1" {
+  device      = netbox_device.sw1.id
+  name        = "Gi0/1"
+  type        = "1000base-t"
+  description = "uplink"
+  enabled     = true
+  mgmt_only   = false
+  mtu         = 9000
+}
+
+import {
+  to = netbox_interface.sw1_gi0_1
+  id = "31"
+}
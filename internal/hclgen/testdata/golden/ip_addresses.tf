@@ -0,0 +1,27 @@
+# Generated by `terraform-provider-netbox generate`.
+
+resource "netbox_ip_address" "ip_address_10_0_0_10_24" {
+  address              = "10.0.0.10/24"
+  assigned_object_id   = netbox_interface.sw1_gi0_1.id
+  assigned_object_type = "dcim.interface"
+  dns_name             = "sw1.example.com"
+  status               = "active"
+  tenant               = netbox_tenant.acme.id
+}
+
+import {
+  to = netbox_ip_address.ip_address_10_0_0_10_24
+  id = "101"
+}
+
+resource "netbox_ip_address" "ip_address_10_0_0_11_24" {
+  address              = "10.0.0.11/24"
+  assigned_object_id   = 55
+  assigned_object_type = "virtualization.vminterface"
+  status               = "reserved"
+}
+
+import {
+  to = netbox_ip_address.ip_address_10_0_0_11_24
+  id = "102"
+}
@@ -0,0 +1,44 @@
+# Generated by `terraform-provider-netbox generate`.
+
+resource "netbox_site" "dc1" {
+  name      = "DC1"
+  slug      = "dc1"
+  facility  = "Building A"
+  latitude  = 52.370216
+  longitude = 4.895168
+  status    = "active"
+  tags      = [netbox_tag.prod.slug]
+  tenant    = netbox_tenant.acme.id
+  time_zone = "Europe/Amsterdam"
+}
+
+import {
+  to = netbox_site.dc1
+  id = "1"
+}
+
+resource "netbox_site" "dc_2" {
+  name        = "DC 2"
+  slug        = "dc-2"
+  description = "Secondary \"DR\" site"
+  region      = "5"
+  status      = "active"
+  tags        = [netbox_tag.core.slug, netbox_tag.prod.slug]
+  tenant      = netbox_tenant.acme.id
+}
+
+import {
+  to = netbox_site.dc_2
+  id = "3"
+}
+
+resource "netbox_site" "site_1st_floor_lab" {
+  name   = "1st Floor Lab"
+  slug   = "1st-floor-lab"
+  status = "planned"
+}
+
+import {
+  to = netbox_site.site_1st_floor_lab
+  id = "12"
+}
@@ -0,0 +1,24 @@
+# Generated by `terraform-provider-netbox generate`.
+
+resource "netbox_tag" "prod" {
+  name        = "Production"
+  slug        = "prod"
+  color       = "f44336"
+  description = "Production workloads"
+}
+
+import {
+  to = netbox_tag.prod
+  id = "1"
+}
+
+resource "netbox_tag" "core" {
+  name  = "Core"
+  slug  = "core"
+  color = "2196f3"
+}
+
+import {
+  to = netbox_tag.core
+  id = "2"
+}
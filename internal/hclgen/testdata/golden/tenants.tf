@@ -0,0 +1,13 @@
+# Generated by `terraform-provider-netbox generate`.
+
+resource "netbox_tenant" "acme" {
+  name  = "Acme"
+  slug  = "acme"
+  group = "9"
+  tags  = [netbox_tag.prod.slug]
+}
+
+import {
+  to = netbox_tenant.acme
+  id = "4"
+}
@@ -0,0 +1,103 @@
+package hclgen
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// objectType describes a NetBox object type that can be exported as HCL.
+type objectType struct {
+	// name is the name used on the command line (e.g. "sites").
+	name string
+	// resource is the Terraform resource type (e.g. "netbox_site").
+	resource string
+	// apiPath is the list endpoint relative to /api/ (e.g. "dcim/sites").
+	apiPath string
+	// contentType is the NetBox content type (e.g. "dcim.site"), used to
+	// resolve generic object references such as assigned_object_type/_id.
+	contentType string
+	// labelFields are dotted JSON paths joined to build the resource label.
+	labelFields []string
+}
+
+// objectTypes lists the exportable object types. The order is the order in
+// which files are generated, roughly following dependencies.
+var objectTypes = []objectType{
+	{name: "tags", resource: "netbox_tag", apiPath: "extras/tags", contentType: "extras.tag", labelFields: []string{"slug"}},
+	{name: "tenant_groups", resource: "netbox_tenant_group", apiPath: "tenancy/tenant-groups", contentType: "tenancy.tenantgroup", labelFields: []string{"slug"}},
+	{name: "tenants", resource: "netbox_tenant", apiPath: "tenancy/tenants", contentType: "tenancy.tenant", labelFields: []string{"slug"}},
+	{name: "regions", resource: "netbox_region", apiPath: "dcim/regions", contentType: "dcim.region", labelFields: []string{"slug"}},
+	{name: "site_groups", resource: "netbox_site_group", apiPath: "dcim/site-groups", contentType: "dcim.sitegroup", labelFields: []string{"slug"}},
+	{name: "sites", resource: "netbox_site", apiPath: "dcim/sites", contentType: "dcim.site", labelFields: []string{"slug"}},
+	{name: "locations", resource: "netbox_location", apiPath: "dcim/locations", contentType: "dcim.location", labelFields: []string{"site.slug", "slug"}},
+	{name: "rack_roles", resource: "netbox_rack_role", apiPath: "dcim/rack-roles", contentType: "dcim.rackrole", labelFields: []string{"slug"}},
+	{name: "racks", resource: "netbox_rack", apiPath: "dcim/racks", contentType: "dcim.rack", labelFields: []string{"site.slug", "name"}},
+	{name: "manufacturers", resource: "netbox_manufacturer", apiPath: "dcim/manufacturers", contentType: "dcim.manufacturer", labelFields: []string{"slug"}},
+	{name: "platforms", resource: "netbox_platform", apiPath: "dcim/platforms", contentType: "dcim.platform", labelFields: []string{"slug"}},
+	{name: "device_roles", resource: "netbox_device_role", apiPath: "dcim/device-roles", contentType: "dcim.devicerole", labelFields: []string{"slug"}},
+	{name: "device_types", resource: "netbox_device_type", apiPath: "dcim/device-types", contentType: "dcim.devicetype", labelFields: []string{"slug"}},
+	{name: "devices", resource: "netbox_device", apiPath: "dcim/devices", contentType: "dcim.device", labelFields: []string{"name"}},
+	{name: "interfaces", resource: "netbox_interface", apiPath: "dcim/interfaces", contentType: "dcim.interface", labelFields: []string{"device.name", "name"}},
+	{name: "rirs", resource: "netbox_rir", apiPath: "ipam/rirs", contentType: "ipam.rir", labelFields: []string{"slug"}},
+	{name: "aggregates", resource: "netbox_aggregate", apiPath: "ipam/aggregates", contentType: "ipam.aggregate", labelFields: []string{"prefix"}},
+	{name: "vrfs", resource: "netbox_vrf", apiPath: "ipam/vrfs", contentType: "ipam.vrf", labelFields: []string{"name"}},
+	{name: "vlan_groups", resource: "netbox_vlan_group", apiPath: "ipam/vlan-groups", contentType: "ipam.vlangroup", labelFields: []string{"slug"}},
+	{name: "vlans", resource: "netbox_vlan", apiPath: "ipam/vlans", contentType: "ipam.vlan", labelFields: []string{"name"}},
+	{name: "prefixes", resource: "netbox_prefix", apiPath: "ipam/prefixes", contentType: "ipam.prefix", labelFields: []string{"prefix"}},
+	{name: "ip_addresses", resource: "netbox_ip_address", apiPath: "ipam/ip-addresses", contentType: "ipam.ipaddress", labelFields: []string{"address"}},
+	{name: "providers", resource: "netbox_provider", apiPath: "circuits/providers", contentType: "circuits.provider", labelFields: []string{"slug"}},
+	{name: "circuit_types", resource: "netbox_circuit_type", apiPath: "circuits/circuit-types", contentType: "circuits.circuittype", labelFields: []string{"slug"}},
+	{name: "circuits", resource: "netbox_circuit", apiPath: "circuits/circuits", contentType: "circuits.circuit", labelFields: []string{"cid"}},
+	{name: "cluster_types", resource: "netbox_cluster_type", apiPath: "virtualization/cluster-types", contentType: "virtualization.clustertype", labelFields: []string{"slug"}},
+	{name: "cluster_groups", resource: "netbox_cluster_group", apiPath: "virtualization/cluster-groups", contentType: "virtualization.clustergroup", labelFields: []string{"slug"}},
+	{name: "clusters", resource: "netbox_cluster", apiPath: "virtualization/clusters", contentType: "virtualization.cluster", labelFields: []string{"name"}},
+	{name: "virtual_machines", resource: "netbox_virtual_machine", apiPath: "virtualization/virtual-machines", contentType: "virtualization.virtualmachine", labelFields: []string{"name"}},
+	{name: "vm_interfaces", resource: "netbox_vm_interface", apiPath: "virtualization/interfaces", contentType: "virtualization.vminterface", labelFields: []string{"virtual_machine.name", "name"}},
+}
+
+// lookupObjectTypes resolves type names from the command line. The special
+// name "all" selects every supported type. The result follows objectTypes order.
+func lookupObjectTypes(names []string) ([]objectType, error) {
+	selected := make(map[string]bool, len(names))
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if name == "all" {
+			return objectTypes, nil
+		}
+		if _, ok := findObjectType(name); !ok {
+			return nil, fmt.Errorf("unknown object type %q; supported types: %s", name, strings.Join(supportedTypeNames(), ", "))
+		}
+		selected[name] = true
+	}
+
+	result := make([]objectType, 0, len(selected))
+	for _, t := range objectTypes {
+		if selected[t.name] {
+			result = append(result, t)
+		}
+	}
+	return result, nil
+}
+
+func findObjectType(name string) (objectType, bool) {
+	for _, t := range objectTypes {
+		if t.name == name {
+			return t, true
+		}
+	}
+	return objectType{}, false
+}
+
+// supportedTypeNames returns the sorted names of all exportable types.
+func supportedTypeNames() []string {
+	names := make([]string, 0, len(objectTypes))
+	for _, t := range objectTypes {
+		names = append(names, t.name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,81 @@
+package netboxlookup
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/bab3l/go-netbox"
+	"github.com/bab3l/terraform-provider-netbox/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// CableTerminationRef identifies a cable termination by name instead of ID:
+// either a component of a device, or one side of a circuit.
+type CableTerminationRef struct {
+	// ObjectType is the content type of the termination, e.g. `dcim.interface`.
+	ObjectType string
+	// Device is the ID or name of the device owning the component.
+	Device string
+	// Name is the name of the component on Device.
+	Name string
+	// Circuit is the ID or CID of the circuit.
+	Circuit string
+	// TermSide is the circuit termination side, `A` or `Z`.
+	TermSide string
+}
+
+// LookupCableTerminationID resolves a cable termination reference to the ID of
+// the termination object.
+func LookupCableTerminationID(ctx context.Context, client *netbox.APIClient, ref CableTerminationRef) (int64, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	endpoint, ok := utils.LookupCableTerminationEndpoint(ref.ObjectType)
+	if !ok {
+		diags.AddError("Unsupported termination type", fmt.Sprintf("Cable terminations of type %q cannot be looked up by name.", ref.ObjectType))
+		return 0, diags
+	}
+
+	if endpoint.DeviceComponent {
+		id := utils.FindDeviceComponentID(ctx, client, endpoint, ref.Device, ref.Name, &diags)
+		return id, diags
+	}
+
+	circuitID, lookupDiags := LookupReferenceID(ctx, client, "circuit", ref.Circuit)
+	diags.Append(lookupDiags...)
+	if diags.HasError() {
+		return 0, diags
+	}
+	query := url.Values{
+		"circuit_id": {strconv.Itoa(int(circuitID))},
+		"term_side":  {ref.TermSide},
+	}
+	results, httpResp, err := utils.ListRawAPIObjects(ctx, client, endpoint.APIPath, query)
+	if err != nil {
+		diags.AddError(
+			"Circuit termination lookup failed",
+			utils.FormatAPIError(fmt.Sprintf("look up side %s of circuit %q", ref.TermSide, ref.Circuit), err, httpResp),
+		)
+		return 0, diags
+	}
+	result, ok := utils.ExpectSingleResult(
+		results,
+		"Circuit termination lookup failed",
+		fmt.Sprintf("Circuit %q has no %s side termination.", ref.Circuit, ref.TermSide),
+		"Circuit termination lookup failed",
+		fmt.Sprintf("Circuit %q has multiple %s side terminations.", ref.Circuit, ref.TermSide),
+		&diags,
+	)
+	if !ok {
+		return 0, diags
+	}
+	var termination struct {
+		ID int64 `json:"id"`
+	}
+	if err := json.Unmarshal(*result, &termination); err != nil {
+		diags.AddError("Circuit termination lookup failed", fmt.Sprintf("Could not decode circuit termination: %s", err))
+		return 0, diags
+	}
+	return termination.ID, diags
+}
@@ -0,0 +1,96 @@
+package netboxlookup
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/bab3l/go-netbox"
+	"github.com/bab3l/terraform-provider-netbox/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+const componentTemplateSuffix = "template"
+
+// ComponentObjectTypes returns the device component types an inventory item
+// can be assigned to, e.g. `dcim.interface`.
+func ComponentObjectTypes() []string {
+	return utils.CableTerminationObjectTypes(true)
+}
+
+// ComponentTemplateObjectTypes returns the component template types an
+// inventory item template can be assigned to, e.g. `dcim.interfacetemplate`.
+func ComponentTemplateObjectTypes() []string {
+	objectTypes := ComponentObjectTypes()
+	for i, objectType := range objectTypes {
+		objectTypes[i] = objectType + componentTemplateSuffix
+	}
+	return objectTypes
+}
+
+// LookupDeviceComponentID resolves the component of the given type called name
+// on a device, given by ID or name, to its ID.
+func LookupDeviceComponentID(ctx context.Context, client *netbox.APIClient, objectType, device, name string) (int64, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	endpoint, ok := utils.LookupCableTerminationEndpoint(objectType)
+	if !ok || !endpoint.DeviceComponent {
+		diags.AddError("Unsupported component type", fmt.Sprintf("Components of type %q cannot be looked up by name.", objectType))
+		return 0, diags
+	}
+	id := utils.FindDeviceComponentID(ctx, client, endpoint, device, name, &diags)
+	return id, diags
+}
+
+// LookupComponentTemplateID resolves the component template of the given type
+// called name on a device type, given by ID or model, to its ID.
+func LookupComponentTemplateID(ctx context.Context, client *netbox.APIClient, objectType, deviceType, name string) (int64, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	endpoint, ok := utils.LookupCableTerminationEndpoint(strings.TrimSuffix(objectType, componentTemplateSuffix))
+	if !ok || !endpoint.DeviceComponent || !strings.HasSuffix(objectType, componentTemplateSuffix) {
+		diags.AddError("Unsupported component type", fmt.Sprintf("Component templates of type %q cannot be looked up by name.", objectType))
+		return 0, diags
+	}
+
+	deviceTypeID, lookupDiags := LookupReferenceID(ctx, client, "device_type", deviceType)
+	diags.Append(lookupDiags...)
+	if diags.HasError() {
+		return 0, diags
+	}
+
+	// dcim/interfaces becomes dcim/interface-templates.
+	apiPath := strings.TrimSuffix(endpoint.APIPath, "s") + "-templates"
+	query := url.Values{
+		"device_type_id": {strconv.Itoa(int(deviceTypeID))},
+		"name":           {name},
+	}
+	results, httpResp, err := utils.ListRawAPIObjects(ctx, client, apiPath, query)
+	if err != nil {
+		diags.AddError(
+			"Error looking up "+objectType,
+			utils.FormatAPIError(fmt.Sprintf("look up %s %q on device type %q", objectType, name, deviceType), err, httpResp),
+		)
+		return 0, diags
+	}
+	result, ok := utils.ExpectSingleResult(
+		results,
+		"Component template not found",
+		fmt.Sprintf("No %s named %q found on device type %q.", objectType, name, deviceType),
+		"Multiple component templates found",
+		fmt.Sprintf("Multiple %s objects named %q found on device type %q.", objectType, name, deviceType),
+		&diags,
+	)
+	if !ok {
+		return 0, diags
+	}
+	var template struct {
+		ID int64 `json:"id"`
+	}
+	if err := json.Unmarshal(*result, &template); err != nil {
+		diags.AddError("Error looking up "+objectType, fmt.Sprintf("Could not decode %s: %s", objectType, err))
+		return 0, diags
+	}
+	return template.ID, diags
+}
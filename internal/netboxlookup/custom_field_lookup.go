@@ -0,0 +1,205 @@
+package netboxlookup
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bab3l/go-netbox"
+	"github.com/bab3l/terraform-provider-netbox/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// customFieldReferenceTypes maps the related object types of object and
+// multiobject custom fields to the resource types of LookupReferenceID.
+var customFieldReferenceTypes = map[string]string{
+	"circuits.circuit":              "circuit",
+	"circuits.provider":             "provider",
+	"dcim.device":                   "device",
+	"dcim.devicerole":               "device_role",
+	"dcim.devicetype":               "device_type",
+	"dcim.inventoryitemrole":        "inventory_item_role",
+	"dcim.location":                 "location",
+	"dcim.manufacturer":             "manufacturer",
+	"dcim.moduletype":               "module_type",
+	"dcim.platform":                 "platform",
+	"dcim.powerpanel":               "power_panel",
+	"dcim.rack":                     "rack",
+	"dcim.rackrole":                 "rack_role",
+	"dcim.racktype":                 "rack_type",
+	"dcim.region":                   "region",
+	"dcim.site":                     "site",
+	"dcim.sitegroup":                "site_group",
+	"extras.configtemplate":         "config_template",
+	"ipam.rir":                      "rir",
+	"ipam.role":                     "role",
+	"ipam.vlan":                     "vlan",
+	"ipam.vlangroup":                "vlan_group",
+	"ipam.vrf":                      "vrf",
+	"tenancy.contactgroup":          "contact_group",
+	"tenancy.tenant":                "tenant",
+	"tenancy.tenantgroup":           "tenant_group",
+	"users.user":                    "user",
+	"virtualization.cluster":        "cluster",
+	"virtualization.virtualmachine": "virtual_machine",
+	"wireless.wirelesslangroup":     "wireless_lan_group",
+}
+
+// ResolveCustomFieldReferences replaces the names and slugs given for object
+// and multiobject custom fields of request with the IDs of the objects they
+// refer to. References are resolved with LookupReferenceID, using the related
+// object type of each field; objects of other types must be given by ID.
+// Values set from custom_fields arrive as strings, with multiobject references
+// separated by commas.
+func ResolveCustomFieldReferences[T utils.CustomFieldsAccessor](ctx context.Context, client *netbox.APIClient, request T, diags *diag.Diagnostics) {
+	if diags.HasError() {
+		return
+	}
+	customFields := request.GetCustomFields()
+	names := make([]string, 0, len(customFields))
+	for name, value := range customFields {
+		if hasCustomFieldReference(value) {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return
+	}
+	sort.Strings(names)
+
+	started := time.Now()
+	registry := utils.CustomFieldRegistryFor(client)
+	definitions, httpResp, err := registry.Definitions(ctx, time.Time{})
+	if err == nil && !hasAllDefinitions(definitions, names) {
+		// Fields created earlier in the same apply are not cached yet.
+		definitions, httpResp, err = registry.Definitions(ctx, started)
+	}
+	if err != nil {
+		diags.AddError(
+			"Error resolving custom field references",
+			utils.FormatAPIError("list custom field definitions", err, httpResp),
+		)
+		return
+	}
+
+	resolved := make(map[string]interface{}, len(customFields))
+	for name, value := range customFields {
+		resolved[name] = value
+	}
+	for _, name := range names {
+		definition, ok := definitions[name]
+		if !ok {
+			continue
+		}
+		switch definition.Type {
+		case "object":
+			reference, ok := customFields[name].(string)
+			if !ok || strings.TrimSpace(reference) == "" {
+				continue
+			}
+			id, ok := resolveCustomFieldReference(ctx, client, definition, reference, diags)
+			if ok {
+				resolved[name] = id
+			}
+		case "multiobject":
+			references, ok := customFieldReferences(customFields[name])
+			if !ok {
+				continue
+			}
+			ids := make([]int64, 0, len(references))
+			for _, reference := range references {
+				id, ok := resolveCustomFieldReference(ctx, client, definition, reference, diags)
+				if !ok {
+					break
+				}
+				ids = append(ids, id)
+			}
+			resolved[name] = ids
+		}
+	}
+	if diags.HasError() {
+		return
+	}
+	request.SetCustomFields(resolved)
+}
+
+// resolveCustomFieldReference returns the ID of the object an ID, name or slug
+// refers to.
+func resolveCustomFieldReference(ctx context.Context, client *netbox.APIClient, definition utils.CustomFieldDefinition, reference string, diags *diag.Diagnostics) (int64, bool) {
+	reference = strings.TrimSpace(reference)
+	if id, err := strconv.ParseInt(reference, 10, 64); err == nil {
+		return id, true
+	}
+	resourceType, ok := customFieldReferenceTypes[definition.RelatedObjectType]
+	if !ok {
+		diags.AddError(
+			"Invalid custom field reference",
+			fmt.Sprintf("Custom field %q refers to %s objects, which cannot be looked up by name or slug. Use the ID of the object instead of %q.",
+				definition.Name, definition.RelatedObjectType, reference),
+		)
+		return 0, false
+	}
+	id, lookupDiags := LookupReferenceID(ctx, client, resourceType, reference)
+	if lookupDiags.HasError() {
+		diags.AddError(
+			"Invalid custom field reference",
+			fmt.Sprintf("Could not resolve %q for custom field %q: %s", reference, definition.Name, lookupDiags.Errors()[0].Detail()),
+		)
+		return 0, false
+	}
+	return int64(id), true
+}
+
+// hasCustomFieldReference reports whether value may hold references that are
+// not IDs yet.
+func hasCustomFieldReference(value interface{}) bool {
+	switch v := value.(type) {
+	case string:
+		return true
+	case []interface{}:
+		for _, item := range v {
+			if _, ok := item.(string); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// customFieldReferences returns the references of a multiobject value.
+func customFieldReferences(value interface{}) ([]string, bool) {
+	switch v := value.(type) {
+	case string:
+		if strings.TrimSpace(v) == "" {
+			return nil, false
+		}
+		return strings.Split(v, ","), true
+	case []interface{}:
+		references := make([]string, 0, len(v))
+		for _, item := range v {
+			switch reference := item.(type) {
+			case string:
+				references = append(references, reference)
+			case int64:
+				references = append(references, strconv.FormatInt(reference, 10))
+			default:
+				return nil, false
+			}
+		}
+		return references, true
+	default:
+		return nil, false
+	}
+}
+
+func hasAllDefinitions(definitions map[string]utils.CustomFieldDefinition, names []string) bool {
+	for _, name := range names {
+		if _, ok := definitions[name]; !ok {
+			return false
+		}
+	}
+	return true
+}
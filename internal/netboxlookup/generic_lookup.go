@@ -1196,6 +1196,37 @@ func LookupCluster(ctx context.Context, client *netbox.APIClient, value string)
 	return GenericLookup(ctx, value, ClusterLookupConfig(client))
 }
 
+// VirtualChassisLookupConfig returns the lookup configuration for Virtual Chassis.
+func VirtualChassisLookupConfig(client *netbox.APIClient) LookupConfig[*netbox.VirtualChassis, netbox.BriefVirtualChassisRequest] {
+	return LookupConfig[*netbox.VirtualChassis, netbox.BriefVirtualChassisRequest]{
+		ResourceName: "Virtual Chassis",
+		RetrieveByID: func(ctx context.Context, id int32) (*netbox.VirtualChassis, *http.Response, error) {
+			return client.DcimAPI.DcimVirtualChassisRetrieve(ctx, id).Execute()
+		},
+		ListBySlug: func(ctx context.Context, name string) ([]*netbox.VirtualChassis, *http.Response, error) {
+			list, resp, err := client.DcimAPI.DcimVirtualChassisList(ctx).Name([]string{name}).Execute()
+			if err != nil {
+				return nil, resp, err
+			}
+			results := make([]*netbox.VirtualChassis, len(list.Results))
+			for i := range list.Results {
+				results[i] = &list.Results[i]
+			}
+			return results, resp, nil
+		},
+		ToBriefRequest: func(vc *netbox.VirtualChassis) netbox.BriefVirtualChassisRequest {
+			return netbox.BriefVirtualChassisRequest{
+				Name: vc.GetName(),
+			}
+		},
+	}
+}
+
+// LookupVirtualChassis looks up a Virtual Chassis by ID or name.
+func LookupVirtualChassis(ctx context.Context, client *netbox.APIClient, value string) (*netbox.BriefVirtualChassisRequest, diag.Diagnostics) {
+	return GenericLookup(ctx, value, VirtualChassisLookupConfig(client))
+}
+
 // ConfigTemplateLookupConfig returns the lookup configuration for Config Templates.
 func ConfigTemplateLookupConfig(client *netbox.APIClient) LookupConfig[*netbox.ConfigTemplate, netbox.BriefConfigTemplateRequest] {
 	return LookupConfig[*netbox.ConfigTemplate, netbox.BriefConfigTemplateRequest]{
@@ -2,16 +2,23 @@ package provider
 
 import (
 	"context"
+	"net/http"
+	"net/url"
 	"os"
+	"strings"
 
 	"github.com/bab3l/go-netbox"
 	"github.com/bab3l/terraform-provider-netbox/internal/datasources"
 	"github.com/bab3l/terraform-provider-netbox/internal/resources"
+	"github.com/bab3l/terraform-provider-netbox/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -32,6 +39,20 @@ type NetboxProviderModel struct {
 	ServerURL types.String `tfsdk:"server_url"`
 	APIToken  types.String `tfsdk:"api_token"`
 	Insecure  types.Bool   `tfsdk:"insecure"`
+
+	DefaultTags         types.Set `tfsdk:"default_tags"`
+	DefaultCustomFields types.Map `tfsdk:"default_custom_fields"`
+	IgnoreTags          types.Set `tfsdk:"ignore_tags"`
+	IgnoreCustomFields  types.Set `tfsdk:"ignore_custom_fields"`
+
+	JournalChanges  types.Bool   `tfsdk:"journal_changes"`
+	JournalKind     types.String `tfsdk:"journal_kind"`
+	JournalTemplate types.String `tfsdk:"journal_template"`
+
+	RequestID types.String `tfsdk:"request_id"`
+	Branch    types.String `tfsdk:"branch"`
+
+	ReadOnly types.Bool `tfsdk:"read_only"`
 }
 
 func (p *NetboxProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -56,6 +77,53 @@ func (p *NetboxProvider) Schema(ctx context.Context, req provider.SchemaRequest,
 				MarkdownDescription: "Whether to skip TLS certificate verification. Defaults to false. Can also be set via the `NETBOX_INSECURE` environment variable.",
 				Optional:            true,
 			},
+			"default_tags": schema.SetAttribute{
+				MarkdownDescription: "Slugs of tags added to every object that supports tags. The tags must already exist in Netbox. Default tags are not shown in the `tags` attribute of resources unless configured there; `tags_all` shows all tags of an object.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"default_custom_fields": schema.MapAttribute{
+				MarkdownDescription: "Custom field values set on every object whose type the custom field is assigned to, keyed by custom field name and written as in the `custom_fields` attribute of resources. A value configured on a resource takes precedence; `custom_fields_all` shows all custom field values of an object.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"ignore_tags": schema.SetAttribute{
+				MarkdownDescription: "Slugs of tags managed outside Terraform. Resources never read these tags into `tags` and never remove them from an object on update; `tags_all` lists them. An entry ending in `*` matches every slug starting with the text before it, e.g. `discovered-*`.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"ignore_custom_fields": schema.SetAttribute{
+				MarkdownDescription: "Names of custom fields managed outside Terraform. Resources never read these custom fields into state and never change or clear them. An entry ending in `*` matches every name starting with the text before it.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"request_id": schema.StringAttribute{
+				MarkdownDescription: "ID sent in the `X-Request-ID` header of every request, e.g. the ID of a CI pipeline run. Can also be set via the `NETBOX_REQUEST_ID` environment variable. Defaults to a random ID shared by all requests of a Terraform run. Netbox stores the ID it assigns to each request in the change log; the `current_run` filter of `netbox_object_changes` finds the changes made by the provider with them.",
+				Optional:            true,
+			},
+			"branch": schema.StringAttribute{
+				MarkdownDescription: "Schema ID of the branch of the netbox-branching plugin in which to make all changes, sent in the `X-NetBox-Branch` header, e.g. to stage an apply for review before merging. Can also be set via the `NETBOX_BRANCH` environment variable. Resources can override it with their `branch` attribute, e.g. set to the `schema_id` of a `netbox_branch` created in the same configuration. Defaults to main.",
+				Optional:            true,
+			},
+			"read_only": schema.BoolAttribute{
+				MarkdownDescription: "Whether to only read from Netbox, e.g. to run `terraform plan` in an audit pipeline with a token that may have write access. Resources fail to create, update or delete objects before sending any request, and the provider sends no request other than `GET`, `HEAD`, `OPTIONS` and queries to the NetBox GraphQL API at `/graphql/` (GraphQL endpoints of plugins are rejected). Can also be set via the `NETBOX_READ_ONLY` environment variable. Defaults to false.",
+				Optional:            true,
+			},
+			"journal_changes": schema.BoolAttribute{
+				MarkdownDescription: "Whether to post a journal entry to every object that a resource creates or updates, recording the Terraform workspace and run and the changed attributes. Objects that do not support journaling, such as contact assignments, are skipped, and deleted objects are not journaled since Netbox deletes the journal of an object with it. Failures to post a journal entry are reported as warnings. Defaults to false.",
+				Optional:            true,
+			},
+			"journal_kind": schema.StringAttribute{
+				MarkdownDescription: "Kind of the journal entries posted when `journal_changes` is enabled: `info`, `success`, `warning` or `danger`. Defaults to `info`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("info", "success", "warning", "danger"),
+				},
+			},
+			"journal_template": schema.StringAttribute{
+				MarkdownDescription: "Go template of the comments of the journal entries posted when `journal_changes` is enabled. It can use `.Action` (`created` or `updated`), `.ObjectType`, `.ObjectID`, `.Workspace` (from `TF_WORKSPACE` or `TFC_WORKSPACE_NAME`), `.RunID` (from `TFC_RUN_ID`), `.Changes` (names of the changed attributes) and the `join` function. Defaults to `" + strings.ReplaceAll(utils.DefaultJournalTemplate, "`", "") + "`.",
+				Optional:            true,
+			},
 		},
 	}
 }
@@ -101,13 +169,52 @@ func (p *NetboxProvider) Configure(ctx context.Context, req provider.ConfigureRe
 				"If either is already set, ensure the value is not empty.",
 		)
 	}
+	parsedServerURL, err := url.Parse(serverURL)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("server_url"),
+			"Invalid Netbox Server URL",
+			"The Netbox server URL could not be parsed: "+err.Error(),
+		)
+	}
+	settings := providerSettings(ctx, data, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	settings.RequestID = os.Getenv("NETBOX_REQUEST_ID")
+	if !data.RequestID.IsNull() {
+		settings.RequestID = data.RequestID.ValueString()
+	}
+	if settings.RequestID == "" {
+		settings.RequestID = utils.GeneratedRequestID()
+	}
+	settings.WriteRequests = utils.NewWriteRequestLog()
+
+	if data.Branch.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("branch"),
+			"Unknown Branch",
+			"The provider cannot be configured with a branch that is not known until apply. "+
+				"Set the branch attribute of the resources instead, e.g. to the schema_id of a netbox_branch resource.",
+		)
+		return
+	}
+	settings.Branch = os.Getenv("NETBOX_BRANCH")
+	if !data.Branch.IsNull() {
+		settings.Branch = data.Branch.ValueString()
+	}
+	// Until its value is known, the provider is read-only.
+	settings.ReadOnly = os.Getenv("NETBOX_READ_ONLY") == "true"
+	if !data.ReadOnly.IsNull() {
+		settings.ReadOnly = data.ReadOnly.IsUnknown() || data.ReadOnly.ValueBool()
+	}
 
 	ctx = tflog.SetField(ctx, "netbox_server_url", serverURL)
 	ctx = tflog.SetField(ctx, "netbox_api_token", apiToken)
 	ctx = tflog.SetField(ctx, "netbox_insecure", insecure)
+	ctx = tflog.SetField(ctx, "netbox_request_id", settings.RequestID)
+	ctx = tflog.SetField(ctx, "netbox_branch", settings.Branch)
+	ctx = tflog.SetField(ctx, "netbox_read_only", settings.ReadOnly)
 	ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, "netbox_api_token")
 	tflog.Debug(ctx, "Creating Netbox client")
 
@@ -132,7 +239,13 @@ func (p *NetboxProvider) Configure(ctx context.Context, req provider.ConfigureRe
 
 	}
 
+	// Settings that concern every request are applied by the transport.
+	cfg.HTTPClient = &http.Client{
+		Transport: &utils.ProviderTransport{Base: http.DefaultTransport, BasePath: parsedServerURL.Path, Settings: settings},
+	}
+
 	client := netbox.NewAPIClient(cfg)
+	utils.SetProviderSettings(client, settings)
 	// Make the Netbox client available during DataSource and Resource
 	// type Configure methods.
 	resp.DataSourceData = client
@@ -140,6 +253,101 @@ func (p *NetboxProvider) Configure(ctx context.Context, req provider.ConfigureRe
 	tflog.Info(ctx, "Configured Netbox client", map[string]any{"success": true})
 }
 
+// providerSettings returns the settings of data that apply to every object
+// managed by the provider.
+func providerSettings(ctx context.Context, data NetboxProviderModel, diags *diag.Diagnostics) utils.ProviderSettings {
+	var settings utils.ProviderSettings
+	if data.DefaultTags.IsUnknown() {
+		diags.AddAttributeError(
+			path.Root("default_tags"),
+			"Unknown Default Tags",
+			"The provider cannot be configured with default tags that are not known until apply. Use values known at plan time.",
+		)
+	} else if !data.DefaultTags.IsNull() {
+		diags.Append(data.DefaultTags.ElementsAs(ctx, &settings.DefaultTags, false)...)
+	}
+	if data.DefaultCustomFields.IsUnknown() {
+		diags.AddAttributeError(
+			path.Root("default_custom_fields"),
+			"Unknown Default Custom Fields",
+			"The provider cannot be configured with default custom fields that are not known until apply. Use values known at plan time.",
+		)
+	} else if !data.DefaultCustomFields.IsNull() {
+		diags.Append(data.DefaultCustomFields.ElementsAs(ctx, &settings.DefaultCustomFields, false)...)
+	}
+	if data.IgnoreTags.IsUnknown() {
+		diags.AddAttributeError(
+			path.Root("ignore_tags"),
+			"Unknown Ignore Tags",
+			"The provider cannot be configured with ignored tags that are not known until apply. Use values known at plan time.",
+		)
+	} else if !data.IgnoreTags.IsNull() {
+		diags.Append(data.IgnoreTags.ElementsAs(ctx, &settings.IgnoreTags, false)...)
+	}
+	if data.IgnoreCustomFields.IsUnknown() {
+		diags.AddAttributeError(
+			path.Root("ignore_custom_fields"),
+			"Unknown Ignore Custom Fields",
+			"The provider cannot be configured with ignored custom fields that are not known until apply. Use values known at plan time.",
+		)
+	} else if !data.IgnoreCustomFields.IsNull() {
+		diags.Append(data.IgnoreCustomFields.ElementsAs(ctx, &settings.IgnoreCustomFields, false)...)
+	}
+
+	if data.JournalChanges.IsUnknown() || data.JournalKind.IsUnknown() || data.JournalTemplate.IsUnknown() {
+		diags.AddError(
+			"Unknown Journal Settings",
+			"The provider cannot be configured with journal settings that are not known until apply. Use values known at plan time.",
+		)
+	} else if data.JournalChanges.ValueBool() {
+		settings.Journal = journalSettings(data, diags)
+	}
+
+	for _, slug := range settings.DefaultTags {
+		if settings.IgnoresTag(slug) {
+			diags.AddAttributeError(
+				path.Root("default_tags"),
+				"Ignored Default Tag",
+				"The default tag \""+slug+"\" matches ignore_tags. A tag cannot be both added by the provider and managed outside Terraform.",
+			)
+		}
+	}
+	for name := range settings.DefaultCustomFields {
+		if settings.IgnoresCustomField(name) {
+			diags.AddAttributeError(
+				path.Root("default_custom_fields").AtMapKey(name),
+				"Ignored Default Custom Field",
+				"The default custom field \""+name+"\" matches ignore_custom_fields. A custom field cannot be both set by the provider and managed outside Terraform.",
+			)
+		}
+	}
+	return settings
+}
+
+// journalSettings returns the settings of the journal entries posted to
+// changed objects.
+func journalSettings(data NetboxProviderModel, diags *diag.Diagnostics) *utils.JournalSettings {
+	journal := &utils.JournalSettings{Kind: "info"}
+	if !data.JournalKind.IsNull() {
+		journal.Kind = data.JournalKind.ValueString()
+	}
+	text := utils.DefaultJournalTemplate
+	if !data.JournalTemplate.IsNull() {
+		text = data.JournalTemplate.ValueString()
+	}
+	tmpl, err := utils.ParseJournalTemplate(text)
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("journal_template"),
+			"Invalid Journal Template",
+			"The journal template could not be parsed: "+err.Error(),
+		)
+		return nil
+	}
+	journal.Template = tmpl
+	return journal
+}
+
 func (p *NetboxProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		resources.NewSiteResource,
@@ -158,6 +366,7 @@ func (p *NetboxProvider) Resources(ctx context.Context) []func() resource.Resour
 		resources.NewDeviceResource,
 		resources.NewDevicePrimaryIPResource,
 		resources.NewInterfaceResource,
+		resources.NewDeviceInterfacesResource,
 		resources.NewVRFResource,
 		resources.NewVLANGroupResource,
 		resources.NewVLANResource,
@@ -246,6 +455,10 @@ func (p *NetboxProvider) Resources(ctx context.Context) []func() resource.Resour
 		resources.NewServiceTemplateResource,
 		resources.NewFHRPGroupAssignmentResource,
 		resources.NewExportTemplateResource,
+		resources.NewObjectResource,
+		resources.NewBranchResource,
+		resources.NewDeviceTypeFromLibraryResource,
+		resources.NewModuleTypeFromLibraryResource,
 	}
 }
 
@@ -346,13 +559,21 @@ func (p *NetboxProvider) DataSources(ctx context.Context) []func() datasource.Da
 		datasources.NewFHRPGroupDataSource,
 		datasources.NewJournalEntryDataSource,
 		datasources.NewCustomFieldChoiceSetDataSource,
+		datasources.NewCustomFieldChoicesDataSource,
+		datasources.NewObjectChangesDataSource,
+		datasources.NewBranchDataSource,
 		datasources.NewCustomLinkDataSource,
 		datasources.NewEventRuleDataSource,
 		datasources.NewNotificationGroupDataSource,
 		datasources.NewRackReservationDataSource,
+		datasources.NewRackElevationDataSource,
+		datasources.NewRackFreeUnitsDataSource,
 		datasources.NewVirtualDeviceContextDataSource,
 		datasources.NewModuleBayTemplateDataSource,
 		datasources.NewCableTerminationDataSource,
+		datasources.NewCableTraceDataSource,
+		datasources.NewPowerFeedUtilizationDataSource,
+		datasources.NewPowerConnectionsDataSource,
 		datasources.NewInventoryItemTemplateDataSource,
 		datasources.NewUserDataSource,
 		datasources.NewContactAssignmentDataSource,
@@ -360,6 +581,107 @@ func (p *NetboxProvider) DataSources(ctx context.Context) []func() datasource.Da
 		datasources.NewFHRPGroupAssignmentDataSource,
 		datasources.NewExportTemplateDataSource,
 		datasources.NewScriptDataSource,
+		datasources.NewObjectsDataSource,
+		datasources.NewGraphQLDataSource,
+		datasources.NewAggregatesDataSource,
+		datasources.NewASNsDataSource,
+		datasources.NewASNRangesDataSource,
+		datasources.NewCablesDataSource,
+		datasources.NewCableTerminationsDataSource,
+		datasources.NewCircuitTypesDataSource,
+		datasources.NewCircuitsDataSource,
+		datasources.NewCircuitGroupAssignmentsDataSource,
+		datasources.NewCircuitGroupsDataSource,
+		datasources.NewCircuitTerminationsDataSource,
+		datasources.NewClusterGroupsDataSource,
+		datasources.NewClusterTypesDataSource,
+		datasources.NewClustersDataSource,
+		datasources.NewConfigContextsDataSource,
+		datasources.NewConfigTemplatesDataSource,
+		datasources.NewConsolePortsDataSource,
+		datasources.NewConsolePortTemplatesDataSource,
+		datasources.NewConsoleServerPortsDataSource,
+		datasources.NewConsoleServerPortTemplatesDataSource,
+		datasources.NewContactAssignmentsDataSource,
+		datasources.NewContactGroupsDataSource,
+		datasources.NewContactRolesDataSource,
+		datasources.NewContactsDataSource,
+		datasources.NewCustomFieldChoiceSetsDataSource,
+		datasources.NewCustomFieldsDataSource,
+		datasources.NewCustomLinksDataSource,
+		datasources.NewDeviceRolesDataSource,
+		datasources.NewDeviceTypesDataSource,
+		datasources.NewDeviceBaysDataSource,
+		datasources.NewDeviceBayTemplatesDataSource,
+		datasources.NewEventRulesDataSource,
+		datasources.NewExportTemplatesDataSource,
+		datasources.NewFHRPGroupAssignmentsDataSource,
+		datasources.NewFHRPGroupsDataSource,
+		datasources.NewFrontPortsDataSource,
+		datasources.NewFrontPortTemplatesDataSource,
+		datasources.NewIKEPoliciesDataSource,
+		datasources.NewIKEProposalsDataSource,
+		datasources.NewInterfaceTemplatesDataSource,
+		datasources.NewInventoryItemRolesDataSource,
+		datasources.NewInventoryItemTemplatesDataSource,
+		datasources.NewInventoryItemsDataSource,
+		datasources.NewIPRangesDataSource,
+		datasources.NewIPSecPoliciesDataSource,
+		datasources.NewIPSecProfilesDataSource,
+		datasources.NewIPSecProposalsDataSource,
+		datasources.NewJournalEntriesDataSource,
+		datasources.NewL2VPNTerminationsDataSource,
+		datasources.NewL2VPNsDataSource,
+		datasources.NewLocationsDataSource,
+		datasources.NewManufacturersDataSource,
+		datasources.NewModuleBaysDataSource,
+		datasources.NewModuleBayTemplatesDataSource,
+		datasources.NewModulesDataSource,
+		datasources.NewModuleTypesDataSource,
+		datasources.NewNotificationGroupsDataSource,
+		datasources.NewPlatformsDataSource,
+		datasources.NewPowerFeedsDataSource,
+		datasources.NewPowerOutletsDataSource,
+		datasources.NewPowerOutletTemplatesDataSource,
+		datasources.NewPowerPanelsDataSource,
+		datasources.NewPowerPortsDataSource,
+		datasources.NewPowerPortTemplatesDataSource,
+		datasources.NewProvidersDataSource,
+		datasources.NewProviderAccountsDataSource,
+		datasources.NewProviderNetworksDataSource,
+		datasources.NewRackRolesDataSource,
+		datasources.NewRacksDataSource,
+		datasources.NewRackReservationsDataSource,
+		datasources.NewRackTypesDataSource,
+		datasources.NewRearPortsDataSource,
+		datasources.NewRearPortTemplatesDataSource,
+		datasources.NewRegionsDataSource,
+		datasources.NewRIRsDataSource,
+		datasources.NewRolesDataSource,
+		datasources.NewRouteTargetsDataSource,
+		datasources.NewScriptsDataSource,
+		datasources.NewServicesDataSource,
+		datasources.NewServiceTemplatesDataSource,
+		datasources.NewSitesDataSource,
+		datasources.NewSiteGroupsDataSource,
+		datasources.NewTagsDataSource,
+		datasources.NewTenantsDataSource,
+		datasources.NewTenantGroupsDataSource,
+		datasources.NewTunnelGroupsDataSource,
+		datasources.NewTunnelTerminationsDataSource,
+		datasources.NewTunnelsDataSource,
+		datasources.NewUsersDataSource,
+		datasources.NewVirtualChassisListDataSource,
+		datasources.NewVirtualDeviceContextsDataSource,
+		datasources.NewVirtualDisksDataSource,
+		datasources.NewVLANsDataSource,
+		datasources.NewVLANGroupsDataSource,
+		datasources.NewVMInterfacesDataSource,
+		datasources.NewVRFsDataSource,
+		datasources.NewWebhooksDataSource,
+		datasources.NewWirelessLANGroupsDataSource,
+		datasources.NewWirelessLANsDataSource,
+		datasources.NewWirelessLinksDataSource,
 	}
 }
 
@@ -4,7 +4,11 @@ import (
 	"context"
 	"testing"
 
+	"github.com/bab3l/terraform-provider-netbox/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // TestAccProtoV6ProviderFactories are used to instantiate a provider during
@@ -51,6 +55,23 @@ func TestProviderSchema(t *testing.T) {
 	if _, ok := attrs["insecure"]; !ok {
 		t.Error("Provider schema should include insecure attribute")
 	}
+	if _, ok := attrs["default_tags"]; !ok {
+		t.Error("Provider schema should include default_tags attribute")
+	}
+	if _, ok := attrs["default_custom_fields"]; !ok {
+		t.Error("Provider schema should include default_custom_fields attribute")
+	}
+	if _, ok := attrs["ignore_tags"]; !ok {
+		t.Error("Provider schema should include ignore_tags attribute")
+	}
+	if _, ok := attrs["ignore_custom_fields"]; !ok {
+		t.Error("Provider schema should include ignore_custom_fields attribute")
+	}
+	for _, name := range []string{"journal_changes", "journal_kind", "journal_template", "request_id", "branch", "read_only"} {
+		if _, ok := attrs[name]; !ok {
+			t.Errorf("Provider schema should include %s attribute", name)
+		}
+	}
 }
 
 func TestProviderResources(t *testing.T) {
@@ -90,3 +111,105 @@ func TestProviderDataSources(t *testing.T) {
 	// This is expected to be empty currently, but the call shouldn't panic
 	_ = dataSources
 }
+
+func TestProviderResourcesCustomFieldsMap(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	p := New("test")()
+
+	// Every resource that manages custom_fields also offers the typed custom_fields_map
+	// and validates both against the custom field definitions at plan time.
+	for _, resourceFunc := range p.Resources(ctx) {
+		r := resourceFunc()
+		metaResp := &resource.MetadataResponse{}
+		r.Metadata(ctx, resource.MetadataRequest{ProviderTypeName: "netbox"}, metaResp)
+		schemaResp := &resource.SchemaResponse{}
+		r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+		// Circuit group assignments expose custom_fields but NetBox does not store them.
+		if _, ok := schemaResp.Schema.Attributes["custom_fields"]; !ok || metaResp.TypeName == "netbox_circuit_group_assignment" {
+			continue
+		}
+		if _, ok := schemaResp.Schema.Attributes["custom_fields_map"]; !ok {
+			t.Errorf("%s has custom_fields but no custom_fields_map", metaResp.TypeName)
+		}
+		if _, ok := r.(resource.ResourceWithModifyPlan); !ok {
+			t.Errorf("%s has custom_fields but does not validate them at plan time", metaResp.TypeName)
+		}
+		if _, ok := schemaResp.Schema.Attributes["custom_fields_all"]; !ok {
+			t.Errorf("%s has custom_fields but no custom_fields_all", metaResp.TypeName)
+		}
+	}
+}
+
+func TestProviderResourcesTagsAll(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	p := New("test")()
+
+	// Every resource that manages tags by slug plans tags_all, so the
+	// provider's default_tags show in plans.
+	for _, resourceFunc := range p.Resources(ctx) {
+		r := resourceFunc()
+		metaResp := &resource.MetadataResponse{}
+		r.Metadata(ctx, resource.MetadataRequest{ProviderTypeName: "netbox"}, metaResp)
+		schemaResp := &resource.SchemaResponse{}
+		r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+		// Circuit group assignments take nested tag objects and config contexts
+		// send tag slugs as plain strings; neither applies default tags.
+		switch metaResp.TypeName {
+		case "netbox_circuit_group_assignment", "netbox_config_context":
+			continue
+		}
+		if _, ok := schemaResp.Schema.Attributes["tags"]; !ok {
+			continue
+		}
+		if _, ok := schemaResp.Schema.Attributes["tags_all"]; !ok {
+			t.Errorf("%s has tags but no tags_all", metaResp.TypeName)
+		}
+		if _, ok := r.(resource.ResourceWithModifyPlan); !ok {
+			t.Errorf("%s has tags but does not plan tags_all", metaResp.TypeName)
+		}
+	}
+}
+
+func TestJournalSettings(t *testing.T) {
+	t.Parallel()
+
+	data := NetboxProviderModel{
+		JournalChanges:  types.BoolValue(true),
+		JournalKind:     types.StringNull(),
+		JournalTemplate: types.StringNull(),
+	}
+	var diags diag.Diagnostics
+	journal := journalSettings(data, &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected errors: %v", diags.Errors())
+	}
+	if journal.Kind != "info" {
+		t.Errorf("journal kind should default to info, got %q", journal.Kind)
+	}
+	comments, err := journal.Comments(utils.JournalChange{Action: "updated", Workspace: "prod", RunID: "run-1", Changes: []string{"description", "status"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "Terraform updated this object in workspace prod (run run-1). Changed: description, status."; comments != want {
+		t.Errorf("got comments %q, want %q", comments, want)
+	}
+
+	data.JournalKind = types.StringValue("warning")
+	data.JournalTemplate = types.StringValue("{{.Action}} {{.ObjectType}} {{.ObjectID}}")
+	journal = journalSettings(data, &diags)
+	comments, _ = journal.Comments(utils.JournalChange{Action: "created", ObjectType: "dcim.site", ObjectID: 4})
+	if journal.Kind != "warning" || comments != "created dcim.site 4" {
+		t.Errorf("got kind %q and comments %q", journal.Kind, comments)
+	}
+
+	data.JournalTemplate = types.StringValue("{{.Action")
+	if journalSettings(data, &diags) != nil || !diags.HasError() {
+		t.Error("an invalid journal template should be rejected")
+	}
+}
@@ -29,6 +29,7 @@ var (
 	_ resource.ResourceWithConfigure   = &AggregateResource{}
 	_ resource.ResourceWithImportState = &AggregateResource{}
 	_ resource.ResourceWithIdentity    = &AggregateResource{}
+	_ resource.ResourceWithModifyPlan  = &AggregateResource{}
 )
 
 // NewAggregateResource returns a new Aggregate resource.
@@ -43,15 +44,19 @@ type AggregateResource struct {
 
 // AggregateResourceModel describes the resource data model.
 type AggregateResourceModel struct {
-	ID           types.String `tfsdk:"id"`
-	Prefix       types.String `tfsdk:"prefix"`
-	RIR          types.String `tfsdk:"rir"`
-	Tenant       types.String `tfsdk:"tenant"`
-	DateAdded    types.String `tfsdk:"date_added"`
-	Description  types.String `tfsdk:"description"`
-	Comments     types.String `tfsdk:"comments"`
-	Tags         types.Set    `tfsdk:"tags"`
-	CustomFields types.Set    `tfsdk:"custom_fields"`
+	ID              types.String  `tfsdk:"id"`
+	Prefix          types.String  `tfsdk:"prefix"`
+	RIR             types.String  `tfsdk:"rir"`
+	Tenant          types.String  `tfsdk:"tenant"`
+	DateAdded       types.String  `tfsdk:"date_added"`
+	Description     types.String  `tfsdk:"description"`
+	Comments        types.String  `tfsdk:"comments"`
+	Tags            types.Set     `tfsdk:"tags"`
+	CustomFields    types.Set     `tfsdk:"custom_fields"`
+	CustomFieldsMap types.Dynamic `tfsdk:"custom_fields_map"`
+	TagsAll         types.Set     `tfsdk:"tags_all"`
+	CustomFieldsAll types.Map     `tfsdk:"custom_fields_all"`
+	Branch          types.String  `tfsdk:"branch"`
 }
 
 // Metadata returns the resource type name.
@@ -88,6 +93,10 @@ func (r *AggregateResource) Schema(ctx context.Context, req resource.SchemaReque
 	// Add metadata attributes (slug list tags, custom_fields)
 	resp.Schema.Attributes["tags"] = nbschema.TagsSlugAttribute()
 	resp.Schema.Attributes["custom_fields"] = nbschema.CustomFieldsAttribute()
+	resp.Schema.Attributes["custom_fields_map"] = nbschema.CustomFieldsMapAttribute()
+	resp.Schema.Attributes["tags_all"] = nbschema.TagsAllAttribute()
+	resp.Schema.Attributes["custom_fields_all"] = nbschema.CustomFieldsAllAttribute()
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 func (r *AggregateResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
@@ -111,8 +120,21 @@ func (r *AggregateResource) Configure(ctx context.Context, req resource.Configur
 	r.client = client
 }
 
+// ModifyPlan validates the configured custom fields against their definitions in NetBox
+// and plans tags_all and custom_fields_all.
+func (r *AggregateResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	utils.ValidateCustomFieldsPlan(ctx, r.client, "ipam.aggregate", req, resp)
+	utils.PlanEffectiveMetadata(ctx, r.client, "ipam.aggregate", req, resp)
+}
+
 // Create creates a new aggregate resource.
 func (r *AggregateResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	defer journalCreate(ctx, r.client, "ipam.aggregate", req, resp)
+
 	var data AggregateResourceModel
 
 	// Read Terraform plan data into the model
@@ -156,6 +178,7 @@ func (r *AggregateResource) Create(ctx context.Context, req resource.CreateReque
 
 // Read reads the aggregate resource.
 func (r *AggregateResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data AggregateResourceModel
 
 	// Read Terraform prior state data into the model
@@ -219,6 +242,13 @@ func (r *AggregateResource) Read(ctx context.Context, req resource.ReadRequest,
 
 // Update updates the aggregate resource.
 func (r *AggregateResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	ctx = withPriorTags(ctx, req.State)
+	defer journalUpdate(ctx, r.client, "ipam.aggregate", req, resp)
+
 	var data AggregateResourceModel
 
 	// Read Terraform plan data into the model
@@ -277,6 +307,10 @@ func (r *AggregateResource) Update(ctx context.Context, req resource.UpdateReque
 
 // Delete deletes the aggregate resource.
 func (r *AggregateResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data AggregateResourceModel
 
 	// Read Terraform prior state data into the model
@@ -362,7 +396,9 @@ func (r *AggregateResource) ImportState(ctx context.Context, req resource.Import
 		} else {
 			data.Tenant = types.StringNull()
 		}
-		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, aggregate.HasTags(), aggregate.GetTags(), data.Tags)
+		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, r.client, aggregate.HasTags(), aggregate.GetTags(), data.Tags)
+		data.TagsAll = utils.TagsAllFromAPI(ctx, aggregate.GetTags())
+		data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, aggregate.GetCustomFields(), &resp.Diagnostics)
 		if parsed.HasCustomFields {
 			if len(parsed.CustomFields) == 0 {
 				data.CustomFields = types.SetValueMust(utils.GetCustomFieldsAttributeType().ElemType, []attr.Value{})
@@ -385,6 +421,7 @@ func (r *AggregateResource) ImportState(ctx context.Context, req resource.Import
 
 		if parsed.HasCustomFields {
 			data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, aggregate.CustomFields, &resp.Diagnostics)
+			data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, aggregate.CustomFields, &resp.Diagnostics)
 		} else {
 			data.CustomFields = types.SetNull(utils.GetCustomFieldsAttributeType().ElemType)
 		}
@@ -467,9 +504,17 @@ func (r *AggregateResource) buildCreateRequest(ctx context.Context, data *Aggreg
 	if state != nil {
 		// Update: use merge-aware helper
 		utils.ApplyCustomFieldsWithMerge(ctx, createReq, data.CustomFields, state.CustomFields, &diags)
+		utils.ApplyCustomFieldsMap(ctx, r.client, createReq, data.CustomFieldsMap, &diags)
+		utils.ApplyProviderTags(ctx, r.client, createReq, &diags)
+		utils.ApplyProviderCustomFields(ctx, r.client, "ipam.aggregate", createReq, &diags)
+		netboxlookup.ResolveCustomFieldReferences(ctx, r.client, createReq, &diags)
 	} else {
 		// Create: apply custom fields directly
 		utils.ApplyCustomFields(ctx, createReq, data.CustomFields, &diags)
+		utils.ApplyCustomFieldsMap(ctx, r.client, createReq, data.CustomFieldsMap, &diags)
+		utils.ApplyProviderTags(ctx, r.client, createReq, &diags)
+		utils.ApplyProviderCustomFields(ctx, r.client, "ipam.aggregate", createReq, &diags)
+		netboxlookup.ResolveCustomFieldReferences(ctx, r.client, createReq, &diags)
 	}
 	if diags.HasError() {
 		return nil, diags
@@ -521,11 +566,14 @@ func (r *AggregateResource) mapResponseToModel(ctx context.Context, aggregate *n
 	}
 
 	// Tags (slug list)
-	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, aggregate.HasTags(), aggregate.GetTags(), data.Tags)
+	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, aggregate.HasTags(), aggregate.GetTags(), data.Tags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, aggregate.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, aggregate.GetCustomFields(), &diags)
 	if diags.HasError() {
 		return
 	}
 
 	// Custom Fields - filter to owned fields only
 	data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, aggregate.CustomFields, &diags)
+	data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, aggregate.CustomFields, &diags)
 }
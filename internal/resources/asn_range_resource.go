@@ -31,6 +31,7 @@ var (
 	_ resource.ResourceWithConfigure   = &ASNRangeResource{}
 	_ resource.ResourceWithImportState = &ASNRangeResource{}
 	_ resource.ResourceWithIdentity    = &ASNRangeResource{}
+	_ resource.ResourceWithModifyPlan  = &ASNRangeResource{}
 )
 
 // NewASNRangeResource returns a new ASNRange resource.
@@ -45,16 +46,20 @@ type ASNRangeResource struct {
 
 // ASNRangeResourceModel describes the resource data model.
 type ASNRangeResourceModel struct {
-	ID           types.String `tfsdk:"id"`
-	Name         types.String `tfsdk:"name"`
-	Slug         types.String `tfsdk:"slug"`
-	RIR          types.String `tfsdk:"rir"`
-	Start        types.String `tfsdk:"start"`
-	End          types.String `tfsdk:"end"`
-	Tenant       types.String `tfsdk:"tenant"`
-	Description  types.String `tfsdk:"description"`
-	Tags         types.Set    `tfsdk:"tags"`
-	CustomFields types.Set    `tfsdk:"custom_fields"`
+	ID              types.String  `tfsdk:"id"`
+	Name            types.String  `tfsdk:"name"`
+	Slug            types.String  `tfsdk:"slug"`
+	RIR             types.String  `tfsdk:"rir"`
+	Start           types.String  `tfsdk:"start"`
+	End             types.String  `tfsdk:"end"`
+	Tenant          types.String  `tfsdk:"tenant"`
+	Description     types.String  `tfsdk:"description"`
+	Tags            types.Set     `tfsdk:"tags"`
+	CustomFields    types.Set     `tfsdk:"custom_fields"`
+	CustomFieldsMap types.Dynamic `tfsdk:"custom_fields_map"`
+	TagsAll         types.Set     `tfsdk:"tags_all"`
+	CustomFieldsAll types.Map     `tfsdk:"custom_fields_all"`
+	Branch          types.String  `tfsdk:"branch"`
 }
 
 // Metadata returns the resource type name.
@@ -101,6 +106,10 @@ func (r *ASNRangeResource) Schema(ctx context.Context, req resource.SchemaReques
 	// Add metadata attributes (slug list tags, custom_fields)
 	resp.Schema.Attributes["tags"] = nbschema.TagsSlugAttribute()
 	resp.Schema.Attributes["custom_fields"] = nbschema.CustomFieldsAttribute()
+	resp.Schema.Attributes["custom_fields_map"] = nbschema.CustomFieldsMapAttribute()
+	resp.Schema.Attributes["tags_all"] = nbschema.TagsAllAttribute()
+	resp.Schema.Attributes["custom_fields_all"] = nbschema.CustomFieldsAllAttribute()
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 func (r *ASNRangeResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
@@ -123,8 +132,21 @@ func (r *ASNRangeResource) Configure(ctx context.Context, req resource.Configure
 	r.client = client
 }
 
+// ModifyPlan validates the configured custom fields against their definitions in NetBox
+// and plans tags_all and custom_fields_all.
+func (r *ASNRangeResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	utils.ValidateCustomFieldsPlan(ctx, r.client, "ipam.asnrange", req, resp)
+	utils.PlanEffectiveMetadata(ctx, r.client, "ipam.asnrange", req, resp)
+}
+
 // Create creates the resource and sets the initial Terraform state.
 func (r *ASNRangeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	defer journalCreate(ctx, r.client, "ipam.asnrange", req, resp)
+
 	var data ASNRangeResourceModel
 
 	// Read Terraform plan data into the model
@@ -203,6 +225,7 @@ func (r *ASNRangeResource) Create(ctx context.Context, req resource.CreateReques
 
 // Read refreshes the Terraform state with the latest data.
 func (r *ASNRangeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data ASNRangeResourceModel
 
 	// Read Terraform prior state data into the model
@@ -264,6 +287,13 @@ func (r *ASNRangeResource) Read(ctx context.Context, req resource.ReadRequest, r
 
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *ASNRangeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	ctx = withPriorTags(ctx, req.State)
+	defer journalUpdate(ctx, r.client, "ipam.asnrange", req, resp)
+
 	var plan ASNRangeResourceModel
 	var state ASNRangeResourceModel
 
@@ -355,6 +385,10 @@ func (r *ASNRangeResource) Update(ctx context.Context, req resource.UpdateReques
 
 // Delete deletes the resource and removes the Terraform state on success.
 func (r *ASNRangeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data ASNRangeResourceModel
 
 	// Read Terraform prior state data into the model
@@ -442,7 +476,9 @@ func (r *ASNRangeResource) ImportState(ctx context.Context, req resource.ImportS
 		} else {
 			data.Tenant = types.StringNull()
 		}
-		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, asnRange.HasTags(), asnRange.GetTags(), data.Tags)
+		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, r.client, asnRange.HasTags(), asnRange.GetTags(), data.Tags)
+		data.TagsAll = utils.TagsAllFromAPI(ctx, asnRange.GetTags())
+		data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, asnRange.GetCustomFields(), &resp.Diagnostics)
 		if parsed.HasCustomFields {
 			if len(parsed.CustomFields) == 0 {
 				data.CustomFields = types.SetValueMust(utils.GetCustomFieldsAttributeType().ElemType, []attr.Value{})
@@ -464,6 +500,7 @@ func (r *ASNRangeResource) ImportState(ctx context.Context, req resource.ImportS
 		}
 		if parsed.HasCustomFields {
 			data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, asnRange.CustomFields, &resp.Diagnostics)
+			data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, asnRange.CustomFields, &resp.Diagnostics)
 		} else {
 			data.CustomFields = types.SetNull(utils.GetCustomFieldsAttributeType().ElemType)
 		}
@@ -518,9 +555,17 @@ func (r *ASNRangeResource) setOptionalFields(ctx context.Context, asnRangeReques
 	if state != nil {
 		// Update operation - merge custom fields to preserve unmanaged fields
 		utils.ApplyCustomFieldsWithMerge(ctx, asnRangeRequest, data.CustomFields, state.CustomFields, diags)
+		utils.ApplyCustomFieldsMap(ctx, r.client, asnRangeRequest, data.CustomFieldsMap, diags)
+		utils.ApplyProviderTags(ctx, r.client, asnRangeRequest, diags)
+		utils.ApplyProviderCustomFields(ctx, r.client, "ipam.asnrange", asnRangeRequest, diags)
+		netboxlookup.ResolveCustomFieldReferences(ctx, r.client, asnRangeRequest, diags)
 	} else {
 		// Create operation - apply custom fields directly
 		utils.ApplyCustomFields(ctx, asnRangeRequest, data.CustomFields, diags)
+		utils.ApplyCustomFieldsMap(ctx, r.client, asnRangeRequest, data.CustomFieldsMap, diags)
+		utils.ApplyProviderTags(ctx, r.client, asnRangeRequest, diags)
+		utils.ApplyProviderCustomFields(ctx, r.client, "ipam.asnrange", asnRangeRequest, diags)
+		netboxlookup.ResolveCustomFieldReferences(ctx, r.client, asnRangeRequest, diags)
 	}
 	if diags.HasError() {
 		return
@@ -563,11 +608,14 @@ func (r *ASNRangeResource) mapASNRangeToState(ctx context.Context, asnRange *net
 	}
 
 	// Tags
-	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, asnRange.HasTags(), asnRange.GetTags(), data.Tags)
+	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, asnRange.HasTags(), asnRange.GetTags(), data.Tags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, asnRange.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, asnRange.GetCustomFields(), diags)
 	if diags.HasError() {
 		return
 	}
 
 	// Custom Fields - filter to owned fields only
 	data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, asnRange.CustomFields, diags)
+	data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, asnRange.CustomFields, diags)
 }
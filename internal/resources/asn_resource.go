@@ -31,6 +31,7 @@ var (
 	_ resource.ResourceWithConfigure   = &ASNResource{}
 	_ resource.ResourceWithImportState = &ASNResource{}
 	_ resource.ResourceWithIdentity    = &ASNResource{}
+	_ resource.ResourceWithModifyPlan  = &ASNResource{}
 )
 
 // NewASNResource returns a new ASN resource.
@@ -45,14 +46,18 @@ type ASNResource struct {
 
 // ASNResourceModel describes the resource data model.
 type ASNResourceModel struct {
-	ID           types.String `tfsdk:"id"`
-	ASN          types.Int64  `tfsdk:"asn"`
-	RIR          types.String `tfsdk:"rir"`
-	Tenant       types.String `tfsdk:"tenant"`
-	Description  types.String `tfsdk:"description"`
-	Comments     types.String `tfsdk:"comments"`
-	Tags         types.Set    `tfsdk:"tags"`
-	CustomFields types.Set    `tfsdk:"custom_fields"`
+	ID              types.String  `tfsdk:"id"`
+	ASN             types.Int64   `tfsdk:"asn"`
+	RIR             types.String  `tfsdk:"rir"`
+	Tenant          types.String  `tfsdk:"tenant"`
+	Description     types.String  `tfsdk:"description"`
+	Comments        types.String  `tfsdk:"comments"`
+	Tags            types.Set     `tfsdk:"tags"`
+	CustomFields    types.Set     `tfsdk:"custom_fields"`
+	CustomFieldsMap types.Dynamic `tfsdk:"custom_fields_map"`
+	TagsAll         types.Set     `tfsdk:"tags_all"`
+	CustomFieldsAll types.Map     `tfsdk:"custom_fields_all"`
+	Branch          types.String  `tfsdk:"branch"`
 }
 
 // Metadata returns the resource type name.
@@ -92,10 +97,14 @@ func (r *ASNResource) Schema(ctx context.Context, req resource.SchemaRequest, re
 				MarkdownDescription: "Additional comments or notes about this ASN.",
 				Optional:            true,
 			},
-			"tags":          nbschema.TagsSlugAttribute(),
-			"custom_fields": nbschema.CustomFieldsAttribute(),
+			"tags":              nbschema.TagsSlugAttribute(),
+			"custom_fields":     nbschema.CustomFieldsAttribute(),
+			"custom_fields_map": nbschema.CustomFieldsMapAttribute(),
+			"tags_all":          nbschema.TagsAllAttribute(),
+			"custom_fields_all": nbschema.CustomFieldsAllAttribute(),
 		},
 	}
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 func (r *ASNResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
@@ -118,8 +127,21 @@ func (r *ASNResource) Configure(ctx context.Context, req resource.ConfigureReque
 	r.client = client
 }
 
+// ModifyPlan validates the configured custom fields against their definitions in NetBox
+// and plans tags_all and custom_fields_all.
+func (r *ASNResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	utils.ValidateCustomFieldsPlan(ctx, r.client, "ipam.asn", req, resp)
+	utils.PlanEffectiveMetadata(ctx, r.client, "ipam.asn", req, resp)
+}
+
 // Create creates the resource and sets the initial Terraform state.
 func (r *ASNResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	defer journalCreate(ctx, r.client, "ipam.asn", req, resp)
+
 	var data ASNResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -162,6 +184,7 @@ func (r *ASNResource) Create(ctx context.Context, req resource.CreateRequest, re
 
 // Read refreshes the Terraform state with the latest data.
 func (r *ASNResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data ASNResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -214,6 +237,13 @@ func (r *ASNResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 
 // Update updates the resource and sets the updated Terraform state.
 func (r *ASNResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	ctx = withPriorTags(ctx, req.State)
+	defer journalUpdate(ctx, r.client, "ipam.asn", req, resp)
+
 	var data ASNResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -274,6 +304,10 @@ func (r *ASNResource) Update(ctx context.Context, req resource.UpdateRequest, re
 
 // Delete deletes the resource and removes the Terraform state.
 func (r *ASNResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data ASNResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -366,7 +400,9 @@ func (r *ASNResource) ImportState(ctx context.Context, req resource.ImportStateR
 		} else {
 			data.Tenant = types.StringNull()
 		}
-		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, asn.HasTags(), asn.GetTags(), data.Tags)
+		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, r.client, asn.HasTags(), asn.GetTags(), data.Tags)
+		data.TagsAll = utils.TagsAllFromAPI(ctx, asn.GetTags())
+		data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, asn.GetCustomFields(), &resp.Diagnostics)
 		if parsed.HasCustomFields {
 			if len(parsed.CustomFields) == 0 {
 				data.CustomFields = types.SetValueMust(utils.GetCustomFieldsAttributeType().ElemType, []attr.Value{})
@@ -389,6 +425,7 @@ func (r *ASNResource) ImportState(ctx context.Context, req resource.ImportStateR
 
 		if parsed.HasCustomFields {
 			data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, asn.GetCustomFields(), &resp.Diagnostics)
+			data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, asn.GetCustomFields(), &resp.Diagnostics)
 		} else {
 			data.CustomFields = types.SetNull(utils.GetCustomFieldsAttributeType().ElemType)
 		}
@@ -475,9 +512,17 @@ func (r *ASNResource) buildASNRequest(ctx context.Context, data *ASNResourceMode
 	if state != nil {
 		// Update: use merge-aware helper
 		utils.ApplyCustomFieldsWithMerge(ctx, asnRequest, data.CustomFields, state.CustomFields, &diags)
+		utils.ApplyCustomFieldsMap(ctx, r.client, asnRequest, data.CustomFieldsMap, &diags)
+		utils.ApplyProviderTags(ctx, r.client, asnRequest, &diags)
+		utils.ApplyProviderCustomFields(ctx, r.client, "ipam.asn", asnRequest, &diags)
+		netboxlookup.ResolveCustomFieldReferences(ctx, r.client, asnRequest, &diags)
 	} else {
 		// Create: apply custom fields directly
 		utils.ApplyCustomFields(ctx, asnRequest, data.CustomFields, &diags)
+		utils.ApplyCustomFieldsMap(ctx, r.client, asnRequest, data.CustomFieldsMap, &diags)
+		utils.ApplyProviderTags(ctx, r.client, asnRequest, &diags)
+		utils.ApplyProviderCustomFields(ctx, r.client, "ipam.asn", asnRequest, &diags)
+		netboxlookup.ResolveCustomFieldReferences(ctx, r.client, asnRequest, &diags)
 	}
 	if diags.HasError() {
 		return nil, diags
@@ -530,11 +575,14 @@ func (r *ASNResource) mapResponseToModel(ctx context.Context, asn *netbox.ASN, d
 	}
 
 	// Tags (slug list)
-	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, asn.HasTags(), asn.GetTags(), data.Tags)
+	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, asn.HasTags(), asn.GetTags(), data.Tags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, asn.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, asn.GetCustomFields(), diags)
 	if diags.HasError() {
 		return
 	}
 
 	// Custom Fields - filter to owned fields only
 	data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, asn.GetCustomFields(), diags)
+	data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, asn.GetCustomFields(), diags)
 }
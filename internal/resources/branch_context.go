@@ -0,0 +1,27 @@
+package resources
+
+import (
+	"context"
+
+	"github.com/bab3l/terraform-provider-netbox/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// attributeGetter is implemented by the plan and the state of a resource.
+type attributeGetter interface {
+	GetAttribute(ctx context.Context, p path.Path, target interface{}) diag.Diagnostics
+}
+
+// withBranch returns ctx set to make requests in the branch of the branch
+// attribute of data, so that resources overriding the provider's branch are
+// created, read, updated and deleted in their own branch. The provider's
+// branch applies when the attribute is null.
+func withBranch(ctx context.Context, data attributeGetter) context.Context {
+	var branch types.String
+	if data.GetAttribute(ctx, path.Root("branch"), &branch).HasError() || !utils.IsSet(branch) {
+		return ctx
+	}
+	return utils.ContextWithBranch(ctx, branch.ValueString())
+}
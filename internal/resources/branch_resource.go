@@ -0,0 +1,316 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/bab3l/go-netbox"
+	nbschema "github.com/bab3l/terraform-provider-netbox/internal/schema"
+	"github.com/bab3l/terraform-provider-netbox/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                = &BranchResource{}
+	_ resource.ResourceWithImportState = &BranchResource{}
+)
+
+func NewBranchResource() resource.Resource {
+	return &BranchResource{}
+}
+
+// BranchResource manages a branch of the netbox-branching plugin.
+type BranchResource struct {
+	client *netbox.APIClient
+}
+
+// BranchResourceModel describes the resource data model.
+type BranchResourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	Name         types.String `tfsdk:"name"`
+	Description  types.String `tfsdk:"description"`
+	Comments     types.String `tfsdk:"comments"`
+	SyncTriggers types.Map    `tfsdk:"sync_triggers"`
+	Merged       types.Bool   `tfsdk:"merged"`
+	SchemaID     types.String `tfsdk:"schema_id"`
+	Status       types.String `tfsdk:"status"`
+	LastSync     types.String `tfsdk:"last_sync"`
+	MergedTime   types.String `tfsdk:"merged_time"`
+}
+
+func (r *BranchResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_branch"
+}
+
+func (r *BranchResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a branch of the netbox-branching plugin, in which changes are staged for review before they are merged into main. " +
+			"Make resources change the branch with their `branch` attribute set to `schema_id`, then set `merged` to merge the branch. " +
+			"Creating, syncing, merging and reverting a branch run as background jobs in Netbox; the resource waits for them to finish.",
+		Attributes: map[string]schema.Attribute{
+			"id": nbschema.IDAttribute("branch"),
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the branch.",
+				Required:            true,
+			},
+			"description": nbschema.DescriptionAttribute("branch"),
+			"comments":    nbschema.CommentsAttribute("branch"),
+			"sync_triggers": schema.MapAttribute{
+				MarkdownDescription: "Arbitrary values that sync the branch with main when they change, e.g. a timestamp or the ID of a review. The branch is not synced once merged.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"merged": nbschema.BoolAttributeWithDefault(
+				"Whether the branch is merged into main. Setting it merges the branch and unsetting it reverts the merge. Merges made outside Terraform are not detected. Defaults to `false`.",
+				false,
+			),
+			"schema_id": schema.StringAttribute{
+				MarkdownDescription: "Schema ID of the branch, which the provider's and the resources' `branch` attributes refer to.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "Status of the branch, e.g. `ready` or `merged`.",
+				Computed:            true,
+			},
+			"last_sync": schema.StringAttribute{
+				MarkdownDescription: "Time the branch was last synced with main, in RFC 3339 format.",
+				Computed:            true,
+			},
+			"merged_time": schema.StringAttribute{
+				MarkdownDescription: "Time the branch was merged, in RFC 3339 format.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *BranchResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*netbox.APIClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *netbox.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.client = client
+}
+
+func (r *BranchResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	var data BranchResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "Creating branch", map[string]interface{}{
+		"name": data.Name.ValueString(),
+	})
+
+	created, httpResp, err := utils.WriteBranch(ctx, r.client, 0, branchFields(&data))
+	defer utils.CloseResponseBody(httpResp)
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating branch",
+			utils.FormatAPIError(fmt.Sprintf("create branch %q", data.Name.ValueString()), err, httpResp))
+		return
+	}
+	if !utils.ValidateStatusCode(&resp.Diagnostics, "create branch", httpResp, http.StatusCreated) {
+		return
+	}
+	data.ID = types.StringValue(fmt.Sprintf("%d", created.ID))
+	// Save the branch before waiting for it to be provisioned, so that it
+	// is not left out of state if provisioning fails.
+	r.mapToState(created, &data)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	branch := r.wait(ctx, created.ID, utils.BranchStatusReady, &resp.Diagnostics)
+	if branch != nil && data.Merged.ValueBool() {
+		branch = r.runJob(ctx, branch.ID, "merge", utils.BranchStatusMerged, &resp.Diagnostics)
+		if branch == nil {
+			data.Merged = types.BoolValue(false)
+		}
+	}
+	if branch != nil {
+		r.mapToState(branch, &data)
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BranchResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data BranchResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	id, err := utils.ParseID64(data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Branch ID", fmt.Sprintf("Branch ID must be a number, got: %s", data.ID.ValueString()))
+		return
+	}
+
+	branch, httpResp, err := utils.GetBranch(ctx, r.client, id)
+	defer utils.CloseResponseBody(httpResp)
+	if err != nil {
+		if utils.HandleNotFound(httpResp, func() { resp.State.RemoveResource(ctx) }) {
+			return
+		}
+		resp.Diagnostics.AddError("Error reading branch",
+			utils.FormatAPIError(fmt.Sprintf("read branch ID %d", id), err, httpResp))
+		return
+	}
+	r.mapToState(branch, &data)
+	if data.Merged.IsNull() {
+		// Imported branches start out as they are.
+		data.Merged = types.BoolValue(branch.Status == utils.BranchStatusMerged)
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BranchResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	var state, plan BranchResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	id, err := utils.ParseID64(state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Branch ID", fmt.Sprintf("Branch ID must be a number, got: %s", state.ID.ValueString()))
+		return
+	}
+	plan.ID = state.ID
+
+	branch, httpResp, err := utils.WriteBranch(ctx, r.client, id, branchFields(&plan))
+	defer utils.CloseResponseBody(httpResp)
+	if err != nil {
+		resp.Diagnostics.AddError("Error updating branch",
+			utils.FormatAPIError(fmt.Sprintf("update branch ID %d", id), err, httpResp))
+		return
+	}
+
+	switch {
+	case plan.Merged.ValueBool() && !state.Merged.ValueBool():
+		branch = r.runJob(ctx, id, "merge", utils.BranchStatusMerged, &resp.Diagnostics)
+	case !plan.Merged.ValueBool() && state.Merged.ValueBool():
+		branch = r.runJob(ctx, id, "revert", utils.BranchStatusReady, &resp.Diagnostics)
+	case !plan.Merged.ValueBool() && !plan.SyncTriggers.Equal(state.SyncTriggers):
+		branch = r.runJob(ctx, id, "sync", utils.BranchStatusReady, &resp.Diagnostics)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r.mapToState(branch, &plan)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *BranchResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	var data BranchResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	id, err := utils.ParseID64(data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Branch ID", fmt.Sprintf("Branch ID must be a number, got: %s", data.ID.ValueString()))
+		return
+	}
+
+	_, httpResp, err := utils.DoRawAPIRequest(ctx, r.client, http.MethodDelete, fmt.Sprintf("%s/%d", utils.BranchesAPIPath, id), nil, nil)
+	defer utils.CloseResponseBody(httpResp)
+	if err != nil {
+		if utils.HandleNotFound(httpResp, func() {}) {
+			return
+		}
+		resp.Diagnostics.AddError("Error deleting branch",
+			utils.FormatAPIError(fmt.Sprintf("delete branch ID %d", id), err, httpResp))
+		return
+	}
+}
+
+func (r *BranchResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	utils.ImportStatePassthroughIDWithValidation(ctx, req, resp, path.Root("id"), true)
+}
+
+// runJob runs the job that performs action on the branch with id and waits
+// until the branch has status want.
+func (r *BranchResource) runJob(ctx context.Context, id int64, action, want string, diags *diag.Diagnostics) *utils.Branch {
+	tflog.Debug(ctx, "Running branch job", map[string]interface{}{
+		"id":     id,
+		"action": action,
+	})
+	jobID, httpResp, err := utils.StartBranchJob(ctx, r.client, id, action)
+	defer utils.CloseResponseBody(httpResp)
+	if err != nil {
+		diags.AddError(fmt.Sprintf("Error starting branch %s", action),
+			utils.FormatAPIError(fmt.Sprintf("%s branch ID %d", action, id), err, httpResp))
+		return nil
+	}
+	jobResp, err := utils.WaitForJob(ctx, r.client, jobID)
+	defer utils.CloseResponseBody(jobResp)
+	if err != nil {
+		diags.AddError(fmt.Sprintf("Error running branch %s", action),
+			utils.FormatAPIError(fmt.Sprintf("%s branch ID %d", action, id), err, jobResp))
+		return nil
+	}
+	return r.wait(ctx, id, want, diags)
+}
+
+// wait waits until no job is working on the branch with id, which must then
+// have status want.
+func (r *BranchResource) wait(ctx context.Context, id int64, want string, diags *diag.Diagnostics) *utils.Branch {
+	branch, httpResp, err := utils.WaitForBranch(ctx, r.client, id, want)
+	defer utils.CloseResponseBody(httpResp)
+	if err != nil {
+		diags.AddError("Error waiting for branch",
+			utils.FormatAPIError(fmt.Sprintf("wait for branch ID %d", id), err, httpResp))
+		return nil
+	}
+	return branch
+}
+
+// branchFields returns the fields of the branch in data to write.
+func branchFields(data *BranchResourceModel) map[string]interface{} {
+	return map[string]interface{}{
+		"name":        data.Name.ValueString(),
+		"description": data.Description.ValueString(),
+		"comments":    data.Comments.ValueString(),
+	}
+}
+
+// mapToState maps the branch to data. sync_triggers and merged are kept as
+// configured.
+func (r *BranchResource) mapToState(branch *utils.Branch, data *BranchResourceModel) {
+	data.ID = types.StringValue(fmt.Sprintf("%d", branch.ID))
+	data.Name = types.StringValue(branch.Name)
+	data.Description = utils.StringFromAPI(branch.Description != "", func() string { return branch.Description }, data.Description)
+	data.Comments = utils.StringFromAPI(branch.Comments != "", func() string { return branch.Comments }, data.Comments)
+	data.SchemaID = types.StringValue(branch.SchemaID)
+	data.Status = types.StringValue(branch.Status)
+	data.LastSync = utils.BranchTime(branch.LastSync)
+	data.MergedTime = utils.BranchTime(branch.MergedTime)
+}
@@ -4,9 +4,11 @@ package resources
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"maps"
 	"net/http"
+	"strings"
 
 	"github.com/bab3l/go-netbox"
 	"github.com/bab3l/terraform-provider-netbox/internal/netboxlookup"
@@ -29,6 +31,8 @@ import (
 var _ resource.Resource = &CableResource{}
 var _ resource.ResourceWithImportState = &CableResource{}
 var _ resource.ResourceWithIdentity = &CableResource{}
+var _ resource.ResourceWithValidateConfig = &CableResource{}
+var _ resource.ResourceWithModifyPlan = &CableResource{}
 
 func NewCableResource() resource.Resource {
 	return &CableResource{}
@@ -41,26 +45,121 @@ type CableResource struct {
 
 // CableResourceModel describes the resource data model.
 type CableResourceModel struct {
-	ID            types.String  `tfsdk:"id"`
-	ATerminations types.List    `tfsdk:"a_terminations"`
-	BTerminations types.List    `tfsdk:"b_terminations"`
-	Type          types.String  `tfsdk:"type"`
-	Status        types.String  `tfsdk:"status"`
-	Tenant        types.String  `tfsdk:"tenant"`
-	Label         types.String  `tfsdk:"label"`
-	Color         types.String  `tfsdk:"color"`
-	Length        types.Float64 `tfsdk:"length"`
-	LengthUnit    types.String  `tfsdk:"length_unit"`
-	Description   types.String  `tfsdk:"description"`
-	Comments      types.String  `tfsdk:"comments"`
-	Tags          types.Set     `tfsdk:"tags"`
-	CustomFields  types.Set     `tfsdk:"custom_fields"`
+	ID              types.String  `tfsdk:"id"`
+	ATerminations   types.List    `tfsdk:"a_terminations"`
+	BTerminations   types.List    `tfsdk:"b_terminations"`
+	Type            types.String  `tfsdk:"type"`
+	Status          types.String  `tfsdk:"status"`
+	Tenant          types.String  `tfsdk:"tenant"`
+	Label           types.String  `tfsdk:"label"`
+	Color           types.String  `tfsdk:"color"`
+	Length          types.Float64 `tfsdk:"length"`
+	LengthUnit      types.String  `tfsdk:"length_unit"`
+	Description     types.String  `tfsdk:"description"`
+	Comments        types.String  `tfsdk:"comments"`
+	Tags            types.Set     `tfsdk:"tags"`
+	CustomFields    types.Set     `tfsdk:"custom_fields"`
+	CustomFieldsMap types.Dynamic `tfsdk:"custom_fields_map"`
+	TagsAll         types.Set     `tfsdk:"tags_all"`
+	CustomFieldsAll types.Map     `tfsdk:"custom_fields_all"`
+	Branch          types.String  `tfsdk:"branch"`
 }
 
-// TerminationModel represents a cable termination point.
+// TerminationModel represents a cable termination point. A termination is
+// given either by object type and ID, by device and port name, or by circuit
+// and termination side.
 type TerminationModel struct {
-	ObjectType types.String `tfsdk:"object_type"`
-	ObjectID   types.Int64  `tfsdk:"object_id"`
+	ObjectType        types.String `tfsdk:"object_type"`
+	ObjectID          types.Int64  `tfsdk:"object_id"`
+	Device            types.String `tfsdk:"device"`
+	Interface         types.String `tfsdk:"interface"`
+	FrontPort         types.String `tfsdk:"front_port"`
+	RearPort          types.String `tfsdk:"rear_port"`
+	ConsolePort       types.String `tfsdk:"console_port"`
+	ConsoleServerPort types.String `tfsdk:"console_server_port"`
+	PowerPort         types.String `tfsdk:"power_port"`
+	PowerOutlet       types.String `tfsdk:"power_outlet"`
+	Circuit           types.String `tfsdk:"circuit"`
+	TermSide          types.String `tfsdk:"term_side"`
+}
+
+// cableTerminationPorts maps the port name attributes of a termination to the
+// object type they select.
+var cableTerminationPorts = []struct {
+	attribute  string
+	objectType string
+	value      func(*TerminationModel) types.String
+}{
+	{"interface", "dcim.interface", func(m *TerminationModel) types.String { return m.Interface }},
+	{"front_port", "dcim.frontport", func(m *TerminationModel) types.String { return m.FrontPort }},
+	{"rear_port", "dcim.rearport", func(m *TerminationModel) types.String { return m.RearPort }},
+	{"console_port", "dcim.consoleport", func(m *TerminationModel) types.String { return m.ConsolePort }},
+	{"console_server_port", "dcim.consoleserverport", func(m *TerminationModel) types.String { return m.ConsoleServerPort }},
+	{"power_port", "dcim.powerport", func(m *TerminationModel) types.String { return m.PowerPort }},
+	{"power_outlet", "dcim.poweroutlet", func(m *TerminationModel) types.String { return m.PowerOutlet }},
+}
+
+const circuitTerminationObjectType = "circuits.circuittermination"
+
+// configuredPorts returns the indexes into cableTerminationPorts of the port
+// name attributes that are set.
+func (m *TerminationModel) configuredPorts() []int {
+	var configured []int
+	for i, port := range cableTerminationPorts {
+		if !port.value(m).IsNull() {
+			configured = append(configured, i)
+		}
+	}
+	return configured
+}
+
+// impliedObjectType returns the object type the termination refers to, or an
+// unknown value when it cannot be determined from the configuration.
+func (m *TerminationModel) impliedObjectType() types.String {
+	if !m.ObjectType.IsNull() {
+		return m.ObjectType
+	}
+	if ports := m.configuredPorts(); len(ports) == 1 {
+		return types.StringValue(cableTerminationPorts[ports[0]].objectType)
+	}
+	if !m.Circuit.IsNull() {
+		return types.StringValue(circuitTerminationObjectType)
+	}
+	return types.StringUnknown()
+}
+
+// reference returns the name-based reference of the termination, and whether
+// the termination is given by name with all values known.
+func (m *TerminationModel) reference() (netboxlookup.CableTerminationRef, bool) {
+	if !m.Device.IsNull() {
+		ports := m.configuredPorts()
+		if len(ports) != 1 {
+			return netboxlookup.CableTerminationRef{}, false
+		}
+		port := cableTerminationPorts[ports[0]]
+		name := port.value(m)
+		ref := netboxlookup.CableTerminationRef{ObjectType: port.objectType, Device: m.Device.ValueString(), Name: name.ValueString()}
+		return ref, !m.Device.IsUnknown() && !name.IsUnknown()
+	}
+	if !m.Circuit.IsNull() {
+		ref := netboxlookup.CableTerminationRef{ObjectType: circuitTerminationObjectType, Circuit: m.Circuit.ValueString(), TermSide: m.TermSide.ValueString()}
+		return ref, utils.IsSet(m.Circuit) && utils.IsSet(m.TermSide)
+	}
+	return netboxlookup.CableTerminationRef{}, false
+}
+
+// setNamesFrom copies the name-based reference of other.
+func (m *TerminationModel) setNamesFrom(other *TerminationModel) {
+	m.Device = other.Device
+	m.Interface = other.Interface
+	m.FrontPort = other.FrontPort
+	m.RearPort = other.RearPort
+	m.ConsolePort = other.ConsolePort
+	m.ConsoleServerPort = other.ConsoleServerPort
+	m.PowerPort = other.PowerPort
+	m.PowerOutlet = other.PowerOutlet
+	m.Circuit = other.Circuit
+	m.TermSide = other.TermSide
 }
 
 func (r *CableResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -68,29 +167,55 @@ func (r *CableResource) Metadata(ctx context.Context, req resource.MetadataReque
 }
 
 func (r *CableResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
-	terminationNestedObject := schema.NestedAttributeObject{
-		Attributes: map[string]schema.Attribute{
-			"object_type": schema.StringAttribute{
-				MarkdownDescription: "Content type of the termination object. Common values: `dcim.interface`, `dcim.frontport`, `dcim.rearport`, `dcim.powerport`, `dcim.poweroutlet`, `dcim.consoleport`, `dcim.consoleserverport`, `circuits.circuittermination`.",
-				Required:            true,
-			},
-			"object_id": schema.Int64Attribute{
-				MarkdownDescription: "ID of the termination object.",
-				Required:            true,
+	portNames := make([]string, 0, len(cableTerminationPorts))
+	for _, port := range cableTerminationPorts {
+		portNames = append(portNames, "`"+port.attribute+"`")
+	}
+	terminationAttributes := map[string]schema.Attribute{
+		"object_type": schema.StringAttribute{
+			MarkdownDescription: "Content type of the termination object. Common values: `dcim.interface`, `dcim.frontport`, `dcim.rearport`, `dcim.powerport`, `dcim.poweroutlet`, `dcim.consoleport`, `dcim.consoleserverport`, `circuits.circuittermination`. Computed when the termination is given by name.",
+			Optional:            true,
+			Computed:            true,
+		},
+		"object_id": schema.Int64Attribute{
+			MarkdownDescription: "ID of the termination object. Computed when the termination is given by name.",
+			Optional:            true,
+			Computed:            true,
+		},
+		"device": schema.StringAttribute{
+			MarkdownDescription: fmt.Sprintf("ID or name of the device owning the termination. Must be combined with exactly one of %s.", strings.Join(portNames, ", ")),
+			Optional:            true,
+		},
+		"circuit": schema.StringAttribute{
+			MarkdownDescription: "ID or CID of the circuit to terminate on. Must be combined with `term_side`.",
+			Optional:            true,
+		},
+		"term_side": schema.StringAttribute{
+			MarkdownDescription: "Side of `circuit` to terminate on: `A` or `Z`.",
+			Optional:            true,
+			Validators: []validator.String{
+				stringvalidator.OneOf("A", "Z"),
 			},
 		},
 	}
+	for _, port := range cableTerminationPorts {
+		terminationAttributes[port.attribute] = schema.StringAttribute{
+			MarkdownDescription: fmt.Sprintf("Name of the `%s` termination on `device`.", port.objectType),
+			Optional:            true,
+		}
+	}
+	terminationNestedObject := schema.NestedAttributeObject{Attributes: terminationAttributes}
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Manages a cable connection between two endpoints in Netbox. Cables represent physical connections between interfaces, ports, or circuit terminations.",
+		MarkdownDescription: "Manages a cable connection between two endpoints in Netbox. Cables represent physical connections between interfaces, ports, or circuit terminations. Each termination is given by `object_type` and `object_id`, by `device` and a port name such as `interface`, or by `circuit` and `term_side`. All terminations on one end must be of the same type, and terminations that already have another cable are rejected at plan time.",
 		Attributes: map[string]schema.Attribute{
 			"id": nbschema.IDAttribute("cable"),
 			"a_terminations": schema.ListNestedAttribute{
-				MarkdownDescription: "A-side termination points for this cable. Each termination specifies an object type and ID.",
+				MarkdownDescription: "A-side termination points for this cable.",
 				Required:            true,
 				NestedObject:        terminationNestedObject,
 			},
 			"b_terminations": schema.ListNestedAttribute{
-				MarkdownDescription: "B-side termination points for this cable. Each termination specifies an object type and ID.",
+				MarkdownDescription: "B-side termination points for this cable.",
 				Required:            true,
 				NestedObject:        terminationNestedObject,
 			},
@@ -147,6 +272,10 @@ func (r *CableResource) Schema(ctx context.Context, req resource.SchemaRequest,
 	// Add metadata attributes (slug list tags, custom_fields)
 	resp.Schema.Attributes["tags"] = nbschema.TagsSlugAttribute()
 	resp.Schema.Attributes["custom_fields"] = nbschema.CustomFieldsAttribute()
+	resp.Schema.Attributes["custom_fields_map"] = nbschema.CustomFieldsMapAttribute()
+	resp.Schema.Attributes["tags_all"] = nbschema.TagsAllAttribute()
+	resp.Schema.Attributes["custom_fields_all"] = nbschema.CustomFieldsAllAttribute()
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 func (r *CableResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
@@ -168,7 +297,198 @@ func (r *CableResource) Configure(ctx context.Context, req resource.ConfigureReq
 	r.client = client
 }
 
+func (r *CableResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data CableResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	validateCableTerminations(ctx, "a_terminations", data.ATerminations, &resp.Diagnostics)
+	validateCableTerminations(ctx, "b_terminations", data.BTerminations, &resp.Diagnostics)
+}
+
+// validateCableTerminations checks that every termination uses exactly one
+// form, and that all terminations on one end are of the same type.
+func validateCableTerminations(ctx context.Context, attribute string, terminations types.List, diags *diag.Diagnostics) {
+	if terminations.IsNull() || terminations.IsUnknown() {
+		return
+	}
+	var models []TerminationModel
+	diags.Append(terminations.ElementsAs(ctx, &models, false)...)
+	if diags.HasError() {
+		return
+	}
+
+	for i := range models {
+		m := &models[i]
+		elementPath := path.Root(attribute).AtListIndex(i)
+		byID := !m.ObjectType.IsNull() || !m.ObjectID.IsNull()
+		byDevice := !m.Device.IsNull() || len(m.configuredPorts()) > 0
+		byCircuit := !m.Circuit.IsNull() || !m.TermSide.IsNull()
+
+		forms := 0
+		for _, set := range []bool{byID, byDevice, byCircuit} {
+			if set {
+				forms++
+			}
+		}
+		switch {
+		case forms != 1:
+			diags.AddAttributeError(elementPath, "Invalid cable termination",
+				"Specify exactly one of `object_type` and `object_id`, `device` with a port name, or `circuit` with `term_side`.")
+		case byID && (m.ObjectType.IsNull() || m.ObjectID.IsNull()):
+			diags.AddAttributeError(elementPath, "Invalid cable termination", "`object_type` and `object_id` must be set together.")
+		case byDevice && (m.Device.IsNull() || len(m.configuredPorts()) != 1):
+			diags.AddAttributeError(elementPath, "Invalid cable termination",
+				"`device` must be set together with exactly one port name attribute, such as `interface` or `front_port`.")
+		case byCircuit && (m.Circuit.IsNull() || m.TermSide.IsNull()):
+			diags.AddAttributeError(elementPath, "Invalid cable termination", "`circuit` and `term_side` must be set together.")
+		}
+	}
+	if diags.HasError() {
+		return
+	}
+	validateCableTerminationTypes(attribute, models, diags)
+}
+
+// validateCableTerminationTypes checks that all terminations on one end of a
+// cable, as far as they are known, are of the same type.
+func validateCableTerminationTypes(attribute string, models []TerminationModel, diags *diag.Diagnostics) {
+	first := ""
+	for i := range models {
+		objectType := models[i].impliedObjectType()
+		if !utils.IsSet(objectType) {
+			continue
+		}
+		if first == "" {
+			first = objectType.ValueString()
+			continue
+		}
+		if objectType.ValueString() != first {
+			diags.AddAttributeError(path.Root(attribute).AtListIndex(i), "Mixed cable termination types",
+				fmt.Sprintf("All terminations on one end of a cable must be of the same type, got %s and %s.", first, objectType.ValueString()))
+			return
+		}
+	}
+}
+
+// ModifyPlan resolves terminations given by name to object IDs and rejects
+// terminations that are already connected to another cable, so that both are
+// reported at plan time rather than as an API error on apply. Custom fields are
+// validated against their definitions in NetBox first, and tags_all and
+// custom_fields_all are planned.
+func (r *CableResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+	utils.ValidateCustomFieldsPlan(ctx, r.client, "dcim.cable", req, resp)
+	utils.PlanEffectiveMetadata(ctx, r.client, "dcim.cable", req, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var plan CableResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	cableID := int64(0)
+	if !req.State.Raw.IsNull() {
+		var state CableResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		cableID, _ = utils.ParseID64(state.ID.ValueString())
+	}
+
+	for _, attribute := range []string{"a_terminations", "b_terminations"} {
+		var terminations types.List
+		resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root(attribute), &terminations)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if terminations.IsNull() || terminations.IsUnknown() {
+			continue
+		}
+		var models []TerminationModel
+		resp.Diagnostics.Append(terminations.ElementsAs(ctx, &models, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		for i := range models {
+			m := &models[i]
+			elementPath := path.Root(attribute).AtListIndex(i)
+			if ref, ok := m.reference(); ok {
+				id, lookupDiags := netboxlookup.LookupCableTerminationID(ctx, r.client, ref)
+				for _, d := range lookupDiags {
+					resp.Diagnostics.AddAttributeError(elementPath, d.Summary(), d.Detail())
+				}
+				if lookupDiags.HasError() {
+					continue
+				}
+				m.ObjectType = types.StringValue(ref.ObjectType)
+				m.ObjectID = types.Int64Value(id)
+			}
+			if utils.IsSet(m.ObjectType) && utils.IsSet(m.ObjectID) {
+				r.checkTerminationAvailable(ctx, elementPath, m.ObjectType.ValueString(), m.ObjectID.ValueInt64(), cableID, &resp.Diagnostics)
+			}
+		}
+		validateCableTerminationTypes(attribute, models, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		resolved, listDiags := types.ListValueFrom(ctx, getTerminationObjectType(), models)
+		resp.Diagnostics.Append(listDiags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root(attribute), resolved)...)
+	}
+}
+
+// checkTerminationAvailable reports an error when the termination object is
+// connected to a cable other than cableID.
+func (r *CableResource) checkTerminationAvailable(ctx context.Context, elementPath path.Path, objectType string, objectID, cableID int64, diags *diag.Diagnostics) {
+	endpoint, ok := utils.LookupCableTerminationEndpoint(objectType)
+	if !ok {
+		return
+	}
+	body, httpResp, err := utils.DoRawAPIRequest(ctx, r.client, http.MethodGet, fmt.Sprintf("%s/%d", endpoint.APIPath, objectID), nil, nil)
+	if err != nil {
+		if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
+			diags.AddAttributeError(elementPath, "Cable termination not found", fmt.Sprintf("No %s found with ID %d.", objectType, objectID))
+			return
+		}
+		diags.AddAttributeError(elementPath, "Error reading cable termination",
+			utils.FormatAPIError(fmt.Sprintf("read %s ID %d", objectType, objectID), err, httpResp))
+		return
+	}
+
+	var termination struct {
+		Display string `json:"display"`
+		Cable   *struct {
+			ID int64 `json:"id"`
+		} `json:"cable"`
+	}
+	if err := json.Unmarshal(body, &termination); err != nil {
+		diags.AddAttributeError(elementPath, "Error reading cable termination", fmt.Sprintf("Could not decode %s: %s", objectType, err))
+		return
+	}
+	if termination.Cable != nil && termination.Cable.ID != cableID {
+		diags.AddAttributeError(elementPath, "Termination already cabled",
+			fmt.Sprintf("%s %q (ID %d) is already connected to cable %d.", objectType, termination.Display, objectID, termination.Cable.ID))
+	}
+}
+
 func (r *CableResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	defer journalCreate(ctx, r.client, "dcim.cable", req, resp)
+
 	var data CableResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -180,7 +500,7 @@ func (r *CableResource) Create(ctx context.Context, req resource.CreateRequest,
 	cableRequest := netbox.NewWritableCableRequest()
 
 	// Set A terminations
-	aTerminations, diags := r.parseTerminations(ctx, data.ATerminations)
+	aTerminations, diags := r.parseTerminations(ctx, &data.ATerminations)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -188,7 +508,7 @@ func (r *CableResource) Create(ctx context.Context, req resource.CreateRequest,
 	cableRequest.ATerminations = aTerminations
 
 	// Set B terminations
-	bTerminations, diags := r.parseTerminations(ctx, data.BTerminations)
+	bTerminations, diags := r.parseTerminations(ctx, &data.BTerminations)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -243,6 +563,10 @@ func (r *CableResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 	utils.ApplyCustomFields(ctx, cableRequest, data.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, cableRequest, data.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, cableRequest, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "dcim.cable", cableRequest, &resp.Diagnostics)
+	netboxlookup.ResolveCustomFieldReferences(ctx, r.client, cableRequest, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -269,6 +593,7 @@ func (r *CableResource) Create(ctx context.Context, req resource.CreateRequest,
 }
 
 func (r *CableResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data CableResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -309,6 +634,13 @@ func (r *CableResource) Read(ctx context.Context, req resource.ReadRequest, resp
 }
 
 func (r *CableResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	ctx = withPriorTags(ctx, req.State)
+	defer journalUpdate(ctx, r.client, "dcim.cable", req, resp)
+
 	var state, data CableResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -326,7 +658,7 @@ func (r *CableResource) Update(ctx context.Context, req resource.UpdateRequest,
 	cableRequest := netbox.NewWritableCableRequest()
 
 	// Set A terminations
-	aTerminations, diags := r.parseTerminations(ctx, data.ATerminations)
+	aTerminations, diags := r.parseTerminations(ctx, &data.ATerminations)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -334,7 +666,7 @@ func (r *CableResource) Update(ctx context.Context, req resource.UpdateRequest,
 	cableRequest.ATerminations = aTerminations
 
 	// Set B terminations
-	bTerminations, diags := r.parseTerminations(ctx, data.BTerminations)
+	bTerminations, diags := r.parseTerminations(ctx, &data.BTerminations)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -413,6 +745,10 @@ func (r *CableResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 	utils.ApplyCustomFieldsWithMerge(ctx, cableRequest, data.CustomFields, state.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, cableRequest, data.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, cableRequest, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "dcim.cable", cableRequest, &resp.Diagnostics)
+	netboxlookup.ResolveCustomFieldReferences(ctx, r.client, cableRequest, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -439,6 +775,10 @@ func (r *CableResource) Update(ctx context.Context, req resource.UpdateRequest,
 }
 
 func (r *CableResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data CableResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -500,7 +840,9 @@ func (r *CableResource) ImportState(ctx context.Context, req resource.ImportStat
 		}
 
 		var data CableResourceModel
-		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, cable.HasTags(), cable.GetTags(), data.Tags)
+		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, r.client, cable.HasTags(), cable.GetTags(), data.Tags)
+		data.TagsAll = utils.TagsAllFromAPI(ctx, cable.GetTags())
+		data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, cable.GetCustomFields(), &resp.Diagnostics)
 		if parsed.HasCustomFields {
 			if len(parsed.CustomFields) == 0 {
 				data.CustomFields = types.SetValueMust(utils.GetCustomFieldsAttributeType().ElemType, []attr.Value{})
@@ -522,6 +864,7 @@ func (r *CableResource) ImportState(ctx context.Context, req resource.ImportStat
 		}
 		if parsed.HasCustomFields {
 			data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, cable.GetCustomFields(), &resp.Diagnostics)
+			data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, cable.GetCustomFields(), &resp.Diagnostics)
 		} else {
 			data.CustomFields = types.SetNull(utils.GetCustomFieldsAttributeType().ElemType)
 		}
@@ -549,7 +892,9 @@ func (r *CableResource) ImportState(ctx context.Context, req resource.ImportStat
 }
 
 // parseTerminations converts Terraform termination list to API format.
-func (r *CableResource) parseTerminations(ctx context.Context, terminations types.List) ([]netbox.GenericObjectRequest, diag.Diagnostics) {
+// Terminations given by name that could not be resolved at plan time are
+// looked up, and the resolved IDs are written back to terminations.
+func (r *CableResource) parseTerminations(ctx context.Context, terminations *types.List) ([]netbox.GenericObjectRequest, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	if terminations.IsNull() || terminations.IsUnknown() {
 		return nil, diags
@@ -560,7 +905,17 @@ func (r *CableResource) parseTerminations(ctx context.Context, terminations type
 		return nil, diags
 	}
 	result := make([]netbox.GenericObjectRequest, len(models))
-	for i, m := range models {
+	for i := range models {
+		m := &models[i]
+		if ref, ok := m.reference(); ok && !utils.IsSet(m.ObjectID) {
+			id, lookupDiags := netboxlookup.LookupCableTerminationID(ctx, r.client, ref)
+			diags.Append(lookupDiags...)
+			if diags.HasError() {
+				return nil, diags
+			}
+			m.ObjectType = types.StringValue(ref.ObjectType)
+			m.ObjectID = types.Int64Value(id)
+		}
 		objectID, err := utils.SafeInt32FromValue(m.ObjectID)
 		if err != nil {
 			diags.AddError("Invalid value", fmt.Sprintf("ObjectID value overflow: %s", err))
@@ -571,6 +926,9 @@ func (r *CableResource) parseTerminations(ctx context.Context, terminations type
 			objectID,
 		)
 	}
+	resolved, d := types.ListValueFrom(ctx, getTerminationObjectType(), models)
+	diags.Append(d...)
+	*terminations = resolved
 	return result, diags
 }
 
@@ -581,14 +939,14 @@ func (r *CableResource) mapResponseToState(ctx context.Context, result *netbox.C
 
 	// Map A terminations
 	if result.HasATerminations() {
-		aTerms, d := r.mapTerminationsToState(ctx, result.GetATerminations())
+		aTerms, d := r.mapTerminationsToState(ctx, result.GetATerminations(), data.ATerminations)
 		diags.Append(d...)
 		data.ATerminations = aTerms
 	}
 
 	// Map B terminations
 	if result.HasBTerminations() {
-		bTerms, d := r.mapTerminationsToState(ctx, result.GetBTerminations())
+		bTerms, d := r.mapTerminationsToState(ctx, result.GetBTerminations(), data.BTerminations)
 		diags.Append(d...)
 		data.BTerminations = bTerms
 	}
@@ -664,7 +1022,9 @@ func (r *CableResource) mapResponseToState(ctx context.Context, result *netbox.C
 	}
 
 	// Tags (slug list)
-	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, result.HasTags(), result.GetTags(), data.Tags)
+	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, result.HasTags(), result.GetTags(), data.Tags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, result.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, result.GetCustomFields(), &diags)
 
 	// Custom fields
 	if result.HasCustomFields() && len(result.GetCustomFields()) > 0 {
@@ -682,18 +1042,31 @@ func (r *CableResource) mapResponseToState(ctx context.Context, result *netbox.C
 	return diags
 }
 
-// mapTerminationsToState converts API terminations to Terraform state.
-func (r *CableResource) mapTerminationsToState(ctx context.Context, terminations []netbox.GenericObject) (types.List, diag.Diagnostics) {
+// mapTerminationsToState converts API terminations to Terraform state. The
+// name-based reference of a termination is kept from prior when it still
+// refers to the same object.
+func (r *CableResource) mapTerminationsToState(ctx context.Context, terminations []netbox.GenericObject, prior types.List) (types.List, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	if len(terminations) == 0 {
 		return types.ListNull(getTerminationObjectType()), diags
 	}
+	var priorModels []TerminationModel
+	if !prior.IsNull() && !prior.IsUnknown() {
+		diags.Append(prior.ElementsAs(ctx, &priorModels, false)...)
+	}
+
 	models := make([]TerminationModel, len(terminations))
 	for i, t := range terminations {
 		models[i] = TerminationModel{
 			ObjectType: types.StringValue(t.GetObjectType()),
 			ObjectID:   types.Int64Value(int64(t.GetObjectId())),
 		}
+		for j := range priorModels {
+			if priorModels[j].ObjectType.Equal(models[i].ObjectType) && priorModels[j].ObjectID.Equal(models[i].ObjectID) {
+				models[i].setNamesFrom(&priorModels[j])
+				break
+			}
+		}
 	}
 	result, d := types.ListValueFrom(ctx, getTerminationObjectType(), models)
 	diags.Append(d...)
@@ -702,10 +1075,15 @@ func (r *CableResource) mapTerminationsToState(ctx context.Context, terminations
 
 // getTerminationObjectType returns the Terraform object type for terminations.
 func getTerminationObjectType() attr.Type {
-	return types.ObjectType{
-		AttrTypes: map[string]attr.Type{
-			"object_type": types.StringType,
-			"object_id":   types.Int64Type,
-		},
+	attrTypes := map[string]attr.Type{
+		"object_type": types.StringType,
+		"object_id":   types.Int64Type,
+		"device":      types.StringType,
+		"circuit":     types.StringType,
+		"term_side":   types.StringType,
+	}
+	for _, port := range cableTerminationPorts {
+		attrTypes[port.attribute] = types.StringType
 	}
+	return types.ObjectType{AttrTypes: attrTypes}
 }
@@ -26,6 +26,8 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &CircuitGroupAssignmentResource{}
 var _ resource.ResourceWithImportState = &CircuitGroupAssignmentResource{}
+var _ resource.ResourceWithModifyPlan = &CircuitGroupAssignmentResource{}
+var _ resource.ResourceWithUpgradeState = &CircuitGroupAssignmentResource{}
 
 func NewCircuitGroupAssignmentResource() resource.Resource {
 	return &CircuitGroupAssignmentResource{}
@@ -44,6 +46,8 @@ type CircuitGroupAssignmentResourceModel struct {
 	Priority     types.String `tfsdk:"priority"`
 	Tags         types.Set    `tfsdk:"tags"`
 	CustomFields types.Set    `tfsdk:"custom_fields"`
+	TagsAll      types.Set    `tfsdk:"tags_all"`
+	Branch       types.String `tfsdk:"branch"`
 }
 
 func (r *CircuitGroupAssignmentResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -52,6 +56,7 @@ func (r *CircuitGroupAssignmentResource) Metadata(ctx context.Context, req resou
 
 func (r *CircuitGroupAssignmentResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version:             1,
 		MarkdownDescription: "Manages a circuit group assignment in Netbox. A circuit group assignment links a circuit to a circuit group with an optional priority.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -79,8 +84,63 @@ func (r *CircuitGroupAssignmentResource) Schema(ctx context.Context, req resourc
 		},
 	}
 
-	// Add common metadata attributes (tags, custom_fields)
+	// Add metadata attributes (slug list tags, custom_fields)
 	maps.Copy(resp.Schema.Attributes, nbschema.CommonMetadataAttributes())
+	resp.Schema.Attributes["tags"] = nbschema.TagsSlugAttribute()
+	resp.Schema.Attributes["tags_all"] = nbschema.TagsAllAttribute()
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
+}
+
+// UpgradeState converts the tags of version 0, which were objects with a name
+// and a slug, to the list of tag slugs.
+func (r *CircuitGroupAssignmentResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	var current resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &current)
+	prior := current.Schema
+	prior.Version = 0
+	prior.Attributes = maps.Clone(current.Schema.Attributes)
+	prior.Attributes["tags"] = nbschema.TagsAttribute()
+	delete(prior.Attributes, "tags_all")
+
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: &prior,
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var data struct {
+					ID           types.String     `tfsdk:"id"`
+					Group        types.String     `tfsdk:"group_id"`
+					Circuit      types.String     `tfsdk:"circuit_id"`
+					Priority     types.String     `tfsdk:"priority"`
+					Tags         []utils.TagModel `tfsdk:"tags"`
+					CustomFields types.Set        `tfsdk:"custom_fields"`
+					Branch       types.String     `tfsdk:"branch"`
+				}
+				resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				tags := types.SetNull(types.StringType)
+				if data.Tags != nil {
+					slugs := make([]string, 0, len(data.Tags))
+					for _, tag := range data.Tags {
+						slugs = append(slugs, tag.Slug.ValueString())
+					}
+					tags = utils.TagsSlugToSet(ctx, slugs)
+				}
+				resp.Diagnostics.Append(resp.State.Set(ctx, &CircuitGroupAssignmentResourceModel{
+					ID:           data.ID,
+					Group:        data.Group,
+					Circuit:      data.Circuit,
+					Priority:     data.Priority,
+					Tags:         tags,
+					CustomFields: data.CustomFields,
+					TagsAll:      types.SetNull(types.StringType),
+					Branch:       data.Branch,
+				})...)
+			},
+		},
+	}
 }
 
 func (r *CircuitGroupAssignmentResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -98,7 +158,16 @@ func (r *CircuitGroupAssignmentResource) Configure(ctx context.Context, req reso
 	r.client = client
 }
 
+// ModifyPlan plans tags_all.
+func (r *CircuitGroupAssignmentResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	utils.PlanEffectiveMetadata(ctx, r.client, "circuits.circuitgroupassignment", req, resp)
+}
+
 func (r *CircuitGroupAssignmentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
 	var data CircuitGroupAssignmentResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -138,7 +207,8 @@ func (r *CircuitGroupAssignmentResource) Create(ctx context.Context, req resourc
 	}
 
 	// Handle tags
-	utils.ApplyTags(ctx, assignmentRequest, data.Tags, &resp.Diagnostics)
+	utils.ApplyTagsFromSlugs(ctx, r.client, assignmentRequest, data.Tags, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, assignmentRequest, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -164,6 +234,7 @@ func (r *CircuitGroupAssignmentResource) Create(ctx context.Context, req resourc
 }
 
 func (r *CircuitGroupAssignmentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data CircuitGroupAssignmentResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -212,6 +283,11 @@ func (r *CircuitGroupAssignmentResource) Read(ctx context.Context, req resource.
 }
 
 func (r *CircuitGroupAssignmentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	ctx = withPriorTags(ctx, req.State)
 	var state, data CircuitGroupAssignmentResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -267,10 +343,11 @@ func (r *CircuitGroupAssignmentResource) Update(ctx context.Context, req resourc
 
 	// Handle tags - merge-aware
 	if !data.Tags.IsNull() && !data.Tags.IsUnknown() {
-		utils.ApplyTags(ctx, assignmentRequest, data.Tags, &resp.Diagnostics)
+		utils.ApplyTagsFromSlugs(ctx, r.client, assignmentRequest, data.Tags, &resp.Diagnostics)
 	} else if !state.Tags.IsNull() && !state.Tags.IsUnknown() {
-		utils.ApplyTags(ctx, assignmentRequest, state.Tags, &resp.Diagnostics)
+		utils.ApplyTagsFromSlugs(ctx, r.client, assignmentRequest, state.Tags, &resp.Diagnostics)
 	}
+	utils.ApplyProviderTags(ctx, r.client, assignmentRequest, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -296,6 +373,10 @@ func (r *CircuitGroupAssignmentResource) Update(ctx context.Context, req resourc
 }
 
 func (r *CircuitGroupAssignmentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data CircuitGroupAssignmentResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -368,6 +449,7 @@ func (r *CircuitGroupAssignmentResource) ImportState(ctx context.Context, req re
 	// Create a new model and map the response
 	var data CircuitGroupAssignmentResourceModel
 	r.mapResponseToState(ctx, assignment, &data, &resp.Diagnostics)
+	data.Tags = utils.PopulateTagsSlugFromAPI(ctx, r.client, assignment.HasTags(), assignment.GetTags(), data.Tags)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -395,11 +477,9 @@ func (r *CircuitGroupAssignmentResource) mapResponseToState(ctx context.Context,
 		data.Priority = types.StringNull()
 	}
 
-	// Populate tags using unified helper
-	data.Tags = utils.PopulateTagsFromAPI(ctx, assignment.HasTags(), assignment.GetTags(), data.Tags, diags)
-	if diags.HasError() {
-		return
-	}
+	// Tags (slug list)
+	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, assignment.HasTags(), assignment.GetTags(), data.Tags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, assignment.GetTags())
 
 	// Custom fields - circuit group assignments don't have custom fields in the response
 	data.CustomFields = types.SetNull(utils.GetCustomFieldsAttributeType().ElemType)
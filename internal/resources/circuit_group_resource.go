@@ -27,6 +27,7 @@ var (
 	_ resource.ResourceWithConfigure   = &CircuitGroupResource{}
 	_ resource.ResourceWithImportState = &CircuitGroupResource{}
 	_ resource.ResourceWithIdentity    = &CircuitGroupResource{}
+	_ resource.ResourceWithModifyPlan  = &CircuitGroupResource{}
 )
 
 // NewCircuitGroupResource returns a new circuit group resource.
@@ -41,14 +42,18 @@ type CircuitGroupResource struct {
 
 // CircuitGroupResourceModel describes the circuit group resource data model.
 type CircuitGroupResourceModel struct {
-	ID           types.String `tfsdk:"id"`
-	Name         types.String `tfsdk:"name"`
-	Slug         types.String `tfsdk:"slug"`
-	Description  types.String `tfsdk:"description"`
-	Tenant       types.String `tfsdk:"tenant"`
-	TenantID     types.String `tfsdk:"tenant_id"`
-	Tags         types.Set    `tfsdk:"tags"`
-	CustomFields types.Set    `tfsdk:"custom_fields"`
+	ID              types.String  `tfsdk:"id"`
+	Name            types.String  `tfsdk:"name"`
+	Slug            types.String  `tfsdk:"slug"`
+	Description     types.String  `tfsdk:"description"`
+	Tenant          types.String  `tfsdk:"tenant"`
+	TenantID        types.String  `tfsdk:"tenant_id"`
+	Tags            types.Set     `tfsdk:"tags"`
+	CustomFields    types.Set     `tfsdk:"custom_fields"`
+	CustomFieldsMap types.Dynamic `tfsdk:"custom_fields_map"`
+	TagsAll         types.Set     `tfsdk:"tags_all"`
+	CustomFieldsAll types.Map     `tfsdk:"custom_fields_all"`
+	Branch          types.String  `tfsdk:"branch"`
 }
 
 // Metadata returns the resource type name.
@@ -78,6 +83,10 @@ func (r *CircuitGroupResource) Schema(ctx context.Context, req resource.SchemaRe
 	// Add metadata attributes (slug list tags, custom_fields)
 	resp.Schema.Attributes["tags"] = nbschema.TagsSlugAttribute()
 	resp.Schema.Attributes["custom_fields"] = nbschema.CustomFieldsAttribute()
+	resp.Schema.Attributes["custom_fields_map"] = nbschema.CustomFieldsMapAttribute()
+	resp.Schema.Attributes["tags_all"] = nbschema.TagsAllAttribute()
+	resp.Schema.Attributes["custom_fields_all"] = nbschema.CustomFieldsAllAttribute()
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 func (r *CircuitGroupResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
@@ -100,8 +109,21 @@ func (r *CircuitGroupResource) Configure(ctx context.Context, req resource.Confi
 	r.client = client
 }
 
+// ModifyPlan validates the configured custom fields against their definitions in NetBox
+// and plans tags_all and custom_fields_all.
+func (r *CircuitGroupResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	utils.ValidateCustomFieldsPlan(ctx, r.client, "circuits.circuitgroup", req, resp)
+	utils.PlanEffectiveMetadata(ctx, r.client, "circuits.circuitgroup", req, resp)
+}
+
 // Create creates the resource and sets the initial Terraform state.
 func (r *CircuitGroupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	defer journalCreate(ctx, r.client, "circuits.circuitgroup", req, resp)
+
 	var data CircuitGroupResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -132,6 +154,10 @@ func (r *CircuitGroupResource) Create(ctx context.Context, req resource.CreateRe
 		return
 	}
 	utils.ApplyCustomFields(ctx, groupRequest, data.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, groupRequest, data.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, groupRequest, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "circuits.circuitgroup", groupRequest, &resp.Diagnostics)
+	netboxlookup.ResolveCustomFieldReferences(ctx, r.client, groupRequest, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -161,6 +187,7 @@ func (r *CircuitGroupResource) Create(ctx context.Context, req resource.CreateRe
 
 // Read refreshes the Terraform state with the latest data.
 func (r *CircuitGroupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data CircuitGroupResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -205,6 +232,13 @@ func (r *CircuitGroupResource) Read(ctx context.Context, req resource.ReadReques
 
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *CircuitGroupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	ctx = withPriorTags(ctx, req.State)
+	defer journalUpdate(ctx, r.client, "circuits.circuitgroup", req, resp)
+
 	var state, data CircuitGroupResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -256,6 +290,10 @@ func (r *CircuitGroupResource) Update(ctx context.Context, req resource.UpdateRe
 
 	// Handle custom fields with merge-aware logic
 	utils.ApplyCustomFieldsWithMerge(ctx, groupRequest, data.CustomFields, state.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, groupRequest, data.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, groupRequest, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "circuits.circuitgroup", groupRequest, &resp.Diagnostics)
+	netboxlookup.ResolveCustomFieldReferences(ctx, r.client, groupRequest, &resp.Diagnostics)
 
 	if resp.Diagnostics.HasError() {
 		return
@@ -288,6 +326,10 @@ func (r *CircuitGroupResource) Update(ctx context.Context, req resource.UpdateRe
 
 // Delete deletes the resource and removes the Terraform state on success.
 func (r *CircuitGroupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data CircuitGroupResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -356,7 +398,9 @@ func (r *CircuitGroupResource) ImportState(ctx context.Context, req resource.Imp
 		}
 
 		var data CircuitGroupResourceModel
-		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, group.HasTags(), group.GetTags(), data.Tags)
+		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, r.client, group.HasTags(), group.GetTags(), data.Tags)
+		data.TagsAll = utils.TagsAllFromAPI(ctx, group.GetTags())
+		data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, group.GetCustomFields(), &resp.Diagnostics)
 		if group.HasTenant() && group.Tenant.IsSet() && group.Tenant.Get() != nil {
 			tenant := group.Tenant.Get()
 			data.Tenant = types.StringValue(fmt.Sprintf("%d", tenant.GetId()))
@@ -382,6 +426,7 @@ func (r *CircuitGroupResource) ImportState(ctx context.Context, req resource.Imp
 		}
 		if parsed.HasCustomFields {
 			data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, group.GetCustomFields(), &resp.Diagnostics)
+			data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, group.GetCustomFields(), &resp.Diagnostics)
 		} else {
 			data.CustomFields = types.SetNull(utils.GetCustomFieldsAttributeType().ElemType)
 		}
@@ -433,12 +478,15 @@ func (r *CircuitGroupResource) mapResponseToState(ctx context.Context, group *ne
 	}
 
 	// Tags (slug list)
-	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, group.HasTags(), group.GetTags(), data.Tags)
+	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, group.HasTags(), group.GetTags(), data.Tags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, group.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, group.GetCustomFields(), diags)
 	if diags.HasError() {
 		return
 	}
 
 	if group.HasCustomFields() {
 		data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, group.GetCustomFields(), diags)
+		data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, group.GetCustomFields(), diags)
 	}
 }
@@ -33,6 +33,7 @@ var (
 	_ resource.ResourceWithConfigure   = &CircuitResource{}
 	_ resource.ResourceWithImportState = &CircuitResource{}
 	_ resource.ResourceWithIdentity    = &CircuitResource{}
+	_ resource.ResourceWithModifyPlan  = &CircuitResource{}
 )
 
 // NewCircuitResource returns a new circuit resource.
@@ -47,20 +48,24 @@ type CircuitResource struct {
 
 // CircuitResourceModel describes the circuit resource data model.
 type CircuitResourceModel struct {
-	ID              types.String `tfsdk:"id"`
-	Cid             types.String `tfsdk:"cid"`
-	CircuitProvider types.String `tfsdk:"circuit_provider"`
-	ProviderAccount types.String `tfsdk:"provider_account"`
-	Type            types.String `tfsdk:"type"`
-	Status          types.String `tfsdk:"status"`
-	Tenant          types.String `tfsdk:"tenant"`
-	InstallDate     types.String `tfsdk:"install_date"`
-	TerminationDate types.String `tfsdk:"termination_date"`
-	CommitRate      types.Int64  `tfsdk:"commit_rate"`
-	Description     types.String `tfsdk:"description"`
-	Comments        types.String `tfsdk:"comments"`
-	Tags            types.Set    `tfsdk:"tags"`
-	CustomFields    types.Set    `tfsdk:"custom_fields"`
+	ID              types.String  `tfsdk:"id"`
+	Cid             types.String  `tfsdk:"cid"`
+	CircuitProvider types.String  `tfsdk:"circuit_provider"`
+	ProviderAccount types.String  `tfsdk:"provider_account"`
+	Type            types.String  `tfsdk:"type"`
+	Status          types.String  `tfsdk:"status"`
+	Tenant          types.String  `tfsdk:"tenant"`
+	InstallDate     types.String  `tfsdk:"install_date"`
+	TerminationDate types.String  `tfsdk:"termination_date"`
+	CommitRate      types.Int64   `tfsdk:"commit_rate"`
+	Description     types.String  `tfsdk:"description"`
+	Comments        types.String  `tfsdk:"comments"`
+	Tags            types.Set     `tfsdk:"tags"`
+	CustomFields    types.Set     `tfsdk:"custom_fields"`
+	CustomFieldsMap types.Dynamic `tfsdk:"custom_fields_map"`
+	TagsAll         types.Set     `tfsdk:"tags_all"`
+	CustomFieldsAll types.Map     `tfsdk:"custom_fields_all"`
+	Branch          types.String  `tfsdk:"branch"`
 }
 
 // Metadata returns the resource type name.
@@ -144,6 +149,10 @@ func (r *CircuitResource) Schema(ctx context.Context, req resource.SchemaRequest
 	// Add metadata attributes (slug list tags, custom_fields)
 	resp.Schema.Attributes["tags"] = nbschema.TagsSlugAttribute()
 	resp.Schema.Attributes["custom_fields"] = nbschema.CustomFieldsAttribute()
+	resp.Schema.Attributes["custom_fields_map"] = nbschema.CustomFieldsMapAttribute()
+	resp.Schema.Attributes["tags_all"] = nbschema.TagsAllAttribute()
+	resp.Schema.Attributes["custom_fields_all"] = nbschema.CustomFieldsAllAttribute()
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 func (r *CircuitResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
@@ -166,8 +175,21 @@ func (r *CircuitResource) Configure(ctx context.Context, req resource.ConfigureR
 	r.client = client
 }
 
+// ModifyPlan validates the configured custom fields against their definitions in NetBox
+// and plans tags_all and custom_fields_all.
+func (r *CircuitResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	utils.ValidateCustomFieldsPlan(ctx, r.client, "circuits.circuit", req, resp)
+	utils.PlanEffectiveMetadata(ctx, r.client, "circuits.circuit", req, resp)
+}
+
 // Create creates a new circuit resource.
 func (r *CircuitResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	defer journalCreate(ctx, r.client, "circuits.circuit", req, resp)
+
 	var data CircuitResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -212,6 +234,7 @@ func (r *CircuitResource) Create(ctx context.Context, req resource.CreateRequest
 
 // Read reads the circuit resource.
 func (r *CircuitResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data CircuitResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -266,6 +289,13 @@ func (r *CircuitResource) Read(ctx context.Context, req resource.ReadRequest, re
 
 // Update updates the circuit resource.
 func (r *CircuitResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	ctx = withPriorTags(ctx, req.State)
+	defer journalUpdate(ctx, r.client, "circuits.circuit", req, resp)
+
 	var plan CircuitResourceModel
 	var state CircuitResourceModel
 
@@ -319,6 +349,10 @@ func (r *CircuitResource) Update(ctx context.Context, req resource.UpdateRequest
 
 // Delete deletes the circuit resource.
 func (r *CircuitResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data CircuitResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -388,7 +422,9 @@ func (r *CircuitResource) ImportState(ctx context.Context, req resource.ImportSt
 		}
 
 		var data CircuitResourceModel
-		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, circuit.HasTags(), circuit.GetTags(), data.Tags)
+		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, r.client, circuit.HasTags(), circuit.GetTags(), data.Tags)
+		data.TagsAll = utils.TagsAllFromAPI(ctx, circuit.GetTags())
+		data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, circuit.GetCustomFields(), &resp.Diagnostics)
 		if parsed.HasCustomFields {
 			if len(parsed.CustomFields) == 0 {
 				data.CustomFields = types.SetValueMust(utils.GetCustomFieldsAttributeType().ElemType, []attr.Value{})
@@ -410,6 +446,7 @@ func (r *CircuitResource) ImportState(ctx context.Context, req resource.ImportSt
 		}
 		if parsed.HasCustomFields {
 			data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, circuit.GetCustomFields(), &resp.Diagnostics)
+			data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, circuit.GetCustomFields(), &resp.Diagnostics)
 		} else {
 			data.CustomFields = types.SetNull(utils.GetCustomFieldsAttributeType().ElemType)
 		}
@@ -539,9 +576,17 @@ func (r *CircuitResource) buildCircuitRequest(ctx context.Context, data *Circuit
 	if state != nil {
 		// Update: merge plan custom fields with existing state custom fields
 		utils.ApplyCustomFieldsWithMerge(ctx, circuitReq, data.CustomFields, state.CustomFields, &diags)
+		utils.ApplyCustomFieldsMap(ctx, r.client, circuitReq, data.CustomFieldsMap, &diags)
+		utils.ApplyProviderTags(ctx, r.client, circuitReq, &diags)
+		utils.ApplyProviderCustomFields(ctx, r.client, "circuits.circuit", circuitReq, &diags)
+		netboxlookup.ResolveCustomFieldReferences(ctx, r.client, circuitReq, &diags)
 	} else {
 		// Create: apply plan custom fields directly
 		utils.ApplyCustomFields(ctx, circuitReq, data.CustomFields, &diags)
+		utils.ApplyCustomFieldsMap(ctx, r.client, circuitReq, data.CustomFieldsMap, &diags)
+		utils.ApplyProviderTags(ctx, r.client, circuitReq, &diags)
+		utils.ApplyProviderCustomFields(ctx, r.client, "circuits.circuit", circuitReq, &diags)
+		netboxlookup.ResolveCustomFieldReferences(ctx, r.client, circuitReq, &diags)
 	}
 
 	if diags.HasError() {
@@ -631,10 +676,13 @@ func (r *CircuitResource) mapCircuitToState(ctx context.Context, circuit *netbox
 	}
 
 	// Tags (slug list)
-	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, circuit.HasTags(), circuit.GetTags(), data.Tags)
+	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, circuit.HasTags(), circuit.GetTags(), data.Tags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, circuit.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, circuit.GetCustomFields(), diags)
 	if diags.HasError() {
 		return
 	}
 
 	data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, circuit.GetCustomFields(), diags)
+	data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, circuit.GetCustomFields(), diags)
 }
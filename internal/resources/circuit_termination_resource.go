@@ -32,6 +32,7 @@ var (
 	_ resource.ResourceWithConfigure   = &CircuitTerminationResource{}
 	_ resource.ResourceWithImportState = &CircuitTerminationResource{}
 	_ resource.ResourceWithIdentity    = &CircuitTerminationResource{}
+	_ resource.ResourceWithModifyPlan  = &CircuitTerminationResource{}
 )
 
 // NewCircuitTerminationResource returns a new Circuit Termination resource.
@@ -46,19 +47,23 @@ type CircuitTerminationResource struct {
 
 // CircuitTerminationResourceModel describes the resource data model.
 type CircuitTerminationResourceModel struct {
-	ID              types.String `tfsdk:"id"`
-	Circuit         types.String `tfsdk:"circuit"`
-	TermSide        types.String `tfsdk:"term_side"`
-	Site            types.String `tfsdk:"site"`
-	ProviderNetwork types.String `tfsdk:"provider_network"`
-	PortSpeed       types.Int64  `tfsdk:"port_speed"`
-	UpstreamSpeed   types.Int64  `tfsdk:"upstream_speed"`
-	XconnectID      types.String `tfsdk:"xconnect_id"`
-	PPInfo          types.String `tfsdk:"pp_info"`
-	Description     types.String `tfsdk:"description"`
-	MarkConnected   types.Bool   `tfsdk:"mark_connected"`
-	Tags            types.Set    `tfsdk:"tags"`
-	CustomFields    types.Set    `tfsdk:"custom_fields"`
+	ID              types.String  `tfsdk:"id"`
+	Circuit         types.String  `tfsdk:"circuit"`
+	TermSide        types.String  `tfsdk:"term_side"`
+	Site            types.String  `tfsdk:"site"`
+	ProviderNetwork types.String  `tfsdk:"provider_network"`
+	PortSpeed       types.Int64   `tfsdk:"port_speed"`
+	UpstreamSpeed   types.Int64   `tfsdk:"upstream_speed"`
+	XconnectID      types.String  `tfsdk:"xconnect_id"`
+	PPInfo          types.String  `tfsdk:"pp_info"`
+	Description     types.String  `tfsdk:"description"`
+	MarkConnected   types.Bool    `tfsdk:"mark_connected"`
+	Tags            types.Set     `tfsdk:"tags"`
+	CustomFields    types.Set     `tfsdk:"custom_fields"`
+	CustomFieldsMap types.Dynamic `tfsdk:"custom_fields_map"`
+	TagsAll         types.Set     `tfsdk:"tags_all"`
+	CustomFieldsAll types.Map     `tfsdk:"custom_fields_all"`
+	Branch          types.String  `tfsdk:"branch"`
 }
 
 // Metadata returns the resource type name.
@@ -128,6 +133,10 @@ func (r *CircuitTerminationResource) Schema(ctx context.Context, req resource.Sc
 	// Add metadata attributes (slug list tags, custom_fields)
 	resp.Schema.Attributes["tags"] = nbschema.TagsSlugAttribute()
 	resp.Schema.Attributes["custom_fields"] = nbschema.CustomFieldsAttribute()
+	resp.Schema.Attributes["custom_fields_map"] = nbschema.CustomFieldsMapAttribute()
+	resp.Schema.Attributes["tags_all"] = nbschema.TagsAllAttribute()
+	resp.Schema.Attributes["custom_fields_all"] = nbschema.CustomFieldsAllAttribute()
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 func (r *CircuitTerminationResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
@@ -151,8 +160,21 @@ func (r *CircuitTerminationResource) Configure(ctx context.Context, req resource
 	r.client = client
 }
 
+// ModifyPlan validates the configured custom fields against their definitions in NetBox
+// and plans tags_all and custom_fields_all.
+func (r *CircuitTerminationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	utils.ValidateCustomFieldsPlan(ctx, r.client, "circuits.circuittermination", req, resp)
+	utils.PlanEffectiveMetadata(ctx, r.client, "circuits.circuittermination", req, resp)
+}
+
 // Create creates a new circuit termination resource.
 func (r *CircuitTerminationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	defer journalCreate(ctx, r.client, "circuits.circuittermination", req, resp)
+
 	var data CircuitTerminationResourceModel
 
 	// Read Terraform plan data into the model
@@ -196,6 +218,7 @@ func (r *CircuitTerminationResource) Create(ctx context.Context, req resource.Cr
 
 // Read reads the circuit termination resource.
 func (r *CircuitTerminationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data CircuitTerminationResourceModel
 
 	// Read Terraform prior state data into the model
@@ -247,6 +270,13 @@ func (r *CircuitTerminationResource) Read(ctx context.Context, req resource.Read
 
 // Update updates the circuit termination resource.
 func (r *CircuitTerminationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	ctx = withPriorTags(ctx, req.State)
+	defer journalUpdate(ctx, r.client, "circuits.circuittermination", req, resp)
+
 	var state, data CircuitTerminationResourceModel
 
 	// Read Terraform plan and state data into the models
@@ -298,6 +328,10 @@ func (r *CircuitTerminationResource) Update(ctx context.Context, req resource.Up
 
 // Delete deletes the circuit termination resource.
 func (r *CircuitTerminationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data CircuitTerminationResourceModel
 
 	// Read Terraform prior state data into the model
@@ -382,7 +416,9 @@ func (r *CircuitTerminationResource) ImportState(ctx context.Context, req resour
 		if pn, ok := termination.GetProviderNetworkOk(); ok && pn != nil && pn.Id != 0 {
 			data.ProviderNetwork = types.StringValue(fmt.Sprintf("%d", pn.Id))
 		}
-		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, len(termination.Tags) > 0, termination.Tags, data.Tags)
+		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, r.client, len(termination.Tags) > 0, termination.Tags, data.Tags)
+		data.TagsAll = utils.TagsAllFromAPI(ctx, termination.Tags)
+		data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, termination.CustomFields, &resp.Diagnostics)
 		if parsed.HasCustomFields {
 			if len(parsed.CustomFields) == 0 {
 				data.CustomFields = types.SetValueMust(utils.GetCustomFieldsAttributeType().ElemType, []attr.Value{})
@@ -404,6 +440,7 @@ func (r *CircuitTerminationResource) ImportState(ctx context.Context, req resour
 		}
 		if parsed.HasCustomFields {
 			data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, termination.CustomFields, &resp.Diagnostics)
+			data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, termination.CustomFields, &resp.Diagnostics)
 		} else {
 			data.CustomFields = types.SetNull(utils.GetCustomFieldsAttributeType().ElemType)
 		}
@@ -534,8 +571,16 @@ func (r *CircuitTerminationResource) buildCreateRequest(ctx context.Context, dat
 	// Handle custom fields with merge-aware logic
 	if state != nil {
 		utils.ApplyCustomFieldsWithMerge(ctx, createReq, data.CustomFields, state.CustomFields, &diags)
+		utils.ApplyCustomFieldsMap(ctx, r.client, createReq, data.CustomFieldsMap, &diags)
+		utils.ApplyProviderTags(ctx, r.client, createReq, &diags)
+		utils.ApplyProviderCustomFields(ctx, r.client, "circuits.circuittermination", createReq, &diags)
+		netboxlookup.ResolveCustomFieldReferences(ctx, r.client, createReq, &diags)
 	} else {
 		utils.ApplyCustomFields(ctx, createReq, data.CustomFields, &diags)
+		utils.ApplyCustomFieldsMap(ctx, r.client, createReq, data.CustomFieldsMap, &diags)
+		utils.ApplyProviderTags(ctx, r.client, createReq, &diags)
+		utils.ApplyProviderCustomFields(ctx, r.client, "circuits.circuittermination", createReq, &diags)
+		netboxlookup.ResolveCustomFieldReferences(ctx, r.client, createReq, &diags)
 	}
 	return createReq, diags
 }
@@ -607,9 +652,12 @@ func (r *CircuitTerminationResource) mapResponseToModel(ctx context.Context, ter
 	}
 
 	// Populate tags using slug list format
-	data.Tags = utils.PopulateTagsSlugFromAPI(ctx, len(termination.Tags) > 0, termination.Tags, data.Tags)
+	data.Tags = utils.PopulateTagsSlugFromAPI(ctx, r.client, len(termination.Tags) > 0, termination.Tags, data.Tags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, termination.Tags)
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, termination.CustomFields, diags)
 	if termination.HasCustomFields() {
 		data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, termination.CustomFields, diags)
+		data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, termination.CustomFields, diags)
 	}
 }
 
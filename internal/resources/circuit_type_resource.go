@@ -10,6 +10,7 @@ import (
 	"regexp"
 
 	"github.com/bab3l/go-netbox"
+	"github.com/bab3l/terraform-provider-netbox/internal/netboxlookup"
 	nbschema "github.com/bab3l/terraform-provider-netbox/internal/schema"
 	"github.com/bab3l/terraform-provider-netbox/internal/utils"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
@@ -31,6 +32,7 @@ var (
 	_ resource.ResourceWithConfigure   = &CircuitTypeResource{}
 	_ resource.ResourceWithImportState = &CircuitTypeResource{}
 	_ resource.ResourceWithIdentity    = &CircuitTypeResource{}
+	_ resource.ResourceWithModifyPlan  = &CircuitTypeResource{}
 )
 
 // NewCircuitTypeResource returns a new circuit type resource.
@@ -45,13 +47,17 @@ type CircuitTypeResource struct {
 
 // CircuitTypeResourceModel describes the circuit type resource data model.
 type CircuitTypeResourceModel struct {
-	ID           types.String `tfsdk:"id"`
-	Name         types.String `tfsdk:"name"`
-	Slug         types.String `tfsdk:"slug"`
-	Description  types.String `tfsdk:"description"`
-	Color        types.String `tfsdk:"color"`
-	Tags         types.Set    `tfsdk:"tags"`
-	CustomFields types.Set    `tfsdk:"custom_fields"`
+	ID              types.String  `tfsdk:"id"`
+	Name            types.String  `tfsdk:"name"`
+	Slug            types.String  `tfsdk:"slug"`
+	Description     types.String  `tfsdk:"description"`
+	Color           types.String  `tfsdk:"color"`
+	Tags            types.Set     `tfsdk:"tags"`
+	CustomFields    types.Set     `tfsdk:"custom_fields"`
+	CustomFieldsMap types.Dynamic `tfsdk:"custom_fields_map"`
+	TagsAll         types.Set     `tfsdk:"tags_all"`
+	CustomFieldsAll types.Map     `tfsdk:"custom_fields_all"`
+	Branch          types.String  `tfsdk:"branch"`
 }
 
 // Metadata returns the resource type name.
@@ -108,6 +114,10 @@ func (r *CircuitTypeResource) Schema(ctx context.Context, req resource.SchemaReq
 	// Add metadata attributes (slug list tags, custom_fields)
 	resp.Schema.Attributes["tags"] = nbschema.TagsSlugAttribute()
 	resp.Schema.Attributes["custom_fields"] = nbschema.CustomFieldsAttribute()
+	resp.Schema.Attributes["custom_fields_map"] = nbschema.CustomFieldsMapAttribute()
+	resp.Schema.Attributes["tags_all"] = nbschema.TagsAllAttribute()
+	resp.Schema.Attributes["custom_fields_all"] = nbschema.CustomFieldsAllAttribute()
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 func (r *CircuitTypeResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
@@ -130,8 +140,21 @@ func (r *CircuitTypeResource) Configure(ctx context.Context, req resource.Config
 	r.client = client
 }
 
+// ModifyPlan validates the configured custom fields against their definitions in NetBox
+// and plans tags_all and custom_fields_all.
+func (r *CircuitTypeResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	utils.ValidateCustomFieldsPlan(ctx, r.client, "circuits.circuittype", req, resp)
+	utils.PlanEffectiveMetadata(ctx, r.client, "circuits.circuittype", req, resp)
+}
+
 // Create creates a new circuit type resource.
 func (r *CircuitTypeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	defer journalCreate(ctx, r.client, "circuits.circuittype", req, resp)
+
 	var data CircuitTypeResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -156,6 +179,13 @@ func (r *CircuitTypeResource) Create(ctx context.Context, req resource.CreateReq
 		return
 	}
 	utils.ApplyCustomFields(ctx, &createReq, data.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, &createReq, data.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, &createReq, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "circuits.circuittype", &createReq, &resp.Diagnostics)
+	netboxlookup.ResolveCustomFieldReferences(ctx, r.client, &createReq, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	tflog.Debug(ctx, "Creating circuit type", map[string]interface{}{
 		"name": data.Name.ValueString(),
 		"slug": data.Slug.ValueString(),
@@ -186,6 +216,7 @@ func (r *CircuitTypeResource) Create(ctx context.Context, req resource.CreateReq
 
 // Read reads the circuit type resource.
 func (r *CircuitTypeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data CircuitTypeResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -231,6 +262,13 @@ func (r *CircuitTypeResource) Read(ctx context.Context, req resource.ReadRequest
 
 // Update updates the circuit type resource.
 func (r *CircuitTypeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	ctx = withPriorTags(ctx, req.State)
+	defer journalUpdate(ctx, r.client, "circuits.circuittype", req, resp)
+
 	var state, data CircuitTypeResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -266,6 +304,13 @@ func (r *CircuitTypeResource) Update(ctx context.Context, req resource.UpdateReq
 
 	// Handle custom fields with merge-aware logic
 	utils.ApplyCustomFieldsWithMerge(ctx, &updateReq, data.CustomFields, state.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, &updateReq, data.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, &updateReq, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "circuits.circuittype", &updateReq, &resp.Diagnostics)
+	netboxlookup.ResolveCustomFieldReferences(ctx, r.client, &updateReq, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	tflog.Debug(ctx, "Updating circuit type", map[string]interface{}{
 		"id":   id,
 		"name": data.Name.ValueString(),
@@ -297,6 +342,10 @@ func (r *CircuitTypeResource) Update(ctx context.Context, req resource.UpdateReq
 
 // Delete deletes the circuit type resource.
 func (r *CircuitTypeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data CircuitTypeResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -365,7 +414,9 @@ func (r *CircuitTypeResource) ImportState(ctx context.Context, req resource.Impo
 		}
 
 		var data CircuitTypeResourceModel
-		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, circuitType.HasTags(), circuitType.GetTags(), data.Tags)
+		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, r.client, circuitType.HasTags(), circuitType.GetTags(), data.Tags)
+		data.TagsAll = utils.TagsAllFromAPI(ctx, circuitType.GetTags())
+		data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, circuitType.GetCustomFields(), &resp.Diagnostics)
 		if parsed.HasCustomFields {
 			if len(parsed.CustomFields) == 0 {
 				data.CustomFields = types.SetValueMust(utils.GetCustomFieldsAttributeType().ElemType, []attr.Value{})
@@ -387,6 +438,7 @@ func (r *CircuitTypeResource) ImportState(ctx context.Context, req resource.Impo
 		}
 		if parsed.HasCustomFields {
 			data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, circuitType.GetCustomFields(), &resp.Diagnostics)
+			data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, circuitType.GetCustomFields(), &resp.Diagnostics)
 		} else {
 			data.CustomFields = types.SetNull(utils.GetCustomFieldsAttributeType().ElemType)
 		}
@@ -434,12 +486,15 @@ func (r *CircuitTypeResource) mapCircuitTypeToState(ctx context.Context, circuit
 	}
 
 	// Populate tags using slug list format
-	data.Tags = utils.PopulateTagsSlugFromAPI(ctx, circuitType.HasTags(), circuitType.GetTags(), data.Tags)
+	data.Tags = utils.PopulateTagsSlugFromAPI(ctx, r.client, circuitType.HasTags(), circuitType.GetTags(), data.Tags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, circuitType.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, circuitType.GetCustomFields(), diags)
 	if diags.HasError() {
 		return
 	}
 
 	if circuitType.HasCustomFields() {
 		data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, circuitType.GetCustomFields(), diags)
+		data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, circuitType.GetCustomFields(), diags)
 	}
 }
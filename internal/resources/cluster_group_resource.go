@@ -7,6 +7,7 @@ import (
 	"net/http"
 
 	"github.com/bab3l/go-netbox"
+	"github.com/bab3l/terraform-provider-netbox/internal/netboxlookup"
 	nbschema "github.com/bab3l/terraform-provider-netbox/internal/schema"
 	"github.com/bab3l/terraform-provider-netbox/internal/utils"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
@@ -21,6 +22,7 @@ var (
 	_ resource.Resource                = &ClusterGroupResource{}
 	_ resource.ResourceWithImportState = &ClusterGroupResource{}
 	_ resource.ResourceWithIdentity    = &ClusterGroupResource{}
+	_ resource.ResourceWithModifyPlan  = &ClusterGroupResource{}
 )
 
 func NewClusterGroupResource() resource.Resource {
@@ -37,12 +39,16 @@ func (r *ClusterGroupResource) GetClient() *netbox.APIClient {
 }
 
 type ClusterGroupResourceModel struct {
-	ID           types.String `tfsdk:"id"`
-	Name         types.String `tfsdk:"name"`
-	Slug         types.String `tfsdk:"slug"`
-	Description  types.String `tfsdk:"description"`
-	Tags         types.Set    `tfsdk:"tags"`
-	CustomFields types.Set    `tfsdk:"custom_fields"`
+	ID              types.String  `tfsdk:"id"`
+	Name            types.String  `tfsdk:"name"`
+	Slug            types.String  `tfsdk:"slug"`
+	Description     types.String  `tfsdk:"description"`
+	Tags            types.Set     `tfsdk:"tags"`
+	CustomFields    types.Set     `tfsdk:"custom_fields"`
+	CustomFieldsMap types.Dynamic `tfsdk:"custom_fields_map"`
+	TagsAll         types.Set     `tfsdk:"tags_all"`
+	CustomFieldsAll types.Map     `tfsdk:"custom_fields_all"`
+	Branch          types.String  `tfsdk:"branch"`
 }
 
 func (r *ClusterGroupResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -65,6 +71,10 @@ func (r *ClusterGroupResource) Schema(ctx context.Context, req resource.SchemaRe
 	// Add metadata attributes (slug list tags, custom_fields)
 	resp.Schema.Attributes["tags"] = nbschema.TagsSlugAttribute()
 	resp.Schema.Attributes["custom_fields"] = nbschema.CustomFieldsAttribute()
+	resp.Schema.Attributes["custom_fields_map"] = nbschema.CustomFieldsMapAttribute()
+	resp.Schema.Attributes["tags_all"] = nbschema.TagsAllAttribute()
+	resp.Schema.Attributes["custom_fields_all"] = nbschema.CustomFieldsAllAttribute()
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 func (r *ClusterGroupResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
@@ -86,7 +96,20 @@ func (r *ClusterGroupResource) Configure(ctx context.Context, req resource.Confi
 	r.client = client
 }
 
+// ModifyPlan validates the configured custom fields against their definitions in NetBox
+// and plans tags_all and custom_fields_all.
+func (r *ClusterGroupResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	utils.ValidateCustomFieldsPlan(ctx, r.client, "virtualization.clustergroup", req, resp)
+	utils.PlanEffectiveMetadata(ctx, r.client, "virtualization.clustergroup", req, resp)
+}
+
 func (r *ClusterGroupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	defer journalCreate(ctx, r.client, "virtualization.clustergroup", req, resp)
+
 	var data ClusterGroupResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -112,6 +135,13 @@ func (r *ClusterGroupResource) Create(ctx context.Context, req resource.CreateRe
 		return
 	}
 	utils.ApplyCustomFields(ctx, &clusterGroupRequest, data.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, &clusterGroupRequest, data.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, &clusterGroupRequest, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "virtualization.clustergroup", &clusterGroupRequest, &resp.Diagnostics)
+	netboxlookup.ResolveCustomFieldReferences(ctx, r.client, &clusterGroupRequest, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Store plan values for filter-to-owned pattern
 	planTags := data.Tags
@@ -149,8 +179,11 @@ func (r *ClusterGroupResource) Create(ctx context.Context, req resource.CreateRe
 	}
 
 	// Apply filter-to-owned pattern for tags and custom_fields
-	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, clusterGroup.HasTags(), clusterGroup.GetTags(), planTags)
+	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, clusterGroup.HasTags(), clusterGroup.GetTags(), planTags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, clusterGroup.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, clusterGroup.GetCustomFields(), &resp.Diagnostics)
 	data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, planCustomFields, clusterGroup.GetCustomFields(), &resp.Diagnostics)
+	data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, clusterGroup.GetCustomFields(), &resp.Diagnostics)
 	utils.SetIdentityCustomFields(ctx, resp.Identity, types.StringValue(data.ID.ValueString()), data.CustomFields, &resp.Diagnostics)
 
 	tflog.Trace(ctx, "created a cluster group resource")
@@ -158,6 +191,7 @@ func (r *ClusterGroupResource) Create(ctx context.Context, req resource.CreateRe
 }
 
 func (r *ClusterGroupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data ClusterGroupResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -193,14 +227,24 @@ func (r *ClusterGroupResource) Read(ctx context.Context, req resource.ReadReques
 	}
 
 	// Apply filter-to-owned pattern for tags and custom_fields
-	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, clusterGroup.HasTags(), clusterGroup.GetTags(), stateTags)
+	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, clusterGroup.HasTags(), clusterGroup.GetTags(), stateTags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, clusterGroup.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, clusterGroup.GetCustomFields(), &resp.Diagnostics)
 	data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, stateCustomFields, clusterGroup.GetCustomFields(), &resp.Diagnostics)
+	data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, clusterGroup.GetCustomFields(), &resp.Diagnostics)
 	utils.SetIdentityCustomFields(ctx, resp.Identity, types.StringValue(data.ID.ValueString()), data.CustomFields, &resp.Diagnostics)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *ClusterGroupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	ctx = withPriorTags(ctx, req.State)
+	defer journalUpdate(ctx, r.client, "virtualization.clustergroup", req, resp)
+
 	var state, plan ClusterGroupResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -233,6 +277,13 @@ func (r *ClusterGroupResource) Update(ctx context.Context, req resource.UpdateRe
 		return
 	}
 	utils.ApplyCustomFieldsWithMerge(ctx, &clusterGroupRequest, plan.CustomFields, state.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, &clusterGroupRequest, plan.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, &clusterGroupRequest, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "virtualization.clustergroup", &clusterGroupRequest, &resp.Diagnostics)
+	netboxlookup.ResolveCustomFieldReferences(ctx, r.client, &clusterGroupRequest, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Update via API
 	clusterGroup, httpResp, err := r.client.VirtualizationAPI.VirtualizationClusterGroupsUpdate(ctx, clusterGroupIDInt).ClusterGroupRequest(clusterGroupRequest).Execute()
@@ -250,14 +301,21 @@ func (r *ClusterGroupResource) Update(ctx context.Context, req resource.UpdateRe
 	}
 
 	// Apply filter-to-owned pattern for tags and custom_fields
-	plan.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, clusterGroup.HasTags(), clusterGroup.GetTags(), plan.Tags)
+	plan.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, clusterGroup.HasTags(), clusterGroup.GetTags(), plan.Tags)
+	plan.TagsAll = utils.TagsAllFromAPI(ctx, clusterGroup.GetTags())
+	plan.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, plan.CustomFieldsAll, clusterGroup.GetCustomFields(), &resp.Diagnostics)
 	plan.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, plan.CustomFields, clusterGroup.GetCustomFields(), &resp.Diagnostics)
+	plan.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, plan.CustomFieldsMap, clusterGroup.GetCustomFields(), &resp.Diagnostics)
 	utils.SetIdentityCustomFields(ctx, resp.Identity, types.StringValue(plan.ID.ValueString()), plan.CustomFields, &resp.Diagnostics)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
 func (r *ClusterGroupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data ClusterGroupResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -312,7 +370,9 @@ func (r *ClusterGroupResource) ImportState(ctx context.Context, req resource.Imp
 		}
 
 		var data ClusterGroupResourceModel
-		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, clusterGroup.HasTags(), clusterGroup.GetTags(), data.Tags)
+		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, r.client, clusterGroup.HasTags(), clusterGroup.GetTags(), data.Tags)
+		data.TagsAll = utils.TagsAllFromAPI(ctx, clusterGroup.GetTags())
+		data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, clusterGroup.GetCustomFields(), &resp.Diagnostics)
 		if parsed.HasCustomFields {
 			if len(parsed.CustomFields) == 0 {
 				data.CustomFields = types.SetValueMust(utils.GetCustomFieldsAttributeType().ElemType, []attr.Value{})
@@ -332,10 +392,13 @@ func (r *ClusterGroupResource) ImportState(ctx context.Context, req resource.Imp
 		if resp.Diagnostics.HasError() {
 			return
 		}
-		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, clusterGroup.HasTags(), clusterGroup.GetTags(), data.Tags)
+		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, r.client, clusterGroup.HasTags(), clusterGroup.GetTags(), data.Tags)
+		data.TagsAll = utils.TagsAllFromAPI(ctx, clusterGroup.GetTags())
+		data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, clusterGroup.GetCustomFields(), &resp.Diagnostics)
 
 		if parsed.HasCustomFields {
 			data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, clusterGroup.GetCustomFields(), &resp.Diagnostics)
+			data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, clusterGroup.GetCustomFields(), &resp.Diagnostics)
 		} else {
 			data.CustomFields = types.SetNull(utils.GetCustomFieldsAttributeType().ElemType)
 		}
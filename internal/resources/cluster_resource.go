@@ -30,6 +30,7 @@ var (
 	_ resource.ResourceWithConfigure   = &ClusterResource{}
 	_ resource.ResourceWithImportState = &ClusterResource{}
 	_ resource.ResourceWithIdentity    = &ClusterResource{}
+	_ resource.ResourceWithModifyPlan  = &ClusterResource{}
 )
 
 // NewClusterResource returns a new Cluster resource.
@@ -44,17 +45,21 @@ type ClusterResource struct {
 
 // ClusterResourceModel describes the resource data model.
 type ClusterResourceModel struct {
-	ID           types.String `tfsdk:"id"`
-	Name         types.String `tfsdk:"name"`
-	Type         types.String `tfsdk:"type"`
-	Group        types.String `tfsdk:"group"`
-	Status       types.String `tfsdk:"status"`
-	Tenant       types.String `tfsdk:"tenant"`
-	Site         types.String `tfsdk:"site"`
-	Description  types.String `tfsdk:"description"`
-	Comments     types.String `tfsdk:"comments"`
-	Tags         types.Set    `tfsdk:"tags"`
-	CustomFields types.Set    `tfsdk:"custom_fields"`
+	ID              types.String  `tfsdk:"id"`
+	Name            types.String  `tfsdk:"name"`
+	Type            types.String  `tfsdk:"type"`
+	Group           types.String  `tfsdk:"group"`
+	Status          types.String  `tfsdk:"status"`
+	Tenant          types.String  `tfsdk:"tenant"`
+	Site            types.String  `tfsdk:"site"`
+	Description     types.String  `tfsdk:"description"`
+	Comments        types.String  `tfsdk:"comments"`
+	Tags            types.Set     `tfsdk:"tags"`
+	CustomFields    types.Set     `tfsdk:"custom_fields"`
+	CustomFieldsMap types.Dynamic `tfsdk:"custom_fields_map"`
+	TagsAll         types.Set     `tfsdk:"tags_all"`
+	CustomFieldsAll types.Map     `tfsdk:"custom_fields_all"`
+	Branch          types.String  `tfsdk:"branch"`
 }
 
 // Metadata returns the resource type name.
@@ -106,6 +111,10 @@ func (r *ClusterResource) Schema(ctx context.Context, req resource.SchemaRequest
 	// Add metadata attributes (slug list tags, custom_fields)
 	resp.Schema.Attributes["tags"] = nbschema.TagsSlugAttribute()
 	resp.Schema.Attributes["custom_fields"] = nbschema.CustomFieldsAttribute()
+	resp.Schema.Attributes["custom_fields_map"] = nbschema.CustomFieldsMapAttribute()
+	resp.Schema.Attributes["tags_all"] = nbschema.TagsAllAttribute()
+	resp.Schema.Attributes["custom_fields_all"] = nbschema.CustomFieldsAllAttribute()
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 func (r *ClusterResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
@@ -234,6 +243,10 @@ func (r *ClusterResource) buildClusterRequest(ctx context.Context, data *Cluster
 
 	// Apply custom fields
 	utils.ApplyCustomFields(ctx, clusterRequest, data.CustomFields, diags)
+	utils.ApplyCustomFieldsMap(ctx, r.client, clusterRequest, data.CustomFieldsMap, diags)
+	utils.ApplyProviderTags(ctx, r.client, clusterRequest, diags)
+	utils.ApplyProviderCustomFields(ctx, r.client, "virtualization.cluster", clusterRequest, diags)
+	netboxlookup.ResolveCustomFieldReferences(ctx, r.client, clusterRequest, diags)
 	return clusterRequest
 }
 
@@ -287,11 +300,28 @@ func (r *ClusterResource) buildClusterRequestWithState(ctx context.Context, plan
 
 	// Apply custom fields with merge (merge-aware)
 	utils.ApplyCustomFieldsWithMerge(ctx, clusterRequest, plan.CustomFields, state.CustomFields, diags)
+	utils.ApplyCustomFieldsMap(ctx, r.client, clusterRequest, plan.CustomFieldsMap, diags)
+	utils.ApplyProviderTags(ctx, r.client, clusterRequest, diags)
+	utils.ApplyProviderCustomFields(ctx, r.client, "virtualization.cluster", clusterRequest, diags)
+	netboxlookup.ResolveCustomFieldReferences(ctx, r.client, clusterRequest, diags)
 	return clusterRequest
 }
 
+// ModifyPlan validates the configured custom fields against their definitions in NetBox
+// and plans tags_all and custom_fields_all.
+func (r *ClusterResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	utils.ValidateCustomFieldsPlan(ctx, r.client, "virtualization.cluster", req, resp)
+	utils.PlanEffectiveMetadata(ctx, r.client, "virtualization.cluster", req, resp)
+}
+
 // Create creates a new cluster resource.
 func (r *ClusterResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	defer journalCreate(ctx, r.client, "virtualization.cluster", req, resp)
+
 	var data ClusterResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -334,8 +364,11 @@ func (r *ClusterResource) Create(ctx context.Context, req resource.CreateRequest
 	}
 
 	// Apply filter-to-owned pattern for tags and custom_fields
-	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, cluster.HasTags(), cluster.GetTags(), planTags)
+	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, cluster.HasTags(), cluster.GetTags(), planTags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, cluster.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, cluster.GetCustomFields(), &resp.Diagnostics)
 	data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, planCustomFields, cluster.GetCustomFields(), &resp.Diagnostics)
+	data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, cluster.GetCustomFields(), &resp.Diagnostics)
 	utils.SetIdentityCustomFields(ctx, resp.Identity, types.StringValue(data.ID.ValueString()), data.CustomFields, &resp.Diagnostics)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -343,6 +376,7 @@ func (r *ClusterResource) Create(ctx context.Context, req resource.CreateRequest
 
 // Read refreshes the Terraform state with the latest data.
 func (r *ClusterResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data ClusterResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -397,8 +431,11 @@ func (r *ClusterResource) Read(ctx context.Context, req resource.ReadRequest, re
 	}
 
 	// Preserve null/empty state values for tags and custom_fields
-	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, cluster.HasTags(), cluster.GetTags(), stateTags)
+	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, cluster.HasTags(), cluster.GetTags(), stateTags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, cluster.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, cluster.GetCustomFields(), &resp.Diagnostics)
 	data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, stateCustomFields, cluster.GetCustomFields(), &resp.Diagnostics)
+	data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, cluster.GetCustomFields(), &resp.Diagnostics)
 	utils.SetIdentityCustomFields(ctx, resp.Identity, types.StringValue(data.ID.ValueString()), data.CustomFields, &resp.Diagnostics)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -406,6 +443,13 @@ func (r *ClusterResource) Read(ctx context.Context, req resource.ReadRequest, re
 
 // Update updates the resource and sets the updated Terraform state.
 func (r *ClusterResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	ctx = withPriorTags(ctx, req.State)
+	defer journalUpdate(ctx, r.client, "virtualization.cluster", req, resp)
+
 	// Read both state and plan for merge-aware custom fields
 	var state, plan ClusterResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -462,8 +506,11 @@ func (r *ClusterResource) Update(ctx context.Context, req resource.UpdateRequest
 	}
 
 	// Apply filter-to-owned pattern for tags and custom_fields
-	plan.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, cluster.HasTags(), cluster.GetTags(), planTags)
+	plan.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, cluster.HasTags(), cluster.GetTags(), planTags)
+	plan.TagsAll = utils.TagsAllFromAPI(ctx, cluster.GetTags())
+	plan.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, plan.CustomFieldsAll, cluster.GetCustomFields(), &resp.Diagnostics)
 	plan.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, planCustomFields, cluster.GetCustomFields(), &resp.Diagnostics)
+	plan.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, plan.CustomFieldsMap, cluster.GetCustomFields(), &resp.Diagnostics)
 	utils.SetIdentityCustomFields(ctx, resp.Identity, types.StringValue(plan.ID.ValueString()), plan.CustomFields, &resp.Diagnostics)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
@@ -471,6 +518,10 @@ func (r *ClusterResource) Update(ctx context.Context, req resource.UpdateRequest
 
 // Delete deletes the resource and removes the Terraform state.
 func (r *ClusterResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data ClusterResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -559,7 +610,9 @@ func (r *ClusterResource) ImportState(ctx context.Context, req resource.ImportSt
 		if cluster.Site.IsSet() && cluster.Site.Get() != nil {
 			data.Site = types.StringValue(fmt.Sprintf("%d", cluster.Site.Get().GetId()))
 		}
-		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, cluster.HasTags(), cluster.GetTags(), data.Tags)
+		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, r.client, cluster.HasTags(), cluster.GetTags(), data.Tags)
+		data.TagsAll = utils.TagsAllFromAPI(ctx, cluster.GetTags())
+		data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, cluster.GetCustomFields(), &resp.Diagnostics)
 		if parsed.HasCustomFields {
 			if len(parsed.CustomFields) == 0 {
 				data.CustomFields = types.SetValueMust(utils.GetCustomFieldsAttributeType().ElemType, []attr.Value{})
@@ -581,6 +634,7 @@ func (r *ClusterResource) ImportState(ctx context.Context, req resource.ImportSt
 		}
 		if parsed.HasCustomFields {
 			data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, cluster.GetCustomFields(), &resp.Diagnostics)
+			data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, cluster.GetCustomFields(), &resp.Diagnostics)
 		} else {
 			data.CustomFields = types.SetNull(utils.GetCustomFieldsAttributeType().ElemType)
 		}
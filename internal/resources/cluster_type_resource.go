@@ -9,6 +9,7 @@ import (
 	"net/http"
 
 	"github.com/bab3l/go-netbox"
+	"github.com/bab3l/terraform-provider-netbox/internal/netboxlookup"
 	nbschema "github.com/bab3l/terraform-provider-netbox/internal/schema"
 	"github.com/bab3l/terraform-provider-netbox/internal/utils"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
@@ -25,6 +26,7 @@ var (
 	_ resource.ResourceWithConfigure   = &ClusterTypeResource{}
 	_ resource.ResourceWithImportState = &ClusterTypeResource{}
 	_ resource.ResourceWithIdentity    = &ClusterTypeResource{}
+	_ resource.ResourceWithModifyPlan  = &ClusterTypeResource{}
 )
 
 // NewClusterTypeResource returns a new Cluster Type resource.
@@ -39,12 +41,16 @@ type ClusterTypeResource struct {
 
 // ClusterTypeResourceModel describes the resource data model.
 type ClusterTypeResourceModel struct {
-	ID           types.String `tfsdk:"id"`
-	Name         types.String `tfsdk:"name"`
-	Slug         types.String `tfsdk:"slug"`
-	Description  types.String `tfsdk:"description"`
-	Tags         types.Set    `tfsdk:"tags"`
-	CustomFields types.Set    `tfsdk:"custom_fields"`
+	ID              types.String  `tfsdk:"id"`
+	Name            types.String  `tfsdk:"name"`
+	Slug            types.String  `tfsdk:"slug"`
+	Description     types.String  `tfsdk:"description"`
+	Tags            types.Set     `tfsdk:"tags"`
+	CustomFields    types.Set     `tfsdk:"custom_fields"`
+	CustomFieldsMap types.Dynamic `tfsdk:"custom_fields_map"`
+	TagsAll         types.Set     `tfsdk:"tags_all"`
+	CustomFieldsAll types.Map     `tfsdk:"custom_fields_all"`
+	Branch          types.String  `tfsdk:"branch"`
 }
 
 // Metadata returns the resource type name.
@@ -69,6 +75,10 @@ func (r *ClusterTypeResource) Schema(ctx context.Context, req resource.SchemaReq
 	// Add metadata attributes (slug list tags, custom_fields)
 	resp.Schema.Attributes["tags"] = nbschema.TagsSlugAttribute()
 	resp.Schema.Attributes["custom_fields"] = nbschema.CustomFieldsAttribute()
+	resp.Schema.Attributes["custom_fields_map"] = nbschema.CustomFieldsMapAttribute()
+	resp.Schema.Attributes["tags_all"] = nbschema.TagsAllAttribute()
+	resp.Schema.Attributes["custom_fields_all"] = nbschema.CustomFieldsAllAttribute()
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 func (r *ClusterTypeResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
@@ -107,8 +117,21 @@ func (r *ClusterTypeResource) mapClusterTypeToState(clusterType *netbox.ClusterT
 	// Tags and custom fields are now handled in Create/Read/Update with filter-to-owned pattern
 }
 
+// ModifyPlan validates the configured custom fields against their definitions in NetBox
+// and plans tags_all and custom_fields_all.
+func (r *ClusterTypeResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	utils.ValidateCustomFieldsPlan(ctx, r.client, "virtualization.clustertype", req, resp)
+	utils.PlanEffectiveMetadata(ctx, r.client, "virtualization.clustertype", req, resp)
+}
+
 // Create creates a new cluster type resource.
 func (r *ClusterTypeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	defer journalCreate(ctx, r.client, "virtualization.clustertype", req, resp)
+
 	var data ClusterTypeResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -134,6 +157,10 @@ func (r *ClusterTypeResource) Create(ctx context.Context, req resource.CreateReq
 		return
 	}
 	utils.ApplyCustomFields(ctx, &clusterTypeRequest, data.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, &clusterTypeRequest, data.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, &clusterTypeRequest, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "virtualization.clustertype", &clusterTypeRequest, &resp.Diagnostics)
+	netboxlookup.ResolveCustomFieldReferences(ctx, r.client, &clusterTypeRequest, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -164,8 +191,11 @@ func (r *ClusterTypeResource) Create(ctx context.Context, req resource.CreateReq
 	}
 
 	// Apply filter-to-owned pattern for tags and custom_fields
-	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, clusterType.HasTags(), clusterType.GetTags(), planTags)
+	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, clusterType.HasTags(), clusterType.GetTags(), planTags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, clusterType.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, clusterType.GetCustomFields(), &resp.Diagnostics)
 	data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, planCustomFields, clusterType.GetCustomFields(), &resp.Diagnostics)
+	data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, clusterType.GetCustomFields(), &resp.Diagnostics)
 	utils.SetIdentityCustomFields(ctx, resp.Identity, types.StringValue(data.ID.ValueString()), data.CustomFields, &resp.Diagnostics)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -173,6 +203,7 @@ func (r *ClusterTypeResource) Create(ctx context.Context, req resource.CreateReq
 
 // Read refreshes the Terraform state with the latest data.
 func (r *ClusterTypeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data ClusterTypeResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -227,8 +258,11 @@ func (r *ClusterTypeResource) Read(ctx context.Context, req resource.ReadRequest
 	}
 
 	// Preserve null/empty state values for tags and custom_fields
-	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, clusterType.HasTags(), clusterType.GetTags(), stateTags)
+	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, clusterType.HasTags(), clusterType.GetTags(), stateTags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, clusterType.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, clusterType.GetCustomFields(), &resp.Diagnostics)
 	data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, stateCustomFields, clusterType.GetCustomFields(), &resp.Diagnostics)
+	data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, clusterType.GetCustomFields(), &resp.Diagnostics)
 	utils.SetIdentityCustomFields(ctx, resp.Identity, types.StringValue(data.ID.ValueString()), data.CustomFields, &resp.Diagnostics)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -236,6 +270,13 @@ func (r *ClusterTypeResource) Read(ctx context.Context, req resource.ReadRequest
 
 // Update updates the resource and sets the updated Terraform state.
 func (r *ClusterTypeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	ctx = withPriorTags(ctx, req.State)
+	defer journalUpdate(ctx, r.client, "virtualization.clustertype", req, resp)
+
 	// Read both state and plan for merge-aware custom fields
 	var state, plan ClusterTypeResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -276,6 +317,13 @@ func (r *ClusterTypeResource) Update(ctx context.Context, req resource.UpdateReq
 		return
 	}
 	utils.ApplyCustomFieldsWithMerge(ctx, &clusterTypeRequest, plan.CustomFields, state.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, &clusterTypeRequest, plan.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, &clusterTypeRequest, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "virtualization.clustertype", &clusterTypeRequest, &resp.Diagnostics)
+	netboxlookup.ResolveCustomFieldReferences(ctx, r.client, &clusterTypeRequest, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Call the API
 	clusterType, httpResp, err := r.client.VirtualizationAPI.VirtualizationClusterTypesUpdate(ctx, clusterTypeIDInt).ClusterTypeRequest(clusterTypeRequest).Execute()
@@ -303,8 +351,11 @@ func (r *ClusterTypeResource) Update(ctx context.Context, req resource.UpdateReq
 	}
 
 	// Apply filter-to-owned pattern for tags and custom_fields
-	plan.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, clusterType.HasTags(), clusterType.GetTags(), planTags)
+	plan.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, clusterType.HasTags(), clusterType.GetTags(), planTags)
+	plan.TagsAll = utils.TagsAllFromAPI(ctx, clusterType.GetTags())
+	plan.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, plan.CustomFieldsAll, clusterType.GetCustomFields(), &resp.Diagnostics)
 	plan.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, planCustomFields, clusterType.GetCustomFields(), &resp.Diagnostics)
+	plan.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, plan.CustomFieldsMap, clusterType.GetCustomFields(), &resp.Diagnostics)
 	utils.SetIdentityCustomFields(ctx, resp.Identity, types.StringValue(plan.ID.ValueString()), plan.CustomFields, &resp.Diagnostics)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
@@ -312,6 +363,10 @@ func (r *ClusterTypeResource) Update(ctx context.Context, req resource.UpdateReq
 
 // Delete deletes the resource and removes the Terraform state.
 func (r *ClusterTypeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data ClusterTypeResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -390,7 +445,9 @@ func (r *ClusterTypeResource) ImportState(ctx context.Context, req resource.Impo
 		}
 
 		var data ClusterTypeResourceModel
-		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, clusterType.HasTags(), clusterType.GetTags(), data.Tags)
+		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, r.client, clusterType.HasTags(), clusterType.GetTags(), data.Tags)
+		data.TagsAll = utils.TagsAllFromAPI(ctx, clusterType.GetTags())
+		data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, clusterType.GetCustomFields(), &resp.Diagnostics)
 		if parsed.HasCustomFields {
 			if len(parsed.CustomFields) == 0 {
 				data.CustomFields = types.SetValueMust(utils.GetCustomFieldsAttributeType().ElemType, []attr.Value{})
@@ -412,6 +469,7 @@ func (r *ClusterTypeResource) ImportState(ctx context.Context, req resource.Impo
 		}
 		if parsed.HasCustomFields {
 			data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, clusterType.GetCustomFields(), &resp.Diagnostics)
+			data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, clusterType.GetCustomFields(), &resp.Diagnostics)
 		} else {
 			data.CustomFields = types.SetNull(utils.GetCustomFieldsAttributeType().ElemType)
 		}
@@ -56,6 +56,7 @@ type ConfigContextResourceModel struct {
 	TenantGroups  types.Set    `tfsdk:"tenant_groups"`
 	Tenants       types.Set    `tfsdk:"tenants"`
 	Tags          types.Set    `tfsdk:"tags"`
+	Branch        types.String `tfsdk:"branch"`
 }
 
 // normalizeJSON normalizes a JSON string by unmarshaling and remarshaling it
@@ -172,6 +173,7 @@ func (r *ConfigContextResource) Schema(ctx context.Context, req resource.SchemaR
 			},
 		},
 	}
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 func (r *ConfigContextResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -190,6 +192,10 @@ func (r *ConfigContextResource) Configure(ctx context.Context, req resource.Conf
 }
 
 func (r *ConfigContextResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
 	var data ConfigContextResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -298,6 +304,7 @@ func (r *ConfigContextResource) Create(ctx context.Context, req resource.CreateR
 }
 
 func (r *ConfigContextResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data ConfigContextResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -359,6 +366,10 @@ func (r *ConfigContextResource) Read(ctx context.Context, req resource.ReadReque
 }
 
 func (r *ConfigContextResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
 	var state, plan ConfigContextResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -462,6 +473,10 @@ func (r *ConfigContextResource) Update(ctx context.Context, req resource.UpdateR
 }
 
 func (r *ConfigContextResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data ConfigContextResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
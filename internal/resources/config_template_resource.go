@@ -24,6 +24,7 @@ import (
 var (
 	_ resource.Resource                = &ConfigTemplateResource{}
 	_ resource.ResourceWithImportState = &ConfigTemplateResource{}
+	_ resource.ResourceWithModifyPlan  = &ConfigTemplateResource{}
 )
 
 // NewConfigTemplateResource returns a new resource implementing the config template resource.
@@ -44,6 +45,8 @@ type ConfigTemplateResourceModel struct {
 	TemplateCode types.String `tfsdk:"template_code"`
 	DataPath     types.String `tfsdk:"data_path"`
 	Tags         types.Set    `tfsdk:"tags"`
+	TagsAll      types.Set    `tfsdk:"tags_all"`
+	Branch       types.String `tfsdk:"branch"`
 }
 
 // Metadata returns the resource type name.
@@ -75,12 +78,14 @@ func (r *ConfigTemplateResource) Schema(ctx context.Context, req resource.Schema
 				MarkdownDescription: "Path to remote file (relative to data source root). Read-only.",
 				Computed:            true,
 			},
-			"tags": nbschema.TagsSlugAttribute(),
+			"tags":     nbschema.TagsSlugAttribute(),
+			"tags_all": nbschema.TagsAllAttribute(),
 		},
 	}
 
 	// Add description attribute
 	maps.Copy(resp.Schema.Attributes, nbschema.DescriptionOnlyAttributes("config template"))
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 // Configure adds the provider configured client to the resource.
@@ -99,8 +104,17 @@ func (r *ConfigTemplateResource) Configure(ctx context.Context, req resource.Con
 	r.client = client
 }
 
+// ModifyPlan plans tags_all.
+func (r *ConfigTemplateResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	utils.PlanEffectiveMetadata(ctx, r.client, "extras.configtemplate", req, resp)
+}
+
 // Create creates the resource and sets the initial Terraform state.
 func (r *ConfigTemplateResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
 	var data ConfigTemplateResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -117,6 +131,7 @@ func (r *ConfigTemplateResource) Create(ctx context.Context, req resource.Create
 	utils.ApplyDescription(apiReq, data.Description)
 
 	utils.ApplyTagsFromSlugs(ctx, r.client, apiReq, data.Tags, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, apiReq, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -144,6 +159,7 @@ func (r *ConfigTemplateResource) Create(ctx context.Context, req resource.Create
 
 // Read refreshes the Terraform state with the latest data.
 func (r *ConfigTemplateResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data ConfigTemplateResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -181,6 +197,11 @@ func (r *ConfigTemplateResource) Read(ctx context.Context, req resource.ReadRequ
 
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *ConfigTemplateResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	ctx = withPriorTags(ctx, req.State)
 	var data ConfigTemplateResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -198,6 +219,7 @@ func (r *ConfigTemplateResource) Update(ctx context.Context, req resource.Update
 	utils.ApplyDescription(apiReq, data.Description)
 
 	utils.ApplyTagsFromSlugs(ctx, r.client, apiReq, data.Tags, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, apiReq, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -222,6 +244,10 @@ func (r *ConfigTemplateResource) Update(ctx context.Context, req resource.Update
 
 // Delete deletes the resource and removes the Terraform state on success.
 func (r *ConfigTemplateResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data ConfigTemplateResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -278,5 +304,6 @@ func (r *ConfigTemplateResource) mapResponseToModel(template *netbox.ConfigTempl
 	data.DataPath = types.StringValue(template.GetDataPath())
 
 	// Tags (slug list)
-	data.Tags = utils.PopulateTagsSlugFromAPI(context.Background(), template.HasTags(), template.GetTags(), data.Tags)
+	data.Tags = utils.PopulateTagsSlugFromAPI(context.Background(), r.client, template.HasTags(), template.GetTags(), data.Tags)
+	data.TagsAll = utils.TagsAllFromAPI(context.Background(), template.GetTags())
 }
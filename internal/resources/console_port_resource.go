@@ -29,6 +29,7 @@ var (
 	_ resource.ResourceWithConfigure   = &ConsolePortResource{}
 	_ resource.ResourceWithImportState = &ConsolePortResource{}
 	_ resource.ResourceWithIdentity    = &ConsolePortResource{}
+	_ resource.ResourceWithModifyPlan  = &ConsolePortResource{}
 )
 
 // NewConsolePortResource returns a new resource implementing the console port resource.
@@ -43,16 +44,20 @@ type ConsolePortResource struct {
 
 // ConsolePortResourceModel describes the resource data model.
 type ConsolePortResourceModel struct {
-	ID            types.String `tfsdk:"id"`
-	Device        types.String `tfsdk:"device"`
-	Name          types.String `tfsdk:"name"`
-	Label         types.String `tfsdk:"label"`
-	Type          types.String `tfsdk:"type"`
-	Speed         types.Int32  `tfsdk:"speed"`
-	Description   types.String `tfsdk:"description"`
-	MarkConnected types.Bool   `tfsdk:"mark_connected"`
-	Tags          types.Set    `tfsdk:"tags"`
-	CustomFields  types.Set    `tfsdk:"custom_fields"`
+	ID              types.String  `tfsdk:"id"`
+	Device          types.String  `tfsdk:"device"`
+	Name            types.String  `tfsdk:"name"`
+	Label           types.String  `tfsdk:"label"`
+	Type            types.String  `tfsdk:"type"`
+	Speed           types.Int32   `tfsdk:"speed"`
+	Description     types.String  `tfsdk:"description"`
+	MarkConnected   types.Bool    `tfsdk:"mark_connected"`
+	Tags            types.Set     `tfsdk:"tags"`
+	CustomFields    types.Set     `tfsdk:"custom_fields"`
+	CustomFieldsMap types.Dynamic `tfsdk:"custom_fields_map"`
+	TagsAll         types.Set     `tfsdk:"tags_all"`
+	CustomFieldsAll types.Map     `tfsdk:"custom_fields_all"`
+	Branch          types.String  `tfsdk:"branch"`
 }
 
 // Metadata returns the resource type name.
@@ -95,13 +100,17 @@ func (r *ConsolePortResource) Schema(ctx context.Context, req resource.SchemaReq
 				Computed:            true,
 				Default:             booldefault.StaticBool(false),
 			},
-			"tags":          nbschema.TagsSlugAttribute(),
-			"custom_fields": nbschema.CustomFieldsAttribute(),
+			"tags":              nbschema.TagsSlugAttribute(),
+			"custom_fields":     nbschema.CustomFieldsAttribute(),
+			"custom_fields_map": nbschema.CustomFieldsMapAttribute(),
+			"tags_all":          nbschema.TagsAllAttribute(),
+			"custom_fields_all": nbschema.CustomFieldsAllAttribute(),
 		},
 	}
 
 	// Add description attribute
 	maps.Copy(resp.Schema.Attributes, nbschema.DescriptionOnlyAttributes("console port"))
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 func (r *ConsolePortResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
@@ -124,8 +133,21 @@ func (r *ConsolePortResource) Configure(ctx context.Context, req resource.Config
 	r.client = client
 }
 
+// ModifyPlan validates the configured custom fields against their definitions in NetBox
+// and plans tags_all and custom_fields_all.
+func (r *ConsolePortResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	utils.ValidateCustomFieldsPlan(ctx, r.client, "dcim.consoleport", req, resp)
+	utils.PlanEffectiveMetadata(ctx, r.client, "dcim.consoleport", req, resp)
+}
+
 // Create creates the resource.
 func (r *ConsolePortResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	defer journalCreate(ctx, r.client, "dcim.consoleport", req, resp)
+
 	var data ConsolePortResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -169,6 +191,10 @@ func (r *ConsolePortResource) Create(ctx context.Context, req resource.CreateReq
 	utils.ApplyDescription(apiReq, data.Description)
 	utils.ApplyTagsFromSlugs(ctx, r.client, apiReq, data.Tags, &resp.Diagnostics)
 	utils.ApplyCustomFields(ctx, apiReq, data.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, apiReq, data.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, apiReq, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "dcim.consoleport", apiReq, &resp.Diagnostics)
+	lookup.ResolveCustomFieldReferences(ctx, r.client, apiReq, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -205,6 +231,7 @@ func (r *ConsolePortResource) Create(ctx context.Context, req resource.CreateReq
 
 // Read refreshes the resource state.
 func (r *ConsolePortResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data ConsolePortResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -250,6 +277,13 @@ func (r *ConsolePortResource) Read(ctx context.Context, req resource.ReadRequest
 
 // Update updates the resource.
 func (r *ConsolePortResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	ctx = withPriorTags(ctx, req.State)
+	defer journalUpdate(ctx, r.client, "dcim.consoleport", req, resp)
+
 	var state, data ConsolePortResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -311,6 +345,10 @@ func (r *ConsolePortResource) Update(ctx context.Context, req resource.UpdateReq
 
 	// Apply custom fields with merge logic (preserves unmanaged fields)
 	utils.ApplyCustomFieldsWithMerge(ctx, apiReq, data.CustomFields, state.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, apiReq, data.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, apiReq, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "dcim.consoleport", apiReq, &resp.Diagnostics)
+	lookup.ResolveCustomFieldReferences(ctx, r.client, apiReq, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -341,6 +379,10 @@ func (r *ConsolePortResource) Update(ctx context.Context, req resource.UpdateReq
 
 // Delete deletes the resource.
 func (r *ConsolePortResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data ConsolePortResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -435,6 +477,7 @@ func (r *ConsolePortResource) ImportState(ctx context.Context, req resource.Impo
 		}
 		if parsed.HasCustomFields {
 			data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, response.GetCustomFields(), &resp.Diagnostics)
+			data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, response.GetCustomFields(), &resp.Diagnostics)
 		} else {
 			data.CustomFields = types.SetNull(utils.GetCustomFieldsAttributeType().ElemType)
 		}
@@ -511,11 +554,14 @@ func (r *ConsolePortResource) mapResponseToModel(ctx context.Context, consolePor
 	}
 
 	// Tags (slug list)
-	data.Tags = utils.PopulateTagsSlugFromAPI(ctx, consolePort.HasTags(), consolePort.GetTags(), data.Tags)
+	data.Tags = utils.PopulateTagsSlugFromAPI(ctx, r.client, consolePort.HasTags(), consolePort.GetTags(), data.Tags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, consolePort.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, consolePort.GetCustomFields(), diags)
 
 	// Handle custom fields
 	if consolePort.HasCustomFields() {
 		data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, consolePort.GetCustomFields(), diags)
+		data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, consolePort.GetCustomFields(), diags)
 	} else {
 		data.CustomFields = types.SetNull(utils.GetCustomFieldsAttributeType().ElemType)
 	}
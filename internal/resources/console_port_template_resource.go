@@ -45,6 +45,7 @@ type ConsolePortTemplateResourceModel struct {
 	Label       types.String `tfsdk:"label"`
 	Type        types.String `tfsdk:"type"`
 	Description types.String `tfsdk:"description"`
+	Branch      types.String `tfsdk:"branch"`
 }
 
 // Metadata returns the resource type name.
@@ -90,6 +91,7 @@ func (r *ConsolePortTemplateResource) Schema(ctx context.Context, req resource.S
 			},
 		},
 	}
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 // Configure adds the provider configured client to the resource.
@@ -110,6 +112,10 @@ func (r *ConsolePortTemplateResource) Configure(ctx context.Context, req resourc
 
 // Create creates the resource and sets the initial Terraform state.
 func (r *ConsolePortTemplateResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
 	var data ConsolePortTemplateResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -176,6 +182,7 @@ func (r *ConsolePortTemplateResource) Create(ctx context.Context, req resource.C
 
 // Read refreshes the Terraform state with the latest data.
 func (r *ConsolePortTemplateResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data ConsolePortTemplateResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -213,6 +220,10 @@ func (r *ConsolePortTemplateResource) Read(ctx context.Context, req resource.Rea
 
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *ConsolePortTemplateResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
 	var data ConsolePortTemplateResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -278,6 +289,10 @@ func (r *ConsolePortTemplateResource) Update(ctx context.Context, req resource.U
 
 // Delete deletes the resource and removes the Terraform state on success.
 func (r *ConsolePortTemplateResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data ConsolePortTemplateResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -29,6 +29,7 @@ var (
 	_ resource.ResourceWithConfigure   = &ConsoleServerPortResource{}
 	_ resource.ResourceWithImportState = &ConsoleServerPortResource{}
 	_ resource.ResourceWithIdentity    = &ConsoleServerPortResource{}
+	_ resource.ResourceWithModifyPlan  = &ConsoleServerPortResource{}
 )
 
 // NewConsoleServerPortResource returns a new resource implementing the console server port resource.
@@ -43,16 +44,20 @@ type ConsoleServerPortResource struct {
 
 // ConsoleServerPortResourceModel describes the resource data model.
 type ConsoleServerPortResourceModel struct {
-	ID            types.String `tfsdk:"id"`
-	Device        types.String `tfsdk:"device"`
-	Name          types.String `tfsdk:"name"`
-	Label         types.String `tfsdk:"label"`
-	Type          types.String `tfsdk:"type"`
-	Speed         types.Int32  `tfsdk:"speed"`
-	Description   types.String `tfsdk:"description"`
-	MarkConnected types.Bool   `tfsdk:"mark_connected"`
-	Tags          types.Set    `tfsdk:"tags"`
-	CustomFields  types.Set    `tfsdk:"custom_fields"`
+	ID              types.String  `tfsdk:"id"`
+	Device          types.String  `tfsdk:"device"`
+	Name            types.String  `tfsdk:"name"`
+	Label           types.String  `tfsdk:"label"`
+	Type            types.String  `tfsdk:"type"`
+	Speed           types.Int32   `tfsdk:"speed"`
+	Description     types.String  `tfsdk:"description"`
+	MarkConnected   types.Bool    `tfsdk:"mark_connected"`
+	Tags            types.Set     `tfsdk:"tags"`
+	CustomFields    types.Set     `tfsdk:"custom_fields"`
+	CustomFieldsMap types.Dynamic `tfsdk:"custom_fields_map"`
+	TagsAll         types.Set     `tfsdk:"tags_all"`
+	CustomFieldsAll types.Map     `tfsdk:"custom_fields_all"`
+	Branch          types.String  `tfsdk:"branch"`
 }
 
 // Metadata returns the resource type name.
@@ -98,8 +103,11 @@ func (r *ConsoleServerPortResource) Schema(ctx context.Context, req resource.Sch
 				Computed:            true,
 				Default:             booldefault.StaticBool(false),
 			},
-			"tags":          nbschema.TagsSlugAttribute(),
-			"custom_fields": nbschema.CustomFieldsAttribute(),
+			"tags":              nbschema.TagsSlugAttribute(),
+			"custom_fields":     nbschema.CustomFieldsAttribute(),
+			"custom_fields_map": nbschema.CustomFieldsMapAttribute(),
+			"tags_all":          nbschema.TagsAllAttribute(),
+			"custom_fields_all": nbschema.CustomFieldsAllAttribute(),
 		},
 	}
 
@@ -107,6 +115,7 @@ func (r *ConsoleServerPortResource) Schema(ctx context.Context, req resource.Sch
 	maps.Copy(resp.Schema.Attributes, nbschema.DescriptionOnlyAttributes("console server port"))
 
 	// Tags and custom fields are defined directly in the schema above.
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 func (r *ConsoleServerPortResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
@@ -129,8 +138,21 @@ func (r *ConsoleServerPortResource) Configure(ctx context.Context, req resource.
 	r.client = client
 }
 
+// ModifyPlan validates the configured custom fields against their definitions in NetBox
+// and plans tags_all and custom_fields_all.
+func (r *ConsoleServerPortResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	utils.ValidateCustomFieldsPlan(ctx, r.client, "dcim.consoleserverport", req, resp)
+	utils.PlanEffectiveMetadata(ctx, r.client, "dcim.consoleserverport", req, resp)
+}
+
 // Create creates the resource.
 func (r *ConsoleServerPortResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	defer journalCreate(ctx, r.client, "dcim.consoleserverport", req, resp)
+
 	var data ConsoleServerPortResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -169,6 +191,10 @@ func (r *ConsoleServerPortResource) Create(ctx context.Context, req resource.Cre
 	utils.ApplyDescription(apiReq, data.Description)
 	utils.ApplyTagsFromSlugs(ctx, r.client, apiReq, data.Tags, &resp.Diagnostics)
 	utils.ApplyCustomFields(ctx, apiReq, data.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, apiReq, data.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, apiReq, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "dcim.consoleserverport", apiReq, &resp.Diagnostics)
+	lookup.ResolveCustomFieldReferences(ctx, r.client, apiReq, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -205,6 +231,7 @@ func (r *ConsoleServerPortResource) Create(ctx context.Context, req resource.Cre
 
 // Read refreshes the resource state.
 func (r *ConsoleServerPortResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data ConsoleServerPortResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -259,6 +286,13 @@ func (r *ConsoleServerPortResource) Read(ctx context.Context, req resource.ReadR
 
 // Update updates the resource.
 func (r *ConsoleServerPortResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	ctx = withPriorTags(ctx, req.State)
+	defer journalUpdate(ctx, r.client, "dcim.consoleserverport", req, resp)
+
 	var state, plan ConsoleServerPortResourceModel
 
 	// Read both state and plan for merge-aware custom fields handling
@@ -321,6 +355,10 @@ func (r *ConsoleServerPortResource) Update(ctx context.Context, req resource.Upd
 	}
 	// Apply custom fields with merge logic to preserve unmanaged fields
 	utils.ApplyCustomFieldsWithMerge(ctx, apiReq, plan.CustomFields, state.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, apiReq, plan.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, apiReq, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "dcim.consoleserverport", apiReq, &resp.Diagnostics)
+	lookup.ResolveCustomFieldReferences(ctx, r.client, apiReq, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -352,6 +390,7 @@ func (r *ConsoleServerPortResource) Update(ctx context.Context, req resource.Upd
 
 	// Apply filter-to-owned pattern for custom fields
 	plan.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, planCustomFields, response.GetCustomFields(), &resp.Diagnostics)
+	plan.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, plan.CustomFieldsMap, response.GetCustomFields(), &resp.Diagnostics)
 	utils.SetIdentityCustomFields(ctx, resp.Identity, types.StringValue(plan.ID.ValueString()), plan.CustomFields, &resp.Diagnostics)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
@@ -359,6 +398,10 @@ func (r *ConsoleServerPortResource) Update(ctx context.Context, req resource.Upd
 
 // Delete deletes the resource.
 func (r *ConsoleServerPortResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data ConsoleServerPortResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -453,6 +496,7 @@ func (r *ConsoleServerPortResource) ImportState(ctx context.Context, req resourc
 		}
 		if parsed.HasCustomFields {
 			data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, response.GetCustomFields(), &resp.Diagnostics)
+			data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, response.GetCustomFields(), &resp.Diagnostics)
 		} else {
 			data.CustomFields = types.SetNull(utils.GetCustomFieldsAttributeType().ElemType)
 		}
@@ -534,8 +578,11 @@ func (r *ConsoleServerPortResource) mapResponseToModel(ctx context.Context, cons
 	}
 
 	// Tags (slug list)
-	data.Tags = utils.PopulateTagsSlugFromAPI(ctx, consoleServerPort.HasTags(), consoleServerPort.GetTags(), data.Tags)
+	data.Tags = utils.PopulateTagsSlugFromAPI(ctx, r.client, consoleServerPort.HasTags(), consoleServerPort.GetTags(), data.Tags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, consoleServerPort.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, consoleServerPort.GetCustomFields(), diags)
 
 	// Handle custom fields - filter to only owned fields
 	data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, consoleServerPort.GetCustomFields(), diags)
+	data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, consoleServerPort.GetCustomFields(), diags)
 }
@@ -46,6 +46,7 @@ type ConsoleServerPortTemplateResourceModel struct {
 	Label       types.String `tfsdk:"label"`
 	Type        types.String `tfsdk:"type"`
 	Description types.String `tfsdk:"description"`
+	Branch      types.String `tfsdk:"branch"`
 }
 
 // Metadata returns the resource type name.
@@ -90,6 +91,7 @@ func (r *ConsoleServerPortTemplateResource) Schema(ctx context.Context, req reso
 
 	// Add description attribute
 	maps.Copy(resp.Schema.Attributes, nbschema.DescriptionOnlyAttributes("console server port template"))
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 // Configure adds the provider configured client to the resource.
@@ -110,6 +112,10 @@ func (r *ConsoleServerPortTemplateResource) Configure(ctx context.Context, req r
 
 // Create creates the resource and sets the initial Terraform state.
 func (r *ConsoleServerPortTemplateResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
 	var data ConsoleServerPortTemplateResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -178,6 +184,7 @@ func (r *ConsoleServerPortTemplateResource) Create(ctx context.Context, req reso
 
 // Read refreshes the Terraform state with the latest data.
 func (r *ConsoleServerPortTemplateResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data ConsoleServerPortTemplateResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -217,6 +224,10 @@ func (r *ConsoleServerPortTemplateResource) Read(ctx context.Context, req resour
 
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *ConsoleServerPortTemplateResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
 	var data ConsoleServerPortTemplateResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -284,6 +295,10 @@ func (r *ConsoleServerPortTemplateResource) Update(ctx context.Context, req reso
 
 // Delete deletes the resource and removes the Terraform state on success.
 func (r *ConsoleServerPortTemplateResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data ConsoleServerPortTemplateResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
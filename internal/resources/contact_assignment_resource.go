@@ -8,6 +8,7 @@ import (
 	"net/http"
 
 	"github.com/bab3l/go-netbox"
+	"github.com/bab3l/terraform-provider-netbox/internal/netboxlookup"
 	nbschema "github.com/bab3l/terraform-provider-netbox/internal/schema"
 	"github.com/bab3l/terraform-provider-netbox/internal/utils"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
@@ -27,6 +28,7 @@ var (
 	_ resource.Resource                = &ContactAssignmentResource{}
 	_ resource.ResourceWithImportState = &ContactAssignmentResource{}
 	_ resource.ResourceWithIdentity    = &ContactAssignmentResource{}
+	_ resource.ResourceWithModifyPlan  = &ContactAssignmentResource{}
 )
 
 func NewContactAssignmentResource() resource.Resource {
@@ -40,14 +42,18 @@ type ContactAssignmentResource struct {
 
 // ContactAssignmentResourceModel describes the resource data model.
 type ContactAssignmentResourceModel struct {
-	ID           types.String `tfsdk:"id"`
-	ObjectType   types.String `tfsdk:"object_type"`
-	ObjectID     types.String `tfsdk:"object_id"`
-	Contact      types.String `tfsdk:"contact_id"`
-	Role         types.String `tfsdk:"role_id"`
-	Priority     types.String `tfsdk:"priority"`
-	Tags         types.Set    `tfsdk:"tags"`
-	CustomFields types.Set    `tfsdk:"custom_fields"`
+	ID              types.String  `tfsdk:"id"`
+	ObjectType      types.String  `tfsdk:"object_type"`
+	ObjectID        types.String  `tfsdk:"object_id"`
+	Contact         types.String  `tfsdk:"contact_id"`
+	Role            types.String  `tfsdk:"role_id"`
+	Priority        types.String  `tfsdk:"priority"`
+	Tags            types.Set     `tfsdk:"tags"`
+	CustomFields    types.Set     `tfsdk:"custom_fields"`
+	CustomFieldsMap types.Dynamic `tfsdk:"custom_fields_map"`
+	TagsAll         types.Set     `tfsdk:"tags_all"`
+	CustomFieldsAll types.Map     `tfsdk:"custom_fields_all"`
+	Branch          types.String  `tfsdk:"branch"`
 }
 
 func (r *ContactAssignmentResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -94,10 +100,14 @@ func (r *ContactAssignmentResource) Schema(ctx context.Context, req resource.Sch
 					stringvalidator.OneOf("primary", "secondary", "tertiary", "inactive", ""),
 				},
 			},
-			"tags":          nbschema.TagsSlugAttribute(),
-			"custom_fields": nbschema.CustomFieldsAttribute(),
+			"tags":              nbschema.TagsSlugAttribute(),
+			"custom_fields":     nbschema.CustomFieldsAttribute(),
+			"custom_fields_map": nbschema.CustomFieldsMapAttribute(),
+			"tags_all":          nbschema.TagsAllAttribute(),
+			"custom_fields_all": nbschema.CustomFieldsAllAttribute(),
 		},
 	}
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 func (r *ContactAssignmentResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
@@ -119,7 +129,18 @@ func (r *ContactAssignmentResource) Configure(ctx context.Context, req resource.
 	r.client = client
 }
 
+// ModifyPlan validates the configured custom fields against their definitions in NetBox
+// and plans tags_all and custom_fields_all.
+func (r *ContactAssignmentResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	utils.ValidateCustomFieldsPlan(ctx, r.client, "tenancy.contactassignment", req, resp)
+	utils.PlanEffectiveMetadata(ctx, r.client, "tenancy.contactassignment", req, resp)
+}
+
 func (r *ContactAssignmentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
 	var data ContactAssignmentResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -195,6 +216,13 @@ func (r *ContactAssignmentResource) Create(ctx context.Context, req resource.Cre
 		return
 	}
 	utils.ApplyCustomFields(ctx, assignmentRequest, data.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, assignmentRequest, data.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, assignmentRequest, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "tenancy.contactassignment", assignmentRequest, &resp.Diagnostics)
+	netboxlookup.ResolveCustomFieldReferences(ctx, r.client, assignmentRequest, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Call the API to create the contact assignment
 	assignment, httpResp, err := r.client.TenancyAPI.TenancyContactAssignmentsCreate(ctx).
@@ -215,8 +243,11 @@ func (r *ContactAssignmentResource) Create(ctx context.Context, req resource.Cre
 	r.mapResponseToState(assignment, &data)
 
 	// Apply filter-to-owned pattern
-	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, assignment.HasTags(), assignment.GetTags(), planTags)
+	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, assignment.HasTags(), assignment.GetTags(), planTags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, assignment.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, assignment.GetCustomFields(), &resp.Diagnostics)
 	data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, planCustomFields, assignment.GetCustomFields(), &resp.Diagnostics)
+	data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, assignment.GetCustomFields(), &resp.Diagnostics)
 	utils.SetIdentityCustomFields(ctx, resp.Identity, types.StringValue(data.ID.ValueString()), data.CustomFields, &resp.Diagnostics)
 
 	tflog.Debug(ctx, "Created contact assignment", map[string]interface{}{
@@ -226,6 +257,7 @@ func (r *ContactAssignmentResource) Create(ctx context.Context, req resource.Cre
 }
 
 func (r *ContactAssignmentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data ContactAssignmentResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -276,13 +308,21 @@ func (r *ContactAssignmentResource) Read(ctx context.Context, req resource.ReadR
 	}
 
 	// Apply filter-to-owned pattern for tags and custom_fields
-	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, assignment.HasTags(), assignment.GetTags(), stateTags)
+	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, assignment.HasTags(), assignment.GetTags(), stateTags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, assignment.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, assignment.GetCustomFields(), &resp.Diagnostics)
 	data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, stateCustomFields, assignment.GetCustomFields(), &resp.Diagnostics)
+	data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, assignment.GetCustomFields(), &resp.Diagnostics)
 	utils.SetIdentityCustomFields(ctx, resp.Identity, types.StringValue(data.ID.ValueString()), data.CustomFields, &resp.Diagnostics)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *ContactAssignmentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	ctx = withPriorTags(ctx, req.State)
 	var state, plan ContactAssignmentResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -366,6 +406,13 @@ func (r *ContactAssignmentResource) Update(ctx context.Context, req resource.Upd
 		return
 	}
 	utils.ApplyCustomFieldsWithMerge(ctx, assignmentRequest, plan.CustomFields, state.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, assignmentRequest, plan.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, assignmentRequest, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "tenancy.contactassignment", assignmentRequest, &resp.Diagnostics)
+	netboxlookup.ResolveCustomFieldReferences(ctx, r.client, assignmentRequest, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Call the API to update the contact assignment
 	assignment, httpResp, err := r.client.TenancyAPI.TenancyContactAssignmentsUpdate(ctx, id).
@@ -386,8 +433,11 @@ func (r *ContactAssignmentResource) Update(ctx context.Context, req resource.Upd
 	r.mapResponseToState(assignment, &plan)
 
 	// Apply filter-to-owned pattern
-	plan.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, assignment.HasTags(), assignment.GetTags(), plan.Tags)
+	plan.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, assignment.HasTags(), assignment.GetTags(), plan.Tags)
+	plan.TagsAll = utils.TagsAllFromAPI(ctx, assignment.GetTags())
+	plan.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, plan.CustomFieldsAll, assignment.GetCustomFields(), &resp.Diagnostics)
 	plan.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, plan.CustomFields, assignment.GetCustomFields(), &resp.Diagnostics)
+	plan.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, plan.CustomFieldsMap, assignment.GetCustomFields(), &resp.Diagnostics)
 	utils.SetIdentityCustomFields(ctx, resp.Identity, types.StringValue(plan.ID.ValueString()), plan.CustomFields, &resp.Diagnostics)
 
 	tflog.Debug(ctx, "Updated contact assignment", map[string]interface{}{
@@ -397,6 +447,10 @@ func (r *ContactAssignmentResource) Update(ctx context.Context, req resource.Upd
 }
 
 func (r *ContactAssignmentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data ContactAssignmentResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -492,10 +546,13 @@ func (r *ContactAssignmentResource) ImportState(ctx context.Context, req resourc
 		if resp.Diagnostics.HasError() {
 			return
 		}
-		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, assignment.HasTags(), assignment.GetTags(), data.Tags)
+		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, r.client, assignment.HasTags(), assignment.GetTags(), data.Tags)
+		data.TagsAll = utils.TagsAllFromAPI(ctx, assignment.GetTags())
+		data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, assignment.GetCustomFields(), &resp.Diagnostics)
 
 		if parsed.HasCustomFields {
 			data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, assignment.GetCustomFields(), &resp.Diagnostics)
+			data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, assignment.GetCustomFields(), &resp.Diagnostics)
 		} else {
 			data.CustomFields = types.SetNull(utils.GetCustomFieldsAttributeType().ElemType)
 		}
@@ -22,6 +22,7 @@ var (
 	_ resource.Resource                = &ContactGroupResource{}
 	_ resource.ResourceWithImportState = &ContactGroupResource{}
 	_ resource.ResourceWithIdentity    = &ContactGroupResource{}
+	_ resource.ResourceWithModifyPlan  = &ContactGroupResource{}
 )
 
 func NewContactGroupResource() resource.Resource {
@@ -38,14 +39,18 @@ func (r *ContactGroupResource) GetClient() *netbox.APIClient {
 }
 
 type ContactGroupResourceModel struct {
-	ID           types.String `tfsdk:"id"`
-	Name         types.String `tfsdk:"name"`
-	Slug         types.String `tfsdk:"slug"`
-	Parent       types.String `tfsdk:"parent"`
-	ParentID     types.String `tfsdk:"parent_id"`
-	Description  types.String `tfsdk:"description"`
-	Tags         types.Set    `tfsdk:"tags"`
-	CustomFields types.Set    `tfsdk:"custom_fields"`
+	ID              types.String  `tfsdk:"id"`
+	Name            types.String  `tfsdk:"name"`
+	Slug            types.String  `tfsdk:"slug"`
+	Parent          types.String  `tfsdk:"parent"`
+	ParentID        types.String  `tfsdk:"parent_id"`
+	Description     types.String  `tfsdk:"description"`
+	Tags            types.Set     `tfsdk:"tags"`
+	CustomFields    types.Set     `tfsdk:"custom_fields"`
+	CustomFieldsMap types.Dynamic `tfsdk:"custom_fields_map"`
+	TagsAll         types.Set     `tfsdk:"tags_all"`
+	CustomFieldsAll types.Map     `tfsdk:"custom_fields_all"`
+	Branch          types.String  `tfsdk:"branch"`
 }
 
 func (r *ContactGroupResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -64,8 +69,11 @@ func (r *ContactGroupResource) Schema(ctx context.Context, req resource.SchemaRe
 				Computed:            true,
 				MarkdownDescription: "The numeric ID of the parent contact group.",
 			},
-			"tags":          nbschema.TagsSlugAttribute(),
-			"custom_fields": nbschema.CustomFieldsAttribute(),
+			"tags":              nbschema.TagsSlugAttribute(),
+			"custom_fields":     nbschema.CustomFieldsAttribute(),
+			"custom_fields_map": nbschema.CustomFieldsMapAttribute(),
+			"tags_all":          nbschema.TagsAllAttribute(),
+			"custom_fields_all": nbschema.CustomFieldsAllAttribute(),
 		},
 	}
 
@@ -73,6 +81,7 @@ func (r *ContactGroupResource) Schema(ctx context.Context, req resource.SchemaRe
 	maps.Copy(resp.Schema.Attributes, nbschema.DescriptionOnlyAttributes("contact group"))
 
 	// Tags and custom fields are defined directly in the schema above.
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 func (r *ContactGroupResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
@@ -95,7 +104,20 @@ func (r *ContactGroupResource) Configure(ctx context.Context, req resource.Confi
 	r.client = client
 }
 
+// ModifyPlan validates the configured custom fields against their definitions in NetBox
+// and plans tags_all and custom_fields_all.
+func (r *ContactGroupResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	utils.ValidateCustomFieldsPlan(ctx, r.client, "tenancy.contactgroup", req, resp)
+	utils.PlanEffectiveMetadata(ctx, r.client, "tenancy.contactgroup", req, resp)
+}
+
 func (r *ContactGroupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	defer journalCreate(ctx, r.client, "tenancy.contactgroup", req, resp)
+
 	var data ContactGroupResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -131,6 +153,13 @@ func (r *ContactGroupResource) Create(ctx context.Context, req resource.CreateRe
 		return
 	}
 	utils.ApplyCustomFields(ctx, &contactGroupRequest, data.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, &contactGroupRequest, data.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, &contactGroupRequest, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "tenancy.contactgroup", &contactGroupRequest, &resp.Diagnostics)
+	netboxlookup.ResolveCustomFieldReferences(ctx, r.client, &contactGroupRequest, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Store plan values for filter-to-owned pattern
 	planTags := data.Tags
@@ -168,8 +197,11 @@ func (r *ContactGroupResource) Create(ctx context.Context, req resource.CreateRe
 	}
 
 	// Apply filter-to-owned pattern for tags and custom_fields
-	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, contactGroup.HasTags(), contactGroup.GetTags(), planTags)
+	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, contactGroup.HasTags(), contactGroup.GetTags(), planTags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, contactGroup.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, contactGroup.GetCustomFields(), &resp.Diagnostics)
 	data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, planCustomFields, contactGroup.GetCustomFields(), &resp.Diagnostics)
+	data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, contactGroup.GetCustomFields(), &resp.Diagnostics)
 	utils.SetIdentityCustomFields(ctx, resp.Identity, types.StringValue(data.ID.ValueString()), data.CustomFields, &resp.Diagnostics)
 
 	tflog.Trace(ctx, "created a contact group resource")
@@ -177,6 +209,7 @@ func (r *ContactGroupResource) Create(ctx context.Context, req resource.CreateRe
 }
 
 func (r *ContactGroupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data ContactGroupResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -212,14 +245,24 @@ func (r *ContactGroupResource) Read(ctx context.Context, req resource.ReadReques
 	}
 
 	// Apply filter-to-owned pattern for tags and custom_fields
-	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, contactGroup.HasTags(), contactGroup.GetTags(), stateTags)
+	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, contactGroup.HasTags(), contactGroup.GetTags(), stateTags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, contactGroup.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, contactGroup.GetCustomFields(), &resp.Diagnostics)
 	data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, stateCustomFields, contactGroup.GetCustomFields(), &resp.Diagnostics)
+	data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, contactGroup.GetCustomFields(), &resp.Diagnostics)
 	utils.SetIdentityCustomFields(ctx, resp.Identity, types.StringValue(data.ID.ValueString()), data.CustomFields, &resp.Diagnostics)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *ContactGroupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	ctx = withPriorTags(ctx, req.State)
+	defer journalUpdate(ctx, r.client, "tenancy.contactgroup", req, resp)
+
 	var state, plan ContactGroupResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -265,6 +308,13 @@ func (r *ContactGroupResource) Update(ctx context.Context, req resource.UpdateRe
 		return
 	}
 	utils.ApplyCustomFieldsWithMerge(ctx, &contactGroupRequest, plan.CustomFields, state.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, &contactGroupRequest, plan.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, &contactGroupRequest, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "tenancy.contactgroup", &contactGroupRequest, &resp.Diagnostics)
+	netboxlookup.ResolveCustomFieldReferences(ctx, r.client, &contactGroupRequest, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Update via API
 	contactGroup, httpResp, err := r.client.TenancyAPI.TenancyContactGroupsUpdate(ctx, contactGroupIDInt).WritableContactGroupRequest(contactGroupRequest).Execute()
@@ -284,14 +334,21 @@ func (r *ContactGroupResource) Update(ctx context.Context, req resource.UpdateRe
 	}
 
 	// Apply filter-to-owned pattern for tags and custom_fields
-	plan.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, contactGroup.HasTags(), contactGroup.GetTags(), plan.Tags)
+	plan.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, contactGroup.HasTags(), contactGroup.GetTags(), plan.Tags)
+	plan.TagsAll = utils.TagsAllFromAPI(ctx, contactGroup.GetTags())
+	plan.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, plan.CustomFieldsAll, contactGroup.GetCustomFields(), &resp.Diagnostics)
 	plan.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, plan.CustomFields, contactGroup.GetCustomFields(), &resp.Diagnostics)
+	plan.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, plan.CustomFieldsMap, contactGroup.GetCustomFields(), &resp.Diagnostics)
 	utils.SetIdentityCustomFields(ctx, resp.Identity, types.StringValue(plan.ID.ValueString()), plan.CustomFields, &resp.Diagnostics)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
 func (r *ContactGroupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data ContactGroupResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -372,10 +429,13 @@ func (r *ContactGroupResource) ImportState(ctx context.Context, req resource.Imp
 		if resp.Diagnostics.HasError() {
 			return
 		}
-		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, contactGroup.HasTags(), contactGroup.GetTags(), data.Tags)
+		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, r.client, contactGroup.HasTags(), contactGroup.GetTags(), data.Tags)
+		data.TagsAll = utils.TagsAllFromAPI(ctx, contactGroup.GetTags())
+		data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, contactGroup.GetCustomFields(), &resp.Diagnostics)
 
 		if parsed.HasCustomFields {
 			data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, contactGroup.GetCustomFields(), &resp.Diagnostics)
+			data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, contactGroup.GetCustomFields(), &resp.Diagnostics)
 		} else {
 			data.CustomFields = types.SetNull(utils.GetCustomFieldsAttributeType().ElemType)
 		}
@@ -22,6 +22,7 @@ import (
 var (
 	_ resource.Resource                = &ContactResource{}
 	_ resource.ResourceWithImportState = &ContactResource{}
+	_ resource.ResourceWithModifyPlan  = &ContactResource{}
 )
 
 func NewContactResource() resource.Resource {
@@ -46,6 +47,8 @@ type ContactResourceModel struct {
 	Description types.String `tfsdk:"description"`
 	Comments    types.String `tfsdk:"comments"`
 	Tags        types.Set    `tfsdk:"tags"`
+	TagsAll     types.Set    `tfsdk:"tags_all"`
+	Branch      types.String `tfsdk:"branch"`
 }
 
 func (r *ContactResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -60,7 +63,8 @@ func (r *ContactResource) Schema(ctx context.Context, req resource.SchemaRequest
 			"name":  nbschema.NameAttribute("contact", 100),
 			"group": nbschema.ReferenceAttributeWithDiffSuppress("contact group", "ID or slug of the contact group this contact belongs to."),
 
-			"tags": nbschema.TagsSlugAttribute(),
+			"tags":     nbschema.TagsSlugAttribute(),
+			"tags_all": nbschema.TagsAllAttribute(),
 			"title": schema.StringAttribute{
 				MarkdownDescription: "Job title or role of the contact.",
 				Optional:            true,
@@ -103,6 +107,7 @@ func (r *ContactResource) Schema(ctx context.Context, req resource.SchemaRequest
 	maps.Copy(resp.Schema.Attributes, nbschema.CommonDescriptiveAttributes("contact"))
 
 	// Note: This resource does not have custom_fields
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 func (r *ContactResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -120,7 +125,18 @@ func (r *ContactResource) Configure(ctx context.Context, req resource.ConfigureR
 	r.client = client
 }
 
+// ModifyPlan plans tags_all.
+func (r *ContactResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	utils.PlanEffectiveMetadata(ctx, r.client, "tenancy.contact", req, resp)
+}
+
 func (r *ContactResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	defer journalCreate(ctx, r.client, "tenancy.contact", req, resp)
+
 	var data ContactResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -188,6 +204,7 @@ func (r *ContactResource) Create(ctx context.Context, req resource.CreateRequest
 
 	// Handle tags
 	utils.ApplyTagsFromSlugs(ctx, r.client, contactRequest, data.Tags, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, contactRequest, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -209,7 +226,7 @@ func (r *ContactResource) Create(ctx context.Context, req resource.CreateRequest
 	r.mapContactToState(contact, &data)
 
 	// Apply filter-to-owned pattern for tags
-	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, contact.HasTags(), contact.GetTags(), planTags)
+	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, contact.HasTags(), contact.GetTags(), planTags)
 	tflog.Debug(ctx, "Created contact", map[string]interface{}{
 		"id":   data.ID.ValueString(),
 		"name": data.Name.ValueString(),
@@ -218,6 +235,7 @@ func (r *ContactResource) Create(ctx context.Context, req resource.CreateRequest
 }
 
 func (r *ContactResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data ContactResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -245,11 +263,18 @@ func (r *ContactResource) Read(ctx context.Context, req resource.ReadRequest, re
 	stateTags := data.Tags
 	r.mapContactToState(contact, &data)
 	// Apply filter-to-owned pattern for tags
-	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, contact.HasTags(), contact.GetTags(), stateTags)
+	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, contact.HasTags(), contact.GetTags(), stateTags)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *ContactResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	ctx = withPriorTags(ctx, req.State)
+	defer journalUpdate(ctx, r.client, "tenancy.contact", req, resp)
+
 	var state, plan ContactResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -323,6 +348,7 @@ func (r *ContactResource) Update(ctx context.Context, req resource.UpdateRequest
 
 	// Handle tags (tags use replace-all semantics)
 	utils.ApplyTagsFromSlugs(ctx, r.client, contactRequest, plan.Tags, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, contactRequest, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -341,7 +367,7 @@ func (r *ContactResource) Update(ctx context.Context, req resource.UpdateRequest
 	// After update, populate tags based on what the user specified
 	// If tags were null in plan (not specified), keep them null to match config
 	// Otherwise, populate from API response (replace-all semantics)
-	plan.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, contact.HasTags(), contact.GetTags(), plan.Tags)
+	plan.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, contact.HasTags(), contact.GetTags(), plan.Tags)
 
 	tflog.Debug(ctx, "Updated contact", map[string]interface{}{
 		"id":   plan.ID.ValueString(),
@@ -351,6 +377,10 @@ func (r *ContactResource) Update(ctx context.Context, req resource.UpdateRequest
 }
 
 func (r *ContactResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data ContactResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -388,6 +418,7 @@ func (r *ContactResource) ImportState(ctx context.Context, req resource.ImportSt
 func (r *ContactResource) mapContactToState(contact *netbox.Contact, data *ContactResourceModel) {
 	data.ID = types.StringValue(fmt.Sprintf("%d", contact.GetId()))
 	data.Name = types.StringValue(contact.GetName())
+	data.TagsAll = utils.TagsAllFromAPI(context.Background(), contact.GetTags())
 
 	// Handle optional group - preserve user's input format
 	if contact.HasGroup() && contact.GetGroup().Id != 0 {
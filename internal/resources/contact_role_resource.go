@@ -7,6 +7,7 @@ import (
 	"net/http"
 
 	"github.com/bab3l/go-netbox"
+	"github.com/bab3l/terraform-provider-netbox/internal/netboxlookup"
 	nbschema "github.com/bab3l/terraform-provider-netbox/internal/schema"
 	"github.com/bab3l/terraform-provider-netbox/internal/utils"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
@@ -21,6 +22,7 @@ var (
 	_ resource.Resource                = &ContactRoleResource{}
 	_ resource.ResourceWithImportState = &ContactRoleResource{}
 	_ resource.ResourceWithIdentity    = &ContactRoleResource{}
+	_ resource.ResourceWithModifyPlan  = &ContactRoleResource{}
 )
 
 func NewContactRoleResource() resource.Resource {
@@ -37,12 +39,16 @@ func (r *ContactRoleResource) GetClient() *netbox.APIClient {
 }
 
 type ContactRoleResourceModel struct {
-	ID           types.String `tfsdk:"id"`
-	Name         types.String `tfsdk:"name"`
-	Slug         types.String `tfsdk:"slug"`
-	Description  types.String `tfsdk:"description"`
-	Tags         types.Set    `tfsdk:"tags"`
-	CustomFields types.Set    `tfsdk:"custom_fields"`
+	ID              types.String  `tfsdk:"id"`
+	Name            types.String  `tfsdk:"name"`
+	Slug            types.String  `tfsdk:"slug"`
+	Description     types.String  `tfsdk:"description"`
+	Tags            types.Set     `tfsdk:"tags"`
+	CustomFields    types.Set     `tfsdk:"custom_fields"`
+	CustomFieldsMap types.Dynamic `tfsdk:"custom_fields_map"`
+	TagsAll         types.Set     `tfsdk:"tags_all"`
+	CustomFieldsAll types.Map     `tfsdk:"custom_fields_all"`
+	Branch          types.String  `tfsdk:"branch"`
 }
 
 func (r *ContactRoleResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -53,12 +59,15 @@ func (r *ContactRoleResource) Schema(ctx context.Context, req resource.SchemaReq
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Manages a contact role in Netbox. Contact roles define the function or responsibility of a contact within an organization (e.g., Technical, Administrative, Billing).",
 		Attributes: map[string]schema.Attribute{
-			"id":            nbschema.IDAttribute("contact role"),
-			"name":          nbschema.NameAttribute("contact role", 100),
-			"slug":          nbschema.SlugAttribute("contact role"),
-			"description":   nbschema.DescriptionAttribute("contact role"),
-			"tags":          nbschema.TagsSlugAttribute(),
-			"custom_fields": nbschema.CustomFieldsAttribute(),
+			"id":                nbschema.IDAttribute("contact role"),
+			"name":              nbschema.NameAttribute("contact role", 100),
+			"slug":              nbschema.SlugAttribute("contact role"),
+			"description":       nbschema.DescriptionAttribute("contact role"),
+			"tags":              nbschema.TagsSlugAttribute(),
+			"custom_fields":     nbschema.CustomFieldsAttribute(),
+			"custom_fields_map": nbschema.CustomFieldsMapAttribute(),
+			"tags_all":          nbschema.TagsAllAttribute(),
+			"custom_fields_all": nbschema.CustomFieldsAllAttribute(),
 		},
 	}
 
@@ -66,6 +75,7 @@ func (r *ContactRoleResource) Schema(ctx context.Context, req resource.SchemaReq
 	maps.Copy(resp.Schema.Attributes, nbschema.DescriptionOnlyAttributes("contact role"))
 
 	// Tags and custom fields are defined directly in the schema above.
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 func (r *ContactRoleResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
@@ -87,7 +97,20 @@ func (r *ContactRoleResource) Configure(ctx context.Context, req resource.Config
 	r.client = client
 }
 
+// ModifyPlan validates the configured custom fields against their definitions in NetBox
+// and plans tags_all and custom_fields_all.
+func (r *ContactRoleResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	utils.ValidateCustomFieldsPlan(ctx, r.client, "tenancy.contactrole", req, resp)
+	utils.PlanEffectiveMetadata(ctx, r.client, "tenancy.contactrole", req, resp)
+}
+
 func (r *ContactRoleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	defer journalCreate(ctx, r.client, "tenancy.contactrole", req, resp)
+
 	var data ContactRoleResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -117,6 +140,13 @@ func (r *ContactRoleResource) Create(ctx context.Context, req resource.CreateReq
 		return
 	}
 	utils.ApplyCustomFields(ctx, &contactRoleRequest, data.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, &contactRoleRequest, data.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, &contactRoleRequest, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "tenancy.contactrole", &contactRoleRequest, &resp.Diagnostics)
+	netboxlookup.ResolveCustomFieldReferences(ctx, r.client, &contactRoleRequest, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Create via API
 	contactRole, httpResp, err := r.client.TenancyAPI.TenancyContactRolesCreate(ctx).ContactRoleRequest(contactRoleRequest).Execute()
@@ -149,8 +179,11 @@ func (r *ContactRoleResource) Create(ctx context.Context, req resource.CreateReq
 	}
 
 	// Apply filter-to-owned pattern
-	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, contactRole.HasTags(), contactRole.GetTags(), planTags)
+	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, contactRole.HasTags(), contactRole.GetTags(), planTags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, contactRole.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, contactRole.GetCustomFields(), &resp.Diagnostics)
 	data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, planCustomFields, contactRole.GetCustomFields(), &resp.Diagnostics)
+	data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, contactRole.GetCustomFields(), &resp.Diagnostics)
 	utils.SetIdentityCustomFields(ctx, resp.Identity, types.StringValue(data.ID.ValueString()), data.CustomFields, &resp.Diagnostics)
 
 	tflog.Trace(ctx, "created a contact role resource")
@@ -158,6 +191,7 @@ func (r *ContactRoleResource) Create(ctx context.Context, req resource.CreateReq
 }
 
 func (r *ContactRoleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data ContactRoleResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -189,13 +223,23 @@ func (r *ContactRoleResource) Read(ctx context.Context, req resource.ReadRequest
 		return
 	}
 	// Apply filter-to-owned pattern
-	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, contactRole.HasTags(), contactRole.GetTags(), stateTags)
+	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, contactRole.HasTags(), contactRole.GetTags(), stateTags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, contactRole.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, contactRole.GetCustomFields(), &resp.Diagnostics)
 	data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, stateCustomFields, contactRole.GetCustomFields(), &resp.Diagnostics)
+	data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, contactRole.GetCustomFields(), &resp.Diagnostics)
 	utils.SetIdentityCustomFields(ctx, resp.Identity, types.StringValue(data.ID.ValueString()), data.CustomFields, &resp.Diagnostics)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *ContactRoleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	ctx = withPriorTags(ctx, req.State)
+	defer journalUpdate(ctx, r.client, "tenancy.contactrole", req, resp)
+
 	var state, plan ContactRoleResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -228,6 +272,13 @@ func (r *ContactRoleResource) Update(ctx context.Context, req resource.UpdateReq
 		return
 	}
 	utils.ApplyCustomFieldsWithMerge(ctx, &contactRoleRequest, plan.CustomFields, state.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, &contactRoleRequest, plan.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, &contactRoleRequest, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "tenancy.contactrole", &contactRoleRequest, &resp.Diagnostics)
+	netboxlookup.ResolveCustomFieldReferences(ctx, r.client, &contactRoleRequest, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Update via API
 	contactRole, httpResp, err := r.client.TenancyAPI.TenancyContactRolesUpdate(ctx, contactRoleIDInt).ContactRoleRequest(contactRoleRequest).Execute()
@@ -244,13 +295,20 @@ func (r *ContactRoleResource) Update(ctx context.Context, req resource.UpdateReq
 		return
 	}
 	// Apply filter-to-owned pattern
-	plan.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, contactRole.HasTags(), contactRole.GetTags(), plan.Tags)
+	plan.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, contactRole.HasTags(), contactRole.GetTags(), plan.Tags)
+	plan.TagsAll = utils.TagsAllFromAPI(ctx, contactRole.GetTags())
+	plan.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, plan.CustomFieldsAll, contactRole.GetCustomFields(), &resp.Diagnostics)
 	plan.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, plan.CustomFields, contactRole.GetCustomFields(), &resp.Diagnostics)
+	plan.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, plan.CustomFieldsMap, contactRole.GetCustomFields(), &resp.Diagnostics)
 	utils.SetIdentityCustomFields(ctx, resp.Identity, types.StringValue(plan.ID.ValueString()), plan.CustomFields, &resp.Diagnostics)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
 func (r *ContactRoleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data ContactRoleResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -324,10 +382,13 @@ func (r *ContactRoleResource) ImportState(ctx context.Context, req resource.Impo
 		if resp.Diagnostics.HasError() {
 			return
 		}
-		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, contactRole.HasTags(), contactRole.GetTags(), data.Tags)
+		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, r.client, contactRole.HasTags(), contactRole.GetTags(), data.Tags)
+		data.TagsAll = utils.TagsAllFromAPI(ctx, contactRole.GetTags())
+		data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, contactRole.GetCustomFields(), &resp.Diagnostics)
 
 		if parsed.HasCustomFields {
 			data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, contactRole.GetCustomFields(), &resp.Diagnostics)
+			data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, contactRole.GetCustomFields(), &resp.Diagnostics)
 		} else {
 			data.CustomFields = types.SetNull(utils.GetCustomFieldsAttributeType().ElemType)
 		}
@@ -44,6 +44,8 @@ type CustomFieldChoiceSetResourceModel struct {
 	BaseChoices         types.String `tfsdk:"base_choices"`
 	ExtraChoices        types.List   `tfsdk:"extra_choices"`
 	OrderAlphabetically types.Bool   `tfsdk:"order_alphabetically"`
+	Choices             types.List   `tfsdk:"choices"`
+	Branch              types.String `tfsdk:"branch"`
 }
 
 // ChoicePairModel represents a key-value pair for choices.
@@ -98,11 +100,29 @@ func (r *CustomFieldChoiceSetResource) Schema(ctx context.Context, req resource.
 				MarkdownDescription: "Whether to order choices alphabetically. Defaults to false.",
 				Optional:            true,
 				Computed:            true,
-			}},
+			},
+			"choices": schema.ListNestedAttribute{
+				MarkdownDescription: "The choices offered by the choice set as resolved by Netbox: the base choices merged with the extra choices, ordered as Netbox presents them.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"value": schema.StringAttribute{
+							MarkdownDescription: "The internal value stored when this choice is selected.",
+							Computed:            true,
+						},
+						"label": schema.StringAttribute{
+							MarkdownDescription: "The display label shown to users.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
 	}
 
 	// Add description attribute
 	maps.Copy(resp.Schema.Attributes, nbschema.DescriptionOnlyAttributes("custom field choice set"))
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 func (r *CustomFieldChoiceSetResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
@@ -120,6 +140,10 @@ func (r *CustomFieldChoiceSetResource) Configure(ctx context.Context, req resour
 }
 
 func (r *CustomFieldChoiceSetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
 	var data CustomFieldChoiceSetResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -165,6 +189,10 @@ func (r *CustomFieldChoiceSetResource) Create(ctx context.Context, req resource.
 		return
 	}
 	r.mapToState(ctx, result, &data)
+	r.mapChoicesToState(ctx, result.GetId(), &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	tflog.Debug(ctx, "Created custom field choice set", map[string]interface{}{
 		"id":   data.ID.ValueString(),
 		"name": data.Name.ValueString(),
@@ -173,6 +201,7 @@ func (r *CustomFieldChoiceSetResource) Create(ctx context.Context, req resource.
 }
 
 func (r *CustomFieldChoiceSetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data CustomFieldChoiceSetResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -198,10 +227,18 @@ func (r *CustomFieldChoiceSetResource) Read(ctx context.Context, req resource.Re
 		return
 	}
 	r.mapToState(ctx, result, &data)
+	r.mapChoicesToState(ctx, id, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *CustomFieldChoiceSetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
 	var data CustomFieldChoiceSetResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -253,10 +290,18 @@ func (r *CustomFieldChoiceSetResource) Update(ctx context.Context, req resource.
 		return
 	}
 	r.mapToState(ctx, result, &data)
+	r.mapChoicesToState(ctx, id, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *CustomFieldChoiceSetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data CustomFieldChoiceSetResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -307,6 +352,18 @@ func (r *CustomFieldChoiceSetResource) buildExtraChoices(ctx context.Context, ch
 	return result, diags
 }
 
+// mapChoicesToState reads the resolved choices of the choice set with id into
+// data.
+func (r *CustomFieldChoiceSetResource) mapChoicesToState(ctx context.Context, id int32, data *CustomFieldChoiceSetResourceModel, diags *diag.Diagnostics) {
+	choices, httpResp, err := utils.ListCustomFieldChoices(ctx, r.client, id)
+	if err != nil {
+		diags.AddError("Error reading custom field choices",
+			utils.FormatAPIError(fmt.Sprintf("read choices of custom field choice set ID %d", id), err, httpResp))
+		return
+	}
+	data.Choices = utils.CustomFieldChoicesToList(choices)
+}
+
 // mapToState maps API response to Terraform state.
 func (r *CustomFieldChoiceSetResource) mapToState(ctx context.Context, result *netbox.CustomFieldChoiceSet, data *CustomFieldChoiceSetResourceModel) {
 	data.ID = types.StringValue(fmt.Sprintf("%d", result.GetId()))
@@ -65,6 +65,7 @@ type CustomFieldResourceModel struct {
 	ValidationRegex   types.String `tfsdk:"validation_regex"`
 	ChoiceSet         types.String `tfsdk:"choice_set"`
 	Comments          types.String `tfsdk:"comments"`
+	Branch            types.String `tfsdk:"branch"`
 }
 
 // Metadata returns the resource type name.
@@ -189,6 +190,7 @@ func (r *CustomFieldResource) Schema(ctx context.Context, req resource.SchemaReq
 
 	// Add description and comments attributes
 	maps.Copy(resp.Schema.Attributes, nbschema.CommonDescriptiveAttributes("custom field"))
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 // Configure adds the provider configured client to the resource.
@@ -211,6 +213,10 @@ func (r *CustomFieldResource) Configure(ctx context.Context, req resource.Config
 
 // Create creates the resource and sets the initial Terraform state.
 func (r *CustomFieldResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
 	var data CustomFieldResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -260,6 +266,7 @@ func (r *CustomFieldResource) Create(ctx context.Context, req resource.CreateReq
 
 // Read refreshes the Terraform state with the latest data.
 func (r *CustomFieldResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data CustomFieldResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -314,6 +321,10 @@ func (r *CustomFieldResource) Read(ctx context.Context, req resource.ReadRequest
 
 // Update updates the resource and sets the updated Terraform state.
 func (r *CustomFieldResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
 	var data CustomFieldResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -373,6 +384,10 @@ func (r *CustomFieldResource) Update(ctx context.Context, req resource.UpdateReq
 
 // Delete deletes the resource and removes the Terraform state.
 func (r *CustomFieldResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data CustomFieldResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
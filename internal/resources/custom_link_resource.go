@@ -6,6 +6,7 @@ import (
 	"net/http"
 
 	"github.com/bab3l/go-netbox"
+	nbschema "github.com/bab3l/terraform-provider-netbox/internal/schema"
 	"github.com/bab3l/terraform-provider-netbox/internal/utils"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
@@ -45,6 +46,7 @@ type CustomLinkResourceModel struct {
 	GroupName   types.String `tfsdk:"group_name"`
 	ButtonClass types.String `tfsdk:"button_class"`
 	NewWindow   types.Bool   `tfsdk:"new_window"`
+	Branch      types.String `tfsdk:"branch"`
 }
 
 func (r *CustomLinkResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -115,6 +117,7 @@ func (r *CustomLinkResource) Schema(ctx context.Context, req resource.SchemaRequ
 			},
 		},
 	}
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 func (r *CustomLinkResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -134,6 +137,10 @@ func (r *CustomLinkResource) Configure(ctx context.Context, req resource.Configu
 }
 
 func (r *CustomLinkResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
 	var data CustomLinkResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -204,6 +211,7 @@ func (r *CustomLinkResource) Create(ctx context.Context, req resource.CreateRequ
 }
 
 func (r *CustomLinkResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data CustomLinkResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -237,6 +245,10 @@ func (r *CustomLinkResource) Read(ctx context.Context, req resource.ReadRequest,
 }
 
 func (r *CustomLinkResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
 	var data CustomLinkResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -322,6 +334,10 @@ func (r *CustomLinkResource) Update(ctx context.Context, req resource.UpdateRequ
 }
 
 func (r *CustomLinkResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data CustomLinkResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -26,6 +26,7 @@ var (
 	_ resource.ResourceWithConfigure   = &DeviceBayResource{}
 	_ resource.ResourceWithImportState = &DeviceBayResource{}
 	_ resource.ResourceWithIdentity    = &DeviceBayResource{}
+	_ resource.ResourceWithModifyPlan  = &DeviceBayResource{}
 )
 
 // NewDeviceBayResource returns a new resource implementing the DeviceBay resource.
@@ -40,14 +41,18 @@ type DeviceBayResource struct {
 
 // DeviceBayResourceModel describes the resource data model.
 type DeviceBayResourceModel struct {
-	ID              types.String `tfsdk:"id"`
-	Device          types.String `tfsdk:"device"`
-	Name            types.String `tfsdk:"name"`
-	Label           types.String `tfsdk:"label"`
-	Description     types.String `tfsdk:"description"`
-	InstalledDevice types.String `tfsdk:"installed_device"`
-	Tags            types.Set    `tfsdk:"tags"`
-	CustomFields    types.Set    `tfsdk:"custom_fields"`
+	ID              types.String  `tfsdk:"id"`
+	Device          types.String  `tfsdk:"device"`
+	Name            types.String  `tfsdk:"name"`
+	Label           types.String  `tfsdk:"label"`
+	Description     types.String  `tfsdk:"description"`
+	InstalledDevice types.String  `tfsdk:"installed_device"`
+	Tags            types.Set     `tfsdk:"tags"`
+	CustomFields    types.Set     `tfsdk:"custom_fields"`
+	CustomFieldsMap types.Dynamic `tfsdk:"custom_fields_map"`
+	TagsAll         types.Set     `tfsdk:"tags_all"`
+	CustomFieldsAll types.Map     `tfsdk:"custom_fields_all"`
+	Branch          types.String  `tfsdk:"branch"`
 }
 
 // Metadata returns the resource type name.
@@ -67,9 +72,12 @@ func (r *DeviceBayResource) Schema(ctx context.Context, req resource.SchemaReque
 				MarkdownDescription: "Physical label for the device bay.",
 				Optional:            true,
 			},
-			"installed_device": nbschema.ReferenceAttributeWithDiffSuppress("device", "The child device installed in this bay. Accepts ID or name."),
-			"tags":             nbschema.TagsSlugAttribute(),
-			"custom_fields":    nbschema.CustomFieldsAttribute(),
+			"installed_device":  nbschema.ReferenceAttributeWithDiffSuppress("device", "The child device installed in this bay. Accepts ID or name."),
+			"tags":              nbschema.TagsSlugAttribute(),
+			"custom_fields":     nbschema.CustomFieldsAttribute(),
+			"custom_fields_map": nbschema.CustomFieldsMapAttribute(),
+			"tags_all":          nbschema.TagsAllAttribute(),
+			"custom_fields_all": nbschema.CustomFieldsAllAttribute(),
 		},
 	}
 
@@ -77,6 +85,7 @@ func (r *DeviceBayResource) Schema(ctx context.Context, req resource.SchemaReque
 	maps.Copy(resp.Schema.Attributes, nbschema.DescriptionOnlyAttributes("device bay"))
 
 	// Tags and custom fields are defined directly in the schema above.
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 func (r *DeviceBayResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
@@ -100,8 +109,21 @@ func (r *DeviceBayResource) Configure(ctx context.Context, req resource.Configur
 	r.client = client
 }
 
+// ModifyPlan validates the configured custom fields against their definitions in NetBox
+// and plans tags_all and custom_fields_all.
+func (r *DeviceBayResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	utils.ValidateCustomFieldsPlan(ctx, r.client, "dcim.devicebay", req, resp)
+	utils.PlanEffectiveMetadata(ctx, r.client, "dcim.devicebay", req, resp)
+}
+
 // Create creates a new device bay resource.
 func (r *DeviceBayResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	defer journalCreate(ctx, r.client, "dcim.devicebay", req, resp)
+
 	var data DeviceBayResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -146,6 +168,7 @@ func (r *DeviceBayResource) Create(ctx context.Context, req resource.CreateReque
 
 // Read reads the device bay resource.
 func (r *DeviceBayResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data DeviceBayResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -191,6 +214,13 @@ func (r *DeviceBayResource) Read(ctx context.Context, req resource.ReadRequest,
 
 // Update updates the device bay resource.
 func (r *DeviceBayResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	ctx = withPriorTags(ctx, req.State)
+	defer journalUpdate(ctx, r.client, "dcim.devicebay", req, resp)
+
 	var state, data DeviceBayResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -239,6 +269,10 @@ func (r *DeviceBayResource) Update(ctx context.Context, req resource.UpdateReque
 
 // Delete deletes the device bay resource.
 func (r *DeviceBayResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data DeviceBayResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -336,6 +370,7 @@ func (r *DeviceBayResource) ImportState(ctx context.Context, req resource.Import
 		}
 		if parsed.HasCustomFields {
 			data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, db.GetCustomFields(), &resp.Diagnostics)
+			data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, db.GetCustomFields(), &resp.Diagnostics)
 		} else {
 			data.CustomFields = types.SetNull(utils.GetCustomFieldsAttributeType().ElemType)
 		}
@@ -416,6 +451,10 @@ func (r *DeviceBayResource) buildRequest(ctx context.Context, data, state *Devic
 		stateCustomFields = types.SetNull(types.StringType)
 	}
 	utils.ApplyCustomFieldsWithMerge(ctx, dbRequest, data.CustomFields, stateCustomFields, &diags)
+	utils.ApplyCustomFieldsMap(ctx, r.client, dbRequest, data.CustomFieldsMap, &diags)
+	utils.ApplyProviderTags(ctx, r.client, dbRequest, &diags)
+	utils.ApplyProviderCustomFields(ctx, r.client, "dcim.devicebay", dbRequest, &diags)
+	netboxlookup.ResolveCustomFieldReferences(ctx, r.client, dbRequest, &diags)
 	if diags.HasError() {
 		return nil, diags
 	}
@@ -454,10 +493,13 @@ func (r *DeviceBayResource) mapResponseToModel(ctx context.Context, db *netbox.D
 	}
 
 	// Tags (slug list)
-	data.Tags = utils.PopulateTagsSlugFromAPI(ctx, db.HasTags(), db.GetTags(), data.Tags)
+	data.Tags = utils.PopulateTagsSlugFromAPI(ctx, r.client, db.HasTags(), db.GetTags(), data.Tags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, db.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, db.GetCustomFields(), diags)
 
 	// Handle custom fields - use filtered-to-owned for partial management
 	if db.HasCustomFields() {
 		data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, db.GetCustomFields(), diags)
+		data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, db.GetCustomFields(), diags)
 	}
 }
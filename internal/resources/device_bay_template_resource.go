@@ -46,6 +46,7 @@ type DeviceBayTemplateResourceModel struct {
 	Name        types.String `tfsdk:"name"`
 	Label       types.String `tfsdk:"label"`
 	Description types.String `tfsdk:"description"`
+	Branch      types.String `tfsdk:"branch"`
 }
 
 // Metadata returns the resource type name.
@@ -82,6 +83,7 @@ func (r *DeviceBayTemplateResource) Schema(ctx context.Context, req resource.Sch
 
 	// Add description attribute
 	maps.Copy(resp.Schema.Attributes, nbschema.DescriptionOnlyAttributes("device bay template"))
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 // Configure adds the provider configured client to the resource.
@@ -103,6 +105,10 @@ func (r *DeviceBayTemplateResource) Configure(ctx context.Context, req resource.
 
 // Create creates the resource and sets the initial Terraform state.
 func (r *DeviceBayTemplateResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
 	var data DeviceBayTemplateResourceModel
 
 	// Read Terraform plan data into the model
@@ -163,6 +169,7 @@ func (r *DeviceBayTemplateResource) Create(ctx context.Context, req resource.Cre
 
 // Read refreshes the Terraform state with the latest data.
 func (r *DeviceBayTemplateResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data DeviceBayTemplateResourceModel
 
 	// Read Terraform prior state data into the model
@@ -225,6 +232,10 @@ func (r *DeviceBayTemplateResource) Read(ctx context.Context, req resource.ReadR
 
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *DeviceBayTemplateResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
 	var data DeviceBayTemplateResourceModel
 
 	// Read Terraform plan data into the model
@@ -302,6 +313,10 @@ func (r *DeviceBayTemplateResource) Update(ctx context.Context, req resource.Upd
 
 // Delete deletes the resource and removes the Terraform state on success.
 func (r *DeviceBayTemplateResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data DeviceBayTemplateResourceModel
 
 	// Read Terraform prior state data into the model
@@ -0,0 +1,706 @@
+// Package resources contains Terraform resource implementations for the Netbox provider.
+
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+
+	"github.com/bab3l/go-netbox"
+	"github.com/bab3l/terraform-provider-netbox/internal/netboxlookup"
+	nbschema "github.com/bab3l/terraform-provider-netbox/internal/schema"
+	"github.com/bab3l/terraform-provider-netbox/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource                   = &DeviceInterfacesResource{}
+	_ resource.ResourceWithConfigure      = &DeviceInterfacesResource{}
+	_ resource.ResourceWithImportState    = &DeviceInterfacesResource{}
+	_ resource.ResourceWithValidateConfig = &DeviceInterfacesResource{}
+	_ resource.ResourceWithModifyPlan     = &DeviceInterfacesResource{}
+)
+
+const deviceInterfacesAPIPath = "dcim/interfaces"
+
+// NewDeviceInterfacesResource returns a new resource managing all interfaces of a device.
+func NewDeviceInterfacesResource() resource.Resource {
+	return &DeviceInterfacesResource{}
+}
+
+// DeviceInterfacesResource manages a set of interfaces on one device using
+// NetBox's bulk endpoints.
+type DeviceInterfacesResource struct {
+	client *netbox.APIClient
+}
+
+// DeviceInterfacesResourceModel describes the resource data model.
+type DeviceInterfacesResourceModel struct {
+	ID                types.String `tfsdk:"id"`
+	Device            types.String `tfsdk:"device"`
+	Authoritative     types.Bool   `tfsdk:"authoritative"`
+	Interfaces        types.Map    `tfsdk:"interfaces"`
+	InterfaceIDs      types.Map    `tfsdk:"interface_ids"`
+	AdoptedInterfaces types.Set    `tfsdk:"adopted_interfaces"`
+	Branch            types.String `tfsdk:"branch"`
+}
+
+// DeviceInterfaceModel describes one entry of the interfaces map, which has
+// the settings of netbox_interface. An entry keyed by a name pattern applies
+// to every interface the pattern expands to.
+type DeviceInterfaceModel = InterfaceAttributesModel
+
+var deviceInterfaceAttrTypes = schema.NestedAttributeObject{Attributes: interfaceAttributes()}.Type().(types.ObjectType).AttrTypes
+
+// deviceInterfacesEqual reports whether two entries describe the same settings.
+func deviceInterfacesEqual(ctx context.Context, a, b DeviceInterfaceModel) bool {
+	aValue, aDiags := types.ObjectValueFrom(ctx, deviceInterfaceAttrTypes, a)
+	bValue, bDiags := types.ObjectValueFrom(ctx, deviceInterfaceAttrTypes, b)
+	return !aDiags.HasError() && !bDiags.HasError() && aValue.Equal(bValue)
+}
+
+// Metadata returns the resource type name.
+func (r *DeviceInterfacesResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_device_interfaces"
+}
+
+// Schema defines the schema for the resource.
+func (r *DeviceInterfacesResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	device := nbschema.RequiredReferenceAttributeWithDiffSuppress("device", "ID or name of the device whose interfaces are managed. Changing this forces a new resource.")
+	device.PlanModifiers = append(device.PlanModifiers, stringplanmodifier.RequiresReplace())
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a set of interfaces on one device in Netbox using the bulk API, so that large port counts are created, updated and deleted in a few requests. " +
+			"Interfaces that already exist on the device, such as those created from the device type's templates, are adopted by name. " +
+			"In authoritative mode, interfaces on the device that are not listed are deleted, and destroying the resource deletes every listed interface. " +
+			"Otherwise unlisted interfaces are ignored, and adopted interfaces are left in place when they are no longer listed or the resource is destroyed.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The unique numeric ID of the device.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"device": device,
+			"authoritative": schema.BoolAttribute{
+				MarkdownDescription: "Whether this resource owns every interface on the device. When `true`, interfaces that are not listed in `interfaces` are deleted, and so are adopted interfaces when the resource is destroyed. Defaults to `false`, which ignores unlisted interfaces.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"interfaces": schema.MapNestedAttribute{
+				MarkdownDescription: "Interfaces keyed by name, with the attributes of `netbox_interface`. A key may be a name pattern such as `ge-0/0/[0-47]` or `Ethernet[1,3,5-7]`, which applies its settings to every interface the pattern expands to. " +
+					"`parent`, `bridge` and `lag` also accept the name of another interface on the device, including one listed here.",
+				Required: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: interfaceAttributes(),
+				},
+			},
+			"interface_ids": schema.MapAttribute{
+				MarkdownDescription: "IDs of the managed interfaces, keyed by expanded interface name.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"adopted_interfaces": schema.SetAttribute{
+				MarkdownDescription: "Names of the managed interfaces that already existed on the device, such as those created from the device type's templates, or all interfaces after an import. Unless `authoritative` is set, they are left in place when they are no longer listed or the resource is destroyed.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+		},
+	}
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *DeviceInterfacesResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*netbox.APIClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *netbox.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.client = client
+}
+
+// ValidateConfig checks that every key expands to valid, distinct interface
+// names and that each entry's attributes suit its interface type.
+func (r *DeviceInterfacesResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var interfaces types.Map
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("interfaces"), &interfaces)...)
+	if resp.Diagnostics.HasError() || interfaces.IsNull() || interfaces.IsUnknown() {
+		return
+	}
+	var models map[string]DeviceInterfaceModel
+	resp.Diagnostics.Append(interfaces.ElementsAs(ctx, &models, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	expandDeviceInterfaces(models, &resp.Diagnostics)
+
+	for key, m := range models {
+		validateInterfaceAttributes(&m, func(attribute string) path.Path {
+			return path.Root("interfaces").AtMapKey(key).AtName(attribute)
+		}, &resp.Diagnostics)
+	}
+}
+
+// ModifyPlan validates the configured custom fields of each entry against
+// their definitions in NetBox.
+func (r *DeviceInterfacesResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	var interfaces types.Map
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("interfaces"), &interfaces)...)
+	if resp.Diagnostics.HasError() || !utils.IsSet(interfaces) {
+		return
+	}
+	elements := interfaces.Elements()
+	for _, key := range slices.Sorted(maps.Keys(elements)) {
+		entry, ok := elements[key].(types.Object)
+		if !ok || !utils.IsSet(entry) {
+			continue
+		}
+		customFields, _ := entry.Attributes()["custom_fields"].(types.Set)
+		utils.ValidateNestedCustomFieldsPlan(ctx, r.client, "dcim.interface", path.Root("interfaces").AtMapKey(key).AtName("custom_fields"), customFields, &resp.Diagnostics)
+	}
+}
+
+// expandDeviceInterfaces expands the keys of the interfaces map into
+// individual interface names, mapped to their keys. It reports invalid
+// patterns and names listed by more than one key.
+func expandDeviceInterfaces(models map[string]DeviceInterfaceModel, diags *diag.Diagnostics) map[string]string {
+	owners := make(map[string]string)
+	for _, key := range slices.Sorted(maps.Keys(models)) {
+		names, err := utils.ExpandNamePattern(key)
+		if err != nil {
+			diags.AddAttributeError(path.Root("interfaces").AtMapKey(key), "Invalid interface name pattern", err.Error())
+			continue
+		}
+		for _, name := range names {
+			if owner, ok := owners[name]; ok {
+				diags.AddAttributeError(
+					path.Root("interfaces").AtMapKey(key),
+					"Duplicate interface name",
+					fmt.Sprintf("Interface %q is listed by both %q and %q.", name, owner, key),
+				)
+				continue
+			}
+			owners[name] = key
+		}
+	}
+	return owners
+}
+
+// Create adopts or creates the listed interfaces, and in authoritative mode
+// deletes all others on the device.
+func (r *DeviceInterfacesResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	var data DeviceInterfacesResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deviceID, diags := netboxlookup.LookupReferenceID(ctx, r.client, "device", data.Device.ValueString())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.ID = types.StringValue(strconv.Itoa(int(deviceID)))
+
+	r.apply(ctx, &data, nil, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read refreshes the Terraform state with the interfaces on the device.
+func (r *DeviceInterfacesResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
+	var data DeviceInterfacesResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	deviceID, err := utils.ParseID(data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Device ID", fmt.Sprintf("Device ID must be a number, got: %s", data.ID.ValueString()))
+		return
+	}
+
+	current, ok := r.listInterfaces(ctx, deviceID, &resp.Diagnostics)
+	if !ok {
+		return
+	}
+	if len(current) == 0 && !r.deviceExists(ctx, deviceID, &resp.Diagnostics) {
+		if !resp.Diagnostics.HasError() {
+			tflog.Debug(ctx, "Device not found, removing interfaces from state", map[string]interface{}{"device_id": deviceID})
+			resp.State.RemoveResource(ctx)
+		}
+		return
+	}
+
+	// After an import only the device is known: adopt all its interfaces.
+	imported := data.Interfaces.IsNull()
+	if data.Device.IsNull() {
+		data.Device = data.ID
+	}
+	if data.Authoritative.IsNull() {
+		data.Authoritative = types.BoolValue(false)
+	}
+	prior := map[string]DeviceInterfaceModel{}
+	if !imported {
+		resp.Diagnostics.Append(data.Interfaces.ElementsAs(ctx, &prior, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// In authoritative mode unlisted interfaces appear in state, so the plan
+	// shows their removal.
+	interfaces, managed := r.refreshInterfaces(ctx, prior, current, imported || data.Authoritative.ValueBool(), &resp.Diagnostics)
+	adopted := deviceInterfaceNames(ctx, data.AdoptedInterfaces)
+	if imported {
+		adopted = managed
+	}
+	maps.DeleteFunc(adopted, func(name string, _ bool) bool { return !managed[name] })
+
+	data.Interfaces = interfaces
+	data.InterfaceIDs = deviceInterfaceIDs(ctx, current, managed, &resp.Diagnostics)
+	data.AdoptedInterfaces = deviceInterfaceNameSet(ctx, adopted, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update reconciles the interfaces on the device with the plan.
+func (r *DeviceInterfacesResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	var state, data DeviceInterfacesResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.ID = state.ID
+
+	r.apply(ctx, &data, &state, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete removes the managed interfaces from the device. Adopted interfaces
+// are left in place unless the resource is authoritative.
+func (r *DeviceInterfacesResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
+	var data DeviceInterfacesResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	deviceID, err := utils.ParseID(data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Device ID", fmt.Sprintf("Device ID must be a number, got: %s", data.ID.ValueString()))
+		return
+	}
+
+	var models map[string]DeviceInterfaceModel
+	resp.Diagnostics.Append(data.Interfaces.ElementsAs(ctx, &models, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	listed := expandDeviceInterfaces(models, &resp.Diagnostics)
+	adopted := deviceInterfaceNames(ctx, data.AdoptedInterfaces)
+	current, ok := r.listInterfaces(ctx, deviceID, &resp.Diagnostics)
+	if !ok {
+		return
+	}
+
+	var remove []map[string]interface{}
+	for _, name := range slices.Sorted(maps.Keys(current)) {
+		if _, ok := listed[name]; ok && (data.Authoritative.ValueBool() || !adopted[name]) {
+			remove = append(remove, map[string]interface{}{"id": current[name].GetId()})
+		}
+	}
+	tflog.Debug(ctx, "Deleting device interfaces", map[string]interface{}{
+		"device_id": deviceID,
+		"delete":    len(remove),
+	})
+	r.bulkRequest(ctx, http.MethodDelete, "delete", remove, &resp.Diagnostics)
+}
+
+// ImportState imports all interfaces of a device, given by ID.
+func (r *DeviceInterfacesResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	utils.ImportStatePassthroughIDWithValidation(ctx, req, resp, path.Root("id"), true)
+}
+
+// apply reconciles the interfaces of the device in data.ID with data.Interfaces
+// using one bulk request per operation. Interfaces that were in the prior state
+// but are no longer planned are deleted, unless they were adopted; in
+// authoritative mode every unlisted interface is deleted.
+func (r *DeviceInterfacesResource) apply(ctx context.Context, data, state *DeviceInterfacesResourceModel, diags *diag.Diagnostics) {
+	deviceID, err := utils.ParseID(data.ID.ValueString())
+	if err != nil {
+		diags.AddError("Invalid Device ID", fmt.Sprintf("Device ID must be a number, got: %s", data.ID.ValueString()))
+		return
+	}
+
+	var models, priorModels map[string]DeviceInterfaceModel
+	priorIDs := map[string]string{}
+	adopted := map[string]bool{}
+	diags.Append(data.Interfaces.ElementsAs(ctx, &models, false)...)
+	if state != nil {
+		if !state.Interfaces.IsNull() {
+			diags.Append(state.Interfaces.ElementsAs(ctx, &priorModels, false)...)
+		}
+		if utils.IsSet(state.InterfaceIDs) {
+			diags.Append(state.InterfaceIDs.ElementsAs(ctx, &priorIDs, false)...)
+		}
+		adopted = deviceInterfaceNames(ctx, state.AdoptedInterfaces)
+	}
+	if diags.HasError() {
+		return
+	}
+	for _, m := range models {
+		validateInterfaceModeAndTaggedVLANs(ctx, &m, diags)
+	}
+	desired := expandDeviceInterfaces(models, diags)
+	prior := expandDeviceInterfaces(priorModels, diags)
+	if diags.HasError() {
+		return
+	}
+
+	current, ok := r.listInterfaces(ctx, deviceID, diags)
+	if !ok {
+		return
+	}
+	requests, deferred := r.interfaceRequests(ctx, models, current, diags)
+	if diags.HasError() {
+		return
+	}
+
+	// A listed interface is adopted if it existed before this resource
+	// managed it, which is the case unless the prior state recorded its ID.
+	nowAdopted := make(map[string]bool)
+	var create, patch, remove []map[string]interface{}
+	for _, name := range slices.Sorted(maps.Keys(current)) {
+		iface := current[name]
+		if key, ok := desired[name]; ok {
+			if adopted[name] || priorIDs[name] != strconv.Itoa(int(iface.GetId())) {
+				nowAdopted[name] = true
+			}
+			if m := models[key]; !deviceInterfacesEqual(ctx, deviceInterfaceFromAPI(ctx, r.client, iface, m, diags), m) {
+				patch = append(patch, r.interfacePayload(ctx, requests[key], deviceID, name, iface, diags))
+			}
+			continue
+		}
+		_, wasManaged := prior[name]
+		if data.Authoritative.ValueBool() || (wasManaged && !adopted[name]) {
+			remove = append(remove, map[string]interface{}{"id": iface.GetId()})
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(desired)) {
+		if _, ok := current[name]; !ok {
+			create = append(create, r.interfacePayload(ctx, requests[desired[name]], deviceID, name, nil, diags))
+		}
+	}
+	if diags.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Reconciling device interfaces", map[string]interface{}{
+		"device_id": deviceID,
+		"create":    len(create),
+		"update":    len(patch),
+		"delete":    len(remove),
+	})
+	r.bulkRequest(ctx, http.MethodDelete, "delete", remove, diags)
+	r.bulkRequest(ctx, http.MethodPatch, "update", patch, diags)
+	r.bulkRequest(ctx, http.MethodPost, "create", create, diags)
+	if diags.HasError() {
+		return
+	}
+
+	current, ok = r.listInterfaces(ctx, deviceID, diags)
+	if !ok {
+		return
+	}
+	if len(deferred) > 0 {
+		// Set the parent, bridge and lag references to interfaces that did not
+		// exist before this apply.
+		deferredModels := make(map[string]DeviceInterfaceModel, len(deferred))
+		for key := range deferred {
+			deferredModels[key] = models[key]
+		}
+		requests, unresolved := r.interfaceRequests(ctx, deferredModels, current, diags)
+		for _, key := range slices.Sorted(maps.Keys(unresolved)) {
+			diags.AddAttributeError(
+				path.Root("interfaces").AtMapKey(key),
+				"Interface not found",
+				"`parent`, `bridge` or `lag` names an interface that does not exist on the device.",
+			)
+		}
+		var references []map[string]interface{}
+		for _, name := range slices.Sorted(maps.Keys(desired)) {
+			if request, ok := requests[desired[name]]; ok && current[name] != nil {
+				references = append(references, r.interfacePayload(ctx, request, deviceID, name, current[name], diags))
+			}
+		}
+		r.bulkRequest(ctx, http.MethodPatch, "update", references, diags)
+		if diags.HasError() {
+			return
+		}
+		if current, ok = r.listInterfaces(ctx, deviceID, diags); !ok {
+			return
+		}
+	}
+
+	interfaces, managed := r.refreshInterfaces(ctx, models, current, false, diags)
+	maps.DeleteFunc(nowAdopted, func(name string, _ bool) bool { return !managed[name] })
+	data.Interfaces = interfaces
+	data.InterfaceIDs = deviceInterfaceIDs(ctx, current, managed, diags)
+	data.AdoptedInterfaces = deviceInterfaceNameSet(ctx, nowAdopted, diags)
+}
+
+// interfaceRequests builds the write request of each entry, with the parent,
+// bridge and lag names resolved to the IDs of the current interfaces. Entries
+// naming interfaces that do not exist yet are returned as deferred, with those
+// references left out of their requests.
+func (r *DeviceInterfacesResource) interfaceRequests(ctx context.Context, models map[string]DeviceInterfaceModel, current map[string]*netbox.Interface, diags *diag.Diagnostics) (map[string]*netbox.WritableInterfaceRequest, map[string]bool) {
+	requests := make(map[string]*netbox.WritableInterfaceRequest, len(models))
+	deferred := make(map[string]bool)
+	for key, m := range models {
+		for _, reference := range []*types.String{&m.Parent, &m.Bridge, &m.Lag} {
+			if !utils.IsSet(*reference) {
+				continue
+			}
+			if _, err := strconv.ParseInt(reference.ValueString(), 10, 32); err == nil {
+				continue
+			}
+			if iface, ok := current[reference.ValueString()]; ok {
+				*reference = types.StringValue(strconv.Itoa(int(iface.GetId())))
+			} else {
+				*reference = types.StringNull()
+				deferred[key] = true
+			}
+		}
+
+		interfaceReq := netbox.NewWritableInterfaceRequest(netbox.BriefDeviceRequest{}, "", netbox.InterfaceTypeValue(m.Type.ValueString()))
+		setInterfaceOptionalFields(ctx, r.client, interfaceReq, &m, diags)
+		utils.ApplyTagsFromSlugs(ctx, r.client, interfaceReq, m.Tags, diags)
+		utils.ApplyCustomFields(ctx, interfaceReq, m.CustomFields, diags)
+		utils.ApplyProviderTags(ctx, r.client, interfaceReq, diags)
+		utils.ApplyProviderCustomFields(ctx, r.client, "dcim.interface", interfaceReq, diags)
+		netboxlookup.ResolveCustomFieldReferences(ctx, r.client, interfaceReq, diags)
+		if diags.HasError() {
+			return nil, nil
+		}
+		requests[key] = interfaceReq
+	}
+	return requests, deferred
+}
+
+// interfacePayload builds the bulk payload for one interface from the request
+// of its entry. The ignored tags of an existing interface are kept.
+func (r *DeviceInterfacesResource) interfacePayload(ctx context.Context, request *netbox.WritableInterfaceRequest, deviceID int32, name string, existing *netbox.Interface, diags *diag.Diagnostics) map[string]interface{} {
+	interfaceReq := *request
+	interfaceReq.Name = name
+	if existing != nil && interfaceReq.HasTags() {
+		slugs := make([]string, 0, len(existing.GetTags()))
+		for _, tag := range existing.GetTags() {
+			slugs = append(slugs, tag.GetSlug())
+		}
+		utils.ApplyProviderTags(utils.ContextWithPriorTags(ctx, slugs), r.client, &interfaceReq, diags)
+	}
+
+	var payload map[string]interface{}
+	body, err := json.Marshal(interfaceReq)
+	if err == nil {
+		err = json.Unmarshal(body, &payload)
+	}
+	if err != nil {
+		diags.AddError("Error building interface request", fmt.Sprintf("Could not encode interface %q: %s", name, err))
+		return nil
+	}
+	payload["device"] = deviceID
+	if existing != nil {
+		payload["id"] = existing.GetId()
+	}
+	return payload
+}
+
+// refreshInterfaces maps the current interfaces to the entries of prior,
+// dropping entries with missing interfaces so that they are re-created. With
+// unlisted set, interfaces not covered by prior are added as entries of their
+// own. It also returns the names of the interfaces in the result.
+func (r *DeviceInterfacesResource) refreshInterfaces(ctx context.Context, prior map[string]DeviceInterfaceModel, current map[string]*netbox.Interface, unlisted bool, diags *diag.Diagnostics) (types.Map, map[string]bool) {
+	refreshed := make(map[string]DeviceInterfaceModel, len(prior))
+	managed := make(map[string]bool)
+	for key, priorModel := range prior {
+		names, err := utils.ExpandNamePattern(key)
+		if err != nil {
+			continue
+		}
+		var model *DeviceInterfaceModel
+		complete := true
+		for _, name := range names {
+			iface, ok := current[name]
+			if !ok {
+				complete = false
+				continue
+			}
+			managed[name] = true
+			// Report the first interface that drifted, so a pattern shows a diff
+			// when any of its members has changed.
+			m := deviceInterfaceFromAPI(ctx, r.client, iface, priorModel, diags)
+			if model == nil || (deviceInterfacesEqual(ctx, *model, priorModel) && !deviceInterfacesEqual(ctx, m, priorModel)) {
+				model = &m
+			}
+		}
+		if complete && model != nil {
+			refreshed[key] = *model
+		}
+	}
+	if unlisted {
+		for name, iface := range current {
+			if managed[name] {
+				continue
+			}
+			m := deviceInterfaceFromAPI(ctx, r.client, iface, DeviceInterfaceModel{
+				Tags:         types.SetNull(types.StringType),
+				CustomFields: types.SetNull(utils.GetCustomFieldsAttributeType().ElemType),
+			}, diags)
+			m.Tags = utils.PopulateTagsSlugFromAPI(ctx, r.client, iface.HasTags(), iface.GetTags(), m.Tags)
+			refreshed[name] = m
+			managed[name] = true
+		}
+	}
+
+	interfaces, mapDiags := types.MapValueFrom(ctx, types.ObjectType{AttrTypes: deviceInterfaceAttrTypes}, refreshed)
+	diags.Append(mapDiags...)
+	return interfaces, managed
+}
+
+// deviceInterfaceFromAPI maps an interface to an entry, keeping the form of
+// the references, tags and custom fields in prior.
+func deviceInterfaceFromAPI(ctx context.Context, client *netbox.APIClient, iface *netbox.Interface, prior DeviceInterfaceModel, diags *diag.Diagnostics) DeviceInterfaceModel {
+	m := prior
+	mapInterfaceAttributes(ctx, client, iface, &m, diags)
+	return m
+}
+
+// bulkRequest sends one bulk request for a list of interface payloads.
+func (r *DeviceInterfacesResource) bulkRequest(ctx context.Context, method, operation string, payloads []map[string]interface{}, diags *diag.Diagnostics) {
+	if len(payloads) == 0 || diags.HasError() {
+		return
+	}
+	_, httpResp, err := utils.DoRawAPIRequest(ctx, r.client, method, deviceInterfacesAPIPath, nil, payloads)
+	if err != nil {
+		diags.AddError(
+			fmt.Sprintf("Error bulk %s of interfaces", operation),
+			utils.FormatAPIError(fmt.Sprintf("%s %d interfaces", operation, len(payloads)), err, httpResp),
+		)
+	}
+}
+
+// listInterfaces returns the interfaces on a device keyed by name.
+func (r *DeviceInterfacesResource) listInterfaces(ctx context.Context, deviceID int32, diags *diag.Diagnostics) (map[string]*netbox.Interface, bool) {
+	query := url.Values{"device_id": {strconv.Itoa(int(deviceID))}}
+	results, httpResp, err := utils.ListRawAPIObjects(ctx, r.client, deviceInterfacesAPIPath, query)
+	if err != nil {
+		diags.AddError(
+			"Error reading device interfaces",
+			utils.FormatAPIError(fmt.Sprintf("list interfaces of device ID %d", deviceID), err, httpResp),
+		)
+		return nil, false
+	}
+	interfaces := make(map[string]*netbox.Interface, len(results))
+	for _, result := range results {
+		var iface netbox.Interface
+		if err := json.Unmarshal(result, &iface); err != nil {
+			diags.AddError("Error reading device interfaces", fmt.Sprintf("Could not decode interface: %s", err))
+			return nil, false
+		}
+		interfaces[iface.GetName()] = &iface
+	}
+	return interfaces, true
+}
+
+// deviceExists checks whether the device is still present in NetBox.
+func (r *DeviceInterfacesResource) deviceExists(ctx context.Context, deviceID int32, diags *diag.Diagnostics) bool {
+	_, httpResp, err := utils.DoRawAPIRequest(ctx, r.client, http.MethodGet, fmt.Sprintf("dcim/devices/%d", deviceID), nil, nil)
+	if err == nil {
+		return true
+	}
+	if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
+		return false
+	}
+	diags.AddError("Error reading device", utils.FormatAPIError(fmt.Sprintf("read device ID %d", deviceID), err, httpResp))
+	return false
+}
+
+// deviceInterfaceIDs maps the managed interface names to their IDs.
+func deviceInterfaceIDs(ctx context.Context, current map[string]*netbox.Interface, managed map[string]bool, diags *diag.Diagnostics) types.Map {
+	ids := make(map[string]string, len(managed))
+	for name := range managed {
+		if iface, ok := current[name]; ok {
+			ids[name] = strconv.Itoa(int(iface.GetId()))
+		}
+	}
+	value, mapDiags := types.MapValueFrom(ctx, types.StringType, ids)
+	diags.Append(mapDiags...)
+	return value
+}
+
+// deviceInterfaceNames returns the interface names in a set.
+func deviceInterfaceNames(ctx context.Context, set types.Set) map[string]bool {
+	names := make(map[string]bool)
+	if utils.IsSet(set) {
+		for _, name := range utils.SetToStringSlice(ctx, set) {
+			names[name] = true
+		}
+	}
+	return names
+}
+
+// deviceInterfaceNameSet returns the interface names as a set.
+func deviceInterfaceNameSet(ctx context.Context, names map[string]bool, diags *diag.Diagnostics) types.Set {
+	value, setDiags := types.SetValueFrom(ctx, types.StringType, slices.Sorted(maps.Keys(names)))
+	diags.Append(setDiags...)
+	return value
+}
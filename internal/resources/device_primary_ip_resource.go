@@ -44,6 +44,7 @@ type DevicePrimaryIPResourceModel struct {
 	PrimaryIP4 types.String `tfsdk:"primary_ip4"`
 	PrimaryIP6 types.String `tfsdk:"primary_ip6"`
 	OobIP      types.String `tfsdk:"oob_ip"`
+	Branch     types.String `tfsdk:"branch"`
 }
 
 // Metadata returns the resource type name.
@@ -78,6 +79,7 @@ func (r *DevicePrimaryIPResource) Schema(ctx context.Context, req resource.Schem
 			},
 		},
 	}
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 // Configure adds the provider configured client to the resource.
@@ -98,6 +100,10 @@ func (r *DevicePrimaryIPResource) Configure(ctx context.Context, req resource.Co
 
 // Create sets primary IP assignments for a device.
 func (r *DevicePrimaryIPResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
 	var data DevicePrimaryIPResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -145,6 +151,7 @@ func (r *DevicePrimaryIPResource) Create(ctx context.Context, req resource.Creat
 
 // Read refreshes the Terraform state with the latest data.
 func (r *DevicePrimaryIPResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data DevicePrimaryIPResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -182,6 +189,10 @@ func (r *DevicePrimaryIPResource) Read(ctx context.Context, req resource.ReadReq
 
 // Update updates primary IP assignments for a device.
 func (r *DevicePrimaryIPResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
 	var data DevicePrimaryIPResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -221,6 +232,10 @@ func (r *DevicePrimaryIPResource) Update(ctx context.Context, req resource.Updat
 
 // Delete clears the primary IP assignments for a device.
 func (r *DevicePrimaryIPResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data DevicePrimaryIPResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -19,6 +19,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -31,9 +32,11 @@ import (
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var (
-	_ resource.Resource                = &DeviceResource{}
-	_ resource.ResourceWithImportState = &DeviceResource{}
-	_ resource.ResourceWithIdentity    = &DeviceResource{}
+	_ resource.Resource                   = &DeviceResource{}
+	_ resource.ResourceWithImportState    = &DeviceResource{}
+	_ resource.ResourceWithIdentity       = &DeviceResource{}
+	_ resource.ResourceWithValidateConfig = &DeviceResource{}
+	_ resource.ResourceWithModifyPlan     = &DeviceResource{}
 )
 
 func NewDeviceResource() resource.Resource {
@@ -65,6 +68,7 @@ type DeviceResourceModel struct {
 	Longitude        types.Float64 `tfsdk:"longitude"`
 	Status           types.String  `tfsdk:"status"`
 	Airflow          types.String  `tfsdk:"airflow"`
+	VirtualChassis   types.String  `tfsdk:"virtual_chassis"`
 	VcPosition       types.Int64   `tfsdk:"vc_position"`
 	VcPriority       types.Int64   `tfsdk:"vc_priority"`
 	Description      types.String  `tfsdk:"description"`
@@ -73,6 +77,10 @@ type DeviceResourceModel struct {
 	LocalContextData types.String  `tfsdk:"local_context_data"`
 	Tags             types.Set     `tfsdk:"tags"`
 	CustomFields     types.Set     `tfsdk:"custom_fields"`
+	CustomFieldsMap  types.Dynamic `tfsdk:"custom_fields_map"`
+	TagsAll          types.Set     `tfsdk:"tags_all"`
+	CustomFieldsAll  types.Map     `tfsdk:"custom_fields_all"`
+	Branch           types.String  `tfsdk:"branch"`
 }
 
 func (r *DeviceResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -139,15 +147,16 @@ func (r *DeviceResource) Schema(ctx context.Context, req resource.SchemaRequest,
 					stringvalidator.OneOf("front-to-rear", "rear-to-front", "left-to-right", "right-to-left", "side-to-rear", "passive", "mixed", ""),
 				},
 			},
+			"virtual_chassis": virtualChassisAttribute(),
 			"vc_position": schema.Int64Attribute{
-				MarkdownDescription: "Position within a virtual chassis (0-255).",
+				MarkdownDescription: "Position within a virtual chassis (0-255). Required when `virtual_chassis` is set. NetBox assigns position 1 to the master of a virtual chassis.",
 				Optional:            true,
 				Validators: []validator.Int64{
 					int64validator.Between(0, 255),
 				},
 			},
 			"vc_priority": schema.Int64Attribute{
-				MarkdownDescription: "Virtual chassis master election priority (0-255).",
+				MarkdownDescription: "Virtual chassis master election priority (0-255). The master of a virtual chassis sets it without `virtual_chassis`.",
 				Optional:            true,
 				Validators: []validator.Int64{
 					int64validator.Between(0, 255),
@@ -171,6 +180,43 @@ func (r *DeviceResource) Schema(ctx context.Context, req resource.SchemaRequest,
 	// Add tags and custom fields
 	resp.Schema.Attributes["tags"] = nbschema.TagsSlugAttribute()
 	resp.Schema.Attributes["custom_fields"] = nbschema.CustomFieldsAttribute()
+	resp.Schema.Attributes["custom_fields_map"] = nbschema.CustomFieldsMapAttribute()
+	resp.Schema.Attributes["tags_all"] = nbschema.TagsAllAttribute()
+	resp.Schema.Attributes["custom_fields_all"] = nbschema.CustomFieldsAllAttribute()
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
+}
+
+// virtualChassisAttribute returns the virtual_chassis reference attribute. It is
+// also computed because NetBox adds the master device to its virtual chassis when
+// `master` is set on netbox_virtual_chassis; configuring the membership on the
+// master device as well would create a dependency cycle.
+func virtualChassisAttribute() schema.StringAttribute {
+	attr := nbschema.ReferenceAttributeWithDiffSuppress(
+		"virtual chassis",
+		"ID or name of the virtual chassis this device is a member of. Requires `vc_position`. "+
+			"Leave unset on the master device when `master` is set on `netbox_virtual_chassis` (set only `vc_position = 1` and optionally `vc_priority`): NetBox adds the master to the virtual chassis itself, and the value is then computed. Removing the attribute keeps the current membership.",
+	)
+	attr.Computed = true
+	return attr
+}
+
+// ValidateConfig checks that a virtual chassis membership includes a position.
+func (r *DeviceResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var virtualChassis types.String
+	var vcPosition types.Int64
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("virtual_chassis"), &virtualChassis)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("vc_position"), &vcPosition)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !virtualChassis.IsNull() && vcPosition.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("vc_position"),
+			"Missing virtual chassis position",
+			"`vc_position` must be set when `virtual_chassis` is set.",
+		)
+	}
 }
 
 func (r *DeviceResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
@@ -192,7 +238,20 @@ func (r *DeviceResource) Configure(ctx context.Context, req resource.ConfigureRe
 	r.client = client
 }
 
+// ModifyPlan validates the configured custom fields against their definitions in NetBox
+// and plans tags_all and custom_fields_all.
+func (r *DeviceResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	utils.ValidateCustomFieldsPlan(ctx, r.client, "dcim.device", req, resp)
+	utils.PlanEffectiveMetadata(ctx, r.client, "dcim.device", req, resp)
+}
+
 func (r *DeviceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	defer journalCreate(ctx, r.client, "dcim.device", req, resp)
+
 	var data DeviceResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -261,6 +320,15 @@ func (r *DeviceResource) Create(ctx context.Context, req resource.CreateRequest,
 		deviceRequest.SetCluster(*cluster)
 	}
 
+	if !data.VirtualChassis.IsNull() && !data.VirtualChassis.IsUnknown() {
+		virtualChassis, diags := netboxlookup.LookupVirtualChassis(ctx, r.client, data.VirtualChassis.ValueString())
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		deviceRequest.SetVirtualChassis(*virtualChassis)
+	}
+
 	if !data.Serial.IsNull() && !data.Serial.IsUnknown() {
 		serial := data.Serial.ValueString()
 		deviceRequest.Serial = &serial
@@ -365,6 +433,10 @@ func (r *DeviceResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 	utils.ApplyCustomFields(ctx, &deviceRequest, data.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, &deviceRequest, data.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, &deviceRequest, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "dcim.device", &deviceRequest, &resp.Diagnostics)
+	netboxlookup.ResolveCustomFieldReferences(ctx, r.client, &deviceRequest, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -394,12 +466,15 @@ func (r *DeviceResource) Create(ctx context.Context, req resource.CreateRequest,
 	}
 
 	// Apply filter-to-owned pattern for tags
-	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, device.HasTags(), device.GetTags(), planTags)
+	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, device.HasTags(), device.GetTags(), planTags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, device.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, device.GetCustomFields(), &resp.Diagnostics)
 
 	// Apply filter-to-owned pattern for custom fields:
 	// Only return custom fields that the user declared in their config.
 	// This prevents the framework from seeing extra fields from the API.
 	data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, planCustomFields, device.GetCustomFields(), &resp.Diagnostics)
+	data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, device.GetCustomFields(), &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -409,6 +484,7 @@ func (r *DeviceResource) Create(ctx context.Context, req resource.CreateRequest,
 }
 
 func (r *DeviceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data DeviceResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -463,7 +539,9 @@ func (r *DeviceResource) Read(ctx context.Context, req resource.ReadRequest, res
 	}
 
 	// Apply filter-to-owned pattern for tags
-	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, device.HasTags(), device.GetTags(), originalTags)
+	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, device.HasTags(), device.GetTags(), originalTags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, device.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, device.GetCustomFields(), &resp.Diagnostics)
 
 	// If custom_fields was null or empty before (not managed or explicitly cleared),
 	// restore that state after mapping.
@@ -481,6 +559,13 @@ func (r *DeviceResource) Read(ctx context.Context, req resource.ReadRequest, res
 }
 
 func (r *DeviceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	ctx = withPriorTags(ctx, req.State)
+	defer journalUpdate(ctx, r.client, "dcim.device", req, resp)
+
 	// Read both state and plan for merge-aware custom fields handling
 	var state, plan DeviceResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -563,6 +648,17 @@ func (r *DeviceResource) Update(ctx context.Context, req resource.UpdateRequest,
 		deviceRequest.SetClusterNil()
 	}
 
+	if !plan.VirtualChassis.IsNull() && !plan.VirtualChassis.IsUnknown() {
+		virtualChassis, diags := netboxlookup.LookupVirtualChassis(ctx, r.client, plan.VirtualChassis.ValueString())
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		deviceRequest.SetVirtualChassis(*virtualChassis)
+	} else if plan.VirtualChassis.IsNull() {
+		deviceRequest.SetVirtualChassisNil()
+	}
+
 	if !plan.Serial.IsNull() && !plan.Serial.IsUnknown() {
 		serial := plan.Serial.ValueString()
 		deviceRequest.Serial = &serial
@@ -680,6 +776,10 @@ func (r *DeviceResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 	utils.ApplyCustomFieldsWithMerge(ctx, &deviceRequest, plan.CustomFields, state.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, &deviceRequest, plan.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, &deviceRequest, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "dcim.device", &deviceRequest, &resp.Diagnostics)
+	netboxlookup.ResolveCustomFieldReferences(ctx, r.client, &deviceRequest, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -709,7 +809,9 @@ func (r *DeviceResource) Update(ctx context.Context, req resource.UpdateRequest,
 	}
 
 	// Apply filter-to-owned pattern for tags
-	plan.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, device.HasTags(), device.GetTags(), planTags)
+	plan.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, device.HasTags(), device.GetTags(), planTags)
+	plan.TagsAll = utils.TagsAllFromAPI(ctx, device.GetTags())
+	plan.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, plan.CustomFieldsAll, device.GetCustomFields(), &resp.Diagnostics)
 
 	// Apply filter-to-owned pattern for custom fields:
 	// Only return custom fields that the user declared in their config.
@@ -717,6 +819,7 @@ func (r *DeviceResource) Update(ctx context.Context, req resource.UpdateRequest,
 	// The ApplyCommonFieldsWithMerge already preserved unmanaged fields in the API,
 	// we just don't expose them in state.
 	plan.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, planCustomFields, device.GetCustomFields(), &resp.Diagnostics)
+	plan.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, plan.CustomFieldsMap, device.GetCustomFields(), &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -726,6 +829,10 @@ func (r *DeviceResource) Update(ctx context.Context, req resource.UpdateRequest,
 }
 
 func (r *DeviceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data DeviceResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -842,9 +949,12 @@ func (r *DeviceResource) ImportState(ctx context.Context, req resource.ImportSta
 		if resp.Diagnostics.HasError() {
 			return
 		}
-		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, device.HasTags(), device.GetTags(), data.Tags)
+		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, r.client, device.HasTags(), device.GetTags(), data.Tags)
+		data.TagsAll = utils.TagsAllFromAPI(ctx, device.GetTags())
+		data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, device.GetCustomFields(), &resp.Diagnostics)
 		if parsed.HasCustomFields {
 			data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, device.GetCustomFields(), &resp.Diagnostics)
+			data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, device.GetCustomFields(), &resp.Diagnostics)
 		} else {
 			data.CustomFields = types.SetNull(utils.GetCustomFieldsAttributeType().ElemType)
 		}
@@ -1035,6 +1145,14 @@ func (r *DeviceResource) mapDeviceToState(ctx context.Context, device *netbox.De
 	}
 	// Otherwise preserve user's configured value (null or explicit value)
 
+	// Handle virtual_chassis
+	if device.HasVirtualChassis() && device.VirtualChassis.Get() != nil {
+		vcObj := device.VirtualChassis.Get()
+		data.VirtualChassis = utils.UpdateReferenceAttribute(data.VirtualChassis, vcObj.GetName(), "", vcObj.GetId())
+	} else {
+		data.VirtualChassis = types.StringNull()
+	}
+
 	// Handle vc_position
 	if device.HasVcPosition() && device.VcPosition.Get() != nil {
 		data.VcPosition = types.Int64Value(int64(*device.VcPosition.Get()))
@@ -1096,5 +1214,6 @@ func (r *DeviceResource) mapDeviceToState(ctx context.Context, device *netbox.De
 	}
 
 	// Handle custom fields using consolidated helper
-	data.CustomFields = utils.PopulateCustomFieldsFromAPI(ctx, device.HasCustomFields(), device.GetCustomFields(), data.CustomFields, diags)
+	data.CustomFields = utils.PopulateCustomFieldsFromAPI(ctx, r.client, device.HasCustomFields(), device.GetCustomFields(), data.CustomFields, diags)
+	data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, device.GetCustomFields(), diags)
 }
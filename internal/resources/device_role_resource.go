@@ -25,6 +25,7 @@ var (
 	_ resource.Resource                = &DeviceRoleResource{}
 	_ resource.ResourceWithImportState = &DeviceRoleResource{}
 	_ resource.ResourceWithIdentity    = &DeviceRoleResource{}
+	_ resource.ResourceWithModifyPlan  = &DeviceRoleResource{}
 )
 
 func NewDeviceRoleResource() resource.Resource {
@@ -38,15 +39,19 @@ type DeviceRoleResource struct {
 
 // DeviceRoleResourceModel describes the resource data model.
 type DeviceRoleResourceModel struct {
-	ID             types.String `tfsdk:"id"`
-	Name           types.String `tfsdk:"name"`
-	Slug           types.String `tfsdk:"slug"`
-	Color          types.String `tfsdk:"color"`
-	VMRole         types.Bool   `tfsdk:"vm_role"`
-	ConfigTemplate types.String `tfsdk:"config_template"`
-	Description    types.String `tfsdk:"description"`
-	Tags           types.Set    `tfsdk:"tags"`
-	CustomFields   types.Set    `tfsdk:"custom_fields"`
+	ID              types.String  `tfsdk:"id"`
+	Name            types.String  `tfsdk:"name"`
+	Slug            types.String  `tfsdk:"slug"`
+	Color           types.String  `tfsdk:"color"`
+	VMRole          types.Bool    `tfsdk:"vm_role"`
+	ConfigTemplate  types.String  `tfsdk:"config_template"`
+	Description     types.String  `tfsdk:"description"`
+	Tags            types.Set     `tfsdk:"tags"`
+	CustomFields    types.Set     `tfsdk:"custom_fields"`
+	CustomFieldsMap types.Dynamic `tfsdk:"custom_fields_map"`
+	TagsAll         types.Set     `tfsdk:"tags_all"`
+	CustomFieldsAll types.Map     `tfsdk:"custom_fields_all"`
+	Branch          types.String  `tfsdk:"branch"`
 }
 
 func (r *DeviceRoleResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -57,14 +62,17 @@ func (r *DeviceRoleResource) Schema(ctx context.Context, req resource.SchemaRequ
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Manages a device role in Netbox. Device roles are used to categorize devices by their function within the network infrastructure (e.g., 'Router', 'Switch', 'Server', 'Firewall').",
 		Attributes: map[string]schema.Attribute{
-			"id":              nbschema.IDAttribute("device role"),
-			"name":            nbschema.NameAttribute("device role", 100),
-			"slug":            nbschema.SlugAttribute("device role"),
-			"color":           nbschema.ComputedColorAttribute("device role"),
-			"vm_role":         nbschema.BoolAttributeWithDefault("Whether virtual machines may be assigned to this role. Set to true to allow VMs to use this role, false otherwise. Defaults to true.", true),
-			"config_template": nbschema.ReferenceAttributeWithDiffSuppress("config template", "ID or name of the config template assigned to this device role."),
-			"tags":            nbschema.TagsSlugAttribute(),
-			"custom_fields":   nbschema.CustomFieldsAttribute(),
+			"id":                nbschema.IDAttribute("device role"),
+			"name":              nbschema.NameAttribute("device role", 100),
+			"slug":              nbschema.SlugAttribute("device role"),
+			"color":             nbschema.ComputedColorAttribute("device role"),
+			"vm_role":           nbschema.BoolAttributeWithDefault("Whether virtual machines may be assigned to this role. Set to true to allow VMs to use this role, false otherwise. Defaults to true.", true),
+			"config_template":   nbschema.ReferenceAttributeWithDiffSuppress("config template", "ID or name of the config template assigned to this device role."),
+			"tags":              nbschema.TagsSlugAttribute(),
+			"custom_fields":     nbschema.CustomFieldsAttribute(),
+			"custom_fields_map": nbschema.CustomFieldsMapAttribute(),
+			"tags_all":          nbschema.TagsAllAttribute(),
+			"custom_fields_all": nbschema.CustomFieldsAllAttribute(),
 		},
 	}
 
@@ -72,6 +80,7 @@ func (r *DeviceRoleResource) Schema(ctx context.Context, req resource.SchemaRequ
 	maps.Copy(resp.Schema.Attributes, nbschema.DescriptionOnlyAttributes("device role"))
 
 	// Tags and custom fields are defined directly in the schema above.
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 func (r *DeviceRoleResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
@@ -139,7 +148,20 @@ func (r *DeviceRoleResource) mapDeviceRoleToState(deviceRole *netbox.DeviceRole,
 	// Tags and custom fields are handled in Create/Read/Update methods using filter-to-owned pattern.
 }
 
+// ModifyPlan validates the configured custom fields against their definitions in NetBox
+// and plans tags_all and custom_fields_all.
+func (r *DeviceRoleResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	utils.ValidateCustomFieldsPlan(ctx, r.client, "dcim.devicerole", req, resp)
+	utils.PlanEffectiveMetadata(ctx, r.client, "dcim.devicerole", req, resp)
+}
+
 func (r *DeviceRoleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	defer journalCreate(ctx, r.client, "dcim.devicerole", req, resp)
+
 	var data DeviceRoleResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -189,6 +211,10 @@ func (r *DeviceRoleResource) Create(ctx context.Context, req resource.CreateRequ
 
 	// Handle custom fields (no merge needed for Create)
 	utils.ApplyCustomFields(ctx, &deviceRoleRequest, data.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, &deviceRoleRequest, data.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, &deviceRoleRequest, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "dcim.devicerole", &deviceRoleRequest, &resp.Diagnostics)
+	netboxlookup.ResolveCustomFieldReferences(ctx, r.client, &deviceRoleRequest, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -218,13 +244,17 @@ func (r *DeviceRoleResource) Create(ctx context.Context, req resource.CreateRequ
 	}
 
 	// Apply filter-to-owned pattern for tags
-	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, deviceRole.HasTags(), deviceRole.GetTags(), planTags)
+	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, deviceRole.HasTags(), deviceRole.GetTags(), planTags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, deviceRole.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, deviceRole.GetCustomFields(), &resp.Diagnostics)
 	data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, planCustomFields, deviceRole.GetCustomFields(), &resp.Diagnostics)
+	data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, deviceRole.GetCustomFields(), &resp.Diagnostics)
 	utils.SetIdentityCustomFields(ctx, resp.Identity, types.StringValue(data.ID.ValueString()), data.CustomFields, &resp.Diagnostics)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *DeviceRoleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data DeviceRoleResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -280,11 +310,16 @@ func (r *DeviceRoleResource) Read(ctx context.Context, req resource.ReadRequest,
 
 	// Apply filter-to-owned pattern for tags; on import, populate from API
 	if originalTags.IsUnknown() {
-		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, deviceRole.HasTags(), deviceRole.GetTags(), originalTags)
+		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, r.client, deviceRole.HasTags(), deviceRole.GetTags(), originalTags)
+		data.TagsAll = utils.TagsAllFromAPI(ctx, deviceRole.GetTags())
+		data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, deviceRole.GetCustomFields(), &resp.Diagnostics)
 	} else {
-		data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, deviceRole.HasTags(), deviceRole.GetTags(), originalTags)
+		data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, deviceRole.HasTags(), deviceRole.GetTags(), originalTags)
+		data.TagsAll = utils.TagsAllFromAPI(ctx, deviceRole.GetTags())
+		data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, deviceRole.GetCustomFields(), &resp.Diagnostics)
 	}
 	data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, originalCustomFields, deviceRole.GetCustomFields(), &resp.Diagnostics)
+	data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, deviceRole.GetCustomFields(), &resp.Diagnostics)
 
 	// Preserve original custom_fields state if it was null or empty
 	// This prevents unmanaged/cleared fields from reappearing in state
@@ -298,6 +333,13 @@ func (r *DeviceRoleResource) Read(ctx context.Context, req resource.ReadRequest,
 }
 
 func (r *DeviceRoleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	ctx = withPriorTags(ctx, req.State)
+	defer journalUpdate(ctx, r.client, "dcim.devicerole", req, resp)
+
 	var plan DeviceRoleResourceModel
 	var state DeviceRoleResourceModel
 
@@ -369,6 +411,10 @@ func (r *DeviceRoleResource) Update(ctx context.Context, req resource.UpdateRequ
 
 	// Handle custom fields with merge-aware logic
 	utils.ApplyCustomFieldsWithMerge(ctx, &deviceRoleRequest, plan.CustomFields, state.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, &deviceRoleRequest, plan.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, &deviceRoleRequest, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "dcim.devicerole", &deviceRoleRequest, &resp.Diagnostics)
+	netboxlookup.ResolveCustomFieldReferences(ctx, r.client, &deviceRoleRequest, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -398,13 +444,20 @@ func (r *DeviceRoleResource) Update(ctx context.Context, req resource.UpdateRequ
 	}
 
 	// Apply filter-to-owned pattern for tags
-	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, deviceRole.HasTags(), deviceRole.GetTags(), plan.Tags)
+	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, deviceRole.HasTags(), deviceRole.GetTags(), plan.Tags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, deviceRole.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, deviceRole.GetCustomFields(), &resp.Diagnostics)
 	data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, plan.CustomFields, deviceRole.GetCustomFields(), &resp.Diagnostics)
+	data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, deviceRole.GetCustomFields(), &resp.Diagnostics)
 	utils.SetIdentityCustomFields(ctx, resp.Identity, types.StringValue(data.ID.ValueString()), data.CustomFields, &resp.Diagnostics)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *DeviceRoleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data DeviceRoleResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -490,7 +543,9 @@ func (r *DeviceRoleResource) ImportState(ctx context.Context, req resource.Impor
 			return
 		}
 
-		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, len(deviceRole.GetTags()) > 0, deviceRole.GetTags(), types.SetNull(types.StringType))
+		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, r.client, len(deviceRole.GetTags()) > 0, deviceRole.GetTags(), types.SetNull(types.StringType))
+		data.TagsAll = utils.TagsAllFromAPI(ctx, deviceRole.GetTags())
+		data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, deviceRole.GetCustomFields(), &resp.Diagnostics)
 		if parsed.HasCustomFields {
 			if len(parsed.CustomFields) == 0 {
 				data.CustomFields = types.SetValueMust(utils.GetCustomFieldsAttributeType().ElemType, []attr.Value{})
@@ -501,6 +556,7 @@ func (r *DeviceRoleResource) ImportState(ctx context.Context, req resource.Impor
 					return
 				}
 				data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, ownedSet, deviceRole.GetCustomFields(), &resp.Diagnostics)
+				data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, deviceRole.GetCustomFields(), &resp.Diagnostics)
 			}
 		} else {
 			data.CustomFields = types.SetNull(utils.GetCustomFieldsAttributeType().ElemType)
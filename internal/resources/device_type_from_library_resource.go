@@ -0,0 +1,592 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/bab3l/go-netbox"
+	"github.com/bab3l/terraform-provider-netbox/internal/devicelibrary"
+	nbschema "github.com/bab3l/terraform-provider-netbox/internal/schema"
+	"github.com/bab3l/terraform-provider-netbox/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource                   = &LibraryTypeResource{}
+	_ resource.ResourceWithImportState    = &LibraryTypeResource{}
+	_ resource.ResourceWithValidateConfig = &LibraryTypeResource{}
+)
+
+var invalidSlugChars = regexp.MustCompile(`[^a-z0-9_-]+`)
+
+// libraryTypeConfig describes the NetBox type managed by a LibraryTypeResource.
+type libraryTypeConfig struct {
+	kind devicelibrary.Kind
+	// typeName is the suffix of the Terraform resource type.
+	typeName string
+	// endpoint is the API path of the type, e.g. "dcim/device-types".
+	endpoint string
+	// parentField is the template field referencing the type, e.g. "device_type".
+	parentField string
+}
+
+func NewDeviceTypeFromLibraryResource() resource.Resource {
+	return &LibraryTypeResource{config: libraryTypeConfig{
+		kind:        devicelibrary.DeviceType,
+		typeName:    "_device_type_from_library",
+		endpoint:    "dcim/device-types",
+		parentField: "device_type",
+	}}
+}
+
+// LibraryTypeResource manages a device type or module type, its manufacturer
+// and all of its component templates from a devicetype-library YAML definition.
+type LibraryTypeResource struct {
+	client *netbox.APIClient
+	config libraryTypeConfig
+}
+
+// LibraryTypeResourceModel describes the resource data model.
+type LibraryTypeResourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	DefinitionYAML types.String `tfsdk:"definition_yaml"`
+	Manufacturer   types.String `tfsdk:"manufacturer"`
+	Model          types.String `tfsdk:"model"`
+	Branch         types.String `tfsdk:"branch"`
+}
+
+func (r *LibraryTypeResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + r.config.typeName
+}
+
+func (r *LibraryTypeResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	kind := r.config.kind.String()
+	resp.Schema = schema.Schema{
+		MarkdownDescription: fmt.Sprintf("Manages a %[1]s and all of its component templates from a [devicetype-library](https://github.com/netbox-community/devicetype-library) YAML definition. "+
+			"The manufacturer is looked up by name and created if missing. On every apply, component templates are matched by name: missing templates are created, changed ones updated, and templates that are not in the definition are deleted. "+
+			"Drift in NetBox is reported as a change to `definition_yaml`. Destroying the resource deletes the %[1]s together with its templates; the manufacturer is kept.", kind),
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("The ID of the %s.", kind),
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"definition_yaml": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("The %s definition in devicetype-library YAML format, e.g. `file(\"devicetype-library/device-types/Juniper/EX4300-48T.yaml\")`. Unsupported top-level keys such as `front_image` are ignored with a warning.", kind),
+				Required:            true,
+			},
+			"manufacturer": schema.StringAttribute{
+				MarkdownDescription: "The ID of the manufacturer.",
+				Computed:            true,
+			},
+			"model": schema.StringAttribute{
+				MarkdownDescription: "The model name.",
+				Computed:            true,
+			},
+		},
+	}
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
+}
+
+func (r *LibraryTypeResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*netbox.APIClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *netbox.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.client = client
+}
+
+func (r *LibraryTypeResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var definition types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("definition_yaml"), &definition)...)
+	if resp.Diagnostics.HasError() || definition.IsNull() || definition.IsUnknown() {
+		return
+	}
+	r.parseDefinition(definition, &resp.Diagnostics)
+}
+
+func (r *LibraryTypeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	var data LibraryTypeResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	def := r.parseDefinition(data.DefinitionYAML, &resp.Diagnostics)
+	if def == nil {
+		return
+	}
+
+	lookup := newManufacturerLookup(r.client)
+	manufacturerID := lookup.id(ctx, def.Manufacturer, true, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	body, httpResp, err := utils.DoRawAPIRequest(ctx, r.client, http.MethodPost, r.config.endpoint, nil, r.typePayload(def, manufacturerID))
+	defer utils.CloseResponseBody(httpResp)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Error creating %s", r.config.kind),
+			utils.FormatAPIError(fmt.Sprintf("create %s %s", r.config.kind, def.Model), err, httpResp),
+		)
+		return
+	}
+	if !utils.ValidateStatusCode(&resp.Diagnostics, "create "+r.config.kind.String(), httpResp, http.StatusCreated) {
+		return
+	}
+	id, err := rawObjectID(body)
+	if err != nil {
+		resp.Diagnostics.AddError("Unexpected API response", fmt.Sprintf("Could not read the ID of the created %s: %s", r.config.kind, err))
+		return
+	}
+
+	// Save the ID first so a failure while creating templates leaves the
+	// (tainted) type in state instead of orphaning it in NetBox.
+	data.ID = types.StringValue(id)
+	data.Manufacturer = types.StringValue(strconv.FormatInt(manufacturerID, 10))
+	data.Model = types.StringValue(def.Model)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.syncTemplates(ctx, id, def, lookup, &resp.Diagnostics)
+	tflog.Debug(ctx, "Created type from library definition", map[string]interface{}{
+		"kind": r.config.kind.String(),
+		"id":   id,
+	})
+}
+
+func (r *LibraryTypeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
+	var data LibraryTypeResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	typePath := r.config.endpoint + "/" + data.ID.ValueString()
+	body, httpResp, err := utils.DoRawAPIRequest(ctx, r.client, http.MethodGet, typePath, nil, nil)
+	defer utils.CloseResponseBody(httpResp)
+	if err != nil {
+		if utils.HandleNotFound(httpResp, func() { resp.State.RemoveResource(ctx) }) {
+			return
+		}
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Error reading %s", r.config.kind),
+			utils.FormatAPIError(fmt.Sprintf("read %s ID %s", r.config.kind, data.ID.ValueString()), err, httpResp),
+		)
+		return
+	}
+	if !utils.ValidateStatusCode(&resp.Diagnostics, "read "+r.config.kind.String(), httpResp, http.StatusOK) {
+		return
+	}
+	actual, err := utils.DecodeJSONObject(body)
+	if err != nil {
+		resp.Diagnostics.AddError("Unexpected API response", fmt.Sprintf("Could not decode %s: %s", r.config.kind, err))
+		return
+	}
+
+	if manufacturer, ok := actual["manufacturer"].(map[string]interface{}); ok {
+		data.Manufacturer = types.StringValue(fmt.Sprintf("%v", manufacturer["id"]))
+	}
+	if model, ok := actual["model"].(string); ok {
+		data.Model = types.StringValue(model)
+	}
+
+	// A definition that no longer matches NetBox is cleared, so the plan shows
+	// the definition being applied again. Imported types have no definition yet.
+	if !data.DefinitionYAML.IsNull() {
+		def, err := devicelibrary.Parse([]byte(data.DefinitionYAML.ValueString()), r.config.kind)
+		if err != nil || r.hasDrift(ctx, data.ID.ValueString(), def, actual, &resp.Diagnostics) {
+			tflog.Debug(ctx, "Type drifted from library definition", map[string]interface{}{
+				"kind": r.config.kind.String(),
+				"id":   data.ID.ValueString(),
+			})
+			data.DefinitionYAML = types.StringNull()
+		}
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *LibraryTypeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	var data LibraryTypeResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	def := r.parseDefinition(data.DefinitionYAML, &resp.Diagnostics)
+	if def == nil {
+		return
+	}
+
+	lookup := newManufacturerLookup(r.client)
+	manufacturerID := lookup.id(ctx, def.Manufacturer, true, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	typePath := r.config.endpoint + "/" + data.ID.ValueString()
+	_, httpResp, err := utils.DoRawAPIRequest(ctx, r.client, http.MethodPatch, typePath, nil, r.typePayload(def, manufacturerID))
+	defer utils.CloseResponseBody(httpResp)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Error updating %s", r.config.kind),
+			utils.FormatAPIError(fmt.Sprintf("update %s ID %s", r.config.kind, data.ID.ValueString()), err, httpResp),
+		)
+		return
+	}
+	if !utils.ValidateStatusCode(&resp.Diagnostics, "update "+r.config.kind.String(), httpResp, http.StatusOK) {
+		return
+	}
+
+	r.syncTemplates(ctx, data.ID.ValueString(), def, lookup, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Manufacturer = types.StringValue(strconv.FormatInt(manufacturerID, 10))
+	data.Model = types.StringValue(def.Model)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *LibraryTypeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
+	var data LibraryTypeResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// NetBox deletes the component templates together with the type.
+	typePath := r.config.endpoint + "/" + data.ID.ValueString()
+	_, httpResp, err := utils.DoRawAPIRequest(ctx, r.client, http.MethodDelete, typePath, nil, nil)
+	defer utils.CloseResponseBody(httpResp)
+	if err != nil {
+		if utils.HandleNotFound(httpResp, func() {}) {
+			return
+		}
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Error deleting %s", r.config.kind),
+			utils.FormatAPIError(fmt.Sprintf("delete %s ID %s", r.config.kind, data.ID.ValueString()), err, httpResp),
+		)
+		return
+	}
+	utils.ValidateStatusCode(&resp.Diagnostics, "delete "+r.config.kind.String(), httpResp, http.StatusNoContent)
+}
+
+func (r *LibraryTypeResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	utils.ImportStatePassthroughIDWithValidation(ctx, req, resp, path.Root("id"), true)
+}
+
+// parseDefinition parses the configured YAML, reporting errors and ignored keys
+// as diagnostics. It returns nil when the definition is invalid.
+func (r *LibraryTypeResource) parseDefinition(definition types.String, diags *diag.Diagnostics) *devicelibrary.Definition {
+	def, err := devicelibrary.Parse([]byte(definition.ValueString()), r.config.kind)
+	if err != nil {
+		diags.AddAttributeError(path.Root("definition_yaml"), "Invalid definition", err.Error())
+		return nil
+	}
+	if len(def.Ignored) > 0 {
+		diags.AddAttributeWarning(
+			path.Root("definition_yaml"),
+			"Unsupported keys ignored",
+			fmt.Sprintf("The following keys are not supported and were ignored: %s.", strings.Join(def.Ignored, ", ")),
+		)
+	}
+	return def
+}
+
+// typePayload builds the request body for the type itself.
+func (r *LibraryTypeResource) typePayload(def *devicelibrary.Definition, manufacturerID int64) map[string]interface{} {
+	payload := make(map[string]interface{}, len(def.Attributes)+3)
+	for key, value := range def.Attributes {
+		payload[key] = value
+	}
+	payload["manufacturer"] = manufacturerID
+	payload["model"] = def.Model
+	if def.Slug != "" {
+		payload["slug"] = def.Slug
+	}
+	return payload
+}
+
+// hasDrift reports whether the type or its templates differ from def.
+func (r *LibraryTypeResource) hasDrift(ctx context.Context, typeID string, def *devicelibrary.Definition, actual map[string]interface{}, diags *diag.Diagnostics) bool {
+	lookup := newManufacturerLookup(r.client)
+	manufacturerID := lookup.id(ctx, def.Manufacturer, false, diags)
+	if diags.HasError() || manufacturerID == 0 {
+		return true
+	}
+	desired := r.typePayload(def, manufacturerID)
+	if !devicelibrary.AttributesMatch(desired, actual) {
+		return true
+	}
+
+	ids := map[string]map[string]int64{}
+	for _, kind := range devicelibrary.ComponentKinds(r.config.kind) {
+		existing := r.listTemplates(ctx, kind, typeID, diags)
+		if diags.HasError() {
+			return false
+		}
+		ids[kind.Key] = templateIDs(existing)
+
+		templates, err := resolveTemplates(ctx, kind, def.Components[kind.Key], ids, lookup, false, diags)
+		if diags.HasError() {
+			return false
+		}
+		if err != nil || !devicelibrary.DiffTemplates(templates, existing).IsEmpty() {
+			return true
+		}
+	}
+	return false
+}
+
+// syncTemplates creates, updates and deletes component templates so that they
+// match def. Orphans are deleted last, in reverse order, so templates that are
+// referenced (rear ports, power ports) outlive the templates referencing them.
+func (r *LibraryTypeResource) syncTemplates(ctx context.Context, typeID string, def *devicelibrary.Definition, lookup *manufacturerLookup, diags *diag.Diagnostics) {
+	parentID, err := strconv.ParseInt(typeID, 10, 64)
+	if err != nil {
+		diags.AddError("Invalid ID", fmt.Sprintf("Could not parse %s ID %q: %s", r.config.kind, typeID, err))
+		return
+	}
+
+	kinds := devicelibrary.ComponentKinds(r.config.kind)
+	orphans := make([][]devicelibrary.ExistingTemplate, len(kinds))
+	ids := map[string]map[string]int64{}
+	for i, kind := range kinds {
+		existing := r.listTemplates(ctx, kind, typeID, diags)
+		if diags.HasError() {
+			return
+		}
+		ids[kind.Key] = templateIDs(existing)
+
+		templates, err := resolveTemplates(ctx, kind, def.Components[kind.Key], ids, lookup, true, diags)
+		if err != nil {
+			diags.AddAttributeError(path.Root("definition_yaml"), "Invalid definition", err.Error())
+		}
+		if diags.HasError() {
+			return
+		}
+
+		changes := devicelibrary.DiffTemplates(templates, existing)
+		for _, tmpl := range changes.Create {
+			payload := templatePayload(tmpl)
+			payload[r.config.parentField] = parentID
+			body, httpResp, err := utils.DoRawAPIRequest(ctx, r.client, http.MethodPost, kind.Endpoint, nil, payload)
+			utils.CloseResponseBody(httpResp)
+			if err != nil {
+				diags.AddError("Error creating component template", utils.FormatAPIError(fmt.Sprintf("create %s %q", kind.Key, tmpl.Name), err, httpResp))
+				return
+			}
+			id, err := rawObjectID(body)
+			if err != nil {
+				diags.AddError("Unexpected API response", fmt.Sprintf("Could not read the ID of %s %q: %s", kind.Key, tmpl.Name, err))
+				return
+			}
+			templateID, _ := strconv.ParseInt(id, 10, 64)
+			ids[kind.Key][tmpl.Name] = templateID
+		}
+		for _, update := range changes.Update {
+			templatePath := fmt.Sprintf("%s/%d", kind.Endpoint, update.ID)
+			_, httpResp, err := utils.DoRawAPIRequest(ctx, r.client, http.MethodPatch, templatePath, nil, templatePayload(update.Template))
+			utils.CloseResponseBody(httpResp)
+			if err != nil {
+				diags.AddError("Error updating component template", utils.FormatAPIError(fmt.Sprintf("update %s %q", kind.Key, update.Template.Name), err, httpResp))
+				return
+			}
+		}
+		orphans[i] = changes.Delete
+	}
+
+	for i := len(kinds) - 1; i >= 0; i-- {
+		for _, orphan := range orphans[i] {
+			templatePath := fmt.Sprintf("%s/%d", kinds[i].Endpoint, orphan.ID)
+			_, httpResp, err := utils.DoRawAPIRequest(ctx, r.client, http.MethodDelete, templatePath, nil, nil)
+			utils.CloseResponseBody(httpResp)
+			if err != nil && !utils.HandleNotFound(httpResp, func() {}) {
+				diags.AddError("Error deleting component template", utils.FormatAPIError(fmt.Sprintf("delete %s %q", kinds[i].Key, orphan.Name), err, httpResp))
+				return
+			}
+		}
+	}
+}
+
+// listTemplates returns the existing templates of kind that belong to the type.
+func (r *LibraryTypeResource) listTemplates(ctx context.Context, kind devicelibrary.ComponentKind, typeID string, diags *diag.Diagnostics) []devicelibrary.ExistingTemplate {
+	query := url.Values{r.config.parentField + "_id": []string{typeID}}
+	results, httpResp, err := utils.ListRawAPIObjects(ctx, r.client, kind.Endpoint, query)
+	defer utils.CloseResponseBody(httpResp)
+	if err != nil {
+		diags.AddError("Error listing component templates", utils.FormatAPIError("list "+kind.Key, err, httpResp))
+		return nil
+	}
+
+	existing := make([]devicelibrary.ExistingTemplate, 0, len(results))
+	for _, raw := range results {
+		fields, err := utils.DecodeJSONObject(raw)
+		if err != nil {
+			diags.AddError("Unexpected API response", fmt.Sprintf("Could not decode %s: %s", kind.Key, err))
+			return nil
+		}
+		id, err := strconv.ParseInt(fmt.Sprintf("%v", fields["id"]), 10, 64)
+		if err != nil {
+			diags.AddError("Unexpected API response", fmt.Sprintf("Template of %s without a numeric ID", kind.Key))
+			return nil
+		}
+		name, _ := fields["name"].(string)
+		existing = append(existing, devicelibrary.ExistingTemplate{ID: id, Name: name, Attributes: fields})
+	}
+	return existing
+}
+
+// resolveTemplates replaces template references (e.g. a front port's
+// rear_port) and inventory item manufacturers, given by name in the YAML, with
+// IDs. A reference to a template that does not exist returns an error.
+func resolveTemplates(ctx context.Context, kind devicelibrary.ComponentKind, templates []devicelibrary.Template, ids map[string]map[string]int64, lookup *manufacturerLookup, create bool, diags *diag.Diagnostics) ([]devicelibrary.Template, error) {
+	resolved := make([]devicelibrary.Template, 0, len(templates))
+	for _, tmpl := range templates {
+		attributes := make(map[string]interface{}, len(tmpl.Attributes))
+		for key, value := range tmpl.Attributes {
+			attributes[key] = value
+		}
+
+		for field, target := range kind.References {
+			name, ok := attributes[field].(string)
+			if !ok {
+				continue
+			}
+			id, ok := ids[target][name]
+			if !ok {
+				return nil, fmt.Errorf("%s %q references unknown %s %q", kind.Key, tmpl.Name, target, name)
+			}
+			attributes[field] = id
+		}
+		if name, ok := attributes["manufacturer"].(string); ok && kind.Key == "inventory-items" {
+			id := lookup.id(ctx, name, create, diags)
+			if diags.HasError() {
+				return nil, nil
+			}
+			if id == 0 {
+				return nil, fmt.Errorf("manufacturer %q does not exist", name)
+			}
+			attributes["manufacturer"] = id
+		}
+
+		resolved = append(resolved, devicelibrary.Template{Name: tmpl.Name, Attributes: attributes})
+	}
+	return resolved, nil
+}
+
+func templatePayload(tmpl devicelibrary.Template) map[string]interface{} {
+	payload := make(map[string]interface{}, len(tmpl.Attributes)+2)
+	for key, value := range tmpl.Attributes {
+		payload[key] = value
+	}
+	payload["name"] = tmpl.Name
+	return payload
+}
+
+func templateIDs(existing []devicelibrary.ExistingTemplate) map[string]int64 {
+	ids := make(map[string]int64, len(existing))
+	for _, e := range existing {
+		ids[e.Name] = e.ID
+	}
+	return ids
+}
+
+// manufacturerLookup resolves manufacturers by name, caching the results of
+// one operation.
+type manufacturerLookup struct {
+	client *netbox.APIClient
+	cache  map[string]int64
+}
+
+func newManufacturerLookup(client *netbox.APIClient) *manufacturerLookup {
+	return &manufacturerLookup{client: client, cache: map[string]int64{}}
+}
+
+// id returns the ID of the manufacturer called name. When it does not exist it
+// is created if create is set, otherwise 0 is returned.
+func (l *manufacturerLookup) id(ctx context.Context, name string, create bool, diags *diag.Diagnostics) int64 {
+	if id, ok := l.cache[name]; ok {
+		return id
+	}
+
+	results, httpResp, err := utils.ListRawAPIObjects(ctx, l.client, "dcim/manufacturers", url.Values{"name": []string{name}})
+	utils.CloseResponseBody(httpResp)
+	if err != nil {
+		diags.AddError("Manufacturer lookup failed", utils.FormatAPIError(fmt.Sprintf("list manufacturers named %q", name), err, httpResp))
+		return 0
+	}
+
+	var id string
+	switch {
+	case len(results) > 0:
+		id, err = rawObjectID(results[0])
+	case create:
+		slug := strings.Trim(invalidSlugChars.ReplaceAllString(strings.ToLower(name), "-"), "-")
+		var body []byte
+		body, httpResp, err = utils.DoRawAPIRequest(ctx, l.client, http.MethodPost, "dcim/manufacturers", nil, map[string]interface{}{"name": name, "slug": slug})
+		utils.CloseResponseBody(httpResp)
+		if err != nil {
+			diags.AddError("Error creating manufacturer", utils.FormatAPIError(fmt.Sprintf("create manufacturer %q", name), err, httpResp))
+			return 0
+		}
+		id, err = rawObjectID(body)
+	default:
+		return 0
+	}
+	if err != nil {
+		diags.AddError("Unexpected API response", fmt.Sprintf("Could not read the ID of manufacturer %q: %s", name, err))
+		return 0
+	}
+
+	parsed, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		diags.AddError("Unexpected API response", fmt.Sprintf("Manufacturer %q has a non-numeric ID %q", name, id))
+		return 0
+	}
+	l.cache[name] = parsed
+	return parsed
+}
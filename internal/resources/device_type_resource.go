@@ -28,6 +28,7 @@ var (
 	_ resource.Resource                = &DeviceTypeResource{}
 	_ resource.ResourceWithImportState = &DeviceTypeResource{}
 	_ resource.ResourceWithIdentity    = &DeviceTypeResource{}
+	_ resource.ResourceWithModifyPlan  = &DeviceTypeResource{}
 )
 
 func NewDeviceTypeResource() resource.Resource {
@@ -58,6 +59,10 @@ type DeviceTypeResourceModel struct {
 	Comments               types.String  `tfsdk:"comments"`
 	Tags                   types.Set     `tfsdk:"tags"`
 	CustomFields           types.Set     `tfsdk:"custom_fields"`
+	CustomFieldsMap        types.Dynamic `tfsdk:"custom_fields_map"`
+	TagsAll                types.Set     `tfsdk:"tags_all"`
+	CustomFieldsAll        types.Map     `tfsdk:"custom_fields_all"`
+	Branch                 types.String  `tfsdk:"branch"`
 }
 
 func (r *DeviceTypeResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -120,12 +125,16 @@ func (r *DeviceTypeResource) Schema(ctx context.Context, req resource.SchemaRequ
 					stringvalidator.OneOf("kg", "g", "lb", "oz", ""),
 				},
 			},
-			"description":   nbschema.DescriptionAttribute("device type"),
-			"comments":      nbschema.CommentsAttribute("device type"),
-			"tags":          nbschema.TagsSlugAttribute(),
-			"custom_fields": nbschema.CustomFieldsAttribute(),
+			"description":       nbschema.DescriptionAttribute("device type"),
+			"comments":          nbschema.CommentsAttribute("device type"),
+			"tags":              nbschema.TagsSlugAttribute(),
+			"custom_fields":     nbschema.CustomFieldsAttribute(),
+			"custom_fields_map": nbschema.CustomFieldsMapAttribute(),
+			"tags_all":          nbschema.TagsAllAttribute(),
+			"custom_fields_all": nbschema.CustomFieldsAllAttribute(),
 		},
 	}
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 func (r *DeviceTypeResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
@@ -147,7 +156,20 @@ func (r *DeviceTypeResource) Configure(ctx context.Context, req resource.Configu
 	r.client = client
 }
 
+// ModifyPlan validates the configured custom fields against their definitions in NetBox
+// and plans tags_all and custom_fields_all.
+func (r *DeviceTypeResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	utils.ValidateCustomFieldsPlan(ctx, r.client, "dcim.devicetype", req, resp)
+	utils.PlanEffectiveMetadata(ctx, r.client, "dcim.devicetype", req, resp)
+}
+
 func (r *DeviceTypeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	defer journalCreate(ctx, r.client, "dcim.devicetype", req, resp)
+
 	var data DeviceTypeResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -257,6 +279,10 @@ func (r *DeviceTypeResource) Create(ctx context.Context, req resource.CreateRequ
 		return
 	}
 	utils.ApplyCustomFields(ctx, &deviceTypeRequest, data.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, &deviceTypeRequest, data.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, &deviceTypeRequest, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "dcim.devicetype", &deviceTypeRequest, &resp.Diagnostics)
+	netboxlookup.ResolveCustomFieldReferences(ctx, r.client, &deviceTypeRequest, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -286,13 +312,17 @@ func (r *DeviceTypeResource) Create(ctx context.Context, req resource.CreateRequ
 	}
 
 	// Apply filter-to-owned pattern for tags
-	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, deviceType.HasTags(), deviceType.GetTags(), planTags)
+	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, deviceType.HasTags(), deviceType.GetTags(), planTags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, deviceType.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, deviceType.GetCustomFields(), &resp.Diagnostics)
 	data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, planCustomFields, deviceType.GetCustomFields(), &resp.Diagnostics)
+	data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, deviceType.GetCustomFields(), &resp.Diagnostics)
 	utils.SetIdentityCustomFields(ctx, resp.Identity, types.StringValue(data.ID.ValueString()), data.CustomFields, &resp.Diagnostics)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *DeviceTypeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data DeviceTypeResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -347,13 +377,23 @@ func (r *DeviceTypeResource) Read(ctx context.Context, req resource.ReadRequest,
 	}
 
 	// Apply filter-to-owned pattern for tags
-	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, deviceType.HasTags(), deviceType.GetTags(), stateTags)
+	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, deviceType.HasTags(), deviceType.GetTags(), stateTags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, deviceType.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, deviceType.GetCustomFields(), &resp.Diagnostics)
 	data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, stateCustomFields, deviceType.GetCustomFields(), &resp.Diagnostics)
+	data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, deviceType.GetCustomFields(), &resp.Diagnostics)
 	utils.SetIdentityCustomFields(ctx, resp.Identity, types.StringValue(data.ID.ValueString()), data.CustomFields, &resp.Diagnostics)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *DeviceTypeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	ctx = withPriorTags(ctx, req.State)
+	defer journalUpdate(ctx, r.client, "dcim.devicetype", req, resp)
+
 	var data DeviceTypeResourceModel
 	var state DeviceTypeResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -470,6 +510,10 @@ func (r *DeviceTypeResource) Update(ctx context.Context, req resource.UpdateRequ
 
 	// Apply custom fields with merge-aware logic
 	utils.ApplyCustomFieldsWithMerge(ctx, &deviceTypeRequest, data.CustomFields, state.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, &deviceTypeRequest, data.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, &deviceTypeRequest, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "dcim.devicetype", &deviceTypeRequest, &resp.Diagnostics)
+	netboxlookup.ResolveCustomFieldReferences(ctx, r.client, &deviceTypeRequest, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -499,13 +543,20 @@ func (r *DeviceTypeResource) Update(ctx context.Context, req resource.UpdateRequ
 	}
 
 	// Apply filter-to-owned pattern for tags
-	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, deviceType.HasTags(), deviceType.GetTags(), data.Tags)
+	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, deviceType.HasTags(), deviceType.GetTags(), data.Tags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, deviceType.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, deviceType.GetCustomFields(), &resp.Diagnostics)
 	data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, deviceType.GetCustomFields(), &resp.Diagnostics)
+	data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, deviceType.GetCustomFields(), &resp.Diagnostics)
 	utils.SetIdentityCustomFields(ctx, resp.Identity, types.StringValue(data.ID.ValueString()), data.CustomFields, &resp.Diagnostics)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *DeviceTypeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data DeviceTypeResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -591,7 +642,9 @@ func (r *DeviceTypeResource) ImportState(ctx context.Context, req resource.Impor
 		if deviceType.HasDefaultPlatform() && deviceType.DefaultPlatform.Get() != nil {
 			data.DefaultPlatform = types.StringValue(fmt.Sprintf("%d", deviceType.DefaultPlatform.Get().GetId()))
 		}
-		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, deviceType.HasTags(), deviceType.GetTags(), data.Tags)
+		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, r.client, deviceType.HasTags(), deviceType.GetTags(), data.Tags)
+		data.TagsAll = utils.TagsAllFromAPI(ctx, deviceType.GetTags())
+		data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, deviceType.GetCustomFields(), &resp.Diagnostics)
 		if parsed.HasCustomFields {
 			if len(parsed.CustomFields) == 0 {
 				data.CustomFields = types.SetValueMust(utils.GetCustomFieldsAttributeType().ElemType, []attr.Value{})
@@ -613,6 +666,7 @@ func (r *DeviceTypeResource) ImportState(ctx context.Context, req resource.Impor
 		}
 		if parsed.HasCustomFields {
 			data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, deviceType.GetCustomFields(), &resp.Diagnostics)
+			data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, deviceType.GetCustomFields(), &resp.Diagnostics)
 		} else {
 			data.CustomFields = types.SetNull(utils.GetCustomFieldsAttributeType().ElemType)
 		}
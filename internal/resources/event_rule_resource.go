@@ -8,6 +8,7 @@ import (
 	"net/http"
 
 	"github.com/bab3l/go-netbox"
+	"github.com/bab3l/terraform-provider-netbox/internal/netboxlookup"
 	nbschema "github.com/bab3l/terraform-provider-netbox/internal/schema"
 	"github.com/bab3l/terraform-provider-netbox/internal/utils"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
@@ -30,6 +31,7 @@ var (
 	_ resource.ResourceWithConfigure   = &EventRuleResource{}
 	_ resource.ResourceWithImportState = &EventRuleResource{}
 	_ resource.ResourceWithIdentity    = &EventRuleResource{}
+	_ resource.ResourceWithModifyPlan  = &EventRuleResource{}
 )
 
 // NewEventRuleResource returns a new resource implementing the event rule resource.
@@ -44,18 +46,22 @@ type EventRuleResource struct {
 
 // EventRuleResourceModel describes the resource data model.
 type EventRuleResourceModel struct {
-	ID               types.String `tfsdk:"id"`
-	Name             types.String `tfsdk:"name"`
-	ObjectTypes      types.Set    `tfsdk:"object_types"`
-	EventTypes       types.Set    `tfsdk:"event_types"`
-	Enabled          types.Bool   `tfsdk:"enabled"`
-	Conditions       types.String `tfsdk:"conditions"`
-	ActionType       types.String `tfsdk:"action_type"`
-	ActionObjectType types.String `tfsdk:"action_object_type"`
-	ActionObjectID   types.String `tfsdk:"action_object_id"`
-	Description      types.String `tfsdk:"description"`
-	Tags             types.Set    `tfsdk:"tags"`
-	CustomFields     types.Set    `tfsdk:"custom_fields"`
+	ID               types.String  `tfsdk:"id"`
+	Name             types.String  `tfsdk:"name"`
+	ObjectTypes      types.Set     `tfsdk:"object_types"`
+	EventTypes       types.Set     `tfsdk:"event_types"`
+	Enabled          types.Bool    `tfsdk:"enabled"`
+	Conditions       types.String  `tfsdk:"conditions"`
+	ActionType       types.String  `tfsdk:"action_type"`
+	ActionObjectType types.String  `tfsdk:"action_object_type"`
+	ActionObjectID   types.String  `tfsdk:"action_object_id"`
+	Description      types.String  `tfsdk:"description"`
+	Tags             types.Set     `tfsdk:"tags"`
+	CustomFields     types.Set     `tfsdk:"custom_fields"`
+	CustomFieldsMap  types.Dynamic `tfsdk:"custom_fields_map"`
+	TagsAll          types.Set     `tfsdk:"tags_all"`
+	CustomFieldsAll  types.Map     `tfsdk:"custom_fields_all"`
+	Branch           types.String  `tfsdk:"branch"`
 }
 
 // Metadata returns the resource type name.
@@ -121,6 +127,10 @@ func (r *EventRuleResource) Schema(ctx context.Context, req resource.SchemaReque
 	// Add tags and custom fields
 	resp.Schema.Attributes["tags"] = nbschema.TagsSlugAttribute()
 	resp.Schema.Attributes["custom_fields"] = nbschema.CustomFieldsAttribute()
+	resp.Schema.Attributes["custom_fields_map"] = nbschema.CustomFieldsMapAttribute()
+	resp.Schema.Attributes["tags_all"] = nbschema.TagsAllAttribute()
+	resp.Schema.Attributes["custom_fields_all"] = nbschema.CustomFieldsAllAttribute()
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 func (r *EventRuleResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
@@ -145,8 +155,19 @@ func (r *EventRuleResource) Configure(ctx context.Context, req resource.Configur
 	r.client = client
 }
 
+// ModifyPlan validates the configured custom fields against their definitions in NetBox
+// and plans tags_all and custom_fields_all.
+func (r *EventRuleResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	utils.ValidateCustomFieldsPlan(ctx, r.client, "extras.eventrule", req, resp)
+	utils.PlanEffectiveMetadata(ctx, r.client, "extras.eventrule", req, resp)
+}
+
 // Create creates the resource.
 func (r *EventRuleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
 	var data EventRuleResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -227,6 +248,10 @@ func (r *EventRuleResource) Create(ctx context.Context, req resource.CreateReque
 	// Apply tags and custom fields
 	utils.ApplyTagsFromSlugs(ctx, r.client, request, data.Tags, &resp.Diagnostics)
 	utils.ApplyCustomFields(ctx, request, data.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, request, data.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, request, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "extras.eventrule", request, &resp.Diagnostics)
+	netboxlookup.ResolveCustomFieldReferences(ctx, r.client, request, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -250,8 +275,11 @@ func (r *EventRuleResource) Create(ctx context.Context, req resource.CreateReque
 	}
 
 	// Apply filter-to-owned pattern for tags
-	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, result.HasTags(), result.GetTags(), planTags)
+	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, result.HasTags(), result.GetTags(), planTags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, result.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, result.GetCustomFields(), &resp.Diagnostics)
 	data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, planCustomFields, result.GetCustomFields(), &resp.Diagnostics)
+	data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, result.GetCustomFields(), &resp.Diagnostics)
 	utils.SetIdentityCustomFields(ctx, resp.Identity, types.StringValue(data.ID.ValueString()), data.CustomFields, &resp.Diagnostics)
 	tflog.Debug(ctx, "Created event rule", map[string]interface{}{
 		"id": data.ID.ValueString(),
@@ -261,6 +289,7 @@ func (r *EventRuleResource) Create(ctx context.Context, req resource.CreateReque
 
 // Read refreshes the Terraform state with the latest data.
 func (r *EventRuleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data EventRuleResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -298,8 +327,11 @@ func (r *EventRuleResource) Read(ctx context.Context, req resource.ReadRequest,
 	}
 
 	// Apply filter-to-owned pattern for tags
-	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, result.HasTags(), result.GetTags(), originalTags)
+	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, result.HasTags(), result.GetTags(), originalTags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, result.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, result.GetCustomFields(), &resp.Diagnostics)
 	data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, originalCustomFields, result.GetCustomFields(), &resp.Diagnostics)
+	data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, result.GetCustomFields(), &resp.Diagnostics)
 
 	// If custom_fields was null or empty before (not managed or explicitly cleared),
 	// restore that state after mapping.
@@ -318,6 +350,11 @@ func (r *EventRuleResource) Read(ctx context.Context, req resource.ReadRequest,
 
 // Update updates the resource.
 func (r *EventRuleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	ctx = withPriorTags(ctx, req.State)
 	// Read BOTH state and plan for merge-aware custom fields
 	var state, plan EventRuleResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -403,6 +440,10 @@ func (r *EventRuleResource) Update(ctx context.Context, req resource.UpdateReque
 		utils.ApplyTagsFromSlugs(ctx, r.client, request, state.Tags, &resp.Diagnostics)
 	}
 	utils.ApplyCustomFieldsWithMerge(ctx, request, plan.CustomFields, state.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, request, plan.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, request, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "extras.eventrule", request, &resp.Diagnostics)
+	netboxlookup.ResolveCustomFieldReferences(ctx, r.client, request, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -432,8 +473,11 @@ func (r *EventRuleResource) Update(ctx context.Context, req resource.UpdateReque
 	}
 
 	// Apply filter-to-owned pattern for tags
-	plan.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, result.HasTags(), result.GetTags(), planTags)
+	plan.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, result.HasTags(), result.GetTags(), planTags)
+	plan.TagsAll = utils.TagsAllFromAPI(ctx, result.GetTags())
+	plan.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, plan.CustomFieldsAll, result.GetCustomFields(), &resp.Diagnostics)
 	plan.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, planCustomFields, result.GetCustomFields(), &resp.Diagnostics)
+	plan.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, plan.CustomFieldsMap, result.GetCustomFields(), &resp.Diagnostics)
 	utils.SetIdentityCustomFields(ctx, resp.Identity, types.StringValue(plan.ID.ValueString()), plan.CustomFields, &resp.Diagnostics)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
@@ -441,6 +485,10 @@ func (r *EventRuleResource) Update(ctx context.Context, req resource.UpdateReque
 
 // Delete deletes the resource.
 func (r *EventRuleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data EventRuleResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -515,9 +563,12 @@ func (r *EventRuleResource) ImportState(ctx context.Context, req resource.Import
 		if resp.Diagnostics.HasError() {
 			return
 		}
-		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, result.HasTags(), result.GetTags(), data.Tags)
+		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, r.client, result.HasTags(), result.GetTags(), data.Tags)
+		data.TagsAll = utils.TagsAllFromAPI(ctx, result.GetTags())
+		data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, result.GetCustomFields(), &resp.Diagnostics)
 		if parsed.HasCustomFields {
 			data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, result.GetCustomFields(), &resp.Diagnostics)
+			data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, result.GetCustomFields(), &resp.Diagnostics)
 		} else {
 			data.CustomFields = types.SetNull(utils.GetCustomFieldsAttributeType().ElemType)
 		}
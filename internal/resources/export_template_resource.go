@@ -49,6 +49,7 @@ type ExportTemplateResourceModel struct {
 	MimeType      types.String `tfsdk:"mime_type"`
 	FileExtension types.String `tfsdk:"file_extension"`
 	AsAttachment  types.Bool   `tfsdk:"as_attachment"`
+	Branch        types.String `tfsdk:"branch"`
 }
 
 // Metadata returns the resource type name.
@@ -97,6 +98,7 @@ func (r *ExportTemplateResource) Schema(ctx context.Context, req resource.Schema
 
 	// Add description attribute
 	maps.Copy(resp.Schema.Attributes, nbschema.DescriptionOnlyAttributes("export template"))
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 // Configure adds the provider configured client to the resource.
@@ -119,6 +121,10 @@ func (r *ExportTemplateResource) Configure(ctx context.Context, req resource.Con
 
 // Create creates a new export template.
 func (r *ExportTemplateResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
 	var data ExportTemplateResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -193,6 +199,7 @@ func (r *ExportTemplateResource) Create(ctx context.Context, req resource.Create
 
 // Read reads the export template.
 func (r *ExportTemplateResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data ExportTemplateResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -236,6 +243,10 @@ func (r *ExportTemplateResource) Read(ctx context.Context, req resource.ReadRequ
 
 // Update updates the export template.
 func (r *ExportTemplateResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
 	var data ExportTemplateResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -320,6 +331,10 @@ func (r *ExportTemplateResource) Update(ctx context.Context, req resource.Update
 
 // Delete deletes the export template.
 func (r *ExportTemplateResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data ExportTemplateResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -8,6 +8,7 @@ import (
 	"net/http"
 
 	"github.com/bab3l/go-netbox"
+	nbschema "github.com/bab3l/terraform-provider-netbox/internal/schema"
 	"github.com/bab3l/terraform-provider-netbox/internal/utils"
 	"github.com/bab3l/terraform-provider-netbox/internal/validators"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
@@ -45,6 +46,7 @@ type FHRPGroupAssignmentResourceModel struct {
 	InterfaceType types.String `tfsdk:"interface_type"`
 	InterfaceID   types.String `tfsdk:"interface_id"`
 	Priority      types.Int64  `tfsdk:"priority"`
+	Branch        types.String `tfsdk:"branch"`
 }
 
 // Metadata returns the resource type name.
@@ -97,6 +99,7 @@ func (r *FHRPGroupAssignmentResource) Schema(ctx context.Context, req resource.S
 			},
 		},
 	}
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 // Configure adds the provider configured client to the resource.
@@ -117,6 +120,10 @@ func (r *FHRPGroupAssignmentResource) Configure(ctx context.Context, req resourc
 
 // Create creates a new FHRP group assignment.
 func (r *FHRPGroupAssignmentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
 	var data FHRPGroupAssignmentResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -201,6 +208,7 @@ func (r *FHRPGroupAssignmentResource) Create(ctx context.Context, req resource.C
 
 // Read reads the FHRP group assignment.
 func (r *FHRPGroupAssignmentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data FHRPGroupAssignmentResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -244,6 +252,10 @@ func (r *FHRPGroupAssignmentResource) Read(ctx context.Context, req resource.Rea
 
 // Update updates the FHRP group assignment.
 func (r *FHRPGroupAssignmentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
 	var data FHRPGroupAssignmentResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -345,6 +357,10 @@ func (r *FHRPGroupAssignmentResource) Update(ctx context.Context, req resource.U
 
 // Delete deletes the FHRP group assignment.
 func (r *FHRPGroupAssignmentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data FHRPGroupAssignmentResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -8,6 +8,7 @@ import (
 	"net/http"
 
 	"github.com/bab3l/go-netbox"
+	"github.com/bab3l/terraform-provider-netbox/internal/netboxlookup"
 	nbschema "github.com/bab3l/terraform-provider-netbox/internal/schema"
 	"github.com/bab3l/terraform-provider-netbox/internal/utils"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
@@ -26,6 +27,7 @@ var (
 	_ resource.Resource                = &FHRPGroupResource{}
 	_ resource.ResourceWithImportState = &FHRPGroupResource{}
 	_ resource.ResourceWithIdentity    = &FHRPGroupResource{}
+	_ resource.ResourceWithModifyPlan  = &FHRPGroupResource{}
 )
 
 func NewFHRPGroupResource() resource.Resource {
@@ -39,16 +41,20 @@ type FHRPGroupResource struct {
 
 // FHRPGroupResourceModel describes the resource data model.
 type FHRPGroupResourceModel struct {
-	ID           types.Int32  `tfsdk:"id"`
-	Name         types.String `tfsdk:"name"`
-	Protocol     types.String `tfsdk:"protocol"`
-	GroupID      types.Int32  `tfsdk:"group_id"`
-	AuthType     types.String `tfsdk:"auth_type"`
-	AuthKey      types.String `tfsdk:"auth_key"`
-	Description  types.String `tfsdk:"description"`
-	Comments     types.String `tfsdk:"comments"`
-	Tags         types.Set    `tfsdk:"tags"`
-	CustomFields types.Set    `tfsdk:"custom_fields"`
+	ID              types.Int32   `tfsdk:"id"`
+	Name            types.String  `tfsdk:"name"`
+	Protocol        types.String  `tfsdk:"protocol"`
+	GroupID         types.Int32   `tfsdk:"group_id"`
+	AuthType        types.String  `tfsdk:"auth_type"`
+	AuthKey         types.String  `tfsdk:"auth_key"`
+	Description     types.String  `tfsdk:"description"`
+	Comments        types.String  `tfsdk:"comments"`
+	Tags            types.Set     `tfsdk:"tags"`
+	CustomFields    types.Set     `tfsdk:"custom_fields"`
+	CustomFieldsMap types.Dynamic `tfsdk:"custom_fields_map"`
+	TagsAll         types.Set     `tfsdk:"tags_all"`
+	CustomFieldsAll types.Map     `tfsdk:"custom_fields_all"`
+	Branch          types.String  `tfsdk:"branch"`
 }
 
 func (r *FHRPGroupResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -87,12 +93,16 @@ func (r *FHRPGroupResource) Schema(ctx context.Context, req resource.SchemaReque
 				Optional:            true,
 				Sensitive:           true,
 			},
-			"description":   nbschema.DescriptionAttribute("FHRP group"),
-			"comments":      nbschema.CommentsAttribute("FHRP group"),
-			"tags":          nbschema.TagsSlugAttribute(),
-			"custom_fields": nbschema.CustomFieldsAttribute(),
+			"description":       nbschema.DescriptionAttribute("FHRP group"),
+			"comments":          nbschema.CommentsAttribute("FHRP group"),
+			"tags":              nbschema.TagsSlugAttribute(),
+			"custom_fields":     nbschema.CustomFieldsAttribute(),
+			"custom_fields_map": nbschema.CustomFieldsMapAttribute(),
+			"tags_all":          nbschema.TagsAllAttribute(),
+			"custom_fields_all": nbschema.CustomFieldsAllAttribute(),
 		},
 	}
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 func (r *FHRPGroupResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
@@ -114,7 +124,20 @@ func (r *FHRPGroupResource) Configure(ctx context.Context, req resource.Configur
 	r.client = client
 }
 
+// ModifyPlan validates the configured custom fields against their definitions in NetBox
+// and plans tags_all and custom_fields_all.
+func (r *FHRPGroupResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	utils.ValidateCustomFieldsPlan(ctx, r.client, "ipam.fhrpgroup", req, resp)
+	utils.PlanEffectiveMetadata(ctx, r.client, "ipam.fhrpgroup", req, resp)
+}
+
 func (r *FHRPGroupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	defer journalCreate(ctx, r.client, "ipam.fhrpgroup", req, resp)
+
 	var data FHRPGroupResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -172,6 +195,7 @@ func (r *FHRPGroupResource) Create(ctx context.Context, req resource.CreateReque
 }
 
 func (r *FHRPGroupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data FHRPGroupResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -225,6 +249,13 @@ func (r *FHRPGroupResource) Read(ctx context.Context, req resource.ReadRequest,
 }
 
 func (r *FHRPGroupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	ctx = withPriorTags(ctx, req.State)
+	defer journalUpdate(ctx, r.client, "ipam.fhrpgroup", req, resp)
+
 	// Read BOTH state and plan for merge-aware custom fields
 	var state, plan FHRPGroupResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -287,6 +318,10 @@ func (r *FHRPGroupResource) Update(ctx context.Context, req resource.UpdateReque
 }
 
 func (r *FHRPGroupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data FHRPGroupResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -368,9 +403,12 @@ func (r *FHRPGroupResource) ImportState(ctx context.Context, req resource.Import
 		if resp.Diagnostics.HasError() {
 			return
 		}
-		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, fhrpGroup.HasTags(), fhrpGroup.GetTags(), data.Tags)
+		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, r.client, fhrpGroup.HasTags(), fhrpGroup.GetTags(), data.Tags)
+		data.TagsAll = utils.TagsAllFromAPI(ctx, fhrpGroup.GetTags())
+		data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, fhrpGroup.GetCustomFields(), &resp.Diagnostics)
 		if parsed.HasCustomFields {
 			data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, fhrpGroup.GetCustomFields(), &resp.Diagnostics)
+			data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, fhrpGroup.GetCustomFields(), &resp.Diagnostics)
 		} else {
 			data.CustomFields = types.SetNull(utils.GetCustomFieldsAttributeType().ElemType)
 		}
@@ -443,8 +481,16 @@ func (r *FHRPGroupResource) setOptionalFields(ctx context.Context, fhrpGroupRequ
 	utils.ApplyTagsFromSlugs(ctx, r.client, fhrpGroupRequest, plan.Tags, diags)
 	if state != nil {
 		utils.ApplyCustomFieldsWithMerge(ctx, fhrpGroupRequest, plan.CustomFields, state.CustomFields, diags)
+		utils.ApplyCustomFieldsMap(ctx, r.client, fhrpGroupRequest, plan.CustomFieldsMap, diags)
+		utils.ApplyProviderTags(ctx, r.client, fhrpGroupRequest, diags)
+		utils.ApplyProviderCustomFields(ctx, r.client, "ipam.fhrpgroup", fhrpGroupRequest, diags)
+		netboxlookup.ResolveCustomFieldReferences(ctx, r.client, fhrpGroupRequest, diags)
 	} else {
 		utils.ApplyCustomFields(ctx, fhrpGroupRequest, plan.CustomFields, diags)
+		utils.ApplyCustomFieldsMap(ctx, r.client, fhrpGroupRequest, plan.CustomFieldsMap, diags)
+		utils.ApplyProviderTags(ctx, r.client, fhrpGroupRequest, diags)
+		utils.ApplyProviderCustomFields(ctx, r.client, "ipam.fhrpgroup", fhrpGroupRequest, diags)
+		netboxlookup.ResolveCustomFieldReferences(ctx, r.client, fhrpGroupRequest, diags)
 	}
 	if diags.HasError() {
 		return
@@ -497,8 +543,11 @@ func (r *FHRPGroupResource) mapFHRPGroupToState(ctx context.Context, fhrpGroup *
 	}
 
 	// Handle tags using slug list handling
-	data.Tags = utils.PopulateTagsSlugFromAPI(ctx, fhrpGroup.HasTags(), fhrpGroup.GetTags(), data.Tags)
+	data.Tags = utils.PopulateTagsSlugFromAPI(ctx, r.client, fhrpGroup.HasTags(), fhrpGroup.GetTags(), data.Tags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, fhrpGroup.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, fhrpGroup.GetCustomFields(), diags)
 
 	// Handle custom fields using filter-to-owned helper
 	data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, fhrpGroup.GetCustomFields(), diags)
+	data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, fhrpGroup.GetCustomFields(), diags)
 }
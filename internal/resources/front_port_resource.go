@@ -30,6 +30,7 @@ var (
 	_ resource.ResourceWithConfigure   = &FrontPortResource{}
 	_ resource.ResourceWithImportState = &FrontPortResource{}
 	_ resource.ResourceWithIdentity    = &FrontPortResource{}
+	_ resource.ResourceWithModifyPlan  = &FrontPortResource{}
 )
 
 // NewFrontPortResource returns a new resource implementing the front port resource.
@@ -44,18 +45,22 @@ type FrontPortResource struct {
 
 // FrontPortResourceModel describes the resource data model.
 type FrontPortResourceModel struct {
-	ID               types.String `tfsdk:"id"`
-	Device           types.String `tfsdk:"device"`
-	Name             types.String `tfsdk:"name"`
-	Label            types.String `tfsdk:"label"`
-	Type             types.String `tfsdk:"type"`
-	Color            types.String `tfsdk:"color"`
-	RearPort         types.String `tfsdk:"rear_port"`
-	RearPortPosition types.Int32  `tfsdk:"rear_port_position"`
-	Description      types.String `tfsdk:"description"`
-	MarkConnected    types.Bool   `tfsdk:"mark_connected"`
-	Tags             types.Set    `tfsdk:"tags"`
-	CustomFields     types.Set    `tfsdk:"custom_fields"`
+	ID               types.String  `tfsdk:"id"`
+	Device           types.String  `tfsdk:"device"`
+	Name             types.String  `tfsdk:"name"`
+	Label            types.String  `tfsdk:"label"`
+	Type             types.String  `tfsdk:"type"`
+	Color            types.String  `tfsdk:"color"`
+	RearPort         types.String  `tfsdk:"rear_port"`
+	RearPortPosition types.Int32   `tfsdk:"rear_port_position"`
+	Description      types.String  `tfsdk:"description"`
+	MarkConnected    types.Bool    `tfsdk:"mark_connected"`
+	Tags             types.Set     `tfsdk:"tags"`
+	CustomFields     types.Set     `tfsdk:"custom_fields"`
+	CustomFieldsMap  types.Dynamic `tfsdk:"custom_fields_map"`
+	TagsAll          types.Set     `tfsdk:"tags_all"`
+	CustomFieldsAll  types.Map     `tfsdk:"custom_fields_all"`
+	Branch           types.String  `tfsdk:"branch"`
 }
 
 // Metadata returns the resource type name.
@@ -120,6 +125,10 @@ func (r *FrontPortResource) Schema(ctx context.Context, req resource.SchemaReque
 	// Add common metadata attributes (tags, custom_fields)
 	resp.Schema.Attributes["tags"] = nbschema.TagsSlugAttribute()
 	resp.Schema.Attributes["custom_fields"] = nbschema.CustomFieldsAttribute()
+	resp.Schema.Attributes["custom_fields_map"] = nbschema.CustomFieldsMapAttribute()
+	resp.Schema.Attributes["tags_all"] = nbschema.TagsAllAttribute()
+	resp.Schema.Attributes["custom_fields_all"] = nbschema.CustomFieldsAllAttribute()
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 func (r *FrontPortResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
@@ -142,8 +151,21 @@ func (r *FrontPortResource) Configure(ctx context.Context, req resource.Configur
 	r.client = client
 }
 
+// ModifyPlan validates the configured custom fields against their definitions in NetBox
+// and plans tags_all and custom_fields_all.
+func (r *FrontPortResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	utils.ValidateCustomFieldsPlan(ctx, r.client, "dcim.frontport", req, resp)
+	utils.PlanEffectiveMetadata(ctx, r.client, "dcim.frontport", req, resp)
+}
+
 // Create creates the resource.
 func (r *FrontPortResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	defer journalCreate(ctx, r.client, "dcim.frontport", req, resp)
+
 	var data FrontPortResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -189,6 +211,10 @@ func (r *FrontPortResource) Create(ctx context.Context, req resource.CreateReque
 	utils.ApplyDescription(apiReq, data.Description)
 	utils.ApplyTagsFromSlugs(ctx, r.client, apiReq, data.Tags, &resp.Diagnostics)
 	utils.ApplyCustomFields(ctx, apiReq, data.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, apiReq, data.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, apiReq, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "dcim.frontport", apiReq, &resp.Diagnostics)
+	lookup.ResolveCustomFieldReferences(ctx, r.client, apiReq, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -225,6 +251,7 @@ func (r *FrontPortResource) Create(ctx context.Context, req resource.CreateReque
 
 // Read retrieves the resource.
 func (r *FrontPortResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data FrontPortResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -274,6 +301,13 @@ func (r *FrontPortResource) Read(ctx context.Context, req resource.ReadRequest,
 
 // Update updates the resource.
 func (r *FrontPortResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	ctx = withPriorTags(ctx, req.State)
+	defer journalUpdate(ctx, r.client, "dcim.frontport", req, resp)
+
 	// Read both state and plan for merge-aware custom fields handling
 	var state, plan FrontPortResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -333,6 +367,10 @@ func (r *FrontPortResource) Update(ctx context.Context, req resource.UpdateReque
 	utils.ApplyDescription(apiReq, plan.Description)
 	utils.ApplyTagsFromSlugs(ctx, r.client, apiReq, plan.Tags, &resp.Diagnostics)
 	utils.ApplyCustomFieldsWithMerge(ctx, apiReq, plan.CustomFields, state.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, apiReq, plan.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, apiReq, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "dcim.frontport", apiReq, &resp.Diagnostics)
+	lookup.ResolveCustomFieldReferences(ctx, r.client, apiReq, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -363,6 +401,10 @@ func (r *FrontPortResource) Update(ctx context.Context, req resource.UpdateReque
 
 // Delete removes the resource.
 func (r *FrontPortResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data FrontPortResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -434,7 +476,9 @@ func (r *FrontPortResource) ImportState(ctx context.Context, req resource.Import
 		if rearPort := response.GetRearPort(); rearPort.Id != 0 {
 			data.RearPort = types.StringValue(fmt.Sprintf("%d", rearPort.GetId()))
 		}
-		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, response.HasTags(), response.GetTags(), data.Tags)
+		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, r.client, response.HasTags(), response.GetTags(), data.Tags)
+		data.TagsAll = utils.TagsAllFromAPI(ctx, response.GetTags())
+		data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, response.GetCustomFields(), &resp.Diagnostics)
 		if parsed.HasCustomFields {
 			if len(parsed.CustomFields) == 0 {
 				data.CustomFields = types.SetValueMust(utils.GetCustomFieldsAttributeType().ElemType, []attr.Value{})
@@ -456,6 +500,7 @@ func (r *FrontPortResource) ImportState(ctx context.Context, req resource.Import
 		}
 		if parsed.HasCustomFields {
 			data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, response.GetCustomFields(), &resp.Diagnostics)
+			data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, response.GetCustomFields(), &resp.Diagnostics)
 		} else {
 			data.CustomFields = types.SetNull(utils.GetCustomFieldsAttributeType().ElemType)
 		}
@@ -567,8 +612,11 @@ func (r *FrontPortResource) mapResponseToModel(ctx context.Context, port *netbox
 
 	// Handle tags with filter-to-owned pattern
 	planTags := data.Tags
-	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, port.HasTags(), port.GetTags(), planTags)
+	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, port.HasTags(), port.GetTags(), planTags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, port.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, port.GetCustomFields(), diags)
 
 	// Handle custom fields with filter-to-owned pattern
 	data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, port.GetCustomFields(), diags)
+	data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, port.GetCustomFields(), diags)
 }
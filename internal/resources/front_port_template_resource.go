@@ -51,6 +51,7 @@ type FrontPortTemplateResourceModel struct {
 	RearPort         types.String `tfsdk:"rear_port"`
 	RearPortPosition types.Int32  `tfsdk:"rear_port_position"`
 	Description      types.String `tfsdk:"description"`
+	Branch           types.String `tfsdk:"branch"`
 }
 
 // Metadata returns the resource type name.
@@ -109,6 +110,7 @@ func (r *FrontPortTemplateResource) Schema(ctx context.Context, req resource.Sch
 
 	// Add description attribute
 	maps.Copy(resp.Schema.Attributes, nbschema.DescriptionOnlyAttributes("front port template"))
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 // Configure adds the provider configured client to the resource.
@@ -129,6 +131,10 @@ func (r *FrontPortTemplateResource) Configure(ctx context.Context, req resource.
 
 // Create creates the resource and sets the initial Terraform state.
 func (r *FrontPortTemplateResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
 	var data FrontPortTemplateResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -209,6 +215,7 @@ func (r *FrontPortTemplateResource) Create(ctx context.Context, req resource.Cre
 
 // Read refreshes the Terraform state with the latest data.
 func (r *FrontPortTemplateResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data FrontPortTemplateResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -247,6 +254,10 @@ func (r *FrontPortTemplateResource) Read(ctx context.Context, req resource.ReadR
 
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *FrontPortTemplateResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
 	var data FrontPortTemplateResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -357,6 +368,10 @@ func (r *FrontPortTemplateResource) resolveRearPortTemplateName(ctx context.Cont
 
 // Delete deletes the resource and removes the Terraform state on success.
 func (r *FrontPortTemplateResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data FrontPortTemplateResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -9,6 +9,7 @@ import (
 	"net/http"
 
 	"github.com/bab3l/go-netbox"
+	"github.com/bab3l/terraform-provider-netbox/internal/netboxlookup"
 	nbschema "github.com/bab3l/terraform-provider-netbox/internal/schema"
 	"github.com/bab3l/terraform-provider-netbox/internal/utils"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
@@ -31,6 +32,7 @@ var (
 	_ resource.ResourceWithConfigure   = &IKEPolicyResource{}
 	_ resource.ResourceWithImportState = &IKEPolicyResource{}
 	_ resource.ResourceWithIdentity    = &IKEPolicyResource{}
+	_ resource.ResourceWithModifyPlan  = &IKEPolicyResource{}
 )
 
 // NewIKEPolicyResource returns a new IKEPolicy resource.
@@ -45,16 +47,20 @@ type IKEPolicyResource struct {
 
 // IKEPolicyResourceModel describes the resource data model.
 type IKEPolicyResourceModel struct {
-	ID           types.String `tfsdk:"id"`
-	Name         types.String `tfsdk:"name"`
-	Description  types.String `tfsdk:"description"`
-	Version      types.Int64  `tfsdk:"version"`
-	Mode         types.String `tfsdk:"mode"`
-	Proposals    types.Set    `tfsdk:"proposals"`
-	PresharedKey types.String `tfsdk:"preshared_key"`
-	Comments     types.String `tfsdk:"comments"`
-	Tags         types.Set    `tfsdk:"tags"`
-	CustomFields types.Set    `tfsdk:"custom_fields"`
+	ID              types.String  `tfsdk:"id"`
+	Name            types.String  `tfsdk:"name"`
+	Description     types.String  `tfsdk:"description"`
+	Version         types.Int64   `tfsdk:"version"`
+	Mode            types.String  `tfsdk:"mode"`
+	Proposals       types.Set     `tfsdk:"proposals"`
+	PresharedKey    types.String  `tfsdk:"preshared_key"`
+	Comments        types.String  `tfsdk:"comments"`
+	Tags            types.Set     `tfsdk:"tags"`
+	CustomFields    types.Set     `tfsdk:"custom_fields"`
+	CustomFieldsMap types.Dynamic `tfsdk:"custom_fields_map"`
+	TagsAll         types.Set     `tfsdk:"tags_all"`
+	CustomFieldsAll types.Map     `tfsdk:"custom_fields_all"`
+	Branch          types.String  `tfsdk:"branch"`
 }
 
 // Metadata returns the resource type name.
@@ -117,6 +123,10 @@ func (r *IKEPolicyResource) Schema(ctx context.Context, req resource.SchemaReque
 	// Add common metadata attributes (tags, custom_fields)
 	resp.Schema.Attributes["tags"] = nbschema.TagsSlugAttribute()
 	resp.Schema.Attributes["custom_fields"] = nbschema.CustomFieldsAttribute()
+	resp.Schema.Attributes["custom_fields_map"] = nbschema.CustomFieldsMapAttribute()
+	resp.Schema.Attributes["tags_all"] = nbschema.TagsAllAttribute()
+	resp.Schema.Attributes["custom_fields_all"] = nbschema.CustomFieldsAllAttribute()
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 func (r *IKEPolicyResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
@@ -139,8 +149,21 @@ func (r *IKEPolicyResource) Configure(ctx context.Context, req resource.Configur
 	r.client = client
 }
 
+// ModifyPlan validates the configured custom fields against their definitions in NetBox
+// and plans tags_all and custom_fields_all.
+func (r *IKEPolicyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	utils.ValidateCustomFieldsPlan(ctx, r.client, "vpn.ikepolicy", req, resp)
+	utils.PlanEffectiveMetadata(ctx, r.client, "vpn.ikepolicy", req, resp)
+}
+
 // Create creates the resource and sets the initial Terraform state.
 func (r *IKEPolicyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	defer journalCreate(ctx, r.client, "vpn.ikepolicy", req, resp)
+
 	var data IKEPolicyResourceModel
 
 	// Read Terraform plan data into the model
@@ -193,6 +216,7 @@ func (r *IKEPolicyResource) Create(ctx context.Context, req resource.CreateReque
 
 // Read refreshes the Terraform state with the latest data.
 func (r *IKEPolicyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data IKEPolicyResourceModel
 
 	// Read Terraform prior state data into the model
@@ -260,6 +284,13 @@ func (r *IKEPolicyResource) Read(ctx context.Context, req resource.ReadRequest,
 
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *IKEPolicyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	ctx = withPriorTags(ctx, req.State)
+	defer journalUpdate(ctx, r.client, "vpn.ikepolicy", req, resp)
+
 	var state, plan IKEPolicyResourceModel
 
 	// Read both state and plan for merge-aware custom fields handling
@@ -315,6 +346,7 @@ func (r *IKEPolicyResource) Update(ctx context.Context, req resource.UpdateReque
 
 	// Apply filter-to-owned pattern for custom fields
 	plan.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, planCustomFields, ike.GetCustomFields(), &resp.Diagnostics)
+	plan.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, plan.CustomFieldsMap, ike.GetCustomFields(), &resp.Diagnostics)
 
 	utils.SetIdentityCustomFields(ctx, resp.Identity, types.StringValue(plan.ID.ValueString()), plan.CustomFields, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
@@ -332,6 +364,10 @@ func (r *IKEPolicyResource) Update(ctx context.Context, req resource.UpdateReque
 
 // Delete deletes the resource and removes the Terraform state on success.
 func (r *IKEPolicyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data IKEPolicyResourceModel
 
 	// Read Terraform prior state data into the model
@@ -399,7 +435,9 @@ func (r *IKEPolicyResource) ImportState(ctx context.Context, req resource.Import
 		}
 
 		var data IKEPolicyResourceModel
-		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, ike.HasTags(), ike.GetTags(), data.Tags)
+		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, r.client, ike.HasTags(), ike.GetTags(), data.Tags)
+		data.TagsAll = utils.TagsAllFromAPI(ctx, ike.GetTags())
+		data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, ike.GetCustomFields(), &resp.Diagnostics)
 		if parsed.HasCustomFields {
 			if len(parsed.CustomFields) == 0 {
 				data.CustomFields = types.SetValueMust(utils.GetCustomFieldsAttributeType().ElemType, []attr.Value{})
@@ -421,6 +459,7 @@ func (r *IKEPolicyResource) ImportState(ctx context.Context, req resource.Import
 		}
 		if parsed.HasCustomFields {
 			data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, ike.GetCustomFields(), &resp.Diagnostics)
+			data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, ike.GetCustomFields(), &resp.Diagnostics)
 		} else {
 			data.CustomFields = types.SetNull(utils.GetCustomFieldsAttributeType().ElemType)
 		}
@@ -531,9 +570,17 @@ func (r *IKEPolicyResource) setOptionalFields(ctx context.Context, ikeRequest *n
 	// Apply custom fields with merge logic to preserve unmanaged fields
 	if state != nil {
 		utils.ApplyCustomFieldsWithMerge(ctx, ikeRequest, plan.CustomFields, state.CustomFields, diags)
+		utils.ApplyCustomFieldsMap(ctx, r.client, ikeRequest, plan.CustomFieldsMap, diags)
+		utils.ApplyProviderTags(ctx, r.client, ikeRequest, diags)
+		utils.ApplyProviderCustomFields(ctx, r.client, "vpn.ikepolicy", ikeRequest, diags)
+		netboxlookup.ResolveCustomFieldReferences(ctx, r.client, ikeRequest, diags)
 	} else {
 		// During Create, no state exists yet
 		utils.ApplyCustomFields(ctx, ikeRequest, plan.CustomFields, diags)
+		utils.ApplyCustomFieldsMap(ctx, r.client, ikeRequest, plan.CustomFieldsMap, diags)
+		utils.ApplyProviderTags(ctx, r.client, ikeRequest, diags)
+		utils.ApplyProviderCustomFields(ctx, r.client, "vpn.ikepolicy", ikeRequest, diags)
+		netboxlookup.ResolveCustomFieldReferences(ctx, r.client, ikeRequest, diags)
 	}
 	if diags.HasError() {
 		return
@@ -596,9 +643,12 @@ func (r *IKEPolicyResource) mapIKEPolicyToState(ctx context.Context, ike *netbox
 
 	// Handle tags with filter-to-owned pattern
 	planTags := data.Tags
-	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, ike.HasTags(), ike.GetTags(), planTags)
+	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, ike.HasTags(), ike.GetTags(), planTags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, ike.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, ike.GetCustomFields(), diags)
 
 	// Handle custom fields using filter-to-owned pattern
 	// Only populate fields that are declared in the config
 	data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, ike.GetCustomFields(), diags)
+	data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, ike.GetCustomFields(), diags)
 }
@@ -9,6 +9,7 @@ import (
 	"net/http"
 
 	"github.com/bab3l/go-netbox"
+	"github.com/bab3l/terraform-provider-netbox/internal/netboxlookup"
 	nbschema "github.com/bab3l/terraform-provider-netbox/internal/schema"
 	"github.com/bab3l/terraform-provider-netbox/internal/utils"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
@@ -32,6 +33,7 @@ var (
 	_ resource.ResourceWithConfigure   = &IKEProposalResource{}
 	_ resource.ResourceWithImportState = &IKEProposalResource{}
 	_ resource.ResourceWithIdentity    = &IKEProposalResource{}
+	_ resource.ResourceWithModifyPlan  = &IKEProposalResource{}
 )
 
 // NewIKEProposalResource returns a new IKEProposal resource.
@@ -46,17 +48,21 @@ type IKEProposalResource struct {
 
 // IKEProposalResourceModel describes the resource data model.
 type IKEProposalResourceModel struct {
-	ID                      types.String `tfsdk:"id"`
-	Name                    types.String `tfsdk:"name"`
-	Description             types.String `tfsdk:"description"`
-	AuthenticationMethod    types.String `tfsdk:"authentication_method"`
-	EncryptionAlgorithm     types.String `tfsdk:"encryption_algorithm"`
-	AuthenticationAlgorithm types.String `tfsdk:"authentication_algorithm"`
-	Group                   types.Int64  `tfsdk:"group"`
-	SALifetime              types.Int64  `tfsdk:"sa_lifetime"`
-	Comments                types.String `tfsdk:"comments"`
-	Tags                    types.Set    `tfsdk:"tags"`
-	CustomFields            types.Set    `tfsdk:"custom_fields"`
+	ID                      types.String  `tfsdk:"id"`
+	Name                    types.String  `tfsdk:"name"`
+	Description             types.String  `tfsdk:"description"`
+	AuthenticationMethod    types.String  `tfsdk:"authentication_method"`
+	EncryptionAlgorithm     types.String  `tfsdk:"encryption_algorithm"`
+	AuthenticationAlgorithm types.String  `tfsdk:"authentication_algorithm"`
+	Group                   types.Int64   `tfsdk:"group"`
+	SALifetime              types.Int64   `tfsdk:"sa_lifetime"`
+	Comments                types.String  `tfsdk:"comments"`
+	Tags                    types.Set     `tfsdk:"tags"`
+	CustomFields            types.Set     `tfsdk:"custom_fields"`
+	CustomFieldsMap         types.Dynamic `tfsdk:"custom_fields_map"`
+	TagsAll                 types.Set     `tfsdk:"tags_all"`
+	CustomFieldsAll         types.Map     `tfsdk:"custom_fields_all"`
+	Branch                  types.String  `tfsdk:"branch"`
 }
 
 // Metadata returns the resource type name.
@@ -129,6 +135,10 @@ func (r *IKEProposalResource) Schema(ctx context.Context, req resource.SchemaReq
 	// Add common metadata attributes (tags, custom_fields)
 	resp.Schema.Attributes["tags"] = nbschema.TagsSlugAttribute()
 	resp.Schema.Attributes["custom_fields"] = nbschema.CustomFieldsAttribute()
+	resp.Schema.Attributes["custom_fields_map"] = nbschema.CustomFieldsMapAttribute()
+	resp.Schema.Attributes["tags_all"] = nbschema.TagsAllAttribute()
+	resp.Schema.Attributes["custom_fields_all"] = nbschema.CustomFieldsAllAttribute()
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 func (r *IKEProposalResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
@@ -151,8 +161,21 @@ func (r *IKEProposalResource) Configure(ctx context.Context, req resource.Config
 	r.client = client
 }
 
+// ModifyPlan validates the configured custom fields against their definitions in NetBox
+// and plans tags_all and custom_fields_all.
+func (r *IKEProposalResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	utils.ValidateCustomFieldsPlan(ctx, r.client, "vpn.ikeproposal", req, resp)
+	utils.PlanEffectiveMetadata(ctx, r.client, "vpn.ikeproposal", req, resp)
+}
+
 // Create creates the resource and sets the initial Terraform state.
 func (r *IKEProposalResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	defer journalCreate(ctx, r.client, "vpn.ikeproposal", req, resp)
+
 	var data IKEProposalResourceModel
 
 	// Read Terraform plan data into the model
@@ -218,6 +241,7 @@ func (r *IKEProposalResource) Create(ctx context.Context, req resource.CreateReq
 
 // Read refreshes the Terraform state with the latest data.
 func (r *IKEProposalResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data IKEProposalResourceModel
 
 	// Read Terraform prior state data into the model
@@ -293,6 +317,13 @@ func (r *IKEProposalResource) Read(ctx context.Context, req resource.ReadRequest
 
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *IKEProposalResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	ctx = withPriorTags(ctx, req.State)
+	defer journalUpdate(ctx, r.client, "vpn.ikeproposal", req, resp)
+
 	var state, plan IKEProposalResourceModel
 
 	// Read current state
@@ -366,6 +397,7 @@ func (r *IKEProposalResource) Update(ctx context.Context, req resource.UpdateReq
 
 	// Apply filter-to-owned pattern for custom fields
 	plan.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, planCustomFields, ike.GetCustomFields(), &resp.Diagnostics)
+	plan.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, plan.CustomFieldsMap, ike.GetCustomFields(), &resp.Diagnostics)
 
 	utils.SetIdentityCustomFields(ctx, resp.Identity, types.StringValue(plan.ID.ValueString()), plan.CustomFields, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
@@ -383,6 +415,10 @@ func (r *IKEProposalResource) Update(ctx context.Context, req resource.UpdateReq
 
 // Delete deletes the resource and removes the Terraform state on success.
 func (r *IKEProposalResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data IKEProposalResourceModel
 
 	// Read Terraform prior state data into the model
@@ -450,7 +486,9 @@ func (r *IKEProposalResource) ImportState(ctx context.Context, req resource.Impo
 		}
 
 		var data IKEProposalResourceModel
-		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, ike.HasTags(), ike.GetTags(), data.Tags)
+		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, r.client, ike.HasTags(), ike.GetTags(), data.Tags)
+		data.TagsAll = utils.TagsAllFromAPI(ctx, ike.GetTags())
+		data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, ike.GetCustomFields(), &resp.Diagnostics)
 		if parsed.HasCustomFields {
 			if len(parsed.CustomFields) == 0 {
 				data.CustomFields = types.SetValueMust(utils.GetCustomFieldsAttributeType().ElemType, []attr.Value{})
@@ -472,6 +510,7 @@ func (r *IKEProposalResource) ImportState(ctx context.Context, req resource.Impo
 		}
 		if parsed.HasCustomFields {
 			data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, ike.GetCustomFields(), &resp.Diagnostics)
+			data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, ike.GetCustomFields(), &resp.Diagnostics)
 		} else {
 			data.CustomFields = types.SetNull(utils.GetCustomFieldsAttributeType().ElemType)
 		}
@@ -529,9 +568,17 @@ func (r *IKEProposalResource) setOptionalFields(ctx context.Context, ikeRequest
 	// Apply custom fields with merge logic to preserve unmanaged fields
 	if state != nil {
 		utils.ApplyCustomFieldsWithMerge(ctx, ikeRequest, plan.CustomFields, state.CustomFields, diags)
+		utils.ApplyCustomFieldsMap(ctx, r.client, ikeRequest, plan.CustomFieldsMap, diags)
+		utils.ApplyProviderTags(ctx, r.client, ikeRequest, diags)
+		utils.ApplyProviderCustomFields(ctx, r.client, "vpn.ikeproposal", ikeRequest, diags)
+		netboxlookup.ResolveCustomFieldReferences(ctx, r.client, ikeRequest, diags)
 	} else {
 		// During Create, no state exists yet
 		utils.ApplyCustomFields(ctx, ikeRequest, plan.CustomFields, diags)
+		utils.ApplyCustomFieldsMap(ctx, r.client, ikeRequest, plan.CustomFieldsMap, diags)
+		utils.ApplyProviderTags(ctx, r.client, ikeRequest, diags)
+		utils.ApplyProviderCustomFields(ctx, r.client, "vpn.ikeproposal", ikeRequest, diags)
+		netboxlookup.ResolveCustomFieldReferences(ctx, r.client, ikeRequest, diags)
 	}
 	if diags.HasError() {
 		return
@@ -591,8 +638,11 @@ func (r *IKEProposalResource) mapIKEProposalToState(ctx context.Context, ike *ne
 
 	// Handle tags with filter-to-owned pattern
 	planTags := data.Tags
-	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, ike.HasTags(), ike.GetTags(), planTags)
+	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, ike.HasTags(), ike.GetTags(), planTags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, ike.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, ike.GetCustomFields(), diags)
 
 	// Handle custom fields using consolidated helper
-	data.CustomFields = utils.PopulateCustomFieldsFromAPI(ctx, ike.HasCustomFields(), ike.GetCustomFields(), data.CustomFields, diags)
+	data.CustomFields = utils.PopulateCustomFieldsFromAPI(ctx, r.client, ike.HasCustomFields(), ike.GetCustomFields(), data.CustomFields, diags)
+	data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, ike.GetCustomFields(), diags)
 }
@@ -6,8 +6,10 @@ import (
 	"context"
 	"fmt"
 	"maps"
+	"math"
 	"net/http"
 	"sort"
+	"strings"
 
 	"github.com/bab3l/go-netbox"
 	"github.com/bab3l/terraform-provider-netbox/internal/netboxlookup"
@@ -15,6 +17,7 @@ import (
 	"github.com/bab3l/terraform-provider-netbox/internal/utils"
 	"github.com/bab3l/terraform-provider-netbox/internal/validators"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -32,6 +35,9 @@ var (
 	_ resource.Resource                = &InterfaceResource{}
 	_ resource.ResourceWithImportState = &InterfaceResource{}
 	_ resource.ResourceWithIdentity    = &InterfaceResource{}
+
+	_ resource.ResourceWithValidateConfig = &InterfaceResource{}
+	_ resource.ResourceWithModifyPlan     = &InterfaceResource{}
 )
 
 func NewInterfaceResource() resource.Resource {
@@ -45,9 +51,19 @@ type InterfaceResource struct {
 
 // InterfaceResourceModel describes the resource data model.
 type InterfaceResourceModel struct {
-	ID            types.String `tfsdk:"id"`
-	Device        types.String `tfsdk:"device"`
-	Name          types.String `tfsdk:"name"`
+	ID     types.String `tfsdk:"id"`
+	Device types.String `tfsdk:"device"`
+	Name   types.String `tfsdk:"name"`
+	InterfaceAttributesModel
+	CustomFieldsMap types.Dynamic `tfsdk:"custom_fields_map"`
+	TagsAll         types.Set     `tfsdk:"tags_all"`
+	CustomFieldsAll types.Map     `tfsdk:"custom_fields_all"`
+	Branch          types.String  `tfsdk:"branch"`
+}
+
+// InterfaceAttributesModel describes the settings of an interface, shared by
+// netbox_interface and the entries of netbox_device_interfaces.
+type InterfaceAttributesModel struct {
 	Label         types.String `tfsdk:"label"`
 	Type          types.String `tfsdk:"type"`
 	Enabled       types.Bool   `tfsdk:"enabled"`
@@ -65,6 +81,14 @@ type InterfaceResourceModel struct {
 	UntaggedVLAN  types.String `tfsdk:"untagged_vlan"`
 	TaggedVLANs   types.Set    `tfsdk:"tagged_vlans"`
 	MarkConnected types.Bool   `tfsdk:"mark_connected"`
+	Vrf           types.String `tfsdk:"vrf"`
+	PoeMode       types.String `tfsdk:"poe_mode"`
+	PoeType       types.String `tfsdk:"poe_type"`
+	RfRole        types.String `tfsdk:"rf_role"`
+	RfChannel     types.String `tfsdk:"rf_channel"`
+	TxPower       types.Int64  `tfsdk:"tx_power"`
+	WirelessLans  types.Set    `tfsdk:"wireless_lans"`
+	Vdcs          types.Set    `tfsdk:"vdcs"`
 	Tags          types.Set    `tfsdk:"tags"`
 	CustomFields  types.Set    `tfsdk:"custom_fields"`
 }
@@ -86,95 +110,161 @@ func (r *InterfaceResource) Schema(ctx context.Context, req resource.SchemaReque
 					stringvalidator.LengthBetween(1, 64),
 				},
 			},
-			"label": schema.StringAttribute{
-				MarkdownDescription: "Physical label on the interface.",
-				Optional:            true,
-				Validators: []validator.String{
-					stringvalidator.LengthAtMost(64),
-				},
+		},
+	}
+
+	// Add the interface settings, including description, tags and custom_fields
+	maps.Copy(resp.Schema.Attributes, interfaceAttributes())
+
+	// Add common metadata attributes
+	resp.Schema.Attributes["custom_fields_map"] = nbschema.CustomFieldsMapAttribute()
+	resp.Schema.Attributes["tags_all"] = nbschema.TagsAllAttribute()
+	resp.Schema.Attributes["custom_fields_all"] = nbschema.CustomFieldsAllAttribute()
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
+}
+
+// interfaceAttributes returns the schema of the interface settings shared by
+// netbox_interface and the entries of netbox_device_interfaces.
+func interfaceAttributes() map[string]schema.Attribute {
+	attributes := map[string]schema.Attribute{
+		"label": schema.StringAttribute{
+			MarkdownDescription: "Physical label on the interface.",
+			Optional:            true,
+			Validators: []validator.String{
+				stringvalidator.LengthAtMost(64),
 			},
-			"type": schema.StringAttribute{
-				MarkdownDescription: "Type of interface. Common values: `virtual`, `bridge`, `lag`, `1000base-t`, `10gbase-t`, `10gbase-x-sfpp`, `25gbase-x-sfp28`, `40gbase-x-qsfpp`, `100gbase-x-qsfp28`. Required.",
-				Required:            true,
+		},
+		"type": schema.StringAttribute{
+			MarkdownDescription: "Type of interface. Common values: `virtual`, `bridge`, `lag`, `1000base-t`, `10gbase-t`, `10gbase-x-sfpp`, `25gbase-x-sfp28`, `40gbase-x-qsfpp`, `100gbase-x-qsfp28`. Required.",
+			Required:            true,
+		},
+		"enabled": schema.BoolAttribute{
+			MarkdownDescription: "Whether the interface is enabled. Defaults to `true`.",
+			Optional:            true,
+			Computed:            true,
+			Default:             booldefault.StaticBool(true),
+		},
+		"parent": nbschema.ReferenceAttributeWithDiffSuppress("parent interface", "ID of the parent interface (for sub-interfaces)."),
+		"bridge": nbschema.ReferenceAttributeWithDiffSuppress("bridge interface", "ID of the bridge interface this interface belongs to."),
+		"lag":    nbschema.ReferenceAttributeWithDiffSuppress("LAG interface", "ID of the LAG (Link Aggregation Group) this interface is a member of."),
+		"mtu": schema.Int64Attribute{
+			MarkdownDescription: "Maximum transmission unit (MTU) size. Common values: 1500 (Ethernet), 9000 (Jumbo frames).",
+			Optional:            true,
+			Validators: []validator.Int64{
+				int64validator.Between(1, 65536),
 			},
-			"enabled": schema.BoolAttribute{
-				MarkdownDescription: "Whether the interface is enabled. Defaults to `true`.",
-				Optional:            true,
-				Computed:            true,
-				Default:             booldefault.StaticBool(true),
+		},
+		"mac_address": schema.StringAttribute{
+			MarkdownDescription: "MAC address of the interface in format `AA:BB:CC:DD:EE:FF`.",
+			Optional:            true,
+			Validators: []validator.String{
+				validators.ValidMACAddress(),
 			},
-			"parent": nbschema.ReferenceAttributeWithDiffSuppress("parent interface", "ID of the parent interface (for sub-interfaces)."),
-			"bridge": nbschema.ReferenceAttributeWithDiffSuppress("bridge interface", "ID of the bridge interface this interface belongs to."),
-			"lag":    nbschema.ReferenceAttributeWithDiffSuppress("LAG interface", "ID of the LAG (Link Aggregation Group) this interface is a member of."),
-			"mtu": schema.Int64Attribute{
-				MarkdownDescription: "Maximum transmission unit (MTU) size. Common values: 1500 (Ethernet), 9000 (Jumbo frames).",
-				Optional:            true,
-				Validators: []validator.Int64{
-					int64validator.Between(1, 65536),
-				},
+		},
+		"speed": schema.Int64Attribute{
+			MarkdownDescription: "Interface speed in Kbps (e.g., 1000000 for 1Gbps, 10000000 for 10Gbps).",
+			Optional:            true,
+			Validators: []validator.Int64{
+				int64validator.Between(0, math.MaxInt32),
 			},
-			"mac_address": schema.StringAttribute{
-				MarkdownDescription: "MAC address of the interface in format `AA:BB:CC:DD:EE:FF`.",
-				Optional:            true,
-				Validators: []validator.String{
-					validators.ValidMACAddress(),
-				},
+		},
+		"duplex": schema.StringAttribute{
+			MarkdownDescription: "Duplex mode. Valid values: `half`, `full`, `auto`. Not supported on virtual or wireless interfaces.",
+			Optional:            true,
+			Validators: []validator.String{
+				stringvalidator.OneOf("half", "full", "auto", ""),
 			},
-			"speed": schema.Int64Attribute{
-				MarkdownDescription: "Interface speed in Kbps (e.g., 1000000 for 1Gbps, 10000000 for 10Gbps).",
-				Optional:            true,
-				Validators: []validator.Int64{
-					int64validator.AtLeast(0),
-				},
+		},
+		"wwn": schema.StringAttribute{
+			MarkdownDescription: "World Wide Name (WWN) for Fibre Channel interfaces.",
+			Optional:            true,
+		},
+		"mgmt_only": schema.BoolAttribute{
+			MarkdownDescription: "This interface is used only for out-of-band management.",
+			Optional:            true,
+			Computed:            true,
+			Default:             booldefault.StaticBool(false),
+		},
+		"mode": schema.StringAttribute{
+			MarkdownDescription: "802.1Q mode. Valid values: `access`, `tagged`, `tagged-all`.",
+			Optional:            true,
+			Validators: []validator.String{
+				stringvalidator.OneOf("access", "tagged", "tagged-all", ""),
 			},
-			"duplex": schema.StringAttribute{
-				MarkdownDescription: "Duplex mode. Valid values: `half`, `full`, `auto`.",
-				Optional:            true,
-				Validators: []validator.String{
-					stringvalidator.OneOf("half", "full", "auto", ""),
-				},
+		},
+		"untagged_vlan": nbschema.ReferenceAttributeWithDiffSuppress(
+			"vlan",
+			"The name or ID of the untagged VLAN (for access or tagged mode).",
+		),
+		"tagged_vlans": schema.SetAttribute{
+			MarkdownDescription: "Set of VLAN names or IDs to tag on this interface. Can only be set when mode is `tagged` or `tagged-all`.",
+			Optional:            true,
+			ElementType:         types.StringType,
+		},
+		"mark_connected": schema.BoolAttribute{
+			MarkdownDescription: "Treat as if a cable is connected, even if no cable is attached.",
+			Optional:            true,
+			Computed:            true,
+			Default:             booldefault.StaticBool(false),
+		},
+		"vrf": nbschema.ReferenceAttributeWithDiffSuppress("VRF", "ID or name of the VRF this interface is assigned to."),
+		"poe_mode": schema.StringAttribute{
+			MarkdownDescription: "Power over Ethernet mode. Valid values: `pd`, `pse`. Not supported on virtual interfaces.",
+			Optional:            true,
+			Validators: []validator.String{
+				stringvalidator.OneOf(utils.EnumStrings(netbox.AllowedInterfacePoeModeValueEnumValues)...),
 			},
-			"wwn": schema.StringAttribute{
-				MarkdownDescription: "World Wide Name (WWN) for Fibre Channel interfaces.",
-				Optional:            true,
+		},
+		"poe_type": schema.StringAttribute{
+			MarkdownDescription: "Power over Ethernet type, e.g. `type2-ieee802.3at`. Requires `poe_mode`.",
+			Optional:            true,
+			Validators: []validator.String{
+				stringvalidator.OneOf(utils.EnumStrings(netbox.AllowedInterfacePoeTypeValueEnumValues)...),
+				stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("poe_mode")),
 			},
-			"mgmt_only": schema.BoolAttribute{
-				MarkdownDescription: "This interface is used only for out-of-band management.",
-				Optional:            true,
-				Computed:            true,
-				Default:             booldefault.StaticBool(false),
+		},
+		"rf_role": schema.StringAttribute{
+			MarkdownDescription: "Wireless role. Valid values: `ap`, `station`. Only supported on wireless interfaces.",
+			Optional:            true,
+			Validators: []validator.String{
+				stringvalidator.OneOf(utils.EnumStrings(netbox.AllowedWirelessRoleEnumValues)...),
 			},
-			"mode": schema.StringAttribute{
-				MarkdownDescription: "802.1Q mode. Valid values: `access`, `tagged`, `tagged-all`.",
-				Optional:            true,
-				Validators: []validator.String{
-					stringvalidator.OneOf("access", "tagged", "tagged-all", ""),
-				},
+		},
+		"rf_channel": schema.StringAttribute{
+			MarkdownDescription: "Wireless channel, e.g. `5g-36-5180-20`. Only supported on wireless interfaces.",
+			Optional:            true,
+			Validators: []validator.String{
+				stringvalidator.OneOf(utils.EnumStrings(netbox.AllowedWirelessChannelEnumValues)...),
 			},
-			"untagged_vlan": nbschema.ReferenceAttributeWithDiffSuppress(
-				"vlan",
-				"The name or ID of the untagged VLAN (for access or tagged mode).",
-			),
-			"tagged_vlans": schema.SetAttribute{
-				MarkdownDescription: "Set of VLAN names or IDs to tag on this interface. Can only be set when mode is `tagged` or `tagged-all`.",
-				Optional:            true,
-				ElementType:         types.StringType,
+		},
+		"tx_power": schema.Int64Attribute{
+			MarkdownDescription: "Transmit power in dBm. Only supported on wireless interfaces.",
+			Optional:            true,
+			Validators: []validator.Int64{
+				int64validator.Between(0, 127),
 			},
-			"mark_connected": schema.BoolAttribute{
-				MarkdownDescription: "Treat as if a cable is connected, even if no cable is attached.",
-				Optional:            true,
-				Computed:            true,
-				Default:             booldefault.StaticBool(false),
+		},
+		"wireless_lans": schema.SetAttribute{
+			MarkdownDescription: "Set of wireless LAN IDs attached to this interface. Only supported on wireless interfaces.",
+			Optional:            true,
+			ElementType:         types.Int64Type,
+			Validators: []validator.Set{
+				setvalidator.ValueInt64sAre(int64validator.Between(1, math.MaxInt32)),
+			},
+		},
+		"vdcs": schema.SetAttribute{
+			MarkdownDescription: "Set of virtual device context IDs this interface is assigned to.",
+			Optional:            true,
+			ElementType:         types.Int64Type,
+			Validators: []validator.Set{
+				setvalidator.ValueInt64sAre(int64validator.Between(1, math.MaxInt32)),
 			},
 		},
 	}
-
-	// Add description attribute
-	maps.Copy(resp.Schema.Attributes, nbschema.DescriptionOnlyAttributes("interface"))
-
-	// Add common metadata attributes (tags, custom_fields)
-	resp.Schema.Attributes["tags"] = nbschema.TagsSlugAttribute()
-	resp.Schema.Attributes["custom_fields"] = nbschema.CustomFieldsAttribute()
+	maps.Copy(attributes, nbschema.DescriptionOnlyAttributes("interface"))
+	attributes["tags"] = nbschema.TagsSlugAttribute()
+	attributes["custom_fields"] = nbschema.CustomFieldsAttribute()
+	return attributes
 }
 
 func (r *InterfaceResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
@@ -197,15 +287,95 @@ func (r *InterfaceResource) Configure(ctx context.Context, req resource.Configur
 	r.client = client
 }
 
+// ValidateConfig checks that PoE, wireless and duplex attributes are only set
+// on interface types that support them.
+func (r *InterfaceResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data InterfaceResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	validateInterfaceAttributes(&data.InterfaceAttributesModel, path.Root, &resp.Diagnostics)
+}
+
+// validateInterfaceAttributes checks that PoE, wireless and duplex attributes
+// are only set on interface types that support them. attributePath returns
+// the path of an attribute of data, for the diagnostics.
+func validateInterfaceAttributes(data *InterfaceAttributesModel, attributePath func(string) path.Path, diags *diag.Diagnostics) {
+	if data.Type.IsNull() || data.Type.IsUnknown() {
+		return
+	}
+	interfaceType := data.Type.ValueString()
+	virtual := isVirtualInterfaceType(interfaceType)
+	wireless := isWirelessInterfaceType(interfaceType)
+
+	unsupported := func(attribute string, value attr.Value, reason string) {
+		if value.IsNull() || value.IsUnknown() {
+			return
+		}
+		diags.AddAttributeError(
+			attributePath(attribute),
+			"Unsupported interface attribute",
+			fmt.Sprintf("`%s` cannot be set on interfaces of type %q: %s.", attribute, interfaceType, reason),
+		)
+	}
+	if virtual {
+		const reason = "virtual interfaces cannot supply or draw PoE"
+		unsupported("poe_mode", data.PoeMode, reason)
+		unsupported("poe_type", data.PoeType, reason)
+	}
+	if virtual || wireless {
+		unsupported("duplex", data.Duplex, "duplex only applies to physical wired interfaces")
+	}
+	if !wireless {
+		const reason = "only wireless interfaces have RF attributes"
+		unsupported("rf_role", data.RfRole, reason)
+		unsupported("rf_channel", data.RfChannel, reason)
+		unsupported("tx_power", data.TxPower, reason)
+		unsupported("wireless_lans", data.WirelessLans, reason)
+	}
+}
+
+// isVirtualInterfaceType reports whether an interface type has no physical
+// presence, as NetBox defines it.
+func isVirtualInterfaceType(interfaceType string) bool {
+	switch interfaceType {
+	case "virtual", "bridge", "lag":
+		return true
+	}
+	return false
+}
+
+// isWirelessInterfaceType reports whether an interface type is a wireless
+// (802.11, 802.15 or other wireless) type.
+func isWirelessInterfaceType(interfaceType string) bool {
+	return strings.HasPrefix(interfaceType, "ieee802.11") ||
+		strings.HasPrefix(interfaceType, "ieee802.15") ||
+		interfaceType == "other-wireless"
+}
+
+// ModifyPlan validates the configured custom fields against their definitions in NetBox
+// and plans tags_all and custom_fields_all.
+func (r *InterfaceResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	utils.ValidateCustomFieldsPlan(ctx, r.client, "dcim.interface", req, resp)
+	utils.PlanEffectiveMetadata(ctx, r.client, "dcim.interface", req, resp)
+}
+
 // Create creates a new interface in Netbox.
 func (r *InterfaceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	defer journalCreate(ctx, r.client, "dcim.interface", req, resp)
+
 	var data InterfaceResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	validateInterfaceModeAndTaggedVLANs(ctx, &data, &resp.Diagnostics)
+	validateInterfaceModeAndTaggedVLANs(ctx, &data.InterfaceAttributesModel, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -222,7 +392,7 @@ func (r *InterfaceResource) Create(ctx context.Context, req resource.CreateReque
 	interfaceReq := netbox.NewWritableInterfaceRequest(*deviceRef, data.Name.ValueString(), interfaceType)
 
 	// Set optional fields
-	r.setOptionalFields(ctx, interfaceReq, &data, &resp.Diagnostics)
+	setInterfaceOptionalFields(ctx, r.client, interfaceReq, &data.InterfaceAttributesModel, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -230,6 +400,10 @@ func (r *InterfaceResource) Create(ctx context.Context, req resource.CreateReque
 	// Apply tags and custom fields separately (filter-to-owned pattern)
 	utils.ApplyTagsFromSlugs(ctx, r.client, interfaceReq, data.Tags, &resp.Diagnostics)
 	utils.ApplyCustomFields(ctx, interfaceReq, data.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, interfaceReq, data.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, interfaceReq, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "dcim.interface", interfaceReq, &resp.Diagnostics)
+	netboxlookup.ResolveCustomFieldReferences(ctx, r.client, interfaceReq, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -266,6 +440,7 @@ func (r *InterfaceResource) Create(ctx context.Context, req resource.CreateReque
 
 // Read refreshes the Terraform state with the latest data.
 func (r *InterfaceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data InterfaceResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -320,6 +495,13 @@ func (r *InterfaceResource) Read(ctx context.Context, req resource.ReadRequest,
 
 // Update updates an existing interface in Netbox.
 func (r *InterfaceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	ctx = withPriorTags(ctx, req.State)
+	defer journalUpdate(ctx, r.client, "dcim.interface", req, resp)
+
 	var state, data InterfaceResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -327,7 +509,7 @@ func (r *InterfaceResource) Update(ctx context.Context, req resource.UpdateReque
 		return
 	}
 
-	validateInterfaceModeAndTaggedVLANs(ctx, &data, &resp.Diagnostics)
+	validateInterfaceModeAndTaggedVLANs(ctx, &data.InterfaceAttributesModel, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -349,7 +531,7 @@ func (r *InterfaceResource) Update(ctx context.Context, req resource.UpdateReque
 	interfaceReq := netbox.NewWritableInterfaceRequest(*deviceRef, data.Name.ValueString(), interfaceType)
 
 	// Set optional fields
-	r.setOptionalFields(ctx, interfaceReq, &data, &resp.Diagnostics)
+	setInterfaceOptionalFields(ctx, r.client, interfaceReq, &data.InterfaceAttributesModel, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -361,6 +543,10 @@ func (r *InterfaceResource) Update(ctx context.Context, req resource.UpdateReque
 		utils.ApplyTagsFromSlugs(ctx, r.client, interfaceReq, state.Tags, &resp.Diagnostics)
 	}
 	utils.ApplyCustomFieldsWithMerge(ctx, interfaceReq, data.CustomFields, state.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, interfaceReq, data.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, interfaceReq, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "dcim.interface", interfaceReq, &resp.Diagnostics)
+	netboxlookup.ResolveCustomFieldReferences(ctx, r.client, interfaceReq, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -396,6 +582,10 @@ func (r *InterfaceResource) Update(ctx context.Context, req resource.UpdateReque
 
 // Delete removes an interface from Netbox.
 func (r *InterfaceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data InterfaceResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -465,7 +655,9 @@ func (r *InterfaceResource) ImportState(ctx context.Context, req resource.Import
 		var data InterfaceResourceModel
 		device := iface.GetDevice()
 		data.Device = types.StringValue(fmt.Sprintf("%d", (&device).GetId()))
-		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, iface.HasTags(), iface.GetTags(), data.Tags)
+		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, r.client, iface.HasTags(), iface.GetTags(), data.Tags)
+		data.TagsAll = utils.TagsAllFromAPI(ctx, iface.GetTags())
+		data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, iface.GetCustomFields(), &resp.Diagnostics)
 		if parsed.HasCustomFields {
 			if len(parsed.CustomFields) == 0 {
 				data.CustomFields = types.SetValueMust(utils.GetCustomFieldsAttributeType().ElemType, []attr.Value{})
@@ -487,6 +679,7 @@ func (r *InterfaceResource) ImportState(ctx context.Context, req resource.Import
 		}
 		if parsed.HasCustomFields {
 			data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, iface.GetCustomFields(), &resp.Diagnostics)
+			data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, iface.GetCustomFields(), &resp.Diagnostics)
 		} else {
 			data.CustomFields = types.SetNull(utils.GetCustomFieldsAttributeType().ElemType)
 		}
@@ -513,8 +706,9 @@ func (r *InterfaceResource) ImportState(ctx context.Context, req resource.Import
 	utils.ImportStatePassthroughIDWithValidation(ctx, req, resp, path.Root("id"), true)
 }
 
-// setOptionalFields sets optional fields on the interface request.
-func (r *InterfaceResource) setOptionalFields(ctx context.Context, interfaceReq *netbox.WritableInterfaceRequest, data *InterfaceResourceModel, diags *diag.Diagnostics) {
+// setInterfaceOptionalFields sets the optional fields of an interface request
+// from the interface settings in data.
+func setInterfaceOptionalFields(ctx context.Context, client *netbox.APIClient, interfaceReq *netbox.WritableInterfaceRequest, data *InterfaceAttributesModel, diags *diag.Diagnostics) {
 	// Label
 	if !data.Label.IsNull() && !data.Label.IsUnknown() {
 		label := data.Label.ValueString()
@@ -627,7 +821,7 @@ func (r *InterfaceResource) setOptionalFields(ctx context.Context, interfaceReq
 
 	// Untagged VLAN
 	if !data.UntaggedVLAN.IsNull() && !data.UntaggedVLAN.IsUnknown() {
-		vlan, vlanDiags := netboxlookup.LookupVLAN(ctx, r.client, data.UntaggedVLAN.ValueString())
+		vlan, vlanDiags := netboxlookup.LookupVLAN(ctx, client, data.UntaggedVLAN.ValueString())
 		diags.Append(vlanDiags...)
 		if diags.HasError() {
 			return
@@ -639,7 +833,7 @@ func (r *InterfaceResource) setOptionalFields(ctx context.Context, interfaceReq
 
 	// Tagged VLANs
 	if !data.TaggedVLANs.IsNull() && !data.TaggedVLANs.IsUnknown() {
-		vlanIDs, vlanDiags := r.resolveInterfaceTaggedVLANIDs(ctx, data.TaggedVLANs)
+		vlanIDs, vlanDiags := resolveInterfaceTaggedVLANIDs(ctx, client, data.TaggedVLANs)
 		diags.Append(vlanDiags...)
 		if diags.HasError() {
 			return
@@ -654,6 +848,52 @@ func (r *InterfaceResource) setOptionalFields(ctx context.Context, interfaceReq
 		markConnected := data.MarkConnected.ValueBool()
 		interfaceReq.MarkConnected = &markConnected
 	}
+
+	// VRF
+	if !data.Vrf.IsNull() && !data.Vrf.IsUnknown() {
+		vrf, vrfDiags := netboxlookup.LookupVRF(ctx, client, data.Vrf.ValueString())
+		diags.Append(vrfDiags...)
+		if diags.HasError() {
+			return
+		}
+		interfaceReq.SetVrf(*vrf)
+	} else if data.Vrf.IsNull() {
+		interfaceReq.SetVrfNil()
+	}
+
+	// PoE and RF choices are NOT NULL in NetBox, so clear them with an empty value.
+	if !data.PoeMode.IsUnknown() {
+		interfaceReq.SetPoeMode(netbox.InterfacePoeModeValue(data.PoeMode.ValueString()))
+	}
+	if !data.PoeType.IsUnknown() {
+		interfaceReq.SetPoeType(netbox.InterfacePoeTypeValue(data.PoeType.ValueString()))
+	}
+	if !data.RfRole.IsUnknown() {
+		interfaceReq.SetRfRole(netbox.WirelessRole(data.RfRole.ValueString()))
+	}
+	if !data.RfChannel.IsUnknown() {
+		interfaceReq.SetRfChannel(netbox.WirelessChannel(data.RfChannel.ValueString()))
+	}
+
+	// TX power
+	if !data.TxPower.IsNull() && !data.TxPower.IsUnknown() {
+		txPower, err := utils.SafeInt32FromValue(data.TxPower)
+		if err != nil {
+			diags.AddError("Invalid value", fmt.Sprintf("TxPower value overflow: %s", err))
+			return
+		}
+		interfaceReq.SetTxPower(txPower)
+	} else if data.TxPower.IsNull() {
+		interfaceReq.SetTxPowerNil()
+	}
+
+	// Wireless LANs and VDCs; an empty list clears the assignments.
+	if !data.WirelessLans.IsUnknown() {
+		interfaceReq.WirelessLans = setToInt32Slice(ctx, data.WirelessLans)
+	}
+	if !data.Vdcs.IsUnknown() {
+		interfaceReq.Vdcs = setToInt32Slice(ctx, data.Vdcs)
+	}
 }
 
 // mapInterfaceToState maps a Netbox Interface to the Terraform state model.
@@ -665,6 +905,17 @@ func (r *InterfaceResource) mapInterfaceToState(ctx context.Context, iface *netb
 	device := iface.GetDevice()
 	data.Device = utils.UpdateReferenceAttribute(data.Device, device.GetName(), "", device.GetId())
 
+	mapInterfaceAttributes(ctx, r.client, iface, &data.InterfaceAttributesModel, diags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, iface.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, iface.GetCustomFields(), diags)
+	if iface.HasCustomFields() {
+		data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, iface.GetCustomFields(), diags)
+	}
+}
+
+// mapInterfaceAttributes maps the settings of a Netbox Interface to data,
+// keeping the configured form of references, tags and custom fields.
+func mapInterfaceAttributes(ctx context.Context, client *netbox.APIClient, iface *netbox.Interface, data *InterfaceAttributesModel, diags *diag.Diagnostics) {
 	// Type
 	ifaceType := iface.GetType()
 	if value, ok := ifaceType.GetValueOk(); ok && value != nil {
@@ -806,9 +1057,53 @@ func (r *InterfaceResource) mapInterfaceToState(ctx context.Context, iface *netb
 		data.MarkConnected = types.BoolValue(false)
 	}
 
+	// VRF
+	if vrf, ok := iface.GetVrfOk(); ok && vrf != nil {
+		data.Vrf = utils.UpdateReferenceAttribute(data.Vrf, vrf.GetName(), "", vrf.GetId())
+	} else {
+		data.Vrf = types.StringNull()
+	}
+
+	// PoE and RF choices
+	data.PoeMode = types.StringNull()
+	if poeMode, ok := iface.GetPoeModeOk(); ok && poeMode != nil && poeMode.GetValue() != "" {
+		data.PoeMode = types.StringValue(string(poeMode.GetValue()))
+	}
+	data.PoeType = types.StringNull()
+	if poeType, ok := iface.GetPoeTypeOk(); ok && poeType != nil && poeType.GetValue() != "" {
+		data.PoeType = types.StringValue(string(poeType.GetValue()))
+	}
+	data.RfRole = types.StringNull()
+	if rfRole, ok := iface.GetRfRoleOk(); ok && rfRole != nil && rfRole.GetValue() != "" {
+		data.RfRole = types.StringValue(string(rfRole.GetValue()))
+	}
+	data.RfChannel = types.StringNull()
+	if rfChannel, ok := iface.GetRfChannelOk(); ok && rfChannel != nil && rfChannel.GetValue() != "" {
+		data.RfChannel = types.StringValue(string(rfChannel.GetValue()))
+	}
+
+	// TX power
+	if txPower, ok := iface.GetTxPowerOk(); ok && txPower != nil {
+		data.TxPower = types.Int64Value(int64(*txPower))
+	} else {
+		data.TxPower = types.Int64Null()
+	}
+
+	// Wireless LANs and VDCs
+	wirelessLANIDs := make([]int32, 0, len(iface.GetWirelessLans()))
+	for _, wlan := range iface.GetWirelessLans() {
+		wirelessLANIDs = append(wirelessLANIDs, wlan.GetId())
+	}
+	data.WirelessLans = updateInterfaceIDSet(ctx, data.WirelessLans, wirelessLANIDs, diags)
+	vdcIDs := make([]int32, 0, len(iface.GetVdcs()))
+	for _, vdc := range iface.GetVdcs() {
+		vdcIDs = append(vdcIDs, vdc.GetId())
+	}
+	data.Vdcs = updateInterfaceIDSet(ctx, data.Vdcs, vdcIDs, diags)
+
 	// Tags with filter-to-owned pattern
 	planTags := data.Tags
-	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, iface.HasTags(), iface.GetTags(), planTags)
+	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, client, iface.HasTags(), iface.GetTags(), planTags)
 
 	// Custom Fields
 	if iface.HasCustomFields() {
@@ -818,7 +1113,7 @@ func (r *InterfaceResource) mapInterfaceToState(ctx context.Context, iface *netb
 	}
 }
 
-func validateInterfaceModeAndTaggedVLANs(ctx context.Context, data *InterfaceResourceModel, diags *diag.Diagnostics) {
+func validateInterfaceModeAndTaggedVLANs(ctx context.Context, data *InterfaceAttributesModel, diags *diag.Diagnostics) {
 	if data.TaggedVLANs.IsNull() || data.TaggedVLANs.IsUnknown() {
 		return
 	}
@@ -845,7 +1140,7 @@ func validateInterfaceModeAndTaggedVLANs(ctx context.Context, data *InterfaceRes
 	}
 }
 
-func (r *InterfaceResource) resolveInterfaceTaggedVLANIDs(ctx context.Context, taggedVlans types.Set) ([]int32, diag.Diagnostics) {
+func resolveInterfaceTaggedVLANIDs(ctx context.Context, client *netbox.APIClient, taggedVlans types.Set) ([]int32, diag.Diagnostics) {
 	var vlanRefs []string
 	var diags diag.Diagnostics
 	if setDiags := taggedVlans.ElementsAs(ctx, &vlanRefs, false); setDiags.HasError() {
@@ -855,7 +1150,7 @@ func (r *InterfaceResource) resolveInterfaceTaggedVLANIDs(ctx context.Context, t
 
 	ids := make([]int32, 0, len(vlanRefs))
 	for _, vlanRef := range vlanRefs {
-		id, vlanDiags := netboxlookup.GenericLookupID(ctx, vlanRef, netboxlookup.VLANLookupConfig(r.client), func(v *netbox.VLAN) int32 {
+		id, vlanDiags := netboxlookup.GenericLookupID(ctx, vlanRef, netboxlookup.VLANLookupConfig(client), func(v *netbox.VLAN) int32 {
 			return v.GetId()
 		})
 		diags.Append(vlanDiags...)
@@ -902,3 +1197,22 @@ func updateInterfaceTaggedVLANs(ctx context.Context, current types.Set, vlans []
 	diags.Append(setDiags...)
 	return setValue
 }
+
+// updateInterfaceIDSet maps a list of related object IDs to a set, keeping an
+// empty configured set distinct from null.
+func updateInterfaceIDSet(ctx context.Context, current types.Set, ids []int32, diags *diag.Diagnostics) types.Set {
+	if len(ids) == 0 {
+		if !current.IsNull() && !current.IsUnknown() && len(current.Elements()) == 0 {
+			return types.SetValueMust(types.Int64Type, []attr.Value{})
+		}
+		return types.SetNull(types.Int64Type)
+	}
+
+	values := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		values = append(values, int64(id))
+	}
+	setValue, setDiags := types.SetValueFrom(ctx, types.Int64Type, values)
+	diags.Append(setDiags...)
+	return setValue
+}
@@ -12,12 +12,15 @@ import (
 	lookup "github.com/bab3l/terraform-provider-netbox/internal/netboxlookup"
 	nbschema "github.com/bab3l/terraform-provider-netbox/internal/schema"
 	"github.com/bab3l/terraform-provider-netbox/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -28,6 +31,7 @@ var (
 	_ resource.ResourceWithConfigure   = &InventoryItemResource{}
 	_ resource.ResourceWithImportState = &InventoryItemResource{}
 	_ resource.ResourceWithIdentity    = &InventoryItemResource{}
+	_ resource.ResourceWithModifyPlan  = &InventoryItemResource{}
 )
 
 // NewInventoryItemResource returns a new resource implementing the inventory item resource.
@@ -42,22 +46,34 @@ type InventoryItemResource struct {
 
 // InventoryItemResourceModel describes the resource data model.
 type InventoryItemResourceModel struct {
-	ID           types.String `tfsdk:"id"`
-	Device       types.String `tfsdk:"device"`
-	Name         types.String `tfsdk:"name"`
-	Label        types.String `tfsdk:"label"`
-	Parent       types.String `tfsdk:"parent"`
-	Role         types.String `tfsdk:"role"`
-	Manufacturer types.String `tfsdk:"manufacturer"`
-	PartID       types.String `tfsdk:"part_id"`
-	Serial       types.String `tfsdk:"serial"`
-	AssetTag     types.String `tfsdk:"asset_tag"`
-	Discovered   types.Bool   `tfsdk:"discovered"`
-	Description  types.String `tfsdk:"description"`
-	Tags         types.Set    `tfsdk:"tags"`
-	CustomFields types.Set    `tfsdk:"custom_fields"`
+	ID              types.String  `tfsdk:"id"`
+	Device          types.String  `tfsdk:"device"`
+	Name            types.String  `tfsdk:"name"`
+	Label           types.String  `tfsdk:"label"`
+	Parent          types.String  `tfsdk:"parent"`
+	Role            types.String  `tfsdk:"role"`
+	Manufacturer    types.String  `tfsdk:"manufacturer"`
+	PartID          types.String  `tfsdk:"part_id"`
+	Serial          types.String  `tfsdk:"serial"`
+	AssetTag        types.String  `tfsdk:"asset_tag"`
+	Discovered      types.Bool    `tfsdk:"discovered"`
+	Description     types.String  `tfsdk:"description"`
+	Tags            types.Set     `tfsdk:"tags"`
+	CustomFields    types.Set     `tfsdk:"custom_fields"`
+	CustomFieldsMap types.Dynamic `tfsdk:"custom_fields_map"`
+	TagsAll         types.Set     `tfsdk:"tags_all"`
+	CustomFieldsAll types.Map     `tfsdk:"custom_fields_all"`
+
+	Status        types.String `tfsdk:"status"`
+	ComponentType types.String `tfsdk:"component_type"`
+	ComponentID   types.String `tfsdk:"component_id"`
+	ComponentName types.String `tfsdk:"component_name"`
+	Branch        types.String `tfsdk:"branch"`
 }
 
+// inventoryItemStatuses are the valid inventory item statuses.
+var inventoryItemStatuses = []string{"offline", "active", "planned", "staged", "failed", "decommissioning"}
+
 // Metadata returns the resource type name.
 func (r *InventoryItemResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_inventory_item"
@@ -105,6 +121,37 @@ func (r *InventoryItemResource) Schema(ctx context.Context, req resource.SchemaR
 				Optional:            true,
 				Computed:            true,
 			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "Operational status. Valid values: `offline`, `active`, `planned`, `staged`, `failed`, `decommissioning`. NetBox defaults to `active`.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(inventoryItemStatuses...),
+				},
+			},
+			"component_type": schema.StringAttribute{
+				MarkdownDescription: "Type of the device component this inventory item is installed in, e.g. `dcim.interface` for an optic in a switch port.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(lookup.ComponentObjectTypes()...),
+				},
+			},
+			"component_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the component this inventory item is installed in. Computed when `component_name` is set.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("component_type")),
+					stringvalidator.ConflictsWith(path.MatchRoot("component_name")),
+				},
+			},
+			"component_name": schema.StringAttribute{
+				MarkdownDescription: "Name of the component on `device` this inventory item is installed in, e.g. the interface name. Resolved to `component_id`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("component_type")),
+				},
+			},
 		},
 	}
 
@@ -114,6 +161,10 @@ func (r *InventoryItemResource) Schema(ctx context.Context, req resource.SchemaR
 	// Add common metadata attributes (tags, custom_fields)
 	resp.Schema.Attributes["tags"] = nbschema.TagsSlugAttribute()
 	resp.Schema.Attributes["custom_fields"] = nbschema.CustomFieldsAttribute()
+	resp.Schema.Attributes["custom_fields_map"] = nbschema.CustomFieldsMapAttribute()
+	resp.Schema.Attributes["tags_all"] = nbschema.TagsAllAttribute()
+	resp.Schema.Attributes["custom_fields_all"] = nbschema.CustomFieldsAllAttribute()
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 func (r *InventoryItemResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
@@ -135,8 +186,21 @@ func (r *InventoryItemResource) Configure(ctx context.Context, req resource.Conf
 	r.client = client
 }
 
+// ModifyPlan validates the configured custom fields against their definitions in NetBox
+// and plans tags_all and custom_fields_all.
+func (r *InventoryItemResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	utils.ValidateCustomFieldsPlan(ctx, r.client, "dcim.inventoryitem", req, resp)
+	utils.PlanEffectiveMetadata(ctx, r.client, "dcim.inventoryitem", req, resp)
+}
+
 // Create creates the resource.
 func (r *InventoryItemResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	defer journalCreate(ctx, r.client, "dcim.inventoryitem", req, resp)
+
 	var data InventoryItemResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -216,10 +280,19 @@ func (r *InventoryItemResource) Create(ctx context.Context, req resource.CreateR
 		apiReq.Discovered = nil
 	}
 
+	r.applyStatusAndComponent(ctx, &data, nil, apiReq, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Handle description, tags, and custom fields
 	utils.ApplyDescription(apiReq, data.Description)
 	utils.ApplyTagsFromSlugs(ctx, r.client, apiReq, data.Tags, &resp.Diagnostics)
 	utils.ApplyCustomFields(ctx, apiReq, data.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, apiReq, data.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, apiReq, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "dcim.inventoryitem", apiReq, &resp.Diagnostics)
+	lookup.ResolveCustomFieldReferences(ctx, r.client, apiReq, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -255,6 +328,7 @@ func (r *InventoryItemResource) Create(ctx context.Context, req resource.CreateR
 
 // Read refreshes the resource state.
 func (r *InventoryItemResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data InventoryItemResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -308,6 +382,13 @@ func (r *InventoryItemResource) Read(ctx context.Context, req resource.ReadReque
 
 // Update updates the resource.
 func (r *InventoryItemResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	ctx = withPriorTags(ctx, req.State)
+	defer journalUpdate(ctx, r.client, "dcim.inventoryitem", req, resp)
+
 	var state, data InventoryItemResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -394,6 +475,11 @@ func (r *InventoryItemResource) Update(ctx context.Context, req resource.UpdateR
 		apiReq.Discovered = nil
 	}
 
+	r.applyStatusAndComponent(ctx, &data, &state, apiReq, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Handle description, tags, and custom fields with merge-aware behavior
 	utils.ApplyDescription(apiReq, data.Description)
 
@@ -409,6 +495,10 @@ func (r *InventoryItemResource) Update(ctx context.Context, req resource.UpdateR
 
 	// Apply custom fields with merge logic (preserves unmanaged fields from state)
 	utils.ApplyCustomFieldsWithMerge(ctx, apiReq, data.CustomFields, state.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, apiReq, data.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, apiReq, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "dcim.inventoryitem", apiReq, &resp.Diagnostics)
+	lookup.ResolveCustomFieldReferences(ctx, r.client, apiReq, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -439,6 +529,10 @@ func (r *InventoryItemResource) Update(ctx context.Context, req resource.UpdateR
 // Delete deletes the resource.
 
 func (r *InventoryItemResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data InventoryItemResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -507,7 +601,9 @@ func (r *InventoryItemResource) ImportState(ctx context.Context, req resource.Im
 		if device := response.GetDevice(); device.Id != 0 {
 			data.Device = utils.UpdateReferenceAttribute(data.Device, device.GetName(), "", device.GetId())
 		}
-		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, response.HasTags(), response.GetTags(), data.Tags)
+		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, r.client, response.HasTags(), response.GetTags(), data.Tags)
+		data.TagsAll = utils.TagsAllFromAPI(ctx, response.GetTags())
+		data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, response.GetCustomFields(), &resp.Diagnostics)
 		if parsed.HasCustomFields {
 			if len(parsed.CustomFields) == 0 {
 				data.CustomFields = types.SetValueMust(utils.GetCustomFieldsAttributeType().ElemType, []attr.Value{})
@@ -529,6 +625,7 @@ func (r *InventoryItemResource) ImportState(ctx context.Context, req resource.Im
 		}
 		if parsed.HasCustomFields {
 			data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, response.GetCustomFields(), &resp.Diagnostics)
+			data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, response.GetCustomFields(), &resp.Diagnostics)
 		} else {
 			data.CustomFields = types.SetNull(utils.GetCustomFieldsAttributeType().ElemType)
 		}
@@ -659,10 +756,83 @@ func (r *InventoryItemResource) mapResponseToModel(ctx context.Context, item *ne
 
 	// Handle tags with filter-to-owned pattern
 	planTags := data.Tags
-	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, item.HasTags(), item.GetTags(), planTags)
+	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, item.HasTags(), item.GetTags(), planTags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, item.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, item.GetCustomFields(), diags)
 
 	// Handle custom fields - use filtered-to-owned for partial management
 	if item.HasCustomFields() {
 		data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, item.GetCustomFields(), diags)
+		data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, item.GetCustomFields(), diags)
+	}
+
+	// Map status (not part of the generated model)
+	data.Status = types.StringNull()
+	if status, ok := item.AdditionalProperties["status"].(map[string]interface{}); ok {
+		if value, ok := status["value"].(string); ok && value != "" {
+			data.Status = types.StringValue(value)
+		}
+	}
+
+	// Map component; the name is only tracked when configured
+	if componentType, ok := item.GetComponentTypeOk(); ok && componentType != nil && *componentType != "" {
+		data.ComponentType = types.StringValue(*componentType)
+	} else {
+		data.ComponentType = types.StringNull()
+	}
+	if componentID, ok := item.GetComponentIdOk(); ok && componentID != nil {
+		data.ComponentID = types.StringValue(fmt.Sprintf("%d", *componentID))
+	} else {
+		data.ComponentID = types.StringNull()
+	}
+	if !data.ComponentName.IsNull() {
+		data.ComponentName = types.StringNull()
+		if component, ok := item.Component.(map[string]interface{}); ok {
+			if name, ok := component["name"].(string); ok {
+				data.ComponentName = types.StringValue(name)
+			}
+		}
+	}
+}
+
+// applyStatusAndComponent sets the status and the component assignment on the
+// request, resolving component_name on the item's device. On update, state is
+// used to clear a component that was removed from the configuration.
+func (r *InventoryItemResource) applyStatusAndComponent(ctx context.Context, data, state *InventoryItemResourceModel, apiReq *netbox.InventoryItemRequest, diags *diag.Diagnostics) {
+	if apiReq.AdditionalProperties == nil {
+		apiReq.AdditionalProperties = make(map[string]interface{})
+	}
+	if utils.IsSet(data.Status) {
+		apiReq.AdditionalProperties["status"] = data.Status.ValueString()
+	}
+
+	if utils.IsSet(data.ComponentName) {
+		componentID, lookupDiags := lookup.LookupDeviceComponentID(ctx, r.client, data.ComponentType.ValueString(), data.Device.ValueString(), data.ComponentName.ValueString())
+		diags.Append(lookupDiags...)
+		if diags.HasError() {
+			return
+		}
+		data.ComponentID = types.StringValue(fmt.Sprintf("%d", componentID))
+	} else if data.ComponentID.IsUnknown() {
+		data.ComponentID = types.StringNull()
+	}
+
+	if utils.IsSet(data.ComponentType) {
+		apiReq.SetComponentType(data.ComponentType.ValueString())
+	} else if state != nil && !state.ComponentType.IsNull() {
+		apiReq.SetComponentTypeNil()
+	}
+	if utils.IsSet(data.ComponentID) {
+		componentID, err := utils.ParseID64(data.ComponentID.ValueString())
+		if err != nil {
+			diags.AddError(
+				"Invalid Component ID",
+				fmt.Sprintf("Component ID must be a number, got: %s", data.ComponentID.ValueString()),
+			)
+			return
+		}
+		apiReq.SetComponentId(componentID)
+	} else if state != nil && !state.ComponentID.IsNull() {
+		apiReq.SetComponentIdNil()
 	}
 }
@@ -9,6 +9,7 @@ import (
 	"net/http"
 
 	"github.com/bab3l/go-netbox"
+	"github.com/bab3l/terraform-provider-netbox/internal/netboxlookup"
 	nbschema "github.com/bab3l/terraform-provider-netbox/internal/schema"
 	"github.com/bab3l/terraform-provider-netbox/internal/utils"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
@@ -28,6 +29,7 @@ var (
 	_ resource.ResourceWithConfigure   = &InventoryItemRoleResource{}
 	_ resource.ResourceWithImportState = &InventoryItemRoleResource{}
 	_ resource.ResourceWithIdentity    = &InventoryItemRoleResource{}
+	_ resource.ResourceWithModifyPlan  = &InventoryItemRoleResource{}
 )
 
 // NewInventoryItemRoleResource returns a new resource implementing the inventory item role resource.
@@ -42,13 +44,17 @@ type InventoryItemRoleResource struct {
 
 // InventoryItemRoleResourceModel describes the resource data model.
 type InventoryItemRoleResourceModel struct {
-	ID           types.String `tfsdk:"id"`
-	Name         types.String `tfsdk:"name"`
-	Slug         types.String `tfsdk:"slug"`
-	Color        types.String `tfsdk:"color"`
-	Description  types.String `tfsdk:"description"`
-	Tags         types.Set    `tfsdk:"tags"`
-	CustomFields types.Set    `tfsdk:"custom_fields"`
+	ID              types.String  `tfsdk:"id"`
+	Name            types.String  `tfsdk:"name"`
+	Slug            types.String  `tfsdk:"slug"`
+	Color           types.String  `tfsdk:"color"`
+	Description     types.String  `tfsdk:"description"`
+	Tags            types.Set     `tfsdk:"tags"`
+	CustomFields    types.Set     `tfsdk:"custom_fields"`
+	CustomFieldsMap types.Dynamic `tfsdk:"custom_fields_map"`
+	TagsAll         types.Set     `tfsdk:"tags_all"`
+	CustomFieldsAll types.Map     `tfsdk:"custom_fields_all"`
+	Branch          types.String  `tfsdk:"branch"`
 }
 
 // Metadata returns the resource type name.
@@ -86,6 +92,10 @@ func (r *InventoryItemRoleResource) Schema(ctx context.Context, req resource.Sch
 	// Add tags and custom_fields
 	resp.Schema.Attributes["tags"] = nbschema.TagsSlugAttribute()
 	resp.Schema.Attributes["custom_fields"] = nbschema.CustomFieldsAttribute()
+	resp.Schema.Attributes["custom_fields_map"] = nbschema.CustomFieldsMapAttribute()
+	resp.Schema.Attributes["tags_all"] = nbschema.TagsAllAttribute()
+	resp.Schema.Attributes["custom_fields_all"] = nbschema.CustomFieldsAllAttribute()
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 func (r *InventoryItemRoleResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
@@ -107,8 +117,21 @@ func (r *InventoryItemRoleResource) Configure(ctx context.Context, req resource.
 	r.client = client
 }
 
+// ModifyPlan validates the configured custom fields against their definitions in NetBox
+// and plans tags_all and custom_fields_all.
+func (r *InventoryItemRoleResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	utils.ValidateCustomFieldsPlan(ctx, r.client, "dcim.inventoryitemrole", req, resp)
+	utils.PlanEffectiveMetadata(ctx, r.client, "dcim.inventoryitemrole", req, resp)
+}
+
 // Create creates the resource.
 func (r *InventoryItemRoleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	defer journalCreate(ctx, r.client, "dcim.inventoryitemrole", req, resp)
+
 	var data InventoryItemRoleResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -129,6 +152,10 @@ func (r *InventoryItemRoleResource) Create(ctx context.Context, req resource.Cre
 	// Handle tags and custom_fields
 	utils.ApplyTagsFromSlugs(ctx, r.client, apiReq, data.Tags, &resp.Diagnostics)
 	utils.ApplyCustomFields(ctx, apiReq, data.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, apiReq, data.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, apiReq, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "dcim.inventoryitemrole", apiReq, &resp.Diagnostics)
+	netboxlookup.ResolveCustomFieldReferences(ctx, r.client, apiReq, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -167,6 +194,7 @@ func (r *InventoryItemRoleResource) Create(ctx context.Context, req resource.Cre
 
 // Read refreshes the resource state.
 func (r *InventoryItemRoleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data InventoryItemRoleResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -230,6 +258,13 @@ func (r *InventoryItemRoleResource) Read(ctx context.Context, req resource.ReadR
 
 // Update updates the resource.
 func (r *InventoryItemRoleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	ctx = withPriorTags(ctx, req.State)
+	defer journalUpdate(ctx, r.client, "dcim.inventoryitemrole", req, resp)
+
 	// Read BOTH state and plan for merge-aware custom fields
 	var state, plan InventoryItemRoleResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -261,6 +296,10 @@ func (r *InventoryItemRoleResource) Update(ctx context.Context, req resource.Upd
 	// Handle tags and custom_fields with merge
 	utils.ApplyTagsFromSlugs(ctx, r.client, apiReq, plan.Tags, &resp.Diagnostics)
 	utils.ApplyCustomFieldsWithMerge(ctx, apiReq, plan.CustomFields, state.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, apiReq, plan.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, apiReq, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "dcim.inventoryitemrole", apiReq, &resp.Diagnostics)
+	netboxlookup.ResolveCustomFieldReferences(ctx, r.client, apiReq, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -301,6 +340,10 @@ func (r *InventoryItemRoleResource) Update(ctx context.Context, req resource.Upd
 
 // Delete deletes the resource.
 func (r *InventoryItemRoleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data InventoryItemRoleResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -362,7 +405,9 @@ func (r *InventoryItemRoleResource) ImportState(ctx context.Context, req resourc
 		}
 
 		var data InventoryItemRoleResourceModel
-		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, response.HasTags(), response.GetTags(), data.Tags)
+		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, r.client, response.HasTags(), response.GetTags(), data.Tags)
+		data.TagsAll = utils.TagsAllFromAPI(ctx, response.GetTags())
+		data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, response.GetCustomFields(), &resp.Diagnostics)
 		if parsed.HasCustomFields {
 			if len(parsed.CustomFields) == 0 {
 				data.CustomFields = types.SetValueMust(utils.GetCustomFieldsAttributeType().ElemType, []attr.Value{})
@@ -384,6 +429,7 @@ func (r *InventoryItemRoleResource) ImportState(ctx context.Context, req resourc
 		}
 		if parsed.HasCustomFields {
 			data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, response.GetCustomFields(), &resp.Diagnostics)
+			data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, response.GetCustomFields(), &resp.Diagnostics)
 		} else {
 			data.CustomFields = types.SetNull(utils.GetCustomFieldsAttributeType().ElemType)
 		}
@@ -432,8 +478,11 @@ func (r *InventoryItemRoleResource) mapResponseToModel(ctx context.Context, role
 
 	// Handle tags (filter-to-owned)
 	planTags := data.Tags
-	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, role.HasTags(), role.GetTags(), planTags)
+	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, role.HasTags(), role.GetTags(), planTags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, role.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, role.GetCustomFields(), diags)
 
 	// Handle custom fields using filter-to-owned helper
 	data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, role.GetCustomFields(), diags)
+	data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, role.GetCustomFields(), diags)
 }
@@ -12,11 +12,14 @@ import (
 	lookup "github.com/bab3l/terraform-provider-netbox/internal/netboxlookup"
 	nbschema "github.com/bab3l/terraform-provider-netbox/internal/schema"
 	"github.com/bab3l/terraform-provider-netbox/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -51,6 +54,8 @@ type InventoryItemTemplateResourceModel struct {
 	Description   types.String `tfsdk:"description"`
 	ComponentType types.String `tfsdk:"component_type"`
 	ComponentID   types.String `tfsdk:"component_id"`
+	ComponentName types.String `tfsdk:"component_name"`
+	Branch        types.String `tfsdk:"branch"`
 }
 
 // Metadata returns the resource type name.
@@ -99,18 +104,34 @@ func (r *InventoryItemTemplateResource) Schema(ctx context.Context, req resource
 				Optional:            true,
 			},
 			"component_type": schema.StringAttribute{
-				MarkdownDescription: "The type of component this inventory item represents (e.g., `dcim.interface`).",
+				MarkdownDescription: "The type of component template this inventory item template is installed in (e.g., `dcim.interfacetemplate`).",
 				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(lookup.ComponentTemplateObjectTypes()...),
+				},
 			},
 			"component_id": schema.StringAttribute{
-				MarkdownDescription: "The ID of the component this inventory item represents.",
+				MarkdownDescription: "The ID of the component template this inventory item template is installed in. Computed when `component_name` is set.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("component_type")),
+					stringvalidator.ConflictsWith(path.MatchRoot("component_name")),
+				},
+			},
+			"component_name": schema.StringAttribute{
+				MarkdownDescription: "Name of the component template on `device_type` this inventory item template is installed in. Resolved to `component_id`.",
 				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("component_type")),
+				},
 			},
 		},
 	}
 
 	// Add description attribute
 	maps.Copy(resp.Schema.Attributes, nbschema.DescriptionOnlyAttributes("inventory item template"))
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 // Configure adds the provider configured client to the resource.
@@ -131,6 +152,10 @@ func (r *InventoryItemTemplateResource) Configure(ctx context.Context, req resou
 
 // Create creates the resource.
 func (r *InventoryItemTemplateResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
 	var data InventoryItemTemplateResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -188,6 +213,11 @@ func (r *InventoryItemTemplateResource) Create(ctx context.Context, req resource
 	// Apply description
 	utils.ApplyDescription(apiReq, data.Description)
 
+	r.resolveComponentID(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	if !data.ComponentType.IsNull() && !data.ComponentType.IsUnknown() {
 		apiReq.SetComponentType(data.ComponentType.ValueString())
 	}
@@ -233,6 +263,7 @@ func (r *InventoryItemTemplateResource) Create(ctx context.Context, req resource
 
 // Read reads the resource.
 func (r *InventoryItemTemplateResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data InventoryItemTemplateResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -277,6 +308,10 @@ func (r *InventoryItemTemplateResource) Read(ctx context.Context, req resource.R
 
 // Update updates the resource.
 func (r *InventoryItemTemplateResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
 	var data InventoryItemTemplateResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -375,6 +410,10 @@ func (r *InventoryItemTemplateResource) Update(ctx context.Context, req resource
 
 	// Apply description
 	utils.ApplyDescription(apiReq, data.Description)
+	r.resolveComponentID(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	if !data.ComponentType.IsNull() && !data.ComponentType.IsUnknown() {
 		apiReq.SetComponentType(data.ComponentType.ValueString())
 	} else if data.ComponentType.IsNull() && !state.ComponentType.IsNull() {
@@ -434,6 +473,10 @@ func (r *InventoryItemTemplateResource) Update(ctx context.Context, req resource
 
 // Delete deletes the resource.
 func (r *InventoryItemTemplateResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data InventoryItemTemplateResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -554,4 +597,30 @@ func (r *InventoryItemTemplateResource) mapToState(result *netbox.InventoryItemT
 	} else {
 		data.ComponentID = types.StringNull()
 	}
+	// Map component name when configured
+	if !data.ComponentName.IsNull() {
+		data.ComponentName = types.StringNull()
+		if component, ok := result.Component.(map[string]interface{}); ok {
+			if name, ok := component["name"].(string); ok {
+				data.ComponentName = types.StringValue(name)
+			}
+		}
+	}
+}
+
+// resolveComponentID sets component_id from component_name, looking the
+// component template up on the device type.
+func (r *InventoryItemTemplateResource) resolveComponentID(ctx context.Context, data *InventoryItemTemplateResourceModel, diags *diag.Diagnostics) {
+	if !utils.IsSet(data.ComponentName) {
+		if data.ComponentID.IsUnknown() {
+			data.ComponentID = types.StringNull()
+		}
+		return
+	}
+	componentID, lookupDiags := lookup.LookupComponentTemplateID(ctx, r.client, data.ComponentType.ValueString(), data.DeviceType.ValueString(), data.ComponentName.ValueString())
+	diags.Append(lookupDiags...)
+	if diags.HasError() {
+		return
+	}
+	data.ComponentID = types.StringValue(fmt.Sprintf("%d", componentID))
 }
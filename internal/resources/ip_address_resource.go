@@ -31,6 +31,7 @@ var (
 	_ resource.ResourceWithConfigure   = &IPAddressResource{}
 	_ resource.ResourceWithImportState = &IPAddressResource{}
 	_ resource.ResourceWithIdentity    = &IPAddressResource{}
+	_ resource.ResourceWithModifyPlan  = &IPAddressResource{}
 )
 
 // NewIPAddressResource returns a new IP Address resource.
@@ -45,20 +46,24 @@ type IPAddressResource struct {
 
 // IPAddressResourceModel describes the resource data model.
 type IPAddressResourceModel struct {
-	ID                 types.String `tfsdk:"id"`
-	Address            types.String `tfsdk:"address"`
-	VRF                types.String `tfsdk:"vrf"`
-	Tenant             types.String `tfsdk:"tenant"`
-	Status             types.String `tfsdk:"status"`
-	Role               types.String `tfsdk:"role"`
-	AssignedObjectType types.String `tfsdk:"assigned_object_type"`
-	AssignedObjectID   types.Int64  `tfsdk:"assigned_object_id"`
-	NatInside          types.String `tfsdk:"nat_inside"`
-	DNSName            types.String `tfsdk:"dns_name"`
-	Description        types.String `tfsdk:"description"`
-	Comments           types.String `tfsdk:"comments"`
-	Tags               types.Set    `tfsdk:"tags"`
-	CustomFields       types.Set    `tfsdk:"custom_fields"`
+	ID                 types.String  `tfsdk:"id"`
+	Address            types.String  `tfsdk:"address"`
+	VRF                types.String  `tfsdk:"vrf"`
+	Tenant             types.String  `tfsdk:"tenant"`
+	Status             types.String  `tfsdk:"status"`
+	Role               types.String  `tfsdk:"role"`
+	AssignedObjectType types.String  `tfsdk:"assigned_object_type"`
+	AssignedObjectID   types.Int64   `tfsdk:"assigned_object_id"`
+	NatInside          types.String  `tfsdk:"nat_inside"`
+	DNSName            types.String  `tfsdk:"dns_name"`
+	Description        types.String  `tfsdk:"description"`
+	Comments           types.String  `tfsdk:"comments"`
+	Tags               types.Set     `tfsdk:"tags"`
+	CustomFields       types.Set     `tfsdk:"custom_fields"`
+	CustomFieldsMap    types.Dynamic `tfsdk:"custom_fields_map"`
+	TagsAll            types.Set     `tfsdk:"tags_all"`
+	CustomFieldsAll    types.Map     `tfsdk:"custom_fields_all"`
+	Branch             types.String  `tfsdk:"branch"`
 }
 
 // Metadata returns the resource type name.
@@ -116,6 +121,10 @@ func (r *IPAddressResource) Schema(ctx context.Context, req resource.SchemaReque
 	// Add tags and custom_fields attributes
 	resp.Schema.Attributes["tags"] = nbschema.TagsSlugAttribute()
 	resp.Schema.Attributes["custom_fields"] = nbschema.CustomFieldsAttribute()
+	resp.Schema.Attributes["custom_fields_map"] = nbschema.CustomFieldsMapAttribute()
+	resp.Schema.Attributes["tags_all"] = nbschema.TagsAllAttribute()
+	resp.Schema.Attributes["custom_fields_all"] = nbschema.CustomFieldsAllAttribute()
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 func (r *IPAddressResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
@@ -138,8 +147,21 @@ func (r *IPAddressResource) Configure(ctx context.Context, req resource.Configur
 	r.client = client
 }
 
+// ModifyPlan validates the configured custom fields against their definitions in NetBox
+// and plans tags_all and custom_fields_all.
+func (r *IPAddressResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	utils.ValidateCustomFieldsPlan(ctx, r.client, "ipam.ipaddress", req, resp)
+	utils.PlanEffectiveMetadata(ctx, r.client, "ipam.ipaddress", req, resp)
+}
+
 // Create creates the resource and sets the initial Terraform state.
 func (r *IPAddressResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	defer journalCreate(ctx, r.client, "ipam.ipaddress", req, resp)
+
 	var data IPAddressResourceModel
 
 	// Read Terraform plan data into the model
@@ -189,10 +211,13 @@ func (r *IPAddressResource) Create(ctx context.Context, req resource.CreateReque
 	}
 
 	// Apply filter-to-owned pattern for tags
-	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, ipAddress.HasTags(), ipAddress.GetTags(), planTags)
+	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, ipAddress.HasTags(), ipAddress.GetTags(), planTags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, ipAddress.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, ipAddress.GetCustomFields(), &resp.Diagnostics)
 
 	// Apply filter-to-owned pattern for custom fields
 	data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, planCustomFields, ipAddress.CustomFields, &resp.Diagnostics)
+	data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, ipAddress.CustomFields, &resp.Diagnostics)
 	utils.SetIdentityCustomFields(ctx, resp.Identity, types.StringValue(data.ID.ValueString()), data.CustomFields, &resp.Diagnostics)
 	tflog.Debug(ctx, "Created IP address", map[string]interface{}{
 		"id":      data.ID.ValueString(),
@@ -205,6 +230,7 @@ func (r *IPAddressResource) Create(ctx context.Context, req resource.CreateReque
 
 // Read refreshes the Terraform state with the latest data.
 func (r *IPAddressResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data IPAddressResourceModel
 
 	// Read Terraform prior state data into the model
@@ -254,10 +280,13 @@ func (r *IPAddressResource) Read(ctx context.Context, req resource.ReadRequest,
 	}
 
 	// Apply filter-to-owned pattern for tags
-	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, ipAddress.HasTags(), ipAddress.GetTags(), originalTags)
+	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, ipAddress.HasTags(), ipAddress.GetTags(), originalTags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, ipAddress.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, ipAddress.GetCustomFields(), &resp.Diagnostics)
 
 	// Apply filter-to-owned pattern for custom fields
 	data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, originalCustomFields, ipAddress.CustomFields, &resp.Diagnostics)
+	data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, ipAddress.CustomFields, &resp.Diagnostics)
 
 	// Preserve original custom_fields state if it was null or empty
 	// This prevents unmanaged/cleared fields from reappearing in state
@@ -277,6 +306,13 @@ func (r *IPAddressResource) Read(ctx context.Context, req resource.ReadRequest,
 
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *IPAddressResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	ctx = withPriorTags(ctx, req.State)
+	defer journalUpdate(ctx, r.client, "ipam.ipaddress", req, resp)
+
 	var state, plan IPAddressResourceModel
 
 	// Read Terraform state and plan data into the models
@@ -330,10 +366,13 @@ func (r *IPAddressResource) Update(ctx context.Context, req resource.UpdateReque
 	}
 
 	// Apply filter-to-owned pattern for tags
-	plan.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, ipAddress.HasTags(), ipAddress.GetTags(), plan.Tags)
+	plan.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, ipAddress.HasTags(), ipAddress.GetTags(), plan.Tags)
+	plan.TagsAll = utils.TagsAllFromAPI(ctx, ipAddress.GetTags())
+	plan.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, plan.CustomFieldsAll, ipAddress.GetCustomFields(), &resp.Diagnostics)
 
 	// Apply filter-to-owned pattern for custom fields
 	plan.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, plan.CustomFields, ipAddress.CustomFields, &resp.Diagnostics)
+	plan.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, plan.CustomFieldsMap, ipAddress.CustomFields, &resp.Diagnostics)
 	utils.SetIdentityCustomFields(ctx, resp.Identity, types.StringValue(plan.ID.ValueString()), plan.CustomFields, &resp.Diagnostics)
 	tflog.Debug(ctx, "Updated IP address", map[string]interface{}{
 		"id":      plan.ID.ValueString(),
@@ -346,6 +385,10 @@ func (r *IPAddressResource) Update(ctx context.Context, req resource.UpdateReque
 
 // Delete deletes the resource and removes the Terraform state on success.
 func (r *IPAddressResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data IPAddressResourceModel
 
 	// Read Terraform prior state data into the model
@@ -434,7 +477,9 @@ func (r *IPAddressResource) ImportState(ctx context.Context, req resource.Import
 			tenant := ipAddress.Tenant.Get()
 			data.Tenant = types.StringValue(fmt.Sprintf("%d", tenant.GetId()))
 		}
-		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, len(ipAddress.Tags) > 0, ipAddress.Tags, data.Tags)
+		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, r.client, len(ipAddress.Tags) > 0, ipAddress.Tags, data.Tags)
+		data.TagsAll = utils.TagsAllFromAPI(ctx, ipAddress.Tags)
+		data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, ipAddress.CustomFields, &resp.Diagnostics)
 		if parsed.HasCustomFields {
 			if len(parsed.CustomFields) == 0 {
 				data.CustomFields = types.SetValueMust(utils.GetCustomFieldsAttributeType().ElemType, []attr.Value{})
@@ -456,6 +501,7 @@ func (r *IPAddressResource) ImportState(ctx context.Context, req resource.Import
 		}
 		if parsed.HasCustomFields {
 			data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, ipAddress.CustomFields, &resp.Diagnostics)
+			data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, ipAddress.CustomFields, &resp.Diagnostics)
 		} else {
 			data.CustomFields = types.SetNull(utils.GetCustomFieldsAttributeType().ElemType)
 		}
@@ -594,8 +640,16 @@ func (r *IPAddressResource) setOptionalFields(ctx context.Context, ipRequest *ne
 	// Handle custom fields - use merge-aware helper if state exists (Update), otherwise regular (Create)
 	if state != nil {
 		utils.ApplyCustomFieldsWithMerge(ctx, ipRequest, plan.CustomFields, state.CustomFields, diags)
+		utils.ApplyCustomFieldsMap(ctx, r.client, ipRequest, plan.CustomFieldsMap, diags)
+		utils.ApplyProviderTags(ctx, r.client, ipRequest, diags)
+		utils.ApplyProviderCustomFields(ctx, r.client, "ipam.ipaddress", ipRequest, diags)
+		netboxlookup.ResolveCustomFieldReferences(ctx, r.client, ipRequest, diags)
 	} else {
 		utils.ApplyCustomFields(ctx, ipRequest, plan.CustomFields, diags)
+		utils.ApplyCustomFieldsMap(ctx, r.client, ipRequest, plan.CustomFieldsMap, diags)
+		utils.ApplyProviderTags(ctx, r.client, ipRequest, diags)
+		utils.ApplyProviderCustomFields(ctx, r.client, "ipam.ipaddress", ipRequest, diags)
+		netboxlookup.ResolveCustomFieldReferences(ctx, r.client, ipRequest, diags)
 	}
 }
 
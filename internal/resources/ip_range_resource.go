@@ -32,6 +32,7 @@ var (
 	_ resource.ResourceWithConfigure   = &IPRangeResource{}
 	_ resource.ResourceWithImportState = &IPRangeResource{}
 	_ resource.ResourceWithIdentity    = &IPRangeResource{}
+	_ resource.ResourceWithModifyPlan  = &IPRangeResource{}
 )
 
 // NewIPRangeResource returns a new IP Range resource.
@@ -46,19 +47,23 @@ type IPRangeResource struct {
 
 // IPRangeResourceModel describes the resource data model.
 type IPRangeResourceModel struct {
-	ID           types.String `tfsdk:"id"`
-	StartAddress types.String `tfsdk:"start_address"`
-	EndAddress   types.String `tfsdk:"end_address"`
-	Size         types.Int64  `tfsdk:"size"`
-	VRF          types.String `tfsdk:"vrf"`
-	Tenant       types.String `tfsdk:"tenant"`
-	Status       types.String `tfsdk:"status"`
-	Role         types.String `tfsdk:"role"`
-	Description  types.String `tfsdk:"description"`
-	Comments     types.String `tfsdk:"comments"`
-	MarkUtilized types.Bool   `tfsdk:"mark_utilized"`
-	Tags         types.Set    `tfsdk:"tags"`
-	CustomFields types.Set    `tfsdk:"custom_fields"`
+	ID              types.String  `tfsdk:"id"`
+	StartAddress    types.String  `tfsdk:"start_address"`
+	EndAddress      types.String  `tfsdk:"end_address"`
+	Size            types.Int64   `tfsdk:"size"`
+	VRF             types.String  `tfsdk:"vrf"`
+	Tenant          types.String  `tfsdk:"tenant"`
+	Status          types.String  `tfsdk:"status"`
+	Role            types.String  `tfsdk:"role"`
+	Description     types.String  `tfsdk:"description"`
+	Comments        types.String  `tfsdk:"comments"`
+	MarkUtilized    types.Bool    `tfsdk:"mark_utilized"`
+	Tags            types.Set     `tfsdk:"tags"`
+	CustomFields    types.Set     `tfsdk:"custom_fields"`
+	CustomFieldsMap types.Dynamic `tfsdk:"custom_fields_map"`
+	TagsAll         types.Set     `tfsdk:"tags_all"`
+	CustomFieldsAll types.Map     `tfsdk:"custom_fields_all"`
+	Branch          types.String  `tfsdk:"branch"`
 }
 
 // Metadata returns the resource type name.
@@ -117,6 +122,10 @@ func (r *IPRangeResource) Schema(ctx context.Context, req resource.SchemaRequest
 	// Add tags and custom_fields
 	resp.Schema.Attributes["tags"] = nbschema.TagsSlugAttribute()
 	resp.Schema.Attributes["custom_fields"] = nbschema.CustomFieldsAttribute()
+	resp.Schema.Attributes["custom_fields_map"] = nbschema.CustomFieldsMapAttribute()
+	resp.Schema.Attributes["tags_all"] = nbschema.TagsAllAttribute()
+	resp.Schema.Attributes["custom_fields_all"] = nbschema.CustomFieldsAllAttribute()
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 func (r *IPRangeResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
@@ -138,8 +147,21 @@ func (r *IPRangeResource) Configure(ctx context.Context, req resource.ConfigureR
 	r.client = client
 }
 
+// ModifyPlan validates the configured custom fields against their definitions in NetBox
+// and plans tags_all and custom_fields_all.
+func (r *IPRangeResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	utils.ValidateCustomFieldsPlan(ctx, r.client, "ipam.iprange", req, resp)
+	utils.PlanEffectiveMetadata(ctx, r.client, "ipam.iprange", req, resp)
+}
+
 // Create creates the resource and sets the initial Terraform state.
 func (r *IPRangeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	defer journalCreate(ctx, r.client, "ipam.iprange", req, resp)
+
 	var data IPRangeResourceModel
 
 	// Read Terraform plan data into the model
@@ -199,6 +221,7 @@ func (r *IPRangeResource) Create(ctx context.Context, req resource.CreateRequest
 
 // Read refreshes the Terraform state with the latest data.
 func (r *IPRangeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data IPRangeResourceModel
 
 	// Read Terraform prior state data into the model
@@ -278,6 +301,13 @@ func (r *IPRangeResource) Read(ctx context.Context, req resource.ReadRequest, re
 
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *IPRangeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	ctx = withPriorTags(ctx, req.State)
+	defer journalUpdate(ctx, r.client, "ipam.iprange", req, resp)
+
 	var data IPRangeResourceModel
 
 	// Read Terraform plan data into the model
@@ -355,6 +385,10 @@ func (r *IPRangeResource) Update(ctx context.Context, req resource.UpdateRequest
 
 // Delete deletes the resource and removes the Terraform state on success.
 func (r *IPRangeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data IPRangeResourceModel
 
 	// Read Terraform prior state data into the model
@@ -451,7 +485,9 @@ func (r *IPRangeResource) ImportState(ctx context.Context, req resource.ImportSt
 				data.Role = types.StringValue(fmt.Sprintf("%d", role.GetId()))
 			}
 		}
-		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, len(ipRange.Tags) > 0, ipRange.Tags, data.Tags)
+		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, r.client, len(ipRange.Tags) > 0, ipRange.Tags, data.Tags)
+		data.TagsAll = utils.TagsAllFromAPI(ctx, ipRange.Tags)
+		data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, ipRange.CustomFields, &resp.Diagnostics)
 		if parsed.HasCustomFields {
 			if len(parsed.CustomFields) == 0 {
 				data.CustomFields = types.SetValueMust(utils.GetCustomFieldsAttributeType().ElemType, []attr.Value{})
@@ -481,6 +517,7 @@ func (r *IPRangeResource) ImportState(ctx context.Context, req resource.ImportSt
 		}
 		if parsed.HasCustomFields {
 			data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, ipRange.CustomFields, &resp.Diagnostics)
+			data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, ipRange.CustomFields, &resp.Diagnostics)
 		} else {
 			data.CustomFields = types.SetNull(utils.GetCustomFieldsAttributeType().ElemType)
 		}
@@ -582,9 +619,17 @@ func (r *IPRangeResource) setOptionalFields(ctx context.Context, ipRangeRequest
 	if state != nil {
 		// Update: use merge-aware helper
 		utils.ApplyCustomFieldsWithMerge(ctx, ipRangeRequest, data.CustomFields, state.CustomFields, diags)
+		utils.ApplyCustomFieldsMap(ctx, r.client, ipRangeRequest, data.CustomFieldsMap, diags)
+		utils.ApplyProviderTags(ctx, r.client, ipRangeRequest, diags)
+		utils.ApplyProviderCustomFields(ctx, r.client, "ipam.iprange", ipRangeRequest, diags)
+		netboxlookup.ResolveCustomFieldReferences(ctx, r.client, ipRangeRequest, diags)
 	} else {
 		// Create: apply custom fields directly
 		utils.ApplyCustomFields(ctx, ipRangeRequest, data.CustomFields, diags)
+		utils.ApplyCustomFieldsMap(ctx, r.client, ipRangeRequest, data.CustomFieldsMap, diags)
+		utils.ApplyProviderTags(ctx, r.client, ipRangeRequest, diags)
+		utils.ApplyProviderCustomFields(ctx, r.client, "ipam.iprange", ipRangeRequest, diags)
+		netboxlookup.ResolveCustomFieldReferences(ctx, r.client, ipRangeRequest, diags)
 	}
 	if diags.HasError() {
 		return
@@ -675,11 +720,14 @@ func (r *IPRangeResource) mapIPRangeToState(ctx context.Context, ipRange *netbox
 
 	// Handle tags with filter-to-owned pattern
 	planTags := data.Tags
-	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, len(ipRange.Tags) > 0, ipRange.Tags, planTags)
+	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, len(ipRange.Tags) > 0, ipRange.Tags, planTags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, ipRange.Tags)
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, ipRange.CustomFields, diags)
 	if diags.HasError() {
 		return
 	}
 
 	// Custom Fields - filter to owned fields only
 	data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, ipRange.CustomFields, diags)
+	data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, ipRange.CustomFields, diags)
 }
@@ -9,6 +9,7 @@ import (
 	"net/http"
 
 	"github.com/bab3l/go-netbox"
+	"github.com/bab3l/terraform-provider-netbox/internal/netboxlookup"
 	nbschema "github.com/bab3l/terraform-provider-netbox/internal/schema"
 	"github.com/bab3l/terraform-provider-netbox/internal/utils"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
@@ -30,6 +31,7 @@ var (
 	_ resource.ResourceWithConfigure   = &IPSecPolicyResource{}
 	_ resource.ResourceWithImportState = &IPSecPolicyResource{}
 	_ resource.ResourceWithIdentity    = &IPSecPolicyResource{}
+	_ resource.ResourceWithModifyPlan  = &IPSecPolicyResource{}
 )
 
 // NewIPSecPolicyResource returns a new IPSecPolicy resource.
@@ -44,14 +46,18 @@ type IPSecPolicyResource struct {
 
 // IPSecPolicyResourceModel describes the resource data model.
 type IPSecPolicyResourceModel struct {
-	ID           types.String `tfsdk:"id"`
-	Name         types.String `tfsdk:"name"`
-	Description  types.String `tfsdk:"description"`
-	Proposals    types.Set    `tfsdk:"proposals"`
-	PFSGroup     types.Int64  `tfsdk:"pfs_group"`
-	Comments     types.String `tfsdk:"comments"`
-	Tags         types.Set    `tfsdk:"tags"`
-	CustomFields types.Set    `tfsdk:"custom_fields"`
+	ID              types.String  `tfsdk:"id"`
+	Name            types.String  `tfsdk:"name"`
+	Description     types.String  `tfsdk:"description"`
+	Proposals       types.Set     `tfsdk:"proposals"`
+	PFSGroup        types.Int64   `tfsdk:"pfs_group"`
+	Comments        types.String  `tfsdk:"comments"`
+	Tags            types.Set     `tfsdk:"tags"`
+	CustomFields    types.Set     `tfsdk:"custom_fields"`
+	CustomFieldsMap types.Dynamic `tfsdk:"custom_fields_map"`
+	TagsAll         types.Set     `tfsdk:"tags_all"`
+	CustomFieldsAll types.Map     `tfsdk:"custom_fields_all"`
+	Branch          types.String  `tfsdk:"branch"`
 }
 
 // Metadata returns the resource type name.
@@ -98,6 +104,10 @@ func (r *IPSecPolicyResource) Schema(ctx context.Context, req resource.SchemaReq
 	// Add tags and custom_fields
 	resp.Schema.Attributes["tags"] = nbschema.TagsSlugAttribute()
 	resp.Schema.Attributes["custom_fields"] = nbschema.CustomFieldsAttribute()
+	resp.Schema.Attributes["custom_fields_map"] = nbschema.CustomFieldsMapAttribute()
+	resp.Schema.Attributes["tags_all"] = nbschema.TagsAllAttribute()
+	resp.Schema.Attributes["custom_fields_all"] = nbschema.CustomFieldsAllAttribute()
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 func (r *IPSecPolicyResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
@@ -119,8 +129,21 @@ func (r *IPSecPolicyResource) Configure(ctx context.Context, req resource.Config
 	r.client = client
 }
 
+// ModifyPlan validates the configured custom fields against their definitions in NetBox
+// and plans tags_all and custom_fields_all.
+func (r *IPSecPolicyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	utils.ValidateCustomFieldsPlan(ctx, r.client, "vpn.ipsecpolicy", req, resp)
+	utils.PlanEffectiveMetadata(ctx, r.client, "vpn.ipsecpolicy", req, resp)
+}
+
 // Create creates the resource and sets the initial Terraform state.
 func (r *IPSecPolicyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	defer journalCreate(ctx, r.client, "vpn.ipsecpolicy", req, resp)
+
 	var data IPSecPolicyResourceModel
 
 	// Read Terraform plan data into the model
@@ -173,6 +196,7 @@ func (r *IPSecPolicyResource) Create(ctx context.Context, req resource.CreateReq
 
 // Read refreshes the Terraform state with the latest data.
 func (r *IPSecPolicyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data IPSecPolicyResourceModel
 
 	// Read Terraform prior state data into the model
@@ -248,6 +272,13 @@ func (r *IPSecPolicyResource) Read(ctx context.Context, req resource.ReadRequest
 
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *IPSecPolicyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	ctx = withPriorTags(ctx, req.State)
+	defer journalUpdate(ctx, r.client, "vpn.ipsecpolicy", req, resp)
+
 	var state, plan IPSecPolicyResourceModel
 
 	// Read both state and plan for merge-aware custom fields handling
@@ -303,6 +334,7 @@ func (r *IPSecPolicyResource) Update(ctx context.Context, req resource.UpdateReq
 
 	// Apply filter-to-owned pattern for custom fields
 	plan.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, planCustomFields, ipsec.GetCustomFields(), &resp.Diagnostics)
+	plan.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, plan.CustomFieldsMap, ipsec.GetCustomFields(), &resp.Diagnostics)
 
 	utils.SetIdentityCustomFields(ctx, resp.Identity, types.StringValue(plan.ID.ValueString()), plan.CustomFields, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
@@ -320,6 +352,10 @@ func (r *IPSecPolicyResource) Update(ctx context.Context, req resource.UpdateReq
 
 // Delete deletes the resource and removes the Terraform state on success.
 func (r *IPSecPolicyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data IPSecPolicyResourceModel
 
 	// Read Terraform prior state data into the model
@@ -390,7 +426,9 @@ func (r *IPSecPolicyResource) ImportState(ctx context.Context, req resource.Impo
 		}
 
 		var data IPSecPolicyResourceModel
-		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, ipsec.HasTags(), ipsec.GetTags(), data.Tags)
+		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, r.client, ipsec.HasTags(), ipsec.GetTags(), data.Tags)
+		data.TagsAll = utils.TagsAllFromAPI(ctx, ipsec.GetTags())
+		data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, ipsec.GetCustomFields(), &resp.Diagnostics)
 		if parsed.HasCustomFields {
 			if len(parsed.CustomFields) == 0 {
 				data.CustomFields = types.SetValueMust(utils.GetCustomFieldsAttributeType().ElemType, []attr.Value{})
@@ -412,6 +450,7 @@ func (r *IPSecPolicyResource) ImportState(ctx context.Context, req resource.Impo
 		}
 		if parsed.HasCustomFields {
 			data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, ipsec.GetCustomFields(), &resp.Diagnostics)
+			data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, ipsec.GetCustomFields(), &resp.Diagnostics)
 		} else {
 			data.CustomFields = types.SetNull(utils.GetCustomFieldsAttributeType().ElemType)
 		}
@@ -488,9 +527,17 @@ func (r *IPSecPolicyResource) setOptionalFields(ctx context.Context, ipsecReques
 	// Apply custom fields with merge logic to preserve unmanaged fields
 	if state != nil {
 		utils.ApplyCustomFieldsWithMerge(ctx, ipsecRequest, plan.CustomFields, state.CustomFields, diags)
+		utils.ApplyCustomFieldsMap(ctx, r.client, ipsecRequest, plan.CustomFieldsMap, diags)
+		utils.ApplyProviderTags(ctx, r.client, ipsecRequest, diags)
+		utils.ApplyProviderCustomFields(ctx, r.client, "vpn.ipsecpolicy", ipsecRequest, diags)
+		netboxlookup.ResolveCustomFieldReferences(ctx, r.client, ipsecRequest, diags)
 	} else {
 		// During Create, no state exists yet
 		utils.ApplyCustomFields(ctx, ipsecRequest, plan.CustomFields, diags)
+		utils.ApplyCustomFieldsMap(ctx, r.client, ipsecRequest, plan.CustomFieldsMap, diags)
+		utils.ApplyProviderTags(ctx, r.client, ipsecRequest, diags)
+		utils.ApplyProviderCustomFields(ctx, r.client, "vpn.ipsecpolicy", ipsecRequest, diags)
+		netboxlookup.ResolveCustomFieldReferences(ctx, r.client, ipsecRequest, diags)
 	}
 }
 
@@ -537,11 +584,14 @@ func (r *IPSecPolicyResource) mapIPSecPolicyToState(ctx context.Context, ipsec *
 
 	// Handle tags with filter-to-owned pattern
 	planTags := data.Tags
-	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, ipsec.HasTags(), ipsec.GetTags(), planTags)
+	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, ipsec.HasTags(), ipsec.GetTags(), planTags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, ipsec.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, ipsec.GetCustomFields(), diags)
 	if diags.HasError() {
 		return
 	}
 
 	// Handle custom fields using consolidated helper
-	data.CustomFields = utils.PopulateCustomFieldsFromAPI(ctx, ipsec.HasCustomFields(), ipsec.GetCustomFields(), data.CustomFields, diags)
+	data.CustomFields = utils.PopulateCustomFieldsFromAPI(ctx, r.client, ipsec.HasCustomFields(), ipsec.GetCustomFields(), data.CustomFields, diags)
+	data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, ipsec.GetCustomFields(), diags)
 }
@@ -10,6 +10,7 @@ import (
 	"strconv"
 
 	"github.com/bab3l/go-netbox"
+	"github.com/bab3l/terraform-provider-netbox/internal/netboxlookup"
 	nbschema "github.com/bab3l/terraform-provider-netbox/internal/schema"
 	"github.com/bab3l/terraform-provider-netbox/internal/utils"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
@@ -31,6 +32,7 @@ var (
 	_ resource.ResourceWithConfigure   = &IPSecProfileResource{}
 	_ resource.ResourceWithImportState = &IPSecProfileResource{}
 	_ resource.ResourceWithIdentity    = &IPSecProfileResource{}
+	_ resource.ResourceWithModifyPlan  = &IPSecProfileResource{}
 )
 
 // NewIPSecProfileResource returns a new IPSecProfile resource.
@@ -45,15 +47,19 @@ type IPSecProfileResource struct {
 
 // IPSecProfileResourceModel describes the resource data model.
 type IPSecProfileResourceModel struct {
-	ID           types.String `tfsdk:"id"`
-	Name         types.String `tfsdk:"name"`
-	Description  types.String `tfsdk:"description"`
-	Mode         types.String `tfsdk:"mode"`
-	IKEPolicy    types.String `tfsdk:"ike_policy"`
-	IPSecPolicy  types.String `tfsdk:"ipsec_policy"`
-	Comments     types.String `tfsdk:"comments"`
-	Tags         types.Set    `tfsdk:"tags"`
-	CustomFields types.Set    `tfsdk:"custom_fields"`
+	ID              types.String  `tfsdk:"id"`
+	Name            types.String  `tfsdk:"name"`
+	Description     types.String  `tfsdk:"description"`
+	Mode            types.String  `tfsdk:"mode"`
+	IKEPolicy       types.String  `tfsdk:"ike_policy"`
+	IPSecPolicy     types.String  `tfsdk:"ipsec_policy"`
+	Comments        types.String  `tfsdk:"comments"`
+	Tags            types.Set     `tfsdk:"tags"`
+	CustomFields    types.Set     `tfsdk:"custom_fields"`
+	CustomFieldsMap types.Dynamic `tfsdk:"custom_fields_map"`
+	TagsAll         types.Set     `tfsdk:"tags_all"`
+	CustomFieldsAll types.Map     `tfsdk:"custom_fields_all"`
+	Branch          types.String  `tfsdk:"branch"`
 }
 
 // Metadata returns the resource type name.
@@ -102,6 +108,10 @@ func (r *IPSecProfileResource) Schema(ctx context.Context, req resource.SchemaRe
 	// Add tags and custom_fields
 	resp.Schema.Attributes["tags"] = nbschema.TagsSlugAttribute()
 	resp.Schema.Attributes["custom_fields"] = nbschema.CustomFieldsAttribute()
+	resp.Schema.Attributes["custom_fields_map"] = nbschema.CustomFieldsMapAttribute()
+	resp.Schema.Attributes["tags_all"] = nbschema.TagsAllAttribute()
+	resp.Schema.Attributes["custom_fields_all"] = nbschema.CustomFieldsAllAttribute()
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 func (r *IPSecProfileResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
@@ -123,8 +133,21 @@ func (r *IPSecProfileResource) Configure(ctx context.Context, req resource.Confi
 	r.client = client
 }
 
+// ModifyPlan validates the configured custom fields against their definitions in NetBox
+// and plans tags_all and custom_fields_all.
+func (r *IPSecProfileResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	utils.ValidateCustomFieldsPlan(ctx, r.client, "vpn.ipsecprofile", req, resp)
+	utils.PlanEffectiveMetadata(ctx, r.client, "vpn.ipsecprofile", req, resp)
+}
+
 // Create creates the resource and sets the initial Terraform state.
 func (r *IPSecProfileResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	defer journalCreate(ctx, r.client, "vpn.ipsecprofile", req, resp)
+
 	var data IPSecProfileResourceModel
 
 	// Read Terraform plan data into the model
@@ -198,6 +221,7 @@ func (r *IPSecProfileResource) Create(ctx context.Context, req resource.CreateRe
 
 // Read refreshes the Terraform state with the latest data.
 func (r *IPSecProfileResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data IPSecProfileResourceModel
 
 	// Read Terraform prior state data into the model
@@ -273,6 +297,13 @@ func (r *IPSecProfileResource) Read(ctx context.Context, req resource.ReadReques
 
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *IPSecProfileResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	ctx = withPriorTags(ctx, req.State)
+	defer journalUpdate(ctx, r.client, "vpn.ipsecprofile", req, resp)
+
 	var state, plan IPSecProfileResourceModel
 
 	// Read both state and plan for merge-aware custom fields handling
@@ -349,6 +380,7 @@ func (r *IPSecProfileResource) Update(ctx context.Context, req resource.UpdateRe
 
 	// Apply filter-to-owned pattern for custom fields
 	plan.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, planCustomFields, ipsec.GetCustomFields(), &resp.Diagnostics)
+	plan.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, plan.CustomFieldsMap, ipsec.GetCustomFields(), &resp.Diagnostics)
 
 	utils.SetIdentityCustomFields(ctx, resp.Identity, types.StringValue(plan.ID.ValueString()), plan.CustomFields, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
@@ -366,6 +398,10 @@ func (r *IPSecProfileResource) Update(ctx context.Context, req resource.UpdateRe
 
 // Delete deletes the resource and removes the Terraform state on success.
 func (r *IPSecProfileResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data IPSecProfileResourceModel
 
 	// Read Terraform prior state data into the model
@@ -436,7 +472,9 @@ func (r *IPSecProfileResource) ImportState(ctx context.Context, req resource.Imp
 		}
 
 		var data IPSecProfileResourceModel
-		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, ipsec.HasTags(), ipsec.GetTags(), data.Tags)
+		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, r.client, ipsec.HasTags(), ipsec.GetTags(), data.Tags)
+		data.TagsAll = utils.TagsAllFromAPI(ctx, ipsec.GetTags())
+		data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, ipsec.GetCustomFields(), &resp.Diagnostics)
 		if parsed.HasCustomFields {
 			if len(parsed.CustomFields) == 0 {
 				data.CustomFields = types.SetValueMust(utils.GetCustomFieldsAttributeType().ElemType, []attr.Value{})
@@ -458,6 +496,7 @@ func (r *IPSecProfileResource) ImportState(ctx context.Context, req resource.Imp
 		}
 		if parsed.HasCustomFields {
 			data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, ipsec.GetCustomFields(), &resp.Diagnostics)
+			data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, ipsec.GetCustomFields(), &resp.Diagnostics)
 		} else {
 			data.CustomFields = types.SetNull(utils.GetCustomFieldsAttributeType().ElemType)
 		}
@@ -498,9 +537,17 @@ func (r *IPSecProfileResource) setOptionalFields(ctx context.Context, ipsecReque
 	// Apply custom fields with merge logic to preserve unmanaged fields
 	if state != nil {
 		utils.ApplyCustomFieldsWithMerge(ctx, ipsecRequest, plan.CustomFields, state.CustomFields, diags)
+		utils.ApplyCustomFieldsMap(ctx, r.client, ipsecRequest, plan.CustomFieldsMap, diags)
+		utils.ApplyProviderTags(ctx, r.client, ipsecRequest, diags)
+		utils.ApplyProviderCustomFields(ctx, r.client, "vpn.ipsecprofile", ipsecRequest, diags)
+		netboxlookup.ResolveCustomFieldReferences(ctx, r.client, ipsecRequest, diags)
 	} else {
 		// During Create, no state exists yet
 		utils.ApplyCustomFields(ctx, ipsecRequest, plan.CustomFields, diags)
+		utils.ApplyCustomFieldsMap(ctx, r.client, ipsecRequest, plan.CustomFieldsMap, diags)
+		utils.ApplyProviderTags(ctx, r.client, ipsecRequest, diags)
+		utils.ApplyProviderCustomFields(ctx, r.client, "vpn.ipsecprofile", ipsecRequest, diags)
+		netboxlookup.ResolveCustomFieldReferences(ctx, r.client, ipsecRequest, diags)
 	}
 }
 
@@ -539,11 +586,14 @@ func (r *IPSecProfileResource) mapIPSecProfileToState(ctx context.Context, ipsec
 
 	// Handle tags with filter-to-owned pattern
 	planTags := data.Tags
-	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, ipsec.HasTags(), ipsec.GetTags(), planTags)
+	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, ipsec.HasTags(), ipsec.GetTags(), planTags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, ipsec.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, ipsec.GetCustomFields(), diags)
 	if diags.HasError() {
 		return
 	}
 
 	// Handle custom fields using consolidated helper
-	data.CustomFields = utils.PopulateCustomFieldsFromAPI(ctx, ipsec.HasCustomFields(), ipsec.GetCustomFields(), data.CustomFields, diags)
+	data.CustomFields = utils.PopulateCustomFieldsFromAPI(ctx, r.client, ipsec.HasCustomFields(), ipsec.GetCustomFields(), data.CustomFields, diags)
+	data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, ipsec.GetCustomFields(), diags)
 }
@@ -9,6 +9,7 @@ import (
 	"net/http"
 
 	"github.com/bab3l/go-netbox"
+	"github.com/bab3l/terraform-provider-netbox/internal/netboxlookup"
 	nbschema "github.com/bab3l/terraform-provider-netbox/internal/schema"
 	"github.com/bab3l/terraform-provider-netbox/internal/utils"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
@@ -31,6 +32,7 @@ var (
 	_ resource.ResourceWithConfigure   = &IPSecProposalResource{}
 	_ resource.ResourceWithImportState = &IPSecProposalResource{}
 	_ resource.ResourceWithIdentity    = &IPSecProposalResource{}
+	_ resource.ResourceWithModifyPlan  = &IPSecProposalResource{}
 )
 
 // NewIPSecProposalResource returns a new IPSecProposal resource.
@@ -45,16 +47,20 @@ type IPSecProposalResource struct {
 
 // IPSecProposalResourceModel describes the resource data model.
 type IPSecProposalResourceModel struct {
-	ID                      types.String `tfsdk:"id"`
-	Name                    types.String `tfsdk:"name"`
-	Description             types.String `tfsdk:"description"`
-	EncryptionAlgorithm     types.String `tfsdk:"encryption_algorithm"`
-	AuthenticationAlgorithm types.String `tfsdk:"authentication_algorithm"`
-	SALifetimeSeconds       types.Int64  `tfsdk:"sa_lifetime_seconds"`
-	SALifetimeData          types.Int64  `tfsdk:"sa_lifetime_data"`
-	Comments                types.String `tfsdk:"comments"`
-	Tags                    types.Set    `tfsdk:"tags"`
-	CustomFields            types.Set    `tfsdk:"custom_fields"`
+	ID                      types.String  `tfsdk:"id"`
+	Name                    types.String  `tfsdk:"name"`
+	Description             types.String  `tfsdk:"description"`
+	EncryptionAlgorithm     types.String  `tfsdk:"encryption_algorithm"`
+	AuthenticationAlgorithm types.String  `tfsdk:"authentication_algorithm"`
+	SALifetimeSeconds       types.Int64   `tfsdk:"sa_lifetime_seconds"`
+	SALifetimeData          types.Int64   `tfsdk:"sa_lifetime_data"`
+	Comments                types.String  `tfsdk:"comments"`
+	Tags                    types.Set     `tfsdk:"tags"`
+	CustomFields            types.Set     `tfsdk:"custom_fields"`
+	CustomFieldsMap         types.Dynamic `tfsdk:"custom_fields_map"`
+	TagsAll                 types.Set     `tfsdk:"tags_all"`
+	CustomFieldsAll         types.Map     `tfsdk:"custom_fields_all"`
+	Branch                  types.String  `tfsdk:"branch"`
 }
 
 // Metadata returns the resource type name.
@@ -117,6 +123,10 @@ func (r *IPSecProposalResource) Schema(ctx context.Context, req resource.SchemaR
 	// Add tags and custom_fields
 	resp.Schema.Attributes["tags"] = nbschema.TagsSlugAttribute()
 	resp.Schema.Attributes["custom_fields"] = nbschema.CustomFieldsAttribute()
+	resp.Schema.Attributes["custom_fields_map"] = nbschema.CustomFieldsMapAttribute()
+	resp.Schema.Attributes["tags_all"] = nbschema.TagsAllAttribute()
+	resp.Schema.Attributes["custom_fields_all"] = nbschema.CustomFieldsAllAttribute()
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 func (r *IPSecProposalResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
@@ -138,8 +148,21 @@ func (r *IPSecProposalResource) Configure(ctx context.Context, req resource.Conf
 	r.client = client
 }
 
+// ModifyPlan validates the configured custom fields against their definitions in NetBox
+// and plans tags_all and custom_fields_all.
+func (r *IPSecProposalResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	utils.ValidateCustomFieldsPlan(ctx, r.client, "vpn.ipsecproposal", req, resp)
+	utils.PlanEffectiveMetadata(ctx, r.client, "vpn.ipsecproposal", req, resp)
+}
+
 // Create creates the resource and sets the initial Terraform state.
 func (r *IPSecProposalResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	defer journalCreate(ctx, r.client, "vpn.ipsecproposal", req, resp)
+
 	var data IPSecProposalResourceModel
 
 	// Read Terraform plan data into the model
@@ -192,6 +215,7 @@ func (r *IPSecProposalResource) Create(ctx context.Context, req resource.CreateR
 
 // Read refreshes the Terraform state with the latest data.
 func (r *IPSecProposalResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data IPSecProposalResourceModel
 
 	// Read Terraform prior state data into the model
@@ -267,6 +291,13 @@ func (r *IPSecProposalResource) Read(ctx context.Context, req resource.ReadReque
 
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *IPSecProposalResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	ctx = withPriorTags(ctx, req.State)
+	defer journalUpdate(ctx, r.client, "vpn.ipsecproposal", req, resp)
+
 	var state, plan IPSecProposalResourceModel
 
 	// Read both state and plan for merge-aware custom fields handling
@@ -322,6 +353,7 @@ func (r *IPSecProposalResource) Update(ctx context.Context, req resource.UpdateR
 
 	// Apply filter-to-owned pattern for custom fields
 	plan.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, planCustomFields, ipsec.GetCustomFields(), &resp.Diagnostics)
+	plan.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, plan.CustomFieldsMap, ipsec.GetCustomFields(), &resp.Diagnostics)
 
 	utils.SetIdentityCustomFields(ctx, resp.Identity, types.StringValue(plan.ID.ValueString()), plan.CustomFields, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
@@ -339,6 +371,10 @@ func (r *IPSecProposalResource) Update(ctx context.Context, req resource.UpdateR
 
 // Delete deletes the resource and removes the Terraform state on success.
 func (r *IPSecProposalResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data IPSecProposalResourceModel
 
 	// Read Terraform prior state data into the model
@@ -409,7 +445,9 @@ func (r *IPSecProposalResource) ImportState(ctx context.Context, req resource.Im
 		}
 
 		var data IPSecProposalResourceModel
-		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, ipsec.HasTags(), ipsec.GetTags(), data.Tags)
+		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, r.client, ipsec.HasTags(), ipsec.GetTags(), data.Tags)
+		data.TagsAll = utils.TagsAllFromAPI(ctx, ipsec.GetTags())
+		data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, ipsec.GetCustomFields(), &resp.Diagnostics)
 		if parsed.HasCustomFields {
 			if len(parsed.CustomFields) == 0 {
 				data.CustomFields = types.SetValueMust(utils.GetCustomFieldsAttributeType().ElemType, []attr.Value{})
@@ -431,6 +469,7 @@ func (r *IPSecProposalResource) ImportState(ctx context.Context, req resource.Im
 		}
 		if parsed.HasCustomFields {
 			data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, ipsec.GetCustomFields(), &resp.Diagnostics)
+			data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, ipsec.GetCustomFields(), &resp.Diagnostics)
 		} else {
 			data.CustomFields = types.SetNull(utils.GetCustomFieldsAttributeType().ElemType)
 		}
@@ -511,9 +550,17 @@ func (r *IPSecProposalResource) setOptionalFields(ctx context.Context, ipsecRequ
 	// Apply custom fields with merge logic to preserve unmanaged fields
 	if state != nil {
 		utils.ApplyCustomFieldsWithMerge(ctx, ipsecRequest, plan.CustomFields, state.CustomFields, diags)
+		utils.ApplyCustomFieldsMap(ctx, r.client, ipsecRequest, plan.CustomFieldsMap, diags)
+		utils.ApplyProviderTags(ctx, r.client, ipsecRequest, diags)
+		utils.ApplyProviderCustomFields(ctx, r.client, "vpn.ipsecproposal", ipsecRequest, diags)
+		netboxlookup.ResolveCustomFieldReferences(ctx, r.client, ipsecRequest, diags)
 	} else {
 		// During Create, no state exists yet
 		utils.ApplyCustomFields(ctx, ipsecRequest, plan.CustomFields, diags)
+		utils.ApplyCustomFieldsMap(ctx, r.client, ipsecRequest, plan.CustomFieldsMap, diags)
+		utils.ApplyProviderTags(ctx, r.client, ipsecRequest, diags)
+		utils.ApplyProviderCustomFields(ctx, r.client, "vpn.ipsecproposal", ipsecRequest, diags)
+		netboxlookup.ResolveCustomFieldReferences(ctx, r.client, ipsecRequest, diags)
 	}
 }
 
@@ -569,11 +616,14 @@ func (r *IPSecProposalResource) mapIPSecProposalToState(ctx context.Context, ips
 
 	// Handle tags with filter-to-owned pattern
 	planTags := data.Tags
-	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, ipsec.HasTags(), ipsec.GetTags(), planTags)
+	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, ipsec.HasTags(), ipsec.GetTags(), planTags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, ipsec.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, ipsec.GetCustomFields(), diags)
 	if diags.HasError() {
 		return
 	}
 
 	// Handle custom fields using consolidated helper
-	data.CustomFields = utils.PopulateCustomFieldsFromAPI(ctx, ipsec.HasCustomFields(), ipsec.GetCustomFields(), data.CustomFields, diags)
+	data.CustomFields = utils.PopulateCustomFieldsFromAPI(ctx, r.client, ipsec.HasCustomFields(), ipsec.GetCustomFields(), data.CustomFields, diags)
+	data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, ipsec.GetCustomFields(), diags)
 }
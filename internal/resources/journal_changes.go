@@ -0,0 +1,158 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/bab3l/go-netbox"
+	"github.com/bab3l/terraform-provider-netbox/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Journal entries are posted by deferring journalCreate or journalUpdate at
+// the start of Create and Update, so that they run once the object and its
+// state are saved, whichever way the method returns. Deleted objects are not
+// journaled, since NetBox deletes the journal of an object with it.
+
+// journalUnchangedAttributes are the attributes left out of the summary of
+// changed attributes, as they only follow other attributes.
+var journalUnchangedAttributes = map[string]bool{
+	"id":                true,
+	"tags_all":          true,
+	"custom_fields_all": true,
+}
+
+// journalCreate posts a journal entry to the object created by a resource,
+// listing the configured attributes, when the provider journals changes.
+func journalCreate(ctx context.Context, client *netbox.APIClient, objectType string, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	changes := changedAttributes(tftypes.NewValue(req.Config.Raw.Type(), nil), req.Config.Raw)
+	postJournalChange(ctx, client, "created", objectType, resp.State.Raw, changes, &resp.Diagnostics)
+}
+
+// journalUpdate posts a journal entry to the object updated by a resource,
+// listing the attributes that changed, when the provider journals changes.
+func journalUpdate(ctx context.Context, client *netbox.APIClient, objectType string, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	changes := changedAttributes(req.State.Raw, resp.State.Raw)
+	postJournalChange(ctx, client, "updated", objectType, resp.State.Raw, changes, &resp.Diagnostics)
+}
+
+// postJournalChange posts the journal entry about a change of the object in
+// state. As the change itself succeeded, failures are warnings.
+func postJournalChange(ctx context.Context, client *netbox.APIClient, action, objectType string, state tftypes.Value, changes []string, diags *diag.Diagnostics) {
+	settings := utils.ProviderSettingsFor(client).Journal
+	if settings == nil {
+		return
+	}
+	objectID, err := stateObjectID(state)
+	if err != nil {
+		diags.AddWarning("Journal entry not created", fmt.Sprintf("Could not journal the change of the %s: %s", objectType, err))
+		return
+	}
+	change := utils.NewJournalChange(action, objectType, objectID, changes)
+	comments, err := settings.Comments(change)
+	if err != nil {
+		diags.AddWarning("Journal entry not created", fmt.Sprintf("Could not render the journal template for %s %d: %s", objectType, objectID, err))
+		return
+	}
+
+	journal := &JournalEntryResource{client: client}
+	plan := JournalEntryResourceModel{
+		AssignedObjectType: types.StringValue(objectType),
+		AssignedObjectID:   types.Int64Value(objectID),
+		Kind:               types.StringValue(settings.Kind),
+		Comments:           types.StringValue(comments),
+		Tags:               types.SetNull(types.StringType),
+		CustomFields:       types.SetNull(utils.GetCustomFieldsAttributeType().ElemType),
+		CustomFieldsMap:    types.DynamicNull(),
+	}
+	var requestDiags diag.Diagnostics
+	request := journal.buildRequest(ctx, &plan, nil, &requestDiags)
+	if requestDiags.HasError() {
+		for _, d := range requestDiags.Errors() {
+			diags.AddWarning("Journal entry not created", fmt.Sprintf("%s %d: %s: %s", objectType, objectID, d.Summary(), d.Detail()))
+		}
+		return
+	}
+	_, httpResp, err := client.ExtrasAPI.ExtrasJournalEntriesCreate(ctx).WritableJournalEntryRequest(request).Execute()
+	defer utils.CloseResponseBody(httpResp)
+	if err != nil {
+		diags.AddWarning("Journal entry not created",
+			utils.FormatAPIError(fmt.Sprintf("create journal entry for %s %d", objectType, objectID), err, httpResp))
+		return
+	}
+	if httpResp.StatusCode != http.StatusCreated {
+		diags.AddWarning("Journal entry not created",
+			fmt.Sprintf("Unexpected status code %d creating journal entry for %s %d.", httpResp.StatusCode, objectType, objectID))
+	}
+}
+
+// changedAttributes returns the sorted names of the top-level attributes that
+// differ between prior and current. With a null prior, these are the
+// attributes set in current.
+func changedAttributes(prior, current tftypes.Value) []string {
+	var priorAttrs, currentAttrs map[string]tftypes.Value
+	if !prior.IsNull() && prior.As(&priorAttrs) != nil {
+		return nil
+	}
+	if current.IsNull() || current.As(&currentAttrs) != nil {
+		return nil
+	}
+	var changes []string
+	for name, value := range currentAttrs {
+		if journalUnchangedAttributes[name] {
+			continue
+		}
+		if before, ok := priorAttrs[name]; ok {
+			if value.Equal(before) {
+				continue
+			}
+		} else if value.IsNull() || !value.IsKnown() {
+			continue
+		}
+		changes = append(changes, name)
+	}
+	sort.Strings(changes)
+	return changes
+}
+
+// stateObjectID returns the id attribute of state, which resources store as a
+// string or a number.
+func stateObjectID(state tftypes.Value) (int64, error) {
+	var attrs map[string]tftypes.Value
+	if err := state.As(&attrs); err != nil {
+		return 0, err
+	}
+	id, ok := attrs["id"]
+	if !ok || id.IsNull() || !id.IsKnown() {
+		return 0, fmt.Errorf("the state has no id")
+	}
+	switch {
+	case id.Type().Is(tftypes.String):
+		var value string
+		if err := id.As(&value); err != nil {
+			return 0, err
+		}
+		return strconv.ParseInt(value, 10, 64)
+	case id.Type().Is(tftypes.Number):
+		value := new(big.Float)
+		if err := id.As(&value); err != nil {
+			return 0, err
+		}
+		n, _ := value.Int64()
+		return n, nil
+	}
+	return 0, fmt.Errorf("unexpected id type %s", id.Type())
+}
@@ -9,6 +9,7 @@ import (
 	"net/http"
 
 	"github.com/bab3l/go-netbox"
+	"github.com/bab3l/terraform-provider-netbox/internal/netboxlookup"
 	nbschema "github.com/bab3l/terraform-provider-netbox/internal/schema"
 	"github.com/bab3l/terraform-provider-netbox/internal/utils"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
@@ -30,6 +31,7 @@ var (
 	_ resource.Resource                = &JournalEntryResource{}
 	_ resource.ResourceWithImportState = &JournalEntryResource{}
 	_ resource.ResourceWithIdentity    = &JournalEntryResource{}
+	_ resource.ResourceWithModifyPlan  = &JournalEntryResource{}
 )
 
 func NewJournalEntryResource() resource.Resource {
@@ -43,13 +45,17 @@ type JournalEntryResource struct {
 
 // JournalEntryResourceModel describes the resource data model.
 type JournalEntryResourceModel struct {
-	ID                 types.Int32  `tfsdk:"id"`
-	AssignedObjectType types.String `tfsdk:"assigned_object_type"`
-	AssignedObjectID   types.Int64  `tfsdk:"assigned_object_id"`
-	Kind               types.String `tfsdk:"kind"`
-	Comments           types.String `tfsdk:"comments"`
-	Tags               types.Set    `tfsdk:"tags"`
-	CustomFields       types.Set    `tfsdk:"custom_fields"`
+	ID                 types.Int32   `tfsdk:"id"`
+	AssignedObjectType types.String  `tfsdk:"assigned_object_type"`
+	AssignedObjectID   types.Int64   `tfsdk:"assigned_object_id"`
+	Kind               types.String  `tfsdk:"kind"`
+	Comments           types.String  `tfsdk:"comments"`
+	Tags               types.Set     `tfsdk:"tags"`
+	CustomFields       types.Set     `tfsdk:"custom_fields"`
+	CustomFieldsMap    types.Dynamic `tfsdk:"custom_fields_map"`
+	TagsAll            types.Set     `tfsdk:"tags_all"`
+	CustomFieldsAll    types.Map     `tfsdk:"custom_fields_all"`
+	Branch             types.String  `tfsdk:"branch"`
 }
 
 func (r *JournalEntryResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -98,6 +104,10 @@ func (r *JournalEntryResource) Schema(ctx context.Context, req resource.SchemaRe
 	// Add metadata attributes (slug list tags, custom_fields)
 	resp.Schema.Attributes["tags"] = nbschema.TagsSlugAttribute()
 	resp.Schema.Attributes["custom_fields"] = nbschema.CustomFieldsAttribute()
+	resp.Schema.Attributes["custom_fields_map"] = nbschema.CustomFieldsMapAttribute()
+	resp.Schema.Attributes["tags_all"] = nbschema.TagsAllAttribute()
+	resp.Schema.Attributes["custom_fields_all"] = nbschema.CustomFieldsAllAttribute()
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 func (r *JournalEntryResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
@@ -119,7 +129,18 @@ func (r *JournalEntryResource) Configure(ctx context.Context, req resource.Confi
 	r.client = client
 }
 
+// ModifyPlan validates the configured custom fields against their definitions in NetBox
+// and plans tags_all and custom_fields_all.
+func (r *JournalEntryResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	utils.ValidateCustomFieldsPlan(ctx, r.client, "extras.journalentry", req, resp)
+	utils.PlanEffectiveMetadata(ctx, r.client, "extras.journalentry", req, resp)
+}
+
 func (r *JournalEntryResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
 	var data JournalEntryResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -131,14 +152,7 @@ func (r *JournalEntryResource) Create(ctx context.Context, req resource.CreateRe
 	})
 
 	// Prepare the Journal Entry request
-	journalEntryRequest := netbox.WritableJournalEntryRequest{
-		AssignedObjectType: data.AssignedObjectType.ValueString(),
-		AssignedObjectId:   data.AssignedObjectID.ValueInt64(),
-		Comments:           data.Comments.ValueString(),
-	}
-
-	// Set optional fields
-	r.setOptionalFields(ctx, &journalEntryRequest, &data, nil, &resp.Diagnostics)
+	journalEntryRequest := r.buildRequest(ctx, &data, nil, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -173,6 +187,7 @@ func (r *JournalEntryResource) Create(ctx context.Context, req resource.CreateRe
 }
 
 func (r *JournalEntryResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data JournalEntryResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -230,6 +245,11 @@ func (r *JournalEntryResource) Read(ctx context.Context, req resource.ReadReques
 }
 
 func (r *JournalEntryResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	ctx = withPriorTags(ctx, req.State)
 	// Read BOTH state and plan for merge-aware custom fields
 	var state, plan JournalEntryResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -242,15 +262,8 @@ func (r *JournalEntryResource) Update(ctx context.Context, req resource.UpdateRe
 		"id": id,
 	})
 
-	// Prepare the Journal Entry request
-	journalEntryRequest := netbox.WritableJournalEntryRequest{
-		AssignedObjectType: plan.AssignedObjectType.ValueString(),
-		AssignedObjectId:   plan.AssignedObjectID.ValueInt64(),
-		Comments:           plan.Comments.ValueString(),
-	}
-
-	// Set optional fields with state for merge
-	r.setOptionalFields(ctx, &journalEntryRequest, &plan, &state, &resp.Diagnostics)
+	// Prepare the Journal Entry request with state for merge
+	journalEntryRequest := r.buildRequest(ctx, &plan, &state, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -291,6 +304,10 @@ func (r *JournalEntryResource) Update(ctx context.Context, req resource.UpdateRe
 }
 
 func (r *JournalEntryResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data JournalEntryResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -375,6 +392,7 @@ func (r *JournalEntryResource) ImportState(ctx context.Context, req resource.Imp
 
 		if parsed.HasCustomFields {
 			data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, journalEntry.CustomFields, &resp.Diagnostics)
+			data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, journalEntry.CustomFields, &resp.Diagnostics)
 		} else {
 			data.CustomFields = types.SetNull(utils.GetCustomFieldsAttributeType().ElemType)
 		}
@@ -409,6 +427,18 @@ func (r *JournalEntryResource) ImportState(ctx context.Context, req resource.Imp
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
 }
 
+// buildRequest builds the Journal Entry request for plan. State is nil on
+// create and the prior state on update.
+func (r *JournalEntryResource) buildRequest(ctx context.Context, plan *JournalEntryResourceModel, state *JournalEntryResourceModel, diags *diag.Diagnostics) netbox.WritableJournalEntryRequest {
+	journalEntryRequest := netbox.WritableJournalEntryRequest{
+		AssignedObjectType: plan.AssignedObjectType.ValueString(),
+		AssignedObjectId:   plan.AssignedObjectID.ValueInt64(),
+		Comments:           plan.Comments.ValueString(),
+	}
+	r.setOptionalFields(ctx, &journalEntryRequest, plan, state, diags)
+	return journalEntryRequest
+}
+
 // setOptionalFields sets optional fields on the Journal Entry request.
 func (r *JournalEntryResource) setOptionalFields(ctx context.Context, journalEntryRequest *netbox.WritableJournalEntryRequest, plan *JournalEntryResourceModel, state *JournalEntryResourceModel, diags *diag.Diagnostics) {
 	// Kind
@@ -432,8 +462,16 @@ func (r *JournalEntryResource) setOptionalFields(ctx context.Context, journalEnt
 	}
 	if state != nil {
 		utils.ApplyCustomFieldsWithMerge(ctx, journalEntryRequest, plan.CustomFields, state.CustomFields, diags)
+		utils.ApplyCustomFieldsMap(ctx, r.client, journalEntryRequest, plan.CustomFieldsMap, diags)
+		utils.ApplyProviderTags(ctx, r.client, journalEntryRequest, diags)
+		utils.ApplyProviderCustomFields(ctx, r.client, "extras.journalentry", journalEntryRequest, diags)
+		netboxlookup.ResolveCustomFieldReferences(ctx, r.client, journalEntryRequest, diags)
 	} else {
 		utils.ApplyCustomFields(ctx, journalEntryRequest, plan.CustomFields, diags)
+		utils.ApplyCustomFieldsMap(ctx, r.client, journalEntryRequest, plan.CustomFieldsMap, diags)
+		utils.ApplyProviderTags(ctx, r.client, journalEntryRequest, diags)
+		utils.ApplyProviderCustomFields(ctx, r.client, "extras.journalentry", journalEntryRequest, diags)
+		netboxlookup.ResolveCustomFieldReferences(ctx, r.client, journalEntryRequest, diags)
 	}
 	if diags.HasError() {
 		return
@@ -455,11 +493,14 @@ func (r *JournalEntryResource) mapJournalEntryToState(ctx context.Context, journ
 	}
 
 	// Handle tags using consolidated helper
-	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, len(journalEntry.Tags) > 0, journalEntry.Tags, data.Tags)
+	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, len(journalEntry.Tags) > 0, journalEntry.Tags, data.Tags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, journalEntry.Tags)
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, journalEntry.CustomFields, diags)
 	if diags.HasError() {
 		return
 	}
 
 	// Handle custom fields using filter-to-owned helper
 	data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, journalEntry.CustomFields, diags)
+	data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, journalEntry.CustomFields, diags)
 }
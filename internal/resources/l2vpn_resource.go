@@ -29,6 +29,7 @@ var (
 	_ resource.Resource                = &L2VPNResource{}
 	_ resource.ResourceWithImportState = &L2VPNResource{}
 	_ resource.ResourceWithIdentity    = &L2VPNResource{}
+	_ resource.ResourceWithModifyPlan  = &L2VPNResource{}
 )
 
 func NewL2VPNResource() resource.Resource {
@@ -42,18 +43,22 @@ type L2VPNResource struct {
 
 // L2VPNResourceModel describes the resource data model.
 type L2VPNResourceModel struct {
-	ID            types.String `tfsdk:"id"`
-	Name          types.String `tfsdk:"name"`
-	Slug          types.String `tfsdk:"slug"`
-	Type          types.String `tfsdk:"type"`
-	Identifier    types.Int64  `tfsdk:"identifier"`
-	ImportTargets types.Set    `tfsdk:"import_targets"`
-	ExportTargets types.Set    `tfsdk:"export_targets"`
-	Tenant        types.String `tfsdk:"tenant"`
-	Description   types.String `tfsdk:"description"`
-	Comments      types.String `tfsdk:"comments"`
-	Tags          types.Set    `tfsdk:"tags"`
-	CustomFields  types.Set    `tfsdk:"custom_fields"`
+	ID              types.String  `tfsdk:"id"`
+	Name            types.String  `tfsdk:"name"`
+	Slug            types.String  `tfsdk:"slug"`
+	Type            types.String  `tfsdk:"type"`
+	Identifier      types.Int64   `tfsdk:"identifier"`
+	ImportTargets   types.Set     `tfsdk:"import_targets"`
+	ExportTargets   types.Set     `tfsdk:"export_targets"`
+	Tenant          types.String  `tfsdk:"tenant"`
+	Description     types.String  `tfsdk:"description"`
+	Comments        types.String  `tfsdk:"comments"`
+	Tags            types.Set     `tfsdk:"tags"`
+	CustomFields    types.Set     `tfsdk:"custom_fields"`
+	CustomFieldsMap types.Dynamic `tfsdk:"custom_fields_map"`
+	TagsAll         types.Set     `tfsdk:"tags_all"`
+	CustomFieldsAll types.Map     `tfsdk:"custom_fields_all"`
+	Branch          types.String  `tfsdk:"branch"`
 }
 
 func (r *L2VPNResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -121,6 +126,10 @@ func (r *L2VPNResource) Schema(ctx context.Context, req resource.SchemaRequest,
 	// Add common metadata attributes (tags, custom_fields)
 	resp.Schema.Attributes["tags"] = nbschema.TagsSlugAttribute()
 	resp.Schema.Attributes["custom_fields"] = nbschema.CustomFieldsAttribute()
+	resp.Schema.Attributes["custom_fields_map"] = nbschema.CustomFieldsMapAttribute()
+	resp.Schema.Attributes["tags_all"] = nbschema.TagsAllAttribute()
+	resp.Schema.Attributes["custom_fields_all"] = nbschema.CustomFieldsAllAttribute()
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 func (r *L2VPNResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
@@ -142,7 +151,20 @@ func (r *L2VPNResource) Configure(ctx context.Context, req resource.ConfigureReq
 	r.client = client
 }
 
+// ModifyPlan validates the configured custom fields against their definitions in NetBox
+// and plans tags_all and custom_fields_all.
+func (r *L2VPNResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	utils.ValidateCustomFieldsPlan(ctx, r.client, "vpn.l2vpn", req, resp)
+	utils.PlanEffectiveMetadata(ctx, r.client, "vpn.l2vpn", req, resp)
+}
+
 func (r *L2VPNResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	defer journalCreate(ctx, r.client, "vpn.l2vpn", req, resp)
+
 	var data L2VPNResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -170,6 +192,10 @@ func (r *L2VPNResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 	utils.ApplyCustomFields(ctx, l2vpnRequest, data.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, l2vpnRequest, data.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, l2vpnRequest, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "vpn.l2vpn", l2vpnRequest, &resp.Diagnostics)
+	netboxlookup.ResolveCustomFieldReferences(ctx, r.client, l2vpnRequest, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -256,6 +282,7 @@ func (r *L2VPNResource) Create(ctx context.Context, req resource.CreateRequest,
 }
 
 func (r *L2VPNResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data L2VPNResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -302,6 +329,13 @@ func (r *L2VPNResource) Read(ctx context.Context, req resource.ReadRequest, resp
 }
 
 func (r *L2VPNResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	ctx = withPriorTags(ctx, req.State)
+	defer journalUpdate(ctx, r.client, "vpn.l2vpn", req, resp)
+
 	var state, data L2VPNResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -342,6 +376,10 @@ func (r *L2VPNResource) Update(ctx context.Context, req resource.UpdateRequest,
 	l2vpnRequest.Description = utils.StringPtr(data.Description)
 	l2vpnRequest.Comments = utils.StringPtr(data.Comments)
 	utils.ApplyCustomFieldsWithMerge(ctx, l2vpnRequest, data.CustomFields, state.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, l2vpnRequest, data.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, l2vpnRequest, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "vpn.l2vpn", l2vpnRequest, &resp.Diagnostics)
+	netboxlookup.ResolveCustomFieldReferences(ctx, r.client, l2vpnRequest, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -429,6 +467,10 @@ func (r *L2VPNResource) Update(ctx context.Context, req resource.UpdateRequest,
 }
 
 func (r *L2VPNResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data L2VPNResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -522,7 +564,9 @@ func (r *L2VPNResource) ImportState(ctx context.Context, req resource.ImportStat
 			}
 			data.ExportTargets = exportSet
 		}
-		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, l2vpn.HasTags(), l2vpn.GetTags(), data.Tags)
+		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, r.client, l2vpn.HasTags(), l2vpn.GetTags(), data.Tags)
+		data.TagsAll = utils.TagsAllFromAPI(ctx, l2vpn.GetTags())
+		data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, l2vpn.GetCustomFields(), &resp.Diagnostics)
 		if parsed.HasCustomFields {
 			if len(parsed.CustomFields) == 0 {
 				data.CustomFields = types.SetValueMust(utils.GetCustomFieldsAttributeType().ElemType, []attr.Value{})
@@ -544,6 +588,7 @@ func (r *L2VPNResource) ImportState(ctx context.Context, req resource.ImportStat
 		}
 		if parsed.HasCustomFields {
 			data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, l2vpn.GetCustomFields(), &resp.Diagnostics)
+			data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, l2vpn.GetCustomFields(), &resp.Diagnostics)
 		} else {
 			data.CustomFields = types.SetNull(utils.GetCustomFieldsAttributeType().ElemType)
 		}
@@ -640,10 +685,13 @@ func (r *L2VPNResource) mapResponseToState(ctx context.Context, l2vpn *netbox.L2
 
 	// Handle tags using filter-to-owned approach
 	planTags := data.Tags
-	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, l2vpn.HasTags(), l2vpn.GetTags(), planTags)
+	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, l2vpn.HasTags(), l2vpn.GetTags(), planTags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, l2vpn.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, l2vpn.GetCustomFields(), diags)
 
 	// Handle custom fields using consolidated helper with filtered-to-owned
 	if l2vpn.HasCustomFields() {
 		data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, l2vpn.GetCustomFields(), diags)
+		data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, l2vpn.GetCustomFields(), diags)
 	}
 }
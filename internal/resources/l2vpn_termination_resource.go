@@ -8,6 +8,7 @@ import (
 	"net/http"
 
 	"github.com/bab3l/go-netbox"
+	"github.com/bab3l/terraform-provider-netbox/internal/netboxlookup"
 	nbschema "github.com/bab3l/terraform-provider-netbox/internal/schema"
 	"github.com/bab3l/terraform-provider-netbox/internal/utils"
 	"github.com/bab3l/terraform-provider-netbox/internal/validators"
@@ -27,6 +28,7 @@ var (
 	_ resource.Resource                = &L2VPNTerminationResource{}
 	_ resource.ResourceWithImportState = &L2VPNTerminationResource{}
 	_ resource.ResourceWithIdentity    = &L2VPNTerminationResource{}
+	_ resource.ResourceWithModifyPlan  = &L2VPNTerminationResource{}
 )
 
 func NewL2VPNTerminationResource() resource.Resource {
@@ -40,12 +42,16 @@ type L2VPNTerminationResource struct {
 
 // L2VPNTerminationResourceModel describes the resource data model.
 type L2VPNTerminationResourceModel struct {
-	ID                 types.String `tfsdk:"id"`
-	L2VPN              types.String `tfsdk:"l2vpn"`
-	AssignedObjectType types.String `tfsdk:"assigned_object_type"`
-	AssignedObjectID   types.Int64  `tfsdk:"assigned_object_id"`
-	Tags               types.Set    `tfsdk:"tags"`
-	CustomFields       types.Set    `tfsdk:"custom_fields"`
+	ID                 types.String  `tfsdk:"id"`
+	L2VPN              types.String  `tfsdk:"l2vpn"`
+	AssignedObjectType types.String  `tfsdk:"assigned_object_type"`
+	AssignedObjectID   types.Int64   `tfsdk:"assigned_object_id"`
+	Tags               types.Set     `tfsdk:"tags"`
+	CustomFields       types.Set     `tfsdk:"custom_fields"`
+	CustomFieldsMap    types.Dynamic `tfsdk:"custom_fields_map"`
+	TagsAll            types.Set     `tfsdk:"tags_all"`
+	CustomFieldsAll    types.Map     `tfsdk:"custom_fields_all"`
+	Branch             types.String  `tfsdk:"branch"`
 }
 
 func (r *L2VPNTerminationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -88,6 +94,10 @@ func (r *L2VPNTerminationResource) Schema(ctx context.Context, req resource.Sche
 	// Add common metadata attributes (tags, custom_fields)
 	resp.Schema.Attributes["tags"] = nbschema.TagsSlugAttribute()
 	resp.Schema.Attributes["custom_fields"] = nbschema.CustomFieldsAttribute()
+	resp.Schema.Attributes["custom_fields_map"] = nbschema.CustomFieldsMapAttribute()
+	resp.Schema.Attributes["tags_all"] = nbschema.TagsAllAttribute()
+	resp.Schema.Attributes["custom_fields_all"] = nbschema.CustomFieldsAllAttribute()
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 func (r *L2VPNTerminationResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
@@ -109,7 +119,20 @@ func (r *L2VPNTerminationResource) Configure(ctx context.Context, req resource.C
 	r.client = client
 }
 
+// ModifyPlan validates the configured custom fields against their definitions in NetBox
+// and plans tags_all and custom_fields_all.
+func (r *L2VPNTerminationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	utils.ValidateCustomFieldsPlan(ctx, r.client, "vpn.l2vpntermination", req, resp)
+	utils.PlanEffectiveMetadata(ctx, r.client, "vpn.l2vpntermination", req, resp)
+}
+
 func (r *L2VPNTerminationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	defer journalCreate(ctx, r.client, "vpn.l2vpntermination", req, resp)
+
 	var data L2VPNTerminationResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -152,6 +175,10 @@ func (r *L2VPNTerminationResource) Create(ctx context.Context, req resource.Crea
 		return
 	}
 	utils.ApplyCustomFields(ctx, terminationRequest, data.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, terminationRequest, data.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, terminationRequest, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "vpn.l2vpntermination", terminationRequest, &resp.Diagnostics)
+	netboxlookup.ResolveCustomFieldReferences(ctx, r.client, terminationRequest, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -191,6 +218,7 @@ func (r *L2VPNTerminationResource) Create(ctx context.Context, req resource.Crea
 }
 
 func (r *L2VPNTerminationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data L2VPNTerminationResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -237,6 +265,13 @@ func (r *L2VPNTerminationResource) Read(ctx context.Context, req resource.ReadRe
 }
 
 func (r *L2VPNTerminationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	ctx = withPriorTags(ctx, req.State)
+	defer journalUpdate(ctx, r.client, "vpn.l2vpntermination", req, resp)
+
 	var state, data L2VPNTerminationResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -293,6 +328,10 @@ func (r *L2VPNTerminationResource) Update(ctx context.Context, req resource.Upda
 	}
 
 	utils.ApplyCustomFieldsWithMerge(ctx, terminationRequest, data.CustomFields, state.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, terminationRequest, data.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, terminationRequest, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "vpn.l2vpntermination", terminationRequest, &resp.Diagnostics)
+	netboxlookup.ResolveCustomFieldReferences(ctx, r.client, terminationRequest, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -328,6 +367,10 @@ func (r *L2VPNTerminationResource) Update(ctx context.Context, req resource.Upda
 }
 
 func (r *L2VPNTerminationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data L2VPNTerminationResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -398,7 +441,9 @@ func (r *L2VPNTerminationResource) ImportState(ctx context.Context, req resource
 		}
 		data.AssignedObjectType = types.StringValue(termination.GetAssignedObjectType())
 		data.AssignedObjectID = types.Int64Value(termination.GetAssignedObjectId())
-		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, termination.HasTags(), termination.GetTags(), data.Tags)
+		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, r.client, termination.HasTags(), termination.GetTags(), data.Tags)
+		data.TagsAll = utils.TagsAllFromAPI(ctx, termination.GetTags())
+		data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, termination.GetCustomFields(), &resp.Diagnostics)
 		if parsed.HasCustomFields {
 			if len(parsed.CustomFields) == 0 {
 				data.CustomFields = types.SetValueMust(utils.GetCustomFieldsAttributeType().ElemType, []attr.Value{})
@@ -420,6 +465,7 @@ func (r *L2VPNTerminationResource) ImportState(ctx context.Context, req resource
 		}
 		if parsed.HasCustomFields {
 			data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, termination.GetCustomFields(), &resp.Diagnostics)
+			data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, termination.GetCustomFields(), &resp.Diagnostics)
 		} else {
 			data.CustomFields = types.SetNull(utils.GetCustomFieldsAttributeType().ElemType)
 		}
@@ -460,10 +506,13 @@ func (r *L2VPNTerminationResource) mapResponseToState(ctx context.Context, termi
 
 	// Handle tags using filter-to-owned approach
 	planTags := data.Tags
-	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, termination.HasTags(), termination.GetTags(), planTags)
+	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, termination.HasTags(), termination.GetTags(), planTags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, termination.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, termination.GetCustomFields(), diags)
 
 	// Handle custom fields using consolidated helper
 	if termination.HasCustomFields() {
 		data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, termination.GetCustomFields(), diags)
+		data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, termination.GetCustomFields(), diags)
 	}
 }
@@ -26,6 +26,7 @@ var (
 	_ resource.Resource                = &LocationResource{}
 	_ resource.ResourceWithImportState = &LocationResource{}
 	_ resource.ResourceWithIdentity    = &LocationResource{}
+	_ resource.ResourceWithModifyPlan  = &LocationResource{}
 )
 
 func NewLocationResource() resource.Resource {
@@ -39,17 +40,21 @@ type LocationResource struct {
 
 // LocationResourceModel describes the resource data model.
 type LocationResourceModel struct {
-	ID           types.String `tfsdk:"id"`
-	Name         types.String `tfsdk:"name"`
-	Slug         types.String `tfsdk:"slug"`
-	Site         types.String `tfsdk:"site"`
-	Parent       types.String `tfsdk:"parent"`
-	Status       types.String `tfsdk:"status"`
-	Tenant       types.String `tfsdk:"tenant"`
-	Facility     types.String `tfsdk:"facility"`
-	Description  types.String `tfsdk:"description"`
-	Tags         types.Set    `tfsdk:"tags"`
-	CustomFields types.Set    `tfsdk:"custom_fields"`
+	ID              types.String  `tfsdk:"id"`
+	Name            types.String  `tfsdk:"name"`
+	Slug            types.String  `tfsdk:"slug"`
+	Site            types.String  `tfsdk:"site"`
+	Parent          types.String  `tfsdk:"parent"`
+	Status          types.String  `tfsdk:"status"`
+	Tenant          types.String  `tfsdk:"tenant"`
+	Facility        types.String  `tfsdk:"facility"`
+	Description     types.String  `tfsdk:"description"`
+	Tags            types.Set     `tfsdk:"tags"`
+	CustomFields    types.Set     `tfsdk:"custom_fields"`
+	CustomFieldsMap types.Dynamic `tfsdk:"custom_fields_map"`
+	TagsAll         types.Set     `tfsdk:"tags_all"`
+	CustomFieldsAll types.Map     `tfsdk:"custom_fields_all"`
+	Branch          types.String  `tfsdk:"branch"`
 }
 
 func (r *LocationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -77,6 +82,10 @@ func (r *LocationResource) Schema(ctx context.Context, req resource.SchemaReques
 	// Add common metadata attributes (tags, custom_fields)
 	resp.Schema.Attributes["tags"] = nbschema.TagsSlugAttribute()
 	resp.Schema.Attributes["custom_fields"] = nbschema.CustomFieldsAttribute()
+	resp.Schema.Attributes["custom_fields_map"] = nbschema.CustomFieldsMapAttribute()
+	resp.Schema.Attributes["tags_all"] = nbschema.TagsAllAttribute()
+	resp.Schema.Attributes["custom_fields_all"] = nbschema.CustomFieldsAllAttribute()
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 func (r *LocationResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
@@ -98,7 +107,20 @@ func (r *LocationResource) Configure(ctx context.Context, req resource.Configure
 	r.client = client
 }
 
+// ModifyPlan validates the configured custom fields against their definitions in NetBox
+// and plans tags_all and custom_fields_all.
+func (r *LocationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	utils.ValidateCustomFieldsPlan(ctx, r.client, "dcim.location", req, resp)
+	utils.PlanEffectiveMetadata(ctx, r.client, "dcim.location", req, resp)
+}
+
 func (r *LocationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	defer journalCreate(ctx, r.client, "dcim.location", req, resp)
+
 	var data LocationResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -165,6 +187,10 @@ func (r *LocationResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 	utils.ApplyCustomFields(ctx, locationRequest, data.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, locationRequest, data.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, locationRequest, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "dcim.location", locationRequest, &resp.Diagnostics)
+	netboxlookup.ResolveCustomFieldReferences(ctx, r.client, locationRequest, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -194,6 +220,7 @@ func (r *LocationResource) Create(ctx context.Context, req resource.CreateReques
 }
 
 func (r *LocationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data LocationResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -239,6 +266,13 @@ func (r *LocationResource) Read(ctx context.Context, req resource.ReadRequest, r
 }
 
 func (r *LocationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	ctx = withPriorTags(ctx, req.State)
+	defer journalUpdate(ctx, r.client, "dcim.location", req, resp)
+
 	var state, plan LocationResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -320,6 +354,10 @@ func (r *LocationResource) Update(ctx context.Context, req resource.UpdateReques
 
 	// Handle custom fields with merge-aware logic
 	utils.ApplyCustomFieldsWithMerge(ctx, locationRequest, plan.CustomFields, state.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, locationRequest, plan.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, locationRequest, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "dcim.location", locationRequest, &resp.Diagnostics)
+	netboxlookup.ResolveCustomFieldReferences(ctx, r.client, locationRequest, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -349,6 +387,10 @@ func (r *LocationResource) Update(ctx context.Context, req resource.UpdateReques
 }
 
 func (r *LocationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data LocationResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -426,7 +468,9 @@ func (r *LocationResource) ImportState(ctx context.Context, req resource.ImportS
 			tenant := location.GetTenant()
 			data.Tenant = types.StringValue(fmt.Sprintf("%d", tenant.GetId()))
 		}
-		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, location.HasTags(), location.GetTags(), data.Tags)
+		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, r.client, location.HasTags(), location.GetTags(), data.Tags)
+		data.TagsAll = utils.TagsAllFromAPI(ctx, location.GetTags())
+		data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, location.GetCustomFields(), &resp.Diagnostics)
 		if parsed.HasCustomFields {
 			if len(parsed.CustomFields) == 0 {
 				data.CustomFields = types.SetValueMust(utils.GetCustomFieldsAttributeType().ElemType, []attr.Value{})
@@ -448,6 +492,7 @@ func (r *LocationResource) ImportState(ctx context.Context, req resource.ImportS
 		}
 		if parsed.HasCustomFields {
 			data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, location.GetCustomFields(), &resp.Diagnostics)
+			data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, location.GetCustomFields(), &resp.Diagnostics)
 		} else {
 			data.CustomFields = types.SetNull(utils.GetCustomFieldsAttributeType().ElemType)
 		}
@@ -538,10 +583,13 @@ func (r *LocationResource) mapLocationToState(ctx context.Context, location *net
 
 	// Handle tags using filter-to-owned approach
 	planTags := data.Tags
-	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, location.HasTags(), location.GetTags(), planTags)
+	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, location.HasTags(), location.GetTags(), planTags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, location.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, location.GetCustomFields(), diags)
 
 	// Handle custom fields - only populate fields that are in plan (owned by this resource)
 	if location.HasCustomFields() {
 		data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, location.GetCustomFields(), diags)
+		data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, location.GetCustomFields(), diags)
 	}
 }
@@ -47,6 +47,7 @@ type ModuleBayTemplateResourceModel struct {
 	Label       types.String `tfsdk:"label"`
 	Position    types.String `tfsdk:"position"`
 	Description types.String `tfsdk:"description"`
+	Branch      types.String `tfsdk:"branch"`
 }
 
 // Metadata returns the resource type name.
@@ -97,6 +98,7 @@ func (r *ModuleBayTemplateResource) Schema(ctx context.Context, req resource.Sch
 	maps.Copy(resp.Schema.Attributes, nbschema.DescriptionOnlyAttributes("module bay template"))
 
 	// This resource does not support tags or custom fields.
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 // Configure adds the provider configured client to the resource.
@@ -117,6 +119,10 @@ func (r *ModuleBayTemplateResource) Configure(ctx context.Context, req resource.
 
 // Create creates the resource.
 func (r *ModuleBayTemplateResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
 	var data ModuleBayTemplateResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -191,6 +197,7 @@ func (r *ModuleBayTemplateResource) Create(ctx context.Context, req resource.Cre
 
 // Read reads the resource.
 func (r *ModuleBayTemplateResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data ModuleBayTemplateResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -235,6 +242,10 @@ func (r *ModuleBayTemplateResource) Read(ctx context.Context, req resource.ReadR
 
 // Update updates the resource.
 func (r *ModuleBayTemplateResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
 	var plan ModuleBayTemplateResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -329,6 +340,10 @@ func (r *ModuleBayTemplateResource) Update(ctx context.Context, req resource.Upd
 
 // Delete deletes the resource.
 func (r *ModuleBayTemplateResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data ModuleBayTemplateResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -4,9 +4,13 @@ package resources
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"maps"
 	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
 
 	"github.com/bab3l/go-netbox"
 	lookup "github.com/bab3l/terraform-provider-netbox/internal/netboxlookup"
@@ -16,6 +20,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -28,6 +34,7 @@ var (
 	_ resource.ResourceWithConfigure   = &ModuleResource{}
 	_ resource.ResourceWithImportState = &ModuleResource{}
 	_ resource.ResourceWithIdentity    = &ModuleResource{}
+	_ resource.ResourceWithModifyPlan  = &ModuleResource{}
 )
 
 // NewModuleResource returns a new resource implementing the module resource.
@@ -42,17 +49,39 @@ type ModuleResource struct {
 
 // ModuleResourceModel describes the resource data model.
 type ModuleResourceModel struct {
-	ID           types.String `tfsdk:"id"`
-	Device       types.String `tfsdk:"device"`
-	ModuleBay    types.Int32  `tfsdk:"module_bay"`
-	ModuleType   types.String `tfsdk:"module_type"`
-	Status       types.String `tfsdk:"status"`
-	Serial       types.String `tfsdk:"serial"`
-	AssetTag     types.String `tfsdk:"asset_tag"`
-	Description  types.String `tfsdk:"description"`
-	Comments     types.String `tfsdk:"comments"`
-	Tags         types.Set    `tfsdk:"tags"`
-	CustomFields types.Set    `tfsdk:"custom_fields"`
+	ID              types.String  `tfsdk:"id"`
+	Device          types.String  `tfsdk:"device"`
+	ModuleBay       types.Int32   `tfsdk:"module_bay"`
+	ModuleType      types.String  `tfsdk:"module_type"`
+	Status          types.String  `tfsdk:"status"`
+	Serial          types.String  `tfsdk:"serial"`
+	AssetTag        types.String  `tfsdk:"asset_tag"`
+	Description     types.String  `tfsdk:"description"`
+	Comments        types.String  `tfsdk:"comments"`
+	Tags            types.Set     `tfsdk:"tags"`
+	CustomFields    types.Set     `tfsdk:"custom_fields"`
+	CustomFieldsMap types.Dynamic `tfsdk:"custom_fields_map"`
+	TagsAll         types.Set     `tfsdk:"tags_all"`
+	CustomFieldsAll types.Map     `tfsdk:"custom_fields_all"`
+
+	ReplicateComponents types.Bool   `tfsdk:"replicate_components"`
+	AdoptComponents     types.Bool   `tfsdk:"adopt_components"`
+	Components          types.List   `tfsdk:"components"`
+	Branch              types.String `tfsdk:"branch"`
+}
+
+// ModuleComponentModel describes a component instantiated by a module.
+type ModuleComponentModel struct {
+	ObjectType types.String `tfsdk:"object_type"`
+	ID         types.Int64  `tfsdk:"id"`
+	Name       types.String `tfsdk:"name"`
+}
+
+// moduleComponentAttrTypes are the attribute types of a components element.
+var moduleComponentAttrTypes = map[string]attr.Type{
+	"object_type": types.StringType,
+	"id":          types.Int64Type,
+	"name":        types.StringType,
 }
 
 // Metadata returns the resource type name.
@@ -97,6 +126,41 @@ func (r *ModuleResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				MarkdownDescription: "A unique tag used to identify this module.",
 				Optional:            true,
 			},
+			"replicate_components": schema.BoolAttribute{
+				MarkdownDescription: "Whether NetBox creates the components defined by the module type's templates when the module is installed. Defaults to `true`. Only used on create; changing it afterwards has no effect on the installed module.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"adopt_components": schema.BoolAttribute{
+				MarkdownDescription: "Whether components that already exist on the device with the names defined by the module type's templates are assigned to the module instead of failing the install. Defaults to `false`. Only used on create; changing it afterwards has no effect on the installed module.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"components": schema.ListNestedAttribute{
+				MarkdownDescription: "Cable-able components (interfaces, console, power, front and rear ports) belonging to the module, sorted by object type and name. Use them to reference the instantiated components from `netbox_cable` terminations or `netbox_ip_address` assignments.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"object_type": schema.StringAttribute{
+							MarkdownDescription: "Content type of the component, e.g. `dcim.interface`.",
+							Computed:            true,
+						},
+						"id": schema.Int64Attribute{
+							MarkdownDescription: "ID of the component.",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Name of the component.",
+							Computed:            true,
+						},
+					},
+				},
+			},
 		},
 	}
 
@@ -106,6 +170,10 @@ func (r *ModuleResource) Schema(ctx context.Context, req resource.SchemaRequest,
 	// Add tags and custom fields
 	resp.Schema.Attributes["tags"] = nbschema.TagsSlugAttribute()
 	resp.Schema.Attributes["custom_fields"] = nbschema.CustomFieldsAttribute()
+	resp.Schema.Attributes["custom_fields_map"] = nbschema.CustomFieldsMapAttribute()
+	resp.Schema.Attributes["tags_all"] = nbschema.TagsAllAttribute()
+	resp.Schema.Attributes["custom_fields_all"] = nbschema.CustomFieldsAllAttribute()
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 func (r *ModuleResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
@@ -127,8 +195,21 @@ func (r *ModuleResource) Configure(ctx context.Context, req resource.ConfigureRe
 	r.client = client
 }
 
+// ModifyPlan validates the configured custom fields against their definitions in NetBox
+// and plans tags_all and custom_fields_all.
+func (r *ModuleResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	utils.ValidateCustomFieldsPlan(ctx, r.client, "dcim.module", req, resp)
+	utils.PlanEffectiveMetadata(ctx, r.client, "dcim.module", req, resp)
+}
+
 // Create creates the resource.
 func (r *ModuleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	defer journalCreate(ctx, r.client, "dcim.module", req, resp)
+
 	var data ModuleResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -166,11 +247,20 @@ func (r *ModuleResource) Create(ctx context.Context, req resource.CreateRequest,
 		apiReq.SetAssetTag(data.AssetTag.ValueString())
 	}
 
+	// Component replication flags are write-only and only honored on create
+	apiReq.AdditionalProperties = make(map[string]interface{})
+	apiReq.AdditionalProperties["replicate_components"] = data.ReplicateComponents.ValueBool()
+	apiReq.AdditionalProperties["adopt_components"] = data.AdoptComponents.ValueBool()
+
 	// Set common fields (description, comments, tags, custom_fields)
 	utils.ApplyDescription(apiReq, data.Description)
 	utils.ApplyComments(apiReq, data.Comments)
 	utils.ApplyTagsFromSlugs(ctx, r.client, apiReq, data.Tags, &resp.Diagnostics)
 	utils.ApplyCustomFields(ctx, apiReq, data.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, apiReq, data.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, apiReq, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "dcim.module", apiReq, &resp.Diagnostics)
+	lookup.ResolveCustomFieldReferences(ctx, r.client, apiReq, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -194,6 +284,7 @@ func (r *ModuleResource) Create(ctx context.Context, req resource.CreateRequest,
 
 	// Map response to model
 	r.mapResponseToModel(ctx, response, &data, &resp.Diagnostics)
+	data.Components = r.readComponents(ctx, response.GetId(), &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -206,6 +297,7 @@ func (r *ModuleResource) Create(ctx context.Context, req resource.CreateRequest,
 
 // Read refreshes the resource state.
 func (r *ModuleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data ModuleResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -240,6 +332,7 @@ func (r *ModuleResource) Read(ctx context.Context, req resource.ReadRequest, res
 
 	// Map response to model
 	r.mapResponseToModel(ctx, response, &data, &resp.Diagnostics)
+	data.Components = r.readComponents(ctx, moduleID, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -249,6 +342,13 @@ func (r *ModuleResource) Read(ctx context.Context, req resource.ReadRequest, res
 
 // Update updates the resource.
 func (r *ModuleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	ctx = withPriorTags(ctx, req.State)
+	defer journalUpdate(ctx, r.client, "dcim.module", req, resp)
+
 	var state, plan ModuleResourceModel
 
 	// Read both state and plan for merge-aware custom fields handling
@@ -313,6 +413,10 @@ func (r *ModuleResource) Update(ctx context.Context, req resource.UpdateRequest,
 	utils.ApplyTagsFromSlugs(ctx, r.client, apiReq, plan.Tags, &resp.Diagnostics)
 	// Apply custom fields with merge logic to preserve unmanaged fields
 	utils.ApplyCustomFieldsWithMerge(ctx, apiReq, plan.CustomFields, state.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, apiReq, plan.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, apiReq, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "dcim.module", apiReq, &resp.Diagnostics)
+	lookup.ResolveCustomFieldReferences(ctx, r.client, apiReq, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -337,12 +441,14 @@ func (r *ModuleResource) Update(ctx context.Context, req resource.UpdateRequest,
 
 	// Map response to model
 	r.mapResponseToModel(ctx, response, &plan, &resp.Diagnostics)
+	plan.Components = r.readComponents(ctx, moduleID, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	// Apply filter-to-owned pattern for custom fields
 	plan.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, planCustomFields, response.GetCustomFields(), &resp.Diagnostics)
+	plan.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, plan.CustomFieldsMap, response.GetCustomFields(), &resp.Diagnostics)
 	utils.SetIdentityCustomFields(ctx, resp.Identity, types.StringValue(plan.ID.ValueString()), plan.CustomFields, &resp.Diagnostics)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
@@ -350,6 +456,10 @@ func (r *ModuleResource) Update(ctx context.Context, req resource.UpdateRequest,
 
 // Delete deletes the resource.
 func (r *ModuleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data ModuleResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -424,7 +534,9 @@ func (r *ModuleResource) ImportState(ctx context.Context, req resource.ImportSta
 		if moduleType := response.GetModuleType(); moduleType.Id != 0 {
 			data.ModuleType = types.StringValue(fmt.Sprintf("%d", moduleType.GetId()))
 		}
-		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, response.HasTags(), response.GetTags(), data.Tags)
+		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, r.client, response.HasTags(), response.GetTags(), data.Tags)
+		data.TagsAll = utils.TagsAllFromAPI(ctx, response.GetTags())
+		data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, response.GetCustomFields(), &resp.Diagnostics)
 		if parsed.HasCustomFields {
 			if len(parsed.CustomFields) == 0 {
 				data.CustomFields = types.SetValueMust(utils.GetCustomFieldsAttributeType().ElemType, []attr.Value{})
@@ -441,11 +553,13 @@ func (r *ModuleResource) ImportState(ctx context.Context, req resource.ImportSta
 		}
 
 		r.mapResponseToModel(ctx, response, &data, &resp.Diagnostics)
+		data.Components = r.readComponents(ctx, moduleID, &resp.Diagnostics)
 		if resp.Diagnostics.HasError() {
 			return
 		}
 		if parsed.HasCustomFields {
 			data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, response.GetCustomFields(), &resp.Diagnostics)
+			data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, response.GetCustomFields(), &resp.Diagnostics)
 		} else {
 			data.CustomFields = types.SetNull(utils.GetCustomFieldsAttributeType().ElemType)
 		}
@@ -491,6 +605,7 @@ func (r *ModuleResource) ImportState(ctx context.Context, req resource.ImportSta
 	}
 	var data ModuleResourceModel
 	r.mapResponseToModel(ctx, response, &data, &resp.Diagnostics)
+	data.Components = r.readComponents(ctx, moduleID, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -551,8 +666,62 @@ func (r *ModuleResource) mapResponseToModel(ctx context.Context, module *netbox.
 	}
 
 	// Populate tags - filter to only those managed in config
-	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, module.HasTags(), module.GetTags(), data.Tags)
+	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, module.HasTags(), module.GetTags(), data.Tags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, module.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, module.GetCustomFields(), diags)
 
 	// Filter custom fields to only those managed in config
 	data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, module.GetCustomFields(), diags)
+	data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, module.GetCustomFields(), diags)
+
+	// NetBox does not return the install-time component flags; imported
+	// modules get the defaults so that the configuration does not show a diff.
+	if data.ReplicateComponents.IsNull() {
+		data.ReplicateComponents = types.BoolValue(true)
+	}
+	if data.AdoptComponents.IsNull() {
+		data.AdoptComponents = types.BoolValue(false)
+	}
+}
+
+// readComponents lists the cable-able components assigned to a module.
+func (r *ModuleResource) readComponents(ctx context.Context, moduleID int32, diags *diag.Diagnostics) types.List {
+	elemType := types.ObjectType{AttrTypes: moduleComponentAttrTypes}
+	components := []ModuleComponentModel{}
+	query := url.Values{"module_id": {strconv.Itoa(int(moduleID))}}
+	for _, objectType := range utils.CableTerminationObjectTypes(true) {
+		endpoint, _ := utils.LookupCableTerminationEndpoint(objectType)
+		results, httpResp, err := utils.ListRawAPIObjects(ctx, r.client, endpoint.APIPath, query)
+		if err != nil {
+			diags.AddError(
+				"Error reading module components",
+				utils.FormatAPIError(fmt.Sprintf("list %s objects of module ID %d", objectType, moduleID), err, httpResp),
+			)
+			return types.ListNull(elemType)
+		}
+		found := make([]ModuleComponentModel, 0, len(results))
+		for _, result := range results {
+			var component struct {
+				ID   int64  `json:"id"`
+				Name string `json:"name"`
+			}
+			if err := json.Unmarshal(result, &component); err != nil {
+				diags.AddError("Error reading module components", fmt.Sprintf("Could not decode %s: %s", objectType, err))
+				return types.ListNull(elemType)
+			}
+			found = append(found, ModuleComponentModel{
+				ObjectType: types.StringValue(objectType),
+				ID:         types.Int64Value(component.ID),
+				Name:       types.StringValue(component.Name),
+			})
+		}
+		sort.SliceStable(found, func(i, j int) bool {
+			return found[i].Name.ValueString() < found[j].Name.ValueString()
+		})
+		components = append(components, found...)
+	}
+
+	list, listDiags := types.ListValueFrom(ctx, elemType, components)
+	diags.Append(listDiags...)
+	return list
 }
@@ -0,0 +1,18 @@
+package resources
+
+import (
+	"github.com/bab3l/terraform-provider-netbox/internal/devicelibrary"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+// NewModuleTypeFromLibraryResource returns the resource managing a module type
+// and its component templates from a devicetype-library definition. It shares
+// its implementation with netbox_device_type_from_library.
+func NewModuleTypeFromLibraryResource() resource.Resource {
+	return &LibraryTypeResource{config: libraryTypeConfig{
+		kind:        devicelibrary.ModuleType,
+		typeName:    "_module_type_from_library",
+		endpoint:    "dcim/module-types",
+		parentField: "module_type",
+	}}
+}
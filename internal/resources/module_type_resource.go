@@ -29,6 +29,7 @@ var (
 	_ resource.ResourceWithConfigure   = &ModuleTypeResource{}
 	_ resource.ResourceWithImportState = &ModuleTypeResource{}
 	_ resource.ResourceWithIdentity    = &ModuleTypeResource{}
+	_ resource.ResourceWithModifyPlan  = &ModuleTypeResource{}
 )
 
 // NewModuleTypeResource returns a new resource implementing the module type resource.
@@ -43,17 +44,21 @@ type ModuleTypeResource struct {
 
 // ModuleTypeResourceModel describes the resource data model.
 type ModuleTypeResourceModel struct {
-	ID           types.String  `tfsdk:"id"`
-	Manufacturer types.String  `tfsdk:"manufacturer"`
-	Model        types.String  `tfsdk:"model"`
-	PartNumber   types.String  `tfsdk:"part_number"`
-	Airflow      types.String  `tfsdk:"airflow"`
-	Weight       types.Float64 `tfsdk:"weight"`
-	WeightUnit   types.String  `tfsdk:"weight_unit"`
-	Description  types.String  `tfsdk:"description"`
-	Comments     types.String  `tfsdk:"comments"`
-	Tags         types.Set     `tfsdk:"tags"`
-	CustomFields types.Set     `tfsdk:"custom_fields"`
+	ID              types.String  `tfsdk:"id"`
+	Manufacturer    types.String  `tfsdk:"manufacturer"`
+	Model           types.String  `tfsdk:"model"`
+	PartNumber      types.String  `tfsdk:"part_number"`
+	Airflow         types.String  `tfsdk:"airflow"`
+	Weight          types.Float64 `tfsdk:"weight"`
+	WeightUnit      types.String  `tfsdk:"weight_unit"`
+	Description     types.String  `tfsdk:"description"`
+	Comments        types.String  `tfsdk:"comments"`
+	Tags            types.Set     `tfsdk:"tags"`
+	CustomFields    types.Set     `tfsdk:"custom_fields"`
+	CustomFieldsMap types.Dynamic `tfsdk:"custom_fields_map"`
+	TagsAll         types.Set     `tfsdk:"tags_all"`
+	CustomFieldsAll types.Map     `tfsdk:"custom_fields_all"`
+	Branch          types.String  `tfsdk:"branch"`
 }
 
 // Metadata returns the resource type name.
@@ -107,6 +112,10 @@ func (r *ModuleTypeResource) Schema(ctx context.Context, req resource.SchemaRequ
 	// Add tags and custom fields
 	resp.Schema.Attributes["tags"] = nbschema.TagsSlugAttribute()
 	resp.Schema.Attributes["custom_fields"] = nbschema.CustomFieldsAttribute()
+	resp.Schema.Attributes["custom_fields_map"] = nbschema.CustomFieldsMapAttribute()
+	resp.Schema.Attributes["tags_all"] = nbschema.TagsAllAttribute()
+	resp.Schema.Attributes["custom_fields_all"] = nbschema.CustomFieldsAllAttribute()
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 func (r *ModuleTypeResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
@@ -128,8 +137,21 @@ func (r *ModuleTypeResource) Configure(ctx context.Context, req resource.Configu
 	r.client = client
 }
 
+// ModifyPlan validates the configured custom fields against their definitions in NetBox
+// and plans tags_all and custom_fields_all.
+func (r *ModuleTypeResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	utils.ValidateCustomFieldsPlan(ctx, r.client, "dcim.moduletype", req, resp)
+	utils.PlanEffectiveMetadata(ctx, r.client, "dcim.moduletype", req, resp)
+}
+
 // Create creates the resource.
 func (r *ModuleTypeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	defer journalCreate(ctx, r.client, "dcim.moduletype", req, resp)
+
 	var data ModuleTypeResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -170,6 +192,10 @@ func (r *ModuleTypeResource) Create(ctx context.Context, req resource.CreateRequ
 	utils.ApplyComments(apiReq, data.Comments)
 	utils.ApplyTagsFromSlugs(ctx, r.client, apiReq, data.Tags, &resp.Diagnostics)
 	utils.ApplyCustomFields(ctx, apiReq, data.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, apiReq, data.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, apiReq, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "dcim.moduletype", apiReq, &resp.Diagnostics)
+	lookup.ResolveCustomFieldReferences(ctx, r.client, apiReq, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -210,6 +236,7 @@ func (r *ModuleTypeResource) Create(ctx context.Context, req resource.CreateRequ
 
 // Read refreshes the resource state.
 func (r *ModuleTypeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data ModuleTypeResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -256,6 +283,13 @@ func (r *ModuleTypeResource) Read(ctx context.Context, req resource.ReadRequest,
 
 // Update updates the resource.
 func (r *ModuleTypeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	ctx = withPriorTags(ctx, req.State)
+	defer journalUpdate(ctx, r.client, "dcim.moduletype", req, resp)
+
 	var state, data ModuleTypeResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -317,6 +351,10 @@ func (r *ModuleTypeResource) Update(ctx context.Context, req resource.UpdateRequ
 	utils.ApplyTagsFromSlugs(ctx, r.client, apiReq, data.Tags, &resp.Diagnostics)
 	// Apply custom fields with merge logic to preserve unmanaged fields
 	utils.ApplyCustomFieldsWithMerge(ctx, apiReq, data.CustomFields, state.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, apiReq, data.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, apiReq, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "dcim.moduletype", apiReq, &resp.Diagnostics)
+	lookup.ResolveCustomFieldReferences(ctx, r.client, apiReq, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -350,6 +388,10 @@ func (r *ModuleTypeResource) Update(ctx context.Context, req resource.UpdateRequ
 
 // Delete deletes the resource.
 func (r *ModuleTypeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data ModuleTypeResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -410,7 +452,9 @@ func (r *ModuleTypeResource) ImportState(ctx context.Context, req resource.Impor
 		}
 
 		var data ModuleTypeResourceModel
-		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, response.HasTags(), response.GetTags(), data.Tags)
+		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, r.client, response.HasTags(), response.GetTags(), data.Tags)
+		data.TagsAll = utils.TagsAllFromAPI(ctx, response.GetTags())
+		data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, response.GetCustomFields(), &resp.Diagnostics)
 		if parsed.HasCustomFields {
 			if len(parsed.CustomFields) == 0 {
 				data.CustomFields = types.SetValueMust(utils.GetCustomFieldsAttributeType().ElemType, []attr.Value{})
@@ -429,6 +473,7 @@ func (r *ModuleTypeResource) ImportState(ctx context.Context, req resource.Impor
 		r.mapResponseToModel(ctx, response, &data, &resp.Diagnostics)
 		if parsed.HasCustomFields {
 			data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, response.GetCustomFields(), &resp.Diagnostics)
+			data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, response.GetCustomFields(), &resp.Diagnostics)
 		} else {
 			data.CustomFields = types.SetNull(utils.GetCustomFieldsAttributeType().ElemType)
 		}
@@ -508,10 +553,13 @@ func (r *ModuleTypeResource) mapResponseToModel(ctx context.Context, moduleType
 	}
 
 	// Populate tags - filter to only those managed in config
-	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, moduleType.HasTags(), moduleType.GetTags(), data.Tags)
+	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, moduleType.HasTags(), moduleType.GetTags(), data.Tags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, moduleType.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, moduleType.GetCustomFields(), diags)
 
 	// Handle custom fields - use filtered-to-owned for partial management
 	if moduleType.HasCustomFields() {
 		data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, moduleType.GetCustomFields(), diags)
+		data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, moduleType.GetCustomFields(), diags)
 	}
 }
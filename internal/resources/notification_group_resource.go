@@ -43,6 +43,7 @@ type NotificationGroupResourceModel struct {
 	Description types.String `tfsdk:"description"`
 	GroupIDs    types.Set    `tfsdk:"group_ids"`
 	UserIDs     types.Set    `tfsdk:"user_ids"`
+	Branch      types.String `tfsdk:"branch"`
 }
 
 // Metadata returns the resource type name.
@@ -77,6 +78,7 @@ func (r *NotificationGroupResource) Schema(ctx context.Context, req resource.Sch
 			},
 		},
 	}
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 // Configure adds the provider configured client to the resource.
@@ -97,6 +99,10 @@ func (r *NotificationGroupResource) Configure(ctx context.Context, req resource.
 
 // Create creates the resource.
 func (r *NotificationGroupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
 	var data NotificationGroupResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -159,6 +165,7 @@ func (r *NotificationGroupResource) Create(ctx context.Context, req resource.Cre
 
 // Read refreshes the Terraform state with the latest data.
 func (r *NotificationGroupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data NotificationGroupResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -199,6 +206,10 @@ func (r *NotificationGroupResource) Read(ctx context.Context, req resource.ReadR
 
 // Update updates the resource.
 func (r *NotificationGroupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
 	var data NotificationGroupResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -276,6 +287,10 @@ func (r *NotificationGroupResource) Update(ctx context.Context, req resource.Upd
 
 // Delete deletes the resource.
 func (r *NotificationGroupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data NotificationGroupResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -0,0 +1,363 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/bab3l/go-netbox"
+	nbschema "github.com/bab3l/terraform-provider-netbox/internal/schema"
+	"github.com/bab3l/terraform-provider-netbox/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource                   = &ObjectResource{}
+	_ resource.ResourceWithImportState    = &ObjectResource{}
+	_ resource.ResourceWithValidateConfig = &ObjectResource{}
+)
+
+func NewObjectResource() resource.Resource {
+	return &ObjectResource{}
+}
+
+// ObjectResource manages an arbitrary NetBox object through the REST API. It is
+// an escape hatch for plugin models and core models without a typed resource.
+type ObjectResource struct {
+	client *netbox.APIClient
+}
+
+// ObjectResourceModel describes the resource data model.
+type ObjectResourceModel struct {
+	ID         types.String  `tfsdk:"id"`
+	Path       types.String  `tfsdk:"path"`
+	Body       types.Dynamic `tfsdk:"body"`
+	ObjectJSON types.String  `tfsdk:"object_json"`
+	Branch     types.String  `tfsdk:"branch"`
+}
+
+func (r *ObjectResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_object"
+}
+
+func (r *ObjectResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages an arbitrary NetBox object through the REST API. Use this for plugin models (e.g. netbox-dns zones, netbox-bgp sessions) or core models without a typed resource. Only the keys set in `body` are managed: drift is detected on those keys alone, and other server-side fields are ignored. Import using `<path>/<id>`, for example `plugins/netbox-dns/zones/12`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the object.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"path": schema.StringAttribute{
+				MarkdownDescription: "API path of the list endpoint relative to `/api/`, for example `plugins/netbox-dns/zones`. Objects are created with `POST <path>/` and managed at `<path>/<id>/`. Changing this forces a new object.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"body": schema.DynamicAttribute{
+				MarkdownDescription: "Object fields to send to NetBox, either as an object or as a JSON string (e.g. from `jsonencode()`). Nested NetBox objects may be referenced by ID and choice fields by value; both compare equal to the expanded API representation. Removing a key stops managing it but does not clear it in NetBox.",
+				Required:            true,
+			},
+			"object_json": schema.StringAttribute{
+				MarkdownDescription: "The full object as last returned by the API, as JSON.",
+				Computed:            true,
+			},
+		},
+	}
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
+}
+
+func (r *ObjectResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*netbox.APIClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *netbox.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.client = client
+}
+
+func (r *ObjectResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data ObjectResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.Path.IsNull() && !data.Path.IsUnknown() && utils.NormalizeAPIPath(data.Path.ValueString()) == "" {
+		resp.Diagnostics.AddAttributeError(path.Root("path"), "Invalid path", "`path` must reference a NetBox API list endpoint, for example `plugins/netbox-dns/zones`.")
+	}
+	if data.Body.IsUnknown() || data.Body.IsUnderlyingValueUnknown() {
+		return
+	}
+	if _, _, err := objectBody(ctx, data.Body); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("body"), "Invalid body", err.Error())
+	}
+}
+
+func (r *ObjectResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	var data ObjectResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	desired, raw, err := objectBody(ctx, data.Body)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("body"), "Invalid body", err.Error())
+		return
+	}
+	apiPath := utils.NormalizeAPIPath(data.Path.ValueString())
+	tflog.Debug(ctx, "Creating object", map[string]interface{}{
+		"path": apiPath,
+	})
+
+	body, httpResp, err := utils.DoRawAPIRequest(ctx, r.client, http.MethodPost, apiPath, nil, json.RawMessage(raw))
+	defer utils.CloseResponseBody(httpResp)
+	if err != nil {
+		// Use enhanced error handler that detects duplicates and provides import hints
+		slug, _ := desired["slug"].(string)
+		handler := utils.CreateErrorHandler{
+			ResourceType: "netbox_object",
+			ResourceName: "this",
+			SlugValue:    slug,
+			LookupFunc: func(lookupCtx context.Context, slug string) (string, error) {
+				results, _, lookupErr := utils.ListRawAPIObjects(lookupCtx, r.client, apiPath, url.Values{"slug": {slug}})
+				if lookupErr != nil {
+					return "", lookupErr
+				}
+				if len(results) == 0 {
+					return "", nil
+				}
+				id, idErr := rawObjectID(results[0])
+				if idErr != nil {
+					return "", idErr
+				}
+				return apiPath + "/" + id, nil
+			},
+		}
+		handler.HandleCreateError(ctx, err, httpResp, &resp.Diagnostics)
+		return
+	}
+	if !utils.ValidateStatusCode(&resp.Diagnostics, "create object", httpResp, http.StatusCreated) {
+		return
+	}
+
+	id, err := rawObjectID(body)
+	if err != nil {
+		resp.Diagnostics.AddError("Unexpected API response", fmt.Sprintf("Could not read the ID of the object created at %q: %s", apiPath, err))
+		return
+	}
+	data.ID = types.StringValue(id)
+	data.ObjectJSON = types.StringValue(string(body))
+
+	tflog.Debug(ctx, "Created object", map[string]interface{}{
+		"path": apiPath,
+		"id":   id,
+	})
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ObjectResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
+	var data ObjectResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	objectPath := utils.NormalizeAPIPath(data.Path.ValueString()) + "/" + data.ID.ValueString()
+	body, httpResp, err := utils.DoRawAPIRequest(ctx, r.client, http.MethodGet, objectPath, nil, nil)
+	defer utils.CloseResponseBody(httpResp)
+	if err != nil {
+		if utils.HandleNotFound(httpResp, func() { resp.State.RemoveResource(ctx) }) {
+			return
+		}
+		resp.Diagnostics.AddError("Error reading object", utils.FormatAPIError(fmt.Sprintf("read object %s", objectPath), err, httpResp))
+		return
+	}
+	if !utils.ValidateStatusCode(&resp.Diagnostics, "read object", httpResp, http.StatusOK) {
+		return
+	}
+
+	actual, err := utils.DecodeJSONObject(body)
+	if err != nil {
+		resp.Diagnostics.AddError("Unexpected API response", fmt.Sprintf("Could not decode object %s: %s", objectPath, err))
+		return
+	}
+	data.ObjectJSON = types.StringValue(string(body))
+
+	// Only the keys the user configured are compared, so fields populated by
+	// NetBox never cause drift. Imported objects have no body until the next apply.
+	if !data.Body.IsNull() && !data.Body.IsUnderlyingValueNull() {
+		desired, _, err := objectBody(ctx, data.Body)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("body"), "Invalid body in state", err.Error())
+			return
+		}
+		if reconciled, drifted := utils.ReconcileOwnedJSON(desired, actual); drifted {
+			tflog.Debug(ctx, "Object drifted from configuration", map[string]interface{}{
+				"path": objectPath,
+			})
+			newBody, err := objectBodyLike(data.Body, reconciled)
+			if err != nil {
+				resp.Diagnostics.AddError("Error mapping object", err.Error())
+				return
+			}
+			data.Body = newBody
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ObjectResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	var state, plan ObjectResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, raw, err := objectBody(ctx, plan.Body)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("body"), "Invalid body", err.Error())
+		return
+	}
+
+	plan.ID = state.ID
+	objectPath := utils.NormalizeAPIPath(plan.Path.ValueString()) + "/" + plan.ID.ValueString()
+	body, httpResp, err := utils.DoRawAPIRequest(ctx, r.client, http.MethodPatch, objectPath, nil, json.RawMessage(raw))
+	defer utils.CloseResponseBody(httpResp)
+	if err != nil {
+		resp.Diagnostics.AddError("Error updating object", utils.FormatAPIError(fmt.Sprintf("update object %s", objectPath), err, httpResp))
+		return
+	}
+	if !utils.ValidateStatusCode(&resp.Diagnostics, "update object", httpResp, http.StatusOK) {
+		return
+	}
+
+	plan.ObjectJSON = types.StringValue(string(body))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *ObjectResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
+	var data ObjectResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	objectPath := utils.NormalizeAPIPath(data.Path.ValueString()) + "/" + data.ID.ValueString()
+	_, httpResp, err := utils.DoRawAPIRequest(ctx, r.client, http.MethodDelete, objectPath, nil, nil)
+	defer utils.CloseResponseBody(httpResp)
+	if err != nil {
+		if utils.HandleNotFound(httpResp, func() {}) {
+			return
+		}
+		resp.Diagnostics.AddError("Error deleting object", utils.FormatAPIError(fmt.Sprintf("delete object %s", objectPath), err, httpResp))
+		return
+	}
+	if !utils.ValidateStatusCode(&resp.Diagnostics, "delete object", httpResp, http.StatusNoContent) {
+		return
+	}
+}
+
+func (r *ObjectResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importID := utils.NormalizeAPIPath(req.ID)
+	idx := strings.LastIndex(importID, "/")
+	if idx <= 0 || idx == len(importID)-1 {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			fmt.Sprintf("Expected import ID in the format `<path>/<id>` (e.g. `plugins/netbox-dns/zones/12`), got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("path"), importID[:idx])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), importID[idx+1:])...)
+}
+
+// objectBody decodes the configured body into a JSON object. The body may be
+// an object value or a string containing a JSON object.
+func objectBody(ctx context.Context, body types.Dynamic) (map[string]interface{}, []byte, error) {
+	if body.IsNull() || body.IsUnderlyingValueNull() {
+		return nil, nil, fmt.Errorf("body must be an object or a JSON object string")
+	}
+
+	var raw []byte
+	if s, ok := body.UnderlyingValue().(types.String); ok {
+		raw = []byte(s.ValueString())
+	} else {
+		encoded, err := utils.DynamicToJSON(ctx, body)
+		if err != nil {
+			return nil, nil, fmt.Errorf("body could not be encoded as JSON: %w", err)
+		}
+		raw = encoded
+	}
+
+	decoded, err := utils.DecodeJSONObject(raw)
+	if err != nil {
+		return nil, nil, fmt.Errorf("body must be a JSON object: %w", err)
+	}
+	return decoded, raw, nil
+}
+
+// objectBodyLike encodes value in the same form as the configured body: a JSON
+// string if the body was configured as a string, otherwise an object value.
+func objectBodyLike(body types.Dynamic, value map[string]interface{}) (types.Dynamic, error) {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return body, fmt.Errorf("could not encode body: %w", err)
+	}
+	if _, ok := body.UnderlyingValue().(types.String); ok {
+		return types.DynamicValue(types.StringValue(string(encoded))), nil
+	}
+	return utils.JSONToDynamic(encoded)
+}
+
+// rawObjectID returns the `id` of a raw API object as a string.
+func rawObjectID(raw []byte) (string, error) {
+	var meta struct {
+		ID json.Number `json:"id"`
+	}
+	if err := json.Unmarshal(raw, &meta); err != nil {
+		return "", err
+	}
+	if meta.ID == "" {
+		return "", fmt.Errorf("response has no id")
+	}
+	return meta.ID.String(), nil
+}
@@ -36,6 +36,7 @@ type PlatformResourceModel struct {
 	Slug         types.String `tfsdk:"slug"`
 	Manufacturer types.String `tfsdk:"manufacturer"`
 	Description  types.String `tfsdk:"description"`
+	Branch       types.String `tfsdk:"branch"`
 }
 
 func (r *PlatformResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -55,6 +56,7 @@ func (r *PlatformResource) Schema(ctx context.Context, req resource.SchemaReques
 
 	// Add description attribute
 	maps.Copy(resp.Schema.Attributes, nbschema.DescriptionOnlyAttributes("platform"))
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 // Implement Create, Read, Update, Delete, and ImportState methods here.
@@ -73,6 +75,10 @@ func (r *PlatformResource) Configure(ctx context.Context, req resource.Configure
 }
 
 func (r *PlatformResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
 	var data PlatformResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -128,6 +134,7 @@ func (r *PlatformResource) Create(ctx context.Context, req resource.CreateReques
 }
 
 func (r *PlatformResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data PlatformResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -181,6 +188,10 @@ func (r *PlatformResource) Read(ctx context.Context, req resource.ReadRequest, r
 }
 
 func (r *PlatformResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
 	var data PlatformResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -249,6 +260,10 @@ func (r *PlatformResource) Update(ctx context.Context, req resource.UpdateReques
 }
 
 func (r *PlatformResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data PlatformResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -31,6 +31,7 @@ var (
 	_ resource.ResourceWithConfigure   = &PowerFeedResource{}
 	_ resource.ResourceWithImportState = &PowerFeedResource{}
 	_ resource.ResourceWithIdentity    = &PowerFeedResource{}
+	_ resource.ResourceWithModifyPlan  = &PowerFeedResource{}
 )
 
 // NewPowerFeedResource returns a new resource implementing the power feed resource.
@@ -45,23 +46,27 @@ type PowerFeedResource struct {
 
 // PowerFeedResourceModel describes the resource data model.
 type PowerFeedResourceModel struct {
-	ID             types.String `tfsdk:"id"`
-	PowerPanel     types.String `tfsdk:"power_panel"`
-	Rack           types.String `tfsdk:"rack"`
-	Name           types.String `tfsdk:"name"`
-	Status         types.String `tfsdk:"status"`
-	Type           types.String `tfsdk:"type"`
-	Supply         types.String `tfsdk:"supply"`
-	Phase          types.String `tfsdk:"phase"`
-	Voltage        types.Int64  `tfsdk:"voltage"`
-	Amperage       types.Int64  `tfsdk:"amperage"`
-	MaxUtilization types.Int64  `tfsdk:"max_utilization"`
-	MarkConnected  types.Bool   `tfsdk:"mark_connected"`
-	Description    types.String `tfsdk:"description"`
-	Tenant         types.String `tfsdk:"tenant"`
-	Comments       types.String `tfsdk:"comments"`
-	Tags           types.Set    `tfsdk:"tags"`
-	CustomFields   types.Set    `tfsdk:"custom_fields"`
+	ID              types.String  `tfsdk:"id"`
+	PowerPanel      types.String  `tfsdk:"power_panel"`
+	Rack            types.String  `tfsdk:"rack"`
+	Name            types.String  `tfsdk:"name"`
+	Status          types.String  `tfsdk:"status"`
+	Type            types.String  `tfsdk:"type"`
+	Supply          types.String  `tfsdk:"supply"`
+	Phase           types.String  `tfsdk:"phase"`
+	Voltage         types.Int64   `tfsdk:"voltage"`
+	Amperage        types.Int64   `tfsdk:"amperage"`
+	MaxUtilization  types.Int64   `tfsdk:"max_utilization"`
+	MarkConnected   types.Bool    `tfsdk:"mark_connected"`
+	Description     types.String  `tfsdk:"description"`
+	Tenant          types.String  `tfsdk:"tenant"`
+	Comments        types.String  `tfsdk:"comments"`
+	Tags            types.Set     `tfsdk:"tags"`
+	CustomFields    types.Set     `tfsdk:"custom_fields"`
+	CustomFieldsMap types.Dynamic `tfsdk:"custom_fields_map"`
+	TagsAll         types.Set     `tfsdk:"tags_all"`
+	CustomFieldsAll types.Map     `tfsdk:"custom_fields_all"`
+	Branch          types.String  `tfsdk:"branch"`
 }
 
 // Metadata returns the resource type name.
@@ -144,6 +149,10 @@ func (r *PowerFeedResource) Schema(ctx context.Context, req resource.SchemaReque
 	// Add tags and custom fields
 	resp.Schema.Attributes["tags"] = nbschema.TagsSlugAttribute()
 	resp.Schema.Attributes["custom_fields"] = nbschema.CustomFieldsAttribute()
+	resp.Schema.Attributes["custom_fields_map"] = nbschema.CustomFieldsMapAttribute()
+	resp.Schema.Attributes["tags_all"] = nbschema.TagsAllAttribute()
+	resp.Schema.Attributes["custom_fields_all"] = nbschema.CustomFieldsAllAttribute()
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 func (r *PowerFeedResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
@@ -166,8 +175,21 @@ func (r *PowerFeedResource) Configure(ctx context.Context, req resource.Configur
 	r.client = client
 }
 
+// ModifyPlan validates the configured custom fields against their definitions in NetBox
+// and plans tags_all and custom_fields_all.
+func (r *PowerFeedResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	utils.ValidateCustomFieldsPlan(ctx, r.client, "dcim.powerfeed", req, resp)
+	utils.PlanEffectiveMetadata(ctx, r.client, "dcim.powerfeed", req, resp)
+}
+
 // Create creates the resource.
 func (r *PowerFeedResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	defer journalCreate(ctx, r.client, "dcim.powerfeed", req, resp)
+
 	var data PowerFeedResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -264,6 +286,10 @@ func (r *PowerFeedResource) Create(ctx context.Context, req resource.CreateReque
 		return
 	}
 	utils.ApplyCustomFields(ctx, apiReq, data.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, apiReq, data.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, apiReq, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "dcim.powerfeed", apiReq, &resp.Diagnostics)
+	lookup.ResolveCustomFieldReferences(ctx, r.client, apiReq, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -294,8 +320,11 @@ func (r *PowerFeedResource) Create(ctx context.Context, req resource.CreateReque
 	}
 
 	// Apply filter-to-owned pattern for tags and custom_fields
-	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, response.HasTags(), response.GetTags(), planTags)
+	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, response.HasTags(), response.GetTags(), planTags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, response.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, response.GetCustomFields(), &resp.Diagnostics)
 	data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, planCustomFields, response.GetCustomFields(), &resp.Diagnostics)
+	data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, response.GetCustomFields(), &resp.Diagnostics)
 
 	utils.SetIdentityCustomFields(ctx, resp.Identity, types.StringValue(data.ID.ValueString()), data.CustomFields, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
@@ -313,6 +342,7 @@ func (r *PowerFeedResource) Create(ctx context.Context, req resource.CreateReque
 
 // Read refreshes the resource state.
 func (r *PowerFeedResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data PowerFeedResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -356,8 +386,11 @@ func (r *PowerFeedResource) Read(ctx context.Context, req resource.ReadRequest,
 	}
 
 	// Preserve null/empty state values for tags and custom_fields
-	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, response.HasTags(), response.GetTags(), stateTags)
+	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, response.HasTags(), response.GetTags(), stateTags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, response.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, response.GetCustomFields(), &resp.Diagnostics)
 	data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, stateCustomFields, response.GetCustomFields(), &resp.Diagnostics)
+	data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, response.GetCustomFields(), &resp.Diagnostics)
 
 	utils.SetIdentityCustomFields(ctx, resp.Identity, types.StringValue(data.ID.ValueString()), data.CustomFields, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
@@ -371,6 +404,13 @@ func (r *PowerFeedResource) Read(ctx context.Context, req resource.ReadRequest,
 
 // Update updates the resource.
 func (r *PowerFeedResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	ctx = withPriorTags(ctx, req.State)
+	defer journalUpdate(ctx, r.client, "dcim.powerfeed", req, resp)
+
 	// Read both state and plan for merge-aware custom fields
 	var state, plan PowerFeedResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -523,6 +563,10 @@ func (r *PowerFeedResource) Update(ctx context.Context, req resource.UpdateReque
 		return
 	}
 	utils.ApplyCustomFieldsWithMerge(ctx, apiReq, plan.CustomFields, state.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, apiReq, plan.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, apiReq, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "dcim.powerfeed", apiReq, &resp.Diagnostics)
+	lookup.ResolveCustomFieldReferences(ctx, r.client, apiReq, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -553,8 +597,11 @@ func (r *PowerFeedResource) Update(ctx context.Context, req resource.UpdateReque
 	}
 
 	// Apply filter-to-owned pattern for tags and custom_fields
-	plan.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, response.HasTags(), response.GetTags(), planTags)
+	plan.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, response.HasTags(), response.GetTags(), planTags)
+	plan.TagsAll = utils.TagsAllFromAPI(ctx, response.GetTags())
+	plan.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, plan.CustomFieldsAll, response.GetCustomFields(), &resp.Diagnostics)
 	plan.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, planCustomFields, response.GetCustomFields(), &resp.Diagnostics)
+	plan.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, plan.CustomFieldsMap, response.GetCustomFields(), &resp.Diagnostics)
 
 	utils.SetIdentityCustomFields(ctx, resp.Identity, types.StringValue(plan.ID.ValueString()), plan.CustomFields, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
@@ -566,6 +613,10 @@ func (r *PowerFeedResource) Update(ctx context.Context, req resource.UpdateReque
 
 // Delete deletes the resource.
 func (r *PowerFeedResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data PowerFeedResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -626,7 +677,9 @@ func (r *PowerFeedResource) ImportState(ctx context.Context, req resource.Import
 		}
 
 		var data PowerFeedResourceModel
-		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, response.HasTags(), response.GetTags(), data.Tags)
+		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, r.client, response.HasTags(), response.GetTags(), data.Tags)
+		data.TagsAll = utils.TagsAllFromAPI(ctx, response.GetTags())
+		data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, response.GetCustomFields(), &resp.Diagnostics)
 		if parsed.HasCustomFields {
 			if len(parsed.CustomFields) == 0 {
 				data.CustomFields = types.SetValueMust(utils.GetCustomFieldsAttributeType().ElemType, []attr.Value{})
@@ -648,6 +701,7 @@ func (r *PowerFeedResource) ImportState(ctx context.Context, req resource.Import
 		}
 		if parsed.HasCustomFields {
 			data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, response.GetCustomFields(), &resp.Diagnostics)
+			data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, response.GetCustomFields(), &resp.Diagnostics)
 		} else {
 			data.CustomFields = types.SetNull(utils.GetCustomFieldsAttributeType().ElemType)
 		}
@@ -771,8 +825,11 @@ func (r *PowerFeedResource) mapResponseToModel(ctx context.Context, pf *netbox.P
 	}
 
 	// Preserve null/empty state for tags and custom_fields (critical for drift prevention)
-	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, pf.HasTags(), pf.GetTags(), data.Tags)
+	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, pf.HasTags(), pf.GetTags(), data.Tags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, pf.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, pf.GetCustomFields(), diags)
 	if !data.CustomFields.IsNull() {
-		data.CustomFields = utils.PopulateCustomFieldsFromAPI(ctx, pf.HasCustomFields(), pf.GetCustomFields(), data.CustomFields, diags)
+		data.CustomFields = utils.PopulateCustomFieldsFromAPI(ctx, r.client, pf.HasCustomFields(), pf.GetCustomFields(), data.CustomFields, diags)
+		data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, pf.GetCustomFields(), diags)
 	}
 }
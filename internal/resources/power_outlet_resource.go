@@ -30,6 +30,7 @@ var (
 	_ resource.ResourceWithConfigure   = &PowerOutletResource{}
 	_ resource.ResourceWithImportState = &PowerOutletResource{}
 	_ resource.ResourceWithIdentity    = &PowerOutletResource{}
+	_ resource.ResourceWithModifyPlan  = &PowerOutletResource{}
 )
 
 // NewPowerOutletResource returns a new resource implementing the power outlet resource.
@@ -44,17 +45,21 @@ type PowerOutletResource struct {
 
 // PowerOutletResourceModel describes the resource data model.
 type PowerOutletResourceModel struct {
-	ID            types.String `tfsdk:"id"`
-	Device        types.String `tfsdk:"device"`
-	Name          types.String `tfsdk:"name"`
-	Label         types.String `tfsdk:"label"`
-	Type          types.String `tfsdk:"type"`
-	PowerPort     types.Int32  `tfsdk:"power_port"`
-	FeedLeg       types.String `tfsdk:"feed_leg"`
-	Description   types.String `tfsdk:"description"`
-	MarkConnected types.Bool   `tfsdk:"mark_connected"`
-	Tags          types.Set    `tfsdk:"tags"`
-	CustomFields  types.Set    `tfsdk:"custom_fields"`
+	ID              types.String  `tfsdk:"id"`
+	Device          types.String  `tfsdk:"device"`
+	Name            types.String  `tfsdk:"name"`
+	Label           types.String  `tfsdk:"label"`
+	Type            types.String  `tfsdk:"type"`
+	PowerPort       types.Int32   `tfsdk:"power_port"`
+	FeedLeg         types.String  `tfsdk:"feed_leg"`
+	Description     types.String  `tfsdk:"description"`
+	MarkConnected   types.Bool    `tfsdk:"mark_connected"`
+	Tags            types.Set     `tfsdk:"tags"`
+	CustomFields    types.Set     `tfsdk:"custom_fields"`
+	CustomFieldsMap types.Dynamic `tfsdk:"custom_fields_map"`
+	TagsAll         types.Set     `tfsdk:"tags_all"`
+	CustomFieldsAll types.Map     `tfsdk:"custom_fields_all"`
+	Branch          types.String  `tfsdk:"branch"`
 }
 
 // Metadata returns the resource type name.
@@ -113,6 +118,10 @@ func (r *PowerOutletResource) Schema(ctx context.Context, req resource.SchemaReq
 	// Add tags and custom_fields attributes
 	resp.Schema.Attributes["tags"] = nbschema.TagsSlugAttribute()
 	resp.Schema.Attributes["custom_fields"] = nbschema.CustomFieldsAttribute()
+	resp.Schema.Attributes["custom_fields_map"] = nbschema.CustomFieldsMapAttribute()
+	resp.Schema.Attributes["tags_all"] = nbschema.TagsAllAttribute()
+	resp.Schema.Attributes["custom_fields_all"] = nbschema.CustomFieldsAllAttribute()
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 func (r *PowerOutletResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
@@ -135,8 +144,21 @@ func (r *PowerOutletResource) Configure(ctx context.Context, req resource.Config
 	r.client = client
 }
 
+// ModifyPlan validates the configured custom fields against their definitions in NetBox
+// and plans tags_all and custom_fields_all.
+func (r *PowerOutletResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	utils.ValidateCustomFieldsPlan(ctx, r.client, "dcim.poweroutlet", req, resp)
+	utils.PlanEffectiveMetadata(ctx, r.client, "dcim.poweroutlet", req, resp)
+}
+
 // Create creates the resource.
 func (r *PowerOutletResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	defer journalCreate(ctx, r.client, "dcim.poweroutlet", req, resp)
+
 	var data PowerOutletResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -185,6 +207,10 @@ func (r *PowerOutletResource) Create(ctx context.Context, req resource.CreateReq
 	utils.ApplyDescription(apiReq, data.Description)
 	utils.ApplyTagsFromSlugs(ctx, r.client, apiReq, data.Tags, &resp.Diagnostics)
 	utils.ApplyCustomFields(ctx, apiReq, data.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, apiReq, data.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, apiReq, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "dcim.poweroutlet", apiReq, &resp.Diagnostics)
+	lookup.ResolveCustomFieldReferences(ctx, r.client, apiReq, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -224,6 +250,7 @@ func (r *PowerOutletResource) Create(ctx context.Context, req resource.CreateReq
 
 // Read refreshes the resource state.
 func (r *PowerOutletResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data PowerOutletResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -280,6 +307,13 @@ func (r *PowerOutletResource) Read(ctx context.Context, req resource.ReadRequest
 
 // Update updates the resource.
 func (r *PowerOutletResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	ctx = withPriorTags(ctx, req.State)
+	defer journalUpdate(ctx, r.client, "dcim.poweroutlet", req, resp)
+
 	var state, data PowerOutletResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -350,6 +384,10 @@ func (r *PowerOutletResource) Update(ctx context.Context, req resource.UpdateReq
 
 	// Apply custom fields with merge logic (preserves unmanaged fields)
 	utils.ApplyCustomFieldsWithMerge(ctx, apiReq, data.CustomFields, state.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, apiReq, data.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, apiReq, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "dcim.poweroutlet", apiReq, &resp.Diagnostics)
+	lookup.ResolveCustomFieldReferences(ctx, r.client, apiReq, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -384,6 +422,10 @@ func (r *PowerOutletResource) Update(ctx context.Context, req resource.UpdateReq
 
 // Delete deletes the resource.
 func (r *PowerOutletResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data PowerOutletResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -444,7 +486,9 @@ func (r *PowerOutletResource) ImportState(ctx context.Context, req resource.Impo
 		}
 
 		var data PowerOutletResourceModel
-		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, response.HasTags(), response.GetTags(), data.Tags)
+		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, r.client, response.HasTags(), response.GetTags(), data.Tags)
+		data.TagsAll = utils.TagsAllFromAPI(ctx, response.GetTags())
+		data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, response.GetCustomFields(), &resp.Diagnostics)
 		if parsed.HasCustomFields {
 			if len(parsed.CustomFields) == 0 {
 				data.CustomFields = types.SetValueMust(utils.GetCustomFieldsAttributeType().ElemType, []attr.Value{})
@@ -466,6 +510,7 @@ func (r *PowerOutletResource) ImportState(ctx context.Context, req resource.Impo
 		}
 		if parsed.HasCustomFields {
 			data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, response.GetCustomFields(), &resp.Diagnostics)
+			data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, response.GetCustomFields(), &resp.Diagnostics)
 		} else {
 			data.CustomFields = types.SetNull(utils.GetCustomFieldsAttributeType().ElemType)
 		}
@@ -545,11 +590,14 @@ func (r *PowerOutletResource) mapResponseToModel(ctx context.Context, powerOutle
 	}
 
 	// Handle tags - filter to owned slugs
-	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, powerOutlet.HasTags(), powerOutlet.GetTags(), data.Tags)
+	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, powerOutlet.HasTags(), powerOutlet.GetTags(), data.Tags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, powerOutlet.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, powerOutlet.GetCustomFields(), diags)
 
 	// Handle custom fields - use filtered-to-owned for partial management
 	if powerOutlet.HasCustomFields() {
 		data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, powerOutlet.GetCustomFields(), diags)
+		data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, powerOutlet.GetCustomFields(), diags)
 	} else {
 		data.CustomFields = types.SetNull(utils.GetCustomFieldsAttributeType().ElemType)
 	}
@@ -29,6 +29,7 @@ var (
 	_ resource.ResourceWithConfigure   = &PowerPanelResource{}
 	_ resource.ResourceWithImportState = &PowerPanelResource{}
 	_ resource.ResourceWithIdentity    = &PowerPanelResource{}
+	_ resource.ResourceWithModifyPlan  = &PowerPanelResource{}
 )
 
 // NewPowerPanelResource returns a new resource implementing the power panel resource.
@@ -43,14 +44,18 @@ type PowerPanelResource struct {
 
 // PowerPanelResourceModel describes the resource data model.
 type PowerPanelResourceModel struct {
-	ID           types.String `tfsdk:"id"`
-	Site         types.String `tfsdk:"site"`
-	Location     types.String `tfsdk:"location"`
-	Name         types.String `tfsdk:"name"`
-	Description  types.String `tfsdk:"description"`
-	Comments     types.String `tfsdk:"comments"`
-	Tags         types.Set    `tfsdk:"tags"`
-	CustomFields types.Set    `tfsdk:"custom_fields"`
+	ID              types.String  `tfsdk:"id"`
+	Site            types.String  `tfsdk:"site"`
+	Location        types.String  `tfsdk:"location"`
+	Name            types.String  `tfsdk:"name"`
+	Description     types.String  `tfsdk:"description"`
+	Comments        types.String  `tfsdk:"comments"`
+	Tags            types.Set     `tfsdk:"tags"`
+	CustomFields    types.Set     `tfsdk:"custom_fields"`
+	CustomFieldsMap types.Dynamic `tfsdk:"custom_fields_map"`
+	TagsAll         types.Set     `tfsdk:"tags_all"`
+	CustomFieldsAll types.Map     `tfsdk:"custom_fields_all"`
+	Branch          types.String  `tfsdk:"branch"`
 }
 
 // Metadata returns the resource type name.
@@ -95,6 +100,10 @@ func (r *PowerPanelResource) Schema(ctx context.Context, req resource.SchemaRequ
 	// Add tags and custom_fields attributes
 	resp.Schema.Attributes["tags"] = nbschema.TagsSlugAttribute()
 	resp.Schema.Attributes["custom_fields"] = nbschema.CustomFieldsAttribute()
+	resp.Schema.Attributes["custom_fields_map"] = nbschema.CustomFieldsMapAttribute()
+	resp.Schema.Attributes["tags_all"] = nbschema.TagsAllAttribute()
+	resp.Schema.Attributes["custom_fields_all"] = nbschema.CustomFieldsAllAttribute()
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 func (r *PowerPanelResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
@@ -117,8 +126,21 @@ func (r *PowerPanelResource) Configure(ctx context.Context, req resource.Configu
 	r.client = client
 }
 
+// ModifyPlan validates the configured custom fields against their definitions in NetBox
+// and plans tags_all and custom_fields_all.
+func (r *PowerPanelResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	utils.ValidateCustomFieldsPlan(ctx, r.client, "dcim.powerpanel", req, resp)
+	utils.PlanEffectiveMetadata(ctx, r.client, "dcim.powerpanel", req, resp)
+}
+
 // Create creates the resource.
 func (r *PowerPanelResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	defer journalCreate(ctx, r.client, "dcim.powerpanel", req, resp)
+
 	var data PowerPanelResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -150,6 +172,10 @@ func (r *PowerPanelResource) Create(ctx context.Context, req resource.CreateRequ
 	utils.ApplyComments(apiReq, data.Comments)
 	utils.ApplyTagsFromSlugs(ctx, r.client, apiReq, data.Tags, &resp.Diagnostics)
 	utils.ApplyCustomFields(ctx, apiReq, data.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, apiReq, data.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, apiReq, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "dcim.powerpanel", apiReq, &resp.Diagnostics)
+	lookup.ResolveCustomFieldReferences(ctx, r.client, apiReq, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -188,6 +214,7 @@ func (r *PowerPanelResource) Create(ctx context.Context, req resource.CreateRequ
 
 // Read refreshes the resource state.
 func (r *PowerPanelResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data PowerPanelResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -235,6 +262,13 @@ func (r *PowerPanelResource) Read(ctx context.Context, req resource.ReadRequest,
 
 // Update updates the resource.
 func (r *PowerPanelResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	ctx = withPriorTags(ctx, req.State)
+	defer journalUpdate(ctx, r.client, "dcim.powerpanel", req, resp)
+
 	var state, plan PowerPanelResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -275,6 +309,10 @@ func (r *PowerPanelResource) Update(ctx context.Context, req resource.UpdateRequ
 	utils.ApplyComments(apiReq, plan.Comments)
 	utils.ApplyTagsFromSlugs(ctx, r.client, apiReq, plan.Tags, &resp.Diagnostics)
 	utils.ApplyCustomFieldsWithMerge(ctx, apiReq, plan.CustomFields, state.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, apiReq, plan.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, apiReq, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "dcim.powerpanel", apiReq, &resp.Diagnostics)
+	lookup.ResolveCustomFieldReferences(ctx, r.client, apiReq, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -309,6 +347,10 @@ func (r *PowerPanelResource) Update(ctx context.Context, req resource.UpdateRequ
 
 // Delete deletes the resource.
 func (r *PowerPanelResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data PowerPanelResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -371,7 +413,9 @@ func (r *PowerPanelResource) ImportState(ctx context.Context, req resource.Impor
 		}
 
 		var data PowerPanelResourceModel
-		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, response.HasTags(), response.GetTags(), data.Tags)
+		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, r.client, response.HasTags(), response.GetTags(), data.Tags)
+		data.TagsAll = utils.TagsAllFromAPI(ctx, response.GetTags())
+		data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, response.GetCustomFields(), &resp.Diagnostics)
 		if parsed.HasCustomFields {
 			if len(parsed.CustomFields) == 0 {
 				data.CustomFields = types.SetValueMust(utils.GetCustomFieldsAttributeType().ElemType, []attr.Value{})
@@ -393,6 +437,7 @@ func (r *PowerPanelResource) ImportState(ctx context.Context, req resource.Impor
 		}
 		if parsed.HasCustomFields {
 			data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, response.GetCustomFields(), &resp.Diagnostics)
+			data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, response.GetCustomFields(), &resp.Diagnostics)
 		} else {
 			data.CustomFields = types.SetNull(utils.GetCustomFieldsAttributeType().ElemType)
 		}
@@ -449,10 +494,13 @@ func (r *PowerPanelResource) mapResponseToModel(ctx context.Context, pp *netbox.
 	}
 
 	// Handle tags - filter to owned slugs
-	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, pp.HasTags(), pp.GetTags(), data.Tags)
+	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, pp.HasTags(), pp.GetTags(), data.Tags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, pp.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, pp.GetCustomFields(), diags)
 
 	// Handle custom fields (filter to owned)
 	data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, pp.GetCustomFields(), diags)
+	data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, pp.GetCustomFields(), diags)
 	if diags.HasError() {
 		return
 	}
@@ -30,6 +30,7 @@ var (
 	_ resource.ResourceWithConfigure   = &PowerPortResource{}
 	_ resource.ResourceWithImportState = &PowerPortResource{}
 	_ resource.ResourceWithIdentity    = &PowerPortResource{}
+	_ resource.ResourceWithModifyPlan  = &PowerPortResource{}
 )
 
 // NewPowerPortResource returns a new resource implementing the power port resource.
@@ -44,17 +45,21 @@ type PowerPortResource struct {
 
 // PowerPortResourceModel describes the resource data model.
 type PowerPortResourceModel struct {
-	ID            types.String `tfsdk:"id"`
-	Device        types.String `tfsdk:"device"`
-	Name          types.String `tfsdk:"name"`
-	Label         types.String `tfsdk:"label"`
-	Type          types.String `tfsdk:"type"`
-	MaximumDraw   types.Int32  `tfsdk:"maximum_draw"`
-	AllocatedDraw types.Int32  `tfsdk:"allocated_draw"`
-	Description   types.String `tfsdk:"description"`
-	MarkConnected types.Bool   `tfsdk:"mark_connected"`
-	Tags          types.Set    `tfsdk:"tags"`
-	CustomFields  types.Set    `tfsdk:"custom_fields"`
+	ID              types.String  `tfsdk:"id"`
+	Device          types.String  `tfsdk:"device"`
+	Name            types.String  `tfsdk:"name"`
+	Label           types.String  `tfsdk:"label"`
+	Type            types.String  `tfsdk:"type"`
+	MaximumDraw     types.Int32   `tfsdk:"maximum_draw"`
+	AllocatedDraw   types.Int32   `tfsdk:"allocated_draw"`
+	Description     types.String  `tfsdk:"description"`
+	MarkConnected   types.Bool    `tfsdk:"mark_connected"`
+	Tags            types.Set     `tfsdk:"tags"`
+	CustomFields    types.Set     `tfsdk:"custom_fields"`
+	CustomFieldsMap types.Dynamic `tfsdk:"custom_fields_map"`
+	TagsAll         types.Set     `tfsdk:"tags_all"`
+	CustomFieldsAll types.Map     `tfsdk:"custom_fields_all"`
+	Branch          types.String  `tfsdk:"branch"`
 }
 
 // Metadata returns the resource type name.
@@ -110,6 +115,10 @@ func (r *PowerPortResource) Schema(ctx context.Context, req resource.SchemaReque
 	// Add tags and custom_fields attributes
 	resp.Schema.Attributes["tags"] = nbschema.TagsSlugAttribute()
 	resp.Schema.Attributes["custom_fields"] = nbschema.CustomFieldsAttribute()
+	resp.Schema.Attributes["custom_fields_map"] = nbschema.CustomFieldsMapAttribute()
+	resp.Schema.Attributes["tags_all"] = nbschema.TagsAllAttribute()
+	resp.Schema.Attributes["custom_fields_all"] = nbschema.CustomFieldsAllAttribute()
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 func (r *PowerPortResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
@@ -132,8 +141,21 @@ func (r *PowerPortResource) Configure(ctx context.Context, req resource.Configur
 	r.client = client
 }
 
+// ModifyPlan validates the configured custom fields against their definitions in NetBox
+// and plans tags_all and custom_fields_all.
+func (r *PowerPortResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	utils.ValidateCustomFieldsPlan(ctx, r.client, "dcim.powerport", req, resp)
+	utils.PlanEffectiveMetadata(ctx, r.client, "dcim.powerport", req, resp)
+}
+
 // Create creates the resource.
 func (r *PowerPortResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	defer journalCreate(ctx, r.client, "dcim.powerport", req, resp)
+
 	var data PowerPortResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -173,6 +195,10 @@ func (r *PowerPortResource) Create(ctx context.Context, req resource.CreateReque
 	utils.ApplyDescription(apiReq, data.Description)
 	utils.ApplyTagsFromSlugs(ctx, r.client, apiReq, data.Tags, &resp.Diagnostics)
 	utils.ApplyCustomFields(ctx, apiReq, data.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, apiReq, data.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, apiReq, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "dcim.powerport", apiReq, &resp.Diagnostics)
+	lookup.ResolveCustomFieldReferences(ctx, r.client, apiReq, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -212,6 +238,7 @@ func (r *PowerPortResource) Create(ctx context.Context, req resource.CreateReque
 
 // Read refreshes the resource state.
 func (r *PowerPortResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data PowerPortResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -268,6 +295,13 @@ func (r *PowerPortResource) Read(ctx context.Context, req resource.ReadRequest,
 
 // Update updates the resource.
 func (r *PowerPortResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	ctx = withPriorTags(ctx, req.State)
+	defer journalUpdate(ctx, r.client, "dcim.powerport", req, resp)
+
 	var state, data PowerPortResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -327,6 +361,10 @@ func (r *PowerPortResource) Update(ctx context.Context, req resource.UpdateReque
 
 	// Apply custom fields with merge logic (preserves unmanaged fields)
 	utils.ApplyCustomFieldsWithMerge(ctx, apiReq, data.CustomFields, state.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, apiReq, data.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, apiReq, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "dcim.powerport", apiReq, &resp.Diagnostics)
+	lookup.ResolveCustomFieldReferences(ctx, r.client, apiReq, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -361,6 +399,10 @@ func (r *PowerPortResource) Update(ctx context.Context, req resource.UpdateReque
 
 // Delete deletes the resource.
 func (r *PowerPortResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data PowerPortResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -421,7 +463,9 @@ func (r *PowerPortResource) ImportState(ctx context.Context, req resource.Import
 		}
 
 		var data PowerPortResourceModel
-		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, response.HasTags(), response.GetTags(), data.Tags)
+		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, r.client, response.HasTags(), response.GetTags(), data.Tags)
+		data.TagsAll = utils.TagsAllFromAPI(ctx, response.GetTags())
+		data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, response.GetCustomFields(), &resp.Diagnostics)
 		if parsed.HasCustomFields {
 			if len(parsed.CustomFields) == 0 {
 				data.CustomFields = types.SetValueMust(utils.GetCustomFieldsAttributeType().ElemType, []attr.Value{})
@@ -443,6 +487,7 @@ func (r *PowerPortResource) ImportState(ctx context.Context, req resource.Import
 		}
 		if parsed.HasCustomFields {
 			data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, response.GetCustomFields(), &resp.Diagnostics)
+			data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, response.GetCustomFields(), &resp.Diagnostics)
 		} else {
 			data.CustomFields = types.SetNull(utils.GetCustomFieldsAttributeType().ElemType)
 		}
@@ -522,11 +567,14 @@ func (r *PowerPortResource) mapResponseToModel(ctx context.Context, powerPort *n
 	}
 
 	// Handle tags - filter to owned slugs
-	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, powerPort.HasTags(), powerPort.GetTags(), data.Tags)
+	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, powerPort.HasTags(), powerPort.GetTags(), data.Tags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, powerPort.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, powerPort.GetCustomFields(), diags)
 
 	// Handle custom fields
 	if powerPort.HasCustomFields() {
 		data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, powerPort.GetCustomFields(), diags)
+		data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, powerPort.GetCustomFields(), diags)
 	} else {
 		data.CustomFields = types.SetNull(utils.GetCustomFieldsAttributeType().ElemType)
 	}
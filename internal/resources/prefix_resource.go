@@ -31,6 +31,7 @@ var (
 	_ resource.ResourceWithConfigure   = &PrefixResource{}
 	_ resource.ResourceWithImportState = &PrefixResource{}
 	_ resource.ResourceWithIdentity    = &PrefixResource{}
+	_ resource.ResourceWithModifyPlan  = &PrefixResource{}
 )
 
 // NewPrefixResource returns a new Prefix resource.
@@ -45,20 +46,24 @@ type PrefixResource struct {
 
 // PrefixResourceModel describes the resource data model.
 type PrefixResourceModel struct {
-	ID           types.String `tfsdk:"id"`
-	Prefix       types.String `tfsdk:"prefix"`
-	Site         types.String `tfsdk:"site"`
-	VRF          types.String `tfsdk:"vrf"`
-	Tenant       types.String `tfsdk:"tenant"`
-	VLAN         types.String `tfsdk:"vlan"`
-	Status       types.String `tfsdk:"status"`
-	Role         types.String `tfsdk:"role"`
-	IsPool       types.Bool   `tfsdk:"is_pool"`
-	MarkUtilized types.Bool   `tfsdk:"mark_utilized"`
-	Description  types.String `tfsdk:"description"`
-	Comments     types.String `tfsdk:"comments"`
-	Tags         types.Set    `tfsdk:"tags"`
-	CustomFields types.Set    `tfsdk:"custom_fields"`
+	ID              types.String  `tfsdk:"id"`
+	Prefix          types.String  `tfsdk:"prefix"`
+	Site            types.String  `tfsdk:"site"`
+	VRF             types.String  `tfsdk:"vrf"`
+	Tenant          types.String  `tfsdk:"tenant"`
+	VLAN            types.String  `tfsdk:"vlan"`
+	Status          types.String  `tfsdk:"status"`
+	Role            types.String  `tfsdk:"role"`
+	IsPool          types.Bool    `tfsdk:"is_pool"`
+	MarkUtilized    types.Bool    `tfsdk:"mark_utilized"`
+	Description     types.String  `tfsdk:"description"`
+	Comments        types.String  `tfsdk:"comments"`
+	Tags            types.Set     `tfsdk:"tags"`
+	CustomFields    types.Set     `tfsdk:"custom_fields"`
+	CustomFieldsMap types.Dynamic `tfsdk:"custom_fields_map"`
+	TagsAll         types.Set     `tfsdk:"tags_all"`
+	CustomFieldsAll types.Map     `tfsdk:"custom_fields_all"`
+	Branch          types.String  `tfsdk:"branch"`
 }
 
 // Metadata returns the resource type name.
@@ -114,6 +119,10 @@ func (r *PrefixResource) Schema(ctx context.Context, req resource.SchemaRequest,
 	// Add common metadata attributes (tags, custom_fields)
 	resp.Schema.Attributes["tags"] = nbschema.TagsSlugAttribute()
 	resp.Schema.Attributes["custom_fields"] = nbschema.CustomFieldsAttribute()
+	resp.Schema.Attributes["custom_fields_map"] = nbschema.CustomFieldsMapAttribute()
+	resp.Schema.Attributes["tags_all"] = nbschema.TagsAllAttribute()
+	resp.Schema.Attributes["custom_fields_all"] = nbschema.CustomFieldsAllAttribute()
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 func (r *PrefixResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
@@ -136,8 +145,21 @@ func (r *PrefixResource) Configure(ctx context.Context, req resource.ConfigureRe
 	r.client = client
 }
 
+// ModifyPlan validates the configured custom fields against their definitions in NetBox
+// and plans tags_all and custom_fields_all.
+func (r *PrefixResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	utils.ValidateCustomFieldsPlan(ctx, r.client, "ipam.prefix", req, resp)
+	utils.PlanEffectiveMetadata(ctx, r.client, "ipam.prefix", req, resp)
+}
+
 // Create creates the resource and sets the initial Terraform state.
 func (r *PrefixResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	defer journalCreate(ctx, r.client, "ipam.prefix", req, resp)
+
 	var data PrefixResourceModel
 
 	// Read Terraform plan data into the model
@@ -193,6 +215,7 @@ func (r *PrefixResource) Create(ctx context.Context, req resource.CreateRequest,
 
 // Read refreshes the Terraform state with the latest data.
 func (r *PrefixResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data PrefixResourceModel
 
 	// Read Terraform prior state data into the model
@@ -257,6 +280,13 @@ func (r *PrefixResource) Read(ctx context.Context, req resource.ReadRequest, res
 
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *PrefixResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	ctx = withPriorTags(ctx, req.State)
+	defer journalUpdate(ctx, r.client, "ipam.prefix", req, resp)
+
 	var plan PrefixResourceModel
 	var state PrefixResourceModel
 
@@ -325,6 +355,10 @@ func (r *PrefixResource) Update(ctx context.Context, req resource.UpdateRequest,
 
 // Delete deletes the resource and removes the Terraform state on success.
 func (r *PrefixResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data PrefixResourceModel
 
 	// Read Terraform prior state data into the model
@@ -415,7 +449,9 @@ func (r *PrefixResource) ImportState(ctx context.Context, req resource.ImportSta
 			role := prefix.Role.Get()
 			data.Role = types.StringValue(fmt.Sprintf("%d", role.GetId()))
 		}
-		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, len(prefix.Tags) > 0, prefix.Tags, data.Tags)
+		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, r.client, len(prefix.Tags) > 0, prefix.Tags, data.Tags)
+		data.TagsAll = utils.TagsAllFromAPI(ctx, prefix.Tags)
+		data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, prefix.CustomFields, &resp.Diagnostics)
 		if parsed.HasCustomFields {
 			if len(parsed.CustomFields) == 0 {
 				data.CustomFields = types.SetValueMust(utils.GetCustomFieldsAttributeType().ElemType, []attr.Value{})
@@ -437,6 +473,7 @@ func (r *PrefixResource) ImportState(ctx context.Context, req resource.ImportSta
 		}
 		if parsed.HasCustomFields {
 			data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, prefix.GetCustomFields(), &resp.Diagnostics)
+			data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, prefix.GetCustomFields(), &resp.Diagnostics)
 		} else {
 			data.CustomFields = types.SetNull(utils.GetCustomFieldsAttributeType().ElemType)
 		}
@@ -570,9 +607,17 @@ func (r *PrefixResource) setOptionalFields(ctx context.Context, prefixRequest *n
 	if state != nil {
 		// Update: merge plan custom fields with existing state custom fields
 		utils.ApplyCustomFieldsWithMerge(ctx, prefixRequest, data.CustomFields, state.CustomFields, diags)
+		utils.ApplyCustomFieldsMap(ctx, r.client, prefixRequest, data.CustomFieldsMap, diags)
+		utils.ApplyProviderTags(ctx, r.client, prefixRequest, diags)
+		utils.ApplyProviderCustomFields(ctx, r.client, "ipam.prefix", prefixRequest, diags)
+		netboxlookup.ResolveCustomFieldReferences(ctx, r.client, prefixRequest, diags)
 	} else {
 		// Create: apply plan custom fields directly
 		utils.ApplyCustomFields(ctx, prefixRequest, data.CustomFields, diags)
+		utils.ApplyCustomFieldsMap(ctx, r.client, prefixRequest, data.CustomFieldsMap, diags)
+		utils.ApplyProviderTags(ctx, r.client, prefixRequest, diags)
+		utils.ApplyProviderCustomFields(ctx, r.client, "ipam.prefix", prefixRequest, diags)
+		netboxlookup.ResolveCustomFieldReferences(ctx, r.client, prefixRequest, diags)
 	}
 
 	if diags.HasError() {
@@ -673,8 +718,11 @@ func (r *PrefixResource) mapPrefixToState(ctx context.Context, prefix *netbox.Pr
 	}
 
 	// Tags
-	data.Tags = utils.PopulateTagsSlugFromAPI(ctx, len(prefix.Tags) > 0, prefix.Tags, data.Tags)
+	data.Tags = utils.PopulateTagsSlugFromAPI(ctx, r.client, len(prefix.Tags) > 0, prefix.Tags, data.Tags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, prefix.Tags)
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, prefix.CustomFields, diags)
 
 	// Custom fields - use filter-to-owned pattern
 	data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, prefix.GetCustomFields(), diags)
+	data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, prefix.GetCustomFields(), diags)
 }
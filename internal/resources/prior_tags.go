@@ -0,0 +1,21 @@
+package resources
+
+import (
+	"context"
+
+	"github.com/bab3l/terraform-provider-netbox/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// withPriorTags returns ctx carrying the tags_all of the prior state, so that
+// utils.ApplyProviderTags keeps the tags of the object that the provider
+// ignores when an update replaces its tags. ctx is returned unchanged when
+// the prior tags are not known.
+func withPriorTags(ctx context.Context, state attributeGetter) context.Context {
+	var tagsAll types.Set
+	if state.GetAttribute(ctx, path.Root("tags_all"), &tagsAll).HasError() || !utils.IsSet(tagsAll) {
+		return ctx
+	}
+	return utils.ContextWithPriorTags(ctx, utils.SetToStringSlice(ctx, tagsAll))
+}
@@ -29,6 +29,7 @@ var (
 	_ resource.ResourceWithConfigure   = &ProviderAccountResource{}
 	_ resource.ResourceWithImportState = &ProviderAccountResource{}
 	_ resource.ResourceWithIdentity    = &ProviderAccountResource{}
+	_ resource.ResourceWithModifyPlan  = &ProviderAccountResource{}
 )
 
 // NewProviderAccountResource returns a new Provider Account resource.
@@ -43,14 +44,18 @@ type ProviderAccountResource struct {
 
 // ProviderAccountResourceModel describes the resource data model.
 type ProviderAccountResourceModel struct {
-	ID              types.String `tfsdk:"id"`
-	CircuitProvider types.String `tfsdk:"circuit_provider"`
-	Name            types.String `tfsdk:"name"`
-	Account         types.String `tfsdk:"account"`
-	Description     types.String `tfsdk:"description"`
-	Comments        types.String `tfsdk:"comments"`
-	Tags            types.Set    `tfsdk:"tags"`
-	CustomFields    types.Set    `tfsdk:"custom_fields"`
+	ID              types.String  `tfsdk:"id"`
+	CircuitProvider types.String  `tfsdk:"circuit_provider"`
+	Name            types.String  `tfsdk:"name"`
+	Account         types.String  `tfsdk:"account"`
+	Description     types.String  `tfsdk:"description"`
+	Comments        types.String  `tfsdk:"comments"`
+	Tags            types.Set     `tfsdk:"tags"`
+	CustomFields    types.Set     `tfsdk:"custom_fields"`
+	CustomFieldsMap types.Dynamic `tfsdk:"custom_fields_map"`
+	TagsAll         types.Set     `tfsdk:"tags_all"`
+	CustomFieldsAll types.Map     `tfsdk:"custom_fields_all"`
+	Branch          types.String  `tfsdk:"branch"`
 }
 
 // Metadata returns the resource type name.
@@ -91,6 +96,10 @@ func (r *ProviderAccountResource) Schema(ctx context.Context, req resource.Schem
 	// Add tags and custom fields attributes
 	resp.Schema.Attributes["tags"] = nbschema.TagsSlugAttribute()
 	resp.Schema.Attributes["custom_fields"] = nbschema.CustomFieldsAttribute()
+	resp.Schema.Attributes["custom_fields_map"] = nbschema.CustomFieldsMapAttribute()
+	resp.Schema.Attributes["tags_all"] = nbschema.TagsAllAttribute()
+	resp.Schema.Attributes["custom_fields_all"] = nbschema.CustomFieldsAllAttribute()
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 func (r *ProviderAccountResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
@@ -113,8 +122,21 @@ func (r *ProviderAccountResource) Configure(ctx context.Context, req resource.Co
 	r.client = client
 }
 
+// ModifyPlan validates the configured custom fields against their definitions in NetBox
+// and plans tags_all and custom_fields_all.
+func (r *ProviderAccountResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	utils.ValidateCustomFieldsPlan(ctx, r.client, "circuits.provideraccount", req, resp)
+	utils.PlanEffectiveMetadata(ctx, r.client, "circuits.provideraccount", req, resp)
+}
+
 // Create creates a new provider account resource.
 func (r *ProviderAccountResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	defer journalCreate(ctx, r.client, "circuits.provideraccount", req, resp)
+
 	var data ProviderAccountResourceModel
 
 	// Read Terraform plan data into the model
@@ -166,6 +188,7 @@ func (r *ProviderAccountResource) Create(ctx context.Context, req resource.Creat
 
 // Read reads the provider account resource.
 func (r *ProviderAccountResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data ProviderAccountResourceModel
 
 	// Read Terraform prior state data into the model
@@ -223,6 +246,13 @@ func (r *ProviderAccountResource) Read(ctx context.Context, req resource.ReadReq
 
 // Update updates the provider account resource.
 func (r *ProviderAccountResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	ctx = withPriorTags(ctx, req.State)
+	defer journalUpdate(ctx, r.client, "circuits.provideraccount", req, resp)
+
 	var state, data ProviderAccountResourceModel
 
 	// Read Terraform plan and state data into the models
@@ -283,6 +313,10 @@ func (r *ProviderAccountResource) Update(ctx context.Context, req resource.Updat
 
 // Delete deletes the provider account resource.
 func (r *ProviderAccountResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data ProviderAccountResourceModel
 
 	// Read Terraform prior state data into the model
@@ -357,7 +391,9 @@ func (r *ProviderAccountResource) ImportState(ctx context.Context, req resource.
 		if provider := providerAccount.GetProvider(); provider.Id != 0 {
 			data.CircuitProvider = types.StringValue(fmt.Sprintf("%d", provider.Id))
 		}
-		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, providerAccount.HasTags(), providerAccount.GetTags(), data.Tags)
+		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, r.client, providerAccount.HasTags(), providerAccount.GetTags(), data.Tags)
+		data.TagsAll = utils.TagsAllFromAPI(ctx, providerAccount.GetTags())
+		data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, providerAccount.GetCustomFields(), &resp.Diagnostics)
 		if parsed.HasCustomFields {
 			if len(parsed.CustomFields) == 0 {
 				data.CustomFields = types.SetValueMust(utils.GetCustomFieldsAttributeType().ElemType, []attr.Value{})
@@ -379,6 +415,7 @@ func (r *ProviderAccountResource) ImportState(ctx context.Context, req resource.
 		}
 		if parsed.HasCustomFields {
 			data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, providerAccount.GetCustomFields(), &resp.Diagnostics)
+			data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, providerAccount.GetCustomFields(), &resp.Diagnostics)
 		} else {
 			data.CustomFields = types.SetNull(utils.GetCustomFieldsAttributeType().ElemType)
 		}
@@ -447,6 +484,10 @@ func (r *ProviderAccountResource) buildCreateRequest(ctx context.Context, data *
 		stateCustomFields = state.CustomFields
 	}
 	utils.ApplyCustomFieldsWithMerge(ctx, createReq, data.CustomFields, stateCustomFields, &diags)
+	utils.ApplyCustomFieldsMap(ctx, r.client, createReq, data.CustomFieldsMap, &diags)
+	utils.ApplyProviderTags(ctx, r.client, createReq, &diags)
+	utils.ApplyProviderCustomFields(ctx, r.client, "circuits.provideraccount", createReq, &diags)
+	netboxlookup.ResolveCustomFieldReferences(ctx, r.client, createReq, &diags)
 	if diags.HasError() {
 		return nil, diags
 	}
@@ -474,10 +515,13 @@ func (r *ProviderAccountResource) mapResponseToModel(ctx context.Context, provid
 	data.Comments = utils.StringFromAPI(providerAccount.HasComments(), providerAccount.GetComments, data.Comments)
 
 	// Filter tags to owned (slug list format)
-	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, providerAccount.HasTags(), providerAccount.GetTags(), data.Tags)
+	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, providerAccount.HasTags(), providerAccount.GetTags(), data.Tags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, providerAccount.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, providerAccount.GetCustomFields(), diags)
 
 	// Populate custom fields
 	if providerAccount.HasCustomFields() {
 		data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, providerAccount.GetCustomFields(), diags)
+		data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, providerAccount.GetCustomFields(), diags)
 	}
 }
@@ -31,6 +31,7 @@ var (
 	_ resource.ResourceWithConfigure   = &ProviderNetworkResource{}
 	_ resource.ResourceWithImportState = &ProviderNetworkResource{}
 	_ resource.ResourceWithIdentity    = &ProviderNetworkResource{}
+	_ resource.ResourceWithModifyPlan  = &ProviderNetworkResource{}
 )
 
 // NewProviderNetworkResource returns a new ProviderNetwork resource.
@@ -45,14 +46,18 @@ type ProviderNetworkResource struct {
 
 // ProviderNetworkResourceModel describes the resource data model.
 type ProviderNetworkResourceModel struct {
-	ID              types.String `tfsdk:"id"`
-	CircuitProvider types.String `tfsdk:"circuit_provider"`
-	Name            types.String `tfsdk:"name"`
-	ServiceID       types.String `tfsdk:"service_id"`
-	Description     types.String `tfsdk:"description"`
-	Comments        types.String `tfsdk:"comments"`
-	Tags            types.Set    `tfsdk:"tags"`
-	CustomFields    types.Set    `tfsdk:"custom_fields"`
+	ID              types.String  `tfsdk:"id"`
+	CircuitProvider types.String  `tfsdk:"circuit_provider"`
+	Name            types.String  `tfsdk:"name"`
+	ServiceID       types.String  `tfsdk:"service_id"`
+	Description     types.String  `tfsdk:"description"`
+	Comments        types.String  `tfsdk:"comments"`
+	Tags            types.Set     `tfsdk:"tags"`
+	CustomFields    types.Set     `tfsdk:"custom_fields"`
+	CustomFieldsMap types.Dynamic `tfsdk:"custom_fields_map"`
+	TagsAll         types.Set     `tfsdk:"tags_all"`
+	CustomFieldsAll types.Map     `tfsdk:"custom_fields_all"`
+	Branch          types.String  `tfsdk:"branch"`
 }
 
 // Metadata returns the resource type name.
@@ -106,6 +111,10 @@ func (r *ProviderNetworkResource) Schema(ctx context.Context, req resource.Schem
 	// Add tags and custom fields attributes
 	resp.Schema.Attributes["tags"] = nbschema.TagsSlugAttribute()
 	resp.Schema.Attributes["custom_fields"] = nbschema.CustomFieldsAttribute()
+	resp.Schema.Attributes["custom_fields_map"] = nbschema.CustomFieldsMapAttribute()
+	resp.Schema.Attributes["tags_all"] = nbschema.TagsAllAttribute()
+	resp.Schema.Attributes["custom_fields_all"] = nbschema.CustomFieldsAllAttribute()
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 func (r *ProviderNetworkResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
@@ -127,8 +136,21 @@ func (r *ProviderNetworkResource) Configure(ctx context.Context, req resource.Co
 	r.client = client
 }
 
+// ModifyPlan validates the configured custom fields against their definitions in NetBox
+// and plans tags_all and custom_fields_all.
+func (r *ProviderNetworkResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	utils.ValidateCustomFieldsPlan(ctx, r.client, "circuits.providernetwork", req, resp)
+	utils.PlanEffectiveMetadata(ctx, r.client, "circuits.providernetwork", req, resp)
+}
+
 // Create creates the resource and sets the initial Terraform state.
 func (r *ProviderNetworkResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	defer journalCreate(ctx, r.client, "circuits.providernetwork", req, resp)
+
 	var data ProviderNetworkResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -178,6 +200,7 @@ func (r *ProviderNetworkResource) Create(ctx context.Context, req resource.Creat
 
 // Read refreshes the Terraform state with the latest data.
 func (r *ProviderNetworkResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data ProviderNetworkResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -233,6 +256,13 @@ func (r *ProviderNetworkResource) Read(ctx context.Context, req resource.ReadReq
 
 // Update updates the resource and sets the updated Terraform state.
 func (r *ProviderNetworkResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	ctx = withPriorTags(ctx, req.State)
+	defer journalUpdate(ctx, r.client, "circuits.providernetwork", req, resp)
+
 	var state, data ProviderNetworkResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -293,6 +323,10 @@ func (r *ProviderNetworkResource) Update(ctx context.Context, req resource.Updat
 
 // Delete deletes the resource and removes the Terraform state.
 func (r *ProviderNetworkResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data ProviderNetworkResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -366,7 +400,9 @@ func (r *ProviderNetworkResource) ImportState(ctx context.Context, req resource.
 			provider := pn.GetProvider()
 			data.CircuitProvider = types.StringValue(fmt.Sprintf("%d", provider.GetId()))
 		}
-		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, pn.HasTags(), pn.GetTags(), data.Tags)
+		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, r.client, pn.HasTags(), pn.GetTags(), data.Tags)
+		data.TagsAll = utils.TagsAllFromAPI(ctx, pn.GetTags())
+		data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, pn.GetCustomFields(), &resp.Diagnostics)
 		if parsed.HasCustomFields {
 			if len(parsed.CustomFields) == 0 {
 				data.CustomFields = types.SetValueMust(utils.GetCustomFieldsAttributeType().ElemType, []attr.Value{})
@@ -388,6 +424,7 @@ func (r *ProviderNetworkResource) ImportState(ctx context.Context, req resource.
 		}
 		if parsed.HasCustomFields {
 			data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, pn.GetCustomFields(), &resp.Diagnostics)
+			data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, pn.GetCustomFields(), &resp.Diagnostics)
 		} else {
 			data.CustomFields = types.SetNull(utils.GetCustomFieldsAttributeType().ElemType)
 		}
@@ -462,6 +499,10 @@ func (r *ProviderNetworkResource) buildProviderNetworkRequest(ctx context.Contex
 		stateCustomFields = state.CustomFields
 	}
 	utils.ApplyCustomFieldsWithMerge(ctx, pnRequest, data.CustomFields, stateCustomFields, &diags)
+	utils.ApplyCustomFieldsMap(ctx, r.client, pnRequest, data.CustomFieldsMap, &diags)
+	utils.ApplyProviderTags(ctx, r.client, pnRequest, &diags)
+	utils.ApplyProviderCustomFields(ctx, r.client, "circuits.providernetwork", pnRequest, &diags)
+	netboxlookup.ResolveCustomFieldReferences(ctx, r.client, pnRequest, &diags)
 
 	if diags.HasError() {
 		return nil, diags
@@ -489,10 +530,13 @@ func (r *ProviderNetworkResource) mapResponseToModel(ctx context.Context, pn *ne
 	data.Comments = utils.StringFromAPI(pn.HasComments(), pn.GetComments, data.Comments)
 
 	// Filter tags to owned (slug list format)
-	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, pn.HasTags(), pn.GetTags(), data.Tags)
+	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, pn.HasTags(), pn.GetTags(), data.Tags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, pn.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, pn.GetCustomFields(), diags)
 
 	// Populate custom fields
 	if pn.HasCustomFields() {
 		data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, pn.GetCustomFields(), diags)
+		data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, pn.GetCustomFields(), diags)
 	}
 }
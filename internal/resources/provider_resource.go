@@ -9,6 +9,7 @@ import (
 	"net/http"
 
 	"github.com/bab3l/go-netbox"
+	"github.com/bab3l/terraform-provider-netbox/internal/netboxlookup"
 	nbschema "github.com/bab3l/terraform-provider-netbox/internal/schema"
 	"github.com/bab3l/terraform-provider-netbox/internal/utils"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
@@ -26,6 +27,7 @@ var (
 	_ resource.ResourceWithConfigure   = &ProviderResource{}
 	_ resource.ResourceWithImportState = &ProviderResource{}
 	_ resource.ResourceWithIdentity    = &ProviderResource{}
+	_ resource.ResourceWithModifyPlan  = &ProviderResource{}
 )
 
 // NewProviderResource returns a new Provider resource (circuit provider, not Terraform provider).
@@ -40,13 +42,17 @@ type ProviderResource struct {
 
 // ProviderResourceModel describes the resource data model.
 type ProviderResourceModel struct {
-	ID           types.String `tfsdk:"id"`
-	Name         types.String `tfsdk:"name"`
-	Slug         types.String `tfsdk:"slug"`
-	Description  types.String `tfsdk:"description"`
-	Comments     types.String `tfsdk:"comments"`
-	Tags         types.Set    `tfsdk:"tags"`
-	CustomFields types.Set    `tfsdk:"custom_fields"`
+	ID              types.String  `tfsdk:"id"`
+	Name            types.String  `tfsdk:"name"`
+	Slug            types.String  `tfsdk:"slug"`
+	Description     types.String  `tfsdk:"description"`
+	Comments        types.String  `tfsdk:"comments"`
+	Tags            types.Set     `tfsdk:"tags"`
+	CustomFields    types.Set     `tfsdk:"custom_fields"`
+	CustomFieldsMap types.Dynamic `tfsdk:"custom_fields_map"`
+	TagsAll         types.Set     `tfsdk:"tags_all"`
+	CustomFieldsAll types.Map     `tfsdk:"custom_fields_all"`
+	Branch          types.String  `tfsdk:"branch"`
 }
 
 // Metadata returns the resource type name.
@@ -71,6 +77,10 @@ func (r *ProviderResource) Schema(ctx context.Context, req resource.SchemaReques
 	// Add tags and custom fields attributes
 	resp.Schema.Attributes["tags"] = nbschema.TagsSlugAttribute()
 	resp.Schema.Attributes["custom_fields"] = nbschema.CustomFieldsAttribute()
+	resp.Schema.Attributes["custom_fields_map"] = nbschema.CustomFieldsMapAttribute()
+	resp.Schema.Attributes["tags_all"] = nbschema.TagsAllAttribute()
+	resp.Schema.Attributes["custom_fields_all"] = nbschema.CustomFieldsAllAttribute()
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 func (r *ProviderResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
@@ -114,16 +124,32 @@ func (r *ProviderResource) mapProviderToState(ctx context.Context, provider *net
 	}
 
 	// Filter tags to owned (slug list format)
-	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, provider.HasTags(), provider.GetTags(), data.Tags)
+	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, provider.HasTags(), provider.GetTags(), data.Tags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, provider.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, provider.GetCustomFields(), diags)
 
 	// Populate custom fields
 	if provider.HasCustomFields() {
 		data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, provider.GetCustomFields(), diags)
+		data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, provider.GetCustomFields(), diags)
 	}
 }
 
+// ModifyPlan validates the configured custom fields against their definitions in NetBox
+// and plans tags_all and custom_fields_all.
+func (r *ProviderResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	utils.ValidateCustomFieldsPlan(ctx, r.client, "circuits.provider", req, resp)
+	utils.PlanEffectiveMetadata(ctx, r.client, "circuits.provider", req, resp)
+}
+
 // Create creates a new provider resource.
 func (r *ProviderResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	defer journalCreate(ctx, r.client, "circuits.provider", req, resp)
+
 	var data ProviderResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -156,6 +182,10 @@ func (r *ProviderResource) Create(ctx context.Context, req resource.CreateReques
 
 	// Handle custom fields
 	utils.ApplyCustomFields(ctx, &providerRequest, data.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, &providerRequest, data.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, &providerRequest, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "circuits.provider", &providerRequest, &resp.Diagnostics)
+	netboxlookup.ResolveCustomFieldReferences(ctx, r.client, &providerRequest, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -192,6 +222,7 @@ func (r *ProviderResource) Create(ctx context.Context, req resource.CreateReques
 
 // Read reads the provider resource.
 func (r *ProviderResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data ProviderResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -241,6 +272,13 @@ func (r *ProviderResource) Read(ctx context.Context, req resource.ReadRequest, r
 
 // Update updates the provider resource.
 func (r *ProviderResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	ctx = withPriorTags(ctx, req.State)
+	defer journalUpdate(ctx, r.client, "circuits.provider", req, resp)
+
 	var state, plan ProviderResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -285,6 +323,10 @@ func (r *ProviderResource) Update(ctx context.Context, req resource.UpdateReques
 
 	// Handle custom fields - merge-aware for updates
 	utils.ApplyCustomFieldsWithMerge(ctx, &providerRequest, plan.CustomFields, state.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, &providerRequest, plan.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, &providerRequest, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "circuits.provider", &providerRequest, &resp.Diagnostics)
+	netboxlookup.ResolveCustomFieldReferences(ctx, r.client, &providerRequest, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -321,6 +363,10 @@ func (r *ProviderResource) Update(ctx context.Context, req resource.UpdateReques
 
 // Delete deletes the provider resource.
 func (r *ProviderResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data ProviderResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -387,7 +433,9 @@ func (r *ProviderResource) ImportState(ctx context.Context, req resource.ImportS
 		}
 
 		var data ProviderResourceModel
-		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, provider.HasTags(), provider.GetTags(), data.Tags)
+		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, r.client, provider.HasTags(), provider.GetTags(), data.Tags)
+		data.TagsAll = utils.TagsAllFromAPI(ctx, provider.GetTags())
+		data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, provider.GetCustomFields(), &resp.Diagnostics)
 		if parsed.HasCustomFields {
 			if len(parsed.CustomFields) == 0 {
 				data.CustomFields = types.SetValueMust(utils.GetCustomFieldsAttributeType().ElemType, []attr.Value{})
@@ -409,6 +457,7 @@ func (r *ProviderResource) ImportState(ctx context.Context, req resource.ImportS
 		}
 		if parsed.HasCustomFields {
 			data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, provider.GetCustomFields(), &resp.Diagnostics)
+			data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, provider.GetCustomFields(), &resp.Diagnostics)
 		} else {
 			data.CustomFields = types.SetNull(utils.GetCustomFieldsAttributeType().ElemType)
 		}
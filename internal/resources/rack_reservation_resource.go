@@ -28,6 +28,7 @@ var (
 	_ resource.ResourceWithConfigure   = &RackReservationResource{}
 	_ resource.ResourceWithImportState = &RackReservationResource{}
 	_ resource.ResourceWithIdentity    = &RackReservationResource{}
+	_ resource.ResourceWithModifyPlan  = &RackReservationResource{}
 )
 
 // NewRackReservationResource returns a new resource implementing the rack reservation resource.
@@ -42,15 +43,19 @@ type RackReservationResource struct {
 
 // RackReservationResourceModel describes the resource data model.
 type RackReservationResourceModel struct {
-	ID           types.String `tfsdk:"id"`
-	Rack         types.String `tfsdk:"rack"`
-	Units        types.Set    `tfsdk:"units"`
-	User         types.String `tfsdk:"user"`
-	Tenant       types.String `tfsdk:"tenant"`
-	Description  types.String `tfsdk:"description"`
-	Comments     types.String `tfsdk:"comments"`
-	Tags         types.Set    `tfsdk:"tags"`
-	CustomFields types.Set    `tfsdk:"custom_fields"`
+	ID              types.String  `tfsdk:"id"`
+	Rack            types.String  `tfsdk:"rack"`
+	Units           types.Set     `tfsdk:"units"`
+	User            types.String  `tfsdk:"user"`
+	Tenant          types.String  `tfsdk:"tenant"`
+	Description     types.String  `tfsdk:"description"`
+	Comments        types.String  `tfsdk:"comments"`
+	Tags            types.Set     `tfsdk:"tags"`
+	CustomFields    types.Set     `tfsdk:"custom_fields"`
+	CustomFieldsMap types.Dynamic `tfsdk:"custom_fields_map"`
+	TagsAll         types.Set     `tfsdk:"tags_all"`
+	CustomFieldsAll types.Map     `tfsdk:"custom_fields_all"`
+	Branch          types.String  `tfsdk:"branch"`
 }
 
 // Metadata returns the resource type name.
@@ -98,6 +103,10 @@ func (r *RackReservationResource) Schema(ctx context.Context, req resource.Schem
 	// Add tags and custom fields attributes
 	resp.Schema.Attributes["tags"] = nbschema.TagsSlugAttribute()
 	resp.Schema.Attributes["custom_fields"] = nbschema.CustomFieldsAttribute()
+	resp.Schema.Attributes["custom_fields_map"] = nbschema.CustomFieldsMapAttribute()
+	resp.Schema.Attributes["tags_all"] = nbschema.TagsAllAttribute()
+	resp.Schema.Attributes["custom_fields_all"] = nbschema.CustomFieldsAllAttribute()
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 func (r *RackReservationResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
@@ -121,8 +130,21 @@ func (r *RackReservationResource) Configure(ctx context.Context, req resource.Co
 	r.client = client
 }
 
+// ModifyPlan validates the configured custom fields against their definitions in NetBox
+// and plans tags_all and custom_fields_all.
+func (r *RackReservationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	utils.ValidateCustomFieldsPlan(ctx, r.client, "dcim.rackreservation", req, resp)
+	utils.PlanEffectiveMetadata(ctx, r.client, "dcim.rackreservation", req, resp)
+}
+
 // Create creates the resource.
 func (r *RackReservationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	defer journalCreate(ctx, r.client, "dcim.rackreservation", req, resp)
+
 	var data RackReservationResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -180,6 +202,10 @@ func (r *RackReservationResource) Create(ctx context.Context, req resource.Creat
 		return
 	}
 	utils.ApplyCustomFields(ctx, apiReq, data.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, apiReq, data.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, apiReq, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "dcim.rackreservation", apiReq, &resp.Diagnostics)
+	lookup.ResolveCustomFieldReferences(ctx, r.client, apiReq, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -218,6 +244,7 @@ func (r *RackReservationResource) Create(ctx context.Context, req resource.Creat
 
 // Read reads the resource.
 func (r *RackReservationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data RackReservationResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -266,6 +293,13 @@ func (r *RackReservationResource) Read(ctx context.Context, req resource.ReadReq
 
 // Update updates the resource.
 func (r *RackReservationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	ctx = withPriorTags(ctx, req.State)
+	defer journalUpdate(ctx, r.client, "dcim.rackreservation", req, resp)
+
 	var state, plan RackReservationResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -335,6 +369,10 @@ func (r *RackReservationResource) Update(ctx context.Context, req resource.Updat
 	utils.ApplyComments(apiReq, plan.Comments)
 	utils.ApplyTagsFromSlugs(ctx, r.client, apiReq, plan.Tags, &resp.Diagnostics)
 	utils.ApplyCustomFieldsWithMerge(ctx, apiReq, plan.CustomFields, state.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, apiReq, plan.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, apiReq, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "dcim.rackreservation", apiReq, &resp.Diagnostics)
+	lookup.ResolveCustomFieldReferences(ctx, r.client, apiReq, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -374,6 +412,10 @@ func (r *RackReservationResource) Update(ctx context.Context, req resource.Updat
 
 // Delete deletes the resource.
 func (r *RackReservationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data RackReservationResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -438,7 +480,9 @@ func (r *RackReservationResource) ImportState(ctx context.Context, req resource.
 		}
 
 		var data RackReservationResourceModel
-		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, result.HasTags(), result.GetTags(), data.Tags)
+		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, r.client, result.HasTags(), result.GetTags(), data.Tags)
+		data.TagsAll = utils.TagsAllFromAPI(ctx, result.GetTags())
+		data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, result.GetCustomFields(), &resp.Diagnostics)
 		if parsed.HasCustomFields {
 			if len(parsed.CustomFields) == 0 {
 				data.CustomFields = types.SetValueMust(utils.GetCustomFieldsAttributeType().ElemType, []attr.Value{})
@@ -457,6 +501,7 @@ func (r *RackReservationResource) ImportState(ctx context.Context, req resource.
 		r.mapToState(ctx, result, &data, &resp.Diagnostics)
 		if parsed.HasCustomFields {
 			data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, result.GetCustomFields(), &resp.Diagnostics)
+			data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, result.GetCustomFields(), &resp.Diagnostics)
 		} else {
 			data.CustomFields = types.SetNull(utils.GetCustomFieldsAttributeType().ElemType)
 		}
@@ -524,8 +569,11 @@ func (r *RackReservationResource) mapToState(ctx context.Context, result *netbox
 	}
 
 	// Filter tags to owned (slug list format)
-	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, result.HasTags(), result.GetTags(), data.Tags)
+	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, result.HasTags(), result.GetTags(), data.Tags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, result.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, result.GetCustomFields(), diags)
 
 	// Map custom fields
 	data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, result.GetCustomFields(), diags)
+	data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, result.GetCustomFields(), diags)
 }
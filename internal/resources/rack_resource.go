@@ -15,6 +15,7 @@ import (
 	"github.com/bab3l/terraform-provider-netbox/internal/validators"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -28,6 +29,7 @@ var (
 	_ resource.Resource                = &RackResource{}
 	_ resource.ResourceWithImportState = &RackResource{}
 	_ resource.ResourceWithIdentity    = &RackResource{}
+	_ resource.ResourceWithModifyPlan  = &RackResource{}
 )
 
 func NewRackResource() resource.Resource {
@@ -41,34 +43,38 @@ type RackResource struct {
 
 // RackResourceModel describes the resource data model.
 type RackResourceModel struct {
-	ID            types.String `tfsdk:"id"`
-	Name          types.String `tfsdk:"name"`
-	FacilityID    types.String `tfsdk:"facility_id"`
-	Site          types.String `tfsdk:"site"`
-	Location      types.String `tfsdk:"location"`
-	Tenant        types.String `tfsdk:"tenant"`
-	Status        types.String `tfsdk:"status"`
-	Role          types.String `tfsdk:"role"`
-	Serial        types.String `tfsdk:"serial"`
-	AssetTag      types.String `tfsdk:"asset_tag"`
-	RackType      types.String `tfsdk:"rack_type"`
-	FormFactor    types.String `tfsdk:"form_factor"`
-	Width         types.String `tfsdk:"width"`
-	UHeight       types.String `tfsdk:"u_height"`
-	StartingUnit  types.String `tfsdk:"starting_unit"`
-	Weight        types.String `tfsdk:"weight"`
-	MaxWeight     types.String `tfsdk:"max_weight"`
-	WeightUnit    types.String `tfsdk:"weight_unit"`
-	DescUnits     types.Bool   `tfsdk:"desc_units"`
-	OuterWidth    types.String `tfsdk:"outer_width"`
-	OuterDepth    types.String `tfsdk:"outer_depth"`
-	OuterUnit     types.String `tfsdk:"outer_unit"`
-	MountingDepth types.String `tfsdk:"mounting_depth"`
-	Airflow       types.String `tfsdk:"airflow"`
-	Description   types.String `tfsdk:"description"`
-	Comments      types.String `tfsdk:"comments"`
-	Tags          types.Set    `tfsdk:"tags"`
-	CustomFields  types.Set    `tfsdk:"custom_fields"`
+	ID              types.String  `tfsdk:"id"`
+	Name            types.String  `tfsdk:"name"`
+	FacilityID      types.String  `tfsdk:"facility_id"`
+	Site            types.String  `tfsdk:"site"`
+	Location        types.String  `tfsdk:"location"`
+	Tenant          types.String  `tfsdk:"tenant"`
+	Status          types.String  `tfsdk:"status"`
+	Role            types.String  `tfsdk:"role"`
+	Serial          types.String  `tfsdk:"serial"`
+	AssetTag        types.String  `tfsdk:"asset_tag"`
+	RackType        types.String  `tfsdk:"rack_type"`
+	FormFactor      types.String  `tfsdk:"form_factor"`
+	Width           types.String  `tfsdk:"width"`
+	UHeight         types.String  `tfsdk:"u_height"`
+	StartingUnit    types.String  `tfsdk:"starting_unit"`
+	Weight          types.String  `tfsdk:"weight"`
+	MaxWeight       types.String  `tfsdk:"max_weight"`
+	WeightUnit      types.String  `tfsdk:"weight_unit"`
+	DescUnits       types.Bool    `tfsdk:"desc_units"`
+	OuterWidth      types.String  `tfsdk:"outer_width"`
+	OuterDepth      types.String  `tfsdk:"outer_depth"`
+	OuterUnit       types.String  `tfsdk:"outer_unit"`
+	MountingDepth   types.String  `tfsdk:"mounting_depth"`
+	Airflow         types.String  `tfsdk:"airflow"`
+	Description     types.String  `tfsdk:"description"`
+	Comments        types.String  `tfsdk:"comments"`
+	Tags            types.Set     `tfsdk:"tags"`
+	CustomFields    types.Set     `tfsdk:"custom_fields"`
+	CustomFieldsMap types.Dynamic `tfsdk:"custom_fields_map"`
+	TagsAll         types.Set     `tfsdk:"tags_all"`
+	CustomFieldsAll types.Map     `tfsdk:"custom_fields_all"`
+	Branch          types.String  `tfsdk:"branch"`
 }
 
 func (r *RackResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -215,6 +221,10 @@ func (r *RackResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 	// Add tags and custom fields attributes
 	resp.Schema.Attributes["tags"] = nbschema.TagsSlugAttribute()
 	resp.Schema.Attributes["custom_fields"] = nbschema.CustomFieldsAttribute()
+	resp.Schema.Attributes["custom_fields_map"] = nbschema.CustomFieldsMapAttribute()
+	resp.Schema.Attributes["tags_all"] = nbschema.TagsAllAttribute()
+	resp.Schema.Attributes["custom_fields_all"] = nbschema.CustomFieldsAllAttribute()
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 func (r *RackResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
@@ -425,13 +435,30 @@ func (r *RackResource) buildRackRequest(ctx context.Context, data *RackResourceM
 		return nil
 	}
 	utils.ApplyCustomFields(ctx, &rackRequest, data.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, &rackRequest, data.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, &rackRequest, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "dcim.rack", &rackRequest, &resp.Diagnostics)
+	netboxlookup.ResolveCustomFieldReferences(ctx, r.client, &rackRequest, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return nil
 	}
 	return &rackRequest
 }
 
+// ModifyPlan validates the configured custom fields against their definitions in NetBox
+// and plans tags_all and custom_fields_all.
+func (r *RackResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	utils.ValidateCustomFieldsPlan(ctx, r.client, "dcim.rack", req, resp)
+	utils.PlanEffectiveMetadata(ctx, r.client, "dcim.rack", req, resp)
+}
+
 func (r *RackResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	defer journalCreate(ctx, r.client, "dcim.rack", req, resp)
+
 	var data RackResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -474,7 +501,7 @@ func (r *RackResource) Create(ctx context.Context, req resource.CreateRequest, r
 	}
 
 	// Map response to state
-	mapRackToState(ctx, rack, &data)
+	mapRackToState(ctx, r.client, rack, &data, &resp.Diagnostics)
 	tflog.Debug(ctx, "Created rack", map[string]interface{}{
 		"id":   data.ID.ValueString(),
 		"name": data.Name.ValueString(),
@@ -487,6 +514,7 @@ func (r *RackResource) Create(ctx context.Context, req resource.CreateRequest, r
 }
 
 func (r *RackResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data RackResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -524,11 +552,12 @@ func (r *RackResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	}
 
 	// Map response to state
-	mapRackToState(ctx, rack, &data)
+	mapRackToState(ctx, r.client, rack, &data, &resp.Diagnostics)
 
 	// Map custom fields - only populate fields that are in plan (owned by this resource)
 	if rack.HasCustomFields() {
 		data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, rack.GetCustomFields(), &resp.Diagnostics)
+		data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, rack.GetCustomFields(), &resp.Diagnostics)
 	}
 	utils.SetIdentityCustomFields(ctx, resp.Identity, types.StringValue(data.ID.ValueString()), data.CustomFields, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
@@ -752,6 +781,13 @@ func (r *RackResource) buildRackRequestForUpdate(ctx context.Context, data *Rack
 }
 
 func (r *RackResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	ctx = withPriorTags(ctx, req.State)
+	defer journalUpdate(ctx, r.client, "dcim.rack", req, resp)
+
 	var data RackResourceModel
 	var state RackResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -788,6 +824,10 @@ func (r *RackResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 	utils.ApplyCustomFieldsWithMerge(ctx, rackRequest, data.CustomFields, state.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, rackRequest, data.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, rackRequest, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "dcim.rack", rackRequest, &resp.Diagnostics)
+	netboxlookup.ResolveCustomFieldReferences(ctx, r.client, rackRequest, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -807,7 +847,7 @@ func (r *RackResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	}
 
 	// Map response to state
-	mapRackToState(ctx, rack, &data)
+	mapRackToState(ctx, r.client, rack, &data, &resp.Diagnostics)
 	tflog.Debug(ctx, "Updated rack", map[string]interface{}{
 		"id":   data.ID.ValueString(),
 		"name": data.Name.ValueString(),
@@ -820,6 +860,10 @@ func (r *RackResource) Update(ctx context.Context, req resource.UpdateRequest, r
 }
 
 func (r *RackResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data RackResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -886,7 +930,9 @@ func (r *RackResource) ImportState(ctx context.Context, req resource.ImportState
 		}
 
 		var data RackResourceModel
-		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, rack.HasTags(), rack.GetTags(), data.Tags)
+		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, r.client, rack.HasTags(), rack.GetTags(), data.Tags)
+		data.TagsAll = utils.TagsAllFromAPI(ctx, rack.GetTags())
+		data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, rack.GetCustomFields(), &resp.Diagnostics)
 		if rack.GetTenant().Id != 0 {
 			data.Tenant = types.StringValue(fmt.Sprintf("%d", rack.GetTenant().Id))
 		}
@@ -905,9 +951,10 @@ func (r *RackResource) ImportState(ctx context.Context, req resource.ImportState
 			data.CustomFields = types.SetNull(utils.GetCustomFieldsAttributeType().ElemType)
 		}
 
-		mapRackToState(ctx, rack, &data)
+		mapRackToState(ctx, r.client, rack, &data, &resp.Diagnostics)
 		if parsed.HasCustomFields {
 			data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, rack.GetCustomFields(), &resp.Diagnostics)
+			data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, rack.GetCustomFields(), &resp.Diagnostics)
 		} else {
 			data.CustomFields = types.SetNull(utils.GetCustomFieldsAttributeType().ElemType)
 		}
@@ -936,7 +983,7 @@ func (r *RackResource) ImportState(ctx context.Context, req resource.ImportState
 
 // mapRackToState maps a Netbox Rack to the Terraform state model.
 
-func mapRackToState(ctx context.Context, rack *netbox.Rack, data *RackResourceModel) {
+func mapRackToState(ctx context.Context, client *netbox.APIClient, rack *netbox.Rack, data *RackResourceModel, diags *diag.Diagnostics) {
 	data.ID = types.StringValue(fmt.Sprintf("%d", rack.GetId()))
 	data.Name = types.StringValue(rack.GetName())
 
@@ -1127,7 +1174,9 @@ func mapRackToState(ctx context.Context, rack *netbox.Rack, data *RackResourceMo
 	}
 
 	// Filter tags to owned (slug list format)
-	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, rack.HasTags(), rack.GetTags(), data.Tags)
+	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, client, rack.HasTags(), rack.GetTags(), data.Tags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, rack.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, client, data.CustomFieldsAll, rack.GetCustomFields(), diags)
 
 	// Note: Custom fields are handled separately in Read to preserve type information
 }
@@ -9,6 +9,7 @@ import (
 	"net/http"
 
 	"github.com/bab3l/go-netbox"
+	"github.com/bab3l/terraform-provider-netbox/internal/netboxlookup"
 	nbschema "github.com/bab3l/terraform-provider-netbox/internal/schema"
 	"github.com/bab3l/terraform-provider-netbox/internal/utils"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
@@ -25,6 +26,7 @@ var (
 	_ resource.Resource                = &RackRoleResource{}
 	_ resource.ResourceWithImportState = &RackRoleResource{}
 	_ resource.ResourceWithIdentity    = &RackRoleResource{}
+	_ resource.ResourceWithModifyPlan  = &RackRoleResource{}
 )
 
 func NewRackRoleResource() resource.Resource {
@@ -38,13 +40,17 @@ type RackRoleResource struct {
 
 // RackRoleResourceModel describes the resource data model.
 type RackRoleResourceModel struct {
-	ID           types.String `tfsdk:"id"`
-	Name         types.String `tfsdk:"name"`
-	Slug         types.String `tfsdk:"slug"`
-	Color        types.String `tfsdk:"color"`
-	Description  types.String `tfsdk:"description"`
-	Tags         types.Set    `tfsdk:"tags"`
-	CustomFields types.Set    `tfsdk:"custom_fields"`
+	ID              types.String  `tfsdk:"id"`
+	Name            types.String  `tfsdk:"name"`
+	Slug            types.String  `tfsdk:"slug"`
+	Color           types.String  `tfsdk:"color"`
+	Description     types.String  `tfsdk:"description"`
+	Tags            types.Set     `tfsdk:"tags"`
+	CustomFields    types.Set     `tfsdk:"custom_fields"`
+	CustomFieldsMap types.Dynamic `tfsdk:"custom_fields_map"`
+	TagsAll         types.Set     `tfsdk:"tags_all"`
+	CustomFieldsAll types.Map     `tfsdk:"custom_fields_all"`
+	Branch          types.String  `tfsdk:"branch"`
 }
 
 func (r *RackRoleResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -68,6 +74,10 @@ func (r *RackRoleResource) Schema(ctx context.Context, req resource.SchemaReques
 	// Add tags and custom fields attributes
 	resp.Schema.Attributes["tags"] = nbschema.TagsSlugAttribute()
 	resp.Schema.Attributes["custom_fields"] = nbschema.CustomFieldsAttribute()
+	resp.Schema.Attributes["custom_fields_map"] = nbschema.CustomFieldsMapAttribute()
+	resp.Schema.Attributes["tags_all"] = nbschema.TagsAllAttribute()
+	resp.Schema.Attributes["custom_fields_all"] = nbschema.CustomFieldsAllAttribute()
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 func (r *RackRoleResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
@@ -90,7 +100,20 @@ func (r *RackRoleResource) Configure(ctx context.Context, req resource.Configure
 	r.client = client
 }
 
+// ModifyPlan validates the configured custom fields against their definitions in NetBox
+// and plans tags_all and custom_fields_all.
+func (r *RackRoleResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	utils.ValidateCustomFieldsPlan(ctx, r.client, "dcim.rackrole", req, resp)
+	utils.PlanEffectiveMetadata(ctx, r.client, "dcim.rackrole", req, resp)
+}
+
 func (r *RackRoleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	defer journalCreate(ctx, r.client, "dcim.rackrole", req, resp)
+
 	var data RackRoleResourceModel
 
 	// Read Terraform plan data into the model
@@ -126,6 +149,10 @@ func (r *RackRoleResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 	utils.ApplyCustomFields(ctx, &rackRoleRequest, data.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, &rackRoleRequest, data.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, &rackRoleRequest, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "dcim.rackrole", &rackRoleRequest, &resp.Diagnostics)
+	netboxlookup.ResolveCustomFieldReferences(ctx, r.client, &rackRoleRequest, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -174,6 +201,7 @@ func (r *RackRoleResource) Create(ctx context.Context, req resource.CreateReques
 }
 
 func (r *RackRoleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data RackRoleResourceModel
 
 	// Read Terraform prior state data into the model
@@ -231,6 +259,13 @@ func (r *RackRoleResource) Read(ctx context.Context, req resource.ReadRequest, r
 }
 
 func (r *RackRoleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	ctx = withPriorTags(ctx, req.State)
+	defer journalUpdate(ctx, r.client, "dcim.rackrole", req, resp)
+
 	var state, plan RackRoleResourceModel
 
 	// Read both state and plan
@@ -280,6 +315,10 @@ func (r *RackRoleResource) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 	utils.ApplyCustomFieldsWithMerge(ctx, &rackRoleRequest, plan.CustomFields, state.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, &rackRoleRequest, plan.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, &rackRoleRequest, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "dcim.rackrole", &rackRoleRequest, &resp.Diagnostics)
+	netboxlookup.ResolveCustomFieldReferences(ctx, r.client, &rackRoleRequest, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -313,6 +352,10 @@ func (r *RackRoleResource) Update(ctx context.Context, req resource.UpdateReques
 }
 
 func (r *RackRoleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data RackRoleResourceModel
 
 	// Read Terraform prior state data into the model
@@ -380,7 +423,9 @@ func (r *RackRoleResource) ImportState(ctx context.Context, req resource.ImportS
 		}
 
 		var data RackRoleResourceModel
-		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, rackRole.HasTags(), rackRole.GetTags(), data.Tags)
+		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, r.client, rackRole.HasTags(), rackRole.GetTags(), data.Tags)
+		data.TagsAll = utils.TagsAllFromAPI(ctx, rackRole.GetTags())
+		data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, rackRole.GetCustomFields(), &resp.Diagnostics)
 		if parsed.HasCustomFields {
 			if len(parsed.CustomFields) == 0 {
 				data.CustomFields = types.SetValueMust(utils.GetCustomFieldsAttributeType().ElemType, []attr.Value{})
@@ -402,6 +447,7 @@ func (r *RackRoleResource) ImportState(ctx context.Context, req resource.ImportS
 		}
 		if parsed.HasCustomFields {
 			data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, rackRole.GetCustomFields(), &resp.Diagnostics)
+			data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, rackRole.GetCustomFields(), &resp.Diagnostics)
 		} else {
 			data.CustomFields = types.SetNull(utils.GetCustomFieldsAttributeType().ElemType)
 		}
@@ -451,10 +497,13 @@ func (r *RackRoleResource) mapRackRoleToState(ctx context.Context, rackRole *net
 	}
 
 	// Handle tags (filter to owned, slug list format)
-	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, rackRole.HasTags(), rackRole.GetTags(), data.Tags)
+	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, rackRole.HasTags(), rackRole.GetTags(), data.Tags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, rackRole.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, rackRole.GetCustomFields(), diags)
 
 	// Handle custom fields (filter to owned)
 	data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, rackRole.GetCustomFields(), diags)
+	data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, rackRole.GetCustomFields(), diags)
 	if diags.HasError() {
 		return
 	}
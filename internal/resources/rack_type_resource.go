@@ -27,6 +27,7 @@ var (
 	_ resource.ResourceWithConfigure   = &RackTypeResource{}
 	_ resource.ResourceWithImportState = &RackTypeResource{}
 	_ resource.ResourceWithIdentity    = &RackTypeResource{}
+	_ resource.ResourceWithModifyPlan  = &RackTypeResource{}
 )
 
 // NewRackTypeResource returns a new resource implementing the RackType resource.
@@ -41,26 +42,30 @@ type RackTypeResource struct {
 
 // RackTypeResourceModel describes the resource data model.
 type RackTypeResourceModel struct {
-	ID            types.String  `tfsdk:"id"`
-	Manufacturer  types.String  `tfsdk:"manufacturer"`
-	Model         types.String  `tfsdk:"model"`
-	Slug          types.String  `tfsdk:"slug"`
-	Description   types.String  `tfsdk:"description"`
-	FormFactor    types.String  `tfsdk:"form_factor"`
-	Width         types.Int64   `tfsdk:"width"`
-	UHeight       types.Int64   `tfsdk:"u_height"`
-	StartingUnit  types.Int64   `tfsdk:"starting_unit"`
-	DescUnits     types.Bool    `tfsdk:"desc_units"`
-	OuterWidth    types.Int64   `tfsdk:"outer_width"`
-	OuterDepth    types.Int64   `tfsdk:"outer_depth"`
-	OuterUnit     types.String  `tfsdk:"outer_unit"`
-	Weight        types.Float64 `tfsdk:"weight"`
-	MaxWeight     types.Int64   `tfsdk:"max_weight"`
-	WeightUnit    types.String  `tfsdk:"weight_unit"`
-	MountingDepth types.Int64   `tfsdk:"mounting_depth"`
-	Comments      types.String  `tfsdk:"comments"`
-	Tags          types.Set     `tfsdk:"tags"`
-	CustomFields  types.Set     `tfsdk:"custom_fields"`
+	ID              types.String  `tfsdk:"id"`
+	Manufacturer    types.String  `tfsdk:"manufacturer"`
+	Model           types.String  `tfsdk:"model"`
+	Slug            types.String  `tfsdk:"slug"`
+	Description     types.String  `tfsdk:"description"`
+	FormFactor      types.String  `tfsdk:"form_factor"`
+	Width           types.Int64   `tfsdk:"width"`
+	UHeight         types.Int64   `tfsdk:"u_height"`
+	StartingUnit    types.Int64   `tfsdk:"starting_unit"`
+	DescUnits       types.Bool    `tfsdk:"desc_units"`
+	OuterWidth      types.Int64   `tfsdk:"outer_width"`
+	OuterDepth      types.Int64   `tfsdk:"outer_depth"`
+	OuterUnit       types.String  `tfsdk:"outer_unit"`
+	Weight          types.Float64 `tfsdk:"weight"`
+	MaxWeight       types.Int64   `tfsdk:"max_weight"`
+	WeightUnit      types.String  `tfsdk:"weight_unit"`
+	MountingDepth   types.Int64   `tfsdk:"mounting_depth"`
+	Comments        types.String  `tfsdk:"comments"`
+	Tags            types.Set     `tfsdk:"tags"`
+	CustomFields    types.Set     `tfsdk:"custom_fields"`
+	CustomFieldsMap types.Dynamic `tfsdk:"custom_fields_map"`
+	TagsAll         types.Set     `tfsdk:"tags_all"`
+	CustomFieldsAll types.Map     `tfsdk:"custom_fields_all"`
+	Branch          types.String  `tfsdk:"branch"`
 }
 
 // Metadata returns the resource type name.
@@ -139,6 +144,10 @@ func (r *RackTypeResource) Schema(ctx context.Context, req resource.SchemaReques
 	// Add tags and custom fields attributes
 	resp.Schema.Attributes["tags"] = nbschema.TagsSlugAttribute()
 	resp.Schema.Attributes["custom_fields"] = nbschema.CustomFieldsAttribute()
+	resp.Schema.Attributes["custom_fields_map"] = nbschema.CustomFieldsMapAttribute()
+	resp.Schema.Attributes["tags_all"] = nbschema.TagsAllAttribute()
+	resp.Schema.Attributes["custom_fields_all"] = nbschema.CustomFieldsAllAttribute()
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 func (r *RackTypeResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
@@ -161,8 +170,21 @@ func (r *RackTypeResource) Configure(ctx context.Context, req resource.Configure
 	r.client = client
 }
 
+// ModifyPlan validates the configured custom fields against their definitions in NetBox
+// and plans tags_all and custom_fields_all.
+func (r *RackTypeResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	utils.ValidateCustomFieldsPlan(ctx, r.client, "dcim.racktype", req, resp)
+	utils.PlanEffectiveMetadata(ctx, r.client, "dcim.racktype", req, resp)
+}
+
 // Create creates a new rack type resource.
 func (r *RackTypeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	defer journalCreate(ctx, r.client, "dcim.racktype", req, resp)
+
 	var data RackTypeResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -212,6 +234,7 @@ func (r *RackTypeResource) Create(ctx context.Context, req resource.CreateReques
 
 // Read reads the rack type resource.
 func (r *RackTypeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data RackTypeResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -266,6 +289,13 @@ func (r *RackTypeResource) Read(ctx context.Context, req resource.ReadRequest, r
 
 // Update updates the rack type resource.
 func (r *RackTypeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	ctx = withPriorTags(ctx, req.State)
+	defer journalUpdate(ctx, r.client, "dcim.racktype", req, resp)
+
 	var state, plan RackTypeResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -314,6 +344,7 @@ func (r *RackTypeResource) Update(ctx context.Context, req resource.UpdateReques
 
 	// Filter custom_fields to only those owned by this resource
 	plan.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, planCustomFields, rackType.GetCustomFields(), &resp.Diagnostics)
+	plan.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, plan.CustomFieldsMap, rackType.GetCustomFields(), &resp.Diagnostics)
 	utils.SetIdentityCustomFields(ctx, resp.Identity, types.StringValue(plan.ID.ValueString()), plan.CustomFields, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
@@ -323,6 +354,10 @@ func (r *RackTypeResource) Update(ctx context.Context, req resource.UpdateReques
 
 // Delete deletes the rack type resource.
 func (r *RackTypeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data RackTypeResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -381,7 +416,9 @@ func (r *RackTypeResource) ImportState(ctx context.Context, req resource.ImportS
 			return
 		}
 		var data RackTypeResourceModel
-		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, rackType.HasTags(), rackType.GetTags(), data.Tags)
+		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, r.client, rackType.HasTags(), rackType.GetTags(), data.Tags)
+		data.TagsAll = utils.TagsAllFromAPI(ctx, rackType.GetTags())
+		data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, rackType.GetCustomFields(), &resp.Diagnostics)
 		if parsed.HasCustomFields {
 			if len(parsed.CustomFields) == 0 {
 				data.CustomFields = types.SetValueMust(utils.GetCustomFieldsAttributeType().ElemType, []attr.Value{})
@@ -400,6 +437,7 @@ func (r *RackTypeResource) ImportState(ctx context.Context, req resource.ImportS
 		r.mapResponseToModel(ctx, rackType, &data, &resp.Diagnostics)
 		if parsed.HasCustomFields {
 			data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, rackType.GetCustomFields(), &resp.Diagnostics)
+			data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, rackType.GetCustomFields(), &resp.Diagnostics)
 		} else {
 			data.CustomFields = types.SetNull(utils.GetCustomFieldsAttributeType().ElemType)
 		}
@@ -577,6 +615,10 @@ func (r *RackTypeResource) buildRequest(ctx context.Context, plan, state *RackTy
 		return nil, diags
 	}
 	utils.ApplyCustomFieldsWithMerge(ctx, rackTypeRequest, plan.CustomFields, state.CustomFields, &diags)
+	utils.ApplyCustomFieldsMap(ctx, r.client, rackTypeRequest, plan.CustomFieldsMap, &diags)
+	utils.ApplyProviderTags(ctx, r.client, rackTypeRequest, &diags)
+	utils.ApplyProviderCustomFields(ctx, r.client, "dcim.racktype", rackTypeRequest, &diags)
+	netboxlookup.ResolveCustomFieldReferences(ctx, r.client, rackTypeRequest, &diags)
 	return rackTypeRequest, diags
 }
 
@@ -688,10 +730,13 @@ func (r *RackTypeResource) mapResponseToModel(ctx context.Context, rackType *net
 	}
 
 	// Handle tags (filter to owned, slug list format)
-	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, rackType.HasTags(), rackType.GetTags(), data.Tags)
+	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, rackType.HasTags(), rackType.GetTags(), data.Tags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, rackType.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, rackType.GetCustomFields(), diags)
 
 	// Handle custom fields
-	data.CustomFields = utils.PopulateCustomFieldsFromAPI(ctx, rackType.HasCustomFields(), rackType.GetCustomFields(), data.CustomFields, diags)
+	data.CustomFields = utils.PopulateCustomFieldsFromAPI(ctx, r.client, rackType.HasCustomFields(), rackType.GetCustomFields(), data.CustomFields, diags)
+	data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, rackType.GetCustomFields(), diags)
 	if diags.HasError() {
 		return
 	}
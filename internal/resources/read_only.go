@@ -0,0 +1,24 @@
+package resources
+
+import (
+	"fmt"
+
+	"github.com/bab3l/go-netbox"
+	"github.com/bab3l/terraform-provider-netbox/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// readOnlyDenied reports whether the provider of client is read-only, adding
+// an error to diags if so. Create, Update and Delete call it first, so that
+// they fail before sending any request; the provider's transport rejects
+// writes regardless.
+func readOnlyDenied(client *netbox.APIClient, action string, diags *diag.Diagnostics) bool {
+	if !utils.ProviderSettingsFor(client).ReadOnly {
+		return false
+	}
+	diags.AddError(
+		"Provider is read-only",
+		fmt.Sprintf("The object cannot be %s because the provider is read-only. Unset the provider's read_only attribute and the NETBOX_READ_ONLY environment variable to change NetBox.", action),
+	)
+	return true
+}
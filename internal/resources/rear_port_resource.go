@@ -31,6 +31,7 @@ var (
 	_ resource.ResourceWithConfigure   = &RearPortResource{}
 	_ resource.ResourceWithImportState = &RearPortResource{}
 	_ resource.ResourceWithIdentity    = &RearPortResource{}
+	_ resource.ResourceWithModifyPlan  = &RearPortResource{}
 )
 
 // NewRearPortResource returns a new resource implementing the rear port resource.
@@ -45,17 +46,21 @@ type RearPortResource struct {
 
 // RearPortResourceModel describes the resource data model.
 type RearPortResourceModel struct {
-	ID            types.String `tfsdk:"id"`
-	Device        types.String `tfsdk:"device"`
-	Name          types.String `tfsdk:"name"`
-	Label         types.String `tfsdk:"label"`
-	Type          types.String `tfsdk:"type"`
-	Color         types.String `tfsdk:"color"`
-	Positions     types.Int32  `tfsdk:"positions"`
-	Description   types.String `tfsdk:"description"`
-	MarkConnected types.Bool   `tfsdk:"mark_connected"`
-	Tags          types.Set    `tfsdk:"tags"`
-	CustomFields  types.Set    `tfsdk:"custom_fields"`
+	ID              types.String  `tfsdk:"id"`
+	Device          types.String  `tfsdk:"device"`
+	Name            types.String  `tfsdk:"name"`
+	Label           types.String  `tfsdk:"label"`
+	Type            types.String  `tfsdk:"type"`
+	Color           types.String  `tfsdk:"color"`
+	Positions       types.Int32   `tfsdk:"positions"`
+	Description     types.String  `tfsdk:"description"`
+	MarkConnected   types.Bool    `tfsdk:"mark_connected"`
+	Tags            types.Set     `tfsdk:"tags"`
+	CustomFields    types.Set     `tfsdk:"custom_fields"`
+	CustomFieldsMap types.Dynamic `tfsdk:"custom_fields_map"`
+	TagsAll         types.Set     `tfsdk:"tags_all"`
+	CustomFieldsAll types.Map     `tfsdk:"custom_fields_all"`
+	Branch          types.String  `tfsdk:"branch"`
 }
 
 // Metadata returns the resource type name.
@@ -116,6 +121,10 @@ func (r *RearPortResource) Schema(ctx context.Context, req resource.SchemaReques
 	// Add common metadata attributes (tags, custom_fields)
 	resp.Schema.Attributes["tags"] = nbschema.TagsSlugAttribute()
 	resp.Schema.Attributes["custom_fields"] = nbschema.CustomFieldsAttribute()
+	resp.Schema.Attributes["custom_fields_map"] = nbschema.CustomFieldsMapAttribute()
+	resp.Schema.Attributes["tags_all"] = nbschema.TagsAllAttribute()
+	resp.Schema.Attributes["custom_fields_all"] = nbschema.CustomFieldsAllAttribute()
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 func (r *RearPortResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
@@ -138,8 +147,21 @@ func (r *RearPortResource) Configure(ctx context.Context, req resource.Configure
 	r.client = client
 }
 
+// ModifyPlan validates the configured custom fields against their definitions in NetBox
+// and plans tags_all and custom_fields_all.
+func (r *RearPortResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	utils.ValidateCustomFieldsPlan(ctx, r.client, "dcim.rearport", req, resp)
+	utils.PlanEffectiveMetadata(ctx, r.client, "dcim.rearport", req, resp)
+}
+
 // Create creates the resource.
 func (r *RearPortResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	defer journalCreate(ctx, r.client, "dcim.rearport", req, resp)
+
 	var data RearPortResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -174,6 +196,10 @@ func (r *RearPortResource) Create(ctx context.Context, req resource.CreateReques
 	utils.ApplyDescription(apiReq, data.Description)
 	utils.ApplyTagsFromSlugs(ctx, r.client, apiReq, data.Tags, &resp.Diagnostics)
 	utils.ApplyCustomFields(ctx, apiReq, data.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, apiReq, data.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, apiReq, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "dcim.rearport", apiReq, &resp.Diagnostics)
+	lookup.ResolveCustomFieldReferences(ctx, r.client, apiReq, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -211,6 +237,7 @@ func (r *RearPortResource) Create(ctx context.Context, req resource.CreateReques
 
 // Read retrieves the resource.
 func (r *RearPortResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data RearPortResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -263,6 +290,13 @@ func (r *RearPortResource) Read(ctx context.Context, req resource.ReadRequest, r
 
 // Update updates the resource.
 func (r *RearPortResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	ctx = withPriorTags(ctx, req.State)
+	defer journalUpdate(ctx, r.client, "dcim.rearport", req, resp)
+
 	// Read both state and plan for merge-aware custom fields handling
 	var state, plan RearPortResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -312,6 +346,10 @@ func (r *RearPortResource) Update(ctx context.Context, req resource.UpdateReques
 	utils.ApplyDescription(apiReq, plan.Description)
 	utils.ApplyTagsFromSlugs(ctx, r.client, apiReq, plan.Tags, &resp.Diagnostics)
 	utils.ApplyCustomFieldsWithMerge(ctx, apiReq, plan.CustomFields, state.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, apiReq, plan.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, apiReq, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "dcim.rearport", apiReq, &resp.Diagnostics)
+	lookup.ResolveCustomFieldReferences(ctx, r.client, apiReq, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -346,6 +384,10 @@ func (r *RearPortResource) Update(ctx context.Context, req resource.UpdateReques
 
 // Delete removes the resource.
 func (r *RearPortResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data RearPortResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -410,7 +452,9 @@ func (r *RearPortResource) ImportState(ctx context.Context, req resource.ImportS
 		}
 
 		var data RearPortResourceModel
-		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, response.HasTags(), response.GetTags(), data.Tags)
+		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, r.client, response.HasTags(), response.GetTags(), data.Tags)
+		data.TagsAll = utils.TagsAllFromAPI(ctx, response.GetTags())
+		data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, response.GetCustomFields(), &resp.Diagnostics)
 		if parsed.HasCustomFields {
 			if len(parsed.CustomFields) == 0 {
 				data.CustomFields = types.SetValueMust(utils.GetCustomFieldsAttributeType().ElemType, []attr.Value{})
@@ -429,6 +473,7 @@ func (r *RearPortResource) ImportState(ctx context.Context, req resource.ImportS
 		r.mapResponseToModel(ctx, response, &data, &resp.Diagnostics)
 		if parsed.HasCustomFields {
 			data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, response.GetCustomFields(), &resp.Diagnostics)
+			data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, response.GetCustomFields(), &resp.Diagnostics)
 		} else {
 			data.CustomFields = types.SetNull(utils.GetCustomFieldsAttributeType().ElemType)
 		}
@@ -504,8 +549,11 @@ func (r *RearPortResource) mapResponseToModel(ctx context.Context, port *netbox.
 	}
 
 	// Handle tags - filter to owned slugs only
-	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, port.HasTags(), port.GetTags(), data.Tags)
+	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, port.HasTags(), port.GetTags(), data.Tags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, port.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, port.GetCustomFields(), diags)
 
 	// Handle custom fields with filter-to-owned pattern
 	data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, port.GetCustomFields(), diags)
+	data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, port.GetCustomFields(), diags)
 }
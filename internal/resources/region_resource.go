@@ -26,6 +26,7 @@ var (
 	_ resource.Resource                = &RegionResource{}
 	_ resource.ResourceWithImportState = &RegionResource{}
 	_ resource.ResourceWithIdentity    = &RegionResource{}
+	_ resource.ResourceWithModifyPlan  = &RegionResource{}
 )
 
 func NewRegionResource() resource.Resource {
@@ -39,13 +40,17 @@ type RegionResource struct {
 
 // RegionResourceModel describes the resource data model.
 type RegionResourceModel struct {
-	ID           types.String `tfsdk:"id"`
-	Name         types.String `tfsdk:"name"`
-	Slug         types.String `tfsdk:"slug"`
-	Parent       types.String `tfsdk:"parent"`
-	Description  types.String `tfsdk:"description"`
-	Tags         types.Set    `tfsdk:"tags"`
-	CustomFields types.Set    `tfsdk:"custom_fields"`
+	ID              types.String  `tfsdk:"id"`
+	Name            types.String  `tfsdk:"name"`
+	Slug            types.String  `tfsdk:"slug"`
+	Parent          types.String  `tfsdk:"parent"`
+	Description     types.String  `tfsdk:"description"`
+	Tags            types.Set     `tfsdk:"tags"`
+	CustomFields    types.Set     `tfsdk:"custom_fields"`
+	CustomFieldsMap types.Dynamic `tfsdk:"custom_fields_map"`
+	TagsAll         types.Set     `tfsdk:"tags_all"`
+	CustomFieldsAll types.Map     `tfsdk:"custom_fields_all"`
+	Branch          types.String  `tfsdk:"branch"`
 }
 
 func (r *RegionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -69,6 +74,10 @@ func (r *RegionResource) Schema(ctx context.Context, req resource.SchemaRequest,
 	// Add common metadata attributes (tags, custom_fields)
 	resp.Schema.Attributes["tags"] = nbschema.TagsSlugAttribute()
 	resp.Schema.Attributes["custom_fields"] = nbschema.CustomFieldsAttribute()
+	resp.Schema.Attributes["custom_fields_map"] = nbschema.CustomFieldsMapAttribute()
+	resp.Schema.Attributes["tags_all"] = nbschema.TagsAllAttribute()
+	resp.Schema.Attributes["custom_fields_all"] = nbschema.CustomFieldsAllAttribute()
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 func (r *RegionResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
@@ -90,7 +99,20 @@ func (r *RegionResource) Configure(ctx context.Context, req resource.ConfigureRe
 	r.client = client
 }
 
+// ModifyPlan validates the configured custom fields against their definitions in NetBox
+// and plans tags_all and custom_fields_all.
+func (r *RegionResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	utils.ValidateCustomFieldsPlan(ctx, r.client, "dcim.region", req, resp)
+	utils.PlanEffectiveMetadata(ctx, r.client, "dcim.region", req, resp)
+}
+
 func (r *RegionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	defer journalCreate(ctx, r.client, "dcim.region", req, resp)
+
 	var data RegionResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -111,6 +133,10 @@ func (r *RegionResource) Create(ctx context.Context, req resource.CreateRequest,
 	utils.ApplyDescription(&regionRequest, data.Description)
 	utils.ApplyTagsFromSlugs(ctx, r.client, &regionRequest, data.Tags, &resp.Diagnostics)
 	utils.ApplyCustomFields(ctx, &regionRequest, data.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, &regionRequest, data.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, &regionRequest, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "dcim.region", &regionRequest, &resp.Diagnostics)
+	netboxlookup.ResolveCustomFieldReferences(ctx, r.client, &regionRequest, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -150,6 +176,7 @@ func (r *RegionResource) Create(ctx context.Context, req resource.CreateRequest,
 }
 
 func (r *RegionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data RegionResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -190,6 +217,13 @@ func (r *RegionResource) Read(ctx context.Context, req resource.ReadRequest, res
 }
 
 func (r *RegionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	ctx = withPriorTags(ctx, req.State)
+	defer journalUpdate(ctx, r.client, "dcim.region", req, resp)
+
 	var state, data RegionResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -227,6 +261,10 @@ func (r *RegionResource) Update(ctx context.Context, req resource.UpdateRequest,
 
 	// Apply custom fields with merge logic (preserves unmanaged fields from state)
 	utils.ApplyCustomFieldsWithMerge(ctx, &regionRequest, data.CustomFields, state.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, &regionRequest, data.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, &regionRequest, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "dcim.region", &regionRequest, &resp.Diagnostics)
+	netboxlookup.ResolveCustomFieldReferences(ctx, r.client, &regionRequest, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -268,6 +306,10 @@ func (r *RegionResource) Update(ctx context.Context, req resource.UpdateRequest,
 }
 
 func (r *RegionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data RegionResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -323,7 +365,9 @@ func (r *RegionResource) ImportState(ctx context.Context, req resource.ImportSta
 			parent := region.GetParent()
 			data.Parent = types.StringValue(fmt.Sprintf("%d", parent.GetId()))
 		}
-		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, region.HasTags(), region.GetTags(), data.Tags)
+		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, r.client, region.HasTags(), region.GetTags(), data.Tags)
+		data.TagsAll = utils.TagsAllFromAPI(ctx, region.GetTags())
+		data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, region.GetCustomFields(), &resp.Diagnostics)
 		if parsed.HasCustomFields {
 			if len(parsed.CustomFields) == 0 {
 				data.CustomFields = types.SetValueMust(utils.GetCustomFieldsAttributeType().ElemType, []attr.Value{})
@@ -345,6 +389,7 @@ func (r *RegionResource) ImportState(ctx context.Context, req resource.ImportSta
 		}
 		if parsed.HasCustomFields {
 			data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, region.GetCustomFields(), &resp.Diagnostics)
+			data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, region.GetCustomFields(), &resp.Diagnostics)
 		} else {
 			data.CustomFields = types.SetNull(utils.GetCustomFieldsAttributeType().ElemType)
 		}
@@ -391,10 +436,13 @@ func (r *RegionResource) mapRegionToState(ctx context.Context, region *netbox.Re
 	data.Description = utils.StringFromAPI(region.HasDescription(), region.GetDescription, data.Description)
 
 	// Handle tags - filter to owned slugs only
-	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, region.HasTags(), region.GetTags(), data.Tags)
+	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, region.HasTags(), region.GetTags(), data.Tags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, region.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, region.GetCustomFields(), diags)
 
 	// Handle custom fields - use filtered-to-owned for partial management
 	if region.HasCustomFields() {
 		data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, region.GetCustomFields(), diags)
+		data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, region.GetCustomFields(), diags)
 	}
 }
@@ -9,6 +9,7 @@ import (
 	"net/http"
 
 	"github.com/bab3l/go-netbox"
+	"github.com/bab3l/terraform-provider-netbox/internal/netboxlookup"
 	nbschema "github.com/bab3l/terraform-provider-netbox/internal/schema"
 	"github.com/bab3l/terraform-provider-netbox/internal/utils"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
@@ -29,6 +30,7 @@ var (
 	_ resource.ResourceWithConfigure   = &RIRResource{}
 	_ resource.ResourceWithImportState = &RIRResource{}
 	_ resource.ResourceWithIdentity    = &RIRResource{}
+	_ resource.ResourceWithModifyPlan  = &RIRResource{}
 )
 
 // NewRIRResource returns a new RIR resource.
@@ -43,13 +45,17 @@ type RIRResource struct {
 
 // RIRResourceModel describes the resource data model.
 type RIRResourceModel struct {
-	ID           types.String `tfsdk:"id"`
-	Name         types.String `tfsdk:"name"`
-	Slug         types.String `tfsdk:"slug"`
-	IsPrivate    types.Bool   `tfsdk:"is_private"`
-	Description  types.String `tfsdk:"description"`
-	Tags         types.Set    `tfsdk:"tags"`
-	CustomFields types.Set    `tfsdk:"custom_fields"`
+	ID              types.String  `tfsdk:"id"`
+	Name            types.String  `tfsdk:"name"`
+	Slug            types.String  `tfsdk:"slug"`
+	IsPrivate       types.Bool    `tfsdk:"is_private"`
+	Description     types.String  `tfsdk:"description"`
+	Tags            types.Set     `tfsdk:"tags"`
+	CustomFields    types.Set     `tfsdk:"custom_fields"`
+	CustomFieldsMap types.Dynamic `tfsdk:"custom_fields_map"`
+	TagsAll         types.Set     `tfsdk:"tags_all"`
+	CustomFieldsAll types.Map     `tfsdk:"custom_fields_all"`
+	Branch          types.String  `tfsdk:"branch"`
 }
 
 // Metadata returns the resource type name.
@@ -86,6 +92,10 @@ func (r *RIRResource) Schema(ctx context.Context, req resource.SchemaRequest, re
 	// Add common metadata attributes (tags, custom_fields)
 	resp.Schema.Attributes["tags"] = nbschema.TagsSlugAttribute()
 	resp.Schema.Attributes["custom_fields"] = nbschema.CustomFieldsAttribute()
+	resp.Schema.Attributes["custom_fields_map"] = nbschema.CustomFieldsMapAttribute()
+	resp.Schema.Attributes["tags_all"] = nbschema.TagsAllAttribute()
+	resp.Schema.Attributes["custom_fields_all"] = nbschema.CustomFieldsAllAttribute()
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 func (r *RIRResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
@@ -108,8 +118,21 @@ func (r *RIRResource) Configure(ctx context.Context, req resource.ConfigureReque
 	r.client = client
 }
 
+// ModifyPlan validates the configured custom fields against their definitions in NetBox
+// and plans tags_all and custom_fields_all.
+func (r *RIRResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	utils.ValidateCustomFieldsPlan(ctx, r.client, "ipam.rir", req, resp)
+	utils.PlanEffectiveMetadata(ctx, r.client, "ipam.rir", req, resp)
+}
+
 // Create creates the resource and sets the initial Terraform state.
 func (r *RIRResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	defer journalCreate(ctx, r.client, "ipam.rir", req, resp)
+
 	var data RIRResourceModel
 
 	// Read Terraform plan data into the model
@@ -163,6 +186,7 @@ func (r *RIRResource) Create(ctx context.Context, req resource.CreateRequest, re
 
 // Read refreshes the Terraform state with the latest data.
 func (r *RIRResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data RIRResourceModel
 
 	// Read Terraform prior state data into the model
@@ -230,6 +254,13 @@ func (r *RIRResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *RIRResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	ctx = withPriorTags(ctx, req.State)
+	defer journalUpdate(ctx, r.client, "ipam.rir", req, resp)
+
 	var data RIRResourceModel
 
 	// Read Terraform plan data into the model
@@ -300,6 +331,10 @@ func (r *RIRResource) Update(ctx context.Context, req resource.UpdateRequest, re
 
 // Delete deletes the resource and removes the Terraform state on success.
 func (r *RIRResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data RIRResourceModel
 
 	// Read Terraform prior state data into the model
@@ -370,7 +405,9 @@ func (r *RIRResource) ImportState(ctx context.Context, req resource.ImportStateR
 		}
 
 		var data RIRResourceModel
-		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, len(rir.Tags) > 0, rir.Tags, data.Tags)
+		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, r.client, len(rir.Tags) > 0, rir.Tags, data.Tags)
+		data.TagsAll = utils.TagsAllFromAPI(ctx, rir.Tags)
+		data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, rir.CustomFields, &resp.Diagnostics)
 		if parsed.HasCustomFields {
 			if len(parsed.CustomFields) == 0 {
 				data.CustomFields = types.SetValueMust(utils.GetCustomFieldsAttributeType().ElemType, []attr.Value{})
@@ -392,6 +429,7 @@ func (r *RIRResource) ImportState(ctx context.Context, req resource.ImportStateR
 		}
 		if parsed.HasCustomFields {
 			data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, rir.CustomFields, &resp.Diagnostics)
+			data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, rir.CustomFields, &resp.Diagnostics)
 		} else {
 			data.CustomFields = types.SetNull(utils.GetCustomFieldsAttributeType().ElemType)
 		}
@@ -440,9 +478,17 @@ func (r *RIRResource) setOptionalFields(ctx context.Context, rirRequest *netbox.
 	if state != nil {
 		// Update: use merge-aware helper
 		utils.ApplyCustomFieldsWithMerge(ctx, rirRequest, data.CustomFields, state.CustomFields, diags)
+		utils.ApplyCustomFieldsMap(ctx, r.client, rirRequest, data.CustomFieldsMap, diags)
+		utils.ApplyProviderTags(ctx, r.client, rirRequest, diags)
+		utils.ApplyProviderCustomFields(ctx, r.client, "ipam.rir", rirRequest, diags)
+		netboxlookup.ResolveCustomFieldReferences(ctx, r.client, rirRequest, diags)
 	} else {
 		// Create: apply custom fields directly
 		utils.ApplyCustomFields(ctx, rirRequest, data.CustomFields, diags)
+		utils.ApplyCustomFieldsMap(ctx, r.client, rirRequest, data.CustomFieldsMap, diags)
+		utils.ApplyProviderTags(ctx, r.client, rirRequest, diags)
+		utils.ApplyProviderCustomFields(ctx, r.client, "ipam.rir", rirRequest, diags)
+		netboxlookup.ResolveCustomFieldReferences(ctx, r.client, rirRequest, diags)
 	}
 	if diags.HasError() {
 		return
@@ -470,7 +516,10 @@ func (r *RIRResource) mapRIRToState(ctx context.Context, rir *netbox.RIR, data *
 	}
 
 	// Tags - filter to owned slugs only
-	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, len(rir.Tags) > 0, rir.Tags, data.Tags)
+	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, len(rir.Tags) > 0, rir.Tags, data.Tags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, rir.Tags)
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, rir.CustomFields, diags)
 	// Custom Fields - filter to owned fields only
 	data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, rir.CustomFields, diags)
+	data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, rir.CustomFields, diags)
 }
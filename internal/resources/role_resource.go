@@ -9,6 +9,7 @@ import (
 	"net/http"
 
 	"github.com/bab3l/go-netbox"
+	"github.com/bab3l/terraform-provider-netbox/internal/netboxlookup"
 	nbschema "github.com/bab3l/terraform-provider-netbox/internal/schema"
 	"github.com/bab3l/terraform-provider-netbox/internal/utils"
 	"github.com/bab3l/terraform-provider-netbox/internal/validators"
@@ -32,6 +33,7 @@ var (
 	_ resource.ResourceWithConfigure   = &RoleResource{}
 	_ resource.ResourceWithImportState = &RoleResource{}
 	_ resource.ResourceWithIdentity    = &RoleResource{}
+	_ resource.ResourceWithModifyPlan  = &RoleResource{}
 )
 
 // NewRoleResource returns a new Role resource.
@@ -46,13 +48,17 @@ type RoleResource struct {
 
 // RoleResourceModel describes the resource data model.
 type RoleResourceModel struct {
-	ID           types.String `tfsdk:"id"`
-	Name         types.String `tfsdk:"name"`
-	Slug         types.String `tfsdk:"slug"`
-	Weight       types.Int64  `tfsdk:"weight"`
-	Description  types.String `tfsdk:"description"`
-	Tags         types.Set    `tfsdk:"tags"`
-	CustomFields types.Set    `tfsdk:"custom_fields"`
+	ID              types.String  `tfsdk:"id"`
+	Name            types.String  `tfsdk:"name"`
+	Slug            types.String  `tfsdk:"slug"`
+	Weight          types.Int64   `tfsdk:"weight"`
+	Description     types.String  `tfsdk:"description"`
+	Tags            types.Set     `tfsdk:"tags"`
+	CustomFields    types.Set     `tfsdk:"custom_fields"`
+	CustomFieldsMap types.Dynamic `tfsdk:"custom_fields_map"`
+	TagsAll         types.Set     `tfsdk:"tags_all"`
+	CustomFieldsAll types.Map     `tfsdk:"custom_fields_all"`
+	Branch          types.String  `tfsdk:"branch"`
 }
 
 // Metadata returns the resource type name.
@@ -102,6 +108,10 @@ func (r *RoleResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 	// Add common metadata attributes (tags, custom_fields)
 	resp.Schema.Attributes["tags"] = nbschema.TagsSlugAttribute()
 	resp.Schema.Attributes["custom_fields"] = nbschema.CustomFieldsAttribute()
+	resp.Schema.Attributes["custom_fields_map"] = nbschema.CustomFieldsMapAttribute()
+	resp.Schema.Attributes["tags_all"] = nbschema.TagsAllAttribute()
+	resp.Schema.Attributes["custom_fields_all"] = nbschema.CustomFieldsAllAttribute()
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 func (r *RoleResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
@@ -124,8 +134,21 @@ func (r *RoleResource) Configure(ctx context.Context, req resource.ConfigureRequ
 	r.client = client
 }
 
+// ModifyPlan validates the configured custom fields against their definitions in NetBox
+// and plans tags_all and custom_fields_all.
+func (r *RoleResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	utils.ValidateCustomFieldsPlan(ctx, r.client, "ipam.role", req, resp)
+	utils.PlanEffectiveMetadata(ctx, r.client, "ipam.role", req, resp)
+}
+
 // Create creates the resource and sets the initial Terraform state.
 func (r *RoleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	defer journalCreate(ctx, r.client, "ipam.role", req, resp)
+
 	var data RoleResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -175,6 +198,7 @@ func (r *RoleResource) Create(ctx context.Context, req resource.CreateRequest, r
 
 // Read refreshes the Terraform state with the latest data.
 func (r *RoleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data RoleResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -230,6 +254,13 @@ func (r *RoleResource) Read(ctx context.Context, req resource.ReadRequest, resp
 
 // Update updates the resource and sets the updated Terraform state.
 func (r *RoleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	ctx = withPriorTags(ctx, req.State)
+	defer journalUpdate(ctx, r.client, "ipam.role", req, resp)
+
 	var state, plan RoleResourceModel
 
 	// Read current state
@@ -307,6 +338,10 @@ func (r *RoleResource) Update(ctx context.Context, req resource.UpdateRequest, r
 
 // Delete deletes the resource and removes the Terraform state.
 func (r *RoleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data RoleResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -374,7 +409,9 @@ func (r *RoleResource) ImportState(ctx context.Context, req resource.ImportState
 			return
 		}
 		var data RoleResourceModel
-		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, role.HasTags(), role.GetTags(), data.Tags)
+		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, r.client, role.HasTags(), role.GetTags(), data.Tags)
+		data.TagsAll = utils.TagsAllFromAPI(ctx, role.GetTags())
+		data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, role.GetCustomFields(), &resp.Diagnostics)
 		if parsed.HasCustomFields {
 			if len(parsed.CustomFields) == 0 {
 				data.CustomFields = types.SetValueMust(utils.GetCustomFieldsAttributeType().ElemType, []attr.Value{})
@@ -396,6 +433,7 @@ func (r *RoleResource) ImportState(ctx context.Context, req resource.ImportState
 		}
 		if parsed.HasCustomFields {
 			data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, role.GetCustomFields(), &resp.Diagnostics)
+			data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, role.GetCustomFields(), &resp.Diagnostics)
 		} else {
 			data.CustomFields = types.SetNull(utils.GetCustomFieldsAttributeType().ElemType)
 		}
@@ -454,6 +492,10 @@ func (r *RoleResource) buildRoleRequest(ctx context.Context, data *RoleResourceM
 		stateCustomFields = state.CustomFields
 	}
 	utils.ApplyCustomFieldsWithMerge(ctx, roleRequest, data.CustomFields, stateCustomFields, &diags)
+	utils.ApplyCustomFieldsMap(ctx, r.client, roleRequest, data.CustomFieldsMap, &diags)
+	utils.ApplyProviderTags(ctx, r.client, roleRequest, &diags)
+	utils.ApplyProviderCustomFields(ctx, r.client, "ipam.role", roleRequest, &diags)
+	netboxlookup.ResolveCustomFieldReferences(ctx, r.client, roleRequest, &diags)
 
 	if diags.HasError() {
 		return nil, diags
@@ -483,8 +525,11 @@ func (r *RoleResource) mapResponseToModel(ctx context.Context, role *netbox.Role
 	}
 
 	// Handle tags - filter to owned slugs only
-	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, role.HasTags(), role.GetTags(), data.Tags)
+	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, role.HasTags(), role.GetTags(), data.Tags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, role.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, role.GetCustomFields(), diags)
 
 	// Handle custom fields
 	data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, role.GetCustomFields(), diags)
+	data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, role.GetCustomFields(), diags)
 }
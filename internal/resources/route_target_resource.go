@@ -29,6 +29,7 @@ var (
 	_ resource.ResourceWithConfigure   = &RouteTargetResource{}
 	_ resource.ResourceWithImportState = &RouteTargetResource{}
 	_ resource.ResourceWithIdentity    = &RouteTargetResource{}
+	_ resource.ResourceWithModifyPlan  = &RouteTargetResource{}
 )
 
 // NewRouteTargetResource returns a new RouteTarget resource.
@@ -43,13 +44,17 @@ type RouteTargetResource struct {
 
 // RouteTargetResourceModel describes the resource data model.
 type RouteTargetResourceModel struct {
-	ID           types.String `tfsdk:"id"`
-	Name         types.String `tfsdk:"name"`
-	Tenant       types.String `tfsdk:"tenant"`
-	Description  types.String `tfsdk:"description"`
-	Comments     types.String `tfsdk:"comments"`
-	Tags         types.Set    `tfsdk:"tags"`
-	CustomFields types.Set    `tfsdk:"custom_fields"`
+	ID              types.String  `tfsdk:"id"`
+	Name            types.String  `tfsdk:"name"`
+	Tenant          types.String  `tfsdk:"tenant"`
+	Description     types.String  `tfsdk:"description"`
+	Comments        types.String  `tfsdk:"comments"`
+	Tags            types.Set     `tfsdk:"tags"`
+	CustomFields    types.Set     `tfsdk:"custom_fields"`
+	CustomFieldsMap types.Dynamic `tfsdk:"custom_fields_map"`
+	TagsAll         types.Set     `tfsdk:"tags_all"`
+	CustomFieldsAll types.Map     `tfsdk:"custom_fields_all"`
+	Branch          types.String  `tfsdk:"branch"`
 }
 
 // Metadata returns the resource type name.
@@ -83,6 +88,10 @@ func (r *RouteTargetResource) Schema(ctx context.Context, req resource.SchemaReq
 	// Add metadata attributes (slug list tags, custom_fields)
 	resp.Schema.Attributes["tags"] = nbschema.TagsSlugAttribute()
 	resp.Schema.Attributes["custom_fields"] = nbschema.CustomFieldsAttribute()
+	resp.Schema.Attributes["custom_fields_map"] = nbschema.CustomFieldsMapAttribute()
+	resp.Schema.Attributes["tags_all"] = nbschema.TagsAllAttribute()
+	resp.Schema.Attributes["custom_fields_all"] = nbschema.CustomFieldsAllAttribute()
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 func (r *RouteTargetResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
@@ -105,8 +114,21 @@ func (r *RouteTargetResource) Configure(ctx context.Context, req resource.Config
 	r.client = client
 }
 
+// ModifyPlan validates the configured custom fields against their definitions in NetBox
+// and plans tags_all and custom_fields_all.
+func (r *RouteTargetResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	utils.ValidateCustomFieldsPlan(ctx, r.client, "ipam.routetarget", req, resp)
+	utils.PlanEffectiveMetadata(ctx, r.client, "ipam.routetarget", req, resp)
+}
+
 // Create creates the resource and sets the initial Terraform state.
 func (r *RouteTargetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	defer journalCreate(ctx, r.client, "ipam.routetarget", req, resp)
+
 	var data RouteTargetResourceModel
 
 	// Read Terraform plan data into the model
@@ -158,6 +180,7 @@ func (r *RouteTargetResource) Create(ctx context.Context, req resource.CreateReq
 
 // Read refreshes the Terraform state with the latest data.
 func (r *RouteTargetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data RouteTargetResourceModel
 
 	// Read Terraform prior state data into the model
@@ -224,6 +247,13 @@ func (r *RouteTargetResource) Read(ctx context.Context, req resource.ReadRequest
 
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *RouteTargetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	ctx = withPriorTags(ctx, req.State)
+	defer journalUpdate(ctx, r.client, "ipam.routetarget", req, resp)
+
 	var data RouteTargetResourceModel
 
 	// Read Terraform plan data into the model
@@ -293,6 +323,10 @@ func (r *RouteTargetResource) Update(ctx context.Context, req resource.UpdateReq
 
 // Delete deletes the resource and removes the Terraform state on success.
 func (r *RouteTargetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data RouteTargetResourceModel
 
 	// Read Terraform prior state data into the model
@@ -359,7 +393,9 @@ func (r *RouteTargetResource) ImportState(ctx context.Context, req resource.Impo
 		}
 
 		var data RouteTargetResourceModel
-		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, rt.HasTags(), rt.GetTags(), data.Tags)
+		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, r.client, rt.HasTags(), rt.GetTags(), data.Tags)
+		data.TagsAll = utils.TagsAllFromAPI(ctx, rt.GetTags())
+		data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, rt.GetCustomFields(), &resp.Diagnostics)
 		if parsed.HasCustomFields {
 			if len(parsed.CustomFields) == 0 {
 				data.CustomFields = types.SetValueMust(utils.GetCustomFieldsAttributeType().ElemType, []attr.Value{})
@@ -378,6 +414,7 @@ func (r *RouteTargetResource) ImportState(ctx context.Context, req resource.Impo
 		r.mapRouteTargetToState(ctx, rt, &data, &resp.Diagnostics)
 		if parsed.HasCustomFields {
 			data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, rt.CustomFields, &resp.Diagnostics)
+			data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, rt.CustomFields, &resp.Diagnostics)
 		} else {
 			data.CustomFields = types.SetNull(utils.GetCustomFieldsAttributeType().ElemType)
 		}
@@ -440,9 +477,17 @@ func (r *RouteTargetResource) setOptionalFields(ctx context.Context, rtRequest *
 	if state != nil {
 		// Update: use merge-aware helper
 		utils.ApplyCustomFieldsWithMerge(ctx, rtRequest, data.CustomFields, state.CustomFields, diags)
+		utils.ApplyCustomFieldsMap(ctx, r.client, rtRequest, data.CustomFieldsMap, diags)
+		utils.ApplyProviderTags(ctx, r.client, rtRequest, diags)
+		utils.ApplyProviderCustomFields(ctx, r.client, "ipam.routetarget", rtRequest, diags)
+		netboxlookup.ResolveCustomFieldReferences(ctx, r.client, rtRequest, diags)
 	} else {
 		// Create: apply custom fields directly
 		utils.ApplyCustomFields(ctx, rtRequest, data.CustomFields, diags)
+		utils.ApplyCustomFieldsMap(ctx, r.client, rtRequest, data.CustomFieldsMap, diags)
+		utils.ApplyProviderTags(ctx, r.client, rtRequest, diags)
+		utils.ApplyProviderCustomFields(ctx, r.client, "ipam.routetarget", rtRequest, diags)
+		netboxlookup.ResolveCustomFieldReferences(ctx, r.client, rtRequest, diags)
 	}
 	if diags.HasError() {
 		return
@@ -476,6 +521,9 @@ func (r *RouteTargetResource) mapRouteTargetToState(ctx context.Context, rt *net
 	}
 
 	// Extract tag slugs from API response and filter to owned tags
-	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, rt.HasTags(), rt.GetTags(), data.Tags)
+	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, rt.HasTags(), rt.GetTags(), data.Tags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, rt.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, rt.GetCustomFields(), diags)
 	data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, rt.CustomFields, diags)
+	data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, rt.CustomFields, diags)
 }
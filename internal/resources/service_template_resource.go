@@ -9,6 +9,7 @@ import (
 	"net/http"
 
 	"github.com/bab3l/go-netbox"
+	"github.com/bab3l/terraform-provider-netbox/internal/netboxlookup"
 	nbschema "github.com/bab3l/terraform-provider-netbox/internal/schema"
 	"github.com/bab3l/terraform-provider-netbox/internal/utils"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
@@ -30,6 +31,7 @@ var (
 	_ resource.ResourceWithConfigure   = &ServiceTemplateResource{}
 	_ resource.ResourceWithImportState = &ServiceTemplateResource{}
 	_ resource.ResourceWithIdentity    = &ServiceTemplateResource{}
+	_ resource.ResourceWithModifyPlan  = &ServiceTemplateResource{}
 )
 
 // NewServiceTemplateResource returns a new resource implementing the service template resource.
@@ -44,14 +46,18 @@ type ServiceTemplateResource struct {
 
 // ServiceTemplateResourceModel describes the resource data model.
 type ServiceTemplateResourceModel struct {
-	ID           types.String `tfsdk:"id"`
-	Name         types.String `tfsdk:"name"`
-	Protocol     types.String `tfsdk:"protocol"`
-	Ports        types.List   `tfsdk:"ports"`
-	Description  types.String `tfsdk:"description"`
-	Comments     types.String `tfsdk:"comments"`
-	Tags         types.Set    `tfsdk:"tags"`
-	CustomFields types.Set    `tfsdk:"custom_fields"`
+	ID              types.String  `tfsdk:"id"`
+	Name            types.String  `tfsdk:"name"`
+	Protocol        types.String  `tfsdk:"protocol"`
+	Ports           types.List    `tfsdk:"ports"`
+	Description     types.String  `tfsdk:"description"`
+	Comments        types.String  `tfsdk:"comments"`
+	Tags            types.Set     `tfsdk:"tags"`
+	CustomFields    types.Set     `tfsdk:"custom_fields"`
+	CustomFieldsMap types.Dynamic `tfsdk:"custom_fields_map"`
+	TagsAll         types.Set     `tfsdk:"tags_all"`
+	CustomFieldsAll types.Map     `tfsdk:"custom_fields_all"`
+	Branch          types.String  `tfsdk:"branch"`
 }
 
 // Metadata returns the resource type name.
@@ -97,6 +103,10 @@ func (r *ServiceTemplateResource) Schema(ctx context.Context, req resource.Schem
 	// Add metadata attributes (slug list tags, custom_fields)
 	resp.Schema.Attributes["tags"] = nbschema.TagsSlugAttribute()
 	resp.Schema.Attributes["custom_fields"] = nbschema.CustomFieldsAttribute()
+	resp.Schema.Attributes["custom_fields_map"] = nbschema.CustomFieldsMapAttribute()
+	resp.Schema.Attributes["tags_all"] = nbschema.TagsAllAttribute()
+	resp.Schema.Attributes["custom_fields_all"] = nbschema.CustomFieldsAllAttribute()
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 func (r *ServiceTemplateResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
@@ -119,8 +129,21 @@ func (r *ServiceTemplateResource) Configure(ctx context.Context, req resource.Co
 	r.client = client
 }
 
+// ModifyPlan validates the configured custom fields against their definitions in NetBox
+// and plans tags_all and custom_fields_all.
+func (r *ServiceTemplateResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	utils.ValidateCustomFieldsPlan(ctx, r.client, "ipam.servicetemplate", req, resp)
+	utils.PlanEffectiveMetadata(ctx, r.client, "ipam.servicetemplate", req, resp)
+}
+
 // Create creates a new service template.
 func (r *ServiceTemplateResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	defer journalCreate(ctx, r.client, "ipam.servicetemplate", req, resp)
+
 	var data ServiceTemplateResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -168,6 +191,10 @@ func (r *ServiceTemplateResource) Create(ctx context.Context, req resource.Creat
 
 	// Apply custom fields
 	utils.ApplyCustomFields(ctx, serviceTemplateRequest, data.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, serviceTemplateRequest, data.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, serviceTemplateRequest, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "ipam.servicetemplate", serviceTemplateRequest, &resp.Diagnostics)
+	netboxlookup.ResolveCustomFieldReferences(ctx, r.client, serviceTemplateRequest, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -195,8 +222,11 @@ func (r *ServiceTemplateResource) Create(ctx context.Context, req resource.Creat
 	r.mapResponseToState(ctx, serviceTemplate, &data, &resp.Diagnostics)
 
 	// Populate tags and custom fields filtered to owned fields only
-	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, serviceTemplate.HasTags(), serviceTemplate.GetTags(), planTags)
+	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, serviceTemplate.HasTags(), serviceTemplate.GetTags(), planTags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, serviceTemplate.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, serviceTemplate.GetCustomFields(), &resp.Diagnostics)
 	data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, planCustomFields, serviceTemplate.GetCustomFields(), &resp.Diagnostics)
+	data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, serviceTemplate.GetCustomFields(), &resp.Diagnostics)
 	utils.SetIdentityCustomFields(ctx, resp.Identity, types.StringValue(data.ID.ValueString()), data.CustomFields, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
@@ -209,6 +239,7 @@ func (r *ServiceTemplateResource) Create(ctx context.Context, req resource.Creat
 
 // Read reads the service template.
 func (r *ServiceTemplateResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data ServiceTemplateResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -256,8 +287,11 @@ func (r *ServiceTemplateResource) Read(ctx context.Context, req resource.ReadReq
 	}
 
 	// Override with filter-to-owned pattern: only show fields that were in original state
-	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, serviceTemplate.HasTags(), serviceTemplate.GetTags(), stateTags)
+	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, serviceTemplate.HasTags(), serviceTemplate.GetTags(), stateTags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, serviceTemplate.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, serviceTemplate.GetCustomFields(), &resp.Diagnostics)
 	data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, stateCustomFields, serviceTemplate.GetCustomFields(), &resp.Diagnostics)
+	data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, serviceTemplate.GetCustomFields(), &resp.Diagnostics)
 
 	utils.SetIdentityCustomFields(ctx, resp.Identity, types.StringValue(data.ID.ValueString()), data.CustomFields, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
@@ -268,6 +302,13 @@ func (r *ServiceTemplateResource) Read(ctx context.Context, req resource.ReadReq
 
 // Update updates the service template.
 func (r *ServiceTemplateResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	ctx = withPriorTags(ctx, req.State)
+	defer journalUpdate(ctx, r.client, "ipam.servicetemplate", req, resp)
+
 	var state, plan ServiceTemplateResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -332,6 +373,10 @@ func (r *ServiceTemplateResource) Update(ctx context.Context, req resource.Updat
 
 	// Apply custom fields with merge logic (preserves unmanaged fields from state)
 	utils.ApplyCustomFieldsWithMerge(ctx, serviceTemplateRequest, plan.CustomFields, state.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, serviceTemplateRequest, plan.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, serviceTemplateRequest, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "ipam.servicetemplate", serviceTemplateRequest, &resp.Diagnostics)
+	netboxlookup.ResolveCustomFieldReferences(ctx, r.client, serviceTemplateRequest, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -362,8 +407,11 @@ func (r *ServiceTemplateResource) Update(ctx context.Context, req resource.Updat
 	}
 
 	// Override with filter-to-owned pattern
-	plan.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, serviceTemplate.HasTags(), serviceTemplate.GetTags(), planTags)
+	plan.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, serviceTemplate.HasTags(), serviceTemplate.GetTags(), planTags)
+	plan.TagsAll = utils.TagsAllFromAPI(ctx, serviceTemplate.GetTags())
+	plan.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, plan.CustomFieldsAll, serviceTemplate.GetCustomFields(), &resp.Diagnostics)
 	plan.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, planCustomFields, serviceTemplate.GetCustomFields(), &resp.Diagnostics)
+	plan.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, plan.CustomFieldsMap, serviceTemplate.GetCustomFields(), &resp.Diagnostics)
 
 	utils.SetIdentityCustomFields(ctx, resp.Identity, types.StringValue(plan.ID.ValueString()), plan.CustomFields, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
@@ -377,6 +425,10 @@ func (r *ServiceTemplateResource) Update(ctx context.Context, req resource.Updat
 
 // Delete deletes the service template.
 func (r *ServiceTemplateResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data ServiceTemplateResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -443,7 +495,9 @@ func (r *ServiceTemplateResource) ImportState(ctx context.Context, req resource.
 		}
 
 		var data ServiceTemplateResourceModel
-		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, serviceTemplate.HasTags(), serviceTemplate.GetTags(), data.Tags)
+		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, r.client, serviceTemplate.HasTags(), serviceTemplate.GetTags(), data.Tags)
+		data.TagsAll = utils.TagsAllFromAPI(ctx, serviceTemplate.GetTags())
+		data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, serviceTemplate.GetCustomFields(), &resp.Diagnostics)
 		if parsed.HasCustomFields {
 			if len(parsed.CustomFields) == 0 {
 				data.CustomFields = types.SetValueMust(utils.GetCustomFieldsAttributeType().ElemType, []attr.Value{})
@@ -465,6 +519,7 @@ func (r *ServiceTemplateResource) ImportState(ctx context.Context, req resource.
 		}
 		if parsed.HasCustomFields {
 			data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, serviceTemplate.GetCustomFields(), &resp.Diagnostics)
+			data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, serviceTemplate.GetCustomFields(), &resp.Diagnostics)
 		} else {
 			data.CustomFields = types.SetNull(utils.GetCustomFieldsAttributeType().ElemType)
 		}
@@ -532,11 +587,14 @@ func (r *ServiceTemplateResource) mapResponseToState(ctx context.Context, servic
 	}
 
 	// Handle tags using consolidated helper
-	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, serviceTemplate.HasTags(), serviceTemplate.GetTags(), data.Tags)
+	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, serviceTemplate.HasTags(), serviceTemplate.GetTags(), data.Tags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, serviceTemplate.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, serviceTemplate.GetCustomFields(), diags)
 	if diags.HasError() {
 		return
 	}
 
 	// Handle custom fields using consolidated helper
-	data.CustomFields = utils.PopulateCustomFieldsFromAPI(ctx, serviceTemplate.HasCustomFields(), serviceTemplate.GetCustomFields(), data.CustomFields, diags)
+	data.CustomFields = utils.PopulateCustomFieldsFromAPI(ctx, r.client, serviceTemplate.HasCustomFields(), serviceTemplate.GetCustomFields(), data.CustomFields, diags)
+	data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, serviceTemplate.GetCustomFields(), diags)
 }
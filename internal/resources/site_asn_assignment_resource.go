@@ -41,9 +41,10 @@ type SiteASNAssignmentResource struct {
 
 // SiteASNAssignmentResourceModel describes the resource data model.
 type SiteASNAssignmentResourceModel struct {
-	ID   types.String `tfsdk:"id"`
-	Site types.String `tfsdk:"site"`
-	ASN  types.String `tfsdk:"asn"`
+	ID     types.String `tfsdk:"id"`
+	Site   types.String `tfsdk:"site"`
+	ASN    types.String `tfsdk:"asn"`
+	Branch types.String `tfsdk:"branch"`
 }
 
 func (r *SiteASNAssignmentResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -80,6 +81,7 @@ func (r *SiteASNAssignmentResource) Schema(ctx context.Context, req resource.Sch
 			},
 		},
 	}
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 func (r *SiteASNAssignmentResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -98,6 +100,10 @@ func (r *SiteASNAssignmentResource) Configure(ctx context.Context, req resource.
 }
 
 func (r *SiteASNAssignmentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
 	var data SiteASNAssignmentResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -139,6 +145,7 @@ func (r *SiteASNAssignmentResource) Create(ctx context.Context, req resource.Cre
 }
 
 func (r *SiteASNAssignmentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data SiteASNAssignmentResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -173,6 +180,10 @@ func (r *SiteASNAssignmentResource) Read(ctx context.Context, req resource.ReadR
 }
 
 func (r *SiteASNAssignmentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
 	var state, plan SiteASNAssignmentResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -216,6 +227,10 @@ func (r *SiteASNAssignmentResource) Update(ctx context.Context, req resource.Upd
 }
 
 func (r *SiteASNAssignmentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data SiteASNAssignmentResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -27,6 +27,7 @@ var (
 	_ resource.Resource                = &SiteGroupResource{}
 	_ resource.ResourceWithImportState = &SiteGroupResource{}
 	_ resource.ResourceWithIdentity    = &SiteGroupResource{}
+	_ resource.ResourceWithModifyPlan  = &SiteGroupResource{}
 )
 
 func NewSiteGroupResource() resource.Resource {
@@ -40,13 +41,17 @@ type SiteGroupResource struct {
 
 // SiteGroupResourceModel describes the resource data model.
 type SiteGroupResourceModel struct {
-	ID           types.String `tfsdk:"id"`
-	Name         types.String `tfsdk:"name"`
-	Slug         types.String `tfsdk:"slug"`
-	Parent       types.String `tfsdk:"parent"`
-	Description  types.String `tfsdk:"description"`
-	Tags         types.Set    `tfsdk:"tags"`
-	CustomFields types.Set    `tfsdk:"custom_fields"`
+	ID              types.String  `tfsdk:"id"`
+	Name            types.String  `tfsdk:"name"`
+	Slug            types.String  `tfsdk:"slug"`
+	Parent          types.String  `tfsdk:"parent"`
+	Description     types.String  `tfsdk:"description"`
+	Tags            types.Set     `tfsdk:"tags"`
+	CustomFields    types.Set     `tfsdk:"custom_fields"`
+	CustomFieldsMap types.Dynamic `tfsdk:"custom_fields_map"`
+	TagsAll         types.Set     `tfsdk:"tags_all"`
+	CustomFieldsAll types.Map     `tfsdk:"custom_fields_all"`
+	Branch          types.String  `tfsdk:"branch"`
 }
 
 func (r *SiteGroupResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -70,6 +75,10 @@ func (r *SiteGroupResource) Schema(ctx context.Context, req resource.SchemaReque
 	// Add metadata attributes (slug list tags, custom_fields)
 	resp.Schema.Attributes["tags"] = nbschema.TagsSlugAttribute()
 	resp.Schema.Attributes["custom_fields"] = nbschema.CustomFieldsAttribute()
+	resp.Schema.Attributes["custom_fields_map"] = nbschema.CustomFieldsMapAttribute()
+	resp.Schema.Attributes["tags_all"] = nbschema.TagsAllAttribute()
+	resp.Schema.Attributes["custom_fields_all"] = nbschema.CustomFieldsAllAttribute()
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 func (r *SiteGroupResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
@@ -92,7 +101,20 @@ func (r *SiteGroupResource) Configure(ctx context.Context, req resource.Configur
 	r.client = client
 }
 
+// ModifyPlan validates the configured custom fields against their definitions in NetBox
+// and plans tags_all and custom_fields_all.
+func (r *SiteGroupResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	utils.ValidateCustomFieldsPlan(ctx, r.client, "dcim.sitegroup", req, resp)
+	utils.PlanEffectiveMetadata(ctx, r.client, "dcim.sitegroup", req, resp)
+}
+
 func (r *SiteGroupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	defer journalCreate(ctx, r.client, "dcim.sitegroup", req, resp)
+
 	var data SiteGroupResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -113,6 +135,10 @@ func (r *SiteGroupResource) Create(ctx context.Context, req resource.CreateReque
 	utils.ApplyDescription(&siteGroupRequest, data.Description)
 	utils.ApplyTagsFromSlugs(ctx, r.client, &siteGroupRequest, data.Tags, &resp.Diagnostics)
 	utils.ApplyCustomFields(ctx, &siteGroupRequest, data.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, &siteGroupRequest, data.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, &siteGroupRequest, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "dcim.sitegroup", &siteGroupRequest, &resp.Diagnostics)
+	netboxlookup.ResolveCustomFieldReferences(ctx, r.client, &siteGroupRequest, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -171,6 +197,7 @@ func (r *SiteGroupResource) Create(ctx context.Context, req resource.CreateReque
 }
 
 func (r *SiteGroupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data SiteGroupResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -206,6 +233,13 @@ func (r *SiteGroupResource) Read(ctx context.Context, req resource.ReadRequest,
 }
 
 func (r *SiteGroupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	ctx = withPriorTags(ctx, req.State)
+	defer journalUpdate(ctx, r.client, "dcim.sitegroup", req, resp)
+
 	var state, plan SiteGroupResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -234,6 +268,10 @@ func (r *SiteGroupResource) Update(ctx context.Context, req resource.UpdateReque
 		utils.ApplyTagsFromSlugs(ctx, r.client, &siteGroupRequest, state.Tags, &resp.Diagnostics)
 	}
 	utils.ApplyCustomFieldsWithMerge(ctx, &siteGroupRequest, plan.CustomFields, state.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, &siteGroupRequest, plan.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, &siteGroupRequest, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "dcim.sitegroup", &siteGroupRequest, &resp.Diagnostics)
+	netboxlookup.ResolveCustomFieldReferences(ctx, r.client, &siteGroupRequest, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -270,6 +308,10 @@ func (r *SiteGroupResource) Update(ctx context.Context, req resource.UpdateReque
 }
 
 func (r *SiteGroupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data SiteGroupResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -323,7 +365,9 @@ func (r *SiteGroupResource) ImportState(ctx context.Context, req resource.Import
 			parent := siteGroup.GetParent()
 			data.Parent = types.StringValue(fmt.Sprintf("%d", parent.Id))
 		}
-		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, siteGroup.HasTags(), siteGroup.GetTags(), data.Tags)
+		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, r.client, siteGroup.HasTags(), siteGroup.GetTags(), data.Tags)
+		data.TagsAll = utils.TagsAllFromAPI(ctx, siteGroup.GetTags())
+		data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, siteGroup.GetCustomFields(), &resp.Diagnostics)
 		if parsed.HasCustomFields {
 			if len(parsed.CustomFields) == 0 {
 				data.CustomFields = types.SetValueMust(utils.GetCustomFieldsAttributeType().ElemType, []attr.Value{})
@@ -342,6 +386,7 @@ func (r *SiteGroupResource) ImportState(ctx context.Context, req resource.Import
 		r.mapSiteGroupToState(ctx, siteGroup, &data)
 		if parsed.HasCustomFields {
 			data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, siteGroup.GetCustomFields(), &resp.Diagnostics)
+			data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, siteGroup.GetCustomFields(), &resp.Diagnostics)
 		} else {
 			data.CustomFields = types.SetNull(utils.GetCustomFieldsAttributeType().ElemType)
 		}
@@ -391,8 +436,11 @@ func (r *SiteGroupResource) mapSiteGroupToState(ctx context.Context, siteGroup *
 
 	// Handle tags
 	var diags diag.Diagnostics
-	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, siteGroup.HasTags(), siteGroup.GetTags(), data.Tags)
+	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, siteGroup.HasTags(), siteGroup.GetTags(), data.Tags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, siteGroup.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, siteGroup.GetCustomFields(), &diags)
 
 	// Handle custom fields - preserve state structure
 	data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, siteGroup.GetCustomFields(), &diags)
+	data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, siteGroup.GetCustomFields(), &diags)
 }
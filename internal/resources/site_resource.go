@@ -32,6 +32,7 @@ var (
 	_ resource.Resource                = &SiteResource{}
 	_ resource.ResourceWithImportState = &SiteResource{}
 	_ resource.ResourceWithIdentity    = &SiteResource{}
+	_ resource.ResourceWithModifyPlan  = &SiteResource{}
 )
 
 func NewSiteResource() resource.Resource {
@@ -65,6 +66,10 @@ type SiteResourceModel struct {
 	Comments        types.String  `tfsdk:"comments"`
 	Tags            types.Set     `tfsdk:"tags"`
 	CustomFields    types.Set     `tfsdk:"custom_fields"`
+	CustomFieldsMap types.Dynamic `tfsdk:"custom_fields_map"`
+	TagsAll         types.Set     `tfsdk:"tags_all"`
+	CustomFieldsAll types.Map     `tfsdk:"custom_fields_all"`
+	Branch          types.String  `tfsdk:"branch"`
 }
 
 func (r *SiteResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -139,6 +144,10 @@ func (r *SiteResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 	// Add metadata attributes (slug list tags, custom_fields)
 	resp.Schema.Attributes["tags"] = nbschema.TagsSlugAttribute()
 	resp.Schema.Attributes["custom_fields"] = nbschema.CustomFieldsAttribute()
+	resp.Schema.Attributes["custom_fields_map"] = nbschema.CustomFieldsMapAttribute()
+	resp.Schema.Attributes["tags_all"] = nbschema.TagsAllAttribute()
+	resp.Schema.Attributes["custom_fields_all"] = nbschema.CustomFieldsAllAttribute()
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 func (r *SiteResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
@@ -163,7 +172,20 @@ func (r *SiteResource) Configure(ctx context.Context, req resource.ConfigureRequ
 	r.client = client
 }
 
+// ModifyPlan validates the configured custom fields against their definitions in NetBox
+// and plans tags_all and custom_fields_all.
+func (r *SiteResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	utils.ValidateCustomFieldsPlan(ctx, r.client, "dcim.site", req, resp)
+	utils.PlanEffectiveMetadata(ctx, r.client, "dcim.site", req, resp)
+}
+
 func (r *SiteResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	defer journalCreate(ctx, r.client, "dcim.site", req, resp)
+
 	var data SiteResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -235,6 +257,10 @@ func (r *SiteResource) Create(ctx context.Context, req resource.CreateRequest, r
 
 	// Apply custom fields
 	utils.ApplyCustomFields(ctx, &siteRequest, data.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, &siteRequest, data.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, &siteRequest, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "dcim.site", &siteRequest, &resp.Diagnostics)
+	netboxlookup.ResolveCustomFieldReferences(ctx, r.client, &siteRequest, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -292,6 +318,7 @@ func (r *SiteResource) Create(ctx context.Context, req resource.CreateRequest, r
 }
 
 func (r *SiteResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data SiteResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -337,6 +364,13 @@ func (r *SiteResource) Read(ctx context.Context, req resource.ReadRequest, resp
 }
 
 func (r *SiteResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	ctx = withPriorTags(ctx, req.State)
+	defer journalUpdate(ctx, r.client, "dcim.site", req, resp)
+
 	var state, plan SiteResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -442,6 +476,10 @@ func (r *SiteResource) Update(ctx context.Context, req resource.UpdateRequest, r
 
 	// Apply custom fields with merge logic (preserves unmanaged fields from state)
 	utils.ApplyCustomFieldsWithMerge(ctx, &siteRequest, plan.CustomFields, state.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, &siteRequest, plan.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, &siteRequest, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "dcim.site", &siteRequest, &resp.Diagnostics)
+	netboxlookup.ResolveCustomFieldReferences(ctx, r.client, &siteRequest, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -473,6 +511,10 @@ func (r *SiteResource) Update(ctx context.Context, req resource.UpdateRequest, r
 }
 
 func (r *SiteResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data SiteResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -542,7 +584,9 @@ func (r *SiteResource) ImportState(ctx context.Context, req resource.ImportState
 			tenant := site.GetTenant()
 			data.Tenant = types.StringValue(fmt.Sprintf("%d", tenant.GetId()))
 		}
-		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, site.HasTags(), site.GetTags(), data.Tags)
+		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, r.client, site.HasTags(), site.GetTags(), data.Tags)
+		data.TagsAll = utils.TagsAllFromAPI(ctx, site.GetTags())
+		data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, site.GetCustomFields(), &resp.Diagnostics)
 		if parsed.HasCustomFields {
 			if len(parsed.CustomFields) == 0 {
 				data.CustomFields = types.SetValueMust(utils.GetCustomFieldsAttributeType().ElemType, []attr.Value{})
@@ -564,6 +608,7 @@ func (r *SiteResource) ImportState(ctx context.Context, req resource.ImportState
 		}
 		if parsed.HasCustomFields {
 			data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, site.GetCustomFields(), &resp.Diagnostics)
+			data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, site.GetCustomFields(), &resp.Diagnostics)
 		} else {
 			data.CustomFields = types.SetNull(utils.GetCustomFieldsAttributeType().ElemType)
 		}
@@ -661,10 +706,13 @@ func (r *SiteResource) mapSiteToState(ctx context.Context, site *netbox.Site, da
 	}
 
 	// Handle tags
-	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, site.HasTags(), site.GetTags(), data.Tags)
+	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, site.HasTags(), site.GetTags(), data.Tags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, site.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, site.GetCustomFields(), diags)
 
 	// Handle custom fields - use filtered-to-owned for partial management
 	if site.HasCustomFields() {
 		data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, site.GetCustomFields(), diags)
+		data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, site.GetCustomFields(), diags)
 	}
 }
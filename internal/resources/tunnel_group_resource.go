@@ -9,6 +9,7 @@ import (
 	"net/http"
 
 	"github.com/bab3l/go-netbox"
+	"github.com/bab3l/terraform-provider-netbox/internal/netboxlookup"
 	nbschema "github.com/bab3l/terraform-provider-netbox/internal/schema"
 	"github.com/bab3l/terraform-provider-netbox/internal/utils"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
@@ -25,6 +26,7 @@ var (
 	_ resource.Resource                = &TunnelGroupResource{}
 	_ resource.ResourceWithImportState = &TunnelGroupResource{}
 	_ resource.ResourceWithIdentity    = &TunnelGroupResource{}
+	_ resource.ResourceWithModifyPlan  = &TunnelGroupResource{}
 )
 
 // NewTunnelGroupResource creates a new TunnelGroupResource.
@@ -39,12 +41,16 @@ type TunnelGroupResource struct {
 
 // TunnelGroupResourceModel describes the resource data model.
 type TunnelGroupResourceModel struct {
-	ID           types.String `tfsdk:"id"`
-	Name         types.String `tfsdk:"name"`
-	Slug         types.String `tfsdk:"slug"`
-	Description  types.String `tfsdk:"description"`
-	Tags         types.Set    `tfsdk:"tags"`
-	CustomFields types.Set    `tfsdk:"custom_fields"`
+	ID              types.String  `tfsdk:"id"`
+	Name            types.String  `tfsdk:"name"`
+	Slug            types.String  `tfsdk:"slug"`
+	Description     types.String  `tfsdk:"description"`
+	Tags            types.Set     `tfsdk:"tags"`
+	CustomFields    types.Set     `tfsdk:"custom_fields"`
+	CustomFieldsMap types.Dynamic `tfsdk:"custom_fields_map"`
+	TagsAll         types.Set     `tfsdk:"tags_all"`
+	CustomFieldsAll types.Map     `tfsdk:"custom_fields_all"`
+	Branch          types.String  `tfsdk:"branch"`
 }
 
 // Metadata returns the resource type name.
@@ -69,6 +75,10 @@ func (r *TunnelGroupResource) Schema(ctx context.Context, req resource.SchemaReq
 	// Add metadata attributes (slug list tags, custom_fields)
 	resp.Schema.Attributes["tags"] = nbschema.TagsSlugAttribute()
 	resp.Schema.Attributes["custom_fields"] = nbschema.CustomFieldsAttribute()
+	resp.Schema.Attributes["custom_fields_map"] = nbschema.CustomFieldsMapAttribute()
+	resp.Schema.Attributes["tags_all"] = nbschema.TagsAllAttribute()
+	resp.Schema.Attributes["custom_fields_all"] = nbschema.CustomFieldsAllAttribute()
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 func (r *TunnelGroupResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
@@ -91,8 +101,21 @@ func (r *TunnelGroupResource) Configure(ctx context.Context, req resource.Config
 	r.client = client
 }
 
+// ModifyPlan validates the configured custom fields against their definitions in NetBox
+// and plans tags_all and custom_fields_all.
+func (r *TunnelGroupResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	utils.ValidateCustomFieldsPlan(ctx, r.client, "vpn.tunnelgroup", req, resp)
+	utils.PlanEffectiveMetadata(ctx, r.client, "vpn.tunnelgroup", req, resp)
+}
+
 // Create creates a new tunnel group resource.
 func (r *TunnelGroupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	defer journalCreate(ctx, r.client, "vpn.tunnelgroup", req, resp)
+
 	var data TunnelGroupResourceModel
 
 	// Read Terraform plan data into the model
@@ -118,6 +141,10 @@ func (r *TunnelGroupResource) Create(ctx context.Context, req resource.CreateReq
 		return
 	}
 	utils.ApplyCustomFields(ctx, &tunnelGroupRequest, data.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, &tunnelGroupRequest, data.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, &tunnelGroupRequest, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "vpn.tunnelgroup", &tunnelGroupRequest, &resp.Diagnostics)
+	netboxlookup.ResolveCustomFieldReferences(ctx, r.client, &tunnelGroupRequest, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -166,6 +193,7 @@ func (r *TunnelGroupResource) Create(ctx context.Context, req resource.CreateReq
 
 // Read reads the tunnel group resource.
 func (r *TunnelGroupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data TunnelGroupResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -210,6 +238,13 @@ func (r *TunnelGroupResource) Read(ctx context.Context, req resource.ReadRequest
 
 // Update updates the tunnel group resource.
 func (r *TunnelGroupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	ctx = withPriorTags(ctx, req.State)
+	defer journalUpdate(ctx, r.client, "vpn.tunnelgroup", req, resp)
+
 	var state, data TunnelGroupResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -246,6 +281,10 @@ func (r *TunnelGroupResource) Update(ctx context.Context, req resource.UpdateReq
 	}
 
 	utils.ApplyCustomFieldsWithMerge(ctx, &tunnelGroupRequest, data.CustomFields, state.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, &tunnelGroupRequest, data.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, &tunnelGroupRequest, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "vpn.tunnelgroup", &tunnelGroupRequest, &resp.Diagnostics)
+	netboxlookup.ResolveCustomFieldReferences(ctx, r.client, &tunnelGroupRequest, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -281,6 +320,10 @@ func (r *TunnelGroupResource) Update(ctx context.Context, req resource.UpdateReq
 
 // Delete deletes the tunnel group resource.
 func (r *TunnelGroupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data TunnelGroupResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -361,6 +404,7 @@ func (r *TunnelGroupResource) ImportState(ctx context.Context, req resource.Impo
 
 		if parsed.HasCustomFields {
 			data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, tunnelGroup.CustomFields, &resp.Diagnostics)
+			data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, tunnelGroup.CustomFields, &resp.Diagnostics)
 		} else {
 			data.CustomFields = types.SetNull(utils.GetCustomFieldsAttributeType().ElemType)
 		}
@@ -401,7 +445,9 @@ func (r *TunnelGroupResource) mapTunnelGroupToState(ctx context.Context, tunnelG
 	}
 
 	// Handle tags using consolidated helper
-	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, len(tunnelGroup.Tags) > 0, tunnelGroup.Tags, data.Tags)
+	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, len(tunnelGroup.Tags) > 0, tunnelGroup.Tags, data.Tags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, tunnelGroup.Tags)
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, tunnelGroup.CustomFields, diags)
 	if diags.HasError() {
 		return
 	}
@@ -409,5 +455,6 @@ func (r *TunnelGroupResource) mapTunnelGroupToState(ctx context.Context, tunnelG
 	// Handle custom fields using consolidated helper
 	if tunnelGroup.CustomFields != nil {
 		data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, tunnelGroup.CustomFields, diags)
+		data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, tunnelGroup.CustomFields, diags)
 	}
 }
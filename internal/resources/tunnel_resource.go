@@ -10,6 +10,7 @@ import (
 	"net/http"
 
 	"github.com/bab3l/go-netbox"
+	"github.com/bab3l/terraform-provider-netbox/internal/netboxlookup"
 	nbschema "github.com/bab3l/terraform-provider-netbox/internal/schema"
 	"github.com/bab3l/terraform-provider-netbox/internal/utils"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
@@ -30,6 +31,7 @@ var (
 	_ resource.Resource                = &TunnelResource{}
 	_ resource.ResourceWithImportState = &TunnelResource{}
 	_ resource.ResourceWithIdentity    = &TunnelResource{}
+	_ resource.ResourceWithModifyPlan  = &TunnelResource{}
 )
 
 func NewTunnelResource() resource.Resource {
@@ -43,18 +45,22 @@ type TunnelResource struct {
 
 // TunnelResourceModel describes the resource data model.
 type TunnelResourceModel struct {
-	ID            types.String `tfsdk:"id"`
-	Name          types.String `tfsdk:"name"`
-	Status        types.String `tfsdk:"status"`
-	Group         types.String `tfsdk:"group"`
-	Encapsulation types.String `tfsdk:"encapsulation"`
-	IPSecProfile  types.String `tfsdk:"ipsec_profile"`
-	Tenant        types.String `tfsdk:"tenant"`
-	TunnelID      types.Int64  `tfsdk:"tunnel_id"`
-	Description   types.String `tfsdk:"description"`
-	Comments      types.String `tfsdk:"comments"`
-	Tags          types.Set    `tfsdk:"tags"`
-	CustomFields  types.Set    `tfsdk:"custom_fields"`
+	ID              types.String  `tfsdk:"id"`
+	Name            types.String  `tfsdk:"name"`
+	Status          types.String  `tfsdk:"status"`
+	Group           types.String  `tfsdk:"group"`
+	Encapsulation   types.String  `tfsdk:"encapsulation"`
+	IPSecProfile    types.String  `tfsdk:"ipsec_profile"`
+	Tenant          types.String  `tfsdk:"tenant"`
+	TunnelID        types.Int64   `tfsdk:"tunnel_id"`
+	Description     types.String  `tfsdk:"description"`
+	Comments        types.String  `tfsdk:"comments"`
+	Tags            types.Set     `tfsdk:"tags"`
+	CustomFields    types.Set     `tfsdk:"custom_fields"`
+	CustomFieldsMap types.Dynamic `tfsdk:"custom_fields_map"`
+	TagsAll         types.Set     `tfsdk:"tags_all"`
+	CustomFieldsAll types.Map     `tfsdk:"custom_fields_all"`
+	Branch          types.String  `tfsdk:"branch"`
 }
 
 func (r *TunnelResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -121,6 +127,10 @@ func (r *TunnelResource) Schema(ctx context.Context, req resource.SchemaRequest,
 	// Add metadata attributes (slug list tags, custom_fields)
 	resp.Schema.Attributes["tags"] = nbschema.TagsSlugAttribute()
 	resp.Schema.Attributes["custom_fields"] = nbschema.CustomFieldsAttribute()
+	resp.Schema.Attributes["custom_fields_map"] = nbschema.CustomFieldsMapAttribute()
+	resp.Schema.Attributes["tags_all"] = nbschema.TagsAllAttribute()
+	resp.Schema.Attributes["custom_fields_all"] = nbschema.CustomFieldsAllAttribute()
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 func (r *TunnelResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
@@ -143,7 +153,20 @@ func (r *TunnelResource) Configure(ctx context.Context, req resource.ConfigureRe
 	r.client = client
 }
 
+// ModifyPlan validates the configured custom fields against their definitions in NetBox
+// and plans tags_all and custom_fields_all.
+func (r *TunnelResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	utils.ValidateCustomFieldsPlan(ctx, r.client, "vpn.tunnel", req, resp)
+	utils.PlanEffectiveMetadata(ctx, r.client, "vpn.tunnel", req, resp)
+}
+
 func (r *TunnelResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	defer journalCreate(ctx, r.client, "vpn.tunnel", req, resp)
+
 	var data TunnelResourceModel
 
 	// Read Terraform plan data into the model
@@ -223,6 +246,10 @@ func (r *TunnelResource) Create(ctx context.Context, req resource.CreateRequest,
 
 	// Handle custom fields
 	utils.ApplyCustomFields(ctx, tunnelRequest, data.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, tunnelRequest, data.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, tunnelRequest, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "vpn.tunnel", tunnelRequest, &resp.Diagnostics)
+	netboxlookup.ResolveCustomFieldReferences(ctx, r.client, tunnelRequest, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -276,6 +303,9 @@ func (r *TunnelResource) Create(ctx context.Context, req resource.CreateRequest,
 		data.Tenant = types.StringNull()
 	}
 
+	data.TagsAll = utils.TagsAllFromAPI(ctx, tunnel.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, tunnel.GetCustomFields(), &resp.Diagnostics)
+
 	// Save data into Terraform state
 	utils.SetIdentityCustomFields(ctx, resp.Identity, types.StringValue(data.ID.ValueString()), data.CustomFields, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
@@ -285,6 +315,7 @@ func (r *TunnelResource) Create(ctx context.Context, req resource.CreateRequest,
 }
 
 func (r *TunnelResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data TunnelResourceModel
 
 	// Read Terraform prior state data into the model
@@ -383,7 +414,9 @@ func (r *TunnelResource) Read(ctx context.Context, req resource.ReadRequest, res
 	}
 
 	// Handle tags using consolidated helper
-	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, tunnel.HasTags(), tunnel.GetTags(), data.Tags)
+	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, tunnel.HasTags(), tunnel.GetTags(), data.Tags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, tunnel.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, tunnel.GetCustomFields(), &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -391,6 +424,7 @@ func (r *TunnelResource) Read(ctx context.Context, req resource.ReadRequest, res
 	// Handle custom fields using consolidated helper
 	if tunnel.HasCustomFields() {
 		data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, tunnel.GetCustomFields(), &resp.Diagnostics)
+		data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, tunnel.GetCustomFields(), &resp.Diagnostics)
 	}
 	if resp.Diagnostics.HasError() {
 		return
@@ -406,6 +440,13 @@ func (r *TunnelResource) Read(ctx context.Context, req resource.ReadRequest, res
 }
 
 func (r *TunnelResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	ctx = withPriorTags(ctx, req.State)
+	defer journalUpdate(ctx, r.client, "vpn.tunnel", req, resp)
+
 	var state, data TunnelResourceModel
 
 	// Read Terraform state and plan data into the models
@@ -516,6 +557,10 @@ func (r *TunnelResource) Update(ctx context.Context, req resource.UpdateRequest,
 
 	// Handle custom fields - merge-aware
 	utils.ApplyCustomFieldsWithMerge(ctx, tunnelRequest, data.CustomFields, state.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, tunnelRequest, data.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, tunnelRequest, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "vpn.tunnel", tunnelRequest, &resp.Diagnostics)
+	netboxlookup.ResolveCustomFieldReferences(ctx, r.client, tunnelRequest, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -561,6 +606,9 @@ func (r *TunnelResource) Update(ctx context.Context, req resource.UpdateRequest,
 		data.Tenant = utils.UpdateReferenceAttribute(data.Tenant, tenant.GetName(), tenant.GetSlug(), tenant.GetId())
 	}
 
+	data.TagsAll = utils.TagsAllFromAPI(ctx, tunnel.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, tunnel.GetCustomFields(), &resp.Diagnostics)
+
 	// Save updated data into Terraform state
 	utils.SetIdentityCustomFields(ctx, resp.Identity, types.StringValue(data.ID.ValueString()), data.CustomFields, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
@@ -571,6 +619,10 @@ func (r *TunnelResource) Update(ctx context.Context, req resource.UpdateRequest,
 }
 
 func (r *TunnelResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data TunnelResourceModel
 
 	// Read Terraform prior state data into the model
@@ -702,10 +754,13 @@ func (r *TunnelResource) ImportState(ctx context.Context, req resource.ImportSta
 			data.Comments = types.StringNull()
 		}
 
-		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, tunnel.HasTags(), tunnel.GetTags(), data.Tags)
+		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, r.client, tunnel.HasTags(), tunnel.GetTags(), data.Tags)
+		data.TagsAll = utils.TagsAllFromAPI(ctx, tunnel.GetTags())
+		data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, tunnel.GetCustomFields(), &resp.Diagnostics)
 
 		if parsed.HasCustomFields {
 			data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, tunnel.GetCustomFields(), &resp.Diagnostics)
+			data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, tunnel.GetCustomFields(), &resp.Diagnostics)
 		} else {
 			data.CustomFields = types.SetNull(utils.GetCustomFieldsAttributeType().ElemType)
 		}
@@ -9,6 +9,7 @@ import (
 	"net/http"
 
 	"github.com/bab3l/go-netbox"
+	"github.com/bab3l/terraform-provider-netbox/internal/netboxlookup"
 	nbschema "github.com/bab3l/terraform-provider-netbox/internal/schema"
 	"github.com/bab3l/terraform-provider-netbox/internal/utils"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
@@ -27,6 +28,7 @@ var (
 	_ resource.Resource                = &TunnelTerminationResource{}
 	_ resource.ResourceWithImportState = &TunnelTerminationResource{}
 	_ resource.ResourceWithIdentity    = &TunnelTerminationResource{}
+	_ resource.ResourceWithModifyPlan  = &TunnelTerminationResource{}
 )
 
 func NewTunnelTerminationResource() resource.Resource {
@@ -40,14 +42,18 @@ type TunnelTerminationResource struct {
 
 // TunnelTerminationResourceModel describes the resource data model.
 type TunnelTerminationResourceModel struct {
-	ID              types.String `tfsdk:"id"`
-	Tunnel          types.String `tfsdk:"tunnel"`
-	Role            types.String `tfsdk:"role"`
-	TerminationType types.String `tfsdk:"termination_type"`
-	TerminationID   types.Int64  `tfsdk:"termination_id"`
-	OutsideIP       types.String `tfsdk:"outside_ip"`
-	Tags            types.Set    `tfsdk:"tags"`
-	CustomFields    types.Set    `tfsdk:"custom_fields"`
+	ID              types.String  `tfsdk:"id"`
+	Tunnel          types.String  `tfsdk:"tunnel"`
+	Role            types.String  `tfsdk:"role"`
+	TerminationType types.String  `tfsdk:"termination_type"`
+	TerminationID   types.Int64   `tfsdk:"termination_id"`
+	OutsideIP       types.String  `tfsdk:"outside_ip"`
+	Tags            types.Set     `tfsdk:"tags"`
+	CustomFields    types.Set     `tfsdk:"custom_fields"`
+	CustomFieldsMap types.Dynamic `tfsdk:"custom_fields_map"`
+	TagsAll         types.Set     `tfsdk:"tags_all"`
+	CustomFieldsAll types.Map     `tfsdk:"custom_fields_all"`
+	Branch          types.String  `tfsdk:"branch"`
 }
 
 func (r *TunnelTerminationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -98,6 +104,10 @@ func (r *TunnelTerminationResource) Schema(ctx context.Context, req resource.Sch
 	// Add metadata attributes (slug list tags, custom_fields)
 	resp.Schema.Attributes["tags"] = nbschema.TagsSlugAttribute()
 	resp.Schema.Attributes["custom_fields"] = nbschema.CustomFieldsAttribute()
+	resp.Schema.Attributes["custom_fields_map"] = nbschema.CustomFieldsMapAttribute()
+	resp.Schema.Attributes["tags_all"] = nbschema.TagsAllAttribute()
+	resp.Schema.Attributes["custom_fields_all"] = nbschema.CustomFieldsAllAttribute()
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 func (r *TunnelTerminationResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
@@ -119,7 +129,18 @@ func (r *TunnelTerminationResource) Configure(ctx context.Context, req resource.
 	r.client = client
 }
 
+// ModifyPlan validates the configured custom fields against their definitions in NetBox
+// and plans tags_all and custom_fields_all.
+func (r *TunnelTerminationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	utils.ValidateCustomFieldsPlan(ctx, r.client, "vpn.tunneltermination", req, resp)
+	utils.PlanEffectiveMetadata(ctx, r.client, "vpn.tunneltermination", req, resp)
+}
+
 func (r *TunnelTerminationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
 	var data TunnelTerminationResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -186,6 +207,10 @@ func (r *TunnelTerminationResource) Create(ctx context.Context, req resource.Cre
 		return
 	}
 	utils.ApplyCustomFields(ctx, tunnelTerminationRequest, data.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, tunnelTerminationRequest, data.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, tunnelTerminationRequest, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "vpn.tunneltermination", tunnelTerminationRequest, &resp.Diagnostics)
+	netboxlookup.ResolveCustomFieldReferences(ctx, r.client, tunnelTerminationRequest, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -221,6 +246,7 @@ func (r *TunnelTerminationResource) Create(ctx context.Context, req resource.Cre
 }
 
 func (r *TunnelTerminationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data TunnelTerminationResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -266,6 +292,11 @@ func (r *TunnelTerminationResource) Read(ctx context.Context, req resource.ReadR
 }
 
 func (r *TunnelTerminationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	ctx = withPriorTags(ctx, req.State)
 	var state, data TunnelTerminationResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -343,6 +374,10 @@ func (r *TunnelTerminationResource) Update(ctx context.Context, req resource.Upd
 		utils.ApplyTagsFromSlugs(ctx, r.client, tunnelTerminationRequest, state.Tags, &resp.Diagnostics)
 	}
 	utils.ApplyCustomFieldsWithMerge(ctx, tunnelTerminationRequest, data.CustomFields, state.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, tunnelTerminationRequest, data.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, tunnelTerminationRequest, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "vpn.tunneltermination", tunnelTerminationRequest, &resp.Diagnostics)
+	netboxlookup.ResolveCustomFieldReferences(ctx, r.client, tunnelTerminationRequest, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -372,6 +407,10 @@ func (r *TunnelTerminationResource) Update(ctx context.Context, req resource.Upd
 }
 
 func (r *TunnelTerminationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data TunnelTerminationResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -456,6 +495,7 @@ func (r *TunnelTerminationResource) ImportState(ctx context.Context, req resourc
 
 		if parsed.HasCustomFields {
 			data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, tunnelTermination.GetCustomFields(), &resp.Diagnostics)
+			data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, tunnelTermination.GetCustomFields(), &resp.Diagnostics)
 		} else {
 			data.CustomFields = types.SetNull(utils.GetCustomFieldsAttributeType().ElemType)
 		}
@@ -523,7 +563,9 @@ func (r *TunnelTerminationResource) mapTunnelTerminationToState(ctx context.Cont
 	}
 
 	// Handle tags using consolidated helper
-	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, tunnelTermination.HasTags(), tunnelTermination.GetTags(), data.Tags)
+	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, tunnelTermination.HasTags(), tunnelTermination.GetTags(), data.Tags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, tunnelTermination.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, tunnelTermination.GetCustomFields(), diags)
 	if diags.HasError() {
 		return
 	}
@@ -531,5 +573,6 @@ func (r *TunnelTerminationResource) mapTunnelTerminationToState(ctx context.Cont
 	// Handle custom fields using consolidated helper
 	if tunnelTermination.HasCustomFields() {
 		data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, tunnelTermination.GetCustomFields(), diags)
+		data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, tunnelTermination.GetCustomFields(), diags)
 	}
 }
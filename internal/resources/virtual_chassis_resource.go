@@ -9,6 +9,7 @@ import (
 	"net/http"
 
 	"github.com/bab3l/go-netbox"
+	"github.com/bab3l/terraform-provider-netbox/internal/netboxlookup"
 	nbschema "github.com/bab3l/terraform-provider-netbox/internal/schema"
 	"github.com/bab3l/terraform-provider-netbox/internal/utils"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
@@ -26,6 +27,7 @@ var (
 	_ resource.ResourceWithConfigure   = &VirtualChassisResource{}
 	_ resource.ResourceWithImportState = &VirtualChassisResource{}
 	_ resource.ResourceWithIdentity    = &VirtualChassisResource{}
+	_ resource.ResourceWithModifyPlan  = &VirtualChassisResource{}
 )
 
 // NewVirtualChassisResource returns a new resource implementing the VirtualChassis resource.
@@ -40,15 +42,19 @@ type VirtualChassisResource struct {
 
 // VirtualChassisResourceModel describes the resource data model.
 type VirtualChassisResourceModel struct {
-	ID           types.String `tfsdk:"id"`
-	Name         types.String `tfsdk:"name"`
-	Domain       types.String `tfsdk:"domain"`
-	Master       types.String `tfsdk:"master"`
-	Description  types.String `tfsdk:"description"`
-	Comments     types.String `tfsdk:"comments"`
-	MemberCount  types.Int64  `tfsdk:"member_count"`
-	Tags         types.Set    `tfsdk:"tags"`
-	CustomFields types.Set    `tfsdk:"custom_fields"`
+	ID              types.String  `tfsdk:"id"`
+	Name            types.String  `tfsdk:"name"`
+	Domain          types.String  `tfsdk:"domain"`
+	Master          types.String  `tfsdk:"master"`
+	Description     types.String  `tfsdk:"description"`
+	Comments        types.String  `tfsdk:"comments"`
+	MemberCount     types.Int64   `tfsdk:"member_count"`
+	Tags            types.Set     `tfsdk:"tags"`
+	CustomFields    types.Set     `tfsdk:"custom_fields"`
+	CustomFieldsMap types.Dynamic `tfsdk:"custom_fields_map"`
+	TagsAll         types.Set     `tfsdk:"tags_all"`
+	CustomFieldsAll types.Map     `tfsdk:"custom_fields_all"`
+	Branch          types.String  `tfsdk:"branch"`
 }
 
 // Metadata returns the resource type name.
@@ -68,7 +74,7 @@ func (r *VirtualChassisResource) Schema(ctx context.Context, req resource.Schema
 				Optional:            true,
 			},
 			"master": schema.StringAttribute{
-				MarkdownDescription: "ID of the master device for this virtual chassis.",
+				MarkdownDescription: "ID of the master device for this virtual chassis. NetBox adds the master device to the virtual chassis at position 1 when the virtual chassis is created.",
 				Optional:            true,
 			},
 			"member_count": schema.Int64Attribute{
@@ -84,6 +90,10 @@ func (r *VirtualChassisResource) Schema(ctx context.Context, req resource.Schema
 	// Add common metadata attributes (tags, custom_fields)
 	resp.Schema.Attributes["tags"] = nbschema.TagsSlugAttribute()
 	resp.Schema.Attributes["custom_fields"] = nbschema.CustomFieldsAttribute()
+	resp.Schema.Attributes["custom_fields_map"] = nbschema.CustomFieldsMapAttribute()
+	resp.Schema.Attributes["tags_all"] = nbschema.TagsAllAttribute()
+	resp.Schema.Attributes["custom_fields_all"] = nbschema.CustomFieldsAllAttribute()
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 func (r *VirtualChassisResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
@@ -107,8 +117,21 @@ func (r *VirtualChassisResource) Configure(ctx context.Context, req resource.Con
 	r.client = client
 }
 
+// ModifyPlan validates the configured custom fields against their definitions in NetBox
+// and plans tags_all and custom_fields_all.
+func (r *VirtualChassisResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	utils.ValidateCustomFieldsPlan(ctx, r.client, "dcim.virtualchassis", req, resp)
+	utils.PlanEffectiveMetadata(ctx, r.client, "dcim.virtualchassis", req, resp)
+}
+
 // Create creates a new virtual chassis resource.
 func (r *VirtualChassisResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	defer journalCreate(ctx, r.client, "dcim.virtualchassis", req, resp)
+
 	var data VirtualChassisResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -156,6 +179,7 @@ func (r *VirtualChassisResource) Create(ctx context.Context, req resource.Create
 
 // Read reads the virtual chassis resource.
 func (r *VirtualChassisResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data VirtualChassisResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -204,6 +228,13 @@ func (r *VirtualChassisResource) Read(ctx context.Context, req resource.ReadRequ
 
 // Update updates the virtual chassis resource.
 func (r *VirtualChassisResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	ctx = withPriorTags(ctx, req.State)
+	defer journalUpdate(ctx, r.client, "dcim.virtualchassis", req, resp)
+
 	var state, plan VirtualChassisResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -256,6 +287,10 @@ func (r *VirtualChassisResource) Update(ctx context.Context, req resource.Update
 
 // Delete deletes the virtual chassis resource.
 func (r *VirtualChassisResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data VirtualChassisResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -340,6 +375,7 @@ func (r *VirtualChassisResource) ImportState(ctx context.Context, req resource.I
 
 		if parsed.HasCustomFields {
 			data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, vc.GetCustomFields(), &resp.Diagnostics)
+			data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, vc.GetCustomFields(), &resp.Diagnostics)
 		} else {
 			data.CustomFields = types.SetNull(utils.GetCustomFieldsAttributeType().ElemType)
 		}
@@ -401,6 +437,10 @@ func (r *VirtualChassisResource) buildRequest(ctx context.Context, plan *Virtual
 		stateCustomFields = state.CustomFields
 	}
 	utils.ApplyCustomFieldsWithMerge(ctx, vcRequest, plan.CustomFields, stateCustomFields, &diags)
+	utils.ApplyCustomFieldsMap(ctx, r.client, vcRequest, plan.CustomFieldsMap, &diags)
+	utils.ApplyProviderTags(ctx, r.client, vcRequest, &diags)
+	utils.ApplyProviderCustomFields(ctx, r.client, "dcim.virtualchassis", vcRequest, &diags)
+	netboxlookup.ResolveCustomFieldReferences(ctx, r.client, vcRequest, &diags)
 	if diags.HasError() {
 		return nil, diags
 	}
@@ -446,8 +486,11 @@ func (r *VirtualChassisResource) mapResponseToModel(ctx context.Context, vc *net
 	data.MemberCount = types.Int64Value(int64(vc.GetMemberCount()))
 
 	// Handle tags (slug list) with empty-set preservation
-	data.Tags = utils.PopulateTagsSlugFromAPI(ctx, vc.HasTags(), vc.GetTags(), data.Tags)
+	data.Tags = utils.PopulateTagsSlugFromAPI(ctx, r.client, vc.HasTags(), vc.GetTags(), data.Tags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, vc.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, vc.GetCustomFields(), diags)
 
 	// Handle custom fields using consolidated helper
 	data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, vc.GetCustomFields(), diags)
+	data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, vc.GetCustomFields(), diags)
 }
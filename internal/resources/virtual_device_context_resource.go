@@ -31,6 +31,7 @@ var (
 	_ resource.ResourceWithConfigure   = &VirtualDeviceContextResource{}
 	_ resource.ResourceWithImportState = &VirtualDeviceContextResource{}
 	_ resource.ResourceWithIdentity    = &VirtualDeviceContextResource{}
+	_ resource.ResourceWithModifyPlan  = &VirtualDeviceContextResource{}
 )
 
 // NewVirtualDeviceContextResource returns a new resource implementing the virtual device context resource.
@@ -45,18 +46,22 @@ type VirtualDeviceContextResource struct {
 
 // VirtualDeviceContextResourceModel describes the resource data model.
 type VirtualDeviceContextResourceModel struct {
-	ID           types.String `tfsdk:"id"`
-	Name         types.String `tfsdk:"name"`
-	Device       types.String `tfsdk:"device"`
-	Identifier   types.Int64  `tfsdk:"identifier"`
-	Tenant       types.String `tfsdk:"tenant"`
-	PrimaryIP4   types.String `tfsdk:"primary_ip4"`
-	PrimaryIP6   types.String `tfsdk:"primary_ip6"`
-	Status       types.String `tfsdk:"status"`
-	Description  types.String `tfsdk:"description"`
-	Comments     types.String `tfsdk:"comments"`
-	Tags         types.Set    `tfsdk:"tags"`
-	CustomFields types.Set    `tfsdk:"custom_fields"`
+	ID              types.String  `tfsdk:"id"`
+	Name            types.String  `tfsdk:"name"`
+	Device          types.String  `tfsdk:"device"`
+	Identifier      types.Int64   `tfsdk:"identifier"`
+	Tenant          types.String  `tfsdk:"tenant"`
+	PrimaryIP4      types.String  `tfsdk:"primary_ip4"`
+	PrimaryIP6      types.String  `tfsdk:"primary_ip6"`
+	Status          types.String  `tfsdk:"status"`
+	Description     types.String  `tfsdk:"description"`
+	Comments        types.String  `tfsdk:"comments"`
+	Tags            types.Set     `tfsdk:"tags"`
+	CustomFields    types.Set     `tfsdk:"custom_fields"`
+	CustomFieldsMap types.Dynamic `tfsdk:"custom_fields_map"`
+	TagsAll         types.Set     `tfsdk:"tags_all"`
+	CustomFieldsAll types.Map     `tfsdk:"custom_fields_all"`
+	Branch          types.String  `tfsdk:"branch"`
 }
 
 // Metadata returns the resource type name.
@@ -116,6 +121,10 @@ func (r *VirtualDeviceContextResource) Schema(ctx context.Context, req resource.
 	// Add common metadata attributes (tags, custom_fields)
 	resp.Schema.Attributes["tags"] = nbschema.TagsSlugAttribute()
 	resp.Schema.Attributes["custom_fields"] = nbschema.CustomFieldsAttribute()
+	resp.Schema.Attributes["custom_fields_map"] = nbschema.CustomFieldsMapAttribute()
+	resp.Schema.Attributes["tags_all"] = nbschema.TagsAllAttribute()
+	resp.Schema.Attributes["custom_fields_all"] = nbschema.CustomFieldsAllAttribute()
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 func (r *VirtualDeviceContextResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
@@ -139,8 +148,21 @@ func (r *VirtualDeviceContextResource) Configure(ctx context.Context, req resour
 	r.client = client
 }
 
+// ModifyPlan validates the configured custom fields against their definitions in NetBox
+// and plans tags_all and custom_fields_all.
+func (r *VirtualDeviceContextResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	utils.ValidateCustomFieldsPlan(ctx, r.client, "dcim.virtualdevicecontext", req, resp)
+	utils.PlanEffectiveMetadata(ctx, r.client, "dcim.virtualdevicecontext", req, resp)
+}
+
 // Create creates the resource.
 func (r *VirtualDeviceContextResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	defer journalCreate(ctx, r.client, "dcim.virtualdevicecontext", req, resp)
+
 	var data VirtualDeviceContextResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -200,6 +222,10 @@ func (r *VirtualDeviceContextResource) Create(ctx context.Context, req resource.
 	utils.ApplyComments(apiReq, data.Comments)
 	utils.ApplyTagsFromSlugs(ctx, r.client, apiReq, data.Tags, &resp.Diagnostics)
 	utils.ApplyCustomFields(ctx, apiReq, data.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, apiReq, data.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, apiReq, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "dcim.virtualdevicecontext", apiReq, &resp.Diagnostics)
+	lookup.ResolveCustomFieldReferences(ctx, r.client, apiReq, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -237,6 +263,7 @@ func (r *VirtualDeviceContextResource) Create(ctx context.Context, req resource.
 
 // Read reads the resource.
 func (r *VirtualDeviceContextResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data VirtualDeviceContextResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -299,6 +326,13 @@ func (r *VirtualDeviceContextResource) Read(ctx context.Context, req resource.Re
 
 // Update updates the resource.
 func (r *VirtualDeviceContextResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	ctx = withPriorTags(ctx, req.State)
+	defer journalUpdate(ctx, r.client, "dcim.virtualdevicecontext", req, resp)
+
 	var state, plan VirtualDeviceContextResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -382,6 +416,10 @@ func (r *VirtualDeviceContextResource) Update(ctx context.Context, req resource.
 	utils.ApplyComments(apiReq, plan.Comments)
 	utils.ApplyTagsFromSlugs(ctx, r.client, apiReq, plan.Tags, &resp.Diagnostics)
 	utils.ApplyCustomFieldsWithMerge(ctx, apiReq, plan.CustomFields, state.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, apiReq, plan.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, apiReq, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "dcim.virtualdevicecontext", apiReq, &resp.Diagnostics)
+	lookup.ResolveCustomFieldReferences(ctx, r.client, apiReq, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -418,6 +456,7 @@ func (r *VirtualDeviceContextResource) Update(ctx context.Context, req resource.
 
 	// Filter custom_fields to only those owned by this resource
 	plan.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, planCustomFields, result.GetCustomFields(), &resp.Diagnostics)
+	plan.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, plan.CustomFieldsMap, result.GetCustomFields(), &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -430,6 +469,10 @@ func (r *VirtualDeviceContextResource) Update(ctx context.Context, req resource.
 
 // Delete deletes the resource.
 func (r *VirtualDeviceContextResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data VirtualDeviceContextResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -516,6 +559,7 @@ func (r *VirtualDeviceContextResource) ImportState(ctx context.Context, req reso
 
 		if parsed.HasCustomFields {
 			data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, result.GetCustomFields(), &resp.Diagnostics)
+			data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, result.GetCustomFields(), &resp.Diagnostics)
 		} else {
 			data.CustomFields = types.SetNull(utils.GetCustomFieldsAttributeType().ElemType)
 		}
@@ -605,8 +649,11 @@ func (r *VirtualDeviceContextResource) mapToState(ctx context.Context, result *n
 	}
 
 	// Handle tags (slug list) with empty-set preservation
-	data.Tags = utils.PopulateTagsSlugFromAPI(ctx, result.HasTags(), result.GetTags(), data.Tags)
+	data.Tags = utils.PopulateTagsSlugFromAPI(ctx, r.client, result.HasTags(), result.GetTags(), data.Tags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, result.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, result.GetCustomFields(), diags)
 
 	// Handle custom fields using consolidated helper
 	data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, result.GetCustomFields(), diags)
+	data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, result.GetCustomFields(), diags)
 }
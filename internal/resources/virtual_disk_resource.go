@@ -32,6 +32,7 @@ var (
 	_ resource.ResourceWithConfigure   = &VirtualDiskResource{}
 	_ resource.ResourceWithImportState = &VirtualDiskResource{}
 	_ resource.ResourceWithIdentity    = &VirtualDiskResource{}
+	_ resource.ResourceWithModifyPlan  = &VirtualDiskResource{}
 )
 
 // NewVirtualDiskResource returns a new VirtualDisk resource.
@@ -46,13 +47,17 @@ type VirtualDiskResource struct {
 
 // VirtualDiskResourceModel describes the resource data model.
 type VirtualDiskResourceModel struct {
-	ID             types.String `tfsdk:"id"`
-	VirtualMachine types.String `tfsdk:"virtual_machine"`
-	Name           types.String `tfsdk:"name"`
-	Size           types.String `tfsdk:"size"`
-	Description    types.String `tfsdk:"description"`
-	Tags           types.Set    `tfsdk:"tags"`
-	CustomFields   types.Set    `tfsdk:"custom_fields"`
+	ID              types.String  `tfsdk:"id"`
+	VirtualMachine  types.String  `tfsdk:"virtual_machine"`
+	Name            types.String  `tfsdk:"name"`
+	Size            types.String  `tfsdk:"size"`
+	Description     types.String  `tfsdk:"description"`
+	Tags            types.Set     `tfsdk:"tags"`
+	CustomFields    types.Set     `tfsdk:"custom_fields"`
+	CustomFieldsMap types.Dynamic `tfsdk:"custom_fields_map"`
+	TagsAll         types.Set     `tfsdk:"tags_all"`
+	CustomFieldsAll types.Map     `tfsdk:"custom_fields_all"`
+	Branch          types.String  `tfsdk:"branch"`
 }
 
 // Metadata returns the resource type name.
@@ -96,6 +101,10 @@ func (r *VirtualDiskResource) Schema(ctx context.Context, req resource.SchemaReq
 	// Add common metadata attributes (tags, custom_fields)
 	resp.Schema.Attributes["tags"] = nbschema.TagsSlugAttribute()
 	resp.Schema.Attributes["custom_fields"] = nbschema.CustomFieldsAttribute()
+	resp.Schema.Attributes["custom_fields_map"] = nbschema.CustomFieldsMapAttribute()
+	resp.Schema.Attributes["tags_all"] = nbschema.TagsAllAttribute()
+	resp.Schema.Attributes["custom_fields_all"] = nbschema.CustomFieldsAllAttribute()
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 func (r *VirtualDiskResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
@@ -119,8 +128,21 @@ func (r *VirtualDiskResource) Configure(ctx context.Context, req resource.Config
 	r.client = client
 }
 
+// ModifyPlan validates the configured custom fields against their definitions in NetBox
+// and plans tags_all and custom_fields_all.
+func (r *VirtualDiskResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	utils.ValidateCustomFieldsPlan(ctx, r.client, "virtualization.virtualdisk", req, resp)
+	utils.PlanEffectiveMetadata(ctx, r.client, "virtualization.virtualdisk", req, resp)
+}
+
 // Create creates the resource and sets the initial Terraform state.
 func (r *VirtualDiskResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	defer journalCreate(ctx, r.client, "virtualization.virtualdisk", req, resp)
+
 	var data VirtualDiskResourceModel
 
 	// Read Terraform plan data into the model
@@ -195,6 +217,7 @@ func (r *VirtualDiskResource) Create(ctx context.Context, req resource.CreateReq
 
 // Read refreshes the Terraform state with the latest data.
 func (r *VirtualDiskResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data VirtualDiskResourceModel
 
 	// Read Terraform prior state data into the model
@@ -266,6 +289,13 @@ func (r *VirtualDiskResource) Read(ctx context.Context, req resource.ReadRequest
 // Update updates the resource and sets the updated Terraform state on success.
 
 func (r *VirtualDiskResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	ctx = withPriorTags(ctx, req.State)
+	defer journalUpdate(ctx, r.client, "virtualization.virtualdisk", req, resp)
+
 	var state, plan VirtualDiskResourceModel
 
 	// Read Terraform plan data into the model
@@ -340,6 +370,7 @@ func (r *VirtualDiskResource) Update(ctx context.Context, req resource.UpdateReq
 
 	// Filter custom_fields to only those owned by this resource
 	plan.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, planCustomFields, vd.GetCustomFields(), &resp.Diagnostics)
+	plan.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, plan.CustomFieldsMap, vd.GetCustomFields(), &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -358,6 +389,10 @@ func (r *VirtualDiskResource) Update(ctx context.Context, req resource.UpdateReq
 
 // Delete deletes the resource and removes the Terraform state on success.
 func (r *VirtualDiskResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data VirtualDiskResourceModel
 
 	// Read Terraform prior state data into the model
@@ -449,6 +484,7 @@ func (r *VirtualDiskResource) ImportState(ctx context.Context, req resource.Impo
 
 		if parsed.HasCustomFields {
 			data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, vd.GetCustomFields(), &resp.Diagnostics)
+			data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, vd.GetCustomFields(), &resp.Diagnostics)
 		} else {
 			data.CustomFields = types.SetNull(utils.GetCustomFieldsAttributeType().ElemType)
 		}
@@ -481,6 +517,10 @@ func (r *VirtualDiskResource) setOptionalFields(ctx context.Context, vdRequest *
 	utils.ApplyDescription(vdRequest, plan.Description)
 	utils.ApplyTagsFromSlugs(ctx, r.client, vdRequest, plan.Tags, diags)
 	utils.ApplyCustomFieldsWithMerge(ctx, vdRequest, plan.CustomFields, state.CustomFields, diags)
+	utils.ApplyCustomFieldsMap(ctx, r.client, vdRequest, plan.CustomFieldsMap, diags)
+	utils.ApplyProviderTags(ctx, r.client, vdRequest, diags)
+	utils.ApplyProviderCustomFields(ctx, r.client, "virtualization.virtualdisk", vdRequest, diags)
+	netboxlookup.ResolveCustomFieldReferences(ctx, r.client, vdRequest, diags)
 }
 
 // mapVirtualDiskToState maps a Netbox VirtualDisk to the Terraform state model.
@@ -500,8 +540,11 @@ func (r *VirtualDiskResource) mapVirtualDiskToState(ctx context.Context, vd *net
 	}
 
 	// Handle tags (slug list) with empty-set preservation
-	data.Tags = utils.PopulateTagsSlugFromAPI(ctx, len(vd.Tags) > 0, vd.Tags, data.Tags)
+	data.Tags = utils.PopulateTagsSlugFromAPI(ctx, r.client, len(vd.Tags) > 0, vd.Tags, data.Tags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, vd.Tags)
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, vd.CustomFields, diags)
 
 	// Handle custom fields using consolidated helper
 	data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, vd.CustomFields, diags)
+	data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, vd.CustomFields, diags)
 }
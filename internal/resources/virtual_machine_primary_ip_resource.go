@@ -43,6 +43,7 @@ type VirtualMachinePrimaryIPResourceModel struct {
 	VirtualMachine types.String `tfsdk:"virtual_machine"`
 	PrimaryIP4     types.String `tfsdk:"primary_ip4"`
 	PrimaryIP6     types.String `tfsdk:"primary_ip6"`
+	Branch         types.String `tfsdk:"branch"`
 }
 
 // Metadata returns the resource type name.
@@ -73,6 +74,7 @@ func (r *VirtualMachinePrimaryIPResource) Schema(ctx context.Context, req resour
 			},
 		},
 	}
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 // Configure adds the provider configured client to the resource.
@@ -93,6 +95,10 @@ func (r *VirtualMachinePrimaryIPResource) Configure(ctx context.Context, req res
 
 // Create sets primary IP assignments for a virtual machine.
 func (r *VirtualMachinePrimaryIPResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
 	var data VirtualMachinePrimaryIPResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -140,6 +146,7 @@ func (r *VirtualMachinePrimaryIPResource) Create(ctx context.Context, req resour
 
 // Read refreshes the Terraform state with the latest data.
 func (r *VirtualMachinePrimaryIPResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data VirtualMachinePrimaryIPResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -177,6 +184,10 @@ func (r *VirtualMachinePrimaryIPResource) Read(ctx context.Context, req resource
 
 // Update updates primary IP assignments for a virtual machine.
 func (r *VirtualMachinePrimaryIPResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
 	var data VirtualMachinePrimaryIPResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -216,6 +227,10 @@ func (r *VirtualMachinePrimaryIPResource) Update(ctx context.Context, req resour
 
 // Delete clears the primary IP assignments for a virtual machine.
 func (r *VirtualMachinePrimaryIPResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data VirtualMachinePrimaryIPResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -31,6 +31,7 @@ var (
 	_ resource.ResourceWithConfigure   = &VirtualMachineResource{}
 	_ resource.ResourceWithImportState = &VirtualMachineResource{}
 	_ resource.ResourceWithIdentity    = &VirtualMachineResource{}
+	_ resource.ResourceWithModifyPlan  = &VirtualMachineResource{}
 )
 
 // NewVirtualMachineResource returns a new Virtual Machine resource.
@@ -64,6 +65,10 @@ type VirtualMachineResourceModel struct {
 	LocalContextData types.String  `tfsdk:"local_context_data"`
 	Tags             types.Set     `tfsdk:"tags"`
 	CustomFields     types.Set     `tfsdk:"custom_fields"`
+	CustomFieldsMap  types.Dynamic `tfsdk:"custom_fields_map"`
+	TagsAll          types.Set     `tfsdk:"tags_all"`
+	CustomFieldsAll  types.Map     `tfsdk:"custom_fields_all"`
+	Branch           types.String  `tfsdk:"branch"`
 }
 
 // Metadata returns the resource type name.
@@ -133,6 +138,10 @@ func (r *VirtualMachineResource) Schema(ctx context.Context, req resource.Schema
 	// Add common metadata attributes (tags, custom_fields)
 	resp.Schema.Attributes["tags"] = nbschema.TagsSlugAttribute()
 	resp.Schema.Attributes["custom_fields"] = nbschema.CustomFieldsAttribute()
+	resp.Schema.Attributes["custom_fields_map"] = nbschema.CustomFieldsMapAttribute()
+	resp.Schema.Attributes["tags_all"] = nbschema.TagsAllAttribute()
+	resp.Schema.Attributes["custom_fields_all"] = nbschema.CustomFieldsAllAttribute()
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 func (r *VirtualMachineResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
@@ -417,6 +426,10 @@ func (r *VirtualMachineResource) buildVirtualMachineRequest(ctx context.Context,
 	utils.ApplyComments(vmRequest, data.Comments)
 	utils.ApplyTagsFromSlugs(ctx, r.client, vmRequest, data.Tags, diags)
 	utils.ApplyCustomFields(ctx, vmRequest, data.CustomFields, diags)
+	utils.ApplyCustomFieldsMap(ctx, r.client, vmRequest, data.CustomFieldsMap, diags)
+	utils.ApplyProviderTags(ctx, r.client, vmRequest, diags)
+	utils.ApplyProviderCustomFields(ctx, r.client, "virtualization.virtualmachine", vmRequest, diags)
+	netboxlookup.ResolveCustomFieldReferences(ctx, r.client, vmRequest, diags)
 	if diags.HasError() {
 		return nil
 	}
@@ -588,6 +601,10 @@ func (r *VirtualMachineResource) buildVirtualMachineRequestWithState(ctx context
 
 	// Apply custom fields with merge (merge-aware)
 	utils.ApplyCustomFieldsWithMerge(ctx, vmRequest, plan.CustomFields, state.CustomFields, diags)
+	utils.ApplyCustomFieldsMap(ctx, r.client, vmRequest, plan.CustomFieldsMap, diags)
+	utils.ApplyProviderTags(ctx, r.client, vmRequest, diags)
+	utils.ApplyProviderCustomFields(ctx, r.client, "virtualization.virtualmachine", vmRequest, diags)
+	netboxlookup.ResolveCustomFieldReferences(ctx, r.client, vmRequest, diags)
 	if diags.HasError() {
 		return nil
 	}
@@ -595,8 +612,21 @@ func (r *VirtualMachineResource) buildVirtualMachineRequestWithState(ctx context
 	return vmRequest
 }
 
+// ModifyPlan validates the configured custom fields against their definitions in NetBox
+// and plans tags_all and custom_fields_all.
+func (r *VirtualMachineResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	utils.ValidateCustomFieldsPlan(ctx, r.client, "virtualization.virtualmachine", req, resp)
+	utils.PlanEffectiveMetadata(ctx, r.client, "virtualization.virtualmachine", req, resp)
+}
+
 // Create creates a new virtual machine resource.
 func (r *VirtualMachineResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	defer journalCreate(ctx, r.client, "virtualization.virtualmachine", req, resp)
+
 	var data VirtualMachineResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -641,8 +671,11 @@ func (r *VirtualMachineResource) Create(ctx context.Context, req resource.Create
 	}
 
 	// Apply filter-to-owned pattern for tags and custom_fields
-	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, vm.HasTags(), vm.GetTags(), planTags)
+	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, vm.HasTags(), vm.GetTags(), planTags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, vm.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, vm.GetCustomFields(), &resp.Diagnostics)
 	data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, planCustomFields, vm.GetCustomFields(), &resp.Diagnostics)
+	data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, vm.GetCustomFields(), &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -655,6 +688,7 @@ func (r *VirtualMachineResource) Create(ctx context.Context, req resource.Create
 
 // Read refreshes the Terraform state with the latest data.
 func (r *VirtualMachineResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data VirtualMachineResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -710,11 +744,16 @@ func (r *VirtualMachineResource) Read(ctx context.Context, req resource.ReadRequ
 
 	// Preserve null/empty state values for tags and custom_fields; on import, populate from API
 	if stateTags.IsUnknown() {
-		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, vm.HasTags(), vm.GetTags(), stateTags)
+		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, r.client, vm.HasTags(), vm.GetTags(), stateTags)
+		data.TagsAll = utils.TagsAllFromAPI(ctx, vm.GetTags())
+		data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, vm.GetCustomFields(), &resp.Diagnostics)
 	} else {
-		data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, vm.HasTags(), vm.GetTags(), stateTags)
+		data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, vm.HasTags(), vm.GetTags(), stateTags)
+		data.TagsAll = utils.TagsAllFromAPI(ctx, vm.GetTags())
+		data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, vm.GetCustomFields(), &resp.Diagnostics)
 	}
 	data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, stateCustomFields, vm.GetCustomFields(), &resp.Diagnostics)
+	data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, vm.GetCustomFields(), &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -727,6 +766,13 @@ func (r *VirtualMachineResource) Read(ctx context.Context, req resource.ReadRequ
 
 // Update updates the resource and sets the updated Terraform state.
 func (r *VirtualMachineResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	ctx = withPriorTags(ctx, req.State)
+	defer journalUpdate(ctx, r.client, "virtualization.virtualmachine", req, resp)
+
 	// Read both state and plan for merge-aware custom fields
 	var state, plan VirtualMachineResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -786,8 +832,11 @@ func (r *VirtualMachineResource) Update(ctx context.Context, req resource.Update
 	}
 
 	// Apply filter-to-owned pattern for tags and custom_fields
-	plan.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, vm.HasTags(), vm.GetTags(), planTags)
+	plan.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, vm.HasTags(), vm.GetTags(), planTags)
+	plan.TagsAll = utils.TagsAllFromAPI(ctx, vm.GetTags())
+	plan.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, plan.CustomFieldsAll, vm.GetCustomFields(), &resp.Diagnostics)
 	plan.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, planCustomFields, vm.GetCustomFields(), &resp.Diagnostics)
+	plan.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, plan.CustomFieldsMap, vm.GetCustomFields(), &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -800,6 +849,10 @@ func (r *VirtualMachineResource) Update(ctx context.Context, req resource.Update
 
 // Delete deletes the resource and removes the Terraform state.
 func (r *VirtualMachineResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data VirtualMachineResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -895,10 +948,13 @@ func (r *VirtualMachineResource) ImportState(ctx context.Context, req resource.I
 			return
 		}
 
-		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, len(vm.GetTags()) > 0, vm.GetTags(), types.SetNull(types.StringType))
+		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, r.client, len(vm.GetTags()) > 0, vm.GetTags(), types.SetNull(types.StringType))
+		data.TagsAll = utils.TagsAllFromAPI(ctx, vm.GetTags())
+		data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, vm.GetCustomFields(), &resp.Diagnostics)
 
 		if parsed.HasCustomFields {
 			data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, vm.GetCustomFields(), &resp.Diagnostics)
+			data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, vm.GetCustomFields(), &resp.Diagnostics)
 		} else {
 			data.CustomFields = types.SetNull(utils.GetCustomFieldsAttributeType().ElemType)
 		}
@@ -943,7 +999,9 @@ func (r *VirtualMachineResource) ImportState(ctx context.Context, req resource.I
 		return
 	}
 
-	data.Tags = utils.PopulateTagsSlugFromAPI(ctx, len(vm.GetTags()) > 0, vm.GetTags(), types.SetNull(types.StringType))
+	data.Tags = utils.PopulateTagsSlugFromAPI(ctx, r.client, len(vm.GetTags()) > 0, vm.GetTags(), types.SetNull(types.StringType))
+	data.TagsAll = utils.TagsAllFromAPI(ctx, vm.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, vm.GetCustomFields(), &resp.Diagnostics)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -9,6 +9,7 @@ import (
 	"net/http"
 
 	"github.com/bab3l/go-netbox"
+	"github.com/bab3l/terraform-provider-netbox/internal/netboxlookup"
 	nbschema "github.com/bab3l/terraform-provider-netbox/internal/schema"
 	"github.com/bab3l/terraform-provider-netbox/internal/utils"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
@@ -25,6 +26,7 @@ var (
 	_ resource.Resource                = &VLANGroupResource{}
 	_ resource.ResourceWithImportState = &VLANGroupResource{}
 	_ resource.ResourceWithIdentity    = &VLANGroupResource{}
+	_ resource.ResourceWithModifyPlan  = &VLANGroupResource{}
 )
 
 func NewVLANGroupResource() resource.Resource {
@@ -38,14 +40,18 @@ type VLANGroupResource struct {
 
 // VLANGroupResourceModel describes the resource data model.
 type VLANGroupResourceModel struct {
-	ID           types.String `tfsdk:"id"`
-	Name         types.String `tfsdk:"name"`
-	Slug         types.String `tfsdk:"slug"`
-	ScopeType    types.String `tfsdk:"scope_type"`
-	ScopeID      types.String `tfsdk:"scope_id"`
-	Description  types.String `tfsdk:"description"`
-	Tags         types.Set    `tfsdk:"tags"`
-	CustomFields types.Set    `tfsdk:"custom_fields"`
+	ID              types.String  `tfsdk:"id"`
+	Name            types.String  `tfsdk:"name"`
+	Slug            types.String  `tfsdk:"slug"`
+	ScopeType       types.String  `tfsdk:"scope_type"`
+	ScopeID         types.String  `tfsdk:"scope_id"`
+	Description     types.String  `tfsdk:"description"`
+	Tags            types.Set     `tfsdk:"tags"`
+	CustomFields    types.Set     `tfsdk:"custom_fields"`
+	CustomFieldsMap types.Dynamic `tfsdk:"custom_fields_map"`
+	TagsAll         types.Set     `tfsdk:"tags_all"`
+	CustomFieldsAll types.Map     `tfsdk:"custom_fields_all"`
+	Branch          types.String  `tfsdk:"branch"`
 }
 
 func (r *VLANGroupResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -77,6 +83,10 @@ func (r *VLANGroupResource) Schema(ctx context.Context, req resource.SchemaReque
 	// Add common metadata attributes (tags, custom_fields)
 	resp.Schema.Attributes["tags"] = nbschema.TagsSlugAttribute()
 	resp.Schema.Attributes["custom_fields"] = nbschema.CustomFieldsAttribute()
+	resp.Schema.Attributes["custom_fields_map"] = nbschema.CustomFieldsMapAttribute()
+	resp.Schema.Attributes["tags_all"] = nbschema.TagsAllAttribute()
+	resp.Schema.Attributes["custom_fields_all"] = nbschema.CustomFieldsAllAttribute()
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 func (r *VLANGroupResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
@@ -99,7 +109,20 @@ func (r *VLANGroupResource) Configure(ctx context.Context, req resource.Configur
 	r.client = client
 }
 
+// ModifyPlan validates the configured custom fields against their definitions in NetBox
+// and plans tags_all and custom_fields_all.
+func (r *VLANGroupResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	utils.ValidateCustomFieldsPlan(ctx, r.client, "ipam.vlangroup", req, resp)
+	utils.PlanEffectiveMetadata(ctx, r.client, "ipam.vlangroup", req, resp)
+}
+
 func (r *VLANGroupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	defer journalCreate(ctx, r.client, "ipam.vlangroup", req, resp)
+
 	var data VLANGroupResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -165,6 +188,7 @@ func (r *VLANGroupResource) Create(ctx context.Context, req resource.CreateReque
 }
 
 func (r *VLANGroupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data VLANGroupResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -222,6 +246,13 @@ func (r *VLANGroupResource) Read(ctx context.Context, req resource.ReadRequest,
 }
 
 func (r *VLANGroupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	ctx = withPriorTags(ctx, req.State)
+	defer journalUpdate(ctx, r.client, "ipam.vlangroup", req, resp)
+
 	var state, plan VLANGroupResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -285,6 +316,10 @@ func (r *VLANGroupResource) Update(ctx context.Context, req resource.UpdateReque
 }
 
 func (r *VLANGroupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data VLANGroupResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -378,6 +413,7 @@ func (r *VLANGroupResource) ImportState(ctx context.Context, req resource.Import
 
 		if parsed.HasCustomFields {
 			data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, vlanGroup.GetCustomFields(), &resp.Diagnostics)
+			data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, vlanGroup.GetCustomFields(), &resp.Diagnostics)
 		} else {
 			data.CustomFields = types.SetNull(utils.GetCustomFieldsAttributeType().ElemType)
 		}
@@ -437,6 +473,10 @@ func (r *VLANGroupResource) setOptionalFieldsWithMerge(ctx context.Context, vlan
 
 	// Apply custom fields with merge-aware logic
 	utils.ApplyCustomFieldsWithMerge(ctx, vlanGroupRequest, plan.CustomFields, state.CustomFields, diags)
+	utils.ApplyCustomFieldsMap(ctx, r.client, vlanGroupRequest, plan.CustomFieldsMap, diags)
+	utils.ApplyProviderTags(ctx, r.client, vlanGroupRequest, diags)
+	utils.ApplyProviderCustomFields(ctx, r.client, "ipam.vlangroup", vlanGroupRequest, diags)
+	netboxlookup.ResolveCustomFieldReferences(ctx, r.client, vlanGroupRequest, diags)
 }
 
 func (r *VLANGroupResource) setOptionalFields(ctx context.Context, vlanGroupRequest *netbox.VLANGroupRequest, data *VLANGroupResourceModel, diags *diag.Diagnostics) {
@@ -462,6 +502,10 @@ func (r *VLANGroupResource) setOptionalFields(ctx context.Context, vlanGroupRequ
 	// Apply metadata fields (tags, custom_fields)
 	utils.ApplyTagsFromSlugs(ctx, r.client, vlanGroupRequest, data.Tags, diags)
 	utils.ApplyCustomFields(ctx, vlanGroupRequest, data.CustomFields, diags)
+	utils.ApplyCustomFieldsMap(ctx, r.client, vlanGroupRequest, data.CustomFieldsMap, diags)
+	utils.ApplyProviderTags(ctx, r.client, vlanGroupRequest, diags)
+	utils.ApplyProviderCustomFields(ctx, r.client, "ipam.vlangroup", vlanGroupRequest, diags)
+	netboxlookup.ResolveCustomFieldReferences(ctx, r.client, vlanGroupRequest, diags)
 }
 
 // mapVLANGroupToState maps a VLANGroup API response to the resource model.
@@ -492,8 +536,11 @@ func (r *VLANGroupResource) mapVLANGroupToState(ctx context.Context, vlanGroup *
 	}
 
 	// Handle tags (slug list) with empty-set preservation
-	data.Tags = utils.PopulateTagsSlugFromAPI(ctx, vlanGroup.HasTags(), vlanGroup.GetTags(), data.Tags)
+	data.Tags = utils.PopulateTagsSlugFromAPI(ctx, r.client, vlanGroup.HasTags(), vlanGroup.GetTags(), data.Tags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, vlanGroup.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, vlanGroup.GetCustomFields(), diags)
 
 	// Handle custom fields using filtered-to-owned helper (preserves state pattern)
 	data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, vlanGroup.GetCustomFields(), diags)
+	data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, vlanGroup.GetCustomFields(), diags)
 }
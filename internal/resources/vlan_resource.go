@@ -29,6 +29,7 @@ var (
 	_ resource.Resource                = &VLANResource{}
 	_ resource.ResourceWithImportState = &VLANResource{}
 	_ resource.ResourceWithIdentity    = &VLANResource{}
+	_ resource.ResourceWithModifyPlan  = &VLANResource{}
 )
 
 func NewVLANResource() resource.Resource {
@@ -42,18 +43,22 @@ type VLANResource struct {
 
 // VLANResourceModel describes the resource data model.
 type VLANResourceModel struct {
-	ID           types.String `tfsdk:"id"`
-	VID          types.Int64  `tfsdk:"vid"`
-	Name         types.String `tfsdk:"name"`
-	Site         types.String `tfsdk:"site"`
-	Group        types.String `tfsdk:"group"`
-	Tenant       types.String `tfsdk:"tenant"`
-	Status       types.String `tfsdk:"status"`
-	Role         types.String `tfsdk:"role"`
-	Description  types.String `tfsdk:"description"`
-	Comments     types.String `tfsdk:"comments"`
-	Tags         types.Set    `tfsdk:"tags"`
-	CustomFields types.Set    `tfsdk:"custom_fields"`
+	ID              types.String  `tfsdk:"id"`
+	VID             types.Int64   `tfsdk:"vid"`
+	Name            types.String  `tfsdk:"name"`
+	Site            types.String  `tfsdk:"site"`
+	Group           types.String  `tfsdk:"group"`
+	Tenant          types.String  `tfsdk:"tenant"`
+	Status          types.String  `tfsdk:"status"`
+	Role            types.String  `tfsdk:"role"`
+	Description     types.String  `tfsdk:"description"`
+	Comments        types.String  `tfsdk:"comments"`
+	Tags            types.Set     `tfsdk:"tags"`
+	CustomFields    types.Set     `tfsdk:"custom_fields"`
+	CustomFieldsMap types.Dynamic `tfsdk:"custom_fields_map"`
+	TagsAll         types.Set     `tfsdk:"tags_all"`
+	CustomFieldsAll types.Map     `tfsdk:"custom_fields_all"`
+	Branch          types.String  `tfsdk:"branch"`
 }
 
 func (r *VLANResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -95,6 +100,10 @@ func (r *VLANResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 	// Add common metadata attributes (tags, custom_fields)
 	resp.Schema.Attributes["tags"] = nbschema.TagsSlugAttribute()
 	resp.Schema.Attributes["custom_fields"] = nbschema.CustomFieldsAttribute()
+	resp.Schema.Attributes["custom_fields_map"] = nbschema.CustomFieldsMapAttribute()
+	resp.Schema.Attributes["tags_all"] = nbschema.TagsAllAttribute()
+	resp.Schema.Attributes["custom_fields_all"] = nbschema.CustomFieldsAllAttribute()
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 func (r *VLANResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
@@ -117,7 +126,20 @@ func (r *VLANResource) Configure(ctx context.Context, req resource.ConfigureRequ
 	r.client = client
 }
 
+// ModifyPlan validates the configured custom fields against their definitions in NetBox
+// and plans tags_all and custom_fields_all.
+func (r *VLANResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	utils.ValidateCustomFieldsPlan(ctx, r.client, "ipam.vlan", req, resp)
+	utils.PlanEffectiveMetadata(ctx, r.client, "ipam.vlan", req, resp)
+}
+
 func (r *VLANResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	defer journalCreate(ctx, r.client, "ipam.vlan", req, resp)
+
 	var data VLANResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -182,6 +204,7 @@ func (r *VLANResource) Create(ctx context.Context, req resource.CreateRequest, r
 }
 
 func (r *VLANResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data VLANResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -248,6 +271,13 @@ func (r *VLANResource) Read(ctx context.Context, req resource.ReadRequest, resp
 }
 
 func (r *VLANResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	ctx = withPriorTags(ctx, req.State)
+	defer journalUpdate(ctx, r.client, "ipam.vlan", req, resp)
+
 	var plan VLANResourceModel
 	var state VLANResourceModel
 
@@ -323,6 +353,10 @@ func (r *VLANResource) Update(ctx context.Context, req resource.UpdateRequest, r
 }
 
 func (r *VLANResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data VLANResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -417,6 +451,7 @@ func (r *VLANResource) ImportState(ctx context.Context, req resource.ImportState
 
 		if parsed.HasCustomFields {
 			data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, vlan.GetCustomFields(), &resp.Diagnostics)
+			data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, vlan.GetCustomFields(), &resp.Diagnostics)
 		} else {
 			data.CustomFields = types.SetNull(utils.GetCustomFieldsAttributeType().ElemType)
 		}
@@ -515,9 +550,17 @@ func (r *VLANResource) setOptionalFields(ctx context.Context, vlanRequest *netbo
 	if state != nil {
 		// Update: merge plan custom fields with existing state custom fields
 		utils.ApplyCustomFieldsWithMerge(ctx, vlanRequest, data.CustomFields, state.CustomFields, diags)
+		utils.ApplyCustomFieldsMap(ctx, r.client, vlanRequest, data.CustomFieldsMap, diags)
+		utils.ApplyProviderTags(ctx, r.client, vlanRequest, diags)
+		utils.ApplyProviderCustomFields(ctx, r.client, "ipam.vlan", vlanRequest, diags)
+		netboxlookup.ResolveCustomFieldReferences(ctx, r.client, vlanRequest, diags)
 	} else {
 		// Create: apply plan custom fields directly
 		utils.ApplyCustomFields(ctx, vlanRequest, data.CustomFields, diags)
+		utils.ApplyCustomFieldsMap(ctx, r.client, vlanRequest, data.CustomFieldsMap, diags)
+		utils.ApplyProviderTags(ctx, r.client, vlanRequest, diags)
+		utils.ApplyProviderCustomFields(ctx, r.client, "ipam.vlan", vlanRequest, diags)
+		netboxlookup.ResolveCustomFieldReferences(ctx, r.client, vlanRequest, diags)
 	}
 
 	if diags.HasError() {
@@ -583,8 +626,11 @@ func (r *VLANResource) mapVLANToState(ctx context.Context, vlan *netbox.VLAN, da
 	}
 
 	// Handle tags (slug list) with empty-set preservation
-	data.Tags = utils.PopulateTagsSlugFromAPI(ctx, vlan.HasTags(), vlan.GetTags(), data.Tags)
+	data.Tags = utils.PopulateTagsSlugFromAPI(ctx, r.client, vlan.HasTags(), vlan.GetTags(), data.Tags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, vlan.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, vlan.GetCustomFields(), diags)
 
 	// Handle custom fields using filter-to-owned pattern
 	data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, vlan.GetCustomFields(), diags)
+	data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, vlan.GetCustomFields(), diags)
 }
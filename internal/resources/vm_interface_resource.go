@@ -34,6 +34,7 @@ var (
 	_ resource.ResourceWithConfigure   = &VMInterfaceResource{}
 	_ resource.ResourceWithImportState = &VMInterfaceResource{}
 	_ resource.ResourceWithIdentity    = &VMInterfaceResource{}
+	_ resource.ResourceWithModifyPlan  = &VMInterfaceResource{}
 )
 
 // NewVMInterfaceResource returns a new VM Interface resource.
@@ -48,21 +49,25 @@ type VMInterfaceResource struct {
 
 // VMInterfaceResourceModel describes the resource data model.
 type VMInterfaceResourceModel struct {
-	ID             types.String `tfsdk:"id"`
-	VirtualMachine types.String `tfsdk:"virtual_machine"`
-	Name           types.String `tfsdk:"name"`
-	Enabled        types.Bool   `tfsdk:"enabled"`
-	MTU            types.Int64  `tfsdk:"mtu"`
-	MACAddress     types.String `tfsdk:"mac_address"`
-	Description    types.String `tfsdk:"description"`
-	Mode           types.String `tfsdk:"mode"`
-	Parent         types.String `tfsdk:"parent"`
-	Bridge         types.String `tfsdk:"bridge"`
-	UntaggedVLAN   types.String `tfsdk:"untagged_vlan"`
-	TaggedVLANs    types.Set    `tfsdk:"tagged_vlans"`
-	VRF            types.String `tfsdk:"vrf"`
-	Tags           types.Set    `tfsdk:"tags"`
-	CustomFields   types.Set    `tfsdk:"custom_fields"`
+	ID              types.String  `tfsdk:"id"`
+	VirtualMachine  types.String  `tfsdk:"virtual_machine"`
+	Name            types.String  `tfsdk:"name"`
+	Enabled         types.Bool    `tfsdk:"enabled"`
+	MTU             types.Int64   `tfsdk:"mtu"`
+	MACAddress      types.String  `tfsdk:"mac_address"`
+	Description     types.String  `tfsdk:"description"`
+	Mode            types.String  `tfsdk:"mode"`
+	Parent          types.String  `tfsdk:"parent"`
+	Bridge          types.String  `tfsdk:"bridge"`
+	UntaggedVLAN    types.String  `tfsdk:"untagged_vlan"`
+	TaggedVLANs     types.Set     `tfsdk:"tagged_vlans"`
+	VRF             types.String  `tfsdk:"vrf"`
+	Tags            types.Set     `tfsdk:"tags"`
+	CustomFields    types.Set     `tfsdk:"custom_fields"`
+	CustomFieldsMap types.Dynamic `tfsdk:"custom_fields_map"`
+	TagsAll         types.Set     `tfsdk:"tags_all"`
+	CustomFieldsAll types.Map     `tfsdk:"custom_fields_all"`
+	Branch          types.String  `tfsdk:"branch"`
 }
 
 // Metadata returns the resource type name.
@@ -141,6 +146,10 @@ func (r *VMInterfaceResource) Schema(ctx context.Context, req resource.SchemaReq
 	// Add common metadata attributes (tags, custom_fields)
 	resp.Schema.Attributes["tags"] = nbschema.TagsSlugAttribute()
 	resp.Schema.Attributes["custom_fields"] = nbschema.CustomFieldsAttribute()
+	resp.Schema.Attributes["custom_fields_map"] = nbschema.CustomFieldsMapAttribute()
+	resp.Schema.Attributes["tags_all"] = nbschema.TagsAllAttribute()
+	resp.Schema.Attributes["custom_fields_all"] = nbschema.CustomFieldsAllAttribute()
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 func (r *VMInterfaceResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
@@ -256,10 +265,13 @@ func (r *VMInterfaceResource) mapVMInterfaceToState(ctx context.Context, iface *
 	}
 
 	// Handle tags (slug list) with empty-set preservation
-	data.Tags = utils.PopulateTagsSlugFromAPI(ctx, iface.HasTags(), iface.GetTags(), data.Tags)
+	data.Tags = utils.PopulateTagsSlugFromAPI(ctx, r.client, iface.HasTags(), iface.GetTags(), data.Tags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, iface.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, iface.GetCustomFields(), diags)
 
 	// Handle custom fields using consolidated helper
 	data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, iface.GetCustomFields(), diags)
+	data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, iface.GetCustomFields(), diags)
 }
 
 // buildVMInterfaceRequest builds a WritableVMInterfaceRequest from the resource model.
@@ -381,6 +393,10 @@ func (r *VMInterfaceResource) buildVMInterfaceRequest(ctx context.Context, plan
 	// Apply metadata fields individually with merge-aware helpers
 	utils.ApplyTagsFromSlugs(ctx, r.client, ifaceRequest, plan.Tags, diags)
 	utils.ApplyCustomFieldsWithMerge(ctx, ifaceRequest, plan.CustomFields, state.CustomFields, diags)
+	utils.ApplyCustomFieldsMap(ctx, r.client, ifaceRequest, plan.CustomFieldsMap, diags)
+	utils.ApplyProviderTags(ctx, r.client, ifaceRequest, diags)
+	utils.ApplyProviderCustomFields(ctx, r.client, "virtualization.vminterface", ifaceRequest, diags)
+	netboxlookup.ResolveCustomFieldReferences(ctx, r.client, ifaceRequest, diags)
 	if diags.HasError() {
 		return nil
 	}
@@ -503,8 +519,21 @@ func validateVMInterfaceModeAndTaggedVLANs(ctx context.Context, plan *VMInterfac
 	}
 }
 
+// ModifyPlan validates the configured custom fields against their definitions in NetBox
+// and plans tags_all and custom_fields_all.
+func (r *VMInterfaceResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	utils.ValidateCustomFieldsPlan(ctx, r.client, "virtualization.vminterface", req, resp)
+	utils.PlanEffectiveMetadata(ctx, r.client, "virtualization.vminterface", req, resp)
+}
+
 // Create creates a new VM interface resource.
 func (r *VMInterfaceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	defer journalCreate(ctx, r.client, "virtualization.vminterface", req, resp)
+
 	var data VMInterfaceResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -558,6 +587,7 @@ func (r *VMInterfaceResource) Create(ctx context.Context, req resource.CreateReq
 
 // Read refreshes the Terraform state with the latest data.
 func (r *VMInterfaceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data VMInterfaceResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 
@@ -629,6 +659,13 @@ func (r *VMInterfaceResource) Read(ctx context.Context, req resource.ReadRequest
 
 // Update updates the resource and sets the updated Terraform state.
 func (r *VMInterfaceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	ctx = withPriorTags(ctx, req.State)
+	defer journalUpdate(ctx, r.client, "virtualization.vminterface", req, resp)
+
 	var state, plan VMInterfaceResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -691,6 +728,7 @@ func (r *VMInterfaceResource) Update(ctx context.Context, req resource.UpdateReq
 
 	// Filter custom_fields to only those owned by this resource
 	plan.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, planCustomFields, iface.GetCustomFields(), &resp.Diagnostics)
+	plan.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, plan.CustomFieldsMap, iface.GetCustomFields(), &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -703,6 +741,10 @@ func (r *VMInterfaceResource) Update(ctx context.Context, req resource.UpdateReq
 
 // Delete deletes the resource and removes the Terraform state.
 func (r *VMInterfaceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data VMInterfaceResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -803,6 +845,7 @@ func (r *VMInterfaceResource) ImportState(ctx context.Context, req resource.Impo
 
 		if parsed.HasCustomFields {
 			data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, iface.GetCustomFields(), &resp.Diagnostics)
+			data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, iface.GetCustomFields(), &resp.Diagnostics)
 		} else {
 			data.CustomFields = types.SetNull(utils.GetCustomFieldsAttributeType().ElemType)
 		}
@@ -26,6 +26,7 @@ var (
 	_ resource.Resource                = &VRFResource{}
 	_ resource.ResourceWithImportState = &VRFResource{}
 	_ resource.ResourceWithIdentity    = &VRFResource{}
+	_ resource.ResourceWithModifyPlan  = &VRFResource{}
 )
 
 func NewVRFResource() resource.Resource {
@@ -39,17 +40,21 @@ type VRFResource struct {
 
 // VRFResourceModel describes the resource data model.
 type VRFResourceModel struct {
-	ID            types.String `tfsdk:"id"`
-	Name          types.String `tfsdk:"name"`
-	RD            types.String `tfsdk:"rd"`
-	Tenant        types.String `tfsdk:"tenant"`
-	EnforceUnique types.Bool   `tfsdk:"enforce_unique"`
-	ImportTargets types.List   `tfsdk:"import_targets"`
-	ExportTargets types.List   `tfsdk:"export_targets"`
-	Description   types.String `tfsdk:"description"`
-	Comments      types.String `tfsdk:"comments"`
-	Tags          types.Set    `tfsdk:"tags"`
-	CustomFields  types.Set    `tfsdk:"custom_fields"`
+	ID              types.String  `tfsdk:"id"`
+	Name            types.String  `tfsdk:"name"`
+	RD              types.String  `tfsdk:"rd"`
+	Tenant          types.String  `tfsdk:"tenant"`
+	EnforceUnique   types.Bool    `tfsdk:"enforce_unique"`
+	ImportTargets   types.List    `tfsdk:"import_targets"`
+	ExportTargets   types.List    `tfsdk:"export_targets"`
+	Description     types.String  `tfsdk:"description"`
+	Comments        types.String  `tfsdk:"comments"`
+	Tags            types.Set     `tfsdk:"tags"`
+	CustomFields    types.Set     `tfsdk:"custom_fields"`
+	CustomFieldsMap types.Dynamic `tfsdk:"custom_fields_map"`
+	TagsAll         types.Set     `tfsdk:"tags_all"`
+	CustomFieldsAll types.Map     `tfsdk:"custom_fields_all"`
+	Branch          types.String  `tfsdk:"branch"`
 }
 
 func (r *VRFResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -83,12 +88,16 @@ func (r *VRFResource) Schema(ctx context.Context, req resource.SchemaRequest, re
 				Optional:            true,
 				ElementType:         types.Int64Type,
 			},
-			"description":   nbschema.DescriptionAttribute("VRF"),
-			"comments":      nbschema.CommentsAttribute("VRF"),
-			"tags":          nbschema.TagsSlugAttribute(),
-			"custom_fields": nbschema.CustomFieldsAttribute(),
+			"description":       nbschema.DescriptionAttribute("VRF"),
+			"comments":          nbschema.CommentsAttribute("VRF"),
+			"tags":              nbschema.TagsSlugAttribute(),
+			"custom_fields":     nbschema.CustomFieldsAttribute(),
+			"custom_fields_map": nbschema.CustomFieldsMapAttribute(),
+			"tags_all":          nbschema.TagsAllAttribute(),
+			"custom_fields_all": nbschema.CustomFieldsAllAttribute(),
 		},
 	}
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 func (r *VRFResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
@@ -111,7 +120,20 @@ func (r *VRFResource) Configure(ctx context.Context, req resource.ConfigureReque
 	r.client = client
 }
 
+// ModifyPlan validates the configured custom fields against their definitions in NetBox
+// and plans tags_all and custom_fields_all.
+func (r *VRFResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	utils.ValidateCustomFieldsPlan(ctx, r.client, "ipam.vrf", req, resp)
+	utils.PlanEffectiveMetadata(ctx, r.client, "ipam.vrf", req, resp)
+}
+
 func (r *VRFResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	defer journalCreate(ctx, r.client, "ipam.vrf", req, resp)
+
 	var data VRFResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -166,6 +188,7 @@ func (r *VRFResource) Create(ctx context.Context, req resource.CreateRequest, re
 }
 
 func (r *VRFResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data VRFResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -232,6 +255,13 @@ func (r *VRFResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 }
 
 func (r *VRFResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	ctx = withPriorTags(ctx, req.State)
+	defer journalUpdate(ctx, r.client, "ipam.vrf", req, resp)
+
 	var plan VRFResourceModel
 	var state VRFResourceModel
 
@@ -300,6 +330,10 @@ func (r *VRFResource) Update(ctx context.Context, req resource.UpdateRequest, re
 }
 
 func (r *VRFResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data VRFResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -373,7 +407,9 @@ func (r *VRFResource) ImportState(ctx context.Context, req resource.ImportStateR
 			tenant := vrf.Tenant.Get()
 			data.Tenant = types.StringValue(fmt.Sprintf("%d", tenant.GetId()))
 		}
-		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, vrf.HasTags(), vrf.GetTags(), data.Tags)
+		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, r.client, vrf.HasTags(), vrf.GetTags(), data.Tags)
+		data.TagsAll = utils.TagsAllFromAPI(ctx, vrf.GetTags())
+		data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, vrf.GetCustomFields(), &resp.Diagnostics)
 		if parsed.HasCustomFields {
 			if len(parsed.CustomFields) == 0 {
 				data.CustomFields = types.SetValueMust(utils.GetCustomFieldsAttributeType().ElemType, []attr.Value{})
@@ -395,6 +431,7 @@ func (r *VRFResource) ImportState(ctx context.Context, req resource.ImportStateR
 		}
 		if parsed.HasCustomFields {
 			data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, vrf.GetCustomFields(), &resp.Diagnostics)
+			data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, vrf.GetCustomFields(), &resp.Diagnostics)
 		} else {
 			data.CustomFields = types.SetNull(utils.GetCustomFieldsAttributeType().ElemType)
 		}
@@ -508,9 +545,17 @@ func (r *VRFResource) setOptionalFields(ctx context.Context, vrfRequest *netbox.
 	if state != nil {
 		// Update operation - merge custom fields to preserve unmanaged fields
 		utils.ApplyCustomFieldsWithMerge(ctx, vrfRequest, data.CustomFields, state.CustomFields, diags)
+		utils.ApplyCustomFieldsMap(ctx, r.client, vrfRequest, data.CustomFieldsMap, diags)
+		utils.ApplyProviderTags(ctx, r.client, vrfRequest, diags)
+		utils.ApplyProviderCustomFields(ctx, r.client, "ipam.vrf", vrfRequest, diags)
+		netboxlookup.ResolveCustomFieldReferences(ctx, r.client, vrfRequest, diags)
 	} else {
 		// Create operation - apply custom fields directly
 		utils.ApplyCustomFields(ctx, vrfRequest, data.CustomFields, diags)
+		utils.ApplyCustomFieldsMap(ctx, r.client, vrfRequest, data.CustomFieldsMap, diags)
+		utils.ApplyProviderTags(ctx, r.client, vrfRequest, diags)
+		utils.ApplyProviderCustomFields(ctx, r.client, "ipam.vrf", vrfRequest, diags)
+		netboxlookup.ResolveCustomFieldReferences(ctx, r.client, vrfRequest, diags)
 	}
 	if diags.HasError() {
 		return
@@ -589,8 +634,11 @@ func (r *VRFResource) mapVRFToState(ctx context.Context, vrf *netbox.VRF, data *
 	)
 
 	// Tags (slug list) with empty-set preservation
-	data.Tags = utils.PopulateTagsSlugFromAPI(ctx, vrf.HasTags(), vrf.GetTags(), data.Tags)
+	data.Tags = utils.PopulateTagsSlugFromAPI(ctx, r.client, vrf.HasTags(), vrf.GetTags(), data.Tags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, vrf.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, vrf.GetCustomFields(), diags)
 
 	// Custom Fields - filter to owned fields only
 	data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, vrf.GetCustomFields(), diags)
+	data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, vrf.GetCustomFields(), diags)
 }
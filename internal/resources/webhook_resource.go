@@ -6,6 +6,7 @@ import (
 	"net/http"
 
 	"github.com/bab3l/go-netbox"
+	"github.com/bab3l/terraform-provider-netbox/internal/netboxlookup"
 	nbschema "github.com/bab3l/terraform-provider-netbox/internal/schema"
 	"github.com/bab3l/terraform-provider-netbox/internal/utils"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
@@ -25,6 +26,7 @@ var (
 	_ resource.Resource                = &WebhookResource{}
 	_ resource.ResourceWithImportState = &WebhookResource{}
 	_ resource.ResourceWithIdentity    = &WebhookResource{}
+	_ resource.ResourceWithModifyPlan  = &WebhookResource{}
 )
 
 func NewWebhookResource() resource.Resource {
@@ -38,19 +40,23 @@ type WebhookResource struct {
 
 // WebhookResourceModel describes the webhook resource data model.
 type WebhookResourceModel struct {
-	ID                types.String `tfsdk:"id"`
-	Name              types.String `tfsdk:"name"`
-	Description       types.String `tfsdk:"description"`
-	PayloadURL        types.String `tfsdk:"payload_url"`
-	HTTPMethod        types.String `tfsdk:"http_method"`
-	HTTPContentType   types.String `tfsdk:"http_content_type"`
-	AdditionalHeaders types.String `tfsdk:"additional_headers"`
-	BodyTemplate      types.String `tfsdk:"body_template"`
-	Secret            types.String `tfsdk:"secret"`
-	SSLVerification   types.Bool   `tfsdk:"ssl_verification"`
-	CAFilePath        types.String `tfsdk:"ca_file_path"`
-	Tags              types.Set    `tfsdk:"tags"`
-	CustomFields      types.Set    `tfsdk:"custom_fields"`
+	ID                types.String  `tfsdk:"id"`
+	Name              types.String  `tfsdk:"name"`
+	Description       types.String  `tfsdk:"description"`
+	PayloadURL        types.String  `tfsdk:"payload_url"`
+	HTTPMethod        types.String  `tfsdk:"http_method"`
+	HTTPContentType   types.String  `tfsdk:"http_content_type"`
+	AdditionalHeaders types.String  `tfsdk:"additional_headers"`
+	BodyTemplate      types.String  `tfsdk:"body_template"`
+	Secret            types.String  `tfsdk:"secret"`
+	SSLVerification   types.Bool    `tfsdk:"ssl_verification"`
+	CAFilePath        types.String  `tfsdk:"ca_file_path"`
+	Tags              types.Set     `tfsdk:"tags"`
+	CustomFields      types.Set     `tfsdk:"custom_fields"`
+	CustomFieldsMap   types.Dynamic `tfsdk:"custom_fields_map"`
+	TagsAll           types.Set     `tfsdk:"tags_all"`
+	CustomFieldsAll   types.Map     `tfsdk:"custom_fields_all"`
+	Branch            types.String  `tfsdk:"branch"`
 }
 
 func (r *WebhookResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -61,11 +67,14 @@ func (r *WebhookResource) Schema(ctx context.Context, req resource.SchemaRequest
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Manages a webhook in Netbox. Webhooks allow Netbox to send HTTP requests to external systems when certain events occur.",
 		Attributes: map[string]schema.Attribute{
-			"id":            nbschema.IDAttribute("webhook"),
-			"name":          nbschema.NameAttribute("webhook", 150),
-			"description":   nbschema.DescriptionAttribute("webhook"),
-			"tags":          nbschema.TagsSlugAttribute(),
-			"custom_fields": nbschema.CustomFieldsAttribute(),
+			"id":                nbschema.IDAttribute("webhook"),
+			"name":              nbschema.NameAttribute("webhook", 150),
+			"description":       nbschema.DescriptionAttribute("webhook"),
+			"tags":              nbschema.TagsSlugAttribute(),
+			"custom_fields":     nbschema.CustomFieldsAttribute(),
+			"custom_fields_map": nbschema.CustomFieldsMapAttribute(),
+			"tags_all":          nbschema.TagsAllAttribute(),
+			"custom_fields_all": nbschema.CustomFieldsAllAttribute(),
 			"payload_url": schema.StringAttribute{
 				MarkdownDescription: "The URL that will be called when the webhook is triggered. Jinja2 template processing is supported.",
 				Required:            true,
@@ -122,6 +131,7 @@ func (r *WebhookResource) Schema(ctx context.Context, req resource.SchemaRequest
 			},
 		},
 	}
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 func (r *WebhookResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
@@ -143,7 +153,18 @@ func (r *WebhookResource) Configure(ctx context.Context, req resource.ConfigureR
 	r.client = client
 }
 
+// ModifyPlan validates the configured custom fields against their definitions in NetBox
+// and plans tags_all and custom_fields_all.
+func (r *WebhookResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	utils.ValidateCustomFieldsPlan(ctx, r.client, "extras.webhook", req, resp)
+	utils.PlanEffectiveMetadata(ctx, r.client, "extras.webhook", req, resp)
+}
+
 func (r *WebhookResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
 	var data WebhookResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -203,6 +224,13 @@ func (r *WebhookResource) Create(ctx context.Context, req resource.CreateRequest
 	// Note: We can't use ApplyCommonFields since webhook doesn't have comments
 	utils.ApplyTagsFromSlugs(ctx, r.client, webhookRequest, data.Tags, &resp.Diagnostics)
 	utils.ApplyCustomFields(ctx, webhookRequest, data.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, webhookRequest, data.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, webhookRequest, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "extras.webhook", webhookRequest, &resp.Diagnostics)
+	netboxlookup.ResolveCustomFieldReferences(ctx, r.client, webhookRequest, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	webhook, httpResp, err := r.client.ExtrasAPI.ExtrasWebhooksCreate(ctx).WebhookRequest(*webhookRequest).Execute()
 	defer utils.CloseResponseBody(httpResp)
@@ -238,6 +266,7 @@ func (r *WebhookResource) Create(ctx context.Context, req resource.CreateRequest
 }
 
 func (r *WebhookResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data WebhookResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -277,6 +306,11 @@ func (r *WebhookResource) Read(ctx context.Context, req resource.ReadRequest, re
 }
 
 func (r *WebhookResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	ctx = withPriorTags(ctx, req.State)
 	var data WebhookResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -352,6 +386,10 @@ func (r *WebhookResource) Update(ctx context.Context, req resource.UpdateRequest
 	// Handle tags and custom fields
 	utils.ApplyTagsFromSlugs(ctx, r.client, webhookRequest, data.Tags, &resp.Diagnostics)
 	utils.ApplyCustomFields(ctx, webhookRequest, data.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, webhookRequest, data.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, webhookRequest, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "extras.webhook", webhookRequest, &resp.Diagnostics)
+	netboxlookup.ResolveCustomFieldReferences(ctx, r.client, webhookRequest, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -385,6 +423,10 @@ func (r *WebhookResource) Update(ctx context.Context, req resource.UpdateRequest
 }
 
 func (r *WebhookResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data WebhookResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -462,6 +504,7 @@ func (r *WebhookResource) ImportState(ctx context.Context, req resource.ImportSt
 
 		if parsed.HasCustomFields {
 			data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, webhook.GetCustomFields(), &resp.Diagnostics)
+			data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, webhook.GetCustomFields(), &resp.Diagnostics)
 		} else {
 			data.CustomFields = types.SetNull(utils.GetCustomFieldsAttributeType().ElemType)
 		}
@@ -551,10 +594,13 @@ func (r *WebhookResource) mapWebhookToState(ctx context.Context, webhook *netbox
 
 	// Map display_name
 	// Handle tags (slug list) with empty-set preservation
-	data.Tags = utils.PopulateTagsSlugFromAPI(ctx, webhook.HasTags(), webhook.GetTags(), data.Tags)
+	data.Tags = utils.PopulateTagsSlugFromAPI(ctx, r.client, webhook.HasTags(), webhook.GetTags(), data.Tags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, webhook.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, webhook.GetCustomFields(), diags)
 
 	// Map custom fields
 	data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, webhook.GetCustomFields(), diags)
+	data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, webhook.GetCustomFields(), diags)
 	if diags.HasError() {
 		return
 	}
@@ -30,6 +30,7 @@ var (
 	_ resource.ResourceWithConfigure   = &WirelessLANResource{}
 	_ resource.ResourceWithImportState = &WirelessLANResource{}
 	_ resource.ResourceWithIdentity    = &WirelessLANResource{}
+	_ resource.ResourceWithModifyPlan  = &WirelessLANResource{}
 )
 
 // NewWirelessLANResource returns a new resource implementing the wireless LAN resource.
@@ -44,19 +45,23 @@ type WirelessLANResource struct {
 
 // WirelessLANResourceModel describes the resource data model.
 type WirelessLANResourceModel struct {
-	ID           types.String `tfsdk:"id"`
-	SSID         types.String `tfsdk:"ssid"`
-	Description  types.String `tfsdk:"description"`
-	Group        types.String `tfsdk:"group"`
-	Status       types.String `tfsdk:"status"`
-	VLAN         types.String `tfsdk:"vlan"`
-	Tenant       types.String `tfsdk:"tenant"`
-	AuthType     types.String `tfsdk:"auth_type"`
-	AuthCipher   types.String `tfsdk:"auth_cipher"`
-	AuthPSK      types.String `tfsdk:"auth_psk"`
-	Comments     types.String `tfsdk:"comments"`
-	Tags         types.Set    `tfsdk:"tags"`
-	CustomFields types.Set    `tfsdk:"custom_fields"`
+	ID              types.String  `tfsdk:"id"`
+	SSID            types.String  `tfsdk:"ssid"`
+	Description     types.String  `tfsdk:"description"`
+	Group           types.String  `tfsdk:"group"`
+	Status          types.String  `tfsdk:"status"`
+	VLAN            types.String  `tfsdk:"vlan"`
+	Tenant          types.String  `tfsdk:"tenant"`
+	AuthType        types.String  `tfsdk:"auth_type"`
+	AuthCipher      types.String  `tfsdk:"auth_cipher"`
+	AuthPSK         types.String  `tfsdk:"auth_psk"`
+	Comments        types.String  `tfsdk:"comments"`
+	Tags            types.Set     `tfsdk:"tags"`
+	CustomFields    types.Set     `tfsdk:"custom_fields"`
+	CustomFieldsMap types.Dynamic `tfsdk:"custom_fields_map"`
+	TagsAll         types.Set     `tfsdk:"tags_all"`
+	CustomFieldsAll types.Map     `tfsdk:"custom_fields_all"`
+	Branch          types.String  `tfsdk:"branch"`
 }
 
 // Metadata returns the resource type name.
@@ -125,6 +130,10 @@ func (r *WirelessLANResource) Schema(ctx context.Context, req resource.SchemaReq
 	maps.Copy(resp.Schema.Attributes, nbschema.CommonMetadataAttributes())
 	resp.Schema.Attributes["tags"] = nbschema.TagsSlugAttribute()
 	resp.Schema.Attributes["custom_fields"] = nbschema.CustomFieldsAttribute()
+	resp.Schema.Attributes["custom_fields_map"] = nbschema.CustomFieldsMapAttribute()
+	resp.Schema.Attributes["tags_all"] = nbschema.TagsAllAttribute()
+	resp.Schema.Attributes["custom_fields_all"] = nbschema.CustomFieldsAllAttribute()
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 func (r *WirelessLANResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
@@ -148,8 +157,21 @@ func (r *WirelessLANResource) Configure(ctx context.Context, req resource.Config
 	r.client = client
 }
 
+// ModifyPlan validates the configured custom fields against their definitions in NetBox
+// and plans tags_all and custom_fields_all.
+func (r *WirelessLANResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	utils.ValidateCustomFieldsPlan(ctx, r.client, "wireless.wirelesslan", req, resp)
+	utils.PlanEffectiveMetadata(ctx, r.client, "wireless.wirelesslan", req, resp)
+}
+
 // Create creates the resource.
 func (r *WirelessLANResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	defer journalCreate(ctx, r.client, "wireless.wirelesslan", req, resp)
+
 	var data WirelessLANResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -221,6 +243,10 @@ func (r *WirelessLANResource) Create(ctx context.Context, req resource.CreateReq
 	// Set tags and custom fields
 	utils.ApplyTagsFromSlugs(ctx, r.client, apiReq, data.Tags, &resp.Diagnostics)
 	utils.ApplyCustomFields(ctx, apiReq, data.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, apiReq, data.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, apiReq, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "wireless.wirelesslan", apiReq, &resp.Diagnostics)
+	lookup.ResolveCustomFieldReferences(ctx, r.client, apiReq, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -248,8 +274,11 @@ func (r *WirelessLANResource) Create(ctx context.Context, req resource.CreateReq
 	}
 
 	// Populate tags and custom fields filtered to owned fields only
-	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, response.HasTags(), response.GetTags(), planTags)
+	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, response.HasTags(), response.GetTags(), planTags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, response.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, response.GetCustomFields(), &resp.Diagnostics)
 	data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, planCustomFields, response.GetCustomFields(), &resp.Diagnostics)
+	data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, response.GetCustomFields(), &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -266,6 +295,7 @@ func (r *WirelessLANResource) Create(ctx context.Context, req resource.CreateReq
 
 // Read refreshes the resource state.
 func (r *WirelessLANResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data WirelessLANResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -317,8 +347,11 @@ func (r *WirelessLANResource) Read(ctx context.Context, req resource.ReadRequest
 	data.AuthPSK = existingPSK
 
 	// Populate tags and custom fields filtered to owned fields only (preserves null/empty state)
-	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, response.HasTags(), response.GetTags(), stateTags)
+	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, response.HasTags(), response.GetTags(), stateTags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, response.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, response.GetCustomFields(), &resp.Diagnostics)
 	data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, stateCustomFields, response.GetCustomFields(), &resp.Diagnostics)
+	data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, response.GetCustomFields(), &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -331,6 +364,13 @@ func (r *WirelessLANResource) Read(ctx context.Context, req resource.ReadRequest
 
 // Update updates the resource.
 func (r *WirelessLANResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	ctx = withPriorTags(ctx, req.State)
+	defer journalUpdate(ctx, r.client, "wireless.wirelesslan", req, resp)
+
 	var state, plan WirelessLANResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -420,6 +460,10 @@ func (r *WirelessLANResource) Update(ctx context.Context, req resource.UpdateReq
 	// Handle tags and custom fields with merge-aware helpers
 	utils.ApplyTagsFromSlugs(ctx, r.client, apiReq, plan.Tags, &resp.Diagnostics)
 	utils.ApplyCustomFieldsWithMerge(ctx, apiReq, plan.CustomFields, state.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, apiReq, plan.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, apiReq, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "wireless.wirelesslan", apiReq, &resp.Diagnostics)
+	lookup.ResolveCustomFieldReferences(ctx, r.client, apiReq, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -456,8 +500,11 @@ func (r *WirelessLANResource) Update(ctx context.Context, req resource.UpdateReq
 	plan.AuthPSK = existingPSK
 
 	// Populate tags and custom fields filtered to owned fields only
-	plan.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, response.HasTags(), response.GetTags(), planTags)
+	plan.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, response.HasTags(), response.GetTags(), planTags)
+	plan.TagsAll = utils.TagsAllFromAPI(ctx, response.GetTags())
+	plan.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, plan.CustomFieldsAll, response.GetCustomFields(), &resp.Diagnostics)
 	plan.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, planCustomFields, response.GetCustomFields(), &resp.Diagnostics)
+	plan.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, plan.CustomFieldsMap, response.GetCustomFields(), &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -470,6 +517,10 @@ func (r *WirelessLANResource) Update(ctx context.Context, req resource.UpdateReq
 
 // Delete deletes the resource.
 func (r *WirelessLANResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data WirelessLANResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -555,6 +606,7 @@ func (r *WirelessLANResource) ImportState(ctx context.Context, req resource.Impo
 
 		if parsed.HasCustomFields {
 			data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, response.GetCustomFields(), &resp.Diagnostics)
+			data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, response.GetCustomFields(), &resp.Diagnostics)
 		} else {
 			data.CustomFields = types.SetNull(utils.GetCustomFieldsAttributeType().ElemType)
 		}
@@ -647,8 +699,11 @@ func (r *WirelessLANResource) mapResponseToModel(ctx context.Context, wlan *netb
 	}
 
 	// Handle tags (slug list) with empty-set preservation
-	data.Tags = utils.PopulateTagsSlugFromAPI(ctx, wlan.HasTags(), wlan.GetTags(), data.Tags)
+	data.Tags = utils.PopulateTagsSlugFromAPI(ctx, r.client, wlan.HasTags(), wlan.GetTags(), data.Tags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, wlan.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, wlan.GetCustomFields(), diags)
 
 	// Handle custom fields using consolidated helper
 	data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, wlan.GetCustomFields(), diags)
+	data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, wlan.GetCustomFields(), diags)
 }
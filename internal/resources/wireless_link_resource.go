@@ -9,6 +9,7 @@ import (
 	"net/http"
 
 	"github.com/bab3l/go-netbox"
+	"github.com/bab3l/terraform-provider-netbox/internal/netboxlookup"
 	nbschema "github.com/bab3l/terraform-provider-netbox/internal/schema"
 	"github.com/bab3l/terraform-provider-netbox/internal/utils"
 	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
@@ -31,6 +32,7 @@ var (
 	_ resource.ResourceWithConfigure   = &WirelessLinkResource{}
 	_ resource.ResourceWithImportState = &WirelessLinkResource{}
 	_ resource.ResourceWithIdentity    = &WirelessLinkResource{}
+	_ resource.ResourceWithModifyPlan  = &WirelessLinkResource{}
 )
 
 // NewWirelessLinkResource returns a new resource implementing the wireless link resource.
@@ -45,21 +47,25 @@ type WirelessLinkResource struct {
 
 // WirelessLinkResourceModel describes the resource data model.
 type WirelessLinkResourceModel struct {
-	ID           types.String  `tfsdk:"id"`
-	InterfaceA   types.String  `tfsdk:"interface_a"`
-	InterfaceB   types.String  `tfsdk:"interface_b"`
-	SSID         types.String  `tfsdk:"ssid"`
-	Status       types.String  `tfsdk:"status"`
-	Tenant       types.String  `tfsdk:"tenant"`
-	AuthType     types.String  `tfsdk:"auth_type"`
-	AuthCipher   types.String  `tfsdk:"auth_cipher"`
-	AuthPSK      types.String  `tfsdk:"auth_psk"`
-	Distance     types.Float64 `tfsdk:"distance"`
-	DistanceUnit types.String  `tfsdk:"distance_unit"`
-	Description  types.String  `tfsdk:"description"`
-	Comments     types.String  `tfsdk:"comments"`
-	Tags         types.Set     `tfsdk:"tags"`
-	CustomFields types.Set     `tfsdk:"custom_fields"`
+	ID              types.String  `tfsdk:"id"`
+	InterfaceA      types.String  `tfsdk:"interface_a"`
+	InterfaceB      types.String  `tfsdk:"interface_b"`
+	SSID            types.String  `tfsdk:"ssid"`
+	Status          types.String  `tfsdk:"status"`
+	Tenant          types.String  `tfsdk:"tenant"`
+	AuthType        types.String  `tfsdk:"auth_type"`
+	AuthCipher      types.String  `tfsdk:"auth_cipher"`
+	AuthPSK         types.String  `tfsdk:"auth_psk"`
+	Distance        types.Float64 `tfsdk:"distance"`
+	DistanceUnit    types.String  `tfsdk:"distance_unit"`
+	Description     types.String  `tfsdk:"description"`
+	Comments        types.String  `tfsdk:"comments"`
+	Tags            types.Set     `tfsdk:"tags"`
+	CustomFields    types.Set     `tfsdk:"custom_fields"`
+	CustomFieldsMap types.Dynamic `tfsdk:"custom_fields_map"`
+	TagsAll         types.Set     `tfsdk:"tags_all"`
+	CustomFieldsAll types.Map     `tfsdk:"custom_fields_all"`
+	Branch          types.String  `tfsdk:"branch"`
 }
 
 // Metadata returns the resource type name.
@@ -149,6 +155,10 @@ func (r *WirelessLinkResource) Schema(ctx context.Context, req resource.SchemaRe
 	maps.Copy(resp.Schema.Attributes, nbschema.CommonMetadataAttributes())
 	resp.Schema.Attributes["tags"] = nbschema.TagsSlugAttribute()
 	resp.Schema.Attributes["custom_fields"] = nbschema.CustomFieldsAttribute()
+	resp.Schema.Attributes["custom_fields_map"] = nbschema.CustomFieldsMapAttribute()
+	resp.Schema.Attributes["tags_all"] = nbschema.TagsAllAttribute()
+	resp.Schema.Attributes["custom_fields_all"] = nbschema.CustomFieldsAllAttribute()
+	resp.Schema.Attributes["branch"] = nbschema.BranchAttribute()
 }
 
 func (r *WirelessLinkResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
@@ -195,8 +205,21 @@ func (r *WirelessLinkResource) lookupInterfaceBrief(ctx context.Context, interfa
 	return briefInterfaceRequest, diags
 }
 
+// ModifyPlan validates the configured custom fields against their definitions in NetBox
+// and plans tags_all and custom_fields_all.
+func (r *WirelessLinkResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	utils.ValidateCustomFieldsPlan(ctx, r.client, "wireless.wirelesslink", req, resp)
+	utils.PlanEffectiveMetadata(ctx, r.client, "wireless.wirelesslink", req, resp)
+}
+
 // Create creates the resource.
 func (r *WirelessLinkResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if readOnlyDenied(r.client, "created", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	defer journalCreate(ctx, r.client, "wireless.wirelesslink", req, resp)
+
 	var data WirelessLinkResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -292,6 +315,10 @@ func (r *WirelessLinkResource) Create(ctx context.Context, req resource.CreateRe
 	utils.ApplyComments(request, data.Comments)
 	utils.ApplyTagsFromSlugs(ctx, r.client, request, data.Tags, &resp.Diagnostics)
 	utils.ApplyCustomFields(ctx, request, data.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, request, data.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, request, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "wireless.wirelesslink", request, &resp.Diagnostics)
+	netboxlookup.ResolveCustomFieldReferences(ctx, r.client, request, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -315,8 +342,11 @@ func (r *WirelessLinkResource) Create(ctx context.Context, req resource.CreateRe
 	}
 
 	// Populate tags and custom fields filtered to owned fields only
-	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, result.HasTags(), result.GetTags(), planTags)
+	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, result.HasTags(), result.GetTags(), planTags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, result.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, result.GetCustomFields(), &resp.Diagnostics)
 	data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, planCustomFields, result.GetCustomFields(), &resp.Diagnostics)
+	data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, result.GetCustomFields(), &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -332,6 +362,7 @@ func (r *WirelessLinkResource) Create(ctx context.Context, req resource.CreateRe
 
 // Read refreshes the Terraform state with the latest data.
 func (r *WirelessLinkResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withBranch(ctx, req.State)
 	var data WirelessLinkResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -370,8 +401,11 @@ func (r *WirelessLinkResource) Read(ctx context.Context, req resource.ReadReques
 	}
 
 	// Populate tags and custom fields filtered to owned fields only
-	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, result.HasTags(), result.GetTags(), stateTags)
+	data.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, result.HasTags(), result.GetTags(), stateTags)
+	data.TagsAll = utils.TagsAllFromAPI(ctx, result.GetTags())
+	data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, result.GetCustomFields(), &resp.Diagnostics)
 	data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, stateCustomFields, result.GetCustomFields(), &resp.Diagnostics)
+	data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, result.GetCustomFields(), &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -384,6 +418,13 @@ func (r *WirelessLinkResource) Read(ctx context.Context, req resource.ReadReques
 
 // Update updates the resource.
 func (r *WirelessLinkResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if readOnlyDenied(r.client, "updated", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.Plan)
+	ctx = withPriorTags(ctx, req.State)
+	defer journalUpdate(ctx, r.client, "wireless.wirelesslink", req, resp)
+
 	var state, plan WirelessLinkResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -511,6 +552,10 @@ func (r *WirelessLinkResource) Update(ctx context.Context, req resource.UpdateRe
 	utils.ApplyComments(request, plan.Comments)
 	utils.ApplyTagsFromSlugs(ctx, r.client, request, plan.Tags, &resp.Diagnostics)
 	utils.ApplyCustomFieldsWithMerge(ctx, request, plan.CustomFields, state.CustomFields, &resp.Diagnostics)
+	utils.ApplyCustomFieldsMap(ctx, r.client, request, plan.CustomFieldsMap, &resp.Diagnostics)
+	utils.ApplyProviderTags(ctx, r.client, request, &resp.Diagnostics)
+	utils.ApplyProviderCustomFields(ctx, r.client, "wireless.wirelesslink", request, &resp.Diagnostics)
+	netboxlookup.ResolveCustomFieldReferences(ctx, r.client, request, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -534,8 +579,11 @@ func (r *WirelessLinkResource) Update(ctx context.Context, req resource.UpdateRe
 	}
 
 	// Populate tags and custom fields filtered to owned fields only
-	plan.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, result.HasTags(), result.GetTags(), planTags)
+	plan.Tags = utils.PopulateTagsSlugFilteredToOwned(ctx, r.client, result.HasTags(), result.GetTags(), planTags)
+	plan.TagsAll = utils.TagsAllFromAPI(ctx, result.GetTags())
+	plan.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, plan.CustomFieldsAll, result.GetCustomFields(), &resp.Diagnostics)
 	plan.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, planCustomFields, result.GetCustomFields(), &resp.Diagnostics)
+	plan.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, plan.CustomFieldsMap, result.GetCustomFields(), &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -548,6 +596,10 @@ func (r *WirelessLinkResource) Update(ctx context.Context, req resource.UpdateRe
 
 // Delete deletes the resource.
 func (r *WirelessLinkResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if readOnlyDenied(r.client, "deleted", &resp.Diagnostics) {
+		return
+	}
+	ctx = withBranch(ctx, req.State)
 	var data WirelessLinkResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -625,10 +677,13 @@ func (r *WirelessLinkResource) ImportState(ctx context.Context, req resource.Imp
 			return
 		}
 
-		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, result.HasTags(), result.GetTags(), data.Tags)
+		data.Tags = utils.PopulateTagsSlugFromAPI(ctx, r.client, result.HasTags(), result.GetTags(), data.Tags)
+		data.TagsAll = utils.TagsAllFromAPI(ctx, result.GetTags())
+		data.CustomFieldsAll = utils.CustomFieldsAllFromAPI(ctx, r.client, data.CustomFieldsAll, result.GetCustomFields(), &resp.Diagnostics)
 
 		if parsed.HasCustomFields {
 			data.CustomFields = utils.PopulateCustomFieldsFilteredToOwned(ctx, data.CustomFields, result.GetCustomFields(), &resp.Diagnostics)
+			data.CustomFieldsMap = utils.CustomFieldsMapFromAPI(ctx, data.CustomFieldsMap, result.GetCustomFields(), &resp.Diagnostics)
 		} else {
 			data.CustomFields = types.SetNull(utils.GetCustomFieldsAttributeType().ElemType)
 		}
@@ -1278,3 +1278,119 @@ resource "netbox_cable" "test" {
 }
 `
 }
+
+func TestAccCableResource_byName(t *testing.T) {
+	t.Parallel()
+
+	siteName := testutil.RandomName("test-site-cable")
+	siteSlug := testutil.GenerateSlug(siteName)
+	deviceName := testutil.RandomName("test-device-cable")
+	mfgName := testutil.RandomName("tf-test-mfg-cable")
+	mfgSlug := testutil.GenerateSlug(mfgName)
+	deviceRoleName := testutil.RandomName("tf-test-role-cable")
+	deviceRoleSlug := testutil.GenerateSlug(deviceRoleName)
+	deviceTypeModel := testutil.RandomName("tf-test-type-cable")
+	deviceTypeSlug := testutil.RandomSlug("device-type")
+	interfaceNameA := testutil.RandomName("eth")
+	interfaceNameB := testutil.RandomName("eth")
+
+	cleanup := testutil.NewCleanupResource(t)
+	cleanup.RegisterSiteCleanup(siteSlug)
+	cleanup.RegisterManufacturerCleanup(mfgSlug)
+	cleanup.RegisterDeviceRoleCleanup(deviceRoleSlug)
+	cleanup.RegisterDeviceTypeCleanup(deviceTypeSlug)
+	cleanup.RegisterDeviceCleanup(deviceName + "-a")
+	cleanup.RegisterDeviceCleanup(deviceName + "-b")
+
+	config := testAccCableResourceConfigByName(siteName, siteSlug, deviceName, mfgName, mfgSlug, deviceRoleName, deviceRoleSlug, deviceTypeModel, deviceTypeSlug, interfaceNameA, interfaceNameB)
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testutil.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: testutil.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("netbox_cable.test", "a_terminations.0.object_type", "dcim.interface"),
+					resource.TestCheckResourceAttrPair("netbox_cable.test", "a_terminations.0.object_id", "netbox_interface.test_a", "id"),
+					resource.TestCheckResourceAttr("netbox_cable.test", "a_terminations.0.interface", interfaceNameA),
+					resource.TestCheckResourceAttrPair("netbox_cable.test", "b_terminations.0.object_id", "netbox_interface.test_b", "id"),
+				),
+			},
+			{
+				Config:   config,
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func testAccCableResourceConfigByName(siteName, siteSlug, deviceName, mfgName, mfgSlug, deviceRoleName, deviceRoleSlug, deviceTypeModel, deviceTypeSlug, interfaceNameA, interfaceNameB string) string {
+	return fmt.Sprintf(`
+resource "netbox_site" "test" {
+  name = %[1]q
+  slug = %[2]q
+  status = "active"
+}
+
+resource "netbox_manufacturer" "test" {
+  name = %[3]q
+  slug = %[4]q
+}
+
+resource "netbox_device_role" "test" {
+  name = %[5]q
+  slug = %[6]q
+}
+
+resource "netbox_device_type" "test" {
+  model = %[7]q
+  slug  = %[8]q
+  manufacturer = netbox_manufacturer.test.id
+}
+
+resource "netbox_device" "test_a" {
+  name           = "%[9]s-a"
+  device_type    = netbox_device_type.test.id
+  role           = netbox_device_role.test.id
+  site           = netbox_site.test.id
+}
+
+resource "netbox_device" "test_b" {
+  name           = "%[9]s-b"
+  device_type    = netbox_device_type.test.id
+  role           = netbox_device_role.test.id
+  site           = netbox_site.test.id
+}
+
+resource "netbox_interface" "test_a" {
+  name      = %[10]q
+  device    = netbox_device.test_a.id
+  type      = "1000base-t"
+}
+
+resource "netbox_interface" "test_b" {
+  name      = %[11]q
+  device    = netbox_device.test_b.id
+  type      = "1000base-t"
+}
+
+resource "netbox_cable" "test" {
+  status = "connected"
+  type   = "cat6"
+  a_terminations = [
+    {
+      device    = netbox_device.test_a.name
+      interface = %[10]q
+    }
+  ]
+  b_terminations = [
+    {
+      device    = netbox_device.test_b.id
+      interface = %[11]q
+    }
+  ]
+
+  depends_on = [netbox_interface.test_a, netbox_interface.test_b]
+}
+`, siteName, siteSlug, mfgName, mfgSlug, deviceRoleName, deviceRoleSlug, deviceTypeModel, deviceTypeSlug, deviceName, interfaceNameA, interfaceNameB)
+}
@@ -0,0 +1,97 @@
+package resources_acceptance_tests
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/bab3l/terraform-provider-netbox/internal/testutil"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDeviceInterfacesResource_basic(t *testing.T) {
+	t.Parallel()
+
+	name := testutil.RandomName("tf-test-dev-ifaces")
+	siteSlug := testutil.RandomSlug("site")
+	mfrSlug := testutil.RandomSlug("mfr")
+	deviceSlug := testutil.RandomSlug("device")
+	roleSlug := testutil.RandomSlug("role")
+
+	cleanup := testutil.NewCleanupResource(t)
+	cleanup.RegisterSiteCleanup(siteSlug)
+	cleanup.RegisterManufacturerCleanup(mfrSlug)
+	cleanup.RegisterDeviceTypeCleanup(deviceSlug)
+	cleanup.RegisterDeviceRoleCleanup(roleSlug)
+	cleanup.RegisterDeviceCleanup(name + "-device")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testutil.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: testutil.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDeviceInterfacesResourceConfig(name, siteSlug, mfrSlug, deviceSlug, roleSlug, "[0-3]", 1500, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("netbox_device_interfaces.test", "id"),
+					resource.TestCheckResourceAttr("netbox_device_interfaces.test", "authoritative", "false"),
+					resource.TestCheckResourceAttr("netbox_device_interfaces.test", "interfaces.%", "3"),
+					resource.TestCheckResourceAttr("netbox_device_interfaces.test", "interfaces.ge-0/0/[0-3].mtu", "1500"),
+					resource.TestCheckResourceAttr("netbox_device_interfaces.test", "interfaces.ge-0/0/[0-3].lag", "ae0"),
+					resource.TestCheckResourceAttr("netbox_device_interfaces.test", "interface_ids.%", "6"),
+					resource.TestCheckResourceAttr("netbox_device_interfaces.test", "adopted_interfaces.#", "0"),
+					resource.TestCheckResourceAttrSet("netbox_device_interfaces.test", "interface_ids.ge-0/0/3"),
+					resource.TestCheckResourceAttrSet("netbox_device_interfaces.test", "interface_ids.mgmt0"),
+				),
+			},
+			{
+				Config:             testAccDeviceInterfacesResourceConfig(name, siteSlug, mfrSlug, deviceSlug, roleSlug, "[0-3]", 1500, false),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: false,
+			},
+			{
+				// Imported interfaces are keyed by their literal names and adopted.
+				ResourceName:            "netbox_device_interfaces.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"interfaces", "adopted_interfaces"},
+			},
+			{
+				Config: testAccDeviceInterfacesResourceConfig(name, siteSlug, mfrSlug, deviceSlug, roleSlug, "[0-1]", 9000, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("netbox_device_interfaces.test", "authoritative", "true"),
+					resource.TestCheckResourceAttr("netbox_device_interfaces.test", "interfaces.ge-0/0/[0-1].mtu", "9000"),
+					resource.TestCheckResourceAttr("netbox_device_interfaces.test", "interface_ids.%", "4"),
+					resource.TestCheckNoResourceAttr("netbox_device_interfaces.test", "interface_ids.ge-0/0/3"),
+				),
+			},
+			{
+				Config:             testAccDeviceInterfacesResourceConfig(name, siteSlug, mfrSlug, deviceSlug, roleSlug, "[0-1]", 9000, true),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: false,
+			},
+		},
+	})
+}
+
+func testAccDeviceInterfacesResourceConfig(name, siteSlug, manufacturerSlug, deviceTypeSlug, roleSlug, portRange string, mtu int, authoritative bool) string {
+	return testAccInterfaceResourcePrereqsWithSlugs(name, siteSlug, manufacturerSlug, deviceTypeSlug, roleSlug) + fmt.Sprintf(`
+resource "netbox_device_interfaces" "test" {
+  device        = netbox_device.test.id
+  authoritative = %t
+
+  interfaces = {
+    "ae0" = {
+      type = "lag"
+    }
+    "ge-0/0/%s" = {
+      type = "1000base-t"
+      mtu  = %d
+      lag  = "ae0"
+    }
+    "mgmt0" = {
+      type      = "1000base-t"
+      mgmt_only = true
+    }
+  }
+}
+`, authoritative, portRange, mtu)
+}
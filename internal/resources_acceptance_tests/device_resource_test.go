@@ -977,6 +977,120 @@ resource "netbox_device" "test" {
 `, deviceName, siteName, siteSlug, manufacturerName, manufacturerSlug, deviceTypeName, deviceTypeSlug, roleName, roleSlug, clusterTypeName, clusterTypeSlug, clusterName, configTemplateName, configTemplateCode)
 }
 
+// TestAccDeviceResource_virtualChassis tests building a virtual chassis where the
+// master joins through netbox_virtual_chassis.master and a member joins through
+// its own virtual_chassis attribute.
+func TestAccDeviceResource_virtualChassis(t *testing.T) {
+	t.Parallel()
+
+	masterName := testutil.RandomName("tf-test-vc-master")
+	memberName := testutil.RandomName("tf-test-vc-member")
+	vcName := testutil.RandomName("tf-test-vc")
+	siteName := testutil.RandomName("tf-test-site")
+	siteSlug := testutil.RandomSlug("tf-test-site")
+	manufacturerName := testutil.RandomName("tf-test-mfr")
+	manufacturerSlug := testutil.RandomSlug("tf-test-mfr")
+	deviceTypeName := testutil.RandomName("tf-test-devtype")
+	deviceTypeSlug := testutil.RandomSlug("tf-test-devtype")
+	roleName := testutil.RandomName("tf-test-role")
+	roleSlug := testutil.RandomSlug("tf-test-role")
+
+	cleanup := testutil.NewCleanupResource(t)
+	cleanup.RegisterSiteCleanup(siteSlug)
+	cleanup.RegisterManufacturerCleanup(manufacturerSlug)
+	cleanup.RegisterDeviceTypeCleanup(deviceTypeSlug)
+	cleanup.RegisterDeviceRoleCleanup(roleSlug)
+	cleanup.RegisterDeviceCleanup(masterName)
+	cleanup.RegisterDeviceCleanup(memberName)
+	cleanup.RegisterVirtualChassisCleanup(vcName)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testutil.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: testutil.TestAccProtoV6ProviderFactories,
+		CheckDestroy: testutil.ComposeCheckDestroy(
+			testutil.CheckDeviceDestroy,
+			testutil.CheckVirtualChassisDestroy,
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDeviceResourceConfig_virtualChassis(
+					masterName, memberName, vcName, siteName, siteSlug, manufacturerName, manufacturerSlug,
+					deviceTypeName, deviceTypeSlug, roleName, roleSlug,
+				),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("netbox_device.member", "virtual_chassis", "netbox_virtual_chassis.test", "id"),
+					resource.TestCheckResourceAttr("netbox_device.member", "vc_position", "2"),
+					resource.TestCheckResourceAttr("netbox_device.member", "vc_priority", "100"),
+					resource.TestCheckResourceAttr("netbox_device.master", "vc_position", "1"),
+				),
+			},
+			{
+				// The master's membership is assigned by NetBox and picked up on refresh.
+				RefreshState: true,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("netbox_device.master", "virtual_chassis", "netbox_virtual_chassis.test", "id"),
+				),
+			},
+			{
+				Config: testAccDeviceResourceConfig_virtualChassis(
+					masterName, memberName, vcName, siteName, siteSlug, manufacturerName, manufacturerSlug,
+					deviceTypeName, deviceTypeSlug, roleName, roleSlug,
+				),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func testAccDeviceResourceConfig_virtualChassis(masterName, memberName, vcName, siteName, siteSlug, manufacturerName, manufacturerSlug, deviceTypeName, deviceTypeSlug, roleName, roleSlug string) string {
+	return fmt.Sprintf(`
+resource "netbox_site" "test" {
+  name = %[4]q
+  slug = %[5]q
+}
+
+resource "netbox_manufacturer" "test" {
+  name = %[6]q
+  slug = %[7]q
+}
+
+resource "netbox_device_type" "test" {
+  model        = %[8]q
+  slug         = %[9]q
+  manufacturer = netbox_manufacturer.test.id
+}
+
+resource "netbox_device_role" "test" {
+  name  = %[10]q
+  slug  = %[11]q
+  color = "ff0000"
+}
+
+resource "netbox_device" "master" {
+  name        = %[1]q
+  site        = netbox_site.test.id
+  device_type = netbox_device_type.test.id
+  role        = netbox_device_role.test.id
+  vc_position = 1
+}
+
+resource "netbox_virtual_chassis" "test" {
+  name   = %[3]q
+  master = netbox_device.master.id
+}
+
+resource "netbox_device" "member" {
+  name            = %[2]q
+  site            = netbox_site.test.id
+  device_type     = netbox_device_type.test.id
+  role            = netbox_device_role.test.id
+  virtual_chassis = netbox_virtual_chassis.test.id
+  vc_position     = 2
+  vc_priority     = 100
+}
+`, masterName, memberName, vcName, siteName, siteSlug, manufacturerName, manufacturerSlug, deviceTypeName, deviceTypeSlug, roleName, roleSlug)
+}
+
 // TestAccDeviceResource_validationErrors tests validation error scenarios.
 func TestAccDeviceResource_validationErrors(t *testing.T) {
 	testutil.RunMultiValidationErrorTest(t, testutil.MultiValidationErrorTestConfig{
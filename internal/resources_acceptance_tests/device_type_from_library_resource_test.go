@@ -0,0 +1,92 @@
+package resources_acceptance_tests
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/bab3l/terraform-provider-netbox/internal/testutil"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDeviceTypeFromLibraryResource_basic(t *testing.T) {
+	t.Parallel()
+
+	model := testutil.RandomName("tf-test-library-dt")
+	slug := testutil.RandomSlug("tf-test-library-dt")
+	// The manufacturer is created from its name, so use a slug-safe name.
+	manufacturer := testutil.RandomSlug("tf-test-library-mfr")
+
+	cleanup := testutil.NewCleanupResource(t)
+	cleanup.RegisterDeviceTypeCleanup(slug)
+	cleanup.RegisterManufacturerCleanup(manufacturer)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testutil.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: testutil.TestAccProtoV6ProviderFactories,
+		CheckDestroy:             testutil.CheckDeviceTypeDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDeviceTypeFromLibraryResourceConfig(manufacturer, model, slug, "eth1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("netbox_device_type_from_library.test", "id"),
+					resource.TestCheckResourceAttrSet("netbox_device_type_from_library.test", "manufacturer"),
+					resource.TestCheckResourceAttr("netbox_device_type_from_library.test", "model", model),
+				),
+			},
+			{
+				Config:   testAccDeviceTypeFromLibraryResourceConfig(manufacturer, model, slug, "eth1"),
+				PlanOnly: true,
+			},
+			{
+				// Renaming an interface replaces the template, the type is updated in place.
+				Config: testAccDeviceTypeFromLibraryResourceConfig(manufacturer, model, slug, "eth2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("netbox_device_type_from_library.test", "model", model),
+				),
+			},
+			{
+				Config:   testAccDeviceTypeFromLibraryResourceConfig(manufacturer, model, slug, "eth2"),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func testAccDeviceTypeFromLibraryResourceConfig(manufacturer, model, slug, secondInterface string) string {
+	return fmt.Sprintf(`
+resource "netbox_device_type_from_library" "test" {
+  definition_yaml = <<-YAML
+    manufacturer: %[1]s
+    model: %[2]s
+    slug: %[3]s
+    u_height: 1
+    is_full_depth: false
+    console-ports:
+      - name: Console
+        type: rj-45
+    power-ports:
+      - name: PSU0
+        type: iec-60320-c14
+    power-outlets:
+      - name: Outlet 1
+        type: iec-60320-c13
+        power_port: PSU0
+    interfaces:
+      - name: eth0
+        type: 1000base-t
+        mgmt_only: true
+      - name: %[4]s
+        type: 10gbase-x-sfpp
+    rear-ports:
+      - name: Rear 1
+        type: 8p8c
+        positions: 2
+    front-ports:
+      - name: Front 1
+        type: 8p8c
+        rear_port: Rear 1
+        rear_port_position: 1
+  YAML
+}
+`, manufacturer, model, slug, secondInterface)
+}
@@ -3,6 +3,7 @@ package resources_acceptance_tests
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/bab3l/terraform-provider-netbox/internal/testutil"
@@ -451,6 +452,85 @@ resource "netbox_interface" "test" {
 `, siteName, siteSlug, manufacturerName, manufacturerSlug, deviceRoleName, deviceRoleSlug, deviceTypeName, deviceTypeSlug, deviceName, interfaceName, optionalField)
 }
 
+func TestAccInterfaceResource_poeAndWireless(t *testing.T) {
+	t.Parallel()
+
+	name := testutil.RandomName("tf-test-interface-poe")
+	vrfName := testutil.RandomName("tf-test-vrf")
+
+	cleanup := testutil.NewCleanupResource(t)
+	cleanup.RegisterDeviceCleanup(name + "-device")
+	cleanup.RegisterVRFCleanup(vrfName)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testutil.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: testutil.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccInterfaceResourceConfig_poeAndWireless(name, vrfName, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("netbox_interface.poe", "poe_mode", "pse"),
+					resource.TestCheckResourceAttr("netbox_interface.poe", "poe_type", "type2-ieee802.3at"),
+					resource.TestCheckResourceAttr("netbox_interface.poe", "vrf", vrfName),
+					resource.TestCheckResourceAttr("netbox_interface.wlan", "rf_role", "ap"),
+					resource.TestCheckResourceAttr("netbox_interface.wlan", "rf_channel", "5g-36-5180-20"),
+					resource.TestCheckResourceAttr("netbox_interface.wlan", "tx_power", "20"),
+				),
+			},
+			{
+				Config:   testAccInterfaceResourceConfig_poeAndWireless(name, vrfName, true),
+				PlanOnly: true,
+			},
+			{
+				Config: testAccInterfaceResourceConfig_poeAndWireless(name, vrfName, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckNoResourceAttr("netbox_interface.poe", "poe_mode"),
+					resource.TestCheckNoResourceAttr("netbox_interface.poe", "poe_type"),
+					resource.TestCheckNoResourceAttr("netbox_interface.poe", "vrf"),
+					resource.TestCheckNoResourceAttr("netbox_interface.wlan", "rf_role"),
+					resource.TestCheckNoResourceAttr("netbox_interface.wlan", "rf_channel"),
+					resource.TestCheckNoResourceAttr("netbox_interface.wlan", "tx_power"),
+				),
+			},
+		},
+	})
+}
+
+func testAccInterfaceResourceConfig_poeAndWireless(name, vrfName string, withAttributes bool) string {
+	poe, wlan := "", ""
+	if withAttributes {
+		poe = `
+  poe_mode = "pse"
+  poe_type = "type2-ieee802.3at"
+  vrf      = netbox_vrf.test.name`
+		wlan = `
+  rf_role    = "ap"
+  rf_channel = "5g-36-5180-20"
+  tx_power   = 20`
+	}
+	return fmt.Sprintf(`
+%s
+
+resource "netbox_vrf" "test" {
+  name = %q
+}
+
+resource "netbox_interface" "poe" {
+  device = netbox_device.test.id
+  name   = "ge-0/0/1"
+  type   = "1000base-t"
+%s
+}
+
+resource "netbox_interface" "wlan" {
+  device = netbox_device.test.id
+  name   = "wlan0"
+  type   = "ieee802.11ax"
+%s
+}
+`, testAccInterfaceResourcePrereqs(name), vrfName, poe, wlan)
+}
+
 func testAccInterfaceResourceConfig_basic(name string) string {
 	return fmt.Sprintf(`
 %s
@@ -1125,6 +1205,19 @@ resource "netbox_interface" "test" {
 				},
 				ExpectedError: testutil.ErrPatternRequired,
 			},
+			"rf_role_on_wired_interface": {
+				Config: func() string {
+					return `
+resource "netbox_interface" "test" {
+  name    = "eth0"
+  device  = "1"
+  type    = "1000base-t"
+  rf_role = "ap"
+}
+`
+				},
+				ExpectedError: regexp.MustCompile(`Unsupported interface attribute`),
+			},
 			"invalid_device_reference": {
 				Config: func() string {
 					return `
@@ -737,3 +737,82 @@ resource "netbox_inventory_item" "test" {
 		},
 	})
 }
+
+func TestAccInventoryItemResource_component(t *testing.T) {
+	t.Parallel()
+
+	name := testutil.RandomName("tf-test-inv-item")
+	siteSlug := testutil.RandomSlug("site")
+	manufacturerSlug := testutil.RandomSlug("mfr")
+	deviceTypeSlug := testutil.RandomSlug("device")
+	deviceRoleSlug := testutil.RandomSlug("role")
+
+	cleanup := testutil.NewCleanupResource(t)
+	cleanup.RegisterSiteCleanup(siteSlug)
+	cleanup.RegisterManufacturerCleanup(manufacturerSlug)
+	cleanup.RegisterDeviceTypeCleanup(deviceTypeSlug)
+	cleanup.RegisterDeviceRoleCleanup(deviceRoleSlug)
+	cleanup.RegisterDeviceCleanup(name + "-device")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testutil.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: testutil.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccInventoryItemResourceConfig_component(name, siteSlug, manufacturerSlug, deviceTypeSlug, deviceRoleSlug, "xe-0/0/0"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("netbox_inventory_item.test", "status", "planned"),
+					resource.TestCheckResourceAttr("netbox_inventory_item.test", "component_type", "dcim.interface"),
+					resource.TestCheckResourceAttrPair("netbox_inventory_item.test", "component_id", "netbox_interface.first", "id"),
+					resource.TestCheckResourceAttrPair("data.netbox_inventory_item.test", "id", "netbox_inventory_item.test", "id"),
+					resource.TestCheckResourceAttr("data.netbox_inventory_item.test", "component_name", "xe-0/0/0"),
+					resource.TestCheckResourceAttr("data.netbox_inventory_item.test", "status", "planned"),
+				),
+			},
+			{
+				Config: testAccInventoryItemResourceConfig_component(name, siteSlug, manufacturerSlug, deviceTypeSlug, deviceRoleSlug, "xe-0/0/1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("netbox_inventory_item.test", "component_name", "xe-0/0/1"),
+					resource.TestCheckResourceAttrPair("netbox_inventory_item.test", "component_id", "netbox_interface.second", "id"),
+				),
+			},
+			{
+				Config:   testAccInventoryItemResourceConfig_component(name, siteSlug, manufacturerSlug, deviceTypeSlug, deviceRoleSlug, "xe-0/0/1"),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func testAccInventoryItemResourceConfig_component(name, siteSlug, manufacturerSlug, deviceTypeSlug, deviceRoleSlug, interfaceName string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "netbox_interface" "first" {
+  device = netbox_device.test.id
+  name   = "xe-0/0/0"
+  type   = "10gbase-x-sfpp"
+}
+
+resource "netbox_interface" "second" {
+  device = netbox_device.test.id
+  name   = "xe-0/0/1"
+  type   = "10gbase-x-sfpp"
+}
+
+resource "netbox_inventory_item" "test" {
+  device         = netbox_device.test.id
+  name           = %q
+  status         = "planned"
+  component_type = "dcim.interface"
+  component_name = %q
+
+  depends_on = [netbox_interface.first, netbox_interface.second]
+}
+
+data "netbox_inventory_item" "test" {
+  component_type = "dcim.interface"
+  component_id   = netbox_inventory_item.test.component_id
+}
+`, testAccInventoryItemResourcePrereqsWithSlugs(name, siteSlug, manufacturerSlug, deviceTypeSlug, deviceRoleSlug), name, interfaceName)
+}
@@ -1031,3 +1031,126 @@ resource "netbox_module" "test" {
 		},
 	})
 }
+
+func TestAccModuleResource_adoptComponents(t *testing.T) {
+	t.Parallel()
+
+	siteName := testutil.RandomName("tf-test-site")
+	siteSlug := testutil.RandomSlug("tf-test-site")
+	mfgName := testutil.RandomName("tf-test-mfg")
+	mfgSlug := testutil.RandomSlug("tf-test-mfg")
+	dtModel := testutil.RandomName("tf-test-dt")
+	dtSlug := testutil.RandomSlug("tf-test-dt")
+	roleName := testutil.RandomName("tf-test-role")
+	roleSlug := testutil.RandomSlug("tf-test-role")
+	deviceName := testutil.RandomName("tf-test-device")
+	bayName := testutil.RandomName("tf-test-mbay")
+	mtModel := testutil.RandomName("tf-test-mt")
+
+	cleanup := testutil.NewCleanupResource(t)
+	cleanup.RegisterSiteCleanup(siteSlug)
+	cleanup.RegisterManufacturerCleanup(mfgSlug)
+	cleanup.RegisterDeviceTypeCleanup(dtSlug)
+	cleanup.RegisterDeviceRoleCleanup(roleSlug)
+	cleanup.RegisterDeviceCleanup(deviceName)
+
+	config := testAccModuleResourceConfig_adoptComponents(siteName, siteSlug, mfgName, mfgSlug, dtModel, dtSlug, roleName, roleSlug, deviceName, bayName, mtModel)
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testutil.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: testutil.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("netbox_module.test", "adopt_components", "true"),
+					resource.TestCheckResourceAttr("netbox_module.test", "replicate_components", "true"),
+					resource.TestCheckResourceAttr("netbox_module.test", "components.#", "2"),
+					resource.TestCheckResourceAttr("netbox_module.test", "components.0.object_type", "dcim.interface"),
+					resource.TestCheckResourceAttr("netbox_module.test", "components.0.name", "et-0/0/0"),
+					resource.TestCheckResourceAttrPair("netbox_module.test", "components.0.id", "netbox_interface.existing", "id"),
+					resource.TestCheckResourceAttr("netbox_module.test", "components.1.name", "et-0/0/1"),
+				),
+			},
+			{
+				Config:   config,
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func testAccModuleResourceConfig_adoptComponents(siteName, siteSlug, mfgName, mfgSlug, dtModel, dtSlug, roleName, roleSlug, deviceName, bayName, mtModel string) string {
+	return fmt.Sprintf(`
+resource "netbox_site" "test" {
+  name   = %q
+  slug   = %q
+  status = "active"
+}
+
+resource "netbox_manufacturer" "test" {
+  name = %q
+  slug = %q
+}
+
+resource "netbox_device_type" "test" {
+  manufacturer = netbox_manufacturer.test.id
+  model        = %q
+  slug         = %q
+}
+
+resource "netbox_device_role" "test" {
+  name  = %q
+  slug  = %q
+  color = "aa1409"
+}
+
+resource "netbox_device" "test" {
+  name        = %q
+  device_type = netbox_device_type.test.id
+  role        = netbox_device_role.test.id
+  site        = netbox_site.test.id
+}
+
+resource "netbox_module_bay" "test" {
+  device = netbox_device.test.id
+  name   = %q
+}
+
+resource "netbox_module_type" "test" {
+  manufacturer = netbox_manufacturer.test.id
+  model        = %q
+}
+
+resource "netbox_interface_template" "first" {
+  module_type = netbox_module_type.test.id
+  name        = "et-0/0/0"
+  type        = "10gbase-x-sfpp"
+}
+
+resource "netbox_interface_template" "second" {
+  module_type = netbox_module_type.test.id
+  name        = "et-0/0/1"
+  type        = "10gbase-x-sfpp"
+}
+
+# Interface created before the line card was installed.
+resource "netbox_interface" "existing" {
+  device = netbox_device.test.id
+  name   = "et-0/0/0"
+  type   = "10gbase-x-sfpp"
+}
+
+resource "netbox_module" "test" {
+  device           = netbox_device.test.id
+  module_bay       = netbox_module_bay.test.id
+  module_type      = netbox_module_type.test.id
+  adopt_components = true
+
+  depends_on = [
+    netbox_interface_template.first,
+    netbox_interface_template.second,
+    netbox_interface.existing,
+  ]
+}
+`, siteName, siteSlug, mfgName, mfgSlug, dtModel, dtSlug, roleName, roleSlug, deviceName, bayName, mtModel)
+}
@@ -0,0 +1,68 @@
+package resources_acceptance_tests
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/bab3l/terraform-provider-netbox/internal/testutil"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccObjectResource_tag(t *testing.T) {
+	t.Parallel()
+
+	name := testutil.RandomName("tf-test-object-tag")
+	slug := testutil.RandomSlug("tf-test-object-tag")
+
+	cleanup := testutil.NewCleanupResource(t)
+	cleanup.RegisterTagCleanup(slug)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testutil.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: testutil.TestAccProtoV6ProviderFactories,
+		CheckDestroy:             testutil.CheckTagDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccObjectResourceConfig(name, slug, "aa1409"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("netbox_object.test", "id"),
+					resource.TestCheckResourceAttr("netbox_object.test", "path", "extras/tags"),
+					resource.TestCheckResourceAttrSet("netbox_object.test", "object_json"),
+				),
+			},
+			{
+				Config: testAccObjectResourceConfig(name, slug, "00ff00"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("netbox_object.test", "body.color", "00ff00"),
+				),
+			},
+			{
+				Config:   testAccObjectResourceConfig(name, slug, "00ff00"),
+				PlanOnly: true,
+			},
+			{
+				ResourceName:            "netbox_object.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateIdPrefix:     "extras/tags/",
+				ImportStateVerifyIgnore: []string{"body", "object_json"},
+			},
+		},
+	})
+}
+
+func testAccObjectResourceConfig(name, slug, color string) string {
+	return fmt.Sprintf(`
+provider "netbox" {}
+
+resource "netbox_object" "test" {
+  path = "extras/tags"
+
+  body = {
+    name  = %q
+    slug  = %q
+    color = %q
+  }
+}
+`, name, slug, color)
+}
@@ -29,6 +29,9 @@ func TestAccCustomFieldChoiceSetResource_basic(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr("netbox_custom_field_choice_set.test", "name", name),
 					resource.TestCheckResourceAttr("netbox_custom_field_choice_set.test", "extra_choices.#", "3"),
+					resource.TestCheckResourceAttr("netbox_custom_field_choice_set.test", "choices.#", "3"),
+					resource.TestCheckResourceAttr("netbox_custom_field_choice_set.test", "choices.0.value", "opt1"),
+					resource.TestCheckResourceAttr("netbox_custom_field_choice_set.test", "choices.0.label", "Option 1"),
 				),
 			},
 			{
@@ -5,6 +5,7 @@ package resources_acceptance_tests_customfields
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/bab3l/terraform-provider-netbox/internal/testutil"
@@ -363,3 +364,416 @@ resource "netbox_site" "test" {
 		cfTextName, cfIntName, description,
 	)
 }
+
+// TestAccSiteResource_customFieldsMap tests the typed custom_fields_map attribute,
+// including decimal values and multiselect choices containing commas.
+func TestAccSiteResource_customFieldsMap(t *testing.T) {
+	// NOTE: t.Parallel() intentionally omitted - this test creates/deletes global custom fields
+
+	siteName := testutil.RandomName("tf-test-site-cfmap")
+	siteSlug := testutil.RandomSlug("tf-test-site-cfmap")
+	choiceSetName := testutil.RandomName("tf-test-site-cfmap-choices")
+	cfInteger := testutil.RandomCustomFieldName("tf_site_map_int")
+	cfDecimal := testutil.RandomCustomFieldName("tf_site_map_dec")
+	cfBoolean := testutil.RandomCustomFieldName("tf_site_map_bool")
+	cfMultiselect := testutil.RandomCustomFieldName("tf_site_map_multi")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testutil.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: testutil.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSiteConfig_customFieldsMap(siteName, siteSlug, choiceSetName, cfInteger, cfDecimal, cfBoolean, cfMultiselect, "42", "12.5", "true"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("netbox_site.test", "custom_fields_map."+cfInteger, "42"),
+					resource.TestCheckResourceAttr("netbox_site.test", "custom_fields_map."+cfDecimal, "12.5"),
+					resource.TestCheckResourceAttr("netbox_site.test", "custom_fields_map."+cfBoolean, "true"),
+					resource.TestCheckResourceAttr("netbox_site.test", "custom_fields_map."+cfMultiselect+".#", "2"),
+					resource.TestCheckResourceAttr("netbox_site.test", "custom_fields_map."+cfMultiselect+".0", "edge, core"),
+				),
+			},
+			{
+				Config:   testAccSiteConfig_customFieldsMap(siteName, siteSlug, choiceSetName, cfInteger, cfDecimal, cfBoolean, cfMultiselect, "42", "12.5", "true"),
+				PlanOnly: true,
+			},
+			{
+				Config: testAccSiteConfig_customFieldsMap(siteName, siteSlug, choiceSetName, cfInteger, cfDecimal, cfBoolean, cfMultiselect, "48", "0.25", "null"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("netbox_site.test", "custom_fields_map."+cfInteger, "48"),
+					resource.TestCheckResourceAttr("netbox_site.test", "custom_fields_map."+cfDecimal, "0.25"),
+					resource.TestCheckNoResourceAttr("netbox_site.test", "custom_fields_map."+cfBoolean),
+				),
+			},
+			{
+				Config:   testAccSiteConfig_customFieldsMap(siteName, siteSlug, choiceSetName, cfInteger, cfDecimal, cfBoolean, cfMultiselect, "48", "0.25", "null"),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func testAccSiteConfig_customFieldsMap(
+	siteName, siteSlug, choiceSetName,
+	cfInteger, cfDecimal, cfBoolean, cfMultiselect,
+	integerValue, decimalValue, booleanValue string,
+) string {
+	return fmt.Sprintf(`
+resource "netbox_custom_field_choice_set" "roles" {
+  name = %[3]q
+  extra_choices = [
+    { value = "edge, core", label = "Edge and core" },
+    { value = "access",     label = "Access" },
+  ]
+}
+
+resource "netbox_custom_field" "integer" {
+  name         = %[4]q
+  type         = "integer"
+  object_types = ["dcim.site"]
+}
+
+resource "netbox_custom_field" "decimal" {
+  name         = %[5]q
+  type         = "decimal"
+  object_types = ["dcim.site"]
+  depends_on   = [netbox_custom_field.integer]
+}
+
+resource "netbox_custom_field" "boolean" {
+  name         = %[6]q
+  type         = "boolean"
+  object_types = ["dcim.site"]
+  depends_on   = [netbox_custom_field.decimal]
+}
+
+resource "netbox_custom_field" "multiselect" {
+  name         = %[7]q
+  type         = "multiselect"
+  object_types = ["dcim.site"]
+  choice_set   = netbox_custom_field_choice_set.roles.name
+  depends_on   = [netbox_custom_field.boolean]
+}
+
+resource "netbox_site" "test" {
+  name   = %[1]q
+  slug   = %[2]q
+  status = "active"
+
+  custom_fields_map = {
+    (netbox_custom_field.integer.name)     = %[8]s
+    (netbox_custom_field.decimal.name)     = %[9]s
+    (netbox_custom_field.boolean.name)     = %[10]s
+    (netbox_custom_field.multiselect.name) = ["edge, core", "access"]
+  }
+}
+`,
+		siteName, siteSlug, choiceSetName,
+		cfInteger, cfDecimal, cfBoolean, cfMultiselect,
+		integerValue, decimalValue, booleanValue,
+	)
+}
+
+// TestAccSiteResource_customFieldsPlanValidation tests that custom field values
+// are validated against their NetBox definitions at plan time.
+func TestAccSiteResource_customFieldsPlanValidation(t *testing.T) {
+	// NOTE: t.Parallel() intentionally omitted - this test creates/deletes global custom fields
+
+	siteName := testutil.RandomName("tf-test-site-cfcheck")
+	siteSlug := testutil.RandomSlug("tf-test-site-cfcheck")
+	cfInteger := testutil.RandomCustomFieldName("tf_site_check_int")
+	cfDevice := testutil.RandomCustomFieldName("tf_site_check_dev")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testutil.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: testutil.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSiteConfig_customFieldsPlanValidation(siteName, siteSlug, cfInteger, cfDevice, "custom_fields_map = { (netbox_custom_field.integer.name) = 42 }"),
+				Check:  resource.TestCheckResourceAttr("netbox_site.test", "custom_fields_map."+cfInteger, "42"),
+			},
+			{
+				Config:      testAccSiteConfig_customFieldsPlanValidation(siteName, siteSlug, cfInteger, cfDevice, "custom_fields_map = { (netbox_custom_field.integer.name) = 52 }"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("greater than the maximum of 48"),
+			},
+			{
+				Config: testAccSiteConfig_customFieldsPlanValidation(siteName, siteSlug, cfInteger, cfDevice, `custom_fields = [
+    { name = netbox_custom_field.integer.name, type = "text", value = "42" },
+  ]`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Custom field type mismatch"),
+			},
+			{
+				Config:      testAccSiteConfig_customFieldsPlanValidation(siteName, siteSlug, cfInteger, cfDevice, `custom_fields_map = { (netbox_custom_field.device.name) = "X" }`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("not assigned to dcim.site objects"),
+			},
+		},
+	})
+}
+
+func testAccSiteConfig_customFieldsPlanValidation(siteName, siteSlug, cfInteger, cfDevice, customFields string) string {
+	return fmt.Sprintf(`
+resource "netbox_custom_field" "integer" {
+  name               = %[3]q
+  type               = "integer"
+  object_types       = ["dcim.site"]
+  validation_minimum = 1
+  validation_maximum = 48
+}
+
+resource "netbox_custom_field" "device" {
+  name         = %[4]q
+  type         = "text"
+  object_types = ["dcim.device"]
+  depends_on   = [netbox_custom_field.integer]
+}
+
+resource "netbox_site" "test" {
+  name   = %[1]q
+  slug   = %[2]q
+  status = "active"
+
+  %[5]s
+}
+`, siteName, siteSlug, cfInteger, cfDevice, customFields)
+}
+
+// TestAccSiteResource_customFieldsObjectReferences tests that object and
+// multiobject custom fields accept references to objects by slug or ID and keep
+// the configured references in state.
+func TestAccSiteResource_customFieldsObjectReferences(t *testing.T) {
+	// NOTE: t.Parallel() intentionally omitted - this test creates/deletes global custom fields
+
+	siteName := testutil.RandomName("tf-test-site-cfref")
+	siteSlug := testutil.RandomSlug("tf-test-site-cfref")
+	tenantSlug := testutil.RandomSlug("tf-test-site-cfref-tenant")
+	cfObject := testutil.RandomCustomFieldName("tf_site_ref_obj")
+	cfMultiobject := testutil.RandomCustomFieldName("tf_site_ref_multi")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testutil.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: testutil.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSiteConfig_customFieldsObjectReferences(siteName, siteSlug, tenantSlug, cfObject, cfMultiobject),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("netbox_site.test", "custom_fields_map."+cfObject, tenantSlug+"-a"),
+					resource.TestCheckResourceAttr("netbox_site.test", "custom_fields_map."+cfMultiobject+".#", "2"),
+					resource.TestCheckResourceAttr("netbox_site.test", "custom_fields_map."+cfMultiobject+".0", tenantSlug+"-b"),
+					resource.TestCheckResourceAttrPair("netbox_site.test", "custom_fields_map."+cfMultiobject+".1", "netbox_tenant.a", "id"),
+					resource.TestCheckTypeSetElemNestedAttrs("netbox_site.set", "custom_fields.*", map[string]string{
+						"name":  cfObject,
+						"type":  "object",
+						"value": tenantSlug + "-b",
+					}),
+				),
+			},
+			{
+				Config:   testAccSiteConfig_customFieldsObjectReferences(siteName, siteSlug, tenantSlug, cfObject, cfMultiobject),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func testAccSiteConfig_customFieldsObjectReferences(siteName, siteSlug, tenantSlug, cfObject, cfMultiobject string) string {
+	return fmt.Sprintf(`
+resource "netbox_tenant" "a" {
+  name = "%[3]s-a"
+  slug = "%[3]s-a"
+}
+
+resource "netbox_tenant" "b" {
+  name = "%[3]s-b"
+  slug = "%[3]s-b"
+}
+
+resource "netbox_custom_field" "object" {
+  name                = %[4]q
+  type                = "object"
+  object_types        = ["dcim.site"]
+  related_object_type = "tenancy.tenant"
+}
+
+resource "netbox_custom_field" "multiobject" {
+  name                = %[5]q
+  type                = "multiobject"
+  object_types        = ["dcim.site"]
+  related_object_type = "tenancy.tenant"
+  depends_on          = [netbox_custom_field.object]
+}
+
+resource "netbox_site" "test" {
+  name   = %[1]q
+  slug   = %[2]q
+  status = "active"
+
+  custom_fields_map = {
+    (netbox_custom_field.object.name)      = netbox_tenant.a.slug
+    (netbox_custom_field.multiobject.name) = [netbox_tenant.b.slug, netbox_tenant.a.id]
+  }
+}
+
+resource "netbox_site" "set" {
+  name   = "%[1]s-set"
+  slug   = "%[2]s-set"
+  status = "active"
+
+  custom_fields = [
+    { name = netbox_custom_field.object.name, type = "object", value = netbox_tenant.b.slug },
+  ]
+}
+`, siteName, siteSlug, tenantSlug, cfObject, cfMultiobject)
+}
+
+// TestAccSiteResource_providerDefaults tests that the provider's default_tags
+// and default_custom_fields are applied to a site, shown in tags_all and
+// custom_fields_all, and not reported as drift in tags and custom_fields.
+func TestAccSiteResource_providerDefaults(t *testing.T) {
+	// NOTE: t.Parallel() intentionally omitted - this test creates/deletes global custom fields
+	// that would affect other tests of the same resource type running in parallel.
+
+	siteName := testutil.RandomName("tf-test-site-defaults")
+	siteSlug := testutil.RandomSlug("tf-test-site-defaults")
+	tagSlug := testutil.RandomSlug("tf-test-default-tag")
+	cfName := testutil.RandomCustomFieldName("tf_site_default")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testutil.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: testutil.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSiteConfig_providerDefaults(siteName, siteSlug, tagSlug, cfName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckNoResourceAttr("netbox_site.test", "tags.#"),
+					resource.TestCheckResourceAttr("netbox_site.test", "tags_all.#", "1"),
+					resource.TestCheckTypeSetElemAttr("netbox_site.test", "tags_all.*", tagSlug),
+					resource.TestCheckNoResourceAttr("netbox_site.test", "custom_fields.#"),
+					resource.TestCheckResourceAttr("netbox_site.test", "custom_fields_all."+cfName, "cc-100"),
+					resource.TestCheckResourceAttr("netbox_site.override", "custom_fields_all."+cfName, "cc-200"),
+				),
+			},
+			{
+				Config:   testAccSiteConfig_providerDefaults(siteName, siteSlug, tagSlug, cfName),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func testAccSiteConfig_providerDefaults(siteName, siteSlug, tagSlug, cfName string) string {
+	return fmt.Sprintf(`
+provider "netbox" {
+  default_tags          = [%[3]q]
+  default_custom_fields = { %[4]s = "cc-100" }
+}
+
+resource "netbox_tag" "default" {
+  name = %[3]q
+  slug = %[3]q
+}
+
+resource "netbox_custom_field" "default" {
+  name         = %[4]q
+  type         = "text"
+  object_types = ["dcim.site"]
+}
+
+resource "netbox_site" "test" {
+  name   = %[1]q
+  slug   = %[2]q
+  status = "active"
+
+  depends_on = [netbox_tag.default, netbox_custom_field.default]
+}
+
+resource "netbox_site" "override" {
+  name   = "%[1]s-override"
+  slug   = "%[2]s-override"
+  status = "active"
+
+  custom_fields_map = {
+    %[4]s = "cc-200"
+  }
+
+  depends_on = [netbox_tag.default, netbox_custom_field.default]
+}
+`, siteName, siteSlug, tagSlug, cfName)
+}
+
+// TestAccSiteResource_providerIgnore tests that tags matching the provider's
+// ignore_tags are left out of tags, listed in tags_all and not removed when
+// the site is updated.
+func TestAccSiteResource_providerIgnore(t *testing.T) {
+	t.Parallel()
+
+	siteName := testutil.RandomName("tf-test-site-ignore")
+	siteSlug := testutil.RandomSlug("tf-test-site-ignore")
+	tagSlug := testutil.RandomSlug("tf-test-managed")
+	ignoredSlug := testutil.RandomSlug("tf-test-discovered")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testutil.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: testutil.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSiteConfig_providerIgnore(siteName, siteSlug, tagSlug, ignoredSlug, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("netbox_site.test", "tags.#", "2"),
+				),
+			},
+			{
+				Config: testAccSiteConfig_providerIgnore(siteName, siteSlug, tagSlug, ignoredSlug, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("netbox_site.test", "tags.#", "1"),
+					resource.TestCheckTypeSetElemAttr("netbox_site.test", "tags.*", tagSlug),
+					resource.TestCheckResourceAttr("netbox_site.test", "tags_all.#", "2"),
+					resource.TestCheckTypeSetElemAttr("netbox_site.test", "tags_all.*", ignoredSlug),
+				),
+			},
+			{
+				// The data source is read again after the update above.
+				Config: testAccSiteConfig_providerIgnore(siteName, siteSlug, tagSlug, ignoredSlug, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.netbox_site.test", "tags.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccSiteConfig_providerIgnore(siteName, siteSlug, tagSlug, ignoredSlug string, ignore bool) string {
+	provider := ""
+	tags := "[netbox_tag.managed.slug, netbox_tag.discovered.slug]"
+	if ignore {
+		provider = fmt.Sprintf(`
+provider "netbox" {
+  ignore_tags = ["%s*"]
+}
+`, ignoredSlug)
+		tags = "[netbox_tag.managed.slug]"
+	}
+	return fmt.Sprintf(`%[5]s
+resource "netbox_tag" "managed" {
+  name = %[3]q
+  slug = %[3]q
+}
+
+resource "netbox_tag" "discovered" {
+  name = %[4]q
+  slug = %[4]q
+}
+
+resource "netbox_site" "test" {
+  name   = %[1]q
+  slug   = %[2]q
+  status = "active"
+  tags   = %[6]s
+}
+
+data "netbox_site" "test" {
+  id = netbox_site.test.id
+}
+`, siteName, siteSlug, tagSlug, ignoredSlug, provider, tags)
+}
@@ -0,0 +1,202 @@
+package resources_unit_tests
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bab3l/terraform-provider-netbox/internal/resources"
+	"github.com/bab3l/terraform-provider-netbox/internal/testutil"
+	"github.com/bab3l/terraform-provider-netbox/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	utils.BranchPollInterval = time.Millisecond
+}
+
+func TestBranchResource(t *testing.T) {
+	t.Parallel()
+
+	r := resources.NewBranchResource()
+	testutil.ValidateResourceMetadata(t, r, "netbox", "netbox_branch")
+	testutil.ValidateResourceConfigure(t, r)
+	testutil.ValidateResourceSchema(t, testutil.ResourceSchema(t, r).Attributes, testutil.SchemaValidation{
+		Required:         []string{"name"},
+		Optional:         []string{"description", "comments", "sync_triggers"},
+		OptionalComputed: []string{"merged"},
+		Computed:         []string{"id", "schema_id", "status", "last_sync", "merged_time"},
+	})
+}
+
+// fakeBranching serves branch 3 of the netbox-branching plugin. Jobs complete
+// at once, and the branch moves to the status of the last job after being
+// read once in the transitional status of the job.
+type fakeBranching struct {
+	t        *testing.T
+	mu       sync.Mutex
+	status   string
+	next     string
+	requests []string
+}
+
+func (f *fakeBranching) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests = append(f.requests, r.Method+" "+strings.TrimPrefix(r.URL.Path, "/"))
+	w.Header().Set("Content-Type", "application/json")
+
+	job := func(transitional, next string) {
+		f.status, f.next = transitional, next
+		_, _ = io.WriteString(w, `{"id": 8, "name": "job", "status": {"value": "pending"}}`)
+	}
+	switch path := r.URL.Path; {
+	case strings.HasSuffix(path, "/api/plugins/branching/branches/") && r.Method == http.MethodPost:
+		f.status, f.next = "new", "ready"
+		w.WriteHeader(http.StatusCreated)
+	case strings.HasSuffix(path, "/api/plugins/branching/branches/3/merge/"):
+		job("merging", "merged")
+		return
+	case strings.HasSuffix(path, "/api/plugins/branching/branches/3/revert/"):
+		job("reverting", "ready")
+		return
+	case strings.HasSuffix(path, "/api/plugins/branching/branches/3/sync/"):
+		job("syncing", "ready")
+		return
+	case strings.HasSuffix(path, "/api/core/jobs/8/"):
+		_, _ = io.WriteString(w, `{"id": 8, "name": "job", "status": {"value": "completed"}}`)
+		return
+	case strings.HasSuffix(path, "/api/plugins/branching/branches/3/"):
+	default:
+		f.t.Errorf("unexpected %s %s", r.Method, path)
+		return
+	}
+
+	merged := "null"
+	if f.status == "merged" {
+		merged = `"2024-05-02T08:00:00Z"`
+	}
+	_, _ = fmt.Fprintf(w, `{"id": 3, "name": "review", "description": "", "comments": "", "schema_id": "td5smq0f",
+		"status": {"value": %q, "label": "x"}, "last_sync": null, "merged_time": %s}`, f.status, merged)
+	if r.Method == http.MethodGet && f.next != "" {
+		f.status, f.next = f.next, ""
+	}
+}
+
+func branchValues(merged bool, triggers map[string]string) map[string]tftypes.Value {
+	triggerValues := map[string]tftypes.Value{}
+	for k, v := range triggers {
+		triggerValues[k] = tftypes.NewValue(tftypes.String, v)
+	}
+	return map[string]tftypes.Value{
+		"name":          tftypes.NewValue(tftypes.String, "review"),
+		"merged":        tftypes.NewValue(tftypes.Bool, merged),
+		"sync_triggers": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, triggerValues),
+	}
+}
+
+func TestBranchResource_Lifecycle(t *testing.T) {
+	t.Parallel()
+
+	server := &fakeBranching{t: t}
+	client := testutil.NewMockAPIClient(t, server)
+	r := resources.NewBranchResource()
+	testutil.ConfigureResource(t, r, client)
+	s := testutil.ResourceSchema(t, r)
+	ctx := context.Background()
+
+	// Create waits until the branch is provisioned.
+	plan := tfsdk.Plan{Schema: s, Raw: testutil.ResourceObjectValue(t, s, branchValues(false, nil))}
+	createResp := &fwresource.CreateResponse{State: tfsdk.State{Schema: s, Raw: tftypes.NewValue(plan.Raw.Type(), nil)}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan}, createResp)
+	require.False(t, createResp.Diagnostics.HasError(), createResp.Diagnostics.Errors())
+	var status, schemaID string
+	require.False(t, createResp.State.GetAttribute(ctx, path.Root("status"), &status).HasError())
+	require.False(t, createResp.State.GetAttribute(ctx, path.Root("schema_id"), &schemaID).HasError())
+	assert.Equal(t, "ready", status)
+	assert.Equal(t, "td5smq0f", schemaID)
+
+	update := func(state tfsdk.State, merged bool, triggers map[string]string) *fwresource.UpdateResponse {
+		t.Helper()
+		values := branchValues(merged, triggers)
+		values["id"] = tftypes.NewValue(tftypes.String, "3")
+		values["schema_id"] = tftypes.NewValue(tftypes.String, "td5smq0f")
+		plan := tfsdk.Plan{Schema: s, Raw: testutil.ResourceObjectValue(t, s, values)}
+		resp := &fwresource.UpdateResponse{State: state}
+		r.Update(ctx, fwresource.UpdateRequest{Plan: plan, State: state}, resp)
+		require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics.Errors())
+		return resp
+	}
+
+	server.requests = nil
+	synced := update(createResp.State, false, map[string]string{"review": "1"})
+	assert.Contains(t, server.requests, "POST api/plugins/branching/branches/3/sync/")
+
+	merged := update(synced.State, true, map[string]string{"review": "1"})
+	require.False(t, merged.State.GetAttribute(ctx, path.Root("status"), &status).HasError())
+	assert.Equal(t, "merged", status)
+	var mergedTime string
+	require.False(t, merged.State.GetAttribute(ctx, path.Root("merged_time"), &mergedTime).HasError())
+	assert.Equal(t, "2024-05-02T08:00:00Z", mergedTime)
+
+	server.requests = nil
+	reverted := update(merged.State, false, map[string]string{"review": "2"})
+	require.False(t, reverted.State.GetAttribute(ctx, path.Root("status"), &status).HasError())
+	assert.Equal(t, "ready", status)
+	assert.Contains(t, server.requests, "POST api/plugins/branching/branches/3/revert/")
+	assert.NotContains(t, server.requests, "POST api/plugins/branching/branches/3/sync/", "reverting is not combined with a sync")
+}
+
+func TestResourceBranchOverride(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	branches := map[string]string{}
+	client := testutil.NewMockAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		branches[r.Method] = r.Header.Get("X-NetBox-Branch")
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		var request map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&request)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"id": 1, "url": "http://netbox/api/dcim/manufacturers/1/", "display": "Cisco",
+			"name": "Cisco", "slug": "cisco", "description": request["description"],
+		})
+	}))
+	client.GetConfig().HTTPClient = &http.Client{Transport: &utils.ProviderTransport{Settings: utils.ProviderSettings{Branch: "main0000"}}}
+
+	r := resources.NewManufacturerResource()
+	testutil.ConfigureResource(t, r, client)
+	s := testutil.ResourceSchema(t, r)
+	values := func(description, branch string) tftypes.Value {
+		return testutil.ResourceObjectValue(t, s, map[string]tftypes.Value{
+			"id":          tftypes.NewValue(tftypes.String, "1"),
+			"name":        tftypes.NewValue(tftypes.String, "Cisco"),
+			"slug":        tftypes.NewValue(tftypes.String, "cisco"),
+			"description": tftypes.NewValue(tftypes.String, description),
+			"branch":      tftypes.NewValue(tftypes.String, branch),
+		})
+	}
+	state := tfsdk.State{Schema: s, Raw: values("old", "td5smq0f")}
+	plan := tfsdk.Plan{Schema: s, Raw: values("new", "td5smq0f")}
+	resp := &fwresource.UpdateResponse{State: state}
+	r.Update(context.Background(), fwresource.UpdateRequest{Plan: plan, State: state}, resp)
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics.Errors())
+
+	var branch string
+	require.False(t, resp.State.GetAttribute(context.Background(), path.Root("branch"), &branch).HasError())
+	assert.Equal(t, "td5smq0f", branch)
+	assert.Equal(t, "td5smq0f", branches[http.MethodPut], "the resource's branch overrides the provider's")
+}
@@ -2,11 +2,19 @@ package resources_unit_tests
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
 	"testing"
 
 	"github.com/bab3l/terraform-provider-netbox/internal/resources"
 	"github.com/bab3l/terraform-provider-netbox/internal/testutil"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	rsschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCableResource(t *testing.T) {
@@ -84,3 +92,236 @@ func TestCableResourceConfigure(t *testing.T) {
 	testutil.ValidateResourceConfigure(t, r)
 
 }
+
+// cableTerminationsValue builds the raw value of a terminations list from
+// termination attribute values; missing attributes are null.
+func cableTerminationsValue(t *testing.T, s rsschema.Schema, terminations ...map[string]tftypes.Value) tftypes.Value {
+	t.Helper()
+
+	listType, ok := s.Attributes["a_terminations"].GetType().TerraformType(context.Background()).(tftypes.List)
+	require.True(t, ok)
+	objectType, ok := listType.ElementType.(tftypes.Object)
+	require.True(t, ok)
+
+	elements := make([]tftypes.Value, 0, len(terminations))
+	for _, values := range terminations {
+		raw := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+		for name, attrType := range objectType.AttributeTypes {
+			if v, ok := values[name]; ok {
+				raw[name] = v
+				continue
+			}
+			raw[name] = tftypes.NewValue(attrType, nil)
+		}
+		elements = append(elements, tftypes.NewValue(objectType, raw))
+	}
+	return tftypes.NewValue(listType, elements)
+}
+
+func byObjectID(objectType string, id int) map[string]tftypes.Value {
+	return map[string]tftypes.Value{
+		"object_type": tftypes.NewValue(tftypes.String, objectType),
+		"object_id":   tftypes.NewValue(tftypes.Number, id),
+	}
+}
+
+func byDevicePort(device, attribute, name string) map[string]tftypes.Value {
+	return map[string]tftypes.Value{
+		"device":  tftypes.NewValue(tftypes.String, device),
+		attribute: tftypes.NewValue(tftypes.String, name),
+	}
+}
+
+func TestCableResourceValidateConfig(t *testing.T) {
+	t.Parallel()
+
+	r := resources.NewCableResource()
+	s := testutil.ResourceSchema(t, r)
+	validator := r.(fwresource.ResourceWithValidateConfig)
+
+	cases := map[string]struct {
+		a, b      []map[string]tftypes.Value
+		expectErr string
+	}{
+		"object type and id": {
+			a: []map[string]tftypes.Value{byObjectID("dcim.interface", 1)},
+			b: []map[string]tftypes.Value{byObjectID("dcim.interface", 2)},
+		},
+		"device and port names": {
+			a: []map[string]tftypes.Value{byDevicePort("sw1", "interface", "eth0"), byDevicePort("sw1", "interface", "eth1")},
+			b: []map[string]tftypes.Value{byDevicePort("pp1", "front_port", "Front 1"), byObjectID("dcim.frontport", 7)},
+		},
+		"circuit": {
+			a: []map[string]tftypes.Value{{
+				"circuit":   tftypes.NewValue(tftypes.String, "CID-1"),
+				"term_side": tftypes.NewValue(tftypes.String, "A"),
+			}},
+			b: []map[string]tftypes.Value{byDevicePort("sw1", "interface", "eth0")},
+		},
+		"unknown device": {
+			a: []map[string]tftypes.Value{{
+				"device":    tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+				"interface": tftypes.NewValue(tftypes.String, "eth0"),
+			}},
+			b: []map[string]tftypes.Value{byObjectID("dcim.interface", 2)},
+		},
+		"mixed types on one end": {
+			a:         []map[string]tftypes.Value{byDevicePort("sw1", "interface", "eth0"), byDevicePort("sw1", "console_port", "con0")},
+			b:         []map[string]tftypes.Value{byObjectID("dcim.interface", 2)},
+			expectErr: "Mixed cable termination types",
+		},
+		"mixed forms with different types": {
+			a:         []map[string]tftypes.Value{byObjectID("dcim.interface", 2)},
+			b:         []map[string]tftypes.Value{byObjectID("dcim.rearport", 3), byDevicePort("pp1", "front_port", "Front 1")},
+			expectErr: "Mixed cable termination types",
+		},
+		"port without device": {
+			a:         []map[string]tftypes.Value{{"interface": tftypes.NewValue(tftypes.String, "eth0")}},
+			b:         []map[string]tftypes.Value{byObjectID("dcim.interface", 2)},
+			expectErr: "Invalid cable termination",
+		},
+		"device with two ports": {
+			a: []map[string]tftypes.Value{{
+				"device":    tftypes.NewValue(tftypes.String, "sw1"),
+				"interface": tftypes.NewValue(tftypes.String, "eth0"),
+				"rear_port": tftypes.NewValue(tftypes.String, "Rear 1"),
+			}},
+			b:         []map[string]tftypes.Value{byObjectID("dcim.interface", 2)},
+			expectErr: "Invalid cable termination",
+		},
+		"object type and device": {
+			a: []map[string]tftypes.Value{{
+				"object_type": tftypes.NewValue(tftypes.String, "dcim.interface"),
+				"device":      tftypes.NewValue(tftypes.String, "sw1"),
+				"interface":   tftypes.NewValue(tftypes.String, "eth0"),
+			}},
+			b:         []map[string]tftypes.Value{byObjectID("dcim.interface", 2)},
+			expectErr: "Invalid cable termination",
+		},
+		"object type without id": {
+			a:         []map[string]tftypes.Value{{"object_type": tftypes.NewValue(tftypes.String, "dcim.interface")}},
+			b:         []map[string]tftypes.Value{byObjectID("dcim.interface", 2)},
+			expectErr: "Invalid cable termination",
+		},
+		"circuit without side": {
+			a:         []map[string]tftypes.Value{{"circuit": tftypes.NewValue(tftypes.String, "CID-1")}},
+			b:         []map[string]tftypes.Value{byObjectID("dcim.interface", 2)},
+			expectErr: "Invalid cable termination",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			config := tfsdk.Config{Schema: s, Raw: testutil.ResourceObjectValue(t, s, map[string]tftypes.Value{
+				"a_terminations": cableTerminationsValue(t, s, tc.a...),
+				"b_terminations": cableTerminationsValue(t, s, tc.b...),
+			})}
+			resp := &fwresource.ValidateConfigResponse{}
+			validator.ValidateConfig(context.Background(), fwresource.ValidateConfigRequest{Config: config}, resp)
+			if tc.expectErr == "" {
+				assert.False(t, resp.Diagnostics.HasError(), "diagnostics: %v", resp.Diagnostics)
+				return
+			}
+			require.True(t, resp.Diagnostics.HasError())
+			assert.Equal(t, tc.expectErr, resp.Diagnostics.Errors()[0].Summary())
+		})
+	}
+}
+
+func TestCableResourceModifyPlan(t *testing.T) {
+	t.Parallel()
+
+	client := testutil.NewMockAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		var body interface{}
+		switch r.URL.Path {
+		case "/api/dcim/interfaces/":
+			results := []interface{}{}
+			if query.Get("device") == "sw1" && query.Get("name") == "eth0" {
+				results = append(results, map[string]interface{}{"id": 10, "name": "eth0"})
+			}
+			body = map[string]interface{}{"count": len(results), "results": results}
+		case "/api/dcim/interfaces/10/":
+			body = map[string]interface{}{"id": 10, "display": "eth0", "cable": nil}
+		case "/api/dcim/front-ports/20/":
+			body = map[string]interface{}{"id": 20, "display": "Front 1", "cable": map[string]interface{}{"id": 5}}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"detail": "Not found."}`))
+			return
+		}
+		_ = json.NewEncoder(w).Encode(body)
+	}))
+
+	r := resources.NewCableResource()
+	testutil.ConfigureResource(t, r, client)
+	s := testutil.ResourceSchema(t, r)
+	modifier := r.(fwresource.ResourceWithModifyPlan)
+
+	plan := func(a, b map[string]tftypes.Value, id interface{}) tftypes.Value {
+		return testutil.ResourceObjectValue(t, s, map[string]tftypes.Value{
+			"id":             tftypes.NewValue(tftypes.String, id),
+			"a_terminations": cableTerminationsValue(t, s, a),
+			"b_terminations": cableTerminationsValue(t, s, b),
+		})
+	}
+	modifyPlan := func(planned tftypes.Value, stateID interface{}) *fwresource.ModifyPlanResponse {
+		state := tftypes.NewValue(s.Type().TerraformType(context.Background()), nil)
+		if stateID != nil {
+			state = plan(byObjectID("dcim.interface", 10), byObjectID("dcim.frontport", 20), stateID)
+		}
+		resp := &fwresource.ModifyPlanResponse{Plan: tfsdk.Plan{Schema: s, Raw: planned}}
+		modifier.ModifyPlan(context.Background(), fwresource.ModifyPlanRequest{
+			Plan:  tfsdk.Plan{Schema: s, Raw: planned},
+			State: tfsdk.State{Schema: s, Raw: state},
+		}, resp)
+		return resp
+	}
+	unknownID := map[string]tftypes.Value{
+		"object_type": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"object_id":   tftypes.NewValue(tftypes.Number, tftypes.UnknownValue),
+	}
+	byName := byDevicePort("sw1", "interface", "eth0")
+	for k, v := range unknownID {
+		byName[k] = v
+	}
+
+	t.Run("resolves names and rejects cabled terminations", func(t *testing.T) {
+		t.Parallel()
+
+		resp := modifyPlan(plan(byName, byObjectID("dcim.frontport", 20), tftypes.UnknownValue), nil)
+		require.True(t, resp.Diagnostics.HasError())
+		assert.Equal(t, "Termination already cabled", resp.Diagnostics.Errors()[0].Summary())
+		assert.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "already connected to cable 5")
+	})
+
+	t.Run("allows terminations of the cable itself", func(t *testing.T) {
+		t.Parallel()
+
+		resp := modifyPlan(plan(byName, byObjectID("dcim.frontport", 20), "5"), "5")
+		require.False(t, resp.Diagnostics.HasError(), "diagnostics: %v", resp.Diagnostics)
+
+		var objectType string
+		var objectID int64
+		var device string
+		require.False(t, resp.Plan.GetAttribute(context.Background(), path.Root("a_terminations").AtListIndex(0).AtName("object_type"), &objectType).HasError())
+		require.False(t, resp.Plan.GetAttribute(context.Background(), path.Root("a_terminations").AtListIndex(0).AtName("object_id"), &objectID).HasError())
+		require.False(t, resp.Plan.GetAttribute(context.Background(), path.Root("a_terminations").AtListIndex(0).AtName("device"), &device).HasError())
+		assert.Equal(t, "dcim.interface", objectType)
+		assert.Equal(t, int64(10), objectID)
+		assert.Equal(t, "sw1", device)
+	})
+
+	t.Run("unknown port", func(t *testing.T) {
+		t.Parallel()
+
+		missing := byDevicePort("sw1", "interface", "eth9")
+		for k, v := range unknownID {
+			missing[k] = v
+		}
+		resp := modifyPlan(plan(missing, byObjectID("dcim.interface", 10), tftypes.UnknownValue), nil)
+		require.True(t, resp.Diagnostics.HasError())
+		assert.Equal(t, "Termination not found", resp.Diagnostics.Errors()[0].Summary())
+	})
+}
@@ -2,11 +2,22 @@ package resources_unit_tests
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
 	"testing"
 
 	"github.com/bab3l/terraform-provider-netbox/internal/resources"
 	"github.com/bab3l/terraform-provider-netbox/internal/testutil"
+	"github.com/bab3l/terraform-provider-netbox/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCircuitGroupAssignmentResource(t *testing.T) {
@@ -53,7 +64,7 @@ func TestCircuitGroupAssignmentResourceSchema(t *testing.T) {
 
 		Optional: []string{"priority", "tags", "custom_fields"},
 
-		Computed: []string{"id"},
+		Computed: []string{"id", "tags_all"},
 	})
 
 }
@@ -77,3 +88,196 @@ func TestCircuitGroupAssignmentResourceConfigure(t *testing.T) {
 	testutil.ValidateResourceConfigure(t, r)
 
 }
+
+// fakeCircuitGroupAssignmentAPI serves a circuit group, a circuit, tags and
+// the circuit group assignments created from them, recording the tag slugs
+// of every write request.
+type fakeCircuitGroupAssignmentAPI struct {
+	mu         sync.Mutex
+	assignment map[string]interface{}
+	writes     [][]string
+}
+
+func (f *fakeCircuitGroupAssignmentAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	nested := func(kind string, id int, fields map[string]interface{}) map[string]interface{} {
+		fields["id"] = id
+		fields["url"] = fmt.Sprintf("http://netbox/api/%s/%d/", kind, id)
+		fields["display"] = fmt.Sprintf("%s-%d", kind, id)
+		return fields
+	}
+	tag := func(slug string) map[string]interface{} {
+		return nested("extras/tags", len(slug), map[string]interface{}{"name": slug, "slug": slug})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	switch {
+	case r.URL.Path == "/api/circuits/circuit-groups/5/":
+		_ = json.NewEncoder(w).Encode(nested("circuits/circuit-groups", 5, map[string]interface{}{"name": "Group", "slug": "group"}))
+	case r.URL.Path == "/api/circuits/circuits/7/":
+		_ = json.NewEncoder(w).Encode(nested("circuits/circuits", 7, map[string]interface{}{
+			"cid":           "CID-7",
+			"provider":      nested("circuits/providers", 1, map[string]interface{}{"name": "Provider", "slug": "provider"}),
+			"type":          nested("circuits/circuit-types", 1, map[string]interface{}{"name": "Type", "slug": "type"}),
+			"termination_a": nil,
+			"termination_z": nil,
+		}))
+	case r.URL.Path == "/api/extras/tags/":
+		result := tag(r.URL.Query().Get("slug"))
+		result["tagged_items"] = 0
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"count": 1, "results": []interface{}{result}})
+	case (r.URL.Path == "/api/circuits/circuit-group-assignments/" && r.Method == http.MethodPost) ||
+		(r.URL.Path == "/api/circuits/circuit-group-assignments/1/" && r.Method == http.MethodPut):
+		var body struct {
+			Tags []struct {
+				Slug string `json:"slug"`
+			} `json:"tags"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		slugs := []string{}
+		tags := []interface{}{}
+		for _, t := range body.Tags {
+			slugs = append(slugs, t.Slug)
+			tags = append(tags, tag(t.Slug))
+		}
+		f.writes = append(f.writes, slugs)
+		f.assignment = nested("circuits/circuit-group-assignments", 1, map[string]interface{}{
+			"group":   nested("circuits/circuit-groups", 5, map[string]interface{}{"name": "Group"}),
+			"circuit": nested("circuits/circuits", 7, map[string]interface{}{"cid": "CID-7", "provider": nested("circuits/providers", 1, map[string]interface{}{"name": "Provider", "slug": "provider"})}),
+			"tags":    tags,
+		})
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusCreated)
+		}
+		_ = json.NewEncoder(w).Encode(f.assignment)
+	case r.URL.Path == "/api/circuits/circuit-group-assignments/1/" && f.assignment != nil:
+		_ = json.NewEncoder(w).Encode(f.assignment)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"detail": "Not found."}`))
+	}
+}
+
+func stringSetValue(values ...string) tftypes.Value {
+	elements := make([]tftypes.Value, 0, len(values))
+	for _, v := range values {
+		elements = append(elements, tftypes.NewValue(tftypes.String, v))
+	}
+	return tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, elements)
+}
+
+func stateStrings(t *testing.T, state tfsdk.State, name string) []string {
+	t.Helper()
+	var values []string
+	require.False(t, state.GetAttribute(context.Background(), path.Root(name), &values).HasError())
+	return values
+}
+
+// TestCircuitGroupAssignmentResourceDefaultTags tests that the provider's
+// default tags are sent with the configured tags and show up in tags_all only.
+func TestCircuitGroupAssignmentResourceDefaultTags(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	api := &fakeCircuitGroupAssignmentAPI{}
+	client := testutil.NewMockAPIClient(t, api)
+	utils.SetProviderSettings(client, utils.ProviderSettings{DefaultTags: []string{"managed"}})
+
+	r := resources.NewCircuitGroupAssignmentResource()
+	testutil.ConfigureResource(t, r, client)
+	s := testutil.ResourceSchema(t, r)
+
+	plan := tfsdk.Plan{Schema: s, Raw: testutil.ResourceObjectValue(t, s, map[string]tftypes.Value{
+		"id":         tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"group_id":   tftypes.NewValue(tftypes.String, "5"),
+		"circuit_id": tftypes.NewValue(tftypes.String, "7"),
+		"tags":       stringSetValue("edge"),
+		"tags_all":   tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, tftypes.UnknownValue),
+	})}
+
+	createResp := &fwresource.CreateResponse{State: tfsdk.State{Schema: s, Raw: plan.Raw}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan}, createResp)
+	require.False(t, createResp.Diagnostics.HasError(), "Create returned errors: %v", createResp.Diagnostics)
+
+	require.Len(t, api.writes, 1)
+	assert.ElementsMatch(t, []string{"edge", "managed"}, api.writes[0])
+	assert.Equal(t, []string{"edge"}, stateStrings(t, createResp.State, "tags"))
+	assert.ElementsMatch(t, []string{"edge", "managed"}, stateStrings(t, createResp.State, "tags_all"))
+
+	readResp := &fwresource.ReadResponse{State: createResp.State}
+	r.Read(ctx, fwresource.ReadRequest{State: createResp.State}, readResp)
+	require.False(t, readResp.Diagnostics.HasError(), "Read returned errors: %v", readResp.Diagnostics)
+	assert.Equal(t, []string{"edge"}, stateStrings(t, readResp.State, "tags"))
+}
+
+// TestCircuitGroupAssignmentResourceIgnoredTags tests that an update keeps
+// the ignored tags of the assignment, and that they are left out of tags.
+func TestCircuitGroupAssignmentResourceIgnoredTags(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	api := &fakeCircuitGroupAssignmentAPI{}
+	client := testutil.NewMockAPIClient(t, api)
+	utils.SetProviderSettings(client, utils.ProviderSettings{IgnoreTags: []string{"ext-*"}})
+
+	r := resources.NewCircuitGroupAssignmentResource()
+	testutil.ConfigureResource(t, r, client)
+	s := testutil.ResourceSchema(t, r)
+
+	values := map[string]tftypes.Value{
+		"id":         tftypes.NewValue(tftypes.String, "1"),
+		"group_id":   tftypes.NewValue(tftypes.String, "5"),
+		"circuit_id": tftypes.NewValue(tftypes.String, "7"),
+		"tags":       stringSetValue("edge"),
+		"tags_all":   stringSetValue("edge", "ext-owner"),
+	}
+	state := tfsdk.State{Schema: s, Raw: testutil.ResourceObjectValue(t, s, values)}
+	values["tags"] = stringSetValue("core")
+	values["tags_all"] = tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, tftypes.UnknownValue)
+	plan := tfsdk.Plan{Schema: s, Raw: testutil.ResourceObjectValue(t, s, values)}
+
+	updateResp := &fwresource.UpdateResponse{State: tfsdk.State{Schema: s, Raw: plan.Raw}}
+	r.Update(ctx, fwresource.UpdateRequest{Plan: plan, State: state}, updateResp)
+	require.False(t, updateResp.Diagnostics.HasError(), "Update returned errors: %v", updateResp.Diagnostics)
+
+	require.Len(t, api.writes, 1)
+	assert.ElementsMatch(t, []string{"core", "ext-owner"}, api.writes[0])
+	assert.Equal(t, []string{"core"}, stateStrings(t, updateResp.State, "tags"))
+	assert.ElementsMatch(t, []string{"core", "ext-owner"}, stateStrings(t, updateResp.State, "tags_all"))
+}
+
+// TestCircuitGroupAssignmentResourceUpgradeState tests that the tag objects
+// of version 0 become tag slugs.
+func TestCircuitGroupAssignmentResourceUpgradeState(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	r := resources.NewCircuitGroupAssignmentResource()
+	s := testutil.ResourceSchema(t, r)
+	upgrader, ok := r.(fwresource.ResourceWithUpgradeState).UpgradeState(ctx)[0]
+	require.True(t, ok, "expected a state upgrader from version 0")
+
+	tagType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{"name": tftypes.String, "slug": tftypes.String}}
+	prior := tfsdk.State{Schema: *upgrader.PriorSchema, Raw: testutil.ResourceObjectValue(t, *upgrader.PriorSchema, map[string]tftypes.Value{
+		"id":         tftypes.NewValue(tftypes.String, "1"),
+		"group_id":   tftypes.NewValue(tftypes.String, "5"),
+		"circuit_id": tftypes.NewValue(tftypes.String, "7"),
+		"tags": tftypes.NewValue(tftypes.Set{ElementType: tagType}, []tftypes.Value{
+			tftypes.NewValue(tagType, map[string]tftypes.Value{
+				"name": tftypes.NewValue(tftypes.String, "Edge"),
+				"slug": tftypes.NewValue(tftypes.String, "edge"),
+			}),
+		}),
+	})}
+
+	resp := &fwresource.UpgradeStateResponse{State: tfsdk.State{Schema: s}}
+	upgrader.StateUpgrader(ctx, fwresource.UpgradeStateRequest{State: &prior}, resp)
+	require.False(t, resp.Diagnostics.HasError(), "UpgradeState returned errors: %v", resp.Diagnostics)
+
+	var id types.String
+	require.False(t, resp.State.GetAttribute(ctx, path.Root("id"), &id).HasError())
+	assert.Equal(t, "1", id.ValueString())
+	assert.Equal(t, []string{"edge"}, stateStrings(t, resp.State, "tags"))
+}
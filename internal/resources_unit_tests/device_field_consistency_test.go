@@ -27,7 +27,7 @@ func TestDeviceResourceSchema_FieldConsistency(t *testing.T) {
 		Required:         []string{"site", "device_type", "role"},
 		Optional:         []string{"name", "description", "comments", "tenant", "platform", "serial", "asset_tag", "rack", "position", "face", "latitude", "longitude", "airflow", "vc_position", "vc_priority", "tags", "custom_fields"},
 		Computed:         []string{"id"},
-		OptionalComputed: []string{"status"},
+		OptionalComputed: []string{"status", "virtual_chassis"},
 	})
 }
 
@@ -0,0 +1,458 @@
+package resources_unit_tests
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/bab3l/terraform-provider-netbox/internal/resources"
+	"github.com/bab3l/terraform-provider-netbox/internal/testutil"
+	"github.com/bab3l/terraform-provider-netbox/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	rsschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeviceInterfacesResource(t *testing.T) {
+	t.Parallel()
+
+	r := resources.NewDeviceInterfacesResource()
+	testutil.ValidateResourceMetadata(t, r, "netbox", "netbox_device_interfaces")
+	testutil.ValidateResourceConfigure(t, r)
+
+	s := testutil.ResourceSchema(t, r)
+	testutil.ValidateResourceSchema(t, s.Attributes, testutil.SchemaValidation{
+		Required:         []string{"device", "interfaces"},
+		Computed:         []string{"id", "interface_ids", "adopted_interfaces"},
+		OptionalComputed: []string{"authoritative"},
+	})
+}
+
+// deviceInterface builds an interfaces map entry with the schema defaults.
+func deviceInterface(interfaceType string) resources.DeviceInterfaceModel {
+	return resources.DeviceInterfaceModel{
+		Type:          types.StringValue(interfaceType),
+		Label:         types.StringNull(),
+		Enabled:       types.BoolValue(true),
+		Parent:        types.StringNull(),
+		Bridge:        types.StringNull(),
+		Lag:           types.StringNull(),
+		Description:   types.StringNull(),
+		Mtu:           types.Int64Null(),
+		MacAddress:    types.StringNull(),
+		Speed:         types.Int64Null(),
+		Duplex:        types.StringNull(),
+		Wwn:           types.StringNull(),
+		MgmtOnly:      types.BoolValue(false),
+		MarkConnected: types.BoolValue(false),
+		Mode:          types.StringNull(),
+		UntaggedVLAN:  types.StringNull(),
+		TaggedVLANs:   types.SetNull(types.StringType),
+		Vrf:           types.StringNull(),
+		PoeMode:       types.StringNull(),
+		PoeType:       types.StringNull(),
+		RfRole:        types.StringNull(),
+		RfChannel:     types.StringNull(),
+		TxPower:       types.Int64Null(),
+		WirelessLans:  types.SetNull(types.Int64Type),
+		Vdcs:          types.SetNull(types.Int64Type),
+		Tags:          types.SetNull(types.StringType),
+		CustomFields:  types.SetNull(utils.GetCustomFieldsAttributeType().ElemType),
+	}
+}
+
+func deviceInterfacesModel(t *testing.T, s rsschema.Schema, authoritative bool, interfaces map[string]resources.DeviceInterfaceModel) resources.DeviceInterfacesResourceModel {
+	t.Helper()
+
+	elemType := s.Attributes["interfaces"].GetType().(types.MapType).ElemType
+	interfacesValue, diags := types.MapValueFrom(context.Background(), elemType, interfaces)
+	require.False(t, diags.HasError(), "%v", diags)
+	return resources.DeviceInterfacesResourceModel{
+		ID:                types.StringValue("1"),
+		Device:            types.StringValue("1"),
+		Authoritative:     types.BoolValue(authoritative),
+		Interfaces:        interfacesValue,
+		InterfaceIDs:      types.MapUnknown(types.StringType),
+		AdoptedInterfaces: types.SetUnknown(types.StringType),
+	}
+}
+
+func emptyResourceState(s rsschema.Schema) tfsdk.State {
+	return tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(context.Background()), nil)}
+}
+
+// deviceInterfacesMock serves the interfaces of device 1 and the tags, and
+// records the bodies of bulk write requests by method. Created interfaces are
+// added to the device with IDs from 100.
+type deviceInterfacesMock struct {
+	mu         sync.Mutex
+	interfaces []map[string]interface{}
+	requests   map[string][]map[string]interface{}
+}
+
+func newDeviceInterfacesMock(t *testing.T, interfaces ...map[string]interface{}) (*deviceInterfacesMock, http.Handler) {
+	m := &deviceInterfacesMock{interfaces: interfaces, requests: map[string][]map[string]interface{}{}}
+	return m, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/extras/tags/":
+			slug := r.URL.Query().Get("slug")
+			tag := map[string]interface{}{"id": 1, "url": "http://netbox/api/extras/tags/1/", "display": slug, "name": strings.ToUpper(slug), "slug": slug, "tagged_items": 0}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"count": 1, "results": []interface{}{tag}})
+			return
+		case "/api/dcim/devices/1/":
+			brief := func(kind string, fields map[string]interface{}) map[string]interface{} {
+				fields["id"], fields["url"], fields["display"] = 1, "http://netbox/api/"+kind+"/1/", kind
+				return fields
+			}
+			_ = json.NewEncoder(w).Encode(brief("dcim/devices", map[string]interface{}{
+				"name": "switch1",
+				"device_type": brief("dcim/device-types", map[string]interface{}{
+					"model": "Switch", "slug": "switch",
+					"manufacturer": brief("dcim/manufacturers", map[string]interface{}{"name": "Vendor", "slug": "vendor"}),
+				}),
+				"role":          brief("dcim/device-roles", map[string]interface{}{"name": "Access", "slug": "access"}),
+				"site":          brief("dcim/sites", map[string]interface{}{"name": "Site", "slug": "site"}),
+				"parent_device": nil, "primary_ip": nil, "console_port_count": 0, "console_server_port_count": 0,
+				"power_port_count": 0, "power_outlet_count": 0, "interface_count": len(m.interfaces), "front_port_count": 0,
+				"rear_port_count": 0, "device_bay_count": 0, "module_bay_count": 0, "inventory_item_count": 0,
+			}))
+			return
+		case "/api/dcim/interfaces/":
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.Method != http.MethodGet {
+			body, _ := io.ReadAll(r.Body)
+			var payloads []map[string]interface{}
+			require.NoError(t, json.Unmarshal(body, &payloads))
+			m.requests[r.Method] = append(m.requests[r.Method], payloads...)
+			if r.Method == http.MethodPost {
+				for i, payload := range payloads {
+					id := 100 + len(m.requests[r.Method]) - len(payloads) + i
+					m.interfaces = append(m.interfaces, apiInterface(id, payload["name"].(string), payload["type"].(string), true))
+				}
+			}
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte("[]"))
+			return
+		}
+		assert.Equal(t, "1", r.URL.Query().Get("device_id"))
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"count": len(m.interfaces), "results": m.interfaces})
+	})
+}
+
+func apiInterface(id int, name, interfaceType string, enabled bool) map[string]interface{} {
+	return map[string]interface{}{
+		"id": id, "url": fmt.Sprintf("http://netbox/api/dcim/interfaces/%d/", id), "display": name,
+		"device": map[string]interface{}{"id": 1, "url": "http://netbox/api/dcim/devices/1/", "display": "switch1", "name": "switch1"},
+		"name":   name, "type": map[string]interface{}{"value": interfaceType},
+		"label": "", "enabled": enabled, "description": "", "mtu": nil, "speed": nil, "duplex": nil,
+		"mgmt_only": false, "mark_connected": false, "mode": nil, "poe_mode": nil, "poe_type": nil,
+		"cable": nil, "wireless_link": nil, "link_peers": []interface{}{}, "link_peers_type": nil, "l2vpn_termination": nil,
+		"connected_endpoints": nil, "connected_endpoints_type": nil, "connected_endpoints_reachable": nil,
+		"count_ipaddresses": 0, "count_fhrp_groups": 0, "_occupied": false, "tags": []interface{}{}, "custom_fields": map[string]interface{}{},
+	}
+}
+
+func TestDeviceInterfacesResourceRead(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	for name, authoritative := range map[string]bool{"non-authoritative": false, "authoritative": true} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			_, handler := newDeviceInterfacesMock(t,
+				apiInterface(10, "ge-0/0/0", "1000base-t", true),
+				apiInterface(11, "ge-0/0/1", "1000base-t", false),
+				apiInterface(20, "mgmt0", "1000base-t", true),
+				apiInterface(30, "xe-0/0/0", "10gbase-x-sfpp", true),
+			)
+			r := resources.NewDeviceInterfacesResource()
+			testutil.ConfigureResource(t, r, testutil.NewMockAPIClient(t, handler))
+			s := testutil.ResourceSchema(t, r)
+
+			state := emptyResourceState(s)
+			require.False(t, state.Set(ctx, deviceInterfacesModel(t, s, authoritative, map[string]resources.DeviceInterfaceModel{
+				"ge-0/0/[0-1]": deviceInterface("1000base-t"),
+				"mgmt0":        deviceInterface("1000base-t"),
+				"ge-0/0/[2-3]": deviceInterface("1000base-t"),
+			})).HasError())
+			resp := &fwresource.ReadResponse{State: state}
+			r.Read(ctx, fwresource.ReadRequest{State: state}, resp)
+			require.False(t, resp.Diagnostics.HasError(), "Read returned errors: %v", resp.Diagnostics)
+
+			var data resources.DeviceInterfacesResourceModel
+			require.False(t, resp.State.Get(ctx, &data).HasError())
+			var interfaces map[string]resources.DeviceInterfaceModel
+			require.False(t, data.Interfaces.ElementsAs(ctx, &interfaces, false).HasError())
+			var ids map[string]string
+			require.False(t, data.InterfaceIDs.ElementsAs(ctx, &ids, false).HasError())
+
+			// A drifted member is reported for the whole pattern.
+			assert.False(t, interfaces["ge-0/0/[0-1]"].Enabled.ValueBool())
+			assert.True(t, interfaces["mgmt0"].Enabled.ValueBool())
+			// Patterns with missing interfaces are dropped so they are re-created.
+			assert.NotContains(t, interfaces, "ge-0/0/[2-3]")
+
+			if authoritative {
+				assert.Equal(t, "10gbase-x-sfpp", interfaces["xe-0/0/0"].Type.ValueString())
+				assert.Equal(t, map[string]string{"ge-0/0/0": "10", "ge-0/0/1": "11", "mgmt0": "20", "xe-0/0/0": "30"}, ids)
+			} else {
+				assert.NotContains(t, interfaces, "xe-0/0/0")
+				assert.Equal(t, map[string]string{"ge-0/0/0": "10", "ge-0/0/1": "11", "mgmt0": "20"}, ids)
+			}
+		})
+	}
+}
+
+func TestDeviceInterfacesResourceUpdate(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	for name, authoritative := range map[string]bool{"non-authoritative": false, "authoritative": true} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			mock, handler := newDeviceInterfacesMock(t,
+				apiInterface(10, "ge-0/0/0", "1000base-t", true),
+				apiInterface(11, "ge-0/0/1", "1000base-t", false),
+				apiInterface(12, "ge-0/0/2", "1000base-t", true),
+				apiInterface(30, "xe-0/0/0", "10gbase-x-sfpp", true),
+			)
+			r := resources.NewDeviceInterfacesResource()
+			testutil.ConfigureResource(t, r, testutil.NewMockAPIClient(t, handler))
+			s := testutil.ResourceSchema(t, r)
+
+			state := emptyResourceState(s)
+			prior := deviceInterfacesModel(t, s, authoritative, map[string]resources.DeviceInterfaceModel{
+				"ge-0/0/[0-2]": deviceInterface("1000base-t"),
+			})
+			prior.InterfaceIDs = types.MapValueMust(types.StringType, map[string]attr.Value{
+				"ge-0/0/0": types.StringValue("10"), "ge-0/0/1": types.StringValue("11"), "ge-0/0/2": types.StringValue("12"),
+			})
+			prior.AdoptedInterfaces = types.SetValueMust(types.StringType, []attr.Value{})
+			require.False(t, state.Set(ctx, prior).HasError())
+			plan := tfsdk.Plan{Schema: s, Raw: state.Raw.Copy()}
+			access := deviceInterface("1000base-t")
+			access.Mode = types.StringValue("access")
+			require.False(t, plan.Set(ctx, deviceInterfacesModel(t, s, authoritative, map[string]resources.DeviceInterfaceModel{
+				"ge-0/0/0":     deviceInterface("1000base-t"),
+				"ge-0/0/[1,3]": access,
+			})).HasError())
+
+			resp := &fwresource.UpdateResponse{State: state}
+			r.Update(ctx, fwresource.UpdateRequest{State: state, Plan: plan}, resp)
+			require.False(t, resp.Diagnostics.HasError(), "Update returned errors: %v", resp.Diagnostics)
+
+			// ge-0/0/0 is unchanged, ge-0/0/1 changes, ge-0/0/3 is new and ge-0/0/2 is no longer listed.
+			require.Len(t, mock.requests[http.MethodPatch], 1)
+			assert.Equal(t, float64(11), mock.requests[http.MethodPatch][0]["id"])
+			assert.Equal(t, "access", mock.requests[http.MethodPatch][0]["mode"])
+			assert.Equal(t, true, mock.requests[http.MethodPatch][0]["enabled"])
+			require.Len(t, mock.requests[http.MethodPost], 1)
+			assert.Equal(t, "ge-0/0/3", mock.requests[http.MethodPost][0]["name"])
+			assert.Equal(t, float64(1), mock.requests[http.MethodPost][0]["device"])
+
+			removed := []interface{}{}
+			for _, payload := range mock.requests[http.MethodDelete] {
+				removed = append(removed, payload["id"])
+			}
+			if authoritative {
+				assert.Equal(t, []interface{}{float64(12), float64(30)}, removed)
+			} else {
+				assert.Equal(t, []interface{}{float64(12)}, removed)
+			}
+		})
+	}
+}
+
+func TestDeviceInterfacesResourceCreate(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	// ge-0/0/0 was created from the device type's templates.
+	mock, handler := newDeviceInterfacesMock(t, apiInterface(10, "ge-0/0/0", "1000base-t", true))
+	r := resources.NewDeviceInterfacesResource()
+	testutil.ConfigureResource(t, r, testutil.NewMockAPIClient(t, handler))
+	s := testutil.ResourceSchema(t, r)
+
+	member := deviceInterface("1000base-t")
+	member.Lag = types.StringValue("ae0")
+	member.Tags = types.SetValueMust(types.StringType, []attr.Value{types.StringValue("core")})
+	plan := tfsdk.Plan{Schema: s, Raw: emptyResourceState(s).Raw}
+	require.False(t, plan.Set(ctx, deviceInterfacesModel(t, s, false, map[string]resources.DeviceInterfaceModel{
+		"ae0":          deviceInterface("lag"),
+		"ge-0/0/[0-1]": member,
+	})).HasError())
+
+	resp := &fwresource.CreateResponse{State: emptyResourceState(s)}
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan}, resp)
+	require.False(t, resp.Diagnostics.HasError(), "Create returned errors: %v", resp.Diagnostics)
+
+	// The LAG is created with the new member, and the members join it once it exists.
+	require.Len(t, mock.requests[http.MethodPost], 2)
+	assert.Equal(t, "ae0", mock.requests[http.MethodPost][0]["name"])
+	assert.Equal(t, "ge-0/0/1", mock.requests[http.MethodPost][1]["name"])
+	assert.NotContains(t, mock.requests[http.MethodPost][1], "lag")
+	assert.Equal(t, []interface{}{map[string]interface{}{"name": "CORE", "slug": "core"}}, mock.requests[http.MethodPost][1]["tags"])
+	patched := map[interface{}]interface{}{}
+	for _, payload := range mock.requests[http.MethodPatch] {
+		if lag, ok := payload["lag"]; ok {
+			patched[payload["id"]] = lag
+		}
+	}
+	assert.Equal(t, map[interface{}]interface{}{float64(10): float64(100), float64(101): float64(100)}, patched)
+
+	var data resources.DeviceInterfacesResourceModel
+	require.False(t, resp.State.Get(ctx, &data).HasError())
+	var ids map[string]string
+	require.False(t, data.InterfaceIDs.ElementsAs(ctx, &ids, false).HasError())
+	assert.Equal(t, map[string]string{"ae0": "100", "ge-0/0/0": "10", "ge-0/0/1": "101"}, ids)
+	assert.Equal(t, []string{"ge-0/0/0"}, utils.SetToStringSlice(ctx, data.AdoptedInterfaces))
+}
+
+func TestDeviceInterfacesResourceDelete(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	for name, authoritative := range map[string]bool{"non-authoritative": false, "authoritative": true} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			mock, handler := newDeviceInterfacesMock(t,
+				apiInterface(10, "ge-0/0/0", "1000base-t", true),
+				apiInterface(11, "ge-0/0/1", "1000base-t", true),
+				apiInterface(30, "xe-0/0/0", "10gbase-x-sfpp", true),
+			)
+			r := resources.NewDeviceInterfacesResource()
+			testutil.ConfigureResource(t, r, testutil.NewMockAPIClient(t, handler))
+			s := testutil.ResourceSchema(t, r)
+
+			state := emptyResourceState(s)
+			model := deviceInterfacesModel(t, s, authoritative, map[string]resources.DeviceInterfaceModel{
+				"ge-0/0/[0-1]": deviceInterface("1000base-t"),
+			})
+			model.AdoptedInterfaces = types.SetValueMust(types.StringType, []attr.Value{types.StringValue("ge-0/0/0")})
+			require.False(t, state.Set(ctx, model).HasError())
+
+			resp := &fwresource.DeleteResponse{State: state}
+			r.Delete(ctx, fwresource.DeleteRequest{State: state}, resp)
+			require.False(t, resp.Diagnostics.HasError(), "Delete returned errors: %v", resp.Diagnostics)
+
+			removed := []interface{}{}
+			for _, payload := range mock.requests[http.MethodDelete] {
+				removed = append(removed, payload["id"])
+			}
+			if authoritative {
+				assert.Equal(t, []interface{}{float64(10), float64(11)}, removed)
+			} else {
+				// The adopted interface is left in place.
+				assert.Equal(t, []interface{}{float64(11)}, removed)
+			}
+		})
+	}
+}
+
+func TestDeviceInterfacesResourceImport(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	_, handler := newDeviceInterfacesMock(t,
+		apiInterface(10, "ge-0/0/0", "1000base-t", true),
+		apiInterface(20, "mgmt0", "1000base-t", false),
+	)
+	r := resources.NewDeviceInterfacesResource()
+	testutil.ConfigureResource(t, r, testutil.NewMockAPIClient(t, handler))
+	s := testutil.ResourceSchema(t, r)
+
+	state := emptyResourceState(s)
+	require.False(t, state.SetAttribute(ctx, path.Root("id"), "1").HasError())
+	resp := &fwresource.ReadResponse{State: state}
+	r.Read(ctx, fwresource.ReadRequest{State: state}, resp)
+	require.False(t, resp.Diagnostics.HasError(), "Read returned errors: %v", resp.Diagnostics)
+
+	var data resources.DeviceInterfacesResourceModel
+	require.False(t, resp.State.Get(ctx, &data).HasError())
+	var interfaces map[string]resources.DeviceInterfaceModel
+	require.False(t, data.Interfaces.ElementsAs(ctx, &interfaces, false).HasError())
+	assert.False(t, interfaces["mgmt0"].Enabled.ValueBool())
+	assert.True(t, interfaces["ge-0/0/0"].Tags.IsNull())
+	// Imported interfaces are adopted, so destroying the resource keeps them.
+	assert.Equal(t, []string{"ge-0/0/0", "mgmt0"}, utils.SetToStringSlice(ctx, data.AdoptedInterfaces))
+}
+
+func TestDeviceInterfacesResourceValidateConfig(t *testing.T) {
+	t.Parallel()
+
+	r := resources.NewDeviceInterfacesResource()
+	s := testutil.ResourceSchema(t, r)
+	validator := r.(fwresource.ResourceWithValidateConfig)
+
+	cases := map[string]struct {
+		interfaces map[string]resources.DeviceInterfaceModel
+		expectErr  string
+	}{
+		"patterns": {
+			interfaces: map[string]resources.DeviceInterfaceModel{
+				"ge-0/0/[0-47]": deviceInterface("1000base-t"),
+				"xe-0/1/[0-3]":  deviceInterface("10gbase-x-sfpp"),
+			},
+		},
+		"invalid pattern": {
+			interfaces: map[string]resources.DeviceInterfaceModel{"ge-0/0/[0-47": deviceInterface("1000base-t")},
+			expectErr:  "Invalid interface name pattern",
+		},
+		"overlapping patterns": {
+			interfaces: map[string]resources.DeviceInterfaceModel{
+				"ge-0/0/[0-47]": deviceInterface("1000base-t"),
+				"ge-0/0/47":     deviceInterface("1000base-t"),
+			},
+			expectErr: "Duplicate interface name",
+		},
+		"poe on lag": {
+			interfaces: map[string]resources.DeviceInterfaceModel{"ae0": func() resources.DeviceInterfaceModel {
+				m := deviceInterface("lag")
+				m.PoeMode = types.StringValue("pse")
+				return m
+			}()},
+			expectErr: "Unsupported interface attribute",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			config := tfsdk.Config{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(context.Background()), nil)}
+			model := deviceInterfacesModel(t, s, false, tc.interfaces)
+			model.ID = types.StringUnknown()
+			state := tfsdk.State(config)
+			require.False(t, state.Set(context.Background(), model).HasError())
+			config.Raw = state.Raw
+
+			resp := &fwresource.ValidateConfigResponse{}
+			validator.ValidateConfig(context.Background(), fwresource.ValidateConfigRequest{Config: config}, resp)
+			if tc.expectErr == "" {
+				assert.False(t, resp.Diagnostics.HasError(), "diagnostics: %v", resp.Diagnostics)
+				return
+			}
+			require.True(t, resp.Diagnostics.HasError())
+			assert.Equal(t, tc.expectErr, resp.Diagnostics.Errors()[0].Summary())
+		})
+	}
+}
@@ -9,6 +9,9 @@ import (
 	"github.com/bab3l/terraform-provider-netbox/internal/testutil"
 	"github.com/bab3l/terraform-provider-netbox/internal/validators"
 	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestDeviceResource(t *testing.T) {
@@ -36,7 +39,7 @@ func TestDeviceResourceSchema(t *testing.T) {
 
 	testutil.ValidateResourceSchema(t, schemaResponse.Schema.Attributes, testutil.SchemaValidation{
 		Required: []string{"site", "device_type", "role"},
-		Optional: []string{"name", "status", "description", "comments", "tenant", "platform", "cluster", "serial", "asset_tag", "rack", "position", "face", "latitude", "longitude", "config_template", "tags", "custom_fields"},
+		Optional: []string{"name", "status", "description", "comments", "tenant", "platform", "cluster", "serial", "asset_tag", "rack", "position", "face", "latitude", "longitude", "config_template", "virtual_chassis", "tags", "custom_fields"},
 		Computed: []string{"id"},
 	})
 
@@ -69,3 +72,79 @@ func TestDeviceResourceConfigure(t *testing.T) {
 	r := resources.NewDeviceResource()
 	testutil.ValidateResourceConfigure(t, r)
 }
+
+func TestDeviceResourceValidateConfig(t *testing.T) {
+	t.Parallel()
+
+	r := resources.NewDeviceResource()
+	s := testutil.ResourceSchema(t, r)
+	validator := r.(fwresource.ResourceWithValidateConfig)
+
+	cases := map[string]struct {
+		values    map[string]tftypes.Value
+		expectErr bool
+	}{
+		"no virtual chassis": {
+			values: map[string]tftypes.Value{},
+		},
+		"member with position": {
+			values: map[string]tftypes.Value{
+				"virtual_chassis": tftypes.NewValue(tftypes.String, "stack1"),
+				"vc_position":     tftypes.NewValue(tftypes.Number, 2),
+			},
+		},
+		"master position without virtual chassis": {
+			values: map[string]tftypes.Value{
+				"vc_position": tftypes.NewValue(tftypes.Number, 1),
+				"vc_priority": tftypes.NewValue(tftypes.Number, 255),
+			},
+		},
+		"master priority without virtual chassis": {
+			values: map[string]tftypes.Value{
+				"vc_priority": tftypes.NewValue(tftypes.Number, 255),
+			},
+		},
+		"member with position and priority": {
+			values: map[string]tftypes.Value{
+				"virtual_chassis": tftypes.NewValue(tftypes.String, "stack1"),
+				"vc_position":     tftypes.NewValue(tftypes.Number, 2),
+				"vc_priority":     tftypes.NewValue(tftypes.Number, 128),
+			},
+		},
+		"unknown virtual chassis with position": {
+			values: map[string]tftypes.Value{
+				"virtual_chassis": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+				"vc_position":     tftypes.NewValue(tftypes.Number, 2),
+			},
+		},
+		"member without position": {
+			values: map[string]tftypes.Value{
+				"virtual_chassis": tftypes.NewValue(tftypes.String, "stack1"),
+			},
+			expectErr: true,
+		},
+		"member with priority but without position": {
+			values: map[string]tftypes.Value{
+				"virtual_chassis": tftypes.NewValue(tftypes.String, "stack1"),
+				"vc_priority":     tftypes.NewValue(tftypes.Number, 128),
+			},
+			expectErr: true,
+		},
+		"unknown virtual chassis without position": {
+			values: map[string]tftypes.Value{
+				"virtual_chassis": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			},
+			expectErr: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			config := tfsdk.Config{Schema: s, Raw: testutil.ResourceObjectValue(t, s, tc.values)}
+			resp := &fwresource.ValidateConfigResponse{}
+			validator.ValidateConfig(context.Background(), fwresource.ValidateConfigRequest{Config: config}, resp)
+			assert.Equal(t, tc.expectErr, resp.Diagnostics.HasError(), "diagnostics: %v", resp.Diagnostics)
+		})
+	}
+}
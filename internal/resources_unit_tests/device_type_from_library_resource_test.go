@@ -0,0 +1,356 @@
+package resources_unit_tests
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/bab3l/terraform-provider-netbox/internal/resources"
+	"github.com/bab3l/terraform-provider-netbox/internal/testutil"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const patchPanelYAML = `
+manufacturer: Generic Cables
+model: Patch Panel
+slug: generic-patch-panel
+u_height: 1
+airflow: passive
+front_image: true
+console-ports:
+  - name: Console
+    type: rj-45
+rear-ports:
+  - name: Rear 1
+    type: 8p8c
+    positions: 2
+front-ports:
+  - name: Front 1
+    type: 8p8c
+    rear_port: Rear 1
+    rear_port_position: 1
+  - name: Front 2
+    type: 8p8c
+    rear_port: Rear 1
+    rear_port_position: 2
+`
+
+const patchPanelYAMLv2 = `
+manufacturer: Generic Cables
+model: Patch Panel
+slug: generic-patch-panel
+u_height: 2
+airflow: passive
+rear-ports:
+  - name: Rear 2
+    type: 8p8c
+    positions: 1
+front-ports:
+  - name: Front 1
+    type: 8p8c
+    rear_port: Rear 2
+    rear_port_position: 1
+`
+
+// fakeNetBox is an in-memory REST store for the endpoints used by the library
+// type resources. Like NetBox, it expands references and choices on read.
+type fakeNetBox struct {
+	mu       sync.Mutex
+	nextID   int
+	objects  map[string]map[int]map[string]interface{}
+	requests []string
+}
+
+func newFakeNetBox() *fakeNetBox {
+	return &fakeNetBox{nextID: 1, objects: map[string]map[int]map[string]interface{}{}}
+}
+
+var (
+	fakeReferenceFields = map[string]bool{"manufacturer": true, "device_type": true, "module_type": true, "rear_port": true, "power_port": true}
+	fakeChoiceFields    = map[string]bool{"type": true, "airflow": true}
+)
+
+func (f *fakeNetBox) expand(obj map[string]interface{}, body map[string]interface{}) {
+	for key, value := range body {
+		switch {
+		case fakeReferenceFields[key]:
+			obj[key] = map[string]interface{}{"id": value, "name": fmt.Sprintf("%s %v", key, value)}
+		case fakeChoiceFields[key]:
+			obj[key] = map[string]interface{}{"value": value, "label": strings.ToUpper(fmt.Sprintf("%v", value))}
+		default:
+			obj[key] = value
+		}
+	}
+}
+
+func (f *fakeNetBox) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/"), "/"), "/")
+	endpoint := strings.Join(parts[:2], "/")
+	var id int
+	if len(parts) > 2 {
+		id, _ = strconv.Atoi(parts[2])
+	}
+	f.requests = append(f.requests, r.Method+" "+strings.Join(parts, "/"))
+	if f.objects[endpoint] == nil {
+		f.objects[endpoint] = map[int]map[string]interface{}{}
+	}
+	store := f.objects[endpoint]
+
+	var body map[string]interface{}
+	if r.Body != nil {
+		_ = json.NewDecoder(r.Body).Decode(&body)
+	}
+
+	switch {
+	case r.Method == http.MethodGet && id == 0:
+		results := []map[string]interface{}{}
+		ids := make([]int, 0, len(store))
+		for objID := range store {
+			ids = append(ids, objID)
+		}
+		sort.Ints(ids)
+		for _, objID := range ids {
+			if fakeMatches(store[objID], r.URL.Query()) {
+				results = append(results, store[objID])
+			}
+		}
+		if r.URL.Query().Get("offset") != "0" {
+			results = []map[string]interface{}{}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"count": len(results), "results": results})
+	case r.Method == http.MethodPost && id == 0:
+		obj := map[string]interface{}{"id": f.nextID}
+		f.expand(obj, body)
+		store[f.nextID] = obj
+		f.nextID++
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(obj)
+	case store[id] == nil:
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"detail": "Not found."}`))
+	case r.Method == http.MethodGet:
+		_ = json.NewEncoder(w).Encode(store[id])
+	case r.Method == http.MethodPatch:
+		f.expand(store[id], body)
+		_ = json.NewEncoder(w).Encode(store[id])
+	case r.Method == http.MethodDelete:
+		delete(store, id)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func fakeMatches(obj map[string]interface{}, query map[string][]string) bool {
+	for key, values := range query {
+		switch {
+		case key == "limit" || key == "offset":
+			continue
+		case strings.HasSuffix(key, "_id"):
+			ref, _ := obj[strings.TrimSuffix(key, "_id")].(map[string]interface{})
+			if ref == nil || fmt.Sprintf("%v", ref["id"]) != values[0] {
+				return false
+			}
+		case fmt.Sprintf("%v", obj[key]) != values[0]:
+			return false
+		}
+	}
+	return true
+}
+
+// names returns the sorted template names stored at endpoint.
+func (f *fakeNetBox) names(endpoint string) []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var names []string
+	for _, obj := range f.objects[endpoint] {
+		names = append(names, fmt.Sprintf("%v", obj["name"]))
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (f *fakeNetBox) find(endpoint, name string) map[string]interface{} {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, obj := range f.objects[endpoint] {
+		if obj["name"] == name || obj["model"] == name {
+			return obj
+		}
+	}
+	return nil
+}
+
+func libraryPlan(t *testing.T, r fwresource.Resource, definition string, id tftypes.Value) tfsdk.Plan {
+	t.Helper()
+	s := testutil.ResourceSchema(t, r)
+	return tfsdk.Plan{Schema: s, Raw: testutil.ResourceObjectValue(t, s, map[string]tftypes.Value{
+		"id":              id,
+		"definition_yaml": tftypes.NewValue(tftypes.String, definition),
+		"manufacturer":    tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"model":           tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+	})}
+}
+
+func TestDeviceTypeFromLibraryResource(t *testing.T) {
+	t.Parallel()
+
+	r := resources.NewDeviceTypeFromLibraryResource()
+	testutil.ValidateResourceMetadata(t, r, "netbox", "netbox_device_type_from_library")
+	testutil.ValidateResourceConfigure(t, r)
+	testutil.ValidateResourceSchema(t, testutil.ResourceSchema(t, r).Attributes, testutil.SchemaValidation{
+		Required: []string{"definition_yaml"},
+		Computed: []string{"id", "manufacturer", "model"},
+	})
+
+	m := resources.NewModuleTypeFromLibraryResource()
+	testutil.ValidateResourceMetadata(t, m, "netbox", "netbox_module_type_from_library")
+}
+
+func TestDeviceTypeFromLibraryResourceLifecycle(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	api := newFakeNetBox()
+	client := testutil.NewMockAPIClient(t, api)
+
+	r := resources.NewDeviceTypeFromLibraryResource()
+	testutil.ConfigureResource(t, r, client)
+	s := testutil.ResourceSchema(t, r)
+
+	// Create: manufacturer, type and templates.
+	plan := libraryPlan(t, r, patchPanelYAML, tftypes.NewValue(tftypes.String, tftypes.UnknownValue))
+	createResp := &fwresource.CreateResponse{State: tfsdk.State{Schema: s, Raw: plan.Raw}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan}, createResp)
+	require.False(t, createResp.Diagnostics.HasError(), "Create returned errors: %v", createResp.Diagnostics)
+
+	manufacturer := api.find("dcim/manufacturers", "Generic Cables")
+	require.NotNil(t, manufacturer)
+	assert.Equal(t, "generic-cables", manufacturer["slug"])
+
+	deviceType := api.find("dcim/device-types", "Patch Panel")
+	require.NotNil(t, deviceType)
+	assert.Equal(t, "generic-patch-panel", deviceType["slug"])
+	assert.NotContains(t, deviceType, "front_image")
+
+	assert.Equal(t, []string{"Console"}, api.names("dcim/console-port-templates"))
+	assert.Equal(t, []string{"Rear 1"}, api.names("dcim/rear-port-templates"))
+	assert.Equal(t, []string{"Front 1", "Front 2"}, api.names("dcim/front-port-templates"))
+	rear := api.find("dcim/rear-port-templates", "Rear 1")
+	front := api.find("dcim/front-port-templates", "Front 2")
+	assert.EqualValues(t, rear["id"], front["rear_port"].(map[string]interface{})["id"])
+
+	var manufacturerID string
+	require.False(t, createResp.State.GetAttribute(ctx, path.Root("manufacturer"), &manufacturerID).HasError())
+	assert.Equal(t, fmt.Sprintf("%v", manufacturer["id"]), manufacturerID)
+
+	// Read without drift keeps the definition.
+	readResp := &fwresource.ReadResponse{State: createResp.State}
+	r.Read(ctx, fwresource.ReadRequest{State: createResp.State}, readResp)
+	require.False(t, readResp.Diagnostics.HasError(), "Read returned errors: %v", readResp.Diagnostics)
+	var definition types.String
+	require.False(t, readResp.State.GetAttribute(ctx, path.Root("definition_yaml"), &definition).HasError())
+	assert.Equal(t, patchPanelYAML, definition.ValueString())
+
+	// A template added in NetBox is drift.
+	api.mu.Lock()
+	api.objects["dcim/interface-templates"] = map[int]map[string]interface{}{
+		900: {"id": 900, "name": "mgmt", "device_type": map[string]interface{}{"id": deviceType["id"]}},
+	}
+	api.mu.Unlock()
+	driftResp := &fwresource.ReadResponse{State: createResp.State}
+	r.Read(ctx, fwresource.ReadRequest{State: createResp.State}, driftResp)
+	require.False(t, driftResp.Diagnostics.HasError(), "Read returned errors: %v", driftResp.Diagnostics)
+	require.False(t, driftResp.State.GetAttribute(ctx, path.Root("definition_yaml"), &definition).HasError())
+	assert.True(t, definition.IsNull(), "drift should clear definition_yaml")
+
+	// Update applies the new definition and deletes orphans.
+	var id string
+	require.False(t, createResp.State.GetAttribute(ctx, path.Root("id"), &id).HasError())
+	updatePlan := libraryPlan(t, r, patchPanelYAMLv2, tftypes.NewValue(tftypes.String, id))
+	updateResp := &fwresource.UpdateResponse{State: driftResp.State}
+	r.Update(ctx, fwresource.UpdateRequest{Plan: updatePlan, State: driftResp.State}, updateResp)
+	require.False(t, updateResp.Diagnostics.HasError(), "Update returned errors: %v", updateResp.Diagnostics)
+
+	assert.InDelta(t, 2, api.find("dcim/device-types", "Patch Panel")["u_height"], 0)
+	assert.Empty(t, api.names("dcim/console-port-templates"))
+	assert.Empty(t, api.names("dcim/interface-templates"))
+	assert.Equal(t, []string{"Rear 2"}, api.names("dcim/rear-port-templates"))
+	assert.Equal(t, []string{"Front 1"}, api.names("dcim/front-port-templates"))
+	rear2 := api.find("dcim/rear-port-templates", "Rear 2")
+	front1 := api.find("dcim/front-port-templates", "Front 1")
+	assert.EqualValues(t, rear2["id"], front1["rear_port"].(map[string]interface{})["id"])
+
+	// The front port is moved to the new rear port before the old one is deleted.
+	var moved, deletedRear int
+	for i, req := range api.requests {
+		if strings.HasPrefix(req, "PATCH dcim/front-port-templates/") {
+			moved = i
+		}
+		if strings.HasPrefix(req, "DELETE dcim/rear-port-templates/") {
+			deletedRear = i
+		}
+	}
+	assert.Less(t, moved, deletedRear)
+
+	afterResp := &fwresource.ReadResponse{State: updateResp.State}
+	r.Read(ctx, fwresource.ReadRequest{State: updateResp.State}, afterResp)
+	require.False(t, afterResp.Diagnostics.HasError(), "Read returned errors: %v", afterResp.Diagnostics)
+	require.False(t, afterResp.State.GetAttribute(ctx, path.Root("definition_yaml"), &definition).HasError())
+	assert.Equal(t, patchPanelYAMLv2, definition.ValueString())
+
+	// Delete removes the type.
+	deleteResp := &fwresource.DeleteResponse{State: updateResp.State}
+	r.Delete(ctx, fwresource.DeleteRequest{State: updateResp.State}, deleteResp)
+	require.False(t, deleteResp.Diagnostics.HasError(), "Delete returned errors: %v", deleteResp.Diagnostics)
+	assert.Nil(t, api.find("dcim/device-types", "Patch Panel"))
+	assert.NotNil(t, api.find("dcim/manufacturers", "Generic Cables"), "the manufacturer is kept")
+}
+
+func TestDeviceTypeFromLibraryResourceValidateConfig(t *testing.T) {
+	t.Parallel()
+
+	r := resources.NewDeviceTypeFromLibraryResource()
+	s := testutil.ResourceSchema(t, r)
+	validator := r.(fwresource.ResourceWithValidateConfig)
+
+	validate := func(definition string) *fwresource.ValidateConfigResponse {
+		config := tfsdk.Config{Schema: s, Raw: testutil.ResourceObjectValue(t, s, map[string]tftypes.Value{
+			"definition_yaml": tftypes.NewValue(tftypes.String, definition),
+		})}
+		resp := &fwresource.ValidateConfigResponse{}
+		validator.ValidateConfig(context.Background(), fwresource.ValidateConfigRequest{Config: config}, resp)
+		return resp
+	}
+
+	resp := validate(patchPanelYAML)
+	assert.False(t, resp.Diagnostics.HasError())
+	require.Equal(t, 1, resp.Diagnostics.WarningsCount())
+	assert.Contains(t, resp.Diagnostics.Warnings()[0].Detail(), "front_image")
+
+	assert.True(t, validate("manufacturer: Generic\nmodel: No slug").Diagnostics.HasError())
+	assert.True(t, validate("model: [").Diagnostics.HasError())
+
+	module := resources.NewModuleTypeFromLibraryResource()
+	moduleValidator := module.(fwresource.ResourceWithValidateConfig)
+	ms := testutil.ResourceSchema(t, module)
+	config := tfsdk.Config{Schema: ms, Raw: testutil.ResourceObjectValue(t, ms, map[string]tftypes.Value{
+		"definition_yaml": tftypes.NewValue(tftypes.String, "manufacturer: Generic\nmodel: SFP\ninterfaces:\n  - name: eth{module}\n    type: 10gbase-x-sfpp\n"),
+	})}
+	moduleResp := &fwresource.ValidateConfigResponse{}
+	moduleValidator.ValidateConfig(context.Background(), fwresource.ValidateConfigRequest{Config: config}, moduleResp)
+	assert.False(t, moduleResp.Diagnostics.HasError(), "module types do not need a slug: %v", moduleResp.Diagnostics)
+}
@@ -9,6 +9,10 @@ import (
 	"github.com/bab3l/terraform-provider-netbox/internal/testutil"
 	"github.com/bab3l/terraform-provider-netbox/internal/validators"
 	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestInterfaceResource(t *testing.T) {
@@ -36,7 +40,7 @@ func TestInterfaceResourceSchema(t *testing.T) {
 
 	testutil.ValidateResourceSchema(t, schemaResponse.Schema.Attributes, testutil.SchemaValidation{
 		Required: []string{"device", "name", "type"},
-		Optional: []string{"label", "enabled", "parent", "bridge", "lag", "mtu", "mac_address", "speed", "duplex", "wwn", "mgmt_only", "description", "mode", "mark_connected", "tags", "custom_fields"},
+		Optional: []string{"label", "enabled", "parent", "bridge", "lag", "mtu", "mac_address", "speed", "duplex", "wwn", "mgmt_only", "description", "mode", "mark_connected", "vrf", "poe_mode", "poe_type", "rf_role", "rf_channel", "tx_power", "wireless_lans", "vdcs", "tags", "custom_fields"},
 		Computed: []string{"id"},
 	})
 
@@ -63,3 +67,68 @@ func TestInterfaceResourceConfigure(t *testing.T) {
 	r := resources.NewInterfaceResource()
 	testutil.ValidateResourceConfigure(t, r)
 }
+
+func TestInterfaceResourceValidateConfig(t *testing.T) {
+	t.Parallel()
+
+	r := resources.NewInterfaceResource()
+	s := testutil.ResourceSchema(t, r)
+	validator := r.(fwresource.ResourceWithValidateConfig)
+
+	str := func(v interface{}) tftypes.Value { return tftypes.NewValue(tftypes.String, v) }
+	num := func(v interface{}) tftypes.Value { return tftypes.NewValue(tftypes.Number, v) }
+	cases := map[string]struct {
+		attrs     map[string]tftypes.Value
+		expectErr string
+	}{
+		"poe on copper": {
+			attrs: map[string]tftypes.Value{"type": str("1000base-t"), "poe_mode": str("pse"), "poe_type": str("type2-ieee802.3at"), "duplex": str("full")},
+		},
+		"rf on wireless": {
+			attrs: map[string]tftypes.Value{
+				"type":          str("ieee802.11ax"),
+				"rf_role":       str("ap"),
+				"rf_channel":    str("5g-36-5180-20"),
+				"tx_power":      num(20),
+				"wireless_lans": tftypes.NewValue(tftypes.Set{ElementType: tftypes.Number}, []tftypes.Value{num(1)}),
+			},
+		},
+		"unknown type": {
+			attrs: map[string]tftypes.Value{"type": str(tftypes.UnknownValue), "rf_role": str("ap")},
+		},
+		"rf role on copper": {
+			attrs:     map[string]tftypes.Value{"type": str("1000base-t"), "rf_role": str("ap")},
+			expectErr: "Unsupported interface attribute",
+		},
+		"wireless lans on lag": {
+			attrs: map[string]tftypes.Value{
+				"type":          str("lag"),
+				"wireless_lans": tftypes.NewValue(tftypes.Set{ElementType: tftypes.Number}, []tftypes.Value{num(1)}),
+			},
+			expectErr: "Unsupported interface attribute",
+		},
+		"poe on virtual": {
+			attrs:     map[string]tftypes.Value{"type": str("virtual"), "poe_mode": str("pd")},
+			expectErr: "Unsupported interface attribute",
+		},
+		"duplex on wireless": {
+			attrs:     map[string]tftypes.Value{"type": str("other-wireless"), "duplex": str("half")},
+			expectErr: "Unsupported interface attribute",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			config := tfsdk.Config{Schema: s, Raw: testutil.ResourceObjectValue(t, s, tc.attrs)}
+			resp := &fwresource.ValidateConfigResponse{}
+			validator.ValidateConfig(context.Background(), fwresource.ValidateConfigRequest{Config: config}, resp)
+			if tc.expectErr == "" {
+				assert.False(t, resp.Diagnostics.HasError(), "diagnostics: %v", resp.Diagnostics)
+				return
+			}
+			require.True(t, resp.Diagnostics.HasError())
+			assert.Equal(t, tc.expectErr, resp.Diagnostics.Errors()[0].Summary())
+		})
+	}
+}
@@ -2,11 +2,18 @@ package resources_unit_tests
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
 	"testing"
 
 	"github.com/bab3l/terraform-provider-netbox/internal/resources"
 	"github.com/bab3l/terraform-provider-netbox/internal/testutil"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestInventoryItemResource(t *testing.T) {
@@ -31,6 +38,13 @@ func TestInventoryItemResourceSchema(t *testing.T) {
 	if schemaResponse.Diagnostics.HasError() {
 		t.Fatalf("Schema returned errors: %v", schemaResponse.Diagnostics)
 	}
+
+	testutil.ValidateResourceSchema(t, schemaResponse.Schema.Attributes, testutil.SchemaValidation{
+		Required:         []string{"device", "name"},
+		Optional:         []string{"label", "parent", "role", "manufacturer", "part_id", "serial", "asset_tag", "component_type", "component_name"},
+		Computed:         []string{"id"},
+		OptionalComputed: []string{"discovered", "status", "component_id"},
+	})
 }
 
 func TestInventoryItemResourceMetadata(t *testing.T) {
@@ -48,3 +62,53 @@ func TestInventoryItemResourceConfigure(t *testing.T) {
 	r := resources.NewInventoryItemResource()
 	testutil.ValidateResourceConfigure(t, r)
 }
+
+func TestInventoryItemResourceReadComponent(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	client := testutil.NewMockAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/dcim/inventory-items/5/", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"id":             5,
+			"url":            "http://netbox/api/dcim/inventory-items/5/",
+			"display":        "SFP-10G-LR",
+			"device":         map[string]interface{}{"id": 1, "url": "http://netbox/api/dcim/devices/1/", "display": "sw1", "name": "sw1"},
+			"name":           "SFP-10G-LR",
+			"status":         map[string]interface{}{"value": "active", "label": "Active"},
+			"component_type": "dcim.interface",
+			"component_id":   12,
+			"component":      map[string]interface{}{"id": 12, "url": "http://netbox/api/dcim/interfaces/12/", "display": "xe-0/0/2", "name": "xe-0/0/2"},
+			"_depth":         0,
+		})
+	}))
+
+	r := resources.NewInventoryItemResource()
+	testutil.ConfigureResource(t, r, client)
+	s := testutil.ResourceSchema(t, r)
+
+	// The optic was moved to another interface outside of Terraform.
+	state := tfsdk.State{Schema: s, Raw: testutil.ResourceObjectValue(t, s, map[string]tftypes.Value{
+		"id":             tftypes.NewValue(tftypes.String, "5"),
+		"device":         tftypes.NewValue(tftypes.String, "sw1"),
+		"name":           tftypes.NewValue(tftypes.String, "SFP-10G-LR"),
+		"component_type": tftypes.NewValue(tftypes.String, "dcim.interface"),
+		"component_id":   tftypes.NewValue(tftypes.String, "11"),
+		"component_name": tftypes.NewValue(tftypes.String, "xe-0/0/1"),
+	})}
+	resp := &fwresource.ReadResponse{State: state}
+	r.Read(ctx, fwresource.ReadRequest{State: state}, resp)
+	require.False(t, resp.Diagnostics.HasError(), "Read returned errors: %v", resp.Diagnostics)
+
+	for attr, expected := range map[string]string{
+		"status":         "active",
+		"component_type": "dcim.interface",
+		"component_id":   "12",
+		"component_name": "xe-0/0/2",
+	} {
+		var value string
+		require.False(t, resp.State.GetAttribute(ctx, path.Root(attr), &value).HasError())
+		assert.Equal(t, expected, value, attr)
+	}
+}
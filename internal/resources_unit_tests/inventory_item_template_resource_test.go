@@ -33,9 +33,10 @@ func TestInventoryItemTemplateResourceSchema(t *testing.T) {
 	}
 
 	testutil.ValidateResourceSchema(t, schemaResponse.Schema.Attributes, testutil.SchemaValidation{
-		Required: []string{"device_type", "name"},
-		Optional: []string{"parent", "label", "role", "manufacturer", "part_id", "description", "component_type", "component_id"},
-		Computed: []string{"id"},
+		Required:         []string{"device_type", "name"},
+		Optional:         []string{"parent", "label", "role", "manufacturer", "part_id", "description", "component_type", "component_name"},
+		OptionalComputed: []string{"component_id"},
+		Computed:         []string{"id"},
 	})
 }
 
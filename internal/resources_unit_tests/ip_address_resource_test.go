@@ -2,13 +2,25 @@ package resources_unit_tests
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
 	"reflect"
+	"slices"
+	"strconv"
+	"sync"
 	"testing"
 
 	"github.com/bab3l/terraform-provider-netbox/internal/resources"
 	"github.com/bab3l/terraform-provider-netbox/internal/testutil"
+	"github.com/bab3l/terraform-provider-netbox/internal/utils"
 	"github.com/bab3l/terraform-provider-netbox/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestIPAddressResource(t *testing.T) {
@@ -63,3 +75,141 @@ func TestIPAddressResourceConfigure(t *testing.T) {
 	r := resources.NewIPAddressResource()
 	testutil.ValidateResourceConfigure(t, r)
 }
+
+// fakeIPAddressAPI serves IP addresses and the custom field definitions they
+// use. As in NetBox, the objects it returns differ from the requests: choices
+// are expanded and decimal custom fields are returned as strings.
+type fakeIPAddressAPI struct {
+	mu      sync.Mutex
+	objects map[string]map[string]interface{}
+	bodies  []map[string]interface{}
+}
+
+func (f *fakeIPAddressAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	switch {
+	case r.URL.Path == "/api/extras/custom-fields/":
+		definitions := map[string]string{"rack_units": "integer", "weight": "decimal", "monitored": "boolean", "roles": "multiselect"}
+		names := r.URL.Query()["name"]
+		results := []map[string]interface{}{}
+		for name, fieldType := range definitions {
+			if len(names) == 0 || slices.Contains(names, name) {
+				results = append(results, map[string]interface{}{
+					"name":         name,
+					"type":         map[string]string{"value": fieldType},
+					"object_types": []string{"ipam.ipaddress"},
+				})
+			}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"count": len(results), "next": nil, "results": results})
+	case r.URL.Path == "/api/ipam/ip-addresses/" && r.Method == http.MethodPost:
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		f.bodies = append(f.bodies, body)
+
+		customFields := map[string]interface{}{}
+		for name, value := range body["custom_fields"].(map[string]interface{}) {
+			customFields[name] = value
+		}
+		if weight, ok := customFields["weight"].(float64); ok {
+			customFields["weight"] = strconv.FormatFloat(weight, 'f', 2, 64)
+		}
+		object := map[string]interface{}{
+			"id":              1,
+			"url":             "http://netbox/api/ipam/ip-addresses/1/",
+			"display":         body["address"],
+			"family":          map[string]interface{}{"value": 4, "label": "IPv4"},
+			"address":         body["address"],
+			"status":          map[string]interface{}{"value": "active", "label": "Active"},
+			"assigned_object": nil,
+			"nat_outside":     []interface{}{},
+			"tags":            []interface{}{},
+			"custom_fields":   customFields,
+		}
+		f.objects["1"] = object
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(object)
+	case r.URL.Path == "/api/ipam/ip-addresses/1/" && r.Method == http.MethodGet && f.objects["1"] != nil:
+		_ = json.NewEncoder(w).Encode(f.objects["1"])
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"detail": "Not found."}`))
+	}
+}
+
+// TestIPAddressResourceCustomFieldsMapRoundTrip tests that custom_fields_map
+// is converted to typed values on Create and refreshed from the differently
+// shaped IP address that NetBox returns on Read.
+func TestIPAddressResourceCustomFieldsMapRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	api := &fakeIPAddressAPI{objects: map[string]map[string]interface{}{}}
+	client := testutil.NewMockAPIClient(t, api)
+
+	r := resources.NewIPAddressResource()
+	testutil.ConfigureResource(t, r, client)
+	s := testutil.ResourceSchema(t, r)
+
+	customFieldsMap := tftypes.NewValue(tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"rack_units": tftypes.Number,
+		"weight":     tftypes.Number,
+		"monitored":  tftypes.Bool,
+		"roles":      tftypes.Tuple{ElementTypes: []tftypes.Type{tftypes.String}},
+	}}, map[string]tftypes.Value{
+		"rack_units": tftypes.NewValue(tftypes.Number, 42),
+		"weight":     tftypes.NewValue(tftypes.Number, 1.5),
+		"monitored":  tftypes.NewValue(tftypes.Bool, true),
+		"roles":      tftypes.NewValue(tftypes.Tuple{ElementTypes: []tftypes.Type{tftypes.String}}, []tftypes.Value{tftypes.NewValue(tftypes.String, "edge,core")}),
+	})
+	plan := tfsdk.Plan{Schema: s, Raw: testutil.ResourceObjectValue(t, s, map[string]tftypes.Value{
+		"id":                tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"address":           tftypes.NewValue(tftypes.String, "192.0.2.10/24"),
+		"status":            tftypes.NewValue(tftypes.String, "active"),
+		"custom_fields_map": customFieldsMap,
+		"tags_all":          tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, tftypes.UnknownValue),
+		"custom_fields_all": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, tftypes.UnknownValue),
+	})}
+
+	// Create sends the values with the types of their custom fields.
+	createResp := &resource.CreateResponse{State: tfsdk.State{Schema: s, Raw: plan.Raw}}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, createResp)
+	require.False(t, createResp.Diagnostics.HasError(), "Create returned errors: %v", createResp.Diagnostics)
+	require.Len(t, api.bodies, 1)
+	assert.Equal(t, map[string]interface{}{
+		"rack_units": float64(42),
+		"weight":     1.5,
+		"monitored":  true,
+		"roles":      []interface{}{"edge,core"},
+	}, api.bodies[0]["custom_fields"])
+
+	customFieldsMapJSON := func(state tfsdk.State) string {
+		t.Helper()
+		var value types.Dynamic
+		require.False(t, state.GetAttribute(ctx, path.Root("custom_fields_map"), &value).HasError())
+		raw, err := utils.DynamicToJSON(ctx, value)
+		require.NoError(t, err)
+		return string(raw)
+	}
+	const configured = `{"rack_units": 42, "weight": 1.5, "monitored": true, "roles": ["edge,core"]}`
+	assert.JSONEq(t, configured, customFieldsMapJSON(createResp.State), "the configured values are kept although NetBox formats the decimal differently")
+
+	// Read does not report drift while the values match.
+	readResp := &resource.ReadResponse{State: createResp.State}
+	r.Read(ctx, resource.ReadRequest{State: createResp.State}, readResp)
+	require.False(t, readResp.Diagnostics.HasError(), "Read returned errors: %v", readResp.Diagnostics)
+	assert.JSONEq(t, configured, customFieldsMapJSON(readResp.State))
+
+	// Values changed outside Terraform show up in state.
+	api.mu.Lock()
+	api.objects["1"]["custom_fields"].(map[string]interface{})["rack_units"] = 48
+	api.mu.Unlock()
+
+	driftResp := &resource.ReadResponse{State: readResp.State}
+	r.Read(ctx, resource.ReadRequest{State: readResp.State}, driftResp)
+	require.False(t, driftResp.Diagnostics.HasError(), "Read returned errors: %v", driftResp.Diagnostics)
+	assert.JSONEq(t, `{"rack_units": 48, "weight": 1.5, "monitored": true, "roles": ["edge,core"]}`, customFieldsMapJSON(driftResp.State))
+}
@@ -0,0 +1,138 @@
+package resources_unit_tests
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/bab3l/terraform-provider-netbox/internal/resources"
+	"github.com/bab3l/terraform-provider-netbox/internal/testutil"
+	"github.com/bab3l/terraform-provider-netbox/internal/utils"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newJournalTestServer serves manufacturer 1 and journal entries, recording
+// the journal entry requests. It answers journal entries with status.
+func newJournalTestServer(t *testing.T, status int) (http.Handler, func() []map[string]interface{}) {
+	var mu sync.Mutex
+	var journal []map[string]interface{}
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		body, _ := io.ReadAll(r.Body)
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/api/extras/journal-entries/"):
+			var entry map[string]interface{}
+			require.NoError(t, json.Unmarshal(body, &entry))
+			mu.Lock()
+			journal = append(journal, entry)
+			mu.Unlock()
+			w.WriteHeader(status)
+			if status != http.StatusCreated {
+				_, _ = io.WriteString(w, `{"detail": "journaling is not supported"}`)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"id": 9, "url": "http://netbox/api/extras/journal-entries/9/", "display": "entry",
+				"assigned_object_type": entry["assigned_object_type"], "assigned_object_id": entry["assigned_object_id"],
+				"assigned_object": map[string]interface{}{}, "comments": entry["comments"],
+			})
+		case strings.HasSuffix(r.URL.Path, "/api/dcim/manufacturers/1/"):
+			var request map[string]interface{}
+			require.NoError(t, json.Unmarshal(body, &request))
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"id": 1, "url": "http://netbox/api/dcim/manufacturers/1/", "display": "Cisco",
+				"name": request["name"], "slug": request["slug"], "description": request["description"],
+			})
+		default:
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+	})
+	return handler, func() []map[string]interface{} {
+		mu.Lock()
+		defer mu.Unlock()
+		return journal
+	}
+}
+
+// updateManufacturerDescription updates the description of manufacturer 1
+// from old to new through the resource.
+func updateManufacturerDescription(t *testing.T, r fwresource.Resource) *fwresource.UpdateResponse {
+	t.Helper()
+	s := testutil.ResourceSchema(t, r)
+	values := func(description string) map[string]tftypes.Value {
+		return map[string]tftypes.Value{
+			"id":          tftypes.NewValue(tftypes.String, "1"),
+			"name":        tftypes.NewValue(tftypes.String, "Cisco"),
+			"slug":        tftypes.NewValue(tftypes.String, "cisco"),
+			"description": tftypes.NewValue(tftypes.String, description),
+		}
+	}
+	state := tfsdk.State{Schema: s, Raw: testutil.ResourceObjectValue(t, s, values("old"))}
+	plan := tfsdk.Plan{Schema: s, Raw: testutil.ResourceObjectValue(t, s, values("new"))}
+	resp := &fwresource.UpdateResponse{State: state}
+	r.Update(context.Background(), fwresource.UpdateRequest{Plan: plan, State: state, Config: tfsdk.Config{Schema: s, Raw: plan.Raw}}, resp)
+	return resp
+}
+
+func TestJournalChanges_Update(t *testing.T) {
+	t.Setenv("TF_WORKSPACE", "prod")
+	t.Setenv("TFC_RUN_ID", "run-42")
+
+	handler, journal := newJournalTestServer(t, http.StatusCreated)
+	client := testutil.NewMockAPIClient(t, handler)
+	tmpl, err := utils.ParseJournalTemplate(utils.DefaultJournalTemplate)
+	require.NoError(t, err)
+	utils.SetProviderSettings(client, utils.ProviderSettings{Journal: &utils.JournalSettings{Kind: "success", Template: tmpl}})
+
+	r := resources.NewManufacturerResource()
+	testutil.ConfigureResource(t, r, client)
+	resp := updateManufacturerDescription(t, r)
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics.Errors())
+	assert.Empty(t, resp.Diagnostics.Warnings())
+
+	require.Len(t, journal(), 1)
+	entry := journal()[0]
+	assert.Equal(t, "dcim.manufacturer", entry["assigned_object_type"])
+	assert.EqualValues(t, 1, entry["assigned_object_id"])
+	assert.Equal(t, "success", entry["kind"])
+	assert.Equal(t, "Terraform updated this object in workspace prod (run run-42). Changed: description.", entry["comments"])
+}
+
+func TestJournalChanges_FailureIsWarning(t *testing.T) {
+	t.Parallel()
+
+	handler, journal := newJournalTestServer(t, http.StatusBadRequest)
+	client := testutil.NewMockAPIClient(t, handler)
+	tmpl, err := utils.ParseJournalTemplate(utils.DefaultJournalTemplate)
+	require.NoError(t, err)
+	utils.SetProviderSettings(client, utils.ProviderSettings{Journal: &utils.JournalSettings{Kind: "info", Template: tmpl}})
+
+	r := resources.NewManufacturerResource()
+	testutil.ConfigureResource(t, r, client)
+	resp := updateManufacturerDescription(t, r)
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics.Errors())
+	require.Len(t, resp.Diagnostics.Warnings(), 1)
+	assert.Equal(t, "Journal entry not created", resp.Diagnostics.Warnings()[0].Summary())
+	assert.Len(t, journal(), 1)
+}
+
+func TestJournalChanges_Disabled(t *testing.T) {
+	t.Parallel()
+
+	handler, journal := newJournalTestServer(t, http.StatusCreated)
+	client := testutil.NewMockAPIClient(t, handler)
+
+	r := resources.NewManufacturerResource()
+	testutil.ConfigureResource(t, r, client)
+	resp := updateManufacturerDescription(t, r)
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics.Errors())
+	assert.Empty(t, journal())
+}
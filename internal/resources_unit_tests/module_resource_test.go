@@ -2,11 +2,19 @@ package resources_unit_tests
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
 	"testing"
 
 	"github.com/bab3l/terraform-provider-netbox/internal/resources"
 	"github.com/bab3l/terraform-provider-netbox/internal/testutil"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestModuleResource(t *testing.T) {
@@ -47,9 +55,9 @@ func TestModuleResourceSchema(t *testing.T) {
 
 		Optional: []string{"serial", "asset_tag", "description", "comments", "tags", "custom_fields"},
 
-		Computed: []string{"id", "status"},
+		Computed: []string{"id", "status", "components"},
 
-		OptionalComputed: []string{},
+		OptionalComputed: []string{"replicate_components", "adopt_components"},
 	})
 
 }
@@ -82,3 +90,64 @@ func TestModuleResourceConfigure(t *testing.T) {
 	testutil.ValidateResourceConfigure(t, r)
 
 }
+
+func TestModuleResourceReadComponents(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	client := testutil.NewMockAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body interface{}
+		switch r.URL.Path {
+		case "/api/dcim/modules/3/":
+			body = map[string]interface{}{
+				"id":          3,
+				"url":         "http://netbox/api/dcim/modules/3/",
+				"display":     "LC-1",
+				"device":      map[string]interface{}{"id": 1, "url": "http://netbox/api/dcim/devices/1/", "display": "router1", "name": "router1"},
+				"module_bay":  map[string]interface{}{"id": 4, "url": "http://netbox/api/dcim/module-bays/4/", "display": "Slot 1", "name": "Slot 1"},
+				"module_type": map[string]interface{}{"id": 2, "url": "http://netbox/api/dcim/module-types/2/", "display": "LC", "model": "LC", "manufacturer": map[string]interface{}{"id": 1, "url": "http://netbox/api/dcim/manufacturers/1/", "display": "Acme", "name": "Acme", "slug": "acme"}},
+				"status":      map[string]interface{}{"value": "active", "label": "Active"},
+			}
+		case "/api/dcim/interfaces/":
+			assert.Equal(t, "3", r.URL.Query().Get("module_id"))
+			body = map[string]interface{}{"count": 2, "results": []interface{}{
+				map[string]interface{}{"id": 12, "name": "et-0/0/1"},
+				map[string]interface{}{"id": 11, "name": "et-0/0/0"},
+			}}
+		case "/api/dcim/console-ports/":
+			body = map[string]interface{}{"count": 1, "results": []interface{}{
+				map[string]interface{}{"id": 21, "name": "con0"},
+			}}
+		default:
+			body = map[string]interface{}{"count": 0, "results": []interface{}{}}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(body)
+	}))
+
+	r := resources.NewModuleResource()
+	testutil.ConfigureResource(t, r, client)
+	s := testutil.ResourceSchema(t, r)
+
+	// Replication flags are null after a plain ID import.
+	state := tfsdk.State{Schema: s, Raw: testutil.ResourceObjectValue(t, s, map[string]tftypes.Value{
+		"id": tftypes.NewValue(tftypes.String, "3"),
+	})}
+	resp := &fwresource.ReadResponse{State: state}
+	r.Read(ctx, fwresource.ReadRequest{State: state}, resp)
+	require.False(t, resp.Diagnostics.HasError(), "Read returned errors: %v", resp.Diagnostics)
+
+	var components []resources.ModuleComponentModel
+	require.False(t, resp.State.GetAttribute(ctx, path.Root("components"), &components).HasError())
+	assert.Equal(t, []resources.ModuleComponentModel{
+		{ObjectType: types.StringValue("dcim.consoleport"), ID: types.Int64Value(21), Name: types.StringValue("con0")},
+		{ObjectType: types.StringValue("dcim.interface"), ID: types.Int64Value(11), Name: types.StringValue("et-0/0/0")},
+		{ObjectType: types.StringValue("dcim.interface"), ID: types.Int64Value(12), Name: types.StringValue("et-0/0/1")},
+	}, components)
+
+	var replicate, adopt types.Bool
+	require.False(t, resp.State.GetAttribute(ctx, path.Root("replicate_components"), &replicate).HasError())
+	require.False(t, resp.State.GetAttribute(ctx, path.Root("adopt_components"), &adopt).HasError())
+	assert.Equal(t, types.BoolValue(true), replicate)
+	assert.Equal(t, types.BoolValue(false), adopt)
+}
@@ -0,0 +1,301 @@
+package resources_unit_tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/bab3l/terraform-provider-netbox/internal/resources"
+	"github.com/bab3l/terraform-provider-netbox/internal/testutil"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestObjectResource(t *testing.T) {
+	t.Parallel()
+
+	r := resources.NewObjectResource()
+	testutil.ValidateResourceMetadata(t, r, "netbox", "netbox_object")
+	testutil.ValidateResourceConfigure(t, r)
+	testutil.ValidateResourceSchema(t, testutil.ResourceSchema(t, r).Attributes, testutil.SchemaValidation{
+		Required: []string{"path", "body"},
+		Computed: []string{"id", "object_json"},
+	})
+}
+
+// fakeZoneAPI is a minimal in-memory implementation of a plugin list endpoint.
+type fakeZoneAPI struct {
+	mu      sync.Mutex
+	nextID  int
+	objects map[string]map[string]interface{}
+	methods []string
+	bodies  []map[string]interface{}
+}
+
+func newFakeZoneAPI() *fakeZoneAPI {
+	return &fakeZoneAPI{nextID: 1, objects: map[string]map[string]interface{}{}}
+}
+
+func (f *fakeZoneAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	const prefix = "/api/plugins/netbox-dns/zones/"
+	if !strings.HasPrefix(r.URL.Path, prefix) {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	id := strings.Trim(strings.TrimPrefix(r.URL.Path, prefix), "/")
+	f.methods = append(f.methods, r.Method)
+
+	var body map[string]interface{}
+	if r.Body != nil {
+		_ = json.NewDecoder(r.Body).Decode(&body)
+	}
+	f.bodies = append(f.bodies, body)
+
+	switch {
+	case r.Method == http.MethodPost && id == "":
+		obj := map[string]interface{}{"id": f.nextID, "url": "http://netbox/api/plugins/netbox-dns/zones/" + strconv.Itoa(f.nextID) + "/", "serial": 1}
+		for k, v := range body {
+			obj[k] = v
+		}
+		// The API expands references and choices on read-back.
+		if view, ok := obj["view"].(float64); ok {
+			obj["view"] = map[string]interface{}{"id": view, "name": "default"}
+		}
+		if status, ok := obj["status"].(string); ok {
+			obj["status"] = map[string]interface{}{"value": status, "label": strings.ToUpper(status)}
+		}
+		f.objects[strconv.Itoa(f.nextID)] = obj
+		f.nextID++
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(obj)
+	case id == "":
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	case f.objects[id] == nil:
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"detail": "Not found."}`))
+	case r.Method == http.MethodGet:
+		_ = json.NewEncoder(w).Encode(f.objects[id])
+	case r.Method == http.MethodPatch:
+		for k, v := range body {
+			f.objects[id][k] = v
+		}
+		_ = json.NewEncoder(w).Encode(f.objects[id])
+	case r.Method == http.MethodDelete:
+		delete(f.objects, id)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func objectBodyValue(attrs map[string]tftypes.Value) tftypes.Value {
+	attrTypes := make(map[string]tftypes.Type, len(attrs))
+	for name, v := range attrs {
+		attrTypes[name] = v.Type()
+	}
+	return tftypes.NewValue(tftypes.Object{AttributeTypes: attrTypes}, attrs)
+}
+
+func TestObjectResourceLifecycle(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	api := newFakeZoneAPI()
+	client := testutil.NewMockAPIClient(t, api)
+
+	r := resources.NewObjectResource()
+	testutil.ConfigureResource(t, r, client)
+	s := testutil.ResourceSchema(t, r)
+
+	body := objectBodyValue(map[string]tftypes.Value{
+		"name":   tftypes.NewValue(tftypes.String, "example.com"),
+		"status": tftypes.NewValue(tftypes.String, "active"),
+		"view":   tftypes.NewValue(tftypes.Number, 3),
+	})
+	plan := tfsdk.Plan{Schema: s, Raw: testutil.ResourceObjectValue(t, s, map[string]tftypes.Value{
+		"id":          tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"path":        tftypes.NewValue(tftypes.String, "plugins/netbox-dns/zones"),
+		"body":        body,
+		"object_json": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+	})}
+
+	// Create.
+	createResp := &fwresource.CreateResponse{State: tfsdk.State{Schema: s, Raw: plan.Raw}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan}, createResp)
+	require.False(t, createResp.Diagnostics.HasError(), "Create returned errors: %v", createResp.Diagnostics)
+	assert.Equal(t, http.MethodPost, api.methods[0])
+	assert.Equal(t, "example.com", api.bodies[0]["name"])
+
+	var id string
+	require.False(t, createResp.State.GetAttribute(ctx, path.Root("id"), &id).HasError())
+	assert.Equal(t, "1", id)
+
+	// Read does not report drift: unmanaged keys are ignored, references match
+	// by ID and choices by value.
+	readResp := &fwresource.ReadResponse{State: createResp.State}
+	r.Read(ctx, fwresource.ReadRequest{State: createResp.State}, readResp)
+	require.False(t, readResp.Diagnostics.HasError(), "Read returned errors: %v", readResp.Diagnostics)
+	assert.True(t, bodyEqual(t, readResp.State, createResp.State), "body should not drift")
+
+	// Out-of-band change to a managed key shows up in state.
+	api.mu.Lock()
+	api.objects["1"]["status"] = map[string]interface{}{"value": "deprecated", "label": "Deprecated"}
+	api.objects["1"]["serial"] = 2
+	api.mu.Unlock()
+
+	driftResp := &fwresource.ReadResponse{State: createResp.State}
+	r.Read(ctx, fwresource.ReadRequest{State: createResp.State}, driftResp)
+	require.False(t, driftResp.Diagnostics.HasError(), "Read returned errors: %v", driftResp.Diagnostics)
+	var drifted types.Dynamic
+	require.False(t, driftResp.State.GetAttribute(ctx, path.Root("body"), &drifted).HasError())
+	driftedAttrs := drifted.UnderlyingValue().(types.Object).Attributes()
+	assert.Equal(t, types.StringValue("deprecated"), driftedAttrs["status"])
+	assert.Equal(t, types.StringValue("example.com"), driftedAttrs["name"])
+
+	// Update sends a PATCH with the configured keys.
+	updateResp := &fwresource.UpdateResponse{State: driftResp.State}
+	r.Update(ctx, fwresource.UpdateRequest{Plan: plan, State: driftResp.State}, updateResp)
+	require.False(t, updateResp.Diagnostics.HasError(), "Update returned errors: %v", updateResp.Diagnostics)
+	assert.Equal(t, http.MethodPatch, api.methods[len(api.methods)-1])
+	assert.Equal(t, "active", api.bodies[len(api.bodies)-1]["status"])
+
+	// Delete.
+	deleteResp := &fwresource.DeleteResponse{State: updateResp.State}
+	r.Delete(ctx, fwresource.DeleteRequest{State: updateResp.State}, deleteResp)
+	require.False(t, deleteResp.Diagnostics.HasError(), "Delete returned errors: %v", deleteResp.Diagnostics)
+	assert.Empty(t, api.objects)
+
+	// Reading a deleted object removes it from state.
+	goneResp := &fwresource.ReadResponse{State: updateResp.State}
+	r.Read(ctx, fwresource.ReadRequest{State: updateResp.State}, goneResp)
+	require.False(t, goneResp.Diagnostics.HasError())
+	assert.True(t, goneResp.State.Raw.IsNull())
+}
+
+func TestObjectResourceJSONStringBody(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	api := newFakeZoneAPI()
+	client := testutil.NewMockAPIClient(t, api)
+
+	r := resources.NewObjectResource()
+	testutil.ConfigureResource(t, r, client)
+	s := testutil.ResourceSchema(t, r)
+
+	bodyJSON := `{"name": "example.org", "tags": [], "default_ttl": 3600}`
+	plan := tfsdk.Plan{Schema: s, Raw: testutil.ResourceObjectValue(t, s, map[string]tftypes.Value{
+		"id":          tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"path":        tftypes.NewValue(tftypes.String, "/api/plugins/netbox-dns/zones/"),
+		"body":        tftypes.NewValue(tftypes.String, bodyJSON),
+		"object_json": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+	})}
+
+	createResp := &fwresource.CreateResponse{State: tfsdk.State{Schema: s, Raw: plan.Raw}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan}, createResp)
+	require.False(t, createResp.Diagnostics.HasError(), "Create returned errors: %v", createResp.Diagnostics)
+	assert.InDelta(t, 3600, api.bodies[0]["default_ttl"], 0)
+
+	// Without drift the configured string is kept verbatim.
+	readResp := &fwresource.ReadResponse{State: createResp.State}
+	r.Read(ctx, fwresource.ReadRequest{State: createResp.State}, readResp)
+	require.False(t, readResp.Diagnostics.HasError(), "Read returned errors: %v", readResp.Diagnostics)
+	var body types.Dynamic
+	require.False(t, readResp.State.GetAttribute(ctx, path.Root("body"), &body).HasError())
+	assert.Equal(t, types.StringValue(bodyJSON), body.UnderlyingValue())
+}
+
+func TestObjectResourceCreateError(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	client := testutil.NewMockAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"name": ["This field is required."]}`))
+	}))
+
+	r := resources.NewObjectResource()
+	testutil.ConfigureResource(t, r, client)
+	s := testutil.ResourceSchema(t, r)
+
+	plan := tfsdk.Plan{Schema: s, Raw: testutil.ResourceObjectValue(t, s, map[string]tftypes.Value{
+		"id":          tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"path":        tftypes.NewValue(tftypes.String, "plugins/bgp/session"),
+		"body":        tftypes.NewValue(tftypes.String, `{}`),
+		"object_json": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+	})}
+
+	createResp := &fwresource.CreateResponse{State: tfsdk.State{Schema: s, Raw: plan.Raw}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan}, createResp)
+	require.True(t, createResp.Diagnostics.HasError())
+	assert.Contains(t, createResp.Diagnostics.Errors()[0].Detail(), "This field is required.")
+}
+
+func TestObjectResourceValidateConfig(t *testing.T) {
+	t.Parallel()
+
+	r := resources.NewObjectResource()
+	s := testutil.ResourceSchema(t, r)
+	validator := r.(fwresource.ResourceWithValidateConfig)
+
+	cases := map[string]tftypes.Value{
+		"list body":    tftypes.NewValue(tftypes.String, `[1, 2]`),
+		"invalid json": tftypes.NewValue(tftypes.String, `{"name": `),
+		"scalar body":  tftypes.NewValue(tftypes.Bool, true),
+	}
+	for name, body := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			config := tfsdk.Config{Schema: s, Raw: testutil.ResourceObjectValue(t, s, map[string]tftypes.Value{
+				"path": tftypes.NewValue(tftypes.String, "plugins/bgp/session"),
+				"body": body,
+			})}
+			resp := &fwresource.ValidateConfigResponse{}
+			validator.ValidateConfig(context.Background(), fwresource.ValidateConfigRequest{Config: config}, resp)
+			assert.True(t, resp.Diagnostics.HasError())
+		})
+	}
+}
+
+func TestObjectResourceImportState(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	r := resources.NewObjectResource()
+	s := testutil.ResourceSchema(t, r)
+	importer := r.(fwresource.ResourceWithImportState)
+
+	resp := &fwresource.ImportStateResponse{State: tfsdk.State{Schema: s, Raw: testutil.ResourceObjectValue(t, s, nil)}}
+	importer.ImportState(ctx, fwresource.ImportStateRequest{ID: "plugins/netbox-dns/zones/12"}, resp)
+	require.False(t, resp.Diagnostics.HasError(), "ImportState returned errors: %v", resp.Diagnostics)
+
+	var apiPath, id string
+	require.False(t, resp.State.GetAttribute(ctx, path.Root("path"), &apiPath).HasError())
+	require.False(t, resp.State.GetAttribute(ctx, path.Root("id"), &id).HasError())
+	assert.Equal(t, "plugins/netbox-dns/zones", apiPath)
+	assert.Equal(t, "12", id)
+
+	badResp := &fwresource.ImportStateResponse{State: tfsdk.State{Schema: s, Raw: testutil.ResourceObjectValue(t, s, nil)}}
+	importer.ImportState(ctx, fwresource.ImportStateRequest{ID: "12"}, badResp)
+	assert.True(t, badResp.Diagnostics.HasError())
+}
+
+func bodyEqual(t *testing.T, a, b tfsdk.State) bool {
+	t.Helper()
+
+	var left, right types.Dynamic
+	require.False(t, a.GetAttribute(context.Background(), path.Root("body"), &left).HasError())
+	require.False(t, b.GetAttribute(context.Background(), path.Root("body"), &right).HasError())
+	return left.Equal(right)
+}
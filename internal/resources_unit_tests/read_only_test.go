@@ -0,0 +1,83 @@
+package resources_unit_tests
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/bab3l/terraform-provider-netbox/internal/resources"
+	"github.com/bab3l/terraform-provider-netbox/internal/testutil"
+	"github.com/bab3l/terraform-provider-netbox/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadOnly_FailsBeforeRequests(t *testing.T) {
+	t.Parallel()
+
+	client := testutil.NewMockAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+	}))
+	utils.SetProviderSettings(client, utils.ProviderSettings{ReadOnly: true})
+
+	r := resources.NewManufacturerResource()
+	testutil.ConfigureResource(t, r, client)
+	s := testutil.ResourceSchema(t, r)
+	value := testutil.ResourceObjectValue(t, s, map[string]tftypes.Value{
+		"id":   tftypes.NewValue(tftypes.String, "1"),
+		"name": tftypes.NewValue(tftypes.String, "Cisco"),
+		"slug": tftypes.NewValue(tftypes.String, "cisco"),
+	})
+	plan := tfsdk.Plan{Schema: s, Raw: value}
+	state := tfsdk.State{Schema: s, Raw: value}
+	ctx := context.Background()
+
+	createResp := &fwresource.CreateResponse{State: tfsdk.State{Schema: s, Raw: tftypes.NewValue(value.Type(), nil)}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan}, createResp)
+	updateResp := &fwresource.UpdateResponse{State: state}
+	r.Update(ctx, fwresource.UpdateRequest{Plan: plan, State: state}, updateResp)
+	deleteResp := &fwresource.DeleteResponse{State: state}
+	r.Delete(ctx, fwresource.DeleteRequest{State: state}, deleteResp)
+
+	for action, diags := range map[string]diag.Diagnostics{
+		"created": createResp.Diagnostics,
+		"updated": updateResp.Diagnostics,
+		"deleted": deleteResp.Diagnostics,
+	} {
+		require.Len(t, diags.Errors(), 1, action)
+		assert.Equal(t, "Provider is read-only", diags.Errors()[0].Summary())
+		assert.Contains(t, diags.Errors()[0].Detail(), "cannot be "+action)
+	}
+	assert.True(t, createResp.State.Raw.IsNull(), "nothing is saved to state")
+}
+
+func TestResourcesCheckReadOnly(t *testing.T) {
+	t.Parallel()
+
+	files, err := filepath.Glob(filepath.Join("..", "resources", "*_resource.go"))
+	require.NoError(t, err)
+	method := regexp.MustCompile(`(?m)^func \(r \*\w+\) (Create|Update|Delete)\(ctx context.Context, req resource.\w+, resp \*resource.\w+\) \{\n(.*)\n`)
+
+	var offenders []string
+	checked := 0
+	for _, file := range files {
+		data, err := os.ReadFile(file) // #nosec G304 -- test scans repo files under known root
+		require.NoError(t, err)
+		for _, match := range method.FindAllStringSubmatch(string(data), -1) {
+			checked++
+			if !strings.Contains(match[2], "readOnlyDenied(") {
+				offenders = append(offenders, filepath.Base(file)+" "+match[1])
+			}
+		}
+	}
+	assert.Greater(t, checked, 300)
+	assert.Empty(t, offenders, "Create, Update and Delete must start by checking readOnlyDenied")
+}
@@ -10,8 +10,10 @@ import (
 	"regexp"
 
 	"github.com/bab3l/terraform-provider-netbox/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework-validators/dynamicvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	dsschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -371,6 +373,40 @@ func TagsSlugAttribute() schema.SetAttribute {
 	}
 }
 
+// TagsAllAttribute returns the computed tags_all attribute for resources: the
+// slugs of all tags of the object, including the provider's default_tags and
+// ignored tags.
+func TagsAllAttribute() schema.SetAttribute {
+	return schema.SetAttribute{
+		MarkdownDescription: "Slugs of all tags assigned to this resource, including the tags added by the provider's `default_tags` and those matching its `ignore_tags`.",
+		Computed:            true,
+		ElementType:         types.StringType,
+	}
+}
+
+// CustomFieldsAllAttribute returns the computed custom_fields_all attribute for
+// resources: every custom field value of the object, including the provider's
+// default_custom_fields.
+func CustomFieldsAllAttribute() schema.MapAttribute {
+	return schema.MapAttribute{
+		MarkdownDescription: "All custom field values of this resource keyed by custom field name, including the values set by the provider's `default_custom_fields` and by NetBox defaults. " +
+			"Values are formatted as in `custom_fields`: lists are comma-separated and related objects are given by ID.",
+		Computed:    true,
+		ElementType: types.StringType,
+	}
+}
+
+// BranchAttribute returns the optional branch attribute for resources: the
+// schema ID of the netbox-branching branch the object is managed in, which
+// overrides the provider's branch.
+func BranchAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		MarkdownDescription: "Schema ID of the branch of the netbox-branching plugin in which to manage this object, e.g. `netbox_branch.example.schema_id`. Overrides the provider's `branch`. " +
+			"Once the branch is merged, remove this attribute to manage the object in main. Imported objects are read in the provider's branch.",
+		Optional: true,
+	}
+}
+
 // CustomFieldsAttribute returns the standard custom fields set attribute for resources.
 // This is Optional-only because:
 //   - Users explicitly manage custom field values in their configuration
@@ -378,7 +414,7 @@ func TagsSlugAttribute() schema.SetAttribute {
 //     is the source of truth for managed resources
 func CustomFieldsAttribute() schema.SetNestedAttribute {
 	return schema.SetNestedAttribute{
-		MarkdownDescription: "Custom fields assigned to this resource. Custom fields must be defined in Netbox before use.",
+		MarkdownDescription: "Custom fields assigned to this resource. Custom fields must be defined in Netbox before use, and values are validated against their definitions at plan time. `object` and `multiobject` values may refer to the related objects by ID or slug, comma-separated for `multiobject`.",
 		Optional:            true,
 		NestedObject: schema.NestedAttributeObject{
 			Attributes: map[string]schema.Attribute{
@@ -426,6 +462,23 @@ func CustomFieldsAttribute() schema.SetNestedAttribute {
 	}
 }
 
+// CustomFieldsMapAttribute returns the custom_fields_map attribute for resources.
+// It is an alternative to custom_fields that takes native Terraform values,
+// which are converted using the custom field definitions in NetBox.
+func CustomFieldsMapAttribute() schema.DynamicAttribute {
+	return schema.DynamicAttribute{
+		MarkdownDescription: "Custom field values keyed by custom field name, using native types: numbers for `integer` and `decimal`, booleans for `boolean` and lists of strings for `multiselect`. " +
+			"`object` fields take the ID or slug of the related object and `multiobject` fields a list of them; the configured references are kept in state while they refer to the same objects. " +
+			"Values are checked against the custom field definitions in NetBox. Only the fields listed here are managed; removing a field from the map leaves its value in NetBox unchanged, while setting it to `null` clears it. " +
+			"Conflicts with `custom_fields`.",
+		Optional: true,
+		Validators: []validator.Dynamic{
+			validators.ValidCustomFieldsMap(),
+			dynamicvalidator.ConflictsWith(path.MatchRoot("custom_fields")),
+		},
+	}
+}
+
 // =====================================================
 // DATA SOURCE SCHEMA ATTRIBUTES
 // =====================================================
@@ -0,0 +1,144 @@
+package testutil
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bab3l/go-netbox"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	dsschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	rsschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// NewMockAPIClient starts an httptest server backed by handler and returns a
+// go-netbox client configured to talk to it. The server is closed when the test ends.
+func NewMockAPIClient(t *testing.T, handler http.Handler) *netbox.APIClient {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	cfg := netbox.NewConfiguration()
+	cfg.Servers = netbox.ServerConfigurations{{URL: server.URL}}
+	cfg.DefaultHeader = map[string]string{"Authorization": "Token test-token"}
+	cfg.HTTPClient = server.Client()
+
+	return netbox.NewAPIClient(cfg)
+}
+
+// DataSourceSchema returns the schema of a data source, failing the test on error.
+func DataSourceSchema(t *testing.T, d datasource.DataSource) dsschema.Schema {
+	t.Helper()
+
+	resp := &datasource.SchemaResponse{}
+	d.Schema(context.Background(), datasource.SchemaRequest{}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Schema returned errors: %v", resp.Diagnostics)
+	}
+	return resp.Schema
+}
+
+// ReadDataSource configures d with client and runs Read using a configuration
+// built from values. Top-level attributes and blocks missing from values are null.
+func ReadDataSource(t *testing.T, d datasource.DataSource, client *netbox.APIClient, values map[string]tftypes.Value) *datasource.ReadResponse {
+	t.Helper()
+
+	ctx := context.Background()
+	if configurable, ok := d.(datasource.DataSourceWithConfigure); ok {
+		configureResp := &datasource.ConfigureResponse{}
+		configurable.Configure(ctx, datasource.ConfigureRequest{ProviderData: client}, configureResp)
+		if configureResp.Diagnostics.HasError() {
+			t.Fatalf("Configure returned errors: %v", configureResp.Diagnostics)
+		}
+	}
+
+	s := DataSourceSchema(t, d)
+	objectType, ok := s.Type().TerraformType(ctx).(tftypes.Object)
+	if !ok {
+		t.Fatalf("Expected schema to have an object type")
+	}
+
+	config := tfsdk.Config{Schema: s, Raw: objectValue(objectType, values)}
+	resp := &datasource.ReadResponse{
+		State: tfsdk.State{Schema: s, Raw: tftypes.NewValue(objectType, nil)},
+	}
+	d.Read(ctx, datasource.ReadRequest{Config: config}, resp)
+	return resp
+}
+
+// ResourceSchema returns the schema of a resource, failing the test on error.
+func ResourceSchema(t *testing.T, r resource.Resource) rsschema.Schema {
+	t.Helper()
+
+	resp := &resource.SchemaResponse{}
+	r.Schema(context.Background(), resource.SchemaRequest{}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Schema returned errors: %v", resp.Diagnostics)
+	}
+	return resp.Schema
+}
+
+// ConfigureResource configures r with client, failing the test on error.
+func ConfigureResource(t *testing.T, r resource.Resource, client *netbox.APIClient) {
+	t.Helper()
+
+	if configurable, ok := r.(resource.ResourceWithConfigure); ok {
+		resp := &resource.ConfigureResponse{}
+		configurable.Configure(context.Background(), resource.ConfigureRequest{ProviderData: client}, resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Configure returned errors: %v", resp.Diagnostics)
+		}
+	}
+}
+
+// ResourceObjectValue builds the raw value of a resource plan, state or config
+// from values. Top-level attributes missing from values are null.
+func ResourceObjectValue(t *testing.T, s rsschema.Schema, values map[string]tftypes.Value) tftypes.Value {
+	t.Helper()
+
+	objectType, ok := s.Type().TerraformType(context.Background()).(tftypes.Object)
+	if !ok {
+		t.Fatalf("Expected schema to have an object type")
+	}
+	return objectValue(objectType, values)
+}
+
+func objectValue(objectType tftypes.Object, values map[string]tftypes.Value) tftypes.Value {
+	raw := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attrType := range objectType.AttributeTypes {
+		if v, ok := values[name]; ok {
+			raw[name] = v
+			continue
+		}
+		raw[name] = tftypes.NewValue(attrType, nil)
+	}
+	return tftypes.NewValue(objectType, raw)
+}
+
+// QueryFilterValue builds the tftypes value of a `filter` set block as used by
+// the plural/query data sources.
+func QueryFilterValue(filters map[string][]string) tftypes.Value {
+	filterType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"name":   tftypes.String,
+		"values": tftypes.List{ElementType: tftypes.String},
+	}}
+
+	elements := make([]tftypes.Value, 0, len(filters))
+	for name, values := range filters {
+		vals := make([]tftypes.Value, 0, len(values))
+		for _, v := range values {
+			vals = append(vals, tftypes.NewValue(tftypes.String, v))
+		}
+		elements = append(elements, tftypes.NewValue(filterType, map[string]tftypes.Value{
+			"name":   tftypes.NewValue(tftypes.String, name),
+			"values": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, vals),
+		}))
+	}
+
+	return tftypes.NewValue(tftypes.Set{ElementType: filterType}, elements)
+}
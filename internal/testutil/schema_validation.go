@@ -177,10 +177,18 @@ func isRequired(attr schema.Attribute) bool {
 
 		return a.Required
 
+	case schema.MapNestedAttribute:
+
+		return a.Required
+
 	case schema.SingleNestedAttribute:
 
 		return a.Required
 
+	case schema.DynamicAttribute:
+
+		return a.Required
+
 	default:
 
 		return false
@@ -231,10 +239,18 @@ func isComputed(attr schema.Attribute) bool {
 
 		return a.Computed
 
+	case schema.MapNestedAttribute:
+
+		return a.Computed
+
 	case schema.SingleNestedAttribute:
 
 		return a.Computed
 
+	case schema.DynamicAttribute:
+
+		return a.Computed
+
 	default:
 
 		return false
@@ -553,6 +569,8 @@ func isDataSourceOptional(attr dsschemapkg.Attribute) bool {
 		return a.Optional
 	case dsschemapkg.Int64Attribute:
 		return a.Optional
+	case dsschemapkg.Float64Attribute:
+		return a.Optional
 	case dsschemapkg.BoolAttribute:
 		return a.Optional
 	case dsschemapkg.SetAttribute:
@@ -567,6 +585,8 @@ func isDataSourceOptional(attr dsschemapkg.Attribute) bool {
 		return a.Optional
 	case dsschemapkg.SingleNestedAttribute:
 		return a.Optional
+	case dsschemapkg.DynamicAttribute:
+		return a.Optional
 	default:
 		return false
 	}
@@ -581,6 +601,8 @@ func isDataSourceComputed(attr dsschemapkg.Attribute) bool {
 		return a.Computed
 	case dsschemapkg.Int64Attribute:
 		return a.Computed
+	case dsschemapkg.Float64Attribute:
+		return a.Computed
 	case dsschemapkg.BoolAttribute:
 		return a.Computed
 	case dsschemapkg.SetAttribute:
@@ -595,6 +617,8 @@ func isDataSourceComputed(attr dsschemapkg.Attribute) bool {
 		return a.Computed
 	case dsschemapkg.SingleNestedAttribute:
 		return a.Computed
+	case dsschemapkg.DynamicAttribute:
+		return a.Computed
 	default:
 		return false
 	}
@@ -0,0 +1,194 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/bab3l/go-netbox"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// BranchesAPIPath is the API path of the branches of the netbox-branching
+// plugin, which the generated client does not cover.
+const BranchesAPIPath = "plugins/branching/branches"
+
+// Statuses of branches.
+const (
+	BranchStatusReady  = "ready"
+	BranchStatusMerged = "merged"
+)
+
+// branchTransitionalStatuses are the statuses of branches that a job is
+// working on.
+var branchTransitionalStatuses = []string{"new", "provisioning", "syncing", "migrating", "merging", "reverting"}
+
+// BranchPollInterval is the interval at which WaitForJob and WaitForBranch
+// read a job or branch.
+var BranchPollInterval = 2 * time.Second
+
+// Branch is a branch of the netbox-branching plugin.
+type Branch struct {
+	ID          int64      `json:"id"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Comments    string     `json:"comments"`
+	SchemaID    string     `json:"schema_id"`
+	Status      string     `json:"-"`
+	LastSync    *time.Time `json:"last_sync"`
+	MergedTime  *time.Time `json:"merged_time"`
+}
+
+// UnmarshalJSON decodes a branch, whose status is a choice object.
+func (b *Branch) UnmarshalJSON(data []byte) error {
+	type branch Branch
+	var decoded struct {
+		branch
+		Status struct {
+			Value string `json:"value"`
+		} `json:"status"`
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	*b = Branch(decoded.branch)
+	b.Status = decoded.Status.Value
+	return nil
+}
+
+// Transitional reports whether a job is working on the branch, e.g. to
+// provision, sync or merge it.
+func (b *Branch) Transitional() bool {
+	return slices.Contains(branchTransitionalStatuses, b.Status)
+}
+
+// GetBranch reads the branch with id.
+func GetBranch(ctx context.Context, client *netbox.APIClient, id int64) (*Branch, *http.Response, error) {
+	body, httpResp, err := DoRawAPIRequest(ctx, client, http.MethodGet, fmt.Sprintf("%s/%d", BranchesAPIPath, id), nil, nil)
+	if err != nil {
+		return nil, httpResp, err
+	}
+	return decodeBranch(body, httpResp)
+}
+
+// ListBranches returns the branches matching query.
+func ListBranches(ctx context.Context, client *netbox.APIClient, query url.Values) ([]Branch, *http.Response, error) {
+	results, httpResp, err := ListRawAPIObjects(ctx, client, BranchesAPIPath, query)
+	if err != nil {
+		return nil, httpResp, err
+	}
+	branches := make([]Branch, len(results))
+	for i, raw := range results {
+		if err := json.Unmarshal(raw, &branches[i]); err != nil {
+			return nil, httpResp, fmt.Errorf("failed to decode branch: %w", err)
+		}
+	}
+	return branches, httpResp, nil
+}
+
+// WriteBranch creates the branch described by fields when id is 0, and
+// updates the branch with id otherwise.
+func WriteBranch(ctx context.Context, client *netbox.APIClient, id int64, fields map[string]interface{}) (*Branch, *http.Response, error) {
+	method, apiPath := http.MethodPost, BranchesAPIPath
+	if id != 0 {
+		method, apiPath = http.MethodPatch, fmt.Sprintf("%s/%d", BranchesAPIPath, id)
+	}
+	body, httpResp, err := DoRawAPIRequest(ctx, client, method, apiPath, nil, fields)
+	if err != nil {
+		return nil, httpResp, err
+	}
+	return decodeBranch(body, httpResp)
+}
+
+// StartBranchJob enqueues the job that performs action on the branch with
+// id: "sync", "merge" or "revert", and returns the ID of the job. Use
+// WaitForJob to wait for it.
+func StartBranchJob(ctx context.Context, client *netbox.APIClient, id int64, action string) (int64, *http.Response, error) {
+	body, httpResp, err := DoRawAPIRequest(ctx, client, http.MethodPost, fmt.Sprintf("%s/%d/%s", BranchesAPIPath, id, action), nil,
+		map[string]interface{}{"commit": true})
+	if err != nil {
+		return 0, httpResp, err
+	}
+	var job struct {
+		ID int64 `json:"id"`
+	}
+	if err := json.Unmarshal(body, &job); err != nil {
+		return 0, httpResp, fmt.Errorf("failed to decode branch job: %w", err)
+	}
+	return job.ID, httpResp, nil
+}
+
+// WaitForJob reads the background job with id until it has finished, and
+// returns an error unless it completed.
+func WaitForJob(ctx context.Context, client *netbox.APIClient, id int64) (*http.Response, error) {
+	for {
+		body, httpResp, err := DoRawAPIRequest(ctx, client, http.MethodGet, fmt.Sprintf("core/jobs/%d", id), nil, nil)
+		if err != nil {
+			return httpResp, err
+		}
+		var job struct {
+			Name   string `json:"name"`
+			Status struct {
+				Value string `json:"value"`
+			} `json:"status"`
+			Error string `json:"error"`
+		}
+		if err := json.Unmarshal(body, &job); err != nil {
+			return httpResp, fmt.Errorf("failed to decode job: %w", err)
+		}
+		switch job.Status.Value {
+		case "completed":
+			return httpResp, nil
+		case "errored", "failed":
+			return httpResp, fmt.Errorf("job %q %s: %s", job.Name, job.Status.Value, job.Error)
+		}
+		select {
+		case <-ctx.Done():
+			return httpResp, fmt.Errorf("job %q is still %s: %w", job.Name, job.Status.Value, ctx.Err())
+		case <-time.After(BranchPollInterval):
+		}
+	}
+}
+
+// WaitForBranch reads the branch with id until no job is working on it, and
+// returns an error unless its status is then want.
+func WaitForBranch(ctx context.Context, client *netbox.APIClient, id int64, want string) (*Branch, *http.Response, error) {
+	for {
+		branch, httpResp, err := GetBranch(ctx, client, id)
+		if err != nil {
+			return nil, httpResp, err
+		}
+		if !branch.Transitional() {
+			if branch.Status != want {
+				return branch, httpResp, fmt.Errorf("branch %q is %s instead of %s; see its jobs in NetBox for details", branch.Name, branch.Status, want)
+			}
+			return branch, httpResp, nil
+		}
+		select {
+		case <-ctx.Done():
+			return branch, httpResp, fmt.Errorf("branch %q is still %s: %w", branch.Name, branch.Status, ctx.Err())
+		case <-time.After(BranchPollInterval):
+		}
+	}
+}
+
+// BranchTime converts a time of a branch to an RFC 3339 string, null when
+// the time is not set.
+func BranchTime(t *time.Time) types.String {
+	if t == nil {
+		return types.StringNull()
+	}
+	return types.StringValue(t.UTC().Format(time.RFC3339))
+}
+
+func decodeBranch(body []byte, httpResp *http.Response) (*Branch, *http.Response, error) {
+	var branch Branch
+	if err := json.Unmarshal(body, &branch); err != nil {
+		return nil, httpResp, fmt.Errorf("failed to decode branch: %w", err)
+	}
+	return &branch, httpResp, nil
+}
@@ -0,0 +1,94 @@
+package utils
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	BranchPollInterval = time.Millisecond
+}
+
+// branchStatusHandler serves branch 3 with statuses in turn, repeating the
+// last one.
+func branchStatusHandler(t *testing.T, statuses ...string) http.HandlerFunc {
+	var reads atomic.Int32
+	return func(w http.ResponseWriter, r *http.Request) {
+		assert.True(t, strings.HasSuffix(r.URL.Path, "/api/plugins/branching/branches/3/"), r.URL.Path)
+		i := min(int(reads.Add(1))-1, len(statuses)-1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"id": 3, "name": "review", "schema_id": "td5smq0f", "status": {"value": "`+statuses[i]+`", "label": "x"},
+			"last_sync": "2024-05-01T10:00:00.123456Z", "merged_time": null}`)
+	}
+}
+
+func TestWaitForBranch(t *testing.T) {
+	t.Parallel()
+
+	client := newRawTestClient(t, branchStatusHandler(t, "new", "provisioning", "ready"))
+	branch, _, err := WaitForBranch(context.Background(), client, 3, BranchStatusReady)
+	require.NoError(t, err)
+	assert.Equal(t, "ready", branch.Status)
+	assert.Equal(t, "td5smq0f", branch.SchemaID)
+	assert.Equal(t, types.StringValue("2024-05-01T10:00:00Z"), BranchTime(branch.LastSync))
+	assert.True(t, BranchTime(branch.MergedTime).IsNull())
+}
+
+func TestWaitForBranch_Failed(t *testing.T) {
+	t.Parallel()
+
+	client := newRawTestClient(t, branchStatusHandler(t, "provisioning", "failed"))
+	_, _, err := WaitForBranch(context.Background(), client, 3, BranchStatusReady)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `branch "review" is failed instead of ready`)
+}
+
+func TestWaitForBranch_Cancelled(t *testing.T) {
+	t.Parallel()
+
+	client := newRawTestClient(t, branchStatusHandler(t, "merging"))
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, _, err := WaitForBranch(ctx, client, 3, BranchStatusMerged)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestStartBranchJob(t *testing.T) {
+	t.Parallel()
+
+	var jobReads atomic.Int32
+	client := newRawTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/api/plugins/branching/branches/3/merge/"):
+			assert.Equal(t, http.MethodPost, r.Method)
+			body, _ := io.ReadAll(r.Body)
+			assert.JSONEq(t, `{"commit": true}`, string(body))
+			_, _ = io.WriteString(w, `{"id": 8, "name": "Merge branch", "status": {"value": "pending"}}`)
+		case strings.HasSuffix(r.URL.Path, "/api/core/jobs/8/"):
+			if jobReads.Add(1) < 2 {
+				_, _ = io.WriteString(w, `{"id": 8, "name": "Merge branch", "status": {"value": "running"}}`)
+				return
+			}
+			_, _ = io.WriteString(w, `{"id": 8, "name": "Merge branch", "status": {"value": "errored"}, "error": "conflicts"}`)
+		default:
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	jobID, _, err := StartBranchJob(context.Background(), client, 3, "merge")
+	require.NoError(t, err)
+	assert.EqualValues(t, 8, jobID)
+	_, err = WaitForJob(context.Background(), client, jobID)
+	require.Error(t, err)
+	assert.Equal(t, `job "Merge branch" errored: conflicts`, err.Error())
+}
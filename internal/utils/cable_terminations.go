@@ -0,0 +1,133 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/bab3l/go-netbox"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// CableTerminationEndpoint describes the REST endpoint of an object type that
+// can terminate a cable.
+type CableTerminationEndpoint struct {
+	// ObjectType is the NetBox content type, e.g. `dcim.interface`.
+	ObjectType string
+	// APIPath is the list endpoint relative to /api/, e.g. `dcim/interfaces`.
+	APIPath string
+	// PassThrough is set for front and rear ports, whose cable paths are
+	// returned by `/paths/` instead of `/trace/`.
+	PassThrough bool
+	// DeviceComponent is set for objects that belong to a device and can be
+	// looked up by device and name.
+	DeviceComponent bool
+}
+
+var cableTerminationEndpoints = map[string]CableTerminationEndpoint{
+	"dcim.interface":              {ObjectType: "dcim.interface", APIPath: "dcim/interfaces", DeviceComponent: true},
+	"dcim.frontport":              {ObjectType: "dcim.frontport", APIPath: "dcim/front-ports", PassThrough: true, DeviceComponent: true},
+	"dcim.rearport":               {ObjectType: "dcim.rearport", APIPath: "dcim/rear-ports", PassThrough: true, DeviceComponent: true},
+	"dcim.consoleport":            {ObjectType: "dcim.consoleport", APIPath: "dcim/console-ports", DeviceComponent: true},
+	"dcim.consoleserverport":      {ObjectType: "dcim.consoleserverport", APIPath: "dcim/console-server-ports", DeviceComponent: true},
+	"dcim.powerport":              {ObjectType: "dcim.powerport", APIPath: "dcim/power-ports", DeviceComponent: true},
+	"dcim.poweroutlet":            {ObjectType: "dcim.poweroutlet", APIPath: "dcim/power-outlets", DeviceComponent: true},
+	"dcim.powerfeed":              {ObjectType: "dcim.powerfeed", APIPath: "dcim/power-feeds"},
+	"circuits.circuittermination": {ObjectType: "circuits.circuittermination", APIPath: "circuits/circuit-terminations", PassThrough: true},
+}
+
+// LookupCableTerminationEndpoint returns the endpoint of a cable termination
+// object type.
+func LookupCableTerminationEndpoint(objectType string) (CableTerminationEndpoint, bool) {
+	endpoint, ok := cableTerminationEndpoints[objectType]
+	return endpoint, ok
+}
+
+// CableTerminationObjectTypes returns the supported termination object types,
+// sorted. When deviceComponentsOnly is set, only types that belong to a device
+// are returned.
+func CableTerminationObjectTypes(deviceComponentsOnly bool) []string {
+	types := make([]string, 0, len(cableTerminationEndpoints))
+	for objectType, endpoint := range cableTerminationEndpoints {
+		if deviceComponentsOnly && !endpoint.DeviceComponent {
+			continue
+		}
+		types = append(types, objectType)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// ObjectTypeFromURL derives the NetBox content type of an object from its API
+// URL, e.g. `https://netbox/api/dcim/front-ports/7/` becomes `dcim.frontport`.
+// It covers the object types found on cable paths.
+// An empty string is returned when the URL is not an object URL.
+func ObjectTypeFromURL(objectURL string) string {
+	if parsed, err := url.Parse(objectURL); err == nil {
+		objectURL = parsed.Path
+	}
+	segments := strings.Split(strings.Trim(objectURL, "/"), "/")
+	for i, segment := range segments {
+		if segment != "api" {
+			continue
+		}
+		rest := segments[i+1:]
+		if len(rest) < 2 || rest[0] == "plugins" {
+			return ""
+		}
+		return rest[0] + "." + strings.TrimSuffix(strings.ReplaceAll(rest[1], "-", ""), "s")
+	}
+	return ""
+}
+
+// FindDeviceComponentID returns the ID of the component called name on a
+// device. The device may be given by ID or name. Errors, including a missing or
+// ambiguous match, are added to diags.
+func FindDeviceComponentID(ctx context.Context, client *netbox.APIClient, endpoint CableTerminationEndpoint, device, name string, diags *diag.Diagnostics) int64 {
+	if !endpoint.DeviceComponent {
+		diags.AddError(
+			"Unsupported termination type",
+			fmt.Sprintf("%s objects do not belong to a device and must be referenced by ID.", endpoint.ObjectType),
+		)
+		return 0
+	}
+
+	query := url.Values{"name": {name}}
+	if _, err := ParseID64(device); err == nil {
+		query.Set("device_id", device)
+	} else {
+		query.Set("device", device)
+	}
+	results, httpResp, err := ListRawAPIObjects(ctx, client, endpoint.APIPath, query)
+	if err != nil {
+		diags.AddError(
+			"Error looking up "+endpoint.ObjectType,
+			FormatAPIError(fmt.Sprintf("look up %s %q on device %q", endpoint.ObjectType, name, device), err, httpResp),
+		)
+		return 0
+	}
+
+	result, ok := ExpectSingleResult(
+		results,
+		"Termination not found",
+		fmt.Sprintf("No %s named %q found on device %q.", endpoint.ObjectType, name, device),
+		"Multiple terminations found",
+		fmt.Sprintf("Multiple %s objects named %q found on devices named %q. Please use the device ID.", endpoint.ObjectType, name, device),
+		diags,
+	)
+	if !ok {
+		return 0
+	}
+
+	var component struct {
+		ID int64 `json:"id"`
+	}
+	if err := json.Unmarshal(*result, &component); err != nil {
+		diags.AddError("Error looking up "+endpoint.ObjectType, fmt.Sprintf("Could not decode %s: %s", endpoint.ObjectType, err))
+		return 0
+	}
+	return component.ID
+}
@@ -0,0 +1,44 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObjectTypeFromURL(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"https://netbox.example/api/dcim/interfaces/10/":                "dcim.interface",
+		"https://netbox.example/api/dcim/front-ports/7/":                "dcim.frontport",
+		"https://netbox.example/api/dcim/console-server-ports/3/":       "dcim.consoleserverport",
+		"https://netbox.example/api/circuits/circuit-terminations/5/":   "circuits.circuittermination",
+		"https://netbox.example/netbox/api/circuits/provider-networks/": "circuits.providernetwork",
+		"/api/dcim/power-feeds/2/":                                      "dcim.powerfeed",
+		"https://netbox.example/api/plugins/bgp/session/1/":             "",
+		"https://netbox.example/dcim/interfaces/10/":                    "",
+		"": "",
+	}
+	for input, want := range tests {
+		assert.Equal(t, want, ObjectTypeFromURL(input), input)
+	}
+}
+
+func TestCableTerminationObjectTypes(t *testing.T) {
+	t.Parallel()
+
+	all := CableTerminationObjectTypes(false)
+	assert.Contains(t, all, "dcim.powerfeed")
+	assert.Contains(t, all, "circuits.circuittermination")
+	assert.IsNonDecreasing(t, all)
+
+	components := CableTerminationObjectTypes(true)
+	assert.NotContains(t, components, "dcim.powerfeed")
+	assert.Contains(t, components, "dcim.interface")
+	for _, objectType := range all {
+		endpoint, ok := LookupCableTerminationEndpoint(objectType)
+		assert.True(t, ok)
+		assert.Equal(t, objectType, endpoint.ObjectType)
+	}
+}
@@ -404,6 +404,9 @@ func MapToCustomFieldModels(customFields map[string]interface{}, stateCustomFiel
 					cf.Value = types.StringValue("")
 				}
 
+			case "object", "multiobject":
+				cf.Value = types.StringValue(formatCustomFieldReferences(stateCF.Value.ValueString(), value))
+
 			case "multiselect", "multiple":
 				if valueSlice, ok := value.([]interface{}); ok {
 					var stringValues []string
@@ -0,0 +1,62 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/bab3l/go-netbox"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// CustomFieldChoice is a choice of a custom field choice set as resolved by
+// NetBox.
+type CustomFieldChoice struct {
+	Value string
+	Label string
+}
+
+// CustomFieldChoiceAttrTypes are the attribute types of a choice in the
+// choices attributes.
+var CustomFieldChoiceAttrTypes = map[string]attr.Type{
+	"value": types.StringType,
+	"label": types.StringType,
+}
+
+// ListCustomFieldChoices returns the choices of the choice set with id as
+// resolved by NetBox: the base choices merged with the extra choices, in the
+// order NetBox offers them. The generated client decodes the choices endpoint
+// as a choice set, so it is read as a raw list.
+func ListCustomFieldChoices(ctx context.Context, client *netbox.APIClient, id int32) ([]CustomFieldChoice, *http.Response, error) {
+	results, httpResp, err := ListRawAPIObjects(ctx, client, fmt.Sprintf("extras/custom-field-choice-sets/%d/choices", id), nil)
+	if err != nil {
+		return nil, httpResp, err
+	}
+	choices := make([]CustomFieldChoice, 0, len(results))
+	for _, raw := range results {
+		var item struct {
+			ID      interface{} `json:"id"`
+			Display interface{} `json:"display"`
+		}
+		if err := json.Unmarshal(raw, &item); err != nil {
+			return nil, httpResp, fmt.Errorf("failed to decode custom field choice: %w", err)
+		}
+		choices = append(choices, CustomFieldChoice{Value: fmt.Sprint(item.ID), Label: fmt.Sprint(item.Display)})
+	}
+	return choices, httpResp, nil
+}
+
+// CustomFieldChoicesToList converts choices to a list of value/label objects.
+func CustomFieldChoicesToList(choices []CustomFieldChoice) types.List {
+	elemType := types.ObjectType{AttrTypes: CustomFieldChoiceAttrTypes}
+	values := make([]attr.Value, len(choices))
+	for i, choice := range choices {
+		values[i] = types.ObjectValueMust(CustomFieldChoiceAttrTypes, map[string]attr.Value{
+			"value": types.StringValue(choice.Value),
+			"label": types.StringValue(choice.Label),
+		})
+	}
+	return types.ListValueMust(elemType, values)
+}
@@ -0,0 +1,41 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListCustomFieldChoices(t *testing.T) {
+	t.Parallel()
+
+	client := newRawTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.True(t, strings.HasSuffix(r.URL.Path, "/api/extras/custom-field-choice-sets/7/choices/"), r.URL.Path)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"count": 2,
+			"results": []interface{}{
+				map[string]interface{}{"id": "AD", "display": "Andorra"},
+				map[string]interface{}{"id": "gold", "display": "Gold"},
+			},
+		})
+	})
+
+	choices, _, err := ListCustomFieldChoices(context.Background(), client, 7)
+	require.NoError(t, err)
+	assert.Equal(t, []CustomFieldChoice{{Value: "AD", Label: "Andorra"}, {Value: "gold", Label: "Gold"}}, choices)
+
+	list := CustomFieldChoicesToList(choices)
+	require.Len(t, list.Elements(), 2)
+	assert.Equal(t, types.ObjectValueMust(CustomFieldChoiceAttrTypes, map[string]attr.Value{
+		"value": types.StringValue("AD"),
+		"label": types.StringValue("Andorra"),
+	}), list.Elements()[0])
+	assert.Empty(t, CustomFieldChoicesToList(nil).Elements())
+}
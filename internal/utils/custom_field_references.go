@@ -0,0 +1,167 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// CustomFieldReferenceMatches reports whether reference, an ID, name or slug,
+// identifies object, a nested object returned by NetBox as the value of an
+// object custom field. Names and slugs are compared case-insensitively.
+func CustomFieldReferenceMatches(reference string, object map[string]interface{}) bool {
+	reference = strings.TrimSpace(reference)
+	if reference == "" {
+		return false
+	}
+	if id, ok := nestedObjectID(object); ok && strconv.FormatInt(id, 10) == reference {
+		return true
+	}
+	for _, key := range []string{"name", "slug", "display"} {
+		if value, ok := object[key].(string); ok && value != "" && strings.EqualFold(value, reference) {
+			return true
+		}
+	}
+	return false
+}
+
+// CustomFieldReferencesMatch reports whether references identify exactly the
+// objects of a multiobject custom field value, in any order.
+func CustomFieldReferencesMatch(references []string, objects []interface{}) bool {
+	if len(references) != len(objects) {
+		return false
+	}
+	used := make([]bool, len(objects))
+	for _, reference := range references {
+		found := false
+		for i, item := range objects {
+			object, ok := item.(map[string]interface{})
+			if !ok || used[i] || !CustomFieldReferenceMatches(reference, object) {
+				continue
+			}
+			used[i] = true
+			found = true
+			break
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// CustomFieldObjectIDs returns the IDs of the nested objects of an object or
+// multiobject custom field value as strings.
+func CustomFieldObjectIDs(value interface{}) []string {
+	var items []interface{}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		items = []interface{}{v}
+	case []interface{}:
+		items = v
+	}
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		object, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if id, ok := nestedObjectID(object); ok {
+			ids = append(ids, strconv.FormatInt(id, 10))
+		}
+	}
+	return ids
+}
+
+// alignCustomFieldReferences returns desired when it refers to the same objects
+// as the API value actual, so that a name, slug or ID configured for an object
+// or multiobject custom field does not show as drift. Otherwise actual is
+// returned unchanged.
+func alignCustomFieldReferences(desired, actual interface{}) interface{} {
+	switch got := actual.(type) {
+	case map[string]interface{}:
+		if _, ok := nestedObjectID(got); !ok {
+			return actual
+		}
+		if reference, ok := referenceString(desired); ok && CustomFieldReferenceMatches(reference, got) {
+			return desired
+		}
+	case []interface{}:
+		want, ok := desired.([]interface{})
+		if !ok {
+			return actual
+		}
+		references := make([]string, 0, len(want))
+		for _, item := range want {
+			reference, ok := referenceString(item)
+			if !ok {
+				return actual
+			}
+			references = append(references, reference)
+		}
+		if CustomFieldReferencesMatch(references, got) {
+			return desired
+		}
+	}
+	return actual
+}
+
+// referenceString returns a configured reference as a string.
+func referenceString(value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case json.Number:
+		return v.String(), true
+	case int64:
+		return strconv.FormatInt(v, 10), true
+	default:
+		return "", false
+	}
+}
+
+// nestedObjectID returns the `id` of a nested NetBox object.
+func nestedObjectID(object map[string]interface{}) (int64, bool) {
+	switch id := object["id"].(type) {
+	case float64:
+		return int64(id), true
+	case json.Number:
+		i, err := id.Int64()
+		return i, err == nil
+	case int64:
+		return id, true
+	case int32:
+		return int64(id), true
+	case int:
+		return int64(id), true
+	case string:
+		i, err := strconv.ParseInt(id, 10, 64)
+		return i, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// formatCustomFieldReferences formats the objects of an object or multiobject
+// value for custom_fields, keeping the configured references when they still
+// identify the same objects.
+func formatCustomFieldReferences(configured string, value interface{}) string {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if CustomFieldReferenceMatches(configured, v) {
+			return configured
+		}
+	case []interface{}:
+		references := strings.Split(configured, ",")
+		for i, reference := range references {
+			references[i] = strings.TrimSpace(reference)
+		}
+		if configured != "" && CustomFieldReferencesMatch(references, v) {
+			return configured
+		}
+	default:
+		return fmt.Sprintf("%v", value)
+	}
+	return strings.Join(CustomFieldObjectIDs(value), ",")
+}
@@ -0,0 +1,85 @@
+package utils
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCustomFieldReferenceMatches(t *testing.T) {
+	t.Parallel()
+
+	tenant := map[string]interface{}{"id": float64(7), "name": "Acme Corp", "slug": "acme", "display": "Acme Corp"}
+	assert.True(t, CustomFieldReferenceMatches("7", tenant))
+	assert.True(t, CustomFieldReferenceMatches("acme", tenant))
+	assert.True(t, CustomFieldReferenceMatches("acme corp", tenant))
+	assert.False(t, CustomFieldReferenceMatches("8", tenant))
+	assert.False(t, CustomFieldReferenceMatches("", tenant))
+
+	objects := []interface{}{
+		map[string]interface{}{"id": float64(1), "slug": "alpha"},
+		map[string]interface{}{"id": float64(2), "slug": "beta"},
+	}
+	assert.True(t, CustomFieldReferencesMatch([]string{"beta", "1"}, objects), "order does not matter")
+	assert.False(t, CustomFieldReferencesMatch([]string{"alpha", "alpha"}, objects))
+	assert.False(t, CustomFieldReferencesMatch([]string{"alpha"}, objects))
+	assert.Equal(t, []string{"1", "2"}, CustomFieldObjectIDs(objects))
+}
+
+func TestMapToCustomFieldModels_ObjectReferences(t *testing.T) {
+	t.Parallel()
+
+	customFields := map[string]interface{}{
+		"owner": map[string]interface{}{"id": float64(7), "slug": "acme"},
+		"peers": []interface{}{
+			map[string]interface{}{"id": float64(1), "name": "dc1"},
+			map[string]interface{}{"id": float64(2), "name": "dc2"},
+		},
+		"backup": map[string]interface{}{"id": float64(9), "slug": "other"},
+	}
+	stateCFs := []CustomFieldModel{
+		{Name: types.StringValue("owner"), Type: types.StringValue("object"), Value: types.StringValue("acme")},
+		{Name: types.StringValue("peers"), Type: types.StringValue("multiobject"), Value: types.StringValue("dc2, 1")},
+		{Name: types.StringValue("backup"), Type: types.StringValue("object"), Value: types.StringValue("acme")},
+	}
+
+	got := map[string]string{}
+	for _, cf := range MapToCustomFieldModels(customFields, stateCFs) {
+		got[cf.Name.ValueString()] = cf.Value.ValueString()
+	}
+	assert.Equal(t, map[string]string{
+		"owner":  "acme",
+		"peers":  "dc2, 1",
+		"backup": "9",
+	}, got)
+}
+
+func TestCustomFieldsMapFromAPI_ObjectReferences(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	configured := mustDynamic(t, `{"owner": "acme", "peers": ["dc2", 1]}`)
+	apiCustomFields := map[string]interface{}{
+		"owner": map[string]interface{}{"id": float64(7), "slug": "acme"},
+		"peers": []interface{}{
+			map[string]interface{}{"id": float64(1), "name": "dc1"},
+			map[string]interface{}{"id": float64(2), "name": "dc2"},
+		},
+	}
+
+	var diags diag.Diagnostics
+	got := CustomFieldsMapFromAPI(ctx, configured, apiCustomFields, &diags)
+	require.False(t, diags.HasError())
+	assert.True(t, got.Equal(configured), "references to the same objects should round-trip unchanged")
+
+	apiCustomFields["owner"] = map[string]interface{}{"id": float64(9), "slug": "other"}
+	got = CustomFieldsMapFromAPI(ctx, configured, apiCustomFields, &diags)
+	require.False(t, diags.HasError())
+	raw, err := DynamicToJSON(ctx, got)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"owner": 9, "peers": ["dc2", 1]}`, string(raw))
+}
@@ -0,0 +1,211 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/bab3l/go-netbox"
+)
+
+// CustomFieldDefinition is the part of a NetBox custom field definition used to
+// convert and validate custom field values.
+type CustomFieldDefinition struct {
+	Name string
+	// Type is the custom field type, e.g. "integer", "decimal" or "multiselect".
+	Type string
+	// ObjectTypes are the object types the field is assigned to, e.g. "dcim.site".
+	ObjectTypes []string
+	Required    bool
+	// HasDefault reports whether NetBox sets a default value on new objects.
+	HasDefault bool
+	// Default is the default value, decoded with numbers as json.Number.
+	Default           interface{}
+	ValidationMinimum *big.Float
+	ValidationMaximum *big.Float
+	ValidationRegex   string
+	// ChoiceSetID is the ID of the choice set of select and multiselect fields.
+	ChoiceSetID int64
+	// Choices are the values allowed by the choice set. It is nil when they are
+	// not known, e.g. for choice sets built on base choices.
+	Choices []string
+	// RelatedObjectType is the object type referenced by object and multiobject
+	// fields, e.g. "dcim.site".
+	RelatedObjectType string
+}
+
+// AppliesTo reports whether the custom field is assigned to objectType.
+func (d CustomFieldDefinition) AppliesTo(objectType string) bool {
+	return slices.Contains(d.ObjectTypes, objectType)
+}
+
+// customFieldDefinitionAPI mirrors the fields of /api/extras/custom-fields/
+// used by CustomFieldDefinition.
+type customFieldDefinitionAPI struct {
+	Name string `json:"name"`
+	Type struct {
+		Value string `json:"value"`
+	} `json:"type"`
+	ObjectTypes       []string        `json:"object_types"`
+	Required          bool            `json:"required"`
+	Default           json.RawMessage `json:"default"`
+	ValidationMinimum json.Number     `json:"validation_minimum"`
+	ValidationMaximum json.Number     `json:"validation_maximum"`
+	ValidationRegex   string          `json:"validation_regex"`
+	RelatedObjectType string          `json:"related_object_type"`
+	ChoiceSet         *struct {
+		ID int64 `json:"id"`
+	} `json:"choice_set"`
+}
+
+// customFieldChoiceSetAPI mirrors the fields of
+// /api/extras/custom-field-choice-sets/ used to resolve choices.
+type customFieldChoiceSetAPI struct {
+	ID           int64           `json:"id"`
+	BaseChoices  json.RawMessage `json:"base_choices"`
+	ExtraChoices [][]interface{} `json:"extra_choices"`
+}
+
+func decodeCustomFieldDefinition(raw json.RawMessage) (CustomFieldDefinition, error) {
+	var item customFieldDefinitionAPI
+	if err := json.Unmarshal(raw, &item); err != nil {
+		return CustomFieldDefinition{}, fmt.Errorf("failed to decode custom field definition: %w", err)
+	}
+	definition := CustomFieldDefinition{
+		Name:              item.Name,
+		Type:              item.Type.Value,
+		ObjectTypes:       item.ObjectTypes,
+		Required:          item.Required,
+		HasDefault:        isJSONValueSet(item.Default),
+		ValidationMinimum: parseBound(item.ValidationMinimum),
+		ValidationMaximum: parseBound(item.ValidationMaximum),
+		ValidationRegex:   item.ValidationRegex,
+		RelatedObjectType: item.RelatedObjectType,
+	}
+	if definition.HasDefault {
+		decoder := json.NewDecoder(bytes.NewReader(item.Default))
+		decoder.UseNumber()
+		if err := decoder.Decode(&definition.Default); err != nil {
+			return CustomFieldDefinition{}, fmt.Errorf("failed to decode default of custom field %q: %w", item.Name, err)
+		}
+	}
+	if item.ChoiceSet != nil {
+		definition.ChoiceSetID = item.ChoiceSet.ID
+	}
+	return definition, nil
+}
+
+func isJSONValueSet(raw json.RawMessage) bool {
+	trimmed := bytes.TrimSpace(raw)
+	return len(trimmed) > 0 && !bytes.Equal(trimmed, []byte("null"))
+}
+
+func parseBound(number json.Number) *big.Float {
+	if number == "" {
+		return nil
+	}
+	bound, _, err := big.ParseFloat(number.String(), 10, 512, big.ToNearestEven)
+	if err != nil {
+		return nil
+	}
+	return bound
+}
+
+// loadCustomFieldDefinitions lists every custom field definition and resolves
+// the choices of their choice sets.
+func loadCustomFieldDefinitions(ctx context.Context, client *netbox.APIClient) (map[string]CustomFieldDefinition, *http.Response, error) {
+	results, httpResp, err := ListRawAPIObjects(ctx, client, "extras/custom-fields", nil)
+	if err != nil {
+		return nil, httpResp, err
+	}
+	definitions := make(map[string]CustomFieldDefinition, len(results))
+	usesChoiceSets := false
+	for _, raw := range results {
+		definition, err := decodeCustomFieldDefinition(raw)
+		if err != nil {
+			return nil, httpResp, err
+		}
+		definitions[definition.Name] = definition
+		usesChoiceSets = usesChoiceSets || definition.ChoiceSetID != 0
+	}
+	if !usesChoiceSets {
+		return definitions, httpResp, nil
+	}
+
+	results, httpResp, err = ListRawAPIObjects(ctx, client, "extras/custom-field-choice-sets", nil)
+	if err != nil {
+		return nil, httpResp, err
+	}
+	choices := make(map[int64][]string, len(results))
+	for _, raw := range results {
+		var item customFieldChoiceSetAPI
+		if err := json.Unmarshal(raw, &item); err != nil {
+			return nil, httpResp, fmt.Errorf("failed to decode custom field choice set: %w", err)
+		}
+		// Base choices (e.g. ISO 3166 country codes) are not listed here and
+		// are left to NetBox to validate.
+		if isJSONValueSet(item.BaseChoices) {
+			continue
+		}
+		values := make([]string, 0, len(item.ExtraChoices))
+		for _, choice := range item.ExtraChoices {
+			if len(choice) > 0 {
+				values = append(values, fmt.Sprint(choice[0]))
+			}
+		}
+		choices[item.ID] = values
+	}
+	for name, definition := range definitions {
+		if values, ok := choices[definition.ChoiceSetID]; ok {
+			definition.Choices = values
+			definitions[name] = definition
+		}
+	}
+	return definitions, httpResp, nil
+}
+
+// CustomFieldRegistry caches the custom field definitions of a NetBox instance.
+// The definitions are loaded on first use and shared by all resources using the
+// same client.
+type CustomFieldRegistry struct {
+	client *netbox.APIClient
+
+	mu          sync.Mutex
+	definitions map[string]CustomFieldDefinition
+	loadedAt    time.Time
+}
+
+var customFieldRegistries sync.Map
+
+// CustomFieldRegistryFor returns the custom field registry of client.
+func CustomFieldRegistryFor(client *netbox.APIClient) *CustomFieldRegistry {
+	registry, _ := customFieldRegistries.LoadOrStore(client, &CustomFieldRegistry{client: client})
+	return registry.(*CustomFieldRegistry)
+}
+
+// Definitions returns the custom field definitions keyed by name. They are
+// reloaded when they were last loaded before notBefore, so a caller that did
+// not find a field can look again after fields were created by the same apply.
+// Pass the zero time to accept any cached definitions.
+func (r *CustomFieldRegistry) Definitions(ctx context.Context, notBefore time.Time) (map[string]CustomFieldDefinition, *http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.definitions != nil && !r.loadedAt.Before(notBefore) {
+		return r.definitions, nil, nil
+	}
+
+	loadedAt := time.Now()
+	definitions, httpResp, err := loadCustomFieldDefinitions(ctx, r.client)
+	if err != nil {
+		return nil, httpResp, err
+	}
+	r.definitions = definitions
+	r.loadedAt = loadedAt
+	return definitions, httpResp, nil
+}
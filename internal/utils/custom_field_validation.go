@@ -0,0 +1,384 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bab3l/go-netbox"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// unknownCustomFieldValue marks a value that is not known until apply.
+type unknownCustomFieldValue struct{}
+
+// plannedCustomField is a custom field value taken from custom_fields or
+// custom_fields_map.
+type plannedCustomField struct {
+	name string
+	// declaredType is the type given in custom_fields; empty for custom_fields_map.
+	declaredType string
+	value        interface{}
+	fromSet      bool
+	path         path.Path
+	valuePath    path.Path
+}
+
+// ValidateCustomFieldsPlan checks the planned custom_fields and
+// custom_fields_map of a resource against the custom field definitions in
+// NetBox, loaded through the client's CustomFieldRegistry. objectType is the
+// NetBox object type of the resource, e.g. "dcim.site".
+//
+// Each field must be assigned to objectType and its value must match the
+// field's type, choice set and validation rules. When an object is created,
+// required fields without a default must be set. Fields that are not defined
+// at all only produce a warning, since they may be created by the same apply.
+func ValidateCustomFieldsPlan(ctx context.Context, client *netbox.APIClient, objectType string, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if client == nil || req.Plan.Raw.IsNull() {
+		return
+	}
+	var customFields types.Set
+	var customFieldsMap types.Dynamic
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("custom_fields"), &customFields)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("custom_fields_map"), &customFieldsMap)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateCustomFields(ctx, client, objectType, path.Root("custom_fields"), customFields, customFieldsMap, req.State.Raw.IsNull(), &resp.Diagnostics)
+}
+
+// ValidateNestedCustomFieldsPlan checks the planned custom_fields of a nested
+// object at customFieldsPath, like ValidateCustomFieldsPlan. Required fields
+// are not checked, since the nested object may describe existing objects.
+func ValidateNestedCustomFieldsPlan(ctx context.Context, client *netbox.APIClient, objectType string, customFieldsPath path.Path, customFields types.Set, diags *diag.Diagnostics) {
+	if client == nil || !IsSet(customFields) || len(customFields.Elements()) == 0 {
+		return
+	}
+	validateCustomFields(ctx, client, objectType, customFieldsPath, customFields, types.DynamicNull(), false, diags)
+}
+
+// validateCustomFields checks planned custom fields against their definitions.
+// customFieldsPath is the path of customFields; required fields are only
+// checked when creating.
+func validateCustomFields(ctx context.Context, client *netbox.APIClient, objectType string, customFieldsPath path.Path, customFields types.Set, customFieldsMap types.Dynamic, creating bool, diags *diag.Diagnostics) {
+	fields, complete := plannedCustomFields(ctx, customFieldsPath, customFields, customFieldsMap)
+	if len(fields) == 0 && !creating {
+		return
+	}
+
+	started := time.Now()
+	registry := CustomFieldRegistryFor(client)
+	definitions, httpResp, err := registry.Definitions(ctx, time.Time{})
+	if err != nil {
+		diags.AddWarning(
+			"Custom fields not validated",
+			"Could not load the custom field definitions from NetBox, so custom fields are only validated on apply. "+
+				FormatAPIError("list custom field definitions", err, httpResp),
+		)
+		return
+	}
+
+	// Fields created or changed earlier in the same apply are not in definitions
+	// yet, so look again once before reporting them.
+	lookup := func(name string) (CustomFieldDefinition, bool) {
+		definition, ok := definitions[name]
+		if ok && definition.AppliesTo(objectType) {
+			return definition, true
+		}
+		if refreshed, _, err := registry.Definitions(ctx, started); err == nil {
+			definitions = refreshed
+			definition, ok = definitions[name]
+		}
+		return definition, ok
+	}
+
+	settings := ProviderSettingsFor(client)
+	configured := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		configured[field.name] = true
+		if settings.IgnoresCustomField(field.name) {
+			diags.AddAttributeError(
+				field.path,
+				"Ignored custom field",
+				fmt.Sprintf("Custom field %q matches the provider's ignore_custom_fields, so it cannot be managed by resources.", field.name),
+			)
+			continue
+		}
+		definition, ok := lookup(field.name)
+		if !ok {
+			diags.AddAttributeWarning(
+				field.path,
+				"Custom field not found",
+				fmt.Sprintf("No custom field named %q is defined in NetBox. Unless it is created by this apply, NetBox will reject the value.", field.name),
+			)
+			continue
+		}
+		validatePlannedCustomField(definition, objectType, field, diags)
+	}
+
+	if !creating || !complete {
+		return
+	}
+	for name := range settings.DefaultCustomFields {
+		configured[name] = true
+	}
+	requiredPath := customFieldsPath
+	if IsSet(customFieldsMap) {
+		requiredPath = path.Root("custom_fields_map")
+	}
+	names := make([]string, 0, len(definitions))
+	for name := range definitions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		definition := definitions[name]
+		if !definition.Required || definition.HasDefault || configured[name] || !definition.AppliesTo(objectType) {
+			continue
+		}
+		diags.AddAttributeError(
+			requiredPath,
+			"Missing required custom field",
+			fmt.Sprintf("Custom field %q is required for %s objects and has no default value, so it must be set.", name, objectType),
+		)
+	}
+}
+
+// plannedCustomFields collects the custom field values of the plan. complete is
+// false when some names are not known yet.
+func plannedCustomFields(ctx context.Context, customFieldsPath path.Path, customFields types.Set, customFieldsMap types.Dynamic) ([]plannedCustomField, bool) {
+	complete := !customFields.IsUnknown() && !customFieldsMap.IsUnknown() && !customFieldsMap.IsUnderlyingValueUnknown()
+	var fields []plannedCustomField
+
+	if IsSet(customFields) {
+		for _, element := range customFields.Elements() {
+			object, ok := element.(types.Object)
+			if !ok || object.IsUnknown() {
+				complete = false
+				continue
+			}
+			attrs := object.Attributes()
+			name, _ := attrs["name"].(types.String)
+			fieldType, _ := attrs["type"].(types.String)
+			value, _ := attrs["value"].(types.String)
+			if name.IsUnknown() {
+				complete = false
+				continue
+			}
+			elementPath := customFieldsPath.AtSetValue(object)
+			field := plannedCustomField{
+				name:         name.ValueString(),
+				declaredType: fieldType.ValueString(),
+				fromSet:      true,
+				path:         elementPath,
+				valuePath:    elementPath.AtName("value"),
+			}
+			// Unknown types and values are checked on apply.
+			if fieldType.IsUnknown() || value.IsUnknown() {
+				field.declaredType = ""
+				field.value = unknownCustomFieldValue{}
+			} else {
+				field.value = value.ValueString()
+			}
+			fields = append(fields, field)
+		}
+	}
+
+	if IsSet(customFieldsMap) {
+		var values map[string]attr.Value
+		switch underlying := customFieldsMap.UnderlyingValue().(type) {
+		case types.Object:
+			values = underlying.Attributes()
+		case types.Map:
+			values = underlying.Elements()
+		}
+		for name, value := range values {
+			fieldPath := path.Root("custom_fields_map").AtMapKey(name)
+			field := plannedCustomField{name: name, path: fieldPath, valuePath: fieldPath}
+			decoded, err := attrToJSONValue(ctx, value)
+			if err != nil {
+				field.value = unknownCustomFieldValue{}
+			} else {
+				field.value = decoded
+			}
+			fields = append(fields, field)
+		}
+	}
+
+	sort.Slice(fields, func(i, j int) bool { return fields[i].name < fields[j].name })
+	return fields, complete
+}
+
+// validatePlannedCustomField checks one planned value against its definition.
+func validatePlannedCustomField(definition CustomFieldDefinition, objectType string, field plannedCustomField, diags *diag.Diagnostics) {
+	if !definition.AppliesTo(objectType) {
+		diags.AddAttributeError(
+			field.path,
+			"Custom field not available",
+			fmt.Sprintf("Custom field %q is not assigned to %s objects. It is assigned to: %s.", field.name, objectType, strings.Join(definition.ObjectTypes, ", ")),
+		)
+		return
+	}
+	if field.fromSet && field.declaredType != "" && !customFieldTypeMatches(field.declaredType, definition.Type) {
+		diags.AddAttributeError(
+			field.path.AtName("type"),
+			"Custom field type mismatch",
+			fmt.Sprintf("Custom field %q has type %q in NetBox, but %q is configured.", field.name, definition.Type, field.declaredType),
+		)
+		return
+	}
+	if _, unknown := field.value.(unknownCustomFieldValue); unknown {
+		return
+	}
+
+	var value interface{}
+	var err error
+	if field.fromSet {
+		value, err = parseCustomFieldString(definition, field.value.(string))
+	} else {
+		value, err = ConvertCustomFieldValue(definition, field.value)
+	}
+	if err == nil {
+		err = checkCustomFieldValue(definition, value)
+	}
+	if err != nil {
+		diags.AddAttributeError(
+			field.valuePath,
+			"Invalid custom field value",
+			fmt.Sprintf("Custom field %q has type %q: %s.", field.name, definition.Type, err),
+		)
+	}
+}
+
+// customFieldTypeMatches reports whether a type given in custom_fields matches
+// the type of the definition. Legacy names are accepted, and "text" is accepted
+// for types that the custom_fields attribute cannot name.
+func customFieldTypeMatches(declared, actual string) bool {
+	switch declared {
+	case "multiple":
+		declared = "multiselect"
+	case "selection":
+		declared = "select"
+	}
+	if declared == actual {
+		return true
+	}
+	return declared == "text" && (actual == "decimal" || actual == "datetime")
+}
+
+// parseCustomFieldString converts a custom_fields value string the same way
+// CustomFieldsToMap does, but reports values it would drop.
+func parseCustomFieldString(definition CustomFieldDefinition, value string) (interface{}, error) {
+	if value == "" {
+		return nil, nil
+	}
+	switch definition.Type {
+	case "integer":
+		i, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a whole number", value)
+		}
+		return i, nil
+	case "decimal":
+		if _, ok := new(big.Float).SetString(value); !ok {
+			return nil, fmt.Errorf("%q is not a number", value)
+		}
+		return json.Number(value), nil
+	case "boolean":
+		if value != "true" && value != "false" {
+			return nil, fmt.Errorf("%q must be either \"true\" or \"false\"", value)
+		}
+		return value == "true", nil
+	case "json":
+		if !json.Valid([]byte(value)) {
+			return nil, fmt.Errorf("%q is not valid JSON", value)
+		}
+		return value, nil
+	case "multiselect":
+		choices := strings.Split(value, ",")
+		for i, choice := range choices {
+			choices[i] = strings.TrimSpace(choice)
+		}
+		return choices, nil
+	default:
+		return value, nil
+	}
+}
+
+// checkCustomFieldValue applies the validation rules of a definition to a
+// converted value.
+func checkCustomFieldValue(definition CustomFieldDefinition, value interface{}) error {
+	if value == nil {
+		if definition.Required {
+			return fmt.Errorf("the field is required and cannot be cleared")
+		}
+		return nil
+	}
+	switch v := value.(type) {
+	case int64:
+		if definition.Type == "integer" {
+			return checkCustomFieldBounds(definition, new(big.Float).SetInt64(v))
+		}
+	case json.Number:
+		number, _, err := big.ParseFloat(v.String(), 10, 512, big.ToNearestEven)
+		if err != nil {
+			return fmt.Errorf("%s is not a number", v)
+		}
+		return checkCustomFieldBounds(definition, number)
+	case string:
+		switch definition.Type {
+		case "select":
+			return checkCustomFieldChoice(definition, v)
+		case "date":
+			if _, err := time.Parse(time.DateOnly, v); err != nil {
+				return fmt.Errorf("%q is not a date in YYYY-MM-DD format", v)
+			}
+		case "text", "longtext", "url":
+			if definition.ValidationRegex == "" {
+				return nil
+			}
+			// NetBox uses Python regular expressions; patterns Go cannot
+			// compile are left to NetBox.
+			pattern, err := regexp.Compile(definition.ValidationRegex)
+			if err == nil && !pattern.MatchString(v) {
+				return fmt.Errorf("%q does not match the pattern %s", v, definition.ValidationRegex)
+			}
+		}
+	case []string:
+		for _, choice := range v {
+			if err := checkCustomFieldChoice(definition, choice); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func checkCustomFieldBounds(definition CustomFieldDefinition, value *big.Float) error {
+	if definition.ValidationMinimum != nil && value.Cmp(definition.ValidationMinimum) < 0 {
+		return fmt.Errorf("%s is less than the minimum of %s", value.Text('f', -1), definition.ValidationMinimum.Text('f', -1))
+	}
+	if definition.ValidationMaximum != nil && value.Cmp(definition.ValidationMaximum) > 0 {
+		return fmt.Errorf("%s is greater than the maximum of %s", value.Text('f', -1), definition.ValidationMaximum.Text('f', -1))
+	}
+	return nil
+}
+
+func checkCustomFieldChoice(definition CustomFieldDefinition, choice string) error {
+	if definition.Choices == nil || slices.Contains(definition.Choices, choice) {
+		return nil
+	}
+	return fmt.Errorf("%q is not one of the choices %q", choice, definition.Choices)
+}
@@ -0,0 +1,309 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bab3l/go-netbox"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testCustomFieldDefinitions = `[
+	{"name": "owner", "type": {"value": "text"}, "object_types": ["dcim.site"], "validation_regex": "^[a-z]+$"},
+	{"name": "rack_units", "type": {"value": "integer"}, "object_types": ["dcim.site"], "validation_minimum": 1, "validation_maximum": 48},
+	{"name": "tier", "type": {"value": "select"}, "object_types": ["dcim.site"], "choice_set": {"id": 1}},
+	{"name": "roles", "type": {"value": "multiselect"}, "object_types": ["dcim.site"], "choice_set": {"id": 1}},
+	{"name": "country", "type": {"value": "select"}, "object_types": ["dcim.site"], "choice_set": {"id": 2}},
+	{"name": "asset_tag", "type": {"value": "text"}, "object_types": ["dcim.site"], "required": true},
+	{"name": "monitored", "type": {"value": "boolean"}, "object_types": ["dcim.site"], "required": true, "default": false},
+	{"name": "serial", "type": {"value": "text"}, "object_types": ["dcim.device"], "required": true}
+]`
+
+// newCustomFieldRegistryClient serves testCustomFieldDefinitions and counts how
+// often the definitions are listed.
+func newCustomFieldRegistryClient(t *testing.T, loads *atomic.Int32) *netbox.APIClient {
+	t.Helper()
+	return newRawTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var results json.RawMessage
+		switch r.URL.Path {
+		case "/api/extras/custom-fields/":
+			loads.Add(1)
+			results = json.RawMessage(testCustomFieldDefinitions)
+		case "/api/extras/custom-field-choice-sets/":
+			results = json.RawMessage(`[
+				{"id": 1, "base_choices": null, "extra_choices": [["gold", "Gold"], ["edge, core", "Edge and core"]]},
+				{"id": 2, "base_choices": {"value": "ISO_3166", "label": "ISO 3166"}, "extra_choices": []}
+			]`)
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			return
+		}
+		var decoded []json.RawMessage
+		require.NoError(t, json.Unmarshal(results, &decoded))
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"count": len(decoded), "results": decoded})
+	})
+}
+
+func customFieldsPlanSchema() schema.Schema {
+	return schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{Computed: true},
+			"custom_fields": schema.SetNestedAttribute{
+				Optional: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name":  schema.StringAttribute{Required: true},
+						"type":  schema.StringAttribute{Required: true},
+						"value": schema.StringAttribute{Required: true},
+					},
+				},
+			},
+			"custom_fields_map": schema.DynamicAttribute{Optional: true},
+		},
+	}
+}
+
+type customFieldsPlanModel struct {
+	ID              types.String  `tfsdk:"id"`
+	CustomFields    types.Set     `tfsdk:"custom_fields"`
+	CustomFieldsMap types.Dynamic `tfsdk:"custom_fields_map"`
+}
+
+func customFieldsSet(t *testing.T, fields ...CustomFieldModel) types.Set {
+	t.Helper()
+	set, diags := types.SetValueFrom(context.Background(), GetCustomFieldsAttributeType().ElemType, fields)
+	require.False(t, diags.HasError(), diags.Errors())
+	return set
+}
+
+func customField(name, fieldType, value string) CustomFieldModel {
+	return CustomFieldModel{Name: types.StringValue(name), Type: types.StringValue(fieldType), Value: types.StringValue(value)}
+}
+
+// validateCustomFieldsPlan runs ValidateCustomFieldsPlan for a dcim.site plan.
+func validateCustomFieldsPlan(t *testing.T, client *netbox.APIClient, creating bool, model customFieldsPlanModel) diag.Diagnostics {
+	t.Helper()
+	ctx := context.Background()
+	s := customFieldsPlanSchema()
+
+	plan := tfsdk.Plan{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(ctx), nil)}
+	require.False(t, plan.Set(ctx, &model).HasError())
+	state := tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(ctx), nil)}
+	if !creating {
+		require.False(t, state.Set(ctx, &model).HasError())
+	}
+
+	req := resource.ModifyPlanRequest{Plan: plan, State: state}
+	resp := &resource.ModifyPlanResponse{Plan: plan}
+	ValidateCustomFieldsPlan(ctx, client, "dcim.site", req, resp)
+	return resp.Diagnostics
+}
+
+func TestValidateCustomFieldsPlan_Set(t *testing.T) {
+	t.Parallel()
+
+	var loads atomic.Int32
+	client := newCustomFieldRegistryClient(t, &loads)
+
+	valid := customFieldsSet(t,
+		customField("asset_tag", "text", "A1"),
+		customField("owner", "text", "ops"),
+		customField("rack_units", "integer", "42"),
+		customField("tier", "select", "gold"),
+		customField("country", "select", "US"),
+	)
+	diags := validateCustomFieldsPlan(t, client, true, customFieldsPlanModel{ID: types.StringUnknown(), CustomFields: valid, CustomFieldsMap: types.DynamicNull()})
+	assert.False(t, diags.HasError(), diags.Errors())
+
+	cases := []struct {
+		name    string
+		field   CustomFieldModel
+		summary string
+		atType  bool
+	}{
+		{name: "wrong object type", field: customField("serial", "text", "X"), summary: "Custom field not available"},
+		{name: "type mismatch", field: customField("rack_units", "text", "42"), summary: "Custom field type mismatch", atType: true},
+		{name: "not an integer", field: customField("rack_units", "integer", "4x"), summary: "Invalid custom field value"},
+		{name: "above maximum", field: customField("rack_units", "integer", "52"), summary: "Invalid custom field value"},
+		{name: "regex", field: customField("owner", "text", "Ops Team"), summary: "Invalid custom field value"},
+		{name: "choice", field: customField("tier", "select", "bronze"), summary: "Invalid custom field value"},
+		{name: "multiselect splits on commas", field: customField("roles", "multiselect", "edge, core"), summary: "Invalid custom field value"},
+		{name: "required cleared", field: customField("asset_tag", "text", ""), summary: "Invalid custom field value"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			set := customFieldsSet(t, tc.field)
+			diags := validateCustomFieldsPlan(t, client, false, customFieldsPlanModel{ID: types.StringValue("1"), CustomFields: set, CustomFieldsMap: types.DynamicNull()})
+			require.Equal(t, 1, diags.ErrorsCount(), diags.Errors())
+			assert.Equal(t, tc.summary, diags.Errors()[0].Summary())
+
+			element := set.Elements()[0]
+			wantPath := path.Root("custom_fields").AtSetValue(element)
+			switch {
+			case tc.atType:
+				wantPath = wantPath.AtName("type")
+			case tc.summary == "Invalid custom field value":
+				wantPath = wantPath.AtName("value")
+			}
+			withPath, ok := diags.Errors()[0].(diag.DiagnosticWithPath)
+			require.True(t, ok)
+			assert.True(t, withPath.Path().Equal(wantPath), "got path %s", withPath.Path())
+		})
+	}
+}
+
+func TestValidateNestedCustomFieldsPlan(t *testing.T) {
+	t.Parallel()
+
+	var loads atomic.Int32
+	client := newCustomFieldRegistryClient(t, &loads)
+	ctx := context.Background()
+	customFieldsPath := path.Root("interfaces").AtMapKey("eth0").AtName("custom_fields")
+
+	// Required fields such as asset_tag are not checked.
+	var diags diag.Diagnostics
+	ValidateNestedCustomFieldsPlan(ctx, client, "dcim.site", customFieldsPath, customFieldsSet(t, customField("owner", "text", "ops")), &diags)
+	assert.False(t, diags.HasError(), diags.Errors())
+
+	set := customFieldsSet(t, customField("rack_units", "integer", "52"))
+	diags = nil
+	ValidateNestedCustomFieldsPlan(ctx, client, "dcim.site", customFieldsPath, set, &diags)
+	require.Equal(t, 1, diags.ErrorsCount(), diags.Errors())
+	withPath, ok := diags.Errors()[0].(diag.DiagnosticWithPath)
+	require.True(t, ok)
+	assert.True(t, withPath.Path().Equal(customFieldsPath.AtSetValue(set.Elements()[0]).AtName("value")), "got path %s", withPath.Path())
+}
+
+func TestValidateCustomFieldsPlan_Map(t *testing.T) {
+	t.Parallel()
+
+	var loads atomic.Int32
+	client := newCustomFieldRegistryClient(t, &loads)
+
+	diags := validateCustomFieldsPlan(t, client, true, customFieldsPlanModel{
+		ID:              types.StringUnknown(),
+		CustomFields:    types.SetNull(GetCustomFieldsAttributeType().ElemType),
+		CustomFieldsMap: mustDynamic(t, `{"asset_tag": "A1", "rack_units": 12, "roles": ["edge, core"]}`),
+	})
+	assert.False(t, diags.HasError(), diags.Errors())
+
+	diags = validateCustomFieldsPlan(t, client, false, customFieldsPlanModel{
+		ID:              types.StringValue("1"),
+		CustomFields:    types.SetNull(GetCustomFieldsAttributeType().ElemType),
+		CustomFieldsMap: mustDynamic(t, `{"rack_units": 0, "roles": ["gold", "silver"]}`),
+	})
+	require.Equal(t, 2, diags.ErrorsCount(), diags.Errors())
+	var paths []path.Path
+	for _, d := range diags.Errors() {
+		paths = append(paths, d.(diag.DiagnosticWithPath).Path())
+	}
+	assert.ElementsMatch(t, []path.Path{
+		path.Root("custom_fields_map").AtMapKey("rack_units"),
+		path.Root("custom_fields_map").AtMapKey("roles"),
+	}, paths)
+}
+
+func TestValidateCustomFieldsPlan_RequiredAndUnknown(t *testing.T) {
+	t.Parallel()
+
+	var loads atomic.Int32
+	client := newCustomFieldRegistryClient(t, &loads)
+
+	// asset_tag is required without a default; monitored has a default.
+	diags := validateCustomFieldsPlan(t, client, true, customFieldsPlanModel{
+		ID:              types.StringUnknown(),
+		CustomFields:    types.SetNull(GetCustomFieldsAttributeType().ElemType),
+		CustomFieldsMap: types.DynamicNull(),
+	})
+	require.Equal(t, 1, diags.ErrorsCount(), diags.Errors())
+	assert.Equal(t, "Missing required custom field", diags.Errors()[0].Summary())
+	assert.Contains(t, diags.Errors()[0].Detail(), `"asset_tag"`)
+
+	// Required fields are only checked on create.
+	diags = validateCustomFieldsPlan(t, client, false, customFieldsPlanModel{
+		ID:              types.StringValue("1"),
+		CustomFields:    types.SetNull(GetCustomFieldsAttributeType().ElemType),
+		CustomFieldsMap: types.DynamicNull(),
+	})
+	assert.Empty(t, diags)
+
+	// A field that is not defined may be created by the same apply, so it is
+	// only a warning, after looking again for a fresh definition.
+	before := loads.Load()
+	diags = validateCustomFieldsPlan(t, client, false, customFieldsPlanModel{
+		ID:              types.StringValue("1"),
+		CustomFields:    types.SetNull(GetCustomFieldsAttributeType().ElemType),
+		CustomFieldsMap: types.DynamicValue(types.ObjectValueMust(map[string]attr.Type{"missing": types.StringType}, map[string]attr.Value{"missing": types.StringValue("x")})),
+	})
+	assert.False(t, diags.HasError())
+	require.Equal(t, 1, diags.WarningsCount())
+	assert.Equal(t, "Custom field not found", diags.Warnings()[0].Summary())
+	assert.Equal(t, before+1, loads.Load())
+}
+
+func TestValidateCustomFieldsPlan_NotConfigured(t *testing.T) {
+	t.Parallel()
+
+	diags := validateCustomFieldsPlan(t, nil, true, customFieldsPlanModel{
+		ID:              types.StringUnknown(),
+		CustomFields:    types.SetNull(GetCustomFieldsAttributeType().ElemType),
+		CustomFieldsMap: types.DynamicNull(),
+	})
+	assert.Empty(t, diags)
+}
+
+func TestCustomFieldRegistry(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	var loads atomic.Int32
+	client := newCustomFieldRegistryClient(t, &loads)
+	registry := CustomFieldRegistryFor(client)
+	assert.Same(t, registry, CustomFieldRegistryFor(client))
+
+	definitions, _, err := registry.Definitions(ctx, time.Time{})
+	require.NoError(t, err)
+	_, _, err = registry.Definitions(ctx, time.Time{})
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), loads.Load(), "definitions should be loaded once and cached")
+
+	assert.Equal(t, []string{"gold", "edge, core"}, definitions["tier"].Choices)
+	assert.Nil(t, definitions["country"].Choices, "base choices are not resolved")
+	assert.True(t, definitions["monitored"].HasDefault)
+	assert.False(t, definitions["asset_tag"].HasDefault)
+	assert.Equal(t, "48", definitions["rack_units"].ValidationMaximum.Text('f', -1))
+
+	_, _, err = registry.Definitions(ctx, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), loads.Load(), "definitions loaded before notBefore should be reloaded")
+}
+
+func TestValidateCustomFieldsPlan_Ignored(t *testing.T) {
+	t.Parallel()
+
+	var loads atomic.Int32
+	client := newCustomFieldRegistryClient(t, &loads)
+	SetProviderSettings(client, ProviderSettings{IgnoreCustomFields: []string{"own*"}})
+
+	diags := validateCustomFieldsPlan(t, client, false, customFieldsPlanModel{
+		ID:              types.StringValue("1"),
+		CustomFields:    customFieldsSet(t, customField("owner", "text", "ops")),
+		CustomFieldsMap: types.DynamicNull(),
+	})
+	require.Equal(t, 1, diags.ErrorsCount(), diags.Errors())
+	assert.Equal(t, "Ignored custom field", diags.Errors()[0].Summary())
+}
@@ -0,0 +1,259 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"sort"
+
+	"github.com/bab3l/go-netbox"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// LookupCustomFieldDefinitions returns the definitions of the named custom
+// fields, keyed by name. Names that are not defined in NetBox are omitted.
+func LookupCustomFieldDefinitions(ctx context.Context, client *netbox.APIClient, names []string) (map[string]CustomFieldDefinition, *http.Response, error) {
+	definitions := make(map[string]CustomFieldDefinition, len(names))
+	if len(names) == 0 {
+		return definitions, nil, nil
+	}
+	results, httpResp, err := ListRawAPIObjects(ctx, client, "extras/custom-fields", url.Values{"name": names})
+	if err != nil {
+		return nil, httpResp, err
+	}
+	for _, raw := range results {
+		definition, err := decodeCustomFieldDefinition(raw)
+		if err != nil {
+			return nil, httpResp, err
+		}
+		definitions[definition.Name] = definition
+	}
+	return definitions, httpResp, nil
+}
+
+// ApplyCustomFieldsMap converts a custom_fields_map value using the custom
+// field definitions in NetBox and merges it into the custom fields of request.
+// Fields in custom_fields_map overwrite those set from custom_fields; a null
+// value clears the field. Nothing is changed when the value is null.
+func ApplyCustomFieldsMap[T CustomFieldsAccessor](ctx context.Context, client *netbox.APIClient, request T, customFieldsMap types.Dynamic, diags *diag.Diagnostics) {
+	if !IsSet(customFieldsMap) {
+		return
+	}
+	values := CustomFieldsMapToAPI(ctx, client, customFieldsMap, diags)
+	if diags.HasError() {
+		return
+	}
+
+	merged := make(map[string]interface{}, len(values))
+	for name, value := range request.GetCustomFields() {
+		merged[name] = value
+	}
+	for name, value := range values {
+		merged[name] = value
+	}
+	request.SetCustomFields(merged)
+}
+
+// CustomFieldsMapToAPI converts a custom_fields_map value to the custom field
+// values sent to NetBox. Each value is checked and converted according to the
+// type of its custom field definition, so numbers, booleans and lists keep
+// their native types.
+func CustomFieldsMapToAPI(ctx context.Context, client *netbox.APIClient, customFieldsMap types.Dynamic, diags *diag.Diagnostics) map[string]interface{} {
+	attrPath := path.Root("custom_fields_map")
+	decoded, err := attrToJSONValue(ctx, customFieldsMap)
+	if err != nil {
+		diags.AddAttributeError(attrPath, "Invalid custom_fields_map", err.Error())
+		return nil
+	}
+	configured, ok := decoded.(map[string]interface{})
+	if !ok {
+		diags.AddAttributeError(attrPath, "Invalid custom_fields_map", "custom_fields_map must be an object or map keyed by custom field name.")
+		return nil
+	}
+
+	names := make([]string, 0, len(configured))
+	for name := range configured {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	definitions, httpResp, err := LookupCustomFieldDefinitions(ctx, client, names)
+	if err != nil {
+		diags.AddError("Error reading custom field definitions", FormatAPIError("list custom field definitions", err, httpResp))
+		return nil
+	}
+
+	values := make(map[string]interface{}, len(configured))
+	for _, name := range names {
+		definition, ok := definitions[name]
+		if !ok {
+			diags.AddAttributeError(attrPath.AtMapKey(name), "Unknown custom field", fmt.Sprintf("No custom field named %q is defined in NetBox.", name))
+			continue
+		}
+		value, err := ConvertCustomFieldValue(definition, configured[name])
+		if err != nil {
+			diags.AddAttributeError(attrPath.AtMapKey(name), "Invalid custom field value", fmt.Sprintf("Custom field %q has type %q: %s.", name, definition.Type, err))
+			continue
+		}
+		values[name] = value
+	}
+	return values
+}
+
+// ConvertCustomFieldValue converts a decoded custom_fields_map value to the
+// representation NetBox expects for the custom field's type. Numbers are
+// expected as json.Number, as returned by DynamicToJSON.
+func ConvertCustomFieldValue(definition CustomFieldDefinition, value interface{}) (interface{}, error) {
+	if value == nil {
+		return nil, nil
+	}
+	switch definition.Type {
+	case "text", "longtext", "url", "date", "datetime", "select":
+		if _, ok := value.(string); !ok {
+			return nil, fmt.Errorf("expected a string, got %s", customFieldValueKind(value))
+		}
+		return value, nil
+	case "integer":
+		return customFieldInteger(value)
+	case "object":
+		// Names and slugs are resolved to IDs when the request is sent.
+		if reference, ok := value.(string); ok {
+			return reference, nil
+		}
+		return customFieldInteger(value)
+	case "decimal":
+		number, ok := value.(json.Number)
+		if !ok {
+			return nil, fmt.Errorf("expected a number, got %s", customFieldValueKind(value))
+		}
+		return number, nil
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return nil, fmt.Errorf("expected a bool, got %s", customFieldValueKind(value))
+		}
+		return value, nil
+	case "multiselect":
+		items, ok := value.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("expected a list of strings, got %s", customFieldValueKind(value))
+		}
+		choices := make([]string, 0, len(items))
+		for _, item := range items {
+			choice, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("expected a list of strings, got an element that is %s", customFieldValueKind(item))
+			}
+			choices = append(choices, choice)
+		}
+		return choices, nil
+	case "multiobject":
+		items, ok := value.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("expected a list of object IDs or names, got %s", customFieldValueKind(value))
+		}
+		ids := make([]int64, 0, len(items))
+		references := make([]interface{}, 0, len(items))
+		for _, item := range items {
+			if reference, ok := item.(string); ok {
+				references = append(references, reference)
+				continue
+			}
+			id, err := customFieldInteger(item)
+			if err != nil {
+				return nil, err
+			}
+			ids = append(ids, id)
+			references = append(references, id)
+		}
+		// Names and slugs are resolved to IDs when the request is sent.
+		if len(ids) < len(items) {
+			return references, nil
+		}
+		return ids, nil
+	default:
+		// json and any type added by newer NetBox versions are sent as is.
+		return value, nil
+	}
+}
+
+func customFieldInteger(value interface{}) (int64, error) {
+	number, ok := value.(json.Number)
+	if !ok {
+		return 0, fmt.Errorf("expected a whole number, got %s", customFieldValueKind(value))
+	}
+	f, _, err := big.ParseFloat(number.String(), 10, 512, big.ToNearestEven)
+	if err != nil || !f.IsInt() {
+		return 0, fmt.Errorf("expected a whole number, got %s", number)
+	}
+	i, accuracy := f.Int64()
+	if accuracy != big.Exact {
+		return 0, fmt.Errorf("%s is out of range", number)
+	}
+	return i, nil
+}
+
+// customFieldValueKind names the kind of a decoded value for error messages.
+func customFieldValueKind(value interface{}) string {
+	switch value.(type) {
+	case string:
+		return "a string"
+	case json.Number:
+		return "a number"
+	case bool:
+		return "a bool"
+	case []interface{}:
+		return "a list"
+	case map[string]interface{}:
+		return "an object"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+// CustomFieldsMapFromAPI refreshes a custom_fields_map value from the custom
+// fields returned by NetBox. Only the configured fields are compared. When
+// they all still match, the value is returned unchanged so that it round-trips
+// exactly; otherwise the drifted fields take the API values, with object
+// references reduced to their IDs.
+func CustomFieldsMapFromAPI(ctx context.Context, customFieldsMap types.Dynamic, customFields map[string]interface{}, diags *diag.Diagnostics) types.Dynamic {
+	if !IsSet(customFieldsMap) {
+		return customFieldsMap
+	}
+	decoded, err := attrToJSONValue(ctx, customFieldsMap)
+	if err != nil {
+		return customFieldsMap
+	}
+	configured, ok := decoded.(map[string]interface{})
+	if !ok {
+		return customFieldsMap
+	}
+
+	// Object references configured by name or slug match the objects NetBox
+	// returns for them.
+	actual := make(map[string]interface{}, len(customFields))
+	for name, value := range customFields {
+		actual[name] = value
+		if want, ok := configured[name]; ok {
+			actual[name] = alignCustomFieldReferences(want, value)
+		}
+	}
+	reconciled, drifted := ReconcileOwnedJSON(configured, actual)
+	if !drifted {
+		return customFieldsMap
+	}
+	raw, err := json.Marshal(reconciled)
+	if err != nil {
+		diags.AddError("Error reading custom fields", fmt.Sprintf("Could not encode custom fields: %s", err))
+		return customFieldsMap
+	}
+	refreshed, err := JSONToDynamic(raw)
+	if err != nil {
+		diags.AddError("Error reading custom fields", err.Error())
+		return customFieldsMap
+	}
+	return refreshed
+}
@@ -0,0 +1,164 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/bab3l/go-netbox"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newCustomFieldDefinitionsClient(t *testing.T) *netbox.APIClient {
+	t.Helper()
+	definitions := map[string]string{
+		"owner":      "text",
+		"rack_units": "integer",
+		"weight":     "decimal",
+		"monitored":  "boolean",
+		"roles":      "multiselect",
+		"peers":      "multiobject",
+		"extra":      "json",
+	}
+	return newRawTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/extras/custom-fields/", r.URL.Path)
+		results := []map[string]interface{}{}
+		for _, name := range r.URL.Query()["name"] {
+			if fieldType, ok := definitions[name]; ok {
+				results = append(results, map[string]interface{}{
+					"name": name,
+					"type": map[string]string{"value": fieldType},
+				})
+			}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"count": len(results), "results": results})
+	})
+}
+
+func mustDynamic(t *testing.T, raw string) types.Dynamic {
+	t.Helper()
+	value, err := JSONToDynamic([]byte(raw))
+	require.NoError(t, err)
+	return value
+}
+
+func TestConvertCustomFieldValue(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name      string
+		fieldType string
+		value     interface{}
+		want      interface{}
+		wantErr   bool
+	}{
+		{name: "text", fieldType: "text", value: "ops", want: "ops"},
+		{name: "text rejects number", fieldType: "text", value: json.Number("1"), wantErr: true},
+		{name: "integer", fieldType: "integer", value: json.Number("42"), want: int64(42)},
+		{name: "integer rejects fraction", fieldType: "integer", value: json.Number("4.5"), wantErr: true},
+		{name: "integer rejects string", fieldType: "integer", value: "42", wantErr: true},
+		{name: "decimal", fieldType: "decimal", value: json.Number("12.75"), want: json.Number("12.75")},
+		{name: "boolean", fieldType: "boolean", value: false, want: false},
+		{name: "boolean rejects string", fieldType: "boolean", value: "true", wantErr: true},
+		{name: "multiselect keeps commas", fieldType: "multiselect", value: []interface{}{"a,b", "c"}, want: []string{"a,b", "c"}},
+		{name: "multiselect rejects numbers", fieldType: "multiselect", value: []interface{}{json.Number("1")}, wantErr: true},
+		{name: "object", fieldType: "object", value: json.Number("7"), want: int64(7)},
+		{name: "object keeps reference", fieldType: "object", value: "dc1", want: "dc1"},
+		{name: "object rejects boolean", fieldType: "object", value: true, wantErr: true},
+		{name: "multiobject", fieldType: "multiobject", value: []interface{}{json.Number("1"), json.Number("2")}, want: []int64{1, 2}},
+		{name: "multiobject keeps references", fieldType: "multiobject", value: []interface{}{"dc1", json.Number("2")}, want: []interface{}{"dc1", int64(2)}},
+		{name: "json passes through", fieldType: "json", value: map[string]interface{}{"a": json.Number("1")}, want: map[string]interface{}{"a": json.Number("1")}},
+		{name: "null clears", fieldType: "integer", value: nil, want: nil},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ConvertCustomFieldValue(CustomFieldDefinition{Name: "field", Type: tc.fieldType}, tc.value)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestApplyCustomFieldsMap(t *testing.T) {
+	t.Parallel()
+
+	client := newCustomFieldDefinitionsClient(t)
+	request := netbox.NewWritableSiteRequest("site", "site")
+	request.SetCustomFields(map[string]interface{}{"legacy": "kept"})
+
+	var diags diag.Diagnostics
+	ApplyCustomFieldsMap(context.Background(), client, request, mustDynamic(t, `{"rack_units": 42, "weight": 1.5, "monitored": true, "roles": ["edge,core"], "owner": null}`), &diags)
+	require.False(t, diags.HasError(), diags.Errors())
+
+	assert.Equal(t, map[string]interface{}{
+		"legacy":     "kept",
+		"rack_units": int64(42),
+		"weight":     json.Number("1.5"),
+		"monitored":  true,
+		"roles":      []string{"edge,core"},
+		"owner":      nil,
+	}, request.GetCustomFields())
+}
+
+func TestApplyCustomFieldsMap_Null(t *testing.T) {
+	t.Parallel()
+
+	request := netbox.NewWritableSiteRequest("site", "site")
+	var diags diag.Diagnostics
+	ApplyCustomFieldsMap(context.Background(), nil, request, types.DynamicNull(), &diags)
+	assert.False(t, diags.HasError())
+	assert.False(t, request.HasCustomFields())
+}
+
+func TestCustomFieldsMapToAPI_Errors(t *testing.T) {
+	t.Parallel()
+
+	client := newCustomFieldDefinitionsClient(t)
+	var diags diag.Diagnostics
+	CustomFieldsMapToAPI(context.Background(), client, mustDynamic(t, `{"missing": "x", "rack_units": "42"}`), &diags)
+	require.Equal(t, 2, diags.ErrorsCount())
+
+	summaries := []string{diags.Errors()[0].Summary(), diags.Errors()[1].Summary()}
+	assert.ElementsMatch(t, []string{"Unknown custom field", "Invalid custom field value"}, summaries)
+
+	diags = nil
+	CustomFieldsMapToAPI(context.Background(), client, mustDynamic(t, `["rack_units"]`), &diags)
+	assert.True(t, diags.HasError())
+}
+
+func TestCustomFieldsMapFromAPI(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	configured := mustDynamic(t, `{"rack_units": 42, "weight": 1.5, "roles": ["edge,core"], "peers": [3]}`)
+	apiCustomFields := map[string]interface{}{
+		"rack_units": float64(42),
+		"weight":     "1.50",
+		"roles":      []interface{}{"edge,core"},
+		"peers":      []interface{}{map[string]interface{}{"id": float64(3), "display": "peer"}},
+		"unmanaged":  "ignored",
+	}
+
+	var diags diag.Diagnostics
+	got := CustomFieldsMapFromAPI(ctx, configured, apiCustomFields, &diags)
+	require.False(t, diags.HasError())
+	assert.True(t, got.Equal(configured), "matching values should round-trip unchanged")
+
+	apiCustomFields["rack_units"] = float64(48)
+	got = CustomFieldsMapFromAPI(ctx, configured, apiCustomFields, &diags)
+	require.False(t, diags.HasError())
+	raw, err := DynamicToJSON(ctx, got)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"rack_units": 48, "weight": 1.5, "roles": ["edge,core"], "peers": [3]}`, string(raw))
+
+	assert.True(t, CustomFieldsMapFromAPI(ctx, types.DynamicNull(), apiCustomFields, &diags).IsNull())
+}
@@ -0,0 +1,159 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// JSONToDynamic decodes a JSON document into a Terraform dynamic value.
+//
+// Objects become object values, arrays become tuples (so elements may have
+// different shapes), numbers keep their full precision and JSON null becomes a
+// null string.
+func JSONToDynamic(raw []byte) (types.Dynamic, error) {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+
+	var decoded interface{}
+	if err := decoder.Decode(&decoded); err != nil {
+		return types.DynamicNull(), fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	value, err := jsonValueToAttr(decoded)
+	if err != nil {
+		return types.DynamicNull(), err
+	}
+	return types.DynamicValue(value), nil
+}
+
+func jsonValueToAttr(v interface{}) (attr.Value, error) {
+	switch val := v.(type) {
+	case nil:
+		return types.StringNull(), nil
+	case string:
+		return types.StringValue(val), nil
+	case bool:
+		return types.BoolValue(val), nil
+	case json.Number:
+		f, _, err := big.ParseFloat(val.String(), 10, 512, big.ToNearestEven)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q: %w", val.String(), err)
+		}
+		return types.NumberValue(f), nil
+	case []interface{}:
+		elemTypes := make([]attr.Type, 0, len(val))
+		elems := make([]attr.Value, 0, len(val))
+		for _, item := range val {
+			elem, err := jsonValueToAttr(item)
+			if err != nil {
+				return nil, err
+			}
+			elemTypes = append(elemTypes, elem.Type(context.Background()))
+			elems = append(elems, elem)
+		}
+		tuple, diags := types.TupleValue(elemTypes, elems)
+		if diags.HasError() {
+			return nil, fmt.Errorf("building tuple: %v", diags)
+		}
+		return tuple, nil
+	case map[string]interface{}:
+		attrTypes := make(map[string]attr.Type, len(val))
+		attrs := make(map[string]attr.Value, len(val))
+		for k, item := range val {
+			elem, err := jsonValueToAttr(item)
+			if err != nil {
+				return nil, err
+			}
+			attrTypes[k] = elem.Type(context.Background())
+			attrs[k] = elem
+		}
+		obj, diags := types.ObjectValue(attrTypes, attrs)
+		if diags.HasError() {
+			return nil, fmt.Errorf("building object: %v", diags)
+		}
+		return obj, nil
+	default:
+		return nil, fmt.Errorf("unsupported JSON value of type %T", v)
+	}
+}
+
+// DynamicToJSON encodes a Terraform value (typically the underlying value of a
+// dynamic attribute) as JSON. Object and map values become JSON objects, lists,
+// sets and tuples become arrays, and null values become JSON null.
+func DynamicToJSON(ctx context.Context, value attr.Value) ([]byte, error) {
+	decoded, err := attrToJSONValue(ctx, value)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(decoded)
+}
+
+func attrToJSONValue(ctx context.Context, value attr.Value) (interface{}, error) {
+	if value == nil || value.IsNull() {
+		return nil, nil
+	}
+	if value.IsUnknown() {
+		return nil, fmt.Errorf("value is not yet known")
+	}
+
+	switch v := value.(type) {
+	case types.Dynamic:
+		return attrToJSONValue(ctx, v.UnderlyingValue())
+	case types.String:
+		return v.ValueString(), nil
+	case types.Bool:
+		return v.ValueBool(), nil
+	case types.Int64:
+		return v.ValueInt64(), nil
+	case types.Int32:
+		return v.ValueInt32(), nil
+	case types.Float64:
+		return v.ValueFloat64(), nil
+	case types.Float32:
+		return v.ValueFloat32(), nil
+	case types.Number:
+		return json.Number(v.ValueBigFloat().Text('f', -1)), nil
+	case types.List:
+		return attrsToJSONArray(ctx, v.Elements())
+	case types.Set:
+		return attrsToJSONArray(ctx, v.Elements())
+	case types.Tuple:
+		return attrsToJSONArray(ctx, v.Elements())
+	case types.Map:
+		return attrsToJSONObject(ctx, v.Elements())
+	case types.Object:
+		return attrsToJSONObject(ctx, v.Attributes())
+	default:
+		return nil, fmt.Errorf("unsupported value type %T", value)
+	}
+}
+
+func attrsToJSONArray(ctx context.Context, elems []attr.Value) (interface{}, error) {
+	result := make([]interface{}, 0, len(elems))
+	for _, elem := range elems {
+		decoded, err := attrToJSONValue(ctx, elem)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, decoded)
+	}
+	return result, nil
+}
+
+func attrsToJSONObject(ctx context.Context, attrs map[string]attr.Value) (interface{}, error) {
+	result := make(map[string]interface{}, len(attrs))
+	for name, elem := range attrs {
+		decoded, err := attrToJSONValue(ctx, elem)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		result[name] = decoded
+	}
+	return result, nil
+}
@@ -0,0 +1,57 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONToDynamic(t *testing.T) {
+	t.Parallel()
+
+	value, err := JSONToDynamic([]byte(`{"name": "zone", "ttl": 3600, "enabled": true, "soa": null, "records": [1, "two", {"x": 1.5}]}`))
+	require.NoError(t, err)
+
+	obj, ok := value.UnderlyingValue().(types.Object)
+	require.True(t, ok)
+	attrs := obj.Attributes()
+	assert.Equal(t, types.StringValue("zone"), attrs["name"])
+	assert.Equal(t, types.BoolValue(true), attrs["enabled"])
+	assert.True(t, attrs["soa"].IsNull())
+
+	ttl, _ := attrs["ttl"].(types.Number).ValueBigFloat().Int64()
+	assert.Equal(t, int64(3600), ttl)
+
+	records, ok := attrs["records"].(types.Tuple)
+	require.True(t, ok)
+	assert.Len(t, records.Elements(), 3)
+	assert.Equal(t, types.StringValue("two"), records.Elements()[1])
+}
+
+func TestJSONToDynamic_Invalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := JSONToDynamic([]byte(`{"name": `))
+	assert.Error(t, err)
+}
+
+func TestDynamicToJSON_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	raw := `{"name":"zone","ttl":3600,"enabled":true,"soa":null,"records":[1,"two",{"x":1.5}]}`
+	value, err := JSONToDynamic([]byte(raw))
+	require.NoError(t, err)
+
+	encoded, err := DynamicToJSON(t.Context(), value)
+	require.NoError(t, err)
+	assert.JSONEq(t, raw, string(encoded))
+}
+
+func TestDynamicToJSON_Unknown(t *testing.T) {
+	t.Parallel()
+
+	_, err := DynamicToJSON(t.Context(), types.DynamicUnknown())
+	assert.Error(t, err)
+}
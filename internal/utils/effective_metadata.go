@@ -0,0 +1,446 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bab3l/go-netbox"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// PlanEffectiveMetadata plans the computed tags_all and custom_fields_all
+// attributes of a resource, so that plans show the tags and custom field
+// values an object ends up with once the provider's default_tags and
+// default_custom_fields are merged in. Attributes missing from the resource
+// schema are skipped. objectType is the NetBox object type of the resource,
+// e.g. "dcim.site".
+func PlanEffectiveMetadata(ctx context.Context, client *netbox.APIClient, objectType string, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	settings := ProviderSettingsFor(client)
+	if hasRootAttribute(ctx, req.Plan, "tags_all") {
+		planTagsAll(ctx, settings, req, resp)
+	}
+	if hasRootAttribute(ctx, req.Plan, "custom_fields_all") {
+		planCustomFieldsAll(ctx, client, settings, objectType, req, resp)
+	}
+}
+
+func hasRootAttribute(ctx context.Context, plan tfsdk.Plan, name string) bool {
+	_, diags := plan.Schema.AttributeAtPath(ctx, path.Root(name))
+	return !diags.HasError()
+}
+
+// planTagsAll plans tags_all as tags plus the default tags and the ignored
+// tags of the object, which updates keep. When neither tags nor default tags
+// are set, the tags of the object are not managed and keep their current
+// value.
+func planTagsAll(ctx context.Context, settings ProviderSettings, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	tagsAllPath := path.Root("tags_all")
+	var tags types.Set
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("tags"), &tags)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if tags.IsUnknown() || slices.ContainsFunc(tags.Elements(), attr.Value.IsUnknown) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, tagsAllPath, types.SetUnknown(types.StringType))...)
+		return
+	}
+
+	if !IsSet(tags) && len(settings.DefaultTags) == 0 {
+		planned := types.SetValueMust(types.StringType, []attr.Value{})
+		if !req.State.Raw.IsNull() {
+			resp.Diagnostics.Append(req.State.GetAttribute(ctx, tagsAllPath, &planned)...)
+			if !IsSet(planned) {
+				planned = types.SetUnknown(types.StringType)
+			}
+		}
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, tagsAllPath, planned)...)
+		return
+	}
+
+	slugs := SetToStringSlice(ctx, tags)
+	for _, slug := range slugs {
+		if settings.IgnoresTag(slug) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("tags"),
+				"Ignored tag",
+				fmt.Sprintf("Tag %q matches the provider's ignore_tags, so it cannot be managed by resources.", slug),
+			)
+		}
+	}
+	for _, slug := range settings.DefaultTags {
+		if !slices.Contains(slugs, slug) {
+			slugs = append(slugs, slug)
+		}
+	}
+	if !req.State.Raw.IsNull() {
+		var prior types.Set
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, tagsAllPath, &prior)...)
+		for _, slug := range SetToStringSlice(ctx, prior) {
+			if settings.IgnoresTag(slug) && !slices.Contains(slugs, slug) {
+				slugs = append(slugs, slug)
+			}
+		}
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, tagsAllPath, stringSetValue(slugs))...)
+}
+
+// planCustomFieldsAll plans custom_fields_all by applying the configured
+// custom fields and the default custom fields to the current values, the same
+// way the request helpers merge them on apply.
+func planCustomFieldsAll(ctx context.Context, client *netbox.APIClient, settings ProviderSettings, objectType string, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	allPath := path.Root("custom_fields_all")
+	unknown := func() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, allPath, types.MapUnknown(types.StringType))...)
+	}
+
+	creating := req.State.Raw.IsNull()
+	var planCustomFields, stateCustomFields types.Set
+	var customFieldsMap types.Dynamic
+	prior := types.MapNull(types.StringType)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("custom_fields"), &planCustomFields)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("custom_fields_map"), &customFieldsMap)...)
+	if !creating {
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("custom_fields"), &stateCustomFields)...)
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, allPath, &prior)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if planCustomFields.IsUnknown() || customFieldsMap.IsUnknown() || customFieldsMap.IsUnderlyingValueUnknown() || (!creating && !IsSet(prior)) {
+		unknown()
+		return
+	}
+
+	definitions, _, err := CustomFieldRegistryFor(client).Definitions(ctx, time.Time{})
+	if err != nil {
+		// ValidateCustomFieldsPlan already warns that definitions are unavailable.
+		unknown()
+		return
+	}
+
+	values := make(map[string]string)
+	if creating {
+		for name, definition := range definitions {
+			if definition.HasDefault && definition.AppliesTo(objectType) && !settings.IgnoresCustomField(name) {
+				if formatted, ok := formatCustomFieldAll(definition.Default); ok {
+					values[name] = formatted
+				}
+			}
+		}
+	} else {
+		for name, value := range prior.Elements() {
+			if s, ok := value.(types.String); ok && !s.IsNull() {
+				values[name] = s.ValueString()
+			}
+		}
+	}
+	set := func(name string, value interface{}) {
+		formatted, ok := formatCustomFieldAll(value)
+		if !ok {
+			delete(values, name)
+			return
+		}
+		if prior, exists := values[name]; !exists || !customFieldAllMatches(prior, value) {
+			values[name] = formatted
+		}
+	}
+
+	// Fields sent by the request helpers; the default custom fields only fill
+	// in the others.
+	sent := make(map[string]bool)
+	if !creating && !IsSet(planCustomFields) {
+		for _, model := range customFieldModels(ctx, stateCustomFields) {
+			sent[model.Name.ValueString()] = true
+		}
+	}
+	if IsSet(planCustomFields) {
+		for _, element := range planCustomFields.Elements() {
+			object, ok := element.(types.Object)
+			if !ok || object.IsUnknown() {
+				unknown()
+				return
+			}
+			for _, value := range object.Attributes() {
+				if value.IsUnknown() {
+					unknown()
+					return
+				}
+			}
+		}
+		models := customFieldModels(ctx, planCustomFields)
+		if !creating && len(models) > 0 {
+			for _, model := range customFieldModels(ctx, stateCustomFields) {
+				sent[model.Name.ValueString()] = true
+			}
+		}
+		for _, model := range models {
+			name, raw := model.Name.ValueString(), model.Value.ValueString()
+			if raw == "" {
+				// Cleared on create; left out of the merged request on update.
+				if creating {
+					sent[name] = true
+					delete(values, name)
+				} else {
+					delete(sent, name)
+				}
+				continue
+			}
+			sent[name] = true
+			var value interface{} = raw
+			if definition, ok := definitions[name]; ok {
+				if parsed, err := parseCustomFieldString(definition, raw); err == nil {
+					value = parsed
+				}
+			}
+			set(name, value)
+		}
+	}
+
+	if IsSet(customFieldsMap) {
+		var elements map[string]attr.Value
+		switch underlying := customFieldsMap.UnderlyingValue().(type) {
+		case types.Object:
+			elements = underlying.Attributes()
+		case types.Map:
+			elements = underlying.Elements()
+		}
+		for name, element := range elements {
+			value, err := attrToJSONValue(ctx, element)
+			if err != nil {
+				unknown()
+				return
+			}
+			sent[name] = true
+			if definition, ok := definitions[name]; ok {
+				if converted, err := ConvertCustomFieldValue(definition, value); err == nil {
+					value = converted
+				}
+			}
+			set(name, value)
+		}
+	}
+
+	for name, raw := range settings.DefaultCustomFields {
+		if sent[name] {
+			continue
+		}
+		definition, ok := definitions[name]
+		if !ok {
+			// The field may be created later in this apply.
+			unknown()
+			return
+		}
+		if !definition.AppliesTo(objectType) {
+			continue
+		}
+		if value, err := parseCustomFieldString(definition, raw); err == nil {
+			set(name, value)
+		}
+	}
+
+	planned, diags := types.MapValueFrom(ctx, types.StringType, values)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, allPath, planned)...)
+}
+
+func customFieldModels(ctx context.Context, set types.Set) []CustomFieldModel {
+	if !IsSet(set) {
+		return nil
+	}
+	var models []CustomFieldModel
+	if diags := set.ElementsAs(ctx, &models, false); diags.HasError() {
+		return nil
+	}
+	return models
+}
+
+// TagsAllFromAPI returns the tags_all value of an object: the slugs of all of
+// its tags, including those ignored by the provider, which updates keep.
+func TagsAllFromAPI(ctx context.Context, tags []netbox.NestedTag) types.Set {
+	slugs := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		slugs = append(slugs, tag.Slug)
+	}
+	return stringSetValue(slugs)
+}
+
+// CustomFieldsAllFromAPI returns the custom_fields_all value of an object: all
+// of its custom fields that have a value and are not ignored by the provider
+// settings of client, formatted as in custom_fields. Values in current that
+// still match the API value are kept as written, so that references by slug
+// and numbers written differently do not show as drift.
+func CustomFieldsAllFromAPI(ctx context.Context, client *netbox.APIClient, current types.Map, customFields map[string]interface{}, diags *diag.Diagnostics) types.Map {
+	customFields = withoutIgnoredCustomFields(client, customFields)
+	previous := map[string]string{}
+	if IsSet(current) {
+		for name, value := range current.Elements() {
+			if s, ok := value.(types.String); ok && IsSet(s) {
+				previous[name] = s.ValueString()
+			}
+		}
+	}
+
+	values := make(map[string]string, len(customFields))
+	for name, value := range customFields {
+		formatted, ok := formatCustomFieldAll(value)
+		if !ok {
+			continue
+		}
+		if prior, ok := previous[name]; ok && customFieldAllMatches(prior, value) {
+			formatted = prior
+		}
+		values[name] = formatted
+	}
+
+	result, mapDiags := types.MapValueFrom(ctx, types.StringType, values)
+	diags.Append(mapDiags...)
+	if mapDiags.HasError() {
+		return current
+	}
+	return result
+}
+
+func stringSetValue(values []string) types.Set {
+	elements := make([]attr.Value, 0, len(values))
+	for _, value := range values {
+		elements = append(elements, types.StringValue(value))
+	}
+	return types.SetValueMust(types.StringType, elements)
+}
+
+// formatCustomFieldAll formats a custom field value for custom_fields_all.
+// Lists are joined with commas and nested objects are reduced to their IDs.
+// It returns false for null values.
+func formatCustomFieldAll(value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case nil:
+		return "", false
+	case string:
+		return v, true
+	case bool:
+		return strconv.FormatBool(v), true
+	case int:
+		return strconv.Itoa(v), true
+	case int64:
+		return strconv.FormatInt(v, 10), true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	case json.Number:
+		return v.String(), true
+	case []string:
+		return strings.Join(v, ","), true
+	case []int64:
+		ids := make([]string, 0, len(v))
+		for _, id := range v {
+			ids = append(ids, strconv.FormatInt(id, 10))
+		}
+		return strings.Join(ids, ","), true
+	case map[string]interface{}:
+		if id, ok := nestedObjectID(v); ok {
+			return strconv.FormatInt(id, 10), true
+		}
+	case []interface{}:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			if _, nested := item.([]interface{}); nested {
+				items = nil
+				break
+			}
+			if object, ok := item.(map[string]interface{}); ok {
+				if _, ok := nestedObjectID(object); !ok {
+					items = nil
+					break
+				}
+			}
+			formatted, ok := formatCustomFieldAll(item)
+			if !ok {
+				items = nil
+				break
+			}
+			items = append(items, formatted)
+		}
+		if items != nil || len(v) == 0 {
+			return strings.Join(items, ","), true
+		}
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return "", false
+	}
+	return string(encoded), true
+}
+
+// customFieldAllMatches reports whether prior, a custom_fields_all value,
+// still describes the custom field value value.
+func customFieldAllMatches(prior string, value interface{}) bool {
+	formatted, ok := formatCustomFieldAll(value)
+	if !ok {
+		return false
+	}
+	if formatted == prior || numericStringsEqual(prior, formatted) {
+		return true
+	}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if _, ok := nestedObjectID(v); ok {
+			return CustomFieldReferenceMatches(prior, v)
+		}
+	case []string:
+		return slices.Equal(splitCustomFieldList(prior), v)
+	case []interface{}:
+		if CustomFieldReferencesMatch(splitCustomFieldList(prior), v) {
+			return true
+		}
+		if slices.Equal(splitCustomFieldList(prior), splitCustomFieldList(formatted)) {
+			return true
+		}
+	}
+	decoder := json.NewDecoder(bytes.NewReader([]byte(prior)))
+	decoder.UseNumber()
+	var decoded interface{}
+	if err := decoder.Decode(&decoded); err != nil {
+		return false
+	}
+	switch decoded.(type) {
+	case map[string]interface{}, []interface{}:
+		return JSONValueMatches(decoded, value)
+	}
+	return false
+}
+
+// numericStringsEqual reports whether a and b are the same number, e.g. "1.5"
+// and "1.50".
+func numericStringsEqual(a, b string) bool {
+	x, ok := new(big.Float).SetString(strings.TrimSpace(a))
+	if !ok {
+		return false
+	}
+	y, ok := new(big.Float).SetString(strings.TrimSpace(b))
+	return ok && x.Cmp(y) == 0
+}
+
+func splitCustomFieldList(value string) []string {
+	items := strings.Split(value, ",")
+	for i, item := range items {
+		items[i] = strings.TrimSpace(item)
+	}
+	return items
+}
@@ -0,0 +1,209 @@
+package utils
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/bab3l/go-netbox"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type effectiveMetadataModel struct {
+	ID              types.String  `tfsdk:"id"`
+	Tags            types.Set     `tfsdk:"tags"`
+	TagsAll         types.Set     `tfsdk:"tags_all"`
+	CustomFields    types.Set     `tfsdk:"custom_fields"`
+	CustomFieldsMap types.Dynamic `tfsdk:"custom_fields_map"`
+	CustomFieldsAll types.Map     `tfsdk:"custom_fields_all"`
+}
+
+func newEffectiveMetadataModel() effectiveMetadataModel {
+	return effectiveMetadataModel{
+		ID:              types.StringValue("1"),
+		Tags:            types.SetNull(types.StringType),
+		TagsAll:         types.SetUnknown(types.StringType),
+		CustomFields:    types.SetNull(GetCustomFieldsAttributeType().ElemType),
+		CustomFieldsMap: types.DynamicNull(),
+		CustomFieldsAll: types.MapUnknown(types.StringType),
+	}
+}
+
+// planEffectiveMetadata runs PlanEffectiveMetadata for a dcim.site plan; a nil
+// prior plans a create.
+func planEffectiveMetadata(t *testing.T, client *netbox.APIClient, prior *effectiveMetadataModel, planned effectiveMetadataModel) effectiveMetadataModel {
+	t.Helper()
+	ctx := context.Background()
+	s := customFieldsPlanSchema()
+	s.Attributes["tags"] = schema.SetAttribute{ElementType: types.StringType, Optional: true}
+	s.Attributes["tags_all"] = schema.SetAttribute{ElementType: types.StringType, Computed: true}
+	s.Attributes["custom_fields_all"] = schema.MapAttribute{ElementType: types.StringType, Computed: true}
+
+	plan := tfsdk.Plan{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(ctx), nil)}
+	require.False(t, plan.Set(ctx, &planned).HasError())
+	state := tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(ctx), nil)}
+	if prior != nil {
+		require.False(t, state.Set(ctx, prior).HasError())
+	}
+
+	resp := &resource.ModifyPlanResponse{Plan: plan}
+	PlanEffectiveMetadata(ctx, client, "dcim.site", resource.ModifyPlanRequest{Plan: plan, State: state}, resp)
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics.Errors())
+
+	var result effectiveMetadataModel
+	require.False(t, resp.Plan.Get(ctx, &result).HasError())
+	return result
+}
+
+func stringMap(t *testing.T, values map[string]string) types.Map {
+	t.Helper()
+	m, diags := types.MapValueFrom(context.Background(), types.StringType, values)
+	require.False(t, diags.HasError(), diags.Errors())
+	return m
+}
+
+func TestPlanEffectiveMetadata_Create(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	var loads atomic.Int32
+	client := newCustomFieldRegistryClient(t, &loads)
+	SetProviderSettings(client, ProviderSettings{
+		DefaultTags:         []string{"managed"},
+		DefaultCustomFields: map[string]string{"owner": "ops", "rack_units": "2", "serial": "abc"},
+	})
+
+	planned := newEffectiveMetadataModel()
+	planned.Tags = TagsSlugToSet(ctx, []string{"edge"})
+	planned.CustomFields = customFieldsSet(t, customField("rack_units", "integer", "4"))
+	got := planEffectiveMetadata(t, client, nil, planned)
+
+	assert.Equal(t, TagsSlugToSet(ctx, []string{"edge", "managed"}), got.TagsAll)
+	assert.Equal(t, stringMap(t, map[string]string{
+		"monitored":  "false",
+		"owner":      "ops",
+		"rack_units": "4",
+	}), got.CustomFieldsAll, "NetBox defaults, configured values and the provider defaults for dcim.site")
+
+	planned.Tags = types.SetUnknown(types.StringType)
+	planned.CustomFields = types.SetUnknown(GetCustomFieldsAttributeType().ElemType)
+	got = planEffectiveMetadata(t, client, nil, planned)
+	assert.True(t, got.TagsAll.IsUnknown())
+	assert.True(t, got.CustomFieldsAll.IsUnknown())
+
+	// A default custom field that is not defined yet may be created in the
+	// same apply.
+	SetProviderSettings(client, ProviderSettings{DefaultCustomFields: map[string]string{"cost_center": "42"}})
+	got = planEffectiveMetadata(t, client, nil, newEffectiveMetadataModel())
+	assert.True(t, got.CustomFieldsAll.IsUnknown())
+}
+
+func TestPlanEffectiveMetadata_Update(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	var loads atomic.Int32
+	client := newCustomFieldRegistryClient(t, &loads)
+
+	prior := newEffectiveMetadataModel()
+	prior.TagsAll = TagsSlugToSet(ctx, []string{"external"})
+	prior.CustomFields = customFieldsSet(t, customField("owner", "text", "netops"))
+	prior.CustomFieldsAll = stringMap(t, map[string]string{"owner": "netops", "asset_tag": "A1"})
+
+	// Unmanaged tags and custom fields keep their current values.
+	got := planEffectiveMetadata(t, client, &prior, prior)
+	assert.Equal(t, prior.TagsAll, got.TagsAll)
+	assert.Equal(t, prior.CustomFieldsAll, got.CustomFieldsAll)
+
+	// A field cleared in custom_fields is left out of the request and the
+	// provider default fills it in.
+	SetProviderSettings(client, ProviderSettings{DefaultTags: []string{"managed"}, DefaultCustomFields: map[string]string{"owner": "ops"}})
+	planned := prior
+	planned.TagsAll = types.SetUnknown(types.StringType)
+	planned.CustomFieldsAll = types.MapUnknown(types.StringType)
+	planned.CustomFields = customFieldsSet(t, customField("owner", "text", ""))
+	got = planEffectiveMetadata(t, client, &prior, planned)
+	assert.Equal(t, TagsSlugToSet(ctx, []string{"managed"}), got.TagsAll)
+	assert.Equal(t, stringMap(t, map[string]string{"owner": "ops", "asset_tag": "A1"}), got.CustomFieldsAll)
+}
+
+func TestEffectiveMetadataFromAPI(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	assert.Equal(t, TagsSlugToSet(ctx, []string{"a", "b"}), TagsAllFromAPI(ctx, []netbox.NestedTag{{Slug: "a"}, {Slug: "b"}}))
+	empty := TagsAllFromAPI(ctx, nil)
+	assert.False(t, empty.IsNull())
+	assert.Empty(t, empty.Elements())
+
+	customFields := map[string]interface{}{
+		"owner":   map[string]interface{}{"id": float64(7), "slug": "acme"},
+		"roles":   []interface{}{"edge", "core"},
+		"units":   float64(4),
+		"comment": nil,
+	}
+	var diags diag.Diagnostics
+	got := CustomFieldsAllFromAPI(ctx, nil, types.MapNull(types.StringType), customFields, &diags)
+	require.False(t, diags.HasError(), diags.Errors())
+	assert.Equal(t, stringMap(t, map[string]string{"owner": "7", "roles": "edge,core", "units": "4"}), got)
+
+	// Values written differently but matching the API value are kept.
+	current := stringMap(t, map[string]string{"owner": "acme", "roles": "edge, core", "units": "4.0"})
+	got = CustomFieldsAllFromAPI(ctx, nil, current, customFields, &diags)
+	require.False(t, diags.HasError(), diags.Errors())
+	assert.Equal(t, current, got)
+}
+
+func TestPlanEffectiveMetadata_IgnoredTag(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	settings := ProviderSettings{IgnoreTags: []string{"discovered-*"}}
+	s := customFieldsPlanSchema()
+	s.Attributes["tags"] = schema.SetAttribute{ElementType: types.StringType, Optional: true}
+	s.Attributes["tags_all"] = schema.SetAttribute{ElementType: types.StringType, Computed: true}
+
+	planned := newEffectiveMetadataModel()
+	planned.Tags = TagsSlugToSet(ctx, []string{"ops", "discovered-lldp"})
+	plan := tfsdk.Plan{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(ctx), nil)}
+	require.False(t, plan.Set(ctx, &struct {
+		ID              types.String  `tfsdk:"id"`
+		Tags            types.Set     `tfsdk:"tags"`
+		TagsAll         types.Set     `tfsdk:"tags_all"`
+		CustomFields    types.Set     `tfsdk:"custom_fields"`
+		CustomFieldsMap types.Dynamic `tfsdk:"custom_fields_map"`
+	}{planned.ID, planned.Tags, planned.TagsAll, planned.CustomFields, planned.CustomFieldsMap}).HasError())
+	state := tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(ctx), nil)}
+
+	resp := &resource.ModifyPlanResponse{Plan: plan}
+	planTagsAll(ctx, settings, resource.ModifyPlanRequest{Plan: plan, State: state}, resp)
+	require.Equal(t, 1, resp.Diagnostics.ErrorsCount(), resp.Diagnostics.Errors())
+	assert.Equal(t, "Ignored tag", resp.Diagnostics.Errors()[0].Summary())
+}
+
+func TestPlanEffectiveMetadata_KeepsIgnoredTags(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	var loads atomic.Int32
+	client := newCustomFieldRegistryClient(t, &loads)
+	SetProviderSettings(client, ProviderSettings{IgnoreTags: []string{"discovered-*"}})
+
+	prior := newEffectiveMetadataModel()
+	prior.Tags = TagsSlugToSet(ctx, []string{"ops", "legacy"})
+	prior.TagsAll = TagsSlugToSet(ctx, []string{"ops", "legacy", "discovered-lldp"})
+	prior.CustomFieldsAll = stringMap(t, map[string]string{})
+
+	planned := prior
+	planned.Tags = TagsSlugToSet(ctx, []string{"ops"})
+	planned.TagsAll = types.SetUnknown(types.StringType)
+	got := planEffectiveMetadata(t, client, &prior, planned)
+	assert.Equal(t, TagsSlugToSet(ctx, []string{"ops", "discovered-lldp"}), got.TagsAll, "ignored tags are kept, other tags are removed")
+}
@@ -0,0 +1,74 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/bab3l/go-netbox"
+)
+
+// GraphQLRequest is the payload sent to the NetBox GraphQL endpoint.
+type GraphQLRequest struct {
+	Query     string                 `json:"query"`
+	Variables map[string]interface{} `json:"variables,omitempty"`
+}
+
+// GraphQLError is a single entry of the `errors` array of a GraphQL response.
+type GraphQLError struct {
+	Message   string        `json:"message"`
+	Path      []interface{} `json:"path,omitempty"`
+	Locations []struct {
+		Line   int `json:"line"`
+		Column int `json:"column"`
+	} `json:"locations,omitempty"`
+}
+
+// String formats the error with its path and location, when present.
+func (e GraphQLError) String() string {
+	var sb strings.Builder
+	sb.WriteString(e.Message)
+	if len(e.Path) > 0 {
+		parts := make([]string, 0, len(e.Path))
+		for _, p := range e.Path {
+			parts = append(parts, fmt.Sprintf("%v", p))
+		}
+		sb.WriteString(fmt.Sprintf(" (path: %s)", strings.Join(parts, ".")))
+	}
+	for _, loc := range e.Locations {
+		sb.WriteString(fmt.Sprintf(" (line %d, column %d)", loc.Line, loc.Column))
+	}
+	return sb.String()
+}
+
+// GraphQLResponse is the envelope returned by the NetBox GraphQL endpoint.
+type GraphQLResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []GraphQLError  `json:"errors,omitempty"`
+}
+
+// ExecuteGraphQL runs a query against the NetBox GraphQL endpoint (`/graphql/`)
+// using the headers and HTTP client configured on the go-netbox client.
+//
+// GraphQL errors are returned in the response rather than as an error, since
+// NetBox may return partial data alongside them. The returned error is only set
+// for transport failures and non-2xx responses.
+func ExecuteGraphQL(ctx context.Context, client *netbox.APIClient, request GraphQLRequest) (*GraphQLResponse, *http.Response, error) {
+	u, err := rawURL(client, "/graphql/", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	body, httpResp, err := doRawRequest(ctx, client, http.MethodPost, u, request)
+	if err != nil {
+		return nil, httpResp, err
+	}
+
+	var result GraphQLResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, httpResp, fmt.Errorf("invalid GraphQL response: %w", err)
+	}
+	return &result, httpResp, nil
+}
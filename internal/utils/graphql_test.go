@@ -0,0 +1,29 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecuteGraphQL(t *testing.T) {
+	t.Parallel()
+
+	client := newRawTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/graphql/", r.URL.Path)
+		var req GraphQLRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "{ site_list { name } }", req.Query)
+		_, _ = w.Write([]byte(`{"data": {"site_list": []}, "errors": [{"message": "boom", "path": ["site_list", 0]}]}`))
+	})
+
+	result, _, err := ExecuteGraphQL(context.Background(), client, GraphQLRequest{Query: "{ site_list { name } }"})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"site_list": []}`, string(result.Data))
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, "boom (path: site_list.0)", result.Errors[0].String())
+}
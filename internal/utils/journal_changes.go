@@ -0,0 +1,69 @@
+package utils
+
+import (
+	"os"
+	"strings"
+	"text/template"
+)
+
+// DefaultJournalTemplate is the template of journal entry comments used when
+// the provider configuration sets none.
+const DefaultJournalTemplate = `Terraform {{.Action}} this object{{with .Workspace}} in workspace {{.}}{{end}}{{with .RunID}} (run {{.}}){{end}}.{{with .Changes}} Changed: {{join . ", "}}.{{end}}`
+
+// JournalSettings configures the journal entries posted to the objects that
+// resources create or update.
+type JournalSettings struct {
+	// Kind is the kind of the journal entries, e.g. "info".
+	Kind string
+	// Template renders the comments of a journal entry from a JournalChange.
+	Template *template.Template
+}
+
+// JournalChange describes a change made by a resource, for rendering with the
+// journal template.
+type JournalChange struct {
+	// Action is "created" or "updated".
+	Action string
+	// ObjectType is the content type of the object, e.g. "dcim.site".
+	ObjectType string
+	ObjectID   int64
+	// Workspace is the Terraform workspace, from TF_WORKSPACE or
+	// TFC_WORKSPACE_NAME.
+	Workspace string
+	// RunID is the run ID, from TFC_RUN_ID.
+	RunID string
+	// Changes are the names of the changed attributes.
+	Changes []string
+}
+
+// ParseJournalTemplate parses the template of journal entry comments. Besides
+// the fields of JournalChange, templates can use join, like strings.Join.
+func ParseJournalTemplate(text string) (*template.Template, error) {
+	return template.New("journal").Funcs(template.FuncMap{"join": strings.Join}).Parse(text)
+}
+
+// NewJournalChange returns the change of the object with objectType and
+// objectID, with the workspace and run ID of the environment.
+func NewJournalChange(action, objectType string, objectID int64, changes []string) JournalChange {
+	workspace := os.Getenv("TF_WORKSPACE")
+	if workspace == "" {
+		workspace = os.Getenv("TFC_WORKSPACE_NAME")
+	}
+	return JournalChange{
+		Action:     action,
+		ObjectType: objectType,
+		ObjectID:   objectID,
+		Workspace:  workspace,
+		RunID:      os.Getenv("TFC_RUN_ID"),
+		Changes:    changes,
+	}
+}
+
+// Comments renders the comments of the journal entry about change.
+func (s *JournalSettings) Comments(change JournalChange) (string, error) {
+	var comments strings.Builder
+	if err := s.Template.Execute(&comments, change); err != nil {
+		return "", err
+	}
+	return comments.String(), nil
+}
@@ -0,0 +1,48 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewJournalChange(t *testing.T) {
+	t.Setenv("TF_WORKSPACE", "")
+	t.Setenv("TFC_WORKSPACE_NAME", "network-prod")
+	t.Setenv("TFC_RUN_ID", "run-abc")
+
+	change := NewJournalChange("created", "dcim.site", 3, []string{"name"})
+	assert.Equal(t, JournalChange{
+		Action:     "created",
+		ObjectType: "dcim.site",
+		ObjectID:   3,
+		Workspace:  "network-prod",
+		RunID:      "run-abc",
+		Changes:    []string{"name"},
+	}, change)
+
+	t.Setenv("TF_WORKSPACE", "staging")
+	assert.Equal(t, "staging", NewJournalChange("updated", "dcim.site", 3, nil).Workspace)
+}
+
+func TestJournalSettingsComments(t *testing.T) {
+	t.Parallel()
+
+	tmpl, err := ParseJournalTemplate(DefaultJournalTemplate)
+	require.NoError(t, err)
+	journal := &JournalSettings{Kind: "info", Template: tmpl}
+
+	comments, err := journal.Comments(JournalChange{Action: "created"})
+	require.NoError(t, err)
+	assert.Equal(t, "Terraform created this object.", comments)
+
+	comments, err = journal.Comments(JournalChange{Action: "updated", RunID: "run-1", Changes: []string{"status"}})
+	require.NoError(t, err)
+	assert.Equal(t, "Terraform updated this object (run run-1). Changed: status.", comments)
+
+	tmpl, err = ParseJournalTemplate("{{.Missing}}")
+	require.NoError(t, err)
+	_, err = (&JournalSettings{Template: tmpl}).Comments(JournalChange{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,92 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ExpandNamePattern expands a NetBox-style alphanumeric name pattern into the
+// names it describes, in order. Each bracketed group holds comma-separated
+// values or ranges, e.g. "ge-0/0/[0-47]" or "Ethernet[1,3,5-7]/[a-b]". A name
+// without brackets expands to itself.
+func ExpandNamePattern(pattern string) ([]string, error) {
+	start := strings.IndexByte(pattern, '[')
+	if start < 0 {
+		if strings.Contains(pattern, "]") {
+			return nil, fmt.Errorf("invalid name pattern %q: unexpected \"]\"", pattern)
+		}
+		return []string{pattern}, nil
+	}
+	end := strings.IndexByte(pattern[start:], ']')
+	if end < 0 {
+		return nil, fmt.Errorf("invalid name pattern %q: unclosed \"[\"", pattern)
+	}
+	end += start
+
+	prefix, group, rest := pattern[:start], pattern[start+1:end], pattern[end+1:]
+	if strings.Contains(prefix, "]") || strings.Contains(group, "[") {
+		return nil, fmt.Errorf("invalid name pattern %q: unbalanced brackets", pattern)
+	}
+	values, err := expandNamePatternGroup(group)
+	if err != nil {
+		return nil, fmt.Errorf("invalid name pattern %q: %w", pattern, err)
+	}
+	tails, err := ExpandNamePattern(rest)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(values)*len(tails))
+	for _, value := range values {
+		for _, tail := range tails {
+			names = append(names, prefix+value+tail)
+		}
+	}
+	return names, nil
+}
+
+// expandNamePatternGroup expands the contents of one bracketed group.
+func expandNamePatternGroup(group string) ([]string, error) {
+	var values []string
+	for _, part := range strings.Split(group, ",") {
+		from, to, isRange := strings.Cut(part, "-")
+		switch {
+		case part == "":
+			return nil, fmt.Errorf("empty value in [%s]", group)
+		case !isRange:
+			values = append(values, part)
+		default:
+			expanded, err := expandNamePatternRange(from, to)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, expanded...)
+		}
+	}
+	return values, nil
+}
+
+// expandNamePatternRange expands a numeric range such as 0-47 or a single
+// letter range such as a-d.
+func expandNamePatternRange(from, to string) ([]string, error) {
+	if start, err := strconv.Atoi(from); err == nil {
+		end, err := strconv.Atoi(to)
+		if err != nil || end < start {
+			return nil, fmt.Errorf("invalid range %s-%s", from, to)
+		}
+		values := make([]string, 0, end-start+1)
+		for i := start; i <= end; i++ {
+			values = append(values, strconv.Itoa(i))
+		}
+		return values, nil
+	}
+	if len(from) != 1 || len(to) != 1 || from[0] > to[0] {
+		return nil, fmt.Errorf("invalid range %s-%s", from, to)
+	}
+	values := make([]string, 0, int(to[0]-from[0])+1)
+	for c := from[0]; c <= to[0]; c++ {
+		values = append(values, string(c))
+	}
+	return values, nil
+}
@@ -0,0 +1,36 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpandNamePattern(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string][]string{
+		"eth0":                 {"eth0"},
+		"ge-0/0/[0-3]":         {"ge-0/0/0", "ge-0/0/1", "ge-0/0/2", "ge-0/0/3"},
+		"Ethernet[1,3,5-6]":    {"Ethernet1", "Ethernet3", "Ethernet5", "Ethernet6"},
+		"port[a-c]":            {"porta", "portb", "portc"},
+		"xe-[0-1]/0/[0-1]":     {"xe-0/0/0", "xe-0/0/1", "xe-1/0/0", "xe-1/0/1"},
+		"[mgmt,console]0":      {"mgmt0", "console0"},
+		"Gi1/0/[9-11].[10,20]": {"Gi1/0/9.10", "Gi1/0/9.20", "Gi1/0/10.10", "Gi1/0/10.20", "Gi1/0/11.10", "Gi1/0/11.20"},
+	}
+	for pattern, want := range cases {
+		got, err := ExpandNamePattern(pattern)
+		require.NoError(t, err, pattern)
+		assert.Equal(t, want, got, pattern)
+	}
+}
+
+func TestExpandNamePattern_Invalid(t *testing.T) {
+	t.Parallel()
+
+	for _, pattern := range []string{"ge-0/0/[0-3", "ge-0/0/0-3]", "ge-[[0-1]]", "ge-[3-1]", "ge-[a-3]", "ge-[1,,2]", "ge-[]", "ge-[aa-b]"} {
+		_, err := ExpandNamePattern(pattern)
+		assert.Error(t, err, pattern)
+	}
+}
@@ -0,0 +1,234 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bab3l/go-netbox"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// ProviderSettings holds the provider configuration that applies to every
+// object managed through a client.
+type ProviderSettings struct {
+	// DefaultTags are the slugs of tags added to every object that supports tags.
+	DefaultTags []string
+	// DefaultCustomFields are custom field values, written as in custom_fields,
+	// set on every object whose type the field is assigned to unless the
+	// resource configures the field itself.
+	DefaultCustomFields map[string]string
+	// IgnoreTags are slugs of tags that are managed outside Terraform. An entry
+	// ending in "*" matches every slug starting with the text before it.
+	IgnoreTags []string
+	// IgnoreCustomFields are names of custom fields that are managed outside
+	// Terraform, matched like IgnoreTags.
+	IgnoreCustomFields []string
+	// Journal configures the journal entries posted to changed objects. It is
+	// nil when changes are not journaled.
+	Journal *JournalSettings
+	// RequestID is sent in the X-Request-ID header of every request.
+	RequestID string
+	// WriteRequests records the request IDs NetBox assigns to write requests.
+	WriteRequests *WriteRequestLog
+	// Branch is the schema ID of the netbox-branching branch that requests
+	// are made in, sent in the X-NetBox-Branch header. Requests are made in
+	// main when it is empty.
+	Branch string
+	// ReadOnly rejects every request that could change NetBox.
+	ReadOnly bool
+}
+
+// IgnoresTag reports whether the tag with slug is ignored.
+func (s ProviderSettings) IgnoresTag(slug string) bool {
+	return matchesIgnored(s.IgnoreTags, slug)
+}
+
+// IgnoresCustomField reports whether the custom field called name is ignored.
+func (s ProviderSettings) IgnoresCustomField(name string) bool {
+	return matchesIgnored(s.IgnoreCustomFields, name)
+}
+
+func matchesIgnored(patterns []string, value string) bool {
+	for _, pattern := range patterns {
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+			if strings.HasPrefix(value, prefix) {
+				return true
+			}
+		} else if pattern == value {
+			return true
+		}
+	}
+	return false
+}
+
+var providerSettings sync.Map
+
+// SetProviderSettings stores the settings of the provider configuration that
+// created client.
+func SetProviderSettings(client *netbox.APIClient, settings ProviderSettings) {
+	providerSettings.Store(client, settings)
+}
+
+// ProviderSettingsFor returns the settings stored for client, or the zero
+// settings when none were stored.
+func ProviderSettingsFor(client *netbox.APIClient) ProviderSettings {
+	if client == nil {
+		return ProviderSettings{}
+	}
+	settings, ok := providerSettings.Load(client)
+	if !ok {
+		return ProviderSettings{}
+	}
+	return settings.(ProviderSettings)
+}
+
+// TagsAccessor is implemented by request types whose tags can also be read
+// back, so further tags can be merged into them.
+type TagsAccessor interface {
+	TagsSetter
+	GetTags() []netbox.NestedTagRequest
+	HasTags() bool
+}
+
+type priorTagsContextKey struct{}
+
+// ContextWithPriorTags returns a copy of ctx carrying the tag slugs an object
+// had before the update that is made with ctx, as recorded in its tags_all,
+// so that ApplyProviderTags can keep the ignored ones.
+func ContextWithPriorTags(ctx context.Context, slugs []string) context.Context {
+	return context.WithValue(ctx, priorTagsContextKey{}, slugs)
+}
+
+// ApplyProviderTags applies the provider's tag settings to request. The
+// default tags are added, and when request replaces the tags of an object,
+// the object's prior tags set on ctx by ContextWithPriorTags that match
+// ignore_tags are added back, so that tags managed outside Terraform are
+// never removed. When the resource does not set tags, the request carries
+// the default tags only, matching the planned tags_all.
+func ApplyProviderTags[T TagsAccessor](ctx context.Context, client *netbox.APIClient, request T, diags *diag.Diagnostics) {
+	settings := ProviderSettingsFor(client)
+	if diags.HasError() || (!request.HasTags() && len(settings.DefaultTags) == 0) {
+		return
+	}
+
+	var tags []netbox.NestedTagRequest
+	if request.HasTags() {
+		tags = request.GetTags()
+	}
+	prior, _ := ctx.Value(priorTagsContextKey{}).([]string)
+	slugs := slices.Clone(settings.DefaultTags)
+	for _, slug := range prior {
+		if settings.IgnoresTag(slug) {
+			slugs = append(slugs, slug)
+		}
+	}
+	var missing []string
+	for _, slug := range slugs {
+		if !slices.Contains(missing, slug) && !slices.ContainsFunc(tags, func(tag netbox.NestedTagRequest) bool { return tag.Slug == slug }) {
+			missing = append(missing, slug)
+		}
+	}
+	if len(missing) == 0 && request.HasTags() {
+		return
+	}
+
+	added := TagSlugsToNestedTagRequests(ctx, client, missing, diags)
+	if diags.HasError() {
+		return
+	}
+	request.SetTags(append(slices.Clone(tags), added...))
+}
+
+// ApplyProviderCustomFields applies the provider's custom field settings to
+// request. Ignored custom fields are left out, so NetBox keeps their values,
+// and the default custom fields are set for the fields that are assigned to
+// objectType and not already set by the resource. Default values are
+// converted using the custom field definitions in NetBox.
+func ApplyProviderCustomFields[T CustomFieldsAccessor](ctx context.Context, client *netbox.APIClient, objectType string, request T, diags *diag.Diagnostics) {
+	settings := ProviderSettingsFor(client)
+	if diags.HasError() {
+		return
+	}
+	if customFields := request.GetCustomFields(); len(settings.IgnoreCustomFields) > 0 && len(customFields) > 0 {
+		request.SetCustomFields(withoutIgnoredCustomFields(client, customFields))
+	}
+
+	defaults := settings.DefaultCustomFields
+	if len(defaults) == 0 {
+		return
+	}
+
+	definitions, ok := providerDefaultDefinitions(ctx, client, defaults, diags)
+	if !ok {
+		return
+	}
+
+	customFields := request.GetCustomFields()
+	merged := make(map[string]interface{}, len(customFields)+len(defaults))
+	for name, value := range customFields {
+		merged[name] = value
+	}
+	names := make([]string, 0, len(defaults))
+	for name := range defaults {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if _, set := merged[name]; set {
+			continue
+		}
+		definition, ok := definitions[name]
+		if !ok {
+			diags.AddError(
+				"Unknown default custom field",
+				fmt.Sprintf("The provider's default_custom_fields sets %q, but no custom field with that name is defined in NetBox.", name),
+			)
+			continue
+		}
+		if !definition.AppliesTo(objectType) {
+			continue
+		}
+		value, err := parseCustomFieldString(definition, defaults[name])
+		if err != nil {
+			diags.AddError(
+				"Invalid default custom field value",
+				fmt.Sprintf("The provider's default value for custom field %q, of type %q, is invalid: %s.", name, definition.Type, err),
+			)
+			continue
+		}
+		merged[name] = value
+	}
+	if diags.HasError() {
+		return
+	}
+	request.SetCustomFields(merged)
+}
+
+// providerDefaultDefinitions returns the definitions of the default custom
+// fields, reloading them once when one of the fields is not cached yet.
+func providerDefaultDefinitions(ctx context.Context, client *netbox.APIClient, defaults map[string]string, diags *diag.Diagnostics) (map[string]CustomFieldDefinition, bool) {
+	started := time.Now()
+	registry := CustomFieldRegistryFor(client)
+	definitions, httpResp, err := registry.Definitions(ctx, time.Time{})
+	if err == nil {
+		for name := range defaults {
+			if _, ok := definitions[name]; !ok {
+				definitions, httpResp, err = registry.Definitions(ctx, started)
+				break
+			}
+		}
+	}
+	if err != nil {
+		diags.AddError(
+			"Error applying default custom fields",
+			FormatAPIError("list custom field definitions", err, httpResp),
+		)
+		return nil, false
+	}
+	return definitions, true
+}
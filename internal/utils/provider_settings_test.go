@@ -0,0 +1,200 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/bab3l/go-netbox"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTagLookupClient serves tag lookups by slug and counts them.
+func newTagLookupClient(t *testing.T, lookups *atomic.Int32) *netbox.APIClient {
+	t.Helper()
+	return newRawTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/api/extras/tags/") {
+			t.Errorf("unexpected request %s", r.URL.Path)
+			return
+		}
+		lookups.Add(1)
+		slug := r.URL.Query().Get("slug")
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"count": 1,
+			"results": []interface{}{map[string]interface{}{
+				"id": 1, "url": "http://netbox/api/extras/tags/1/", "display": slug, "name": "Tag " + slug, "slug": slug, "tagged_items": 0,
+			}},
+		})
+	})
+}
+
+func TestProviderSettingsFor(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, ProviderSettings{}, ProviderSettingsFor(nil))
+	client := netbox.NewAPIClient(netbox.NewConfiguration())
+	assert.Equal(t, ProviderSettings{}, ProviderSettingsFor(client))
+
+	settings := ProviderSettings{DefaultTags: []string{"managed"}, DefaultCustomFields: map[string]string{"owner": "ops"}}
+	SetProviderSettings(client, settings)
+	assert.Equal(t, settings, ProviderSettingsFor(client))
+}
+
+func TestApplyProviderTags(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	var lookups atomic.Int32
+	client := newTagLookupClient(t, &lookups)
+
+	// Without default tags the request is left alone.
+	var diags diag.Diagnostics
+	request := netbox.NewWritableSiteRequest("site", "site")
+	ApplyProviderTags(ctx, client, request, &diags)
+	require.False(t, diags.HasError(), diags.Errors())
+	assert.False(t, request.HasTags())
+
+	SetProviderSettings(client, ProviderSettings{DefaultTags: []string{"managed", "ops"}})
+
+	ApplyProviderTags(ctx, client, request, &diags)
+	require.False(t, diags.HasError(), diags.Errors())
+	assert.Equal(t, []netbox.NestedTagRequest{{Name: "Tag managed", Slug: "managed"}, {Name: "Tag ops", Slug: "ops"}}, request.GetTags())
+
+	lookups.Store(0)
+	request = netbox.NewWritableSiteRequest("site", "site")
+	request.SetTags([]netbox.NestedTagRequest{{Name: "Ops", Slug: "ops"}})
+	ApplyProviderTags(ctx, client, request, &diags)
+	require.False(t, diags.HasError(), diags.Errors())
+	assert.Equal(t, []netbox.NestedTagRequest{{Name: "Ops", Slug: "ops"}, {Name: "Tag managed", Slug: "managed"}}, request.GetTags())
+	assert.Equal(t, int32(1), lookups.Load(), "only missing default tags are looked up")
+
+	// Ignored tags the object had before an update are kept.
+	SetProviderSettings(client, ProviderSettings{IgnoreTags: []string{"discovered-*"}})
+	updateCtx := ContextWithPriorTags(ctx, []string{"ops", "legacy", "discovered-lldp"})
+	request = netbox.NewWritableSiteRequest("site", "site")
+	request.SetTags([]netbox.NestedTagRequest{{Name: "Ops", Slug: "ops"}})
+	ApplyProviderTags(updateCtx, client, request, &diags)
+	require.False(t, diags.HasError(), diags.Errors())
+	assert.Equal(t, []netbox.NestedTagRequest{{Name: "Ops", Slug: "ops"}, {Name: "Tag discovered-lldp", Slug: "discovered-lldp"}}, request.GetTags())
+
+	request = netbox.NewWritableSiteRequest("site", "site")
+	request.SetTags([]netbox.NestedTagRequest{})
+	ApplyProviderTags(updateCtx, client, request, &diags)
+	require.False(t, diags.HasError(), diags.Errors())
+	assert.Equal(t, []netbox.NestedTagRequest{{Name: "Tag discovered-lldp", Slug: "discovered-lldp"}}, request.GetTags(), "clearing tags keeps the ignored ones")
+
+	request = netbox.NewWritableSiteRequest("site", "site")
+	ApplyProviderTags(updateCtx, client, request, &diags)
+	require.False(t, diags.HasError(), diags.Errors())
+	assert.False(t, request.HasTags(), "requests that leave the tags alone are not changed")
+}
+
+func TestApplyProviderCustomFields(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	var loads atomic.Int32
+	client := newCustomFieldRegistryClient(t, &loads)
+	SetProviderSettings(client, ProviderSettings{DefaultCustomFields: map[string]string{
+		"owner":      "ops",
+		"rack_units": "4",
+		"serial":     "abc",
+	}})
+
+	var diags diag.Diagnostics
+	request := netbox.NewWritableSiteRequest("site", "site")
+	request.SetCustomFields(map[string]interface{}{"owner": "netops"})
+	ApplyProviderCustomFields(ctx, client, "dcim.site", request, &diags)
+	require.False(t, diags.HasError(), diags.Errors())
+	assert.Equal(t, map[string]interface{}{"owner": "netops", "rack_units": int64(4)}, request.GetCustomFields(),
+		"configured fields win and fields of other object types are skipped")
+
+	SetProviderSettings(client, ProviderSettings{DefaultCustomFields: map[string]string{"rack_units": "four"}})
+	request = netbox.NewWritableSiteRequest("site", "site")
+	ApplyProviderCustomFields(ctx, client, "dcim.site", request, &diags)
+	require.True(t, diags.HasError())
+	assert.Equal(t, "Invalid default custom field value", diags.Errors()[0].Summary())
+
+	diags = nil
+	SetProviderSettings(client, ProviderSettings{DefaultCustomFields: map[string]string{"cost_center": "42"}})
+	ApplyProviderCustomFields(ctx, client, "dcim.site", request, &diags)
+	require.True(t, diags.HasError())
+	assert.Equal(t, "Unknown default custom field", diags.Errors()[0].Summary())
+}
+
+func TestPopulateTagsSlug_DefaultTags(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	client := netbox.NewAPIClient(netbox.NewConfiguration())
+	SetProviderSettings(client, ProviderSettings{DefaultTags: []string{"managed"}})
+	tags := []netbox.NestedTag{{Name: "Managed", Slug: "managed"}, {Name: "Ops", Slug: "ops"}}
+	onlyDefault := []netbox.NestedTag{{Name: "Managed", Slug: "managed"}}
+
+	assert.Equal(t, TagsSlugToSet(ctx, []string{"ops"}), PopulateTagsSlugFilteredToOwned(ctx, client, true, tags, TagsSlugToSet(ctx, []string{"ops"})))
+	assert.Equal(t, TagsSlugToSet(ctx, []string{"managed", "ops"}), PopulateTagsSlugFilteredToOwned(ctx, client, true, tags, TagsSlugToSet(ctx, []string{"managed", "ops"})),
+		"default tags that are configured stay in tags")
+
+	assert.Equal(t, TagsSlugToSet(ctx, []string{"ops"}), PopulateTagsSlugFromAPI(ctx, client, true, tags, types.SetNull(types.StringType)))
+	assert.True(t, PopulateTagsSlugFromAPI(ctx, client, true, onlyDefault, types.SetNull(types.StringType)).IsNull())
+	assert.Equal(t, TagsSlugToSet(ctx, []string{"managed", "ops"}), PopulateTagsSlugFromAPI(ctx, nil, true, tags, types.SetNull(types.StringType)),
+		"a nil client reads every tag")
+}
+
+func TestProviderSettingsIgnores(t *testing.T) {
+	t.Parallel()
+
+	settings := ProviderSettings{IgnoreTags: []string{"discovered-*", "legacy"}, IgnoreCustomFields: []string{"sync_*"}}
+	assert.True(t, settings.IgnoresTag("discovered-lldp"))
+	assert.True(t, settings.IgnoresTag("legacy"))
+	assert.False(t, settings.IgnoresTag("legacy-site"))
+	assert.False(t, settings.IgnoresTag("managed"))
+	assert.True(t, settings.IgnoresCustomField("sync_time"))
+	assert.False(t, settings.IgnoresCustomField("owner"))
+	assert.False(t, ProviderSettings{}.IgnoresTag("managed"))
+}
+
+func TestApplyProviderCustomFields_Ignored(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	client := netbox.NewAPIClient(netbox.NewConfiguration())
+	SetProviderSettings(client, ProviderSettings{IgnoreCustomFields: []string{"sync_*"}})
+
+	var diags diag.Diagnostics
+	request := netbox.NewWritableSiteRequest("site", "site")
+	request.SetCustomFields(map[string]interface{}{"owner": "ops", "sync_time": nil})
+	ApplyProviderCustomFields(ctx, client, "dcim.site", request, &diags)
+	require.False(t, diags.HasError(), diags.Errors())
+	assert.Equal(t, map[string]interface{}{"owner": "ops"}, request.GetCustomFields(), "ignored fields are left for NetBox to keep")
+}
+
+func TestPopulateFromAPI_Ignored(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	client := netbox.NewAPIClient(netbox.NewConfiguration())
+	SetProviderSettings(client, ProviderSettings{IgnoreTags: []string{"discovered-*"}, IgnoreCustomFields: []string{"sync_time"}})
+	tags := []netbox.NestedTag{{Name: "Ops", Slug: "ops"}, {Name: "LLDP", Slug: "discovered-lldp"}}
+
+	assert.Equal(t, TagsSlugToSet(ctx, []string{"ops"}), PopulateTagsSlugFromAPI(ctx, client, true, tags, types.SetNull(types.StringType)))
+	assert.Equal(t, TagsSlugToSet(ctx, []string{"ops"}), PopulateTagsSlugFilteredToOwned(ctx, client, true, tags, TagsSlugToSet(ctx, []string{"ops"})))
+	assert.Equal(t, TagsSlugToSet(ctx, []string{"ops", "discovered-lldp"}), TagsAllFromAPI(ctx, tags), "tags_all lists ignored tags, so updates can keep them")
+
+	var diags diag.Diagnostics
+	customFields := map[string]interface{}{"owner": "ops", "sync_time": "2024-01-01"}
+	got := PopulateCustomFieldsFromAPI(ctx, client, true, customFields, customFieldsSet(t, customField("owner", "text", "ops")), &diags)
+	require.False(t, diags.HasError(), diags.Errors())
+	assert.Equal(t, customFieldsSet(t, customField("owner", "text", "ops")), got)
+
+	all := CustomFieldsAllFromAPI(ctx, client, types.MapNull(types.StringType), customFields, &diags)
+	require.False(t, diags.HasError(), diags.Errors())
+	assert.Equal(t, stringMap(t, map[string]string{"owner": "ops"}), all)
+}
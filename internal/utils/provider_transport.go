@@ -0,0 +1,159 @@
+package utils
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	requestIDHeader = "X-Request-ID"
+	branchHeader    = "X-NetBox-Branch"
+)
+
+// graphQLPath is the path of the GraphQL API under the server URL, which
+// only reads.
+const graphQLPath = "/graphql/"
+
+// ErrReadOnly is returned for the requests that a read-only provider rejects.
+var ErrReadOnly = errors.New("the provider is read-only: only GET requests and GraphQL queries are sent to NetBox")
+
+// branchingAPIPath is the path of the API of the netbox-branching plugin,
+// whose requests manage branches and are always made in main.
+const branchingAPIPath = "/api/plugins/branching/"
+
+// ProviderTransport is the HTTP transport of the provider's API client. It
+// applies the provider settings that concern every request, whichever
+// resource sends it.
+type ProviderTransport struct {
+	// Base sends the requests; http.DefaultTransport when nil.
+	Base http.RoundTripper
+	// BasePath is the path of the server URL, e.g. "/netbox" for
+	// https://example.com/netbox, under which the APIs are served.
+	BasePath string
+	Settings ProviderSettings
+}
+
+// RoundTrip implements http.RoundTripper. Every request carries the
+// provider's request ID in the X-Request-ID header, and the request IDs
+// NetBox returns for successful writes are recorded in the settings'
+// WriteRequests.
+//
+// Requests are made in the branch set on their context by ContextWithBranch,
+// or else in the provider's branch, by sending its schema ID in the
+// X-NetBox-Branch header.
+//
+// A read-only provider rejects every request but reads before sending it.
+func (t *ProviderTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.Settings.ReadOnly && !t.readRequest(req) {
+		return nil, fmt.Errorf("%s %s: %w", req.Method, req.URL.Path, ErrReadOnly)
+	}
+	req = req.Clone(req.Context())
+	if t.Settings.RequestID != "" {
+		req.Header.Set(requestIDHeader, t.Settings.RequestID)
+	}
+	if branch := t.branch(req); branch != "" {
+		req.Header.Set(branchHeader, branch)
+	}
+
+	resp, err := t.base().RoundTrip(req)
+	if err == nil && t.Settings.WriteRequests != nil && req.Method != http.MethodGet && resp.StatusCode < http.StatusMultipleChoices {
+		t.Settings.WriteRequests.record(resp.Header.Get(requestIDHeader))
+	}
+	return resp, err
+}
+
+// readRequest reports whether req only reads: a GET, HEAD or OPTIONS
+// request, or a GraphQL query, since the GraphQL API of NetBox has no
+// mutations. Only the GraphQL API of NetBox itself qualifies, not the
+// GraphQL endpoints plugins may add under other paths.
+func (t *ProviderTransport) readRequest(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	case http.MethodPost:
+		return req.URL.Path == strings.TrimRight(t.BasePath, "/")+graphQLPath
+	}
+	return false
+}
+
+// branch returns the schema ID of the branch req is made in.
+func (t *ProviderTransport) branch(req *http.Request) string {
+	if strings.Contains(req.URL.Path, branchingAPIPath) {
+		return ""
+	}
+	if branch, ok := req.Context().Value(branchContextKey{}).(string); ok && branch != "" {
+		return branch
+	}
+	return t.Settings.Branch
+}
+
+func (t *ProviderTransport) base() http.RoundTripper {
+	if t.Base != nil {
+		return t.Base
+	}
+	return http.DefaultTransport
+}
+
+type branchContextKey struct{}
+
+// ContextWithBranch returns a copy of ctx in which the requests of the
+// provider are made in the branch with schemaID instead of the provider's
+// branch. An empty schemaID leaves the provider's branch in effect.
+func ContextWithBranch(ctx context.Context, schemaID string) context.Context {
+	return context.WithValue(ctx, branchContextKey{}, schemaID)
+}
+
+// WriteRequestLog records the IDs NetBox assigns to the write requests of a
+// provider, which NetBox stores in the change records of the objects they
+// change.
+type WriteRequestLog struct {
+	mu    sync.Mutex
+	since time.Time
+	ids   []string
+}
+
+// NewWriteRequestLog returns an empty log started now.
+func NewWriteRequestLog() *WriteRequestLog {
+	return &WriteRequestLog{since: time.Now()}
+}
+
+func (l *WriteRequestLog) record(id string) {
+	if id == "" {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !slices.Contains(l.ids, id) {
+		l.ids = append(l.ids, id)
+	}
+}
+
+// IDs returns the recorded request IDs.
+func (l *WriteRequestLog) IDs() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return slices.Clone(l.ids)
+}
+
+// Since returns when the log was started.
+func (l *WriteRequestLog) Since() time.Time {
+	return l.since
+}
+
+// GeneratedRequestID returns a random request ID, the same for every provider
+// configuration of the process, so that all requests of a Terraform run share
+// it.
+var GeneratedRequestID = sync.OnceValue(func() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40 // version 4
+	b[8] = b[8]&0x3f | 0x80 // RFC 4122 variant
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+})
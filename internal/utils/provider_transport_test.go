@@ -0,0 +1,138 @@
+package utils
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProviderTransport_RequestID(t *testing.T) {
+	t.Parallel()
+
+	var received []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = append(received, r.Header.Get("X-Request-ID"))
+		w.Header().Set("X-Request-ID", "netbox-"+r.Method)
+		if r.URL.Path == "/api/dcim/sites/2/" {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	log := NewWriteRequestLog()
+	client := &http.Client{Transport: &ProviderTransport{Settings: ProviderSettings{RequestID: "run-1", WriteRequests: log}}}
+	send := func(method, path string) {
+		t.Helper()
+		req, err := http.NewRequest(method, server.URL+path, nil)
+		require.NoError(t, err)
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+
+	send(http.MethodGet, "/api/dcim/sites/1/")
+	send(http.MethodPatch, "/api/dcim/sites/1/")
+	send(http.MethodPatch, "/api/dcim/sites/1/")
+	send(http.MethodDelete, "/api/dcim/sites/2/")
+	send(http.MethodDelete, "/api/dcim/sites/1/")
+
+	assert.Equal(t, []string{"run-1", "run-1", "run-1", "run-1", "run-1"}, received)
+	assert.Equal(t, []string{"netbox-PATCH", "netbox-DELETE"}, log.IDs(), "only successful writes are recorded, once each")
+	assert.False(t, log.Since().IsZero())
+}
+
+func TestGeneratedRequestID(t *testing.T) {
+	t.Parallel()
+
+	id := GeneratedRequestID()
+	assert.Regexp(t, `^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`, id)
+	assert.Equal(t, id, GeneratedRequestID(), "the ID is stable for the run")
+}
+
+func TestProviderTransport_Branch(t *testing.T) {
+	t.Parallel()
+
+	var received []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = append(received, r.Header.Get("X-NetBox-Branch"))
+	}))
+	t.Cleanup(server.Close)
+
+	client := &http.Client{Transport: &ProviderTransport{Settings: ProviderSettings{Branch: "provider"}}}
+	send := func(ctx context.Context, path string) {
+		t.Helper()
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+path, nil)
+		require.NoError(t, err)
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+
+	send(context.Background(), "/api/dcim/sites/")
+	send(ContextWithBranch(context.Background(), "resource"), "/api/dcim/sites/")
+	send(ContextWithBranch(context.Background(), ""), "/api/dcim/sites/")
+	send(ContextWithBranch(context.Background(), "resource"), "/api/plugins/branching/branches/")
+
+	assert.Equal(t, []string{"provider", "resource", "provider", ""}, received, "branches are managed in main")
+}
+
+func TestProviderTransport_ReadOnly(t *testing.T) {
+	t.Parallel()
+
+	var received []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = append(received, r.Method+" "+r.URL.Path)
+	}))
+	t.Cleanup(server.Close)
+
+	client := &http.Client{Transport: &ProviderTransport{Settings: ProviderSettings{ReadOnly: true}}}
+	send := func(method, path string) error {
+		t.Helper()
+		req, err := http.NewRequest(method, server.URL+path, strings.NewReader(`{}`))
+		require.NoError(t, err)
+		resp, err := client.Do(req)
+		if err == nil {
+			resp.Body.Close()
+		}
+		return err
+	}
+
+	require.NoError(t, send(http.MethodGet, "/api/dcim/sites/"))
+	require.NoError(t, send(http.MethodPost, "/graphql/"))
+	for _, method := range []string{http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete} {
+		err := send(method, "/api/dcim/sites/1/")
+		require.ErrorIs(t, err, ErrReadOnly, method)
+	}
+	require.ErrorIs(t, send(http.MethodPost, "/api/plugins/example/graphql/"), ErrReadOnly, "plugin GraphQL endpoints may write")
+	assert.Equal(t, []string{"GET /api/dcim/sites/", "POST /graphql/"}, received, "writes are never sent")
+}
+
+func TestProviderTransport_ReadOnlyBasePath(t *testing.T) {
+	t.Parallel()
+
+	var received []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = append(received, r.Method+" "+r.URL.Path)
+	}))
+	t.Cleanup(server.Close)
+
+	client := &http.Client{Transport: &ProviderTransport{BasePath: "/netbox/", Settings: ProviderSettings{ReadOnly: true}}}
+	send := func(path string) error {
+		t.Helper()
+		resp, err := client.Post(server.URL+path, "application/json", strings.NewReader(`{}`))
+		if err == nil {
+			resp.Body.Close()
+		}
+		return err
+	}
+
+	require.NoError(t, send("/netbox/graphql/"))
+	require.ErrorIs(t, send("/graphql/"), ErrReadOnly)
+	require.ErrorIs(t, send("/netbox/api/plugins/example/graphql/"), ErrReadOnly)
+	assert.Equal(t, []string{"POST /netbox/graphql/"}, received)
+}
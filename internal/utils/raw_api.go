@@ -0,0 +1,185 @@
+// Package utils provides utility functions for working with Netbox provider data structures.
+
+package utils
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/bab3l/go-netbox"
+)
+
+// RawAPIPageLimit is the page size used when paginating raw list requests.
+const RawAPIPageLimit = 100
+
+// NormalizeAPIPath trims an API path such as "/api/dcim/sites/" or "dcim/sites"
+// down to its canonical "dcim/sites" form.
+func NormalizeAPIPath(apiPath string) string {
+	p := strings.Trim(strings.TrimSpace(apiPath), "/")
+	p = strings.TrimPrefix(p, "api/")
+	return strings.Trim(p, "/")
+}
+
+// RawAPIURL builds the absolute URL of a NetBox REST API path using the server
+// configured on the go-netbox client.
+func RawAPIURL(client *netbox.APIClient, apiPath string, query url.Values) (string, error) {
+	return rawURL(client, "/api/"+NormalizeAPIPath(apiPath)+"/", query)
+}
+
+func rawURL(client *netbox.APIClient, urlPath string, query url.Values) (string, error) {
+	if client == nil || client.GetConfig() == nil {
+		return "", fmt.Errorf("netbox client is not configured")
+	}
+	base, err := client.GetConfig().ServerURL(0, nil)
+	if err != nil {
+		return "", err
+	}
+	u := strings.TrimRight(base, "/") + urlPath
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	return u, nil
+}
+
+// DoRawAPIRequest performs a JSON request against an arbitrary NetBox REST API path
+// (relative to /api/), reusing the headers and HTTP client configured on the
+// go-netbox client. It is used for endpoints that the generated client does not
+// cover, such as plugin models, or where filters must be forwarded verbatim.
+//
+// On a non-2xx response the returned error mirrors the go-netbox error format and
+// the response body remains readable, so callers can use FormatAPIError as usual.
+func DoRawAPIRequest(ctx context.Context, client *netbox.APIClient, method, apiPath string, query url.Values, body interface{}) ([]byte, *http.Response, error) {
+	u, err := RawAPIURL(client, apiPath, query)
+	if err != nil {
+		return nil, nil, err
+	}
+	return doRawRequest(ctx, client, method, u, body)
+}
+
+func doRawRequest(ctx context.Context, client *netbox.APIClient, method, requestURL string, body interface{}) ([]byte, *http.Response, error) {
+	cfg := client.GetConfig()
+
+	var reader io.Reader
+	if body != nil {
+		var payload []byte
+		switch b := body.(type) {
+		case []byte:
+			payload = b
+		case json.RawMessage:
+			payload = b
+		default:
+			encoded, err := json.Marshal(body)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to encode request body: %w", err)
+			}
+			payload = encoded
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, requestURL, reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	for k, v := range cfg.DefaultHeader {
+		req.Header.Set(k, v)
+	}
+	if cfg.UserAgent != "" {
+		req.Header.Set("User-Agent", cfg.UserAgent)
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	httpClient := cfg.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	httpResp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, httpResp, err
+	}
+
+	respBody, err := io.ReadAll(httpResp.Body)
+	_ = httpResp.Body.Close()
+	httpResp.Body = io.NopCloser(bytes.NewReader(respBody))
+	if err != nil {
+		return nil, httpResp, err
+	}
+
+	if httpResp.StatusCode >= 300 {
+		return respBody, httpResp, fmt.Errorf("%s", httpResp.Status)
+	}
+	return respBody, httpResp, nil
+}
+
+// rawPage is the envelope NetBox uses for paginated list responses.
+type rawPage struct {
+	Count   int64             `json:"count"`
+	Results []json.RawMessage `json:"results"`
+}
+
+// ListRawAPIObjects pages through a NetBox list endpoint using limit/offset and
+// returns the raw JSON of every result. Query values are forwarded unchanged,
+// except for limit and offset which are controlled by the pagination loop.
+func ListRawAPIObjects(ctx context.Context, client *netbox.APIClient, apiPath string, query url.Values) ([]json.RawMessage, *http.Response, error) {
+	params := url.Values{}
+	for k, v := range query {
+		params[k] = append([]string(nil), v...)
+	}
+	params.Set("limit", strconv.Itoa(RawAPIPageLimit))
+
+	var (
+		offset   int64
+		results  []json.RawMessage
+		httpResp *http.Response
+	)
+	for {
+		params.Set("offset", strconv.FormatInt(offset, 10))
+		body, resp, err := DoRawAPIRequest(ctx, client, http.MethodGet, apiPath, params, nil)
+		httpResp = resp
+		if err != nil {
+			return nil, httpResp, err
+		}
+
+		var page rawPage
+		if err := json.Unmarshal(body, &page); err != nil {
+			return nil, httpResp, fmt.Errorf("failed to decode list response from %s: %w", NormalizeAPIPath(apiPath), err)
+		}
+		if len(page.Results) == 0 {
+			break
+		}
+		if len(page.Results) > RawAPIPageLimit {
+			return nil, httpResp, fmt.Errorf("expected page size to be between 0 and %d, got %d", RawAPIPageLimit, len(page.Results))
+		}
+
+		results = append(results, page.Results...)
+		offset += int64(len(page.Results))
+		if offset >= page.Count {
+			break
+		}
+	}
+
+	return results, httpResp, nil
+}
+
+// QueryFiltersToValues converts expanded query filters into URL query values.
+// Each value of a filter is sent as a repeated query parameter, which NetBox
+// treats as an OR within that filter.
+func QueryFiltersToValues(filters map[string][]string) url.Values {
+	values := url.Values{}
+	for name, vals := range filters {
+		for _, v := range vals {
+			values.Add(name, v)
+		}
+	}
+	return values
+}
@@ -0,0 +1,113 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/bab3l/go-netbox"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRawTestClient(t *testing.T, handler http.HandlerFunc) *netbox.APIClient {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	cfg := netbox.NewConfiguration()
+	cfg.Servers = netbox.ServerConfigurations{{URL: server.URL + "/"}}
+	cfg.DefaultHeader = map[string]string{"Authorization": "Token abc"}
+	return netbox.NewAPIClient(cfg)
+}
+
+func TestNormalizeAPIPath(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]string{
+		"dcim/sites":              "dcim/sites",
+		"/api/dcim/sites/":        "dcim/sites",
+		"api/plugins/bgp/session": "plugins/bgp/session",
+		" /dcim/sites/1/ ":        "dcim/sites/1",
+	}
+	for in, want := range cases {
+		assert.Equal(t, want, NormalizeAPIPath(in), in)
+	}
+}
+
+func TestQueryFiltersToValues(t *testing.T) {
+	t.Parallel()
+
+	values := QueryFiltersToValues(map[string][]string{
+		"site":   {"a", "b"},
+		"status": {"active"},
+	})
+	assert.Equal(t, []string{"a", "b"}, values["site"])
+	assert.Equal(t, []string{"active"}, values["status"])
+}
+
+func TestDoRawAPIRequest(t *testing.T) {
+	t.Parallel()
+
+	client := newRawTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPatch, r.Method)
+		assert.Equal(t, "/api/plugins/dns/zones/5/", r.URL.Path)
+		assert.Equal(t, "Token abc", r.Header.Get("Authorization"))
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		var body map[string]interface{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "example.com", body["name"])
+		_, _ = w.Write([]byte(`{"id": 5, "name": "example.com"}`))
+	})
+
+	body, httpResp, err := DoRawAPIRequest(context.Background(), client, http.MethodPatch, "/api/plugins/dns/zones/5/", nil, map[string]interface{}{"name": "example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, httpResp.StatusCode)
+	assert.JSONEq(t, `{"id": 5, "name": "example.com"}`, string(body))
+}
+
+func TestDoRawAPIRequest_ErrorKeepsBodyReadable(t *testing.T) {
+	t.Parallel()
+
+	client := newRawTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"name": ["This field is required."]}`))
+	})
+
+	_, httpResp, err := DoRawAPIRequest(context.Background(), client, http.MethodPost, "dcim/sites", nil, map[string]interface{}{})
+	require.Error(t, err)
+	assert.Contains(t, FormatAPIError("create site", err, httpResp), "This field is required.")
+
+	// The body can still be read after formatting the error.
+	_, readErr := io.ReadAll(httpResp.Body)
+	assert.NoError(t, readErr)
+}
+
+func TestListRawAPIObjects_Paginates(t *testing.T) {
+	t.Parallel()
+
+	const total = 230
+	calls := 0
+	client := newRawTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		assert.Equal(t, []string{"a", "b"}, r.URL.Query()["tag"])
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		results := []map[string]int{}
+		for i := offset; i < total && i < offset+limit; i++ {
+			results = append(results, map[string]int{"id": i + 1})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"count": total, "results": results})
+	})
+
+	results, _, err := ListRawAPIObjects(context.Background(), client, "dcim/devices", map[string][]string{"tag": {"a", "b"}, "limit": {"5"}})
+	require.NoError(t, err)
+	assert.Len(t, results, total)
+	assert.Equal(t, 3, calls)
+	assert.JSONEq(t, `{"id": 230}`, string(results[total-1]))
+}
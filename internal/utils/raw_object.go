@@ -0,0 +1,179 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+)
+
+// DecodeJSONObject decodes a JSON object, keeping numbers as json.Number so they
+// can be compared without losing precision.
+func DecodeJSONObject(raw []byte) (map[string]interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+
+	var decoded interface{}
+	if err := decoder.Decode(&decoded); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+	obj, ok := decoded.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expected a JSON object, got %T", decoded)
+	}
+	return obj, nil
+}
+
+// ReconcileOwnedJSON compares the keys the user configured (desired) against the
+// object read back from NetBox (actual). Only keys present in desired are
+// considered, so server-populated fields never cause drift.
+//
+// For every owned key whose value still matches, the desired value is kept
+// as-is. For keys that drifted, the returned object holds the API value,
+// projected to the shape the user configured (e.g. a nested object is reduced
+// to its `id`, a choice to its `value`). The boolean reports whether any key drifted.
+func ReconcileOwnedJSON(desired, actual map[string]interface{}) (map[string]interface{}, bool) {
+	result := make(map[string]interface{}, len(desired))
+	drifted := false
+	for key, want := range desired {
+		got, ok := actual[key]
+		if ok && JSONValueMatches(want, got) {
+			result[key] = want
+			continue
+		}
+		drifted = true
+		if ok {
+			result[key] = projectJSONValue(want, got)
+		} else {
+			result[key] = nil
+		}
+	}
+	return result, drifted
+}
+
+// JSONValueMatches reports whether the API value actual satisfies the configured
+// value desired. Objects are compared as subsets, nested NetBox objects match
+// their `id`, and choice fields match their `value`.
+func JSONValueMatches(desired, actual interface{}) bool {
+	switch want := desired.(type) {
+	case nil:
+		return actual == nil
+	case map[string]interface{}:
+		got, ok := actual.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for key, value := range want {
+			if !JSONValueMatches(value, got[key]) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		got, ok := actual.([]interface{})
+		if !ok || len(got) != len(want) {
+			return false
+		}
+		for i := range want {
+			if !JSONValueMatches(want[i], got[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		if obj, ok := actual.(map[string]interface{}); ok {
+			if id, ok := obj["id"]; ok && scalarEqual(want, id) {
+				return true
+			}
+			if value, ok := obj["value"]; ok && scalarEqual(want, value) {
+				return true
+			}
+			return false
+		}
+		return scalarEqual(want, actual)
+	}
+}
+
+// projectJSONValue reduces an API value to the shape of the configured value.
+func projectJSONValue(desired, actual interface{}) interface{} {
+	switch want := desired.(type) {
+	case map[string]interface{}:
+		got, ok := actual.(map[string]interface{})
+		if !ok {
+			return actual
+		}
+		projected := make(map[string]interface{}, len(want))
+		for key, value := range want {
+			projected[key] = projectJSONValue(value, got[key])
+		}
+		return projected
+	case []interface{}:
+		got, ok := actual.([]interface{})
+		if !ok {
+			return actual
+		}
+		projected := make([]interface{}, len(got))
+		for i := range got {
+			if i < len(want) {
+				projected[i] = projectJSONValue(want[i], got[i])
+			} else if len(want) > 0 {
+				projected[i] = projectJSONValue(want[0], got[i])
+			} else {
+				projected[i] = got[i]
+			}
+		}
+		return projected
+	case nil:
+		return actual
+	default:
+		if obj, ok := actual.(map[string]interface{}); ok {
+			if id, ok := obj["id"]; ok {
+				return id
+			}
+			if value, ok := obj["value"]; ok {
+				return value
+			}
+		}
+		return actual
+	}
+}
+
+// scalarEqual compares two JSON scalars. Numbers are compared numerically and
+// a number matches its string representation (e.g. 5 and "5").
+func scalarEqual(a, b interface{}) bool {
+	_, aString := a.(string)
+	_, bString := b.(string)
+	if aString && bString {
+		return a == b
+	}
+	if af, ok := jsonNumber(a); ok {
+		if bf, ok := jsonNumber(b); ok {
+			return af.Cmp(bf) == 0
+		}
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func jsonNumber(v interface{}) (*big.Float, bool) {
+	var text string
+	switch n := v.(type) {
+	case json.Number:
+		text = n.String()
+	case string:
+		text = n
+	case float64:
+		return big.NewFloat(n), true
+	case int:
+		return new(big.Float).SetInt64(int64(n)), true
+	case int64:
+		return new(big.Float).SetInt64(n), true
+	default:
+		return nil, false
+	}
+	f, _, err := big.ParseFloat(text, 10, 512, big.ToNearestEven)
+	if err != nil {
+		return nil, false
+	}
+	return f, true
+}
@@ -0,0 +1,65 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReconcileOwnedJSON_NoDrift(t *testing.T) {
+	t.Parallel()
+
+	desired, err := DecodeJSONObject([]byte(`{"name": "zone", "view": 3, "status": "active", "tags": [1], "soa": {"ttl": 60}}`))
+	require.NoError(t, err)
+	actual, err := DecodeJSONObject([]byte(`{
+		"id": 9, "serial": 4,
+		"name": "zone",
+		"view": {"id": 3, "name": "default"},
+		"status": {"value": "active", "label": "Active"},
+		"tags": [{"id": 1, "slug": "a"}],
+		"soa": {"ttl": 60.0, "mname": "ns1"}
+	}`))
+	require.NoError(t, err)
+
+	reconciled, drifted := ReconcileOwnedJSON(desired, actual)
+	assert.False(t, drifted)
+	assert.Equal(t, desired, reconciled)
+}
+
+func TestReconcileOwnedJSON_Drift(t *testing.T) {
+	t.Parallel()
+
+	desired, err := DecodeJSONObject([]byte(`{"name": "zone", "view": 3, "status": "active", "description": "x"}`))
+	require.NoError(t, err)
+	actual, err := DecodeJSONObject([]byte(`{
+		"name": "zone",
+		"view": {"id": 4, "name": "other"},
+		"status": {"value": "deprecated", "label": "Deprecated"}
+	}`))
+	require.NoError(t, err)
+
+	reconciled, drifted := ReconcileOwnedJSON(desired, actual)
+	assert.True(t, drifted)
+	assert.Equal(t, "zone", reconciled["name"])
+	assert.Equal(t, "4", reconciled["view"].(interface{ String() string }).String())
+	assert.Equal(t, "deprecated", reconciled["status"])
+	assert.Nil(t, reconciled["description"])
+}
+
+func TestJSONValueMatches_Scalars(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, JSONValueMatches("5", float64(5)))
+	assert.False(t, JSONValueMatches("5.0", "5"))
+	assert.False(t, JSONValueMatches([]interface{}{"a"}, []interface{}{"a", "b"}))
+	assert.True(t, JSONValueMatches(nil, nil))
+	assert.False(t, JSONValueMatches(nil, "x"))
+}
+
+func TestDecodeJSONObject_RejectsNonObjects(t *testing.T) {
+	t.Parallel()
+
+	_, err := DecodeJSONObject([]byte(`[1]`))
+	assert.Error(t, err)
+}
@@ -44,6 +44,13 @@ type CustomFieldsSetter interface {
 	SetCustomFields(v map[string]interface{})
 }
 
+// CustomFieldsAccessor is implemented by request types whose custom fields can
+// also be read back, so further values can be merged into them.
+type CustomFieldsAccessor interface {
+	CustomFieldsSetter
+	GetCustomFields() map[string]interface{}
+}
+
 // CommonDescriptiveSetter combines Description and Comments setters.
 type CommonDescriptiveSetter interface {
 	DescriptionSetter
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"math"
 	"net/http"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -213,6 +214,18 @@ func BoolFromAPI(hasValue bool, getValue func() bool, current types.Bool) types.
 	return current
 }
 
+// EnumStrings converts the allowed values of a go-netbox enum into strings for
+// use with stringvalidator.OneOf, dropping the empty "unset" value.
+func EnumStrings[T ~string](values []T) []string {
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		if value != "" {
+			result = append(result, string(value))
+		}
+	}
+	return result
+}
+
 // =====================================================
 // REFERENCE FIELD HELPERS
 // =====================================================
@@ -524,7 +537,9 @@ func PopulateTagsFilteredToOwned(ctx context.Context, hasTags bool, tags []netbo
 // If tags are omitted (null/unknown), keep state null even if API has tags.
 // If tags are explicitly set to empty, keep an empty set.
 // If tags are set, mirror API tag slugs (or empty set if none).
-func PopulateTagsSlugFilteredToOwned(ctx context.Context, hasTags bool, tags []netbox.NestedTag, planTags types.Set) types.Set {
+// The provider's default and ignored tags of client are left out unless they are
+// configured; default tags are reported in tags_all instead.
+func PopulateTagsSlugFilteredToOwned(ctx context.Context, client *netbox.APIClient, hasTags bool, tags []netbox.NestedTag, planTags types.Set) types.Set {
 	if planTags.IsNull() || planTags.IsUnknown() {
 		return types.SetNull(types.StringType)
 	}
@@ -535,9 +550,9 @@ func PopulateTagsSlugFilteredToOwned(ctx context.Context, hasTags bool, tags []n
 	}
 
 	if hasTags && len(tags) > 0 {
-		tagSlugs := make([]string, 0, len(tags))
-		for _, tag := range tags {
-			tagSlugs = append(tagSlugs, tag.Slug)
+		tagSlugs := ownedTagSlugs(client, tags, SetToStringSlice(ctx, planTags))
+		if len(tagSlugs) == 0 {
+			return types.SetValueMust(types.StringType, []attr.Value{})
 		}
 		return TagsSlugToSet(ctx, tagSlugs)
 	}
@@ -547,16 +562,17 @@ func PopulateTagsSlugFilteredToOwned(ctx context.Context, hasTags bool, tags []n
 }
 
 // PopulateTagsSlugFromAPI converts API tags to a Terraform string set of slugs.
-// Preserves explicit empty sets in state (tags = []) versus null.
-func PopulateTagsSlugFromAPI(ctx context.Context, hasTags bool, tags []netbox.NestedTag, stateTags types.Set) types.Set {
+// Preserves explicit empty sets in state (tags = []) versus null. The
+// provider's default and ignored tags of client are left out unless they are
+// in stateTags; data sources pass a nil client to read every tag.
+func PopulateTagsSlugFromAPI(ctx context.Context, client *netbox.APIClient, hasTags bool, tags []netbox.NestedTag, stateTags types.Set) types.Set {
 	wasExplicitlyEmpty := !stateTags.IsNull() && !stateTags.IsUnknown() && len(stateTags.Elements()) == 0
 
 	if hasTags && len(tags) > 0 {
-		tagSlugs := make([]string, 0, len(tags))
-		for _, tag := range tags {
-			tagSlugs = append(tagSlugs, tag.Slug)
+		tagSlugs := ownedTagSlugs(client, tags, SetToStringSlice(ctx, stateTags))
+		if len(tagSlugs) > 0 {
+			return TagsSlugToSet(ctx, tagSlugs)
 		}
-		return TagsSlugToSet(ctx, tagSlugs)
 	}
 
 	if wasExplicitlyEmpty {
@@ -566,6 +582,20 @@ func PopulateTagsSlugFromAPI(ctx context.Context, hasTags bool, tags []netbox.Ne
 	return types.SetNull(types.StringType)
 }
 
+// ownedTagSlugs returns the slugs of tags, leaving out the provider's default
+// and ignored tags of client that are not in configured.
+func ownedTagSlugs(client *netbox.APIClient, tags []netbox.NestedTag, configured []string) []string {
+	settings := ProviderSettingsFor(client)
+	tagSlugs := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		if (slices.Contains(settings.DefaultTags, tag.Slug) || settings.IgnoresTag(tag.Slug)) && !slices.Contains(configured, tag.Slug) {
+			continue
+		}
+		tagSlugs = append(tagSlugs, tag.Slug)
+	}
+	return tagSlugs
+}
+
 // PopulateTagsSlugListFromAPI converts API tags to a Terraform string list of slugs.
 // Used by data sources that model tags as types.List.
 func PopulateTagsSlugListFromAPI(ctx context.Context, hasTags bool, tags []netbox.NestedTag, diags *diag.Diagnostics) types.List {
@@ -629,8 +659,12 @@ func PopulateCustomFieldsFromMap(ctx context.Context, hasCustomFields bool, cust
 //
 // Example:
 //
-//	data.CustomFields = utils.PopulateCustomFieldsFromAPI(ctx, device.HasCustomFields(), device.GetCustomFields(), data.CustomFields, diags)
-func PopulateCustomFieldsFromAPI(ctx context.Context, hasCustomFields bool, customFieldsMap map[string]interface{}, stateCustomFields types.Set, diags *diag.Diagnostics) types.Set {
+//	data.CustomFields = utils.PopulateCustomFieldsFromAPI(ctx, r.client, device.HasCustomFields(), device.GetCustomFields(), data.CustomFields, diags)
+//
+// Custom fields ignored by the provider settings of client are never read.
+func PopulateCustomFieldsFromAPI(ctx context.Context, client *netbox.APIClient, hasCustomFields bool, customFieldsMap map[string]interface{}, stateCustomFields types.Set, diags *diag.Diagnostics) types.Set {
+	customFieldsMap = withoutIgnoredCustomFields(client, customFieldsMap)
+
 	// Determine if we should preserve empty set (config explicitly set it) or use null (config didn't specify)
 	// If state was previously an empty set, we should preserve it as empty set (not null)
 	// to maintain consistency when config has "custom_fields = []"
@@ -682,6 +716,22 @@ func PopulateCustomFieldsFromAPI(ctx context.Context, hasCustomFields bool, cust
 	return types.SetNull(GetCustomFieldsAttributeType().ElemType)
 }
 
+// withoutIgnoredCustomFields returns customFields without the custom fields
+// ignored by the provider settings of client.
+func withoutIgnoredCustomFields(client *netbox.APIClient, customFields map[string]interface{}) map[string]interface{} {
+	settings := ProviderSettingsFor(client)
+	if len(settings.IgnoreCustomFields) == 0 {
+		return customFields
+	}
+	kept := make(map[string]interface{}, len(customFields))
+	for name, value := range customFields {
+		if !settings.IgnoresCustomField(name) {
+			kept[name] = value
+		}
+	}
+	return kept
+}
+
 // SetToStringSlice converts a Terraform string set into a []string.
 // Returns an empty slice for null/unknown values.
 func SetToStringSlice(ctx context.Context, set types.Set) []string {
@@ -918,6 +918,15 @@ func TestEnumFromAPIWithDefault(t *testing.T) {
 	}
 }
 
+func TestEnumStrings(t *testing.T) {
+	t.Parallel()
+
+	got := EnumStrings([]TestEnum{TestEnumActive, "", TestEnumInactive})
+	if len(got) != 2 || got[0] != "active" || got[1] != "inactive" {
+		t.Errorf("EnumStrings() = %v, want [active inactive]", got)
+	}
+}
+
 // =====================================================
 // REQUEST BUILDING HELPER TESTS
 // =====================================================
@@ -12,9 +12,12 @@ import (
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // CustomFieldValueValidator validates that a custom field value is appropriate for its type.
+// It only knows the type it was created with; resources validate values against the
+// custom field definitions in NetBox at plan time with utils.ValidateCustomFieldsPlan.
 type CustomFieldValueValidator struct {
 	customFieldType string
 }
@@ -36,8 +39,6 @@ func (v CustomFieldValueValidator) ValidateString(ctx context.Context, request v
 	}
 	value := request.ConfigValue.ValueString()
 
-	// Get the custom field type from the configuration
-	// This is a simplified approach - in a real implementation, you'd extract the type from the same object
 	cfType := v.customFieldType
 	switch cfType {
 	case "integer":
@@ -580,3 +581,60 @@ func (v IPPrefixValidator) ValidateString(ctx context.Context, request validator
 func ValidIPPrefix() validator.String {
 	return IPPrefixValidator{}
 }
+
+// CustomFieldsMapValidator validates that a custom_fields_map value is an object
+// or map keyed by valid custom field names.
+type CustomFieldsMapValidator struct{}
+
+// Description returns a description of the validator.
+func (v CustomFieldsMapValidator) Description(_ context.Context) string {
+	return "value must be an object or map keyed by custom field name"
+}
+
+// MarkdownDescription returns a markdown description of the validator.
+func (v CustomFieldsMapValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateDynamic performs the validation.
+func (v CustomFieldsMapValidator) ValidateDynamic(ctx context.Context, request validator.DynamicRequest, response *validator.DynamicResponse) {
+	if request.ConfigValue.IsNull() || request.ConfigValue.IsUnknown() || request.ConfigValue.IsUnderlyingValueUnknown() {
+		return
+	}
+
+	var names []string
+	switch value := request.ConfigValue.UnderlyingValue().(type) {
+	case types.Object:
+		for name := range value.Attributes() {
+			names = append(names, name)
+		}
+	case types.Map:
+		for name := range value.Elements() {
+			names = append(names, name)
+		}
+	default:
+		response.Diagnostics.AddAttributeError(
+			request.Path,
+			"Invalid Custom Fields Map",
+			"custom_fields_map must be an object or map keyed by custom field name, e.g. { rack_units = 42 }",
+		)
+		return
+	}
+
+	for _, name := range names {
+		if len(name) > 50 || !customFieldsMapKeyRegex.MatchString(name) {
+			response.Diagnostics.AddAttributeError(
+				request.Path.AtMapKey(name),
+				"Invalid Custom Field Name",
+				fmt.Sprintf("Custom field name '%s' must be at most 50 characters and contain only lowercase letters, numbers, and underscores", name),
+			)
+		}
+	}
+}
+
+var customFieldsMapKeyRegex = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// ValidCustomFieldsMap returns a validator for custom_fields_map values.
+func ValidCustomFieldsMap() validator.Dynamic {
+	return CustomFieldsMapValidator{}
+}
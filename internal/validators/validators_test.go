@@ -2,8 +2,10 @@ package validators
 
 import (
 	"context"
+	"math/big"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -705,3 +707,77 @@ func TestValidMACAddress(t *testing.T) {
 		})
 	}
 }
+
+func TestValidCustomFieldsMap(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	v := ValidCustomFieldsMap()
+
+	objectValue := func(attrs map[string]attr.Value) types.Dynamic {
+		attrTypes := make(map[string]attr.Type, len(attrs))
+		for name, value := range attrs {
+			attrTypes[name] = value.Type(ctx)
+		}
+		return types.DynamicValue(types.ObjectValueMust(attrTypes, attrs))
+	}
+
+	tests := []struct {
+		name        string
+		value       types.Dynamic
+		expectError bool
+	}{
+		{
+			name: "valid object",
+			value: objectValue(map[string]attr.Value{
+				"rack_units": types.NumberValue(big.NewFloat(42)),
+				"monitored":  types.BoolValue(true),
+			}),
+			expectError: false,
+		},
+		{
+			name:        "valid map",
+			value:       types.DynamicValue(types.MapValueMust(types.StringType, map[string]attr.Value{"owner": types.StringValue("ops")})),
+			expectError: false,
+		},
+		{
+			name:        "null value",
+			value:       types.DynamicNull(),
+			expectError: false,
+		},
+		{
+			name:        "unknown value",
+			value:       types.DynamicUnknown(),
+			expectError: false,
+		},
+		{
+			name:        "not an object",
+			value:       types.DynamicValue(types.StringValue("owner=ops")),
+			expectError: true,
+		},
+		{
+			name:        "invalid key",
+			value:       objectValue(map[string]attr.Value{"Rack-Units": types.StringValue("42")}),
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			req := validator.DynamicRequest{
+				Path:        path.Root("custom_fields_map"),
+				ConfigValue: tt.value,
+			}
+			resp := &validator.DynamicResponse{}
+
+			v.ValidateDynamic(ctx, req, resp)
+
+			hasError := resp.Diagnostics.HasError()
+			if hasError != tt.expectError {
+				t.Errorf("Expected error: %v, got error: %v. Diagnostics: %v",
+					tt.expectError, hasError, resp.Diagnostics.Errors())
+			}
+		})
+	}
+}
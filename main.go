@@ -4,7 +4,9 @@ import (
 	"context"
 	"flag"
 	"log"
+	"os"
 
+	"github.com/bab3l/terraform-provider-netbox/internal/hclgen"
 	"github.com/bab3l/terraform-provider-netbox/internal/provider"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 )
@@ -28,6 +30,12 @@ var (
 )
 
 func main() {
+	// "terraform-provider-netbox generate ..." exports existing NetBox objects
+	// as configuration instead of serving the provider.
+	if len(os.Args) > 1 && os.Args[1] == hclgen.CommandName {
+		os.Exit(hclgen.Run(context.Background(), os.Args[2:], os.Stdout, os.Stderr))
+	}
+
 	var debug bool
 	flag.BoolVar(&debug, "debug", false, "set to true to run the provider with support for debuggers like delve")
 	flag.Parse()